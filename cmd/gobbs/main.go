@@ -2,48 +2,362 @@ package main
 
 import (
 	"GoBBS/config"
+	"GoBBS/domain/model"
 	"GoBBS/domain/service"
+	"GoBBS/interface/digest"
+	"GoBBS/interface/gateway"
 	"GoBBS/interface/handler"
+	"GoBBS/interface/logging"
+	"GoBBS/interface/mail"
+	"GoBBS/interface/markdown"
+	"GoBBS/interface/metrics"
+	"GoBBS/interface/middleware"
+	"GoBBS/interface/middleware/middlewarehelper"
+	"GoBBS/interface/oidc"
+	"GoBBS/interface/pubsub"
+	"GoBBS/interface/scheduler"
 	"GoBBS/interface/security"
+	"GoBBS/interface/storage"
+	"GoBBS/interface/tracing"
+	"GoBBS/interface/webhook"
 	"GoBBS/usecase"
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
+	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+	_ "time/tzdata"
 
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/go-sql-driver/mysql"
 )
 
+const (
+	// eventHistorySize スレッドごとに再送用に保持するイベント数
+	eventHistorySize = 100
+	// eventBufferSize 接続ごとの未送信イベント数の上限
+	eventBufferSize = 64
+	// eventIdleTopics 接続のないスレッドの再送用の履歴を保持するスレッド数の上限
+	eventIdleTopics = 1000
+)
+
+// usage コマンドの使い方
+const usage = `usage:
+  gobbs [flags]               サーバーを起動する
+  gobbs config print [flags]  読み込んだ設定を秘密情報を伏せて出力する
+
+flagsは gobbs -h で確認できる
+`
+
 func main() {
-	env, err := config.GetEnv()
+	args := os.Args[1:]
+	if len(args) > 0 && args[0] == "config" {
+		if len(args) < 2 || args[1] != "print" {
+			fmt.Fprint(os.Stderr, usage)
+			os.Exit(2)
+		}
+		cfg, err := config.Load(args[2:])
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := cfg.Print(os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	holder, err := config.NewHolder(args)
 	if err != nil {
 		log.Fatal(err)
 	}
+	cfg := holder.Get()
+	logLevel := new(slog.LevelVar)
+	logLevel.Set(cfg.Log.Level)
+	logger := logging.NewLogger(os.Stderr, logLevel)
+	slog.SetDefault(logger)
+	holder.OnReload(func(c *config.Config) {
+		logLevel.Set(c.Log.Level)
+	})
+	go reloadOnSignal(holder)
+
+	shutdownTracing, err := tracing.Setup(
+		context.Background(),
+		cfg.Tracing.Exporter == config.TraceExporterOTLP,
+		cfg.Tracing.OTLPEndpoint,
+		"gobbs",
+	)
+	if err != nil {
+		fatal("tracing error", err)
+	}
+	defer shutdownTracing(context.Background())
+
+	// パニック回復はアクセスログ・メトリクスに500が記録されるよう後に適用する
+	// それより前のミドルウェアで発生したパニックも同じ処理でmiddlewarehelper.Applyが回復する
+	recovery := middleware.NewRecovery(logger)
+	middlewarehelper.OnPanic(recovery.HandlePanic)
+	middlewarehelper.Use(
+		middleware.NewRequestID().Assign,
+		middleware.NewTracing().Trace,
+		middleware.NewAccessLog(logger).Record,
+		middleware.NewMetrics().Observe,
+		recovery.Recover,
+		middleware.NewCORS(func() config.CORSConfig { return holder.Get().CORS }).Handle,
+	)
+
 	dsn := fmt.Sprintf(
 		"%s:%s@tcp(%s)/%s?parseTime=true",
-		env.DBUser(),
-		env.DBPassword(),
-		env.DBHost(),
-		env.DBName(),
+		cfg.DB.User,
+		cfg.DB.Password,
+		cfg.DB.Host,
+		cfg.DB.Name,
 	)
-	db, err := sql.Open("mysql", dsn)
+	dbConfig, err := mysql.ParseDSN(dsn)
 	if err != nil {
-		log.Fatal(err)
+		fatal("parse dsn error", err)
 	}
+	connector, err := mysql.NewConnector(dbConfig)
+	if err != nil {
+		fatal("open db error", err)
+	}
+	db := sql.OpenDB(tracing.WrapConnector(connector, "mysql"))
 	defer db.Close()
+	if err := metrics.RegisterDB(db, cfg.DB.Name); err != nil {
+		fatal("register db metrics error", err)
+	}
+
+	blobStore, err := newBlobStore(cfg.Blob)
+	if err != nil {
+		fatal("blob store error", err)
+	}
+
+	jwtKeys, err := loadJWTKeys(cfg.JWT)
+	if err != nil {
+		fatal("jwt keys error", err)
+	}
+	// HS256の共通鍵はkidなしで署名する
+	activeKeyID := cfg.JWT.ActiveKeyID
+	if cfg.JWT.Algorithm == config.JWTAlgorithmHS256 {
+		activeKeyID = ""
+	}
+	jwtToken, err := security.NewJWTToken(
+		security.JWTOptions{
+			Issuer:   cfg.JWT.Issuer,
+			Audience: cfg.JWT.Audience,
+			TTL:      cfg.JWT.TTL,
+			Leeway:   cfg.JWT.Leeway,
+		},
+		activeKeyID,
+		jwtKeys...,
+	)
+	if err != nil {
+		fatal("jwt token error", err)
+	}
+	handler.NewJWKSHandler(jwtToken).RegistHandlerFunc()
 
-	usecase := usecase.NewUserUseCase(
+	userUseCase := usecase.NewUserUseCase(
 		db,
 		service.NewUserServiceFactory(),
-		security.NewJWTToken(env.JWTSecretKey()),
-	)
-	handler.NewUserHandler(
-		usecase,
-		env.CORSAllowOrigin(),
-		env.CORSAllowMethods(),
-		env.CORSAllowHeaders(),
-		env.CORSMaxAge(),
+		service.NewEventServiceFactory(),
+		service.NewSessionServiceFactory(),
+		service.NewMFAServiceFactory(),
+		service.NewUserIdentityServiceFactory(),
+		service.NewAPITokenServiceFactory(),
+		jwtToken,
+	)
+
+	sessionUseCase := usecase.NewSessionUseCase(db, service.NewSessionServiceFactory())
+	handler.NewUserHandler(userUseCase, sessionUseCase).RegistHandlerFunc()
+	handler.NewSessionHandler(sessionUseCase, userUseCase).RegistHandlerFunc()
+
+	mfaUseCase := usecase.NewMFAUseCase(db, service.NewUserServiceFactory(), service.NewMFAServiceFactory())
+	handler.NewMFAHandler(mfaUseCase, userUseCase).RegistHandlerFunc()
+
+	apiTokenUseCase := usecase.NewAPITokenUseCase(db, service.NewAPITokenServiceFactory())
+	handler.NewAPITokenHandler(apiTokenUseCase, userUseCase).RegistHandlerFunc()
+
+	if cfg.OIDC.Issuer != "" {
+		oidcProvider := oidc.NewProvider(
+			cfg.OIDC.Issuer,
+			cfg.OIDC.ClientID,
+			cfg.OIDC.ClientSecret,
+			cfg.OIDC.RedirectURL,
+			cfg.OIDC.Scopes,
+		)
+		handler.NewOIDCHandler(oidcProvider, userUseCase, cfg.OIDC.PostLoginURL).RegistHandlerFunc()
+	}
+
+	uploadUseCase := usecase.NewUploadUseCase(
+		db,
+		service.NewUserServiceFactory(),
+		service.NewAttachmentServiceFactory(),
+		blobStore,
+	)
+	handler.NewUploadHandler(uploadUseCase, userUseCase).RegistHandlerFunc()
+
+	notificationUseCase := usecase.NewNotificationUseCase(db, service.NewNotificationServiceFactory())
+	handler.NewNotificationHandler(notificationUseCase, userUseCase).RegistHandlerFunc()
+
+	mailer, err := newMailer(cfg.Mail, logger)
+	if err != nil {
+		fatal("mailer error", err)
+	}
+	digestRenderer, err := digest.NewRenderer()
+	if err != nil {
+		fatal("digest renderer error", err)
+	}
+	digestLocation, err := time.LoadLocation(cfg.Digest.Timezone)
+	if err != nil {
+		fatal("digest timezone error", err)
+	}
+	digestUseCase := usecase.NewDigestUseCase(
+		db,
+		service.NewDigestServiceFactory(),
+		service.NewUserServiceFactory(),
+		digest.NewPostActivitySource(db, cfg.Server.BaseURL),
+		digestRenderer,
+		mailer,
+		cfg.Server.BaseURL,
+	)
+	handler.NewDigestHandler(digestUseCase, userUseCase).RegistHandlerFunc()
+	go scheduler.NewScheduler(cfg.Jobs.DigestInterval, digestLocation, func(now time.Time) {
+		if sent, err := digestUseCase.SendDigests(context.Background(), now); err != nil {
+			slog.Error("send digests error", "error", err)
+		} else if sent > 0 {
+			slog.Info("sent digests", "count", sent)
+		}
+	}).Run(context.Background())
+
+	webhookUseCase := usecase.NewWebhookUseCase(db, service.NewWebhookServiceFactory(), webhook.NewHTTPSender())
+	handler.NewWebhookHandler(webhookUseCase, userUseCase, cfg.Admin.UserIDs).RegistHandlerFunc()
+	go scheduler.NewScheduler(cfg.Jobs.WebhookInterval, time.UTC, func(now time.Time) {
+		if _, err := webhookUseCase.DeliverDue(context.Background(), now); err != nil {
+			slog.Error("deliver webhooks error", "error", err)
+		}
+	}).Run(context.Background())
+
+	eventUseCase := usecase.NewEventUseCase(db, service.NewEventServiceFactory())
+	for _, eventType := range []model.EventType{model.EventThreadCreated, model.EventPostCreated, model.EventPostHidden} {
+		eventUseCase.Subscribe(eventType, webhookUseCase.ForwardEvent)
+	}
+	go scheduler.NewScheduler(cfg.Jobs.EventDispatchInterval, time.UTC, func(now time.Time) {
+		if _, err := eventUseCase.DispatchDue(context.Background(), now); err != nil {
+			slog.Error("dispatch events error", "error", err)
+		}
+	}).Run(context.Background())
+
+	hub := pubsub.NewMemoryHub(eventHistorySize, eventBufferSize, eventIdleTopics)
+	boardUseCase := usecase.NewBoardUseCase(db, service.NewBoardServiceFactory())
+	postUseCase := usecase.NewPostUseCase(
+		db,
+		service.NewBoardServiceFactory(),
+		service.NewPostServiceFactory(),
+		service.NewUserServiceFactory(),
+		service.NewNotificationServiceFactory(),
+		service.NewEventServiceFactory(),
+		markdown.NewRenderer(func(number int) string { return "#post-" + strconv.Itoa(number) }),
+		func() time.Duration { return holder.Get().Post.EditWindow },
+		hub,
+	)
+	handler.NewBoardHandler(boardUseCase, postUseCase, userUseCase, cfg.Admin.UserIDs).RegistHandlerFunc()
+	handler.NewPostHandler(postUseCase, userUseCase, cfg.Admin.UserIDs).RegistHandlerFunc()
+	handler.NewThreadHandler(postUseCase, userUseCase, handler.NewThreadEventHandler(hub, userUseCase).Handler()).RegistHandlerFunc()
+	handler.NewGatewayHandler(
+		gateway.NewGateway(hub, func() gateway.Limits {
+			limits := holder.Get().Gateway
+			return gateway.Limits{
+				MaxSubscriptions:  limits.MaxSubscriptions,
+				MessagesPerSecond: limits.MessagesPerSecond,
+				MessageBurst:      limits.MessageBurst,
+				MaxMessageBytes:   limits.MaxMessageBytes,
+				SendBuffer:        limits.SendBuffer,
+			}
+		}),
+		userUseCase,
+		func() []string { return holder.Get().CORS.AllowOrigins },
 	).RegistHandlerFunc()
 
-	log.Fatal(http.ListenAndServe(":8100", nil))
+	http.Handle("/metrics", metrics.Handler())
+
+	fatal("listen error", http.ListenAndServe(cfg.Server.Addr, nil))
+}
+
+// fatal エラーを記録して終了する
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+// reloadOnSignal SIGHUPを受け取るたびに設定を再読み込みする
+// 再起動が必要な項目の変更は反映せず、警告を記録する
+func reloadOnSignal(holder *config.Holder) {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGHUP)
+	for range sig {
+		ignored, err := holder.Reload()
+		if err != nil {
+			slog.Error("reload config error", "error", err)
+			continue
+		}
+		if len(ignored) > 0 {
+			slog.Warn("config changes require restart", "keys", ignored)
+		}
+		slog.Info("reloaded config")
+	}
+}
+
+// loadJWTKeys 設定に応じたJWTの署名・検証の鍵を読み込む
+// HS256の場合はkidなしの共通鍵で署名する。RS256/EdDSAの場合も共通鍵が設定されていれば、切り替え前のトークンの検証に使う
+func loadJWTKeys(cfg config.JWTConfig) ([]*security.Key, error) {
+	keys := []*security.Key{}
+	if cfg.SecretKey != "" {
+		keys = append(keys, security.NewHMACKey("", []byte(cfg.SecretKey)))
+	}
+	if cfg.Algorithm == config.JWTAlgorithmHS256 {
+		return keys, nil
+	}
+
+	dirKeys, err := security.LoadKeyDir(cfg.KeyDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range dirKeys {
+		if key.ID == cfg.ActiveKeyID && key.Algorithm() != cfg.Algorithm {
+			return nil, fmt.Errorf("active key %s is %s, not %s", key.ID, key.Algorithm(), cfg.Algorithm)
+		}
+	}
+	return append(keys, dirKeys...), nil
+}
+
+// newBlobStore 設定に応じたオブジェクトストレージを生成する
+func newBlobStore(cfg config.BlobConfig) (storage.BlobStore, error) {
+	if cfg.Store == config.BlobStoreS3 {
+		return storage.NewS3BlobStore(
+			cfg.S3.Endpoint,
+			cfg.S3.Region,
+			cfg.S3.Bucket,
+			cfg.S3.AccessKeyID,
+			cfg.S3.SecretAccessKey,
+			http.DefaultClient,
+		)
+	}
+	return storage.NewLocalBlobStore(cfg.LocalDir)
+}
+
+// newMailer 設定に応じたメール送信を生成する
+func newMailer(cfg config.MailConfig, logger *slog.Logger) (mail.Mailer, error) {
+	if cfg.Driver == config.MailDriverSMTP {
+		return mail.NewSMTPMailer(
+			cfg.SMTP.Host,
+			cfg.SMTP.Port,
+			cfg.SMTP.Username,
+			cfg.SMTP.Password,
+			cfg.From,
+		)
+	}
+	return mail.NewLogMailer(slog.NewLogLogger(logger.Handler(), slog.LevelInfo)), nil
 }
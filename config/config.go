@@ -0,0 +1,642 @@
+package config
+
+import (
+	"encoding"
+	"flag"
+	"io"
+	"log/slog"
+	"net/url"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	// Config 設定
+	// デフォルト値、設定ファイル(YAML)、環境変数、コマンドライン引数の順に上書きして読み込む
+	// 各項目のタグ: yamlは設定ファイルのキー、envは環境変数名、flagは引数名(秘密情報は引数で渡せないよう省略する)、
+	// secretはconfig printで伏せる項目、reloadは再起動せずにSIGHUPで再読み込みできる項目
+	// 環境変数は名前の末尾に_FILEを付けると、値の代わりにファイルのパスを指定できる(Docker/Kubernetesのシークレット用)
+	Config struct {
+		Server  ServerConfig  `yaml:"server"`
+		DB      DBConfig      `yaml:"db"`
+		JWT     JWTConfig     `yaml:"jwt"`
+		CORS    CORSConfig    `yaml:"cors"`
+		Gateway GatewayConfig `yaml:"gateway"`
+		Post    PostConfig    `yaml:"post"`
+		OIDC    OIDCConfig    `yaml:"oidc"`
+		Blob    BlobConfig    `yaml:"blob"`
+		Mail    MailConfig    `yaml:"mail"`
+		Digest  DigestConfig  `yaml:"digest"`
+		Jobs    JobsConfig    `yaml:"jobs"`
+		Admin   AdminConfig   `yaml:"admin"`
+		Log     LogConfig     `yaml:"log"`
+		Tracing TracingConfig `yaml:"tracing"`
+	}
+
+	// ServerConfig HTTPサーバーの設定
+	ServerConfig struct {
+		// Addr 待ち受けるアドレス
+		Addr string `yaml:"addr" env:"SERVER_ADDR" flag:"addr"`
+		// BaseURL メール本文などに記載する公開URL(末尾の/なし)
+		BaseURL string `yaml:"base_url" env:"BASE_URL" flag:"base-url"`
+	}
+
+	// DBConfig DBの設定
+	DBConfig struct {
+		Host     string `yaml:"host" env:"DB_HOST" flag:"db-host"`
+		Name     string `yaml:"name" env:"DB_NAME" flag:"db-name"`
+		User     string `yaml:"user" env:"DB_USER" flag:"db-user"`
+		Password string `yaml:"password" env:"DB_PASSWORD" secret:"true"`
+	}
+
+	// JWTConfig JWTの設定
+	JWTConfig struct {
+		// Algorithm 署名アルゴリズム(HS256, RS256, EdDSA)
+		Algorithm string `yaml:"algorithm" env:"JWT_ALGORITHM" flag:"jwt-algorithm"`
+		// SecretKey HS256の鍵
+		// RS256/EdDSAの場合も設定されていれば、切り替え前に発行したkidのないトークンの検証に使う
+		SecretKey string `yaml:"secret_key" env:"JWT_SECRET_KEY" secret:"true"`
+		// KeyDir RS256/EdDSAの鍵(PEM)を置くディレクトリ
+		// ファイル名から.pemを除いたものをkidとし、公開鍵だけのファイルは検証にだけ使う
+		KeyDir string `yaml:"key_dir" env:"JWT_KEY_DIR" flag:"jwt-key-dir"`
+		// ActiveKeyID 署名に使う鍵のkid(RS256/EdDSA)
+		ActiveKeyID string `yaml:"active_key_id" env:"JWT_ACTIVE_KEY_ID" flag:"jwt-active-key-id"`
+		// Issuer トークンのiss(未設定の場合はserver.base_url)
+		Issuer string `yaml:"issuer" env:"JWT_ISSUER" flag:"jwt-issuer"`
+		// Audience トークンのaud
+		Audience string `yaml:"audience" env:"JWT_AUDIENCE" flag:"jwt-audience"`
+		// TTL トークンの有効期間
+		TTL time.Duration `yaml:"ttl" env:"JWT_TTL" flag:"jwt-ttl"`
+		// Leeway 有効期限などの検証で許容する時計のずれ
+		Leeway time.Duration `yaml:"leeway" env:"JWT_LEEWAY" flag:"jwt-leeway"`
+	}
+
+	// CORSConfig CORSの設定
+	CORSConfig struct {
+		// AllowOrigins 許可するオリジン("*"は全て、"https://*.example.com"はサブドメインを許可する)
+		AllowOrigins []string `yaml:"allow_origins" env:"CORS_ALLOW_ORIGINS" flag:"cors-allow-origins" reload:"true"`
+		// AllowMethods, AllowHeaders プリフライトで許可するメソッドとヘッダー("*"はリクエストされたものを全て許可する)
+		AllowMethods []string `yaml:"allow_methods" env:"CORS_ALLOW_METHODS" flag:"cors-allow-methods" reload:"true"`
+		AllowHeaders []string `yaml:"allow_headers" env:"CORS_ALLOW_HEADERS" flag:"cors-allow-headers" reload:"true"`
+		// AllowCredentials クッキーを付けたリクエストを許可する(クッキーのセッションで使う)
+		AllowCredentials bool `yaml:"allow_credentials" env:"CORS_ALLOW_CREDENTIALS" flag:"cors-allow-credentials" reload:"true"`
+		// MaxAge プリフライトのキャッシュ時間(秒)
+		MaxAge int `yaml:"max_age" env:"CORS_MAX_AGE" flag:"cors-max-age" reload:"true"`
+	}
+
+	// GatewayConfig WebSocketゲートウェイの接続ごとの制限(再読み込み後は新しい接続に適用する)
+	GatewayConfig struct {
+		// MaxSubscriptions 同時に購読できるトピック数
+		MaxSubscriptions int `yaml:"max_subscriptions" env:"GATEWAY_MAX_SUBSCRIPTIONS" flag:"gateway-max-subscriptions" reload:"true"`
+		// MessagesPerSecond クライアントから受け付けるメッセージの平均レート
+		MessagesPerSecond float64 `yaml:"messages_per_second" env:"GATEWAY_MESSAGES_PER_SECOND" flag:"gateway-messages-per-second" reload:"true"`
+		// MessageBurst 一時的に受け付けるメッセージ数
+		MessageBurst int `yaml:"message_burst" env:"GATEWAY_MESSAGE_BURST" flag:"gateway-message-burst" reload:"true"`
+		// MaxMessageBytes クライアントから受け付けるメッセージの最大バイト数
+		MaxMessageBytes int64 `yaml:"max_message_bytes" env:"GATEWAY_MAX_MESSAGE_BYTES" flag:"gateway-max-message-bytes" reload:"true"`
+		// SendBuffer 未送信メッセージ数の上限、超過した接続は切断する
+		SendBuffer int `yaml:"send_buffer" env:"GATEWAY_SEND_BUFFER" flag:"gateway-send-buffer" reload:"true"`
+	}
+
+	// PostConfig 投稿の設定
+	PostConfig struct {
+		// EditWindow 投稿者が投稿を編集できる期間(0の場合は投稿者は編集できない、管理者はいつでも編集できる)
+		EditWindow time.Duration `yaml:"edit_window" env:"POST_EDIT_WINDOW" flag:"post-edit-window" reload:"true"`
+	}
+
+	// OIDCConfig OpenID Connectで外部のIdPからログインする設定(issuer未設定の場合は無効)
+	OIDCConfig struct {
+		// Issuer IdPのissuer(/.well-known/openid-configurationを取得するURL)
+		Issuer       string `yaml:"issuer" env:"OIDC_ISSUER" flag:"oidc-issuer"`
+		ClientID     string `yaml:"client_id" env:"OIDC_CLIENT_ID" flag:"oidc-client-id"`
+		ClientSecret string `yaml:"client_secret" env:"OIDC_CLIENT_SECRET" secret:"true"`
+		// RedirectURL IdPに登録したコールバックURL(未設定の場合はserver.base_url/oidc/callback)
+		RedirectURL string `yaml:"redirect_url" env:"OIDC_REDIRECT_URL" flag:"oidc-redirect-url"`
+		// Scopes 要求するスコープ(openidは常に含める)
+		Scopes []string `yaml:"scopes" env:"OIDC_SCOPES" flag:"oidc-scopes"`
+		// PostLoginURL ログイン後にリダイレクトするフロントエンドのURL(未設定の場合はserver.base_url/)
+		PostLoginURL string `yaml:"post_login_url" env:"OIDC_POST_LOGIN_URL" flag:"oidc-post-login-url"`
+	}
+
+	// BlobConfig オブジェクトストレージの設定
+	BlobConfig struct {
+		// Store 種類(local, s3)
+		Store    string   `yaml:"store" env:"BLOB_STORE" flag:"blob-store"`
+		LocalDir string   `yaml:"local_dir" env:"BLOB_LOCAL_DIR" flag:"blob-local-dir"`
+		S3       S3Config `yaml:"s3"`
+	}
+
+	// S3Config S3互換ストレージの設定
+	S3Config struct {
+		Endpoint        string `yaml:"endpoint" env:"S3_ENDPOINT" flag:"s3-endpoint"`
+		Region          string `yaml:"region" env:"S3_REGION" flag:"s3-region"`
+		Bucket          string `yaml:"bucket" env:"S3_BUCKET" flag:"s3-bucket"`
+		AccessKeyID     string `yaml:"access_key_id" env:"S3_ACCESS_KEY_ID" flag:"s3-access-key-id"`
+		SecretAccessKey string `yaml:"secret_access_key" env:"S3_SECRET_ACCESS_KEY" secret:"true"`
+	}
+
+	// MailConfig メール送信の設定
+	MailConfig struct {
+		// Driver 送信方式(log, smtp)
+		Driver string     `yaml:"driver" env:"MAIL_DRIVER" flag:"mail-driver"`
+		From   string     `yaml:"from" env:"MAIL_FROM" flag:"mail-from"`
+		SMTP   SMTPConfig `yaml:"smtp"`
+	}
+
+	// SMTPConfig SMTPサーバーの設定
+	SMTPConfig struct {
+		Host     string `yaml:"host" env:"SMTP_HOST" flag:"smtp-host"`
+		Port     string `yaml:"port" env:"SMTP_PORT" flag:"smtp-port"`
+		Username string `yaml:"username" env:"SMTP_USERNAME" flag:"smtp-username"`
+		Password string `yaml:"password" env:"SMTP_PASSWORD" secret:"true"`
+	}
+
+	// DigestConfig ダイジェストメールの設定
+	DigestConfig struct {
+		// Timezone 集計期間を区切るタイムゾーン
+		Timezone string `yaml:"timezone" env:"DIGEST_TIMEZONE" flag:"digest-timezone"`
+	}
+
+	// JobsConfig 定期実行する処理の設定
+	JobsConfig struct {
+		DigestInterval        time.Duration `yaml:"digest_interval" env:"DIGEST_INTERVAL" flag:"digest-interval"`
+		WebhookInterval       time.Duration `yaml:"webhook_interval" env:"WEBHOOK_INTERVAL" flag:"webhook-interval"`
+		EventDispatchInterval time.Duration `yaml:"event_dispatch_interval" env:"EVENT_DISPATCH_INTERVAL" flag:"event-dispatch-interval"`
+	}
+
+	// AdminConfig 管理者の設定
+	AdminConfig struct {
+		UserIDs []string `yaml:"user_ids" env:"ADMIN_USER_IDS" flag:"admin-user-ids"`
+	}
+
+	// LogConfig ログの設定
+	LogConfig struct {
+		// Level 出力する最低レベル(debug, info, warn, error)
+		Level slog.Level `yaml:"level" env:"LOG_LEVEL" flag:"log-level" reload:"true"`
+	}
+
+	// TracingConfig トレースの設定
+	TracingConfig struct {
+		// Exporter 送信方式(none, otlp)
+		Exporter string `yaml:"exporter" env:"TRACE_EXPORTER" flag:"trace-exporter"`
+		// OTLPEndpoint トレースを送信するOTLPコレクターのURL
+		OTLPEndpoint string `yaml:"otlp_endpoint" env:"OTLP_ENDPOINT" flag:"otlp-endpoint"`
+	}
+
+	// field 設定の末端の項目
+	field struct {
+		// key 設定ファイルのキーを.でつないだもの(db.hostなど)
+		key    string
+		env    string
+		flag   string
+		secret bool
+		reload bool
+		value  reflect.Value
+	}
+)
+
+const (
+	// BlobStoreLocal ローカルディスクのオブジェクトストレージ
+	BlobStoreLocal = "local"
+	// BlobStoreS3 S3互換のオブジェクトストレージ
+	BlobStoreS3 = "s3"
+)
+
+const (
+	// MailDriverLog メールを送信せずログに出力する
+	MailDriverLog = "log"
+	// MailDriverSMTP SMTPサーバー経由でメールを送信する
+	MailDriverSMTP = "smtp"
+)
+
+const (
+	// TraceExporterNone トレースを送信しない
+	TraceExporterNone = "none"
+	// TraceExporterOTLP OTLP(HTTP)でトレースを送信する
+	TraceExporterOTLP = "otlp"
+)
+
+const (
+	// JWTAlgorithmHS256 共通鍵(HMAC SHA-256)で署名する
+	JWTAlgorithmHS256 = "HS256"
+	// JWTAlgorithmRS256 RSA鍵で署名する
+	JWTAlgorithmRS256 = "RS256"
+	// JWTAlgorithmEdDSA Ed25519鍵で署名する
+	JWTAlgorithmEdDSA = "EdDSA"
+)
+
+const (
+	// EnvConfigFile 設定ファイルのパスを指定する環境変数
+	EnvConfigFile = "GOBBS_CONFIG"
+	// envFileSuffix 値を読み込むファイルのパスを指定する環境変数の接尾辞
+	envFileSuffix = "_FILE"
+	// envDeprecatedCORSAllowOrigin CORS_ALLOW_ORIGINSの旧名(1件のオリジンだけを指定できた)
+	envDeprecatedCORSAllowOrigin = "CORS_ALLOW_ORIGIN"
+	// MinJWTSecretKeyLength JWTシークレットキーの最低長(HS256の鍵長256bit)
+	MinJWTSecretKeyLength = 32
+	// redacted config printで秘密情報の代わりに出力する値
+	redacted = "[REDACTED]"
+)
+
+// Default デフォルト値の設定を返す
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Addr:    ":8100",
+			BaseURL: "http://localhost:8100",
+		},
+		JWT: JWTConfig{
+			Algorithm: JWTAlgorithmHS256,
+			Audience:  "gobbs",
+			TTL:       time.Hour,
+			Leeway:    30 * time.Second,
+		},
+		CORS: CORSConfig{
+			AllowOrigins: []string{},
+			AllowMethods: []string{},
+			AllowHeaders: []string{},
+		},
+		Gateway: GatewayConfig{
+			MaxSubscriptions:  50,
+			MessagesPerSecond: 5,
+			MessageBurst:      20,
+			MaxMessageBytes:   4096,
+			SendBuffer:        256,
+		},
+		Post: PostConfig{EditWindow: 30 * time.Minute},
+		OIDC: OIDCConfig{
+			Scopes: []string{"openid", "email", "profile"},
+		},
+		Blob: BlobConfig{
+			Store:    BlobStoreLocal,
+			LocalDir: "./blobs",
+		},
+		Mail: MailConfig{
+			Driver: MailDriverLog,
+			From:   "GoBBS <noreply@localhost>",
+			SMTP:   SMTPConfig{Port: "587"},
+		},
+		Digest: DigestConfig{Timezone: "Asia/Tokyo"},
+		Jobs: JobsConfig{
+			DigestInterval:        10 * time.Minute,
+			WebhookInterval:       5 * time.Second,
+			EventDispatchInterval: 2 * time.Second,
+		},
+		Admin: AdminConfig{UserIDs: []string{}},
+		Log:   LogConfig{Level: slog.LevelInfo},
+		Tracing: TracingConfig{
+			Exporter:     TraceExporterNone,
+			OTLPEndpoint: "http://localhost:4318",
+		},
+	}
+}
+
+// Load 設定を読み込み、検証する
+// argsはコマンドライン引数(--config 設定ファイルのパス、--db-host など各項目の上書き)
+func Load(args []string) (*Config, error) {
+	c := Default()
+
+	fs := flag.NewFlagSet("gobbs", flag.ContinueOnError)
+	configFile := fs.String("config", os.Getenv(EnvConfigFile), "設定ファイル(YAML)のパス (環境変数 "+EnvConfigFile+")")
+	flagFields := map[string]field{}
+	for _, f := range c.fields() {
+		if f.flag == "" {
+			continue
+		}
+		fs.String(f.flag, "", f.key+"を上書きする (環境変数 "+f.env+")")
+		flagFields[f.flag] = f
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, errors.Wrap(err, "Load flag error")
+	}
+	if fs.NArg() > 0 {
+		return nil, errors.Errorf("Load unexpected argument: %s", fs.Arg(0))
+	}
+
+	if *configFile != "" {
+		if err := c.loadFile(*configFile); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.loadDeprecatedEnv(); err != nil {
+		return nil, err
+	}
+	for _, f := range c.fields() {
+		value, err := lookupEnv(f.env)
+		if err != nil {
+			return nil, err
+		}
+		if value != "" {
+			if err := f.set(value); err != nil {
+				return nil, errors.Wrapf(err, "Load %s error", f.env)
+			}
+		}
+	}
+
+	var flagErr error
+	fs.Visit(func(fl *flag.Flag) {
+		f, ok := flagFields[fl.Name]
+		if !ok || flagErr != nil {
+			return
+		}
+		if err := f.set(fl.Value.String()); err != nil {
+			flagErr = errors.Wrapf(err, "Load --%s error", fl.Name)
+		}
+	})
+	if flagErr != nil {
+		return nil, flagErr
+	}
+	c.Server.BaseURL = strings.TrimSuffix(c.Server.BaseURL, "/")
+	if c.JWT.Issuer == "" {
+		c.JWT.Issuer = c.Server.BaseURL
+	}
+	if c.OIDC.RedirectURL == "" {
+		c.OIDC.RedirectURL = c.Server.BaseURL + "/oidc/callback"
+	}
+	if c.OIDC.PostLoginURL == "" {
+		c.OIDC.PostLoginURL = c.Server.BaseURL + "/"
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// loadFile 設定ファイルの値で上書きする(未知のキーはエラーにする)
+func (c *Config) loadFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return errors.Wrap(err, "Load config file error")
+	}
+	defer file.Close()
+
+	dec := yaml.NewDecoder(file)
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil && err != io.EOF {
+		return errors.Wrapf(err, "Load config file %s error", path)
+	}
+	return nil
+}
+
+// loadDeprecatedEnv 名前を変更した環境変数を新しい項目として読み込む
+// 旧名だけを設定した環境で設定が黙って無視されないよう、CORS_ALLOW_ORIGINは1件のCORS_ALLOW_ORIGINSとして扱う
+// 新旧両方が設定されている場合は、どちらを使うべきか判断できないためエラーにする
+func (c *Config) loadDeprecatedEnv() error {
+	value, err := lookupEnv(envDeprecatedCORSAllowOrigin)
+	if err != nil || value == "" {
+		return err
+	}
+	current, err := lookupEnv("CORS_ALLOW_ORIGINS")
+	if err != nil {
+		return err
+	}
+	if current != "" {
+		return errors.Errorf("Load %s and %s are both set", envDeprecatedCORSAllowOrigin, "CORS_ALLOW_ORIGINS")
+	}
+	slog.Warn("deprecated environment variable", "name", envDeprecatedCORSAllowOrigin, "use", "CORS_ALLOW_ORIGINS")
+	c.CORS.AllowOrigins = []string{strings.TrimSpace(value)}
+	return nil
+}
+
+// lookupEnv 環境変数の値を返す
+// NAME_FILEが設定されていればファイルの内容(末尾の改行を除く)を返す。NAMEと両方設定されている場合はエラー
+func lookupEnv(name string) (string, error) {
+	path := os.Getenv(name + envFileSuffix)
+	if path == "" {
+		return os.Getenv(name), nil
+	}
+	if os.Getenv(name) != "" {
+		return "", errors.Errorf("Load %s and %s are both set", name, name+envFileSuffix)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "Load %s error", name+envFileSuffix)
+	}
+	return strings.TrimRight(string(b), "\r\n"), nil
+}
+
+// Validate 必須項目、値の範囲、選択肢を検証し、全ての問題をまとめて返す
+func (c *Config) Validate() error {
+	problems := []string{}
+	require := func(key string, value string) {
+		if value == "" {
+			problems = append(problems, key+" is required")
+		}
+	}
+	oneOf := func(key string, value string, choices ...string) {
+		for _, choice := range choices {
+			if value == choice {
+				return
+			}
+		}
+		problems = append(problems, key+" must be one of "+strings.Join(choices, ", ")+": "+value)
+	}
+	positive := func(key string, value time.Duration) {
+		if value <= 0 {
+			problems = append(problems, key+" must be positive: "+value.String())
+		}
+	}
+	atLeastOne := func(key string, value int64) {
+		if value < 1 {
+			problems = append(problems, key+" must be at least 1: "+strconv.FormatInt(value, 10))
+		}
+	}
+
+	require("server.addr", c.Server.Addr)
+	if u, err := url.Parse(c.Server.BaseURL); err != nil || u.Scheme == "" || u.Host == "" {
+		problems = append(problems, "server.base_url must be an absolute URL: "+c.Server.BaseURL)
+	}
+	require("db.host", c.DB.Host)
+	require("db.name", c.DB.Name)
+	require("db.user", c.DB.User)
+	require("db.password", c.DB.Password)
+	oneOf("jwt.algorithm", c.JWT.Algorithm, JWTAlgorithmHS256, JWTAlgorithmRS256, JWTAlgorithmEdDSA)
+	if c.JWT.Algorithm != JWTAlgorithmHS256 {
+		require("jwt.key_dir", c.JWT.KeyDir)
+		require("jwt.active_key_id", c.JWT.ActiveKeyID)
+	}
+	if (c.JWT.Algorithm == JWTAlgorithmHS256 || c.JWT.SecretKey != "") && len(c.JWT.SecretKey) < MinJWTSecretKeyLength {
+		problems = append(problems, "jwt.secret_key must be at least "+strconv.Itoa(MinJWTSecretKeyLength)+" bytes")
+	}
+	require("jwt.issuer", c.JWT.Issuer)
+	require("jwt.audience", c.JWT.Audience)
+	positive("jwt.ttl", c.JWT.TTL)
+	if c.JWT.Leeway < 0 {
+		problems = append(problems, "jwt.leeway must not be negative: "+c.JWT.Leeway.String())
+	}
+	for _, origin := range c.CORS.AllowOrigins {
+		if !validOriginPattern(origin) {
+			problems = append(problems, "cors.allow_origins must be * or scheme://host[:port]: "+origin)
+		}
+		if origin == "*" && c.CORS.AllowCredentials {
+			problems = append(problems, "cors.allow_origins must not contain * when cors.allow_credentials is true")
+		}
+	}
+	if c.CORS.MaxAge < 0 {
+		problems = append(problems, "cors.max_age must not be negative: "+strconv.Itoa(c.CORS.MaxAge))
+	}
+	atLeastOne("gateway.max_subscriptions", int64(c.Gateway.MaxSubscriptions))
+	if c.Gateway.MessagesPerSecond < 0 {
+		problems = append(problems, "gateway.messages_per_second must not be negative: "+strconv.FormatFloat(c.Gateway.MessagesPerSecond, 'g', -1, 64))
+	}
+	atLeastOne("gateway.message_burst", int64(c.Gateway.MessageBurst))
+	atLeastOne("gateway.max_message_bytes", c.Gateway.MaxMessageBytes)
+	atLeastOne("gateway.send_buffer", int64(c.Gateway.SendBuffer))
+	if c.Post.EditWindow < 0 {
+		problems = append(problems, "post.edit_window must not be negative: "+c.Post.EditWindow.String())
+	}
+	if c.OIDC.Issuer != "" {
+		if u, err := url.Parse(c.OIDC.Issuer); err != nil || u.Scheme == "" || u.Host == "" {
+			problems = append(problems, "oidc.issuer must be an absolute URL: "+c.OIDC.Issuer)
+		}
+		require("oidc.client_id", c.OIDC.ClientID)
+		require("oidc.redirect_url", c.OIDC.RedirectURL)
+	}
+	oneOf("blob.store", c.Blob.Store, BlobStoreLocal, BlobStoreS3)
+	if c.Blob.Store == BlobStoreS3 {
+		require("blob.s3.bucket", c.Blob.S3.Bucket)
+	}
+	oneOf("mail.driver", c.Mail.Driver, MailDriverLog, MailDriverSMTP)
+	if c.Mail.Driver == MailDriverSMTP {
+		require("mail.smtp.host", c.Mail.SMTP.Host)
+	}
+	if _, err := time.LoadLocation(c.Digest.Timezone); err != nil {
+		problems = append(problems, "digest.timezone is unknown: "+c.Digest.Timezone)
+	}
+	positive("jobs.digest_interval", c.Jobs.DigestInterval)
+	positive("jobs.webhook_interval", c.Jobs.WebhookInterval)
+	positive("jobs.event_dispatch_interval", c.Jobs.EventDispatchInterval)
+	oneOf("tracing.exporter", c.Tracing.Exporter, TraceExporterNone, TraceExporterOTLP)
+
+	if len(problems) > 0 {
+		return errors.Errorf("invalid config: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// Redacted 秘密情報を伏せた設定のコピーを返す(未設定の項目は空のまま)
+func (c *Config) Redacted() *Config {
+	copied := *c
+	for _, f := range copied.fields() {
+		if f.secret && f.value.String() != "" {
+			f.value.SetString(redacted)
+		}
+	}
+	return &copied
+}
+
+// Print 秘密情報を伏せた設定をYAMLで出力する
+func (c *Config) Print(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(c.Redacted()); err != nil {
+		return errors.Wrap(err, "Print error")
+	}
+	return enc.Close()
+}
+
+// fields 設定の末端の項目を列挙する
+func (c *Config) fields() []field {
+	return collectFields(reflect.ValueOf(c).Elem(), "")
+}
+
+// collectFields 構造体のフィールドを再帰的にたどり、末端の項目を列挙する
+func collectFields(v reflect.Value, prefix string) []field {
+	fields := []field{}
+	for i := 0; i < v.NumField(); i++ {
+		sf := v.Type().Field(i)
+		key := prefix + strings.Split(sf.Tag.Get("yaml"), ",")[0]
+		if sf.Type.Kind() == reflect.Struct && sf.Tag.Get("env") == "" {
+			fields = append(fields, collectFields(v.Field(i), key+".")...)
+			continue
+		}
+		fields = append(fields, field{
+			key:    key,
+			env:    sf.Tag.Get("env"),
+			flag:   sf.Tag.Get("flag"),
+			secret: sf.Tag.Get("secret") == "true",
+			reload: sf.Tag.Get("reload") == "true",
+			value:  v.Field(i),
+		})
+	}
+	return fields
+}
+
+// set 環境変数・引数の文字列を項目の型に変換してセットする
+func (f field) set(value string) error {
+	switch p := f.value.Addr().Interface().(type) {
+	case *string:
+		*p = value
+	case *bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		*p = b
+	case *int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		*p = n
+	case *int64:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		*p = n
+	case *float64:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		*p = n
+	case *[]string:
+		*p = splitList(value)
+	case *time.Duration:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		*p = d
+	case encoding.TextUnmarshaler:
+		return p.UnmarshalText([]byte(value))
+	default:
+		return errors.Errorf("unsupported config type: %s", f.value.Type())
+	}
+	return nil
+}
+
+// validOriginPattern CORSで許可するオリジンの形式か判定する
+// パスやクエリは含めず、ホストの先頭にだけ"*."を付けられる
+func validOriginPattern(origin string) bool {
+	if origin == "*" {
+		return true
+	}
+	u, err := url.Parse(strings.Replace(origin, "://*.", "://wildcard.", 1))
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" && !strings.Contains(u.Host, "*") &&
+		u.User == nil && u.Path == "" && u.RawQuery == "" && u.Fragment == ""
+}
+
+// splitList カンマ区切りの値を分割する(前後の空白と空の要素は除く)
+func splitList(value string) []string {
+	list := []string{}
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
@@ -0,0 +1,481 @@
+package config
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSecretKey = "0123456789abcdef0123456789abcdef"
+
+// setTestEnv 設定の環境変数を全て未設定にしたうえで、必須項目を設定する
+func setTestEnv(t *testing.T) {
+	t.Helper()
+	t.Setenv(EnvConfigFile, "")
+	t.Setenv(envDeprecatedCORSAllowOrigin, "")
+	t.Setenv(envDeprecatedCORSAllowOrigin+envFileSuffix, "")
+	for _, f := range Default().fields() {
+		t.Setenv(f.env, "")
+		t.Setenv(f.env+envFileSuffix, "")
+	}
+	t.Setenv("DB_HOST", "localhost")
+	t.Setenv("DB_NAME", "bbs")
+	t.Setenv("DB_USER", "user")
+	t.Setenv("DB_PASSWORD", "password")
+	t.Setenv("JWT_SECRET_KEY", testSecretKey)
+}
+
+// writeTestFile 一時ディレクトリに設定ファイルを作成する
+func writeTestFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "gobbs.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("設定ファイルの作成に失敗(error: %s)", err)
+	}
+	return path
+}
+
+// testConfig 必須項目を設定したデフォルト値の設定を返す
+func testConfig() *Config {
+	c := Default()
+	c.DB = DBConfig{Host: "localhost", Name: "bbs", User: "user", Password: "password"}
+	c.JWT.SecretKey = testSecretKey
+	c.JWT.Issuer = c.Server.BaseURL
+	c.OIDC.RedirectURL = c.Server.BaseURL + "/oidc/callback"
+	c.OIDC.PostLoginURL = c.Server.BaseURL + "/"
+	return c
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		init    func(t *testing.T) []string
+		want    func() *Config
+		wantErr string
+	}{
+		{
+			name: "正常ケース(デフォルト値と環境変数)",
+			init: func(t *testing.T) []string {
+				t.Setenv("CORS_ALLOW_ORIGINS", "http://localhost, https://*.example.com")
+				t.Setenv("CORS_ALLOW_CREDENTIALS", "true")
+				t.Setenv("CORS_ALLOW_METHODS", "GET, POST")
+				t.Setenv("CORS_MAX_AGE", "7200")
+				t.Setenv("GATEWAY_MESSAGES_PER_SECOND", "2.5")
+				t.Setenv("GATEWAY_MAX_MESSAGE_BYTES", "8192")
+				t.Setenv("ADMIN_USER_IDS", " 1, ,3 ")
+				t.Setenv("BASE_URL", "https://bbs.example.com/")
+				t.Setenv("JWT_TTL", "15m")
+				return nil
+			},
+			want: func() *Config {
+				c := testConfig()
+				c.CORS.AllowOrigins = []string{"http://localhost", "https://*.example.com"}
+				c.CORS.AllowCredentials = true
+				c.CORS.AllowMethods = []string{"GET", "POST"}
+				c.CORS.MaxAge = 7200
+				c.Gateway.MessagesPerSecond = 2.5
+				c.Gateway.MaxMessageBytes = 8192
+				c.Admin.UserIDs = []string{"1", "3"}
+				c.Server.BaseURL = "https://bbs.example.com"
+				c.JWT.Issuer = "https://bbs.example.com"
+				c.OIDC.RedirectURL = "https://bbs.example.com/oidc/callback"
+				c.OIDC.PostLoginURL = "https://bbs.example.com/"
+				c.JWT.TTL = 15 * time.Minute
+				return c
+			},
+		},
+		{
+			name: "正常ケース(設定ファイル < 環境変数 < 引数)",
+			init: func(t *testing.T) []string {
+				path := writeTestFile(t, strings.Join([]string{
+					"db:",
+					"  host: file-host",
+					"  name: file-db",
+					"blob:",
+					"  store: s3",
+					"  s3:",
+					"    bucket: bbs",
+					"    secret_access_key: file-secret",
+					"jobs:",
+					"  digest_interval: 1m",
+					"log:",
+					"  level: debug",
+					"tracing:",
+					"  exporter: otlp",
+				}, "\n"))
+				t.Setenv("DB_HOST", "env-host")
+				t.Setenv("LOG_LEVEL", "warn")
+				t.Setenv("WEBHOOK_INTERVAL", "30s")
+				return []string{"--config", path, "--db-host", "flag-host", "--log-level", "error"}
+			},
+			want: func() *Config {
+				c := testConfig()
+				c.DB.Host = "flag-host"
+				c.Blob.Store = BlobStoreS3
+				c.Blob.S3.Bucket = "bbs"
+				c.Blob.S3.SecretAccessKey = "file-secret"
+				c.Jobs.DigestInterval = time.Minute
+				c.Jobs.WebhookInterval = 30 * time.Second
+				c.Log.Level = slog.LevelError
+				c.Tracing.Exporter = TraceExporterOTLP
+				return c
+			},
+		},
+		{
+			name: "正常ケース(設定ファイルを環境変数で指定)",
+			init: func(t *testing.T) []string {
+				t.Setenv(EnvConfigFile, writeTestFile(t, "mail:\n  from: bbs@example.com\n"))
+				return nil
+			},
+			want: func() *Config {
+				c := testConfig()
+				c.Mail.From = "bbs@example.com"
+				return c
+			},
+		},
+		{
+			name: "正常ケース(秘密情報をファイルから読み込む)",
+			init: func(t *testing.T) []string {
+				t.Setenv("DB_PASSWORD", "")
+				t.Setenv("DB_PASSWORD_FILE", writeTestFile(t, "file-password\n"))
+				return nil
+			},
+			want: func() *Config {
+				c := testConfig()
+				c.DB.Password = "file-password"
+				return c
+			},
+		},
+		{
+			name: "正常ケース(旧名のCORS_ALLOW_ORIGINを1件のオリジンとして読み込む)",
+			init: func(t *testing.T) []string {
+				t.Setenv(envDeprecatedCORSAllowOrigin, "http://localhost")
+				return nil
+			},
+			want: func() *Config {
+				c := testConfig()
+				c.CORS.AllowOrigins = []string{"http://localhost"}
+				return c
+			},
+		},
+		{
+			name: "異常ケース(CORS_ALLOW_ORIGINとCORS_ALLOW_ORIGINSの両方を設定)",
+			init: func(t *testing.T) []string {
+				t.Setenv(envDeprecatedCORSAllowOrigin, "http://localhost")
+				t.Setenv("CORS_ALLOW_ORIGINS", "https://bbs.example.com")
+				return nil
+			},
+			wantErr: "CORS_ALLOW_ORIGIN and CORS_ALLOW_ORIGINS are both set",
+		},
+		{
+			name: "異常ケース(旧名のCORS_ALLOW_ORIGINに複数のオリジン)",
+			init: func(t *testing.T) []string {
+				t.Setenv(envDeprecatedCORSAllowOrigin, "http://localhost, https://bbs.example.com")
+				return nil
+			},
+			wantErr: "cors.allow_origins must be * or scheme://host[:port]",
+		},
+		{
+			name: "異常ケース(環境変数と_FILEの両方を設定)",
+			init: func(t *testing.T) []string {
+				t.Setenv("DB_PASSWORD_FILE", writeTestFile(t, "file-password"))
+				return nil
+			},
+			wantErr: "DB_PASSWORD and DB_PASSWORD_FILE are both set",
+		},
+		{
+			name: "異常ケース(_FILEのファイルなし)",
+			init: func(t *testing.T) []string {
+				t.Setenv("DB_PASSWORD", "")
+				t.Setenv("DB_PASSWORD_FILE", filepath.Join(t.TempDir(), "none"))
+				return nil
+			},
+			wantErr: "DB_PASSWORD_FILE",
+		},
+		{
+			name: "異常ケース(未知の引数)",
+			init: func(t *testing.T) []string {
+				return []string{"--db-password", "secret"}
+			},
+			wantErr: "flag provided but not defined",
+		},
+		{
+			name: "異常ケース(余分な引数)",
+			init: func(t *testing.T) []string {
+				return []string{"serve"}
+			},
+			wantErr: "unexpected argument",
+		},
+		{
+			name: "異常ケース(設定ファイルなし)",
+			init: func(t *testing.T) []string {
+				return []string{"--config", filepath.Join(t.TempDir(), "none.yaml")}
+			},
+			wantErr: "config file",
+		},
+		{
+			name: "異常ケース(設定ファイルの未知のキー)",
+			init: func(t *testing.T) []string {
+				return []string{"--config", writeTestFile(t, "db:\n  hostname: localhost\n")}
+			},
+			wantErr: "field hostname not found",
+		},
+		{
+			name: "異常ケース(環境変数の真偽値不正)",
+			init: func(t *testing.T) []string {
+				t.Setenv("CORS_ALLOW_CREDENTIALS", "ng")
+				return nil
+			},
+			wantErr: "CORS_ALLOW_CREDENTIALS",
+		},
+		{
+			name: "異常ケース(環境変数の数値不正)",
+			init: func(t *testing.T) []string {
+				t.Setenv("CORS_MAX_AGE", "ng")
+				return nil
+			},
+			wantErr: "CORS_MAX_AGE",
+		},
+		{
+			name: "異常ケース(引数の時間不正)",
+			init: func(t *testing.T) []string {
+				return []string{"--digest-interval", "10"}
+			},
+			wantErr: "--digest-interval",
+		},
+		{
+			name: "異常ケース(検証エラー)",
+			init: func(t *testing.T) []string {
+				t.Setenv("JWT_SECRET_KEY", "secretkey")
+				return nil
+			},
+			wantErr: "jwt.secret_key must be at least 32 bytes",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setTestEnv(t)
+			args := tt.init(t)
+			got, err := Load(args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Load() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if want := tt.want(); !reflect.DeepEqual(got, want) {
+				t.Errorf("Load() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(c *Config)
+		wantErrs []string
+	}{
+		{
+			name:   "正常ケース",
+			modify: func(c *Config) {},
+		},
+		{
+			name: "異常ケース(必須項目なし)",
+			modify: func(c *Config) {
+				c.DB = DBConfig{}
+				c.JWT.SecretKey = ""
+				c.JWT.Issuer = ""
+				c.JWT.Audience = ""
+			},
+			wantErrs: []string{
+				"db.host is required",
+				"db.name is required",
+				"db.user is required",
+				"db.password is required",
+				"jwt.secret_key must be at least 32 bytes",
+				"jwt.issuer is required",
+				"jwt.audience is required",
+			},
+		},
+		{
+			name: "異常ケース(選択肢・範囲外)",
+			modify: func(c *Config) {
+				c.Server.BaseURL = "/bbs"
+				c.CORS.MaxAge = -1
+				c.Gateway.MaxSubscriptions = 0
+				c.Gateway.MessagesPerSecond = -1
+				c.Post.EditWindow = -time.Minute
+				c.Blob.Store = "ftp"
+				c.Mail.Driver = "sendmail"
+				c.Digest.Timezone = "Mars/Olympus"
+				c.Jobs.DigestInterval = 0
+				c.Jobs.WebhookInterval = -time.Second
+				c.Tracing.Exporter = "jaeger"
+				c.JWT.Algorithm = "none"
+				c.JWT.TTL = 0
+				c.JWT.Leeway = -time.Second
+			},
+			wantErrs: []string{
+				"server.base_url must be an absolute URL",
+				"cors.max_age must not be negative",
+				"gateway.max_subscriptions must be at least 1: 0",
+				"gateway.messages_per_second must not be negative: -1",
+				"post.edit_window must not be negative: -1m0s",
+				"blob.store must be one of local, s3: ftp",
+				"mail.driver must be one of log, smtp: sendmail",
+				"digest.timezone is unknown",
+				"jobs.digest_interval must be positive",
+				"jobs.webhook_interval must be positive",
+				"tracing.exporter must be one of none, otlp: jaeger",
+				"jwt.algorithm must be one of HS256, RS256, EdDSA: none",
+				"jwt.ttl must be positive",
+				"jwt.leeway must not be negative",
+			},
+		},
+		{
+			name: "異常ケース(CORSのオリジン不正)",
+			modify: func(c *Config) {
+				c.CORS.AllowOrigins = []string{"*", "https://*.example.com", "https://example.com/", "example.com", "https://*"}
+				c.CORS.AllowCredentials = true
+			},
+			wantErrs: []string{
+				"cors.allow_origins must not contain * when cors.allow_credentials is true",
+				"cors.allow_origins must be * or scheme://host[:port]: https://example.com/",
+				"cors.allow_origins must be * or scheme://host[:port]: example.com",
+				"cors.allow_origins must be * or scheme://host[:port]: https://*",
+			},
+		},
+		{
+			name: "異常ケース(選択した方式の必須項目なし)",
+			modify: func(c *Config) {
+				c.Blob.Store = BlobStoreS3
+				c.Mail.Driver = MailDriverSMTP
+			},
+			wantErrs: []string{
+				"blob.s3.bucket is required",
+				"mail.smtp.host is required",
+			},
+		},
+		{
+			name: "異常ケース(OIDCの設定不正)",
+			modify: func(c *Config) {
+				c.OIDC.Issuer = "idp.example.com"
+			},
+			wantErrs: []string{
+				"oidc.issuer must be an absolute URL: idp.example.com",
+				"oidc.client_id is required",
+			},
+		},
+		{
+			name: "正常ケース(公開鍵暗号の署名は共通鍵なしでよい)",
+			modify: func(c *Config) {
+				c.JWT.Algorithm = JWTAlgorithmEdDSA
+				c.JWT.SecretKey = ""
+				c.JWT.KeyDir = "keys"
+				c.JWT.ActiveKeyID = "2025-01"
+			},
+		},
+		{
+			name: "異常ケース(公開鍵暗号の署名の必須項目なし)",
+			modify: func(c *Config) {
+				c.JWT.Algorithm = JWTAlgorithmRS256
+				c.JWT.SecretKey = "short"
+			},
+			wantErrs: []string{
+				"jwt.key_dir is required",
+				"jwt.active_key_id is required",
+				"jwt.secret_key must be at least 32 bytes",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testConfig()
+			tt.modify(c)
+			err := c.Validate()
+			if len(tt.wantErrs) == 0 {
+				if err != nil {
+					t.Errorf("Config.Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("予期せぬ正常終了")
+			}
+			for _, want := range tt.wantErrs {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Config.Validate() error = %v, want %v", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestConfig_Redacted(t *testing.T) {
+	c := testConfig()
+	c.Mail.SMTP.Password = "smtppass"
+
+	got := c.Redacted()
+	if got.DB.Password != redacted || got.JWT.SecretKey != redacted || got.Mail.SMTP.Password != redacted {
+		t.Errorf("秘密情報が伏せられていない got: %+v", got)
+	}
+	if got.Blob.S3.SecretAccessKey != "" {
+		t.Errorf("未設定の秘密情報不一致 got: %#v want: %#v", got.Blob.S3.SecretAccessKey, "")
+	}
+	if got.DB.User != "user" {
+		t.Errorf("秘密情報以外不一致 got: %#v want: %#v", got.DB.User, "user")
+	}
+	if c.DB.Password != "password" {
+		t.Errorf("元の設定が変更された got: %#v", c.DB.Password)
+	}
+}
+
+func TestConfig_Print(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testConfig().Print(&buf); err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+
+	got := buf.String()
+	for _, want := range []string{
+		"  host: localhost\n",
+		"  password: '[REDACTED]'\n",
+		"  secret_key: '[REDACTED]'\n",
+		"  digest_interval: 10m0s\n",
+		"  level: INFO\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("出力に %q が含まれない got: %s", want, got)
+		}
+	}
+	if strings.Contains(got, "password: password") || strings.Contains(got, testSecretKey) {
+		t.Errorf("秘密情報が出力された got: %s", got)
+	}
+}
+
+func Test_splitList(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  []string
+	}{
+		{name: "正常ケース", value: " 1, ,3 ", want: []string{"1", "3"}},
+		{name: "正常ケース(空)", value: "", want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitList(tt.value); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitList() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// Holder 現在の設定を保持し、再読み込みする
+// 複数のゴルーチンから同時に参照・再読み込みしてよい
+type Holder struct {
+	// args 再読み込み時にも使う起動時のコマンドライン引数
+	args    []string
+	current atomic.Pointer[Config]
+
+	// mu 再読み込みと購読者の登録を直列化する
+	mu          sync.Mutex
+	subscribers []func(*Config)
+}
+
+// NewHolder 設定を読み込み、保持する
+func NewHolder(args []string) (*Holder, error) {
+	c, err := Load(args)
+	if err != nil {
+		return nil, err
+	}
+	h := &Holder{args: args}
+	h.current.Store(c)
+	return h, nil
+}
+
+// Get 現在の設定を返す
+// 返した設定は変更しないこと(再読み込み時は新しい設定に置き換える)
+func (h *Holder) Get() *Config {
+	return h.current.Load()
+}
+
+// OnReload 再読み込みした設定を受け取る関数を登録する
+func (h *Holder) OnReload(f func(*Config)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.subscribers = append(h.subscribers, f)
+}
+
+// Reload 設定を読み込み直し、再読み込みできる項目(reloadタグ)だけを反映する
+// 変更されていたが再起動が必要な項目のキーをignoredで返す
+// 読み込みや検証に失敗した場合は現在の設定のまま、エラーを返す
+func (h *Holder) Reload() (ignored []string, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	loaded, err := Load(h.args)
+	if err != nil {
+		return nil, err
+	}
+
+	next := *h.Get()
+	nextFields, loadedFields := next.fields(), loaded.fields()
+	for i, f := range nextFields {
+		if reflect.DeepEqual(f.value.Interface(), loadedFields[i].value.Interface()) {
+			continue
+		}
+		if f.reload {
+			f.value.Set(loadedFields[i].value)
+		} else {
+			ignored = append(ignored, f.key)
+		}
+	}
+
+	h.current.Store(&next)
+	for _, f := range h.subscribers {
+		f(&next)
+	}
+	return ignored, nil
+}
@@ -0,0 +1,123 @@
+package config
+
+import (
+	"log/slog"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestHolder_Reload(t *testing.T) {
+	tests := []struct {
+		name        string
+		change      func(t *testing.T)
+		want        func() *Config
+		wantIgnored []string
+		wantErr     string
+	}{
+		{
+			name: "正常ケース(再読み込みできる項目を反映)",
+			change: func(t *testing.T) {
+				t.Setenv("CORS_ALLOW_ORIGINS", "http://example.com")
+				t.Setenv("CORS_MAX_AGE", "60")
+				t.Setenv("GATEWAY_MAX_SUBSCRIPTIONS", "10")
+				t.Setenv("LOG_LEVEL", "debug")
+			},
+			want: func() *Config {
+				c := testConfig()
+				c.CORS.AllowOrigins = []string{"http://example.com"}
+				c.CORS.MaxAge = 60
+				c.Gateway.MaxSubscriptions = 10
+				c.Log.Level = slog.LevelDebug
+				return c
+			},
+		},
+		{
+			name: "正常ケース(再起動が必要な項目は反映しない)",
+			change: func(t *testing.T) {
+				t.Setenv("DB_HOST", "db.example.com")
+				t.Setenv("SERVER_ADDR", ":9000")
+				t.Setenv("LOG_LEVEL", "warn")
+			},
+			want: func() *Config {
+				c := testConfig()
+				c.Log.Level = slog.LevelWarn
+				return c
+			},
+			wantIgnored: []string{"server.addr", "db.host"},
+		},
+		{
+			name: "異常ケース(検証エラーの場合は現在の設定のまま)",
+			change: func(t *testing.T) {
+				t.Setenv("CORS_ALLOW_ORIGINS", "http://example.com")
+				t.Setenv("CORS_MAX_AGE", "-1")
+			},
+			want:    testConfig,
+			wantErr: "cors.max_age must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setTestEnv(t)
+			h, err := NewHolder(nil)
+			if err != nil {
+				t.Fatalf("予期せぬエラー(error: %s)", err)
+			}
+			var notified *Config
+			h.OnReload(func(c *Config) { notified = c })
+			before := h.Get()
+
+			tt.change(t)
+			ignored, err := h.Reload()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Reload() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if notified != nil {
+					t.Errorf("失敗時に通知された")
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("予期せぬエラー(error: %s)", err)
+				}
+				if !reflect.DeepEqual(ignored, tt.wantIgnored) {
+					t.Errorf("戻り値不一致 got: %#v want: %#v", ignored, tt.wantIgnored)
+				}
+				if notified != h.Get() {
+					t.Errorf("再読み込みした設定が通知されていない")
+				}
+			}
+			if got, want := h.Get(), tt.want(); !reflect.DeepEqual(got, want) {
+				t.Errorf("設定不一致 got: %+v want: %+v", got, want)
+			}
+			if !reflect.DeepEqual(before, testConfig()) {
+				t.Errorf("再読み込み前の設定が変更された got: %+v", before)
+			}
+		})
+	}
+}
+
+func TestHolder_Concurrent(t *testing.T) {
+	setTestEnv(t)
+	h, err := NewHolder(nil)
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if _, err := h.Reload(); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			_ = h.Get().CORS.AllowOrigins
+		}()
+	}
+	wg.Wait()
+}
@@ -8,7 +8,269 @@ CREATE TABLE IF NOT EXISTS `bbs`.`user`
     `email` VARCHAR(255) NOT NULL UNIQUE,
     `password` VARCHAR(255) NOT NULL,
     `salt` CHAR(32) NOT NULL,
+    `display_name` VARCHAR(255) NOT NULL DEFAULT '',
+    `bio` TEXT NOT NULL,
+    `avatar_url` VARCHAR(2048) NOT NULL DEFAULT '',
+    `signature` VARCHAR(255) NOT NULL DEFAULT '',
     `created_at` DATETIME NOT NULL,
     `updated_at` DATETIME NOT NULL,
+    PRIMARY KEY (id),
+    INDEX (`name`)
+);
+
+CREATE TABLE IF NOT EXISTS `bbs`.`board`
+(
+    `id` MEDIUMINT NOT NULL AUTO_INCREMENT,
+    `name` VARCHAR(100) NOT NULL,
+    `description` VARCHAR(1024) NOT NULL DEFAULT '',
+    `created_at` DATETIME NOT NULL,
     PRIMARY KEY (id)
-);
\ No newline at end of file
+);
+
+CREATE TABLE IF NOT EXISTS `bbs`.`thread`
+(
+    `id` MEDIUMINT NOT NULL AUTO_INCREMENT,
+    `board_id` MEDIUMINT NOT NULL,
+    `user_id` MEDIUMINT NULL,
+    `title` VARCHAR(255) NOT NULL,
+    `post_count` INT NOT NULL DEFAULT 0,
+    `last_posted_at` DATETIME NOT NULL,
+    `created_at` DATETIME NOT NULL,
+    PRIMARY KEY (id),
+    INDEX (`board_id`, `id`),
+    INDEX (`board_id`, `last_posted_at`),
+    FOREIGN KEY (`board_id`) REFERENCES `board` (`id`) ON DELETE CASCADE,
+    FOREIGN KEY (`user_id`) REFERENCES `user` (`id`) ON DELETE SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS `bbs`.`post`
+(
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `thread_id` MEDIUMINT NOT NULL,
+    `user_id` MEDIUMINT NULL,
+    `number` INT NOT NULL,
+    `body` TEXT NOT NULL,
+    `body_html` MEDIUMTEXT NOT NULL,
+    `created_at` DATETIME NOT NULL,
+    `edited_at` DATETIME NULL,
+    `hidden_at` DATETIME NULL,
+    `hidden_reason` VARCHAR(255) NOT NULL DEFAULT '',
+    PRIMARY KEY (id),
+    UNIQUE (`thread_id`, `number`),
+    INDEX (`thread_id`, `created_at`),
+    FOREIGN KEY (`thread_id`) REFERENCES `thread` (`id`) ON DELETE CASCADE,
+    FOREIGN KEY (`user_id`) REFERENCES `user` (`id`) ON DELETE SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS `bbs`.`post_revision`
+(
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `post_id` INT NOT NULL,
+    `editor_id` MEDIUMINT NULL,
+    `body` TEXT NOT NULL,
+    `created_at` DATETIME NOT NULL,
+    PRIMARY KEY (id),
+    INDEX (`post_id`, `id`),
+    FOREIGN KEY (`post_id`) REFERENCES `post` (`id`) ON DELETE CASCADE,
+    FOREIGN KEY (`editor_id`) REFERENCES `user` (`id`) ON DELETE SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS `bbs`.`attachment`
+(
+    `id` MEDIUMINT NOT NULL AUTO_INCREMENT,
+    `user_id` MEDIUMINT NOT NULL,
+    `post_id` MEDIUMINT NULL,
+    `blob_key` VARCHAR(255) NOT NULL,
+    `thumbnail_key` VARCHAR(255) NOT NULL,
+    `content_type` VARCHAR(64) NOT NULL,
+    `size` INT NOT NULL,
+    `width` INT NOT NULL,
+    `height` INT NOT NULL,
+    `created_at` DATETIME NOT NULL,
+    PRIMARY KEY (id),
+    FOREIGN KEY (`user_id`) REFERENCES `user` (`id`) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS `bbs`.`notification`
+(
+    `id` MEDIUMINT NOT NULL AUTO_INCREMENT,
+    `user_id` MEDIUMINT NOT NULL,
+    `kind` VARCHAR(16) NOT NULL,
+    `actor_id` MEDIUMINT NULL,
+    `target_id` MEDIUMINT NOT NULL,
+    `read_at` DATETIME NULL,
+    `created_at` DATETIME NOT NULL,
+    PRIMARY KEY (id),
+    INDEX (`user_id`, `read_at`),
+    FOREIGN KEY (`user_id`) REFERENCES `user` (`id`) ON DELETE CASCADE,
+    FOREIGN KEY (`actor_id`) REFERENCES `user` (`id`) ON DELETE SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS `bbs`.`notification_preference`
+(
+    `user_id` MEDIUMINT NOT NULL,
+    `reply` BOOLEAN NOT NULL,
+    `quote` BOOLEAN NOT NULL,
+    `mention` BOOLEAN NOT NULL,
+    `updated_at` DATETIME NOT NULL,
+    PRIMARY KEY (user_id),
+    FOREIGN KEY (`user_id`) REFERENCES `user` (`id`) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS `bbs`.`watch`
+(
+    `user_id` MEDIUMINT NOT NULL,
+    `kind` VARCHAR(16) NOT NULL,
+    `target_id` MEDIUMINT NOT NULL,
+    `created_at` DATETIME NOT NULL,
+    PRIMARY KEY (`user_id`, `kind`, `target_id`),
+    FOREIGN KEY (`user_id`) REFERENCES `user` (`id`) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS `bbs`.`digest_subscription`
+(
+    `user_id` MEDIUMINT NOT NULL,
+    `frequency` VARCHAR(16) NOT NULL,
+    `locale` VARCHAR(8) NOT NULL,
+    `unsubscribe_token` VARCHAR(64) NOT NULL,
+    `updated_at` DATETIME NOT NULL,
+    PRIMARY KEY (user_id),
+    UNIQUE (`unsubscribe_token`),
+    INDEX (`frequency`),
+    FOREIGN KEY (`user_id`) REFERENCES `user` (`id`) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS `bbs`.`digest_delivery`
+(
+    `user_id` MEDIUMINT NOT NULL,
+    `period_key` VARCHAR(16) NOT NULL,
+    `sent_at` DATETIME NOT NULL,
+    PRIMARY KEY (`user_id`, `period_key`),
+    FOREIGN KEY (`user_id`) REFERENCES `user` (`id`) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS `bbs`.`webhook`
+(
+    `id` MEDIUMINT NOT NULL AUTO_INCREMENT,
+    `board_id` MEDIUMINT NOT NULL,
+    `url` VARCHAR(2048) NOT NULL,
+    `secret` VARCHAR(64) NOT NULL,
+    `events` VARCHAR(255) NOT NULL,
+    `created_at` DATETIME NOT NULL,
+    PRIMARY KEY (id),
+    INDEX (`board_id`)
+);
+
+CREATE TABLE IF NOT EXISTS `bbs`.`webhook_delivery`
+(
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `webhook_id` MEDIUMINT NOT NULL,
+    `event_id` INT NOT NULL,
+    `event` VARCHAR(32) NOT NULL,
+    `payload` MEDIUMTEXT NOT NULL,
+    `status` VARCHAR(16) NOT NULL,
+    `attempts` INT NOT NULL,
+    `next_attempt_at` DATETIME NOT NULL,
+    `last_status_code` INT NULL,
+    `last_error` VARCHAR(1024) NULL,
+    `created_at` DATETIME NOT NULL,
+    `delivered_at` DATETIME NULL,
+    PRIMARY KEY (id),
+    INDEX (`status`, `next_attempt_at`),
+    INDEX (`webhook_id`, `id`),
+    UNIQUE (`webhook_id`, `event_id`),
+    FOREIGN KEY (`webhook_id`) REFERENCES `webhook` (`id`) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS `bbs`.`event_outbox`
+(
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `type` VARCHAR(32) NOT NULL,
+    `aggregate_id` VARCHAR(64) NOT NULL,
+    `payload` MEDIUMTEXT NOT NULL,
+    `attempts` INT NOT NULL,
+    `next_attempt_at` DATETIME NOT NULL,
+    `last_error` VARCHAR(1024) NULL,
+    `occurred_at` DATETIME NOT NULL,
+    `dispatched_at` DATETIME NULL,
+    PRIMARY KEY (id),
+    INDEX (`dispatched_at`, `next_attempt_at`)
+);
+
+CREATE TABLE IF NOT EXISTS `bbs`.`session`
+(
+    `id` CHAR(32) NOT NULL,
+    `user_id` MEDIUMINT NOT NULL,
+    `device` VARCHAR(64) NOT NULL,
+    `ip` VARCHAR(45) NOT NULL,
+    `user_agent` VARCHAR(512) NOT NULL,
+    `created_at` DATETIME NOT NULL,
+    `last_seen_at` DATETIME NOT NULL,
+    `expires_at` DATETIME NOT NULL,
+    `revoked_at` DATETIME NULL,
+    PRIMARY KEY (id),
+    INDEX (`user_id`, `created_at`),
+    FOREIGN KEY (`user_id`) REFERENCES `user` (`id`) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS `bbs`.`user_mfa`
+(
+    `user_id` MEDIUMINT NOT NULL,
+    `secret` VARCHAR(64) NOT NULL,
+    `confirmed_at` DATETIME NULL,
+    `last_used_step` BIGINT NOT NULL DEFAULT 0,
+    `created_at` DATETIME NOT NULL,
+    PRIMARY KEY (user_id),
+    FOREIGN KEY (`user_id`) REFERENCES `user` (`id`) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS `bbs`.`user_mfa_recovery_code`
+(
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `user_id` MEDIUMINT NOT NULL,
+    `code_hash` CHAR(64) NOT NULL,
+    `used_at` DATETIME NULL,
+    PRIMARY KEY (id),
+    UNIQUE (`user_id`, `code_hash`),
+    FOREIGN KEY (`user_id`) REFERENCES `user` (`id`) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS `bbs`.`mfa_challenge`
+(
+    `id` CHAR(64) NOT NULL,
+    `user_id` MEDIUMINT NOT NULL,
+    `attempts` INT NOT NULL DEFAULT 0,
+    `expires_at` DATETIME NOT NULL,
+    `created_at` DATETIME NOT NULL,
+    PRIMARY KEY (id),
+    INDEX (`user_id`, `expires_at`),
+    FOREIGN KEY (`user_id`) REFERENCES `user` (`id`) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS `bbs`.`user_identity`
+(
+    `id` MEDIUMINT NOT NULL AUTO_INCREMENT,
+    `issuer` VARCHAR(255) NOT NULL,
+    `subject` VARCHAR(255) NOT NULL,
+    `user_id` MEDIUMINT NOT NULL,
+    `email` VARCHAR(255) NOT NULL,
+    `created_at` DATETIME NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE (`issuer`, `subject`),
+    FOREIGN KEY (`user_id`) REFERENCES `user` (`id`) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS `bbs`.`api_token`
+(
+    `id` CHAR(32) NOT NULL,
+    `user_id` MEDIUMINT NOT NULL,
+    `name` VARCHAR(100) NOT NULL,
+    `token_hash` CHAR(64) NOT NULL UNIQUE,
+    `scopes` VARCHAR(64) NOT NULL,
+    `expires_at` DATETIME NOT NULL,
+    `last_used_at` DATETIME NULL,
+    `created_at` DATETIME NOT NULL,
+    PRIMARY KEY (id),
+    INDEX (`user_id`, `created_at`),
+    FOREIGN KEY (`user_id`) REFERENCES `user` (`id`) ON DELETE CASCADE
+);
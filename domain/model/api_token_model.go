@@ -0,0 +1,151 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+type (
+	// APIToken ボットやスクリプトがAPIを呼び出すための個人用アクセストークン
+	// mockgen -source domain/model/api_token_model.go -destination mock/mock_model/api_token_model_mock.go
+	APIToken interface {
+		ID() string
+		UserID() string
+		Name() string
+		Hash() string
+		Scopes() []string
+		ExpiresAt() time.Time
+		LastUsedAt() time.Time
+		CreatedAt() time.Time
+		IsValid(now time.Time) bool
+		HasScope(scope string) bool
+	}
+
+	// apiToken 個人用アクセストークン
+	apiToken struct {
+		id         string
+		userID     string
+		name       string
+		hash       string
+		scopes     []string
+		expiresAt  time.Time
+		lastUsedAt time.Time
+		createdAt  time.Time
+	}
+)
+
+const (
+	// APIScopeRead プロフィール、通知、スレッドのイベントを読み取る
+	APIScopeRead = "read"
+	// APIScopePost 投稿する(添付ファイルのアップロード、通知の既読化を含む)
+	APIScopePost = "post"
+	// APIScopeModerate 管理者の権限で掲示板を管理する(管理者のユーザーのトークンに限る。/admin配下はセッションのみ)
+	APIScopeModerate = "moderate"
+
+	// APITokenPrefix アクセストークンの接頭辞(JWTと区別し、漏洩したトークンを検出しやすくする)
+	APITokenPrefix = "gbp_"
+)
+
+// APIScopes 個人用アクセストークンに付けられるスコープ
+var APIScopes = []string{APIScopeRead, APIScopePost, APIScopeModerate}
+
+// NewAPIToken 個人用アクセストークンを生成する(使われていない場合lastUsedAtはゼロ値)
+func NewAPIToken(
+	id string,
+	userID string,
+	name string,
+	hash string,
+	scopes []string,
+	expiresAt time.Time,
+	lastUsedAt time.Time,
+	createdAt time.Time) APIToken {
+	return &apiToken{
+		id:         id,
+		userID:     userID,
+		name:       name,
+		hash:       hash,
+		scopes:     scopes,
+		expiresAt:  expiresAt,
+		lastUsedAt: lastUsedAt,
+		createdAt:  createdAt,
+	}
+}
+
+// ID IDを返す(一覧や失効に使い、トークンとしては使えない)
+func (t *apiToken) ID() string {
+	return t.id
+}
+
+// UserID 発行したユーザーのIDを返す
+func (t *apiToken) UserID() string {
+	return t.userID
+}
+
+// Name 用途がわかるよう付けた名前を返す
+func (t *apiToken) Name() string {
+	return t.name
+}
+
+// Hash トークンのハッシュを返す(トークン自体は発行時にだけ返し、保存しない)
+func (t *apiToken) Hash() string {
+	return t.hash
+}
+
+// Scopes 許可したスコープを返す
+func (t *apiToken) Scopes() []string {
+	return t.scopes
+}
+
+// ExpiresAt 有効期限を返す
+func (t *apiToken) ExpiresAt() time.Time {
+	return t.expiresAt
+}
+
+// LastUsedAt 最後に認証に使われた日時を返す
+func (t *apiToken) LastUsedAt() time.Time {
+	return t.lastUsedAt
+}
+
+// CreatedAt 発行日時を返す
+func (t *apiToken) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+// IsValid 有効期限内か判定する
+func (t *apiToken) IsValid(now time.Time) bool {
+	return now.Before(t.expiresAt)
+}
+
+// HasScope スコープを許可しているか判定する
+func (t *apiToken) HasScope(scope string) bool {
+	for _, s := range t.scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAPIScope 個人用アクセストークンに付けられるスコープか判定する
+func IsAPIScope(scope string) bool {
+	for _, s := range APIScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAPIToken 個人用アクセストークンの形式か判定する(JWTとの区別に使う)
+func IsAPIToken(token string) bool {
+	return strings.HasPrefix(token, APITokenPrefix)
+}
+
+// HashAPIToken 個人用アクセストークンを保存するためのハッシュを返す
+// 十分な長さの乱数のため、ソルトなしのSHA-256とする
+func HashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,106 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_apiToken_Getters(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	lastUsed := now.Add(time.Minute)
+	expires := now.Add(time.Hour)
+	token := NewAPIToken("tid", "1", "ci-bot", "hash", []string{APIScopeRead, APIScopePost}, expires, lastUsed, now)
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{name: "ID", got: token.ID(), want: "tid"},
+		{name: "UserID", got: token.UserID(), want: "1"},
+		{name: "Name", got: token.Name(), want: "ci-bot"},
+		{name: "Hash", got: token.Hash(), want: "hash"},
+		{name: "Scopes", got: token.Scopes(), want: []string{"read", "post"}},
+		{name: "ExpiresAt", got: token.ExpiresAt(), want: expires},
+		{name: "LastUsedAt", got: token.LastUsedAt(), want: lastUsed},
+		{name: "CreatedAt", got: token.CreatedAt(), want: now},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_apiToken_IsValid(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		token APIToken
+		want  bool
+	}{
+		{name: "有効", token: NewAPIToken("tid", "1", "", "", nil, now.Add(time.Second), time.Time{}, now), want: true},
+		{name: "有効期限切れ", token: NewAPIToken("tid", "1", "", "", nil, now, time.Time{}, now), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.token.IsValid(now); got != tt.want {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_apiToken_HasScope(t *testing.T) {
+	token := NewAPIToken("tid", "1", "", "", []string{APIScopeRead}, time.Time{}, time.Time{}, time.Time{})
+
+	tests := []struct {
+		scope string
+		want  bool
+	}{
+		{scope: APIScopeRead, want: true},
+		{scope: APIScopePost, want: false},
+		{scope: APIScopeModerate, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.scope, func(t *testing.T) {
+			if got := token.HasScope(tt.scope); got != tt.want {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsAPIScope(t *testing.T) {
+	for _, scope := range []string{"read", "post", "moderate"} {
+		if !IsAPIScope(scope) {
+			t.Errorf("IsAPIScope(%q) = false", scope)
+		}
+	}
+	for _, scope := range []string{"", "admin", "READ"} {
+		if IsAPIScope(scope) {
+			t.Errorf("IsAPIScope(%q) = true", scope)
+		}
+	}
+}
+
+func TestIsAPIToken(t *testing.T) {
+	if !IsAPIToken("gbp_abc") {
+		t.Errorf("IsAPIToken() = false")
+	}
+	if IsAPIToken("eyJhbGciOiJIUzI1NiJ9.e30.sig") {
+		t.Errorf("IsAPIToken() = true")
+	}
+}
+
+func TestHashAPIToken(t *testing.T) {
+	// echo -n abc | sha256sum
+	want := "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
+	if got := HashAPIToken("abc"); got != want {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
+	}
+}
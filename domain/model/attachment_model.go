@@ -0,0 +1,110 @@
+package model
+
+import "time"
+
+type (
+	// Attachment 添付ファイル
+	// mockgen -source domain/model/attachment_model.go -destination mock/mock_model/attachment_model_mock.go
+	Attachment interface {
+		ID() string
+		UserID() string
+		PostID() string
+		BlobKey() string
+		ThumbnailKey() string
+		ContentType() string
+		Size() int64
+		Width() int
+		Height() int
+		CreatedAt() time.Time
+	}
+
+	// attachment 添付ファイル
+	attachment struct {
+		id           string
+		userID       string
+		postID       string
+		blobKey      string
+		thumbnailKey string
+		contentType  string
+		size         int64
+		width        int
+		height       int
+		createdAt    time.Time
+	}
+)
+
+// NewAttachment 添付ファイルを生成する(投稿に未紐付けの場合postIDは空)
+func NewAttachment(
+	id string,
+	userID string,
+	postID string,
+	blobKey string,
+	thumbnailKey string,
+	contentType string,
+	size int64,
+	width int,
+	height int,
+	createdAt time.Time) Attachment {
+	return &attachment{
+		id:           id,
+		userID:       userID,
+		postID:       postID,
+		blobKey:      blobKey,
+		thumbnailKey: thumbnailKey,
+		contentType:  contentType,
+		size:         size,
+		width:        width,
+		height:       height,
+		createdAt:    createdAt,
+	}
+}
+
+// ID IDを返す
+func (a *attachment) ID() string {
+	return a.id
+}
+
+// UserID アップロードしたユーザーのIDを返す
+func (a *attachment) UserID() string {
+	return a.userID
+}
+
+// PostID 紐付く投稿のIDを返す
+func (a *attachment) PostID() string {
+	return a.postID
+}
+
+// BlobKey 原本のキーを返す
+func (a *attachment) BlobKey() string {
+	return a.blobKey
+}
+
+// ThumbnailKey サムネイルのキーを返す
+func (a *attachment) ThumbnailKey() string {
+	return a.thumbnailKey
+}
+
+// ContentType コンテントタイプを返す
+func (a *attachment) ContentType() string {
+	return a.contentType
+}
+
+// Size 原本のバイト数を返す
+func (a *attachment) Size() int64 {
+	return a.size
+}
+
+// Width 幅を返す
+func (a *attachment) Width() int {
+	return a.width
+}
+
+// Height 高さを返す
+func (a *attachment) Height() int {
+	return a.height
+}
+
+// CreatedAt 登録日時を返す
+func (a *attachment) CreatedAt() time.Time {
+	return a.createdAt
+}
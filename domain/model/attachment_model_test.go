@@ -0,0 +1,57 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewAttachment(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	got := NewAttachment("1", "2", "3", "a.png", "a-thumb.png", "image/png", 100, 20, 10, now)
+	want := &attachment{
+		id:           "1",
+		userID:       "2",
+		postID:       "3",
+		blobKey:      "a.png",
+		thumbnailKey: "a-thumb.png",
+		contentType:  "image/png",
+		size:         100,
+		width:        20,
+		height:       10,
+		createdAt:    now,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewAttachment() = %v, want %v", got, want)
+	}
+}
+
+func Test_attachment_Getters(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	a := NewAttachment("1", "2", "3", "a.png", "a-thumb.png", "image/png", 100, 20, 10, now)
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{name: "ID", got: a.ID(), want: "1"},
+		{name: "UserID", got: a.UserID(), want: "2"},
+		{name: "PostID", got: a.PostID(), want: "3"},
+		{name: "BlobKey", got: a.BlobKey(), want: "a.png"},
+		{name: "ThumbnailKey", got: a.ThumbnailKey(), want: "a-thumb.png"},
+		{name: "ContentType", got: a.ContentType(), want: "image/png"},
+		{name: "Size", got: a.Size(), want: int64(100)},
+		{name: "Width", got: a.Width(), want: 20},
+		{name: "Height", got: a.Height(), want: 10},
+		{name: "CreatedAt", got: a.CreatedAt(), want: now},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("attachment.%s() = %v, want %v", tt.name, tt.got, tt.want)
+			}
+		})
+	}
+}
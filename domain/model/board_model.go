@@ -0,0 +1,52 @@
+package model
+
+import "time"
+
+type (
+	// Board 板
+	// mockgen -source domain/model/board_model.go -destination mock/mock_model/board_model_mock.go
+	Board interface {
+		ID() string
+		Name() string
+		Description() string
+		CreatedAt() time.Time
+	}
+
+	// board 板
+	board struct {
+		id          string
+		name        string
+		description string
+		createdAt   time.Time
+	}
+)
+
+// NewBoard 板を生成する
+func NewBoard(id string, name string, description string, createdAt time.Time) Board {
+	return &board{
+		id:          id,
+		name:        name,
+		description: description,
+		createdAt:   createdAt,
+	}
+}
+
+// ID IDを返す
+func (b *board) ID() string {
+	return b.id
+}
+
+// Name 板の名前を返す
+func (b *board) Name() string {
+	return b.name
+}
+
+// Description 板の説明を返す
+func (b *board) Description() string {
+	return b.description
+}
+
+// CreatedAt 作成日時を返す
+func (b *board) CreatedAt() time.Time {
+	return b.createdAt
+}
@@ -0,0 +1,40 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewBoard(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	got := NewBoard("1", "name", "description", now)
+	want := &board{id: "1", name: "name", description: "description", createdAt: now}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewBoard() = %v, want %v", got, want)
+	}
+}
+
+func Test_board_Getters(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	b := NewBoard("1", "name", "description", now)
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{name: "ID", got: b.ID(), want: "1"},
+		{name: "Name", got: b.Name(), want: "name"},
+		{name: "Description", got: b.Description(), want: "description"},
+		{name: "CreatedAt", got: b.CreatedAt(), want: now},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", tt.got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+type (
+	// DigestFrequency ダイジェストメールの配信頻度
+	DigestFrequency string
+
+	// DigestSubscription ダイジェストメールの配信設定
+	DigestSubscription struct {
+		Frequency        DigestFrequency
+		Locale           string
+		UnsubscribeToken string
+	}
+
+	// DigestPeriod ダイジェストの集計期間(Keyは送信済み判定に使用する)
+	DigestPeriod struct {
+		Key  string
+		From time.Time
+		To   time.Time
+	}
+)
+
+const (
+	// DigestOff 配信しない
+	DigestOff DigestFrequency = "off"
+	// DigestDaily 毎日配信する
+	DigestDaily DigestFrequency = "daily"
+	// DigestWeekly 毎週配信する
+	DigestWeekly DigestFrequency = "weekly"
+)
+
+const (
+	// DigestLocaleJa 日本語
+	DigestLocaleJa = "ja"
+	// DigestLocaleEn 英語
+	DigestLocaleEn = "en"
+)
+
+// DefaultDigestSubscription 未設定の場合の配信設定を返す
+func DefaultDigestSubscription() DigestSubscription {
+	return DigestSubscription{Frequency: DigestOff, Locale: DigestLocaleJa}
+}
+
+// IsValid 配信頻度が定義済みか判定する
+func (f DigestFrequency) IsValid() bool {
+	switch f {
+	case DigestOff, DigestDaily, DigestWeekly:
+		return true
+	}
+	return false
+}
+
+// IsValidDigestLocale ダイジェストメールが対応している言語か判定する
+func IsValidDigestLocale(locale string) bool {
+	return locale == DigestLocaleJa || locale == DigestLocaleEn
+}
+
+// NewDigestPeriod nowの時点で配信すべき直前の集計期間を返す
+// 毎日は前日0時から当日0時まで、毎週は前週月曜0時から今週月曜0時までで、nowのタイムゾーンで区切る
+func NewDigestPeriod(frequency DigestFrequency, now time.Time) (DigestPeriod, bool) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch frequency {
+	case DigestDaily:
+		return DigestPeriod{
+			Key:  today.Format("2006-01-02"),
+			From: today.AddDate(0, 0, -1),
+			To:   today,
+		}, true
+	case DigestWeekly:
+		// 月曜日を週の始まりとする
+		monday := today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+		year, week := monday.ISOWeek()
+		return DigestPeriod{
+			Key:  fmt.Sprintf("%04d-W%02d", year, week),
+			From: monday.AddDate(0, 0, -7),
+			To:   monday,
+		}, true
+	}
+	return DigestPeriod{}, false
+}
@@ -0,0 +1,102 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDigestFrequency_IsValid(t *testing.T) {
+	tests := []struct {
+		frequency DigestFrequency
+		want      bool
+	}{
+		{frequency: DigestOff, want: true},
+		{frequency: DigestDaily, want: true},
+		{frequency: DigestWeekly, want: true},
+		{frequency: "monthly", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.frequency), func(t *testing.T) {
+			if got := tt.frequency.IsValid(); got != tt.want {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsValidDigestLocale(t *testing.T) {
+	for locale, want := range map[string]bool{"ja": true, "en": true, "fr": false, "": false} {
+		if got := IsValidDigestLocale(locale); got != want {
+			t.Errorf("IsValidDigestLocale(%q) = %v, want %v", locale, got, want)
+		}
+	}
+}
+
+func TestNewDigestPeriod(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+
+	tests := []struct {
+		name      string
+		frequency DigestFrequency
+		now       time.Time
+		want      DigestPeriod
+		wantOK    bool
+	}{
+		{
+			name:      "毎日",
+			frequency: DigestDaily,
+			now:       time.Date(2023, 1, 4, 7, 30, 0, 0, jst),
+			want: DigestPeriod{
+				Key:  "2023-01-04",
+				From: time.Date(2023, 1, 3, 0, 0, 0, 0, jst),
+				To:   time.Date(2023, 1, 4, 0, 0, 0, 0, jst),
+			},
+			wantOK: true,
+		},
+		{
+			name:      "毎週(水曜日)",
+			frequency: DigestWeekly,
+			now:       time.Date(2023, 1, 4, 7, 30, 0, 0, jst),
+			want: DigestPeriod{
+				Key:  "2023-W01",
+				From: time.Date(2022, 12, 26, 0, 0, 0, 0, jst),
+				To:   time.Date(2023, 1, 2, 0, 0, 0, 0, jst),
+			},
+			wantOK: true,
+		},
+		{
+			name:      "毎週(日曜日は前週扱い)",
+			frequency: DigestWeekly,
+			now:       time.Date(2023, 1, 8, 23, 0, 0, 0, jst),
+			want: DigestPeriod{
+				Key:  "2023-W01",
+				From: time.Date(2022, 12, 26, 0, 0, 0, 0, jst),
+				To:   time.Date(2023, 1, 2, 0, 0, 0, 0, jst),
+			},
+			wantOK: true,
+		},
+		{
+			name:      "配信しない",
+			frequency: DigestOff,
+			now:       time.Date(2023, 1, 4, 7, 30, 0, 0, jst),
+			want:      DigestPeriod{},
+			wantOK:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := NewDigestPeriod(tt.frequency, tt.now)
+			if ok != tt.wantOK || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v, %v want: %#v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestDefaultDigestSubscription(t *testing.T) {
+	want := DigestSubscription{Frequency: DigestOff, Locale: DigestLocaleJa}
+	if got := DefaultDigestSubscription(); got != want {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
+	}
+}
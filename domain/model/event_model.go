@@ -0,0 +1,160 @@
+package model
+
+import "time"
+
+type (
+	// Event ドメインイベント(アウトボックスに保存し、配信状態を持つ)
+	// mockgen -source domain/model/event_model.go -destination mock/mock_model/event_model_mock.go
+	Event interface {
+		ID() string
+		Type() EventType
+		AggregateID() string
+		Payload() []byte
+		Attempts() int
+		NextAttemptAt() time.Time
+		LastError() string
+		OccurredAt() time.Time
+		DispatchedAt() time.Time
+		IsDispatched() bool
+	}
+
+	// EventType ドメインイベントの種類
+	EventType string
+
+	// UserRegisteredData ユーザー登録イベントの内容
+	UserRegisteredData struct {
+		UserID string `json:"user_id"`
+		Name   string `json:"name"`
+	}
+
+	// ThreadCreatedData スレッド作成イベントの内容
+	ThreadCreatedData struct {
+		BoardID  string `json:"board_id"`
+		ThreadID string `json:"thread_id"`
+		UserID   string `json:"user_id"`
+		Title    string `json:"title"`
+	}
+
+	// PostCreatedData 投稿作成イベントの内容
+	PostCreatedData struct {
+		BoardID  string `json:"board_id"`
+		ThreadID string `json:"thread_id"`
+		PostID   string `json:"post_id"`
+		UserID   string `json:"user_id"`
+	}
+
+	// PostHiddenData 投稿非表示イベントの内容
+	PostHiddenData struct {
+		BoardID  string `json:"board_id"`
+		ThreadID string `json:"thread_id"`
+		PostID   string `json:"post_id"`
+		Reason   string `json:"reason"`
+	}
+
+	// event ドメインイベント
+	event struct {
+		id            string
+		eventType     EventType
+		aggregateID   string
+		payload       []byte
+		attempts      int
+		nextAttemptAt time.Time
+		lastError     string
+		occurredAt    time.Time
+		dispatchedAt  time.Time
+	}
+)
+
+const (
+	// EventUserRegistered ユーザーの登録
+	EventUserRegistered EventType = "user.registered"
+	// EventThreadCreated スレッドの作成
+	EventThreadCreated EventType = "thread.created"
+	// EventPostCreated 投稿の作成
+	EventPostCreated EventType = "post.created"
+	// EventPostHidden 投稿の非表示
+	EventPostHidden EventType = "post.hidden"
+)
+
+// NewEvent ドメインイベントを生成する(未配信の場合dispatchedAtはゼロ値)
+func NewEvent(
+	id string,
+	eventType EventType,
+	aggregateID string,
+	payload []byte,
+	attempts int,
+	nextAttemptAt time.Time,
+	lastError string,
+	occurredAt time.Time,
+	dispatchedAt time.Time) Event {
+	return &event{
+		id:            id,
+		eventType:     eventType,
+		aggregateID:   aggregateID,
+		payload:       payload,
+		attempts:      attempts,
+		nextAttemptAt: nextAttemptAt,
+		lastError:     lastError,
+		occurredAt:    occurredAt,
+		dispatchedAt:  dispatchedAt,
+	}
+}
+
+// ID IDを返す
+func (e *event) ID() string {
+	return e.id
+}
+
+// Type 種類を返す
+func (e *event) Type() EventType {
+	return e.eventType
+}
+
+// AggregateID イベントが発生したユーザー・スレッド・投稿などのIDを返す
+func (e *event) AggregateID() string {
+	return e.aggregateID
+}
+
+// Payload イベントの内容(JSON)を返す
+func (e *event) Payload() []byte {
+	return e.payload
+}
+
+// Attempts 配信を試行した回数を返す
+func (e *event) Attempts() int {
+	return e.attempts
+}
+
+// NextAttemptAt 次に配信を試行する日時を返す
+func (e *event) NextAttemptAt() time.Time {
+	return e.nextAttemptAt
+}
+
+// LastError 最後の試行のエラーを返す
+func (e *event) LastError() string {
+	return e.lastError
+}
+
+// OccurredAt 発生日時を返す
+func (e *event) OccurredAt() time.Time {
+	return e.occurredAt
+}
+
+// DispatchedAt 配信が完了した日時を返す
+func (e *event) DispatchedAt() time.Time {
+	return e.dispatchedAt
+}
+
+// IsDispatched 配信済みか判定する
+func (e *event) IsDispatched() bool {
+	return !e.dispatchedAt.IsZero()
+}
+
+// IsValid 種類が定義済みか判定する
+func (t EventType) IsValid() bool {
+	switch t {
+	case EventUserRegistered, EventThreadCreated, EventPostCreated, EventPostHidden:
+		return true
+	}
+	return false
+}
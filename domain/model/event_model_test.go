@@ -0,0 +1,59 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_event_Getters(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	next := now.Add(time.Minute)
+	dispatched := now.Add(time.Second)
+	e := NewEvent("1", EventPostCreated, "10", []byte("{}"), 2, next, "error", now, dispatched)
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{name: "ID", got: e.ID(), want: "1"},
+		{name: "Type", got: e.Type(), want: EventPostCreated},
+		{name: "AggregateID", got: e.AggregateID(), want: "10"},
+		{name: "Payload", got: e.Payload(), want: []byte("{}")},
+		{name: "Attempts", got: e.Attempts(), want: 2},
+		{name: "NextAttemptAt", got: e.NextAttemptAt(), want: next},
+		{name: "LastError", got: e.LastError(), want: "error"},
+		{name: "OccurredAt", got: e.OccurredAt(), want: now},
+		{name: "DispatchedAt", got: e.DispatchedAt(), want: dispatched},
+		{name: "IsDispatched", got: e.IsDispatched(), want: true},
+		{name: "IsDispatched(未配信)", got: NewEvent("1", EventPostCreated, "10", nil, 0, now, "", now, time.Time{}).IsDispatched(), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEventType_IsValid(t *testing.T) {
+	tests := []struct {
+		eventType EventType
+		want      bool
+	}{
+		{eventType: EventUserRegistered, want: true},
+		{eventType: EventThreadCreated, want: true},
+		{eventType: EventPostCreated, want: true},
+		{eventType: EventPostHidden, want: true},
+		{eventType: "user.deleted", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.eventType), func(t *testing.T) {
+			if got := tt.eventType.IsValid(); got != tt.want {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package model
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	// MFA ユーザーのTOTPによる2要素認証の設定
+	// mockgen -source domain/model/mfa_model.go -destination mock/mock_model/mfa_model_mock.go
+	MFA interface {
+		UserID() string
+		Secret() string
+		ConfirmedAt() time.Time
+		LastUsedStep() int64
+		CreatedAt() time.Time
+		IsEnabled() bool
+		VerifyCode(code string, now time.Time) (int64, bool)
+		URI(issuer string, accountName string) string
+	}
+
+	// MFAChallenge パスワード認証後、確認コードの入力を待つログイン
+	MFAChallenge interface {
+		ID() string
+		UserID() string
+		Attempts() int
+		ExpiresAt() time.Time
+		CreatedAt() time.Time
+		IsValid(now time.Time) bool
+	}
+
+	// mfa 2要素認証の設定
+	mfa struct {
+		userID       string
+		secret       string
+		confirmedAt  time.Time
+		lastUsedStep int64
+		createdAt    time.Time
+	}
+
+	// mfaChallenge 確認コードの入力を待つログイン
+	mfaChallenge struct {
+		id        string
+		userID    string
+		attempts  int
+		expiresAt time.Time
+		createdAt time.Time
+	}
+)
+
+const (
+	// TOTPDigits 確認コードの桁数
+	TOTPDigits = 6
+	// TOTPPeriod 確認コードが切り替わる間隔
+	TOTPPeriod = 30 * time.Second
+	// totpSkew 時計のずれを許容する前後のステップ数
+	totpSkew = 1
+	// MaxMFAChallengeAttempts 1回のログインで確認コードを誤ってよい回数
+	MaxMFAChallengeAttempts = 5
+)
+
+// totpEncoding TOTPの秘密鍵のエンコーディング(認証アプリが読み込めるようパディングなしのBase32にする)
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// NewMFA 2要素認証の設定を生成する(確認前の場合confirmedAtはゼロ値)
+func NewMFA(userID string, secret string, confirmedAt time.Time, lastUsedStep int64, createdAt time.Time) MFA {
+	return &mfa{
+		userID:       userID,
+		secret:       secret,
+		confirmedAt:  confirmedAt,
+		lastUsedStep: lastUsedStep,
+		createdAt:    createdAt,
+	}
+}
+
+// UserID ユーザーIDを返す
+func (m *mfa) UserID() string {
+	return m.userID
+}
+
+// Secret TOTPの秘密鍵(Base32)を返す
+func (m *mfa) Secret() string {
+	return m.secret
+}
+
+// ConfirmedAt 最初の確認コードで有効にした日時を返す
+func (m *mfa) ConfirmedAt() time.Time {
+	return m.confirmedAt
+}
+
+// LastUsedStep 最後に使われた確認コードのステップを返す(同じコードを再利用させない)
+func (m *mfa) LastUsedStep() int64 {
+	return m.lastUsedStep
+}
+
+// CreatedAt 登録を開始した日時を返す
+func (m *mfa) CreatedAt() time.Time {
+	return m.createdAt
+}
+
+// IsEnabled 確認コードで有効にしたか判定する
+func (m *mfa) IsEnabled() bool {
+	return !m.confirmedAt.IsZero()
+}
+
+// VerifyCode 確認コードを検証し、一致したステップを返す
+// 前後1ステップのずれを許容し、使用済みのステップ以前のコードは受け付けない
+func (m *mfa) VerifyCode(code string, now time.Time) (int64, bool) {
+	if !IsTOTPCode(code) {
+		return 0, false
+	}
+
+	current := now.Unix() / int64(TOTPPeriod/time.Second)
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if step <= m.lastUsedStep {
+			continue
+		}
+		want, err := TOTPCode(m.secret, step)
+		if err != nil {
+			return 0, false
+		}
+		if hmac.Equal([]byte(code), []byte(want)) {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// URI 認証アプリに登録するotpauth URIを返す
+func (m *mfa) URI(issuer string, accountName string) string {
+	query := url.Values{}
+	query.Set("secret", m.secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", strconv.Itoa(TOTPDigits))
+	query.Set("period", strconv.Itoa(int(TOTPPeriod/time.Second)))
+
+	return "otpauth://totp/" + url.PathEscape(issuer+":"+accountName) + "?" + query.Encode()
+}
+
+// TOTPCode ステップの確認コードを計算する(RFC 6238、HMAC-SHA1)
+func TOTPCode(secret string, step int64) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", errors.Wrap(err, "TOTPCode error")
+	}
+
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", TOTPDigits, value%1000000), nil
+}
+
+// EncodeTOTPSecret 乱数をTOTPの秘密鍵(Base32)にする
+func EncodeTOTPSecret(b []byte) string {
+	return totpEncoding.EncodeToString(b)
+}
+
+// IsTOTPCode 確認コードの形式(6桁の数字)か判定する
+func IsTOTPCode(code string) bool {
+	if len(code) != TOTPDigits {
+		return false
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// HashMFAToken リカバリーコードやチャレンジトークンを保存するためのハッシュを返す
+// いずれも十分な長さの乱数のため、ソルトなしのSHA-256とする
+func HashMFAToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// NewMFAChallenge 確認コードの入力を待つログインを生成する(idはトークンのハッシュ)
+func NewMFAChallenge(id string, userID string, attempts int, expiresAt time.Time, createdAt time.Time) MFAChallenge {
+	return &mfaChallenge{
+		id:        id,
+		userID:    userID,
+		attempts:  attempts,
+		expiresAt: expiresAt,
+		createdAt: createdAt,
+	}
+}
+
+// ID トークンのハッシュを返す
+func (c *mfaChallenge) ID() string {
+	return c.id
+}
+
+// UserID パスワード認証に成功したユーザーのIDを返す
+func (c *mfaChallenge) UserID() string {
+	return c.userID
+}
+
+// Attempts 確認コードを誤った回数を返す
+func (c *mfaChallenge) Attempts() int {
+	return c.attempts
+}
+
+// ExpiresAt 有効期限を返す
+func (c *mfaChallenge) ExpiresAt() time.Time {
+	return c.expiresAt
+}
+
+// CreatedAt パスワード認証に成功した日時を返す
+func (c *mfaChallenge) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+// IsValid 有効期限内で、確認コードを誤った回数が上限未満か判定する
+func (c *mfaChallenge) IsValid(now time.Time) bool {
+	return c.attempts < MaxMFAChallengeAttempts && now.Before(c.expiresAt)
+}
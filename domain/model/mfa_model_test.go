@@ -0,0 +1,169 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret RFC 6238のテストベクタの秘密鍵("12345678901234567890")
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func Test_mfa_Getters(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	confirmed := now.Add(time.Minute)
+	m := NewMFA("1", rfc6238Secret, confirmed, 10, now)
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{name: "UserID", got: m.UserID(), want: "1"},
+		{name: "Secret", got: m.Secret(), want: rfc6238Secret},
+		{name: "ConfirmedAt", got: m.ConfirmedAt(), want: confirmed},
+		{name: "LastUsedStep", got: m.LastUsedStep(), want: int64(10)},
+		{name: "CreatedAt", got: m.CreatedAt(), want: now},
+		{name: "IsEnabled", got: m.IsEnabled(), want: true},
+		{name: "IsEnabled(確認前)", got: NewMFA("1", rfc6238Secret, time.Time{}, 0, now).IsEnabled(), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTOTPCode(t *testing.T) {
+	tests := []struct {
+		name    string
+		secret  string
+		unix    int64
+		want    string
+		wantErr bool
+	}{
+		{name: "正常ケース(59)", secret: rfc6238Secret, unix: 59, want: "287082"},
+		{name: "正常ケース(1111111109)", secret: rfc6238Secret, unix: 1111111109, want: "081804"},
+		{name: "正常ケース(1234567890)", secret: rfc6238Secret, unix: 1234567890, want: "005924"},
+		{name: "正常ケース(小文字)", secret: "gezdgnbvgy3tqojqgezdgnbvgy3tqojq", unix: 59, want: "287082"},
+		{name: "異常ケース(Base32でない)", secret: "!!", unix: 59, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TOTPCode(tt.secret, tt.unix/30)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("予期せぬエラー(error: %s)", err)
+			}
+			if got != tt.want {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_mfa_VerifyCode(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+	step := now.Unix() / 30
+	code := func(step int64) string {
+		c, err := TOTPCode(rfc6238Secret, step)
+		if err != nil {
+			t.Fatalf("予期せぬエラー(error: %s)", err)
+		}
+		return c
+	}
+
+	tests := []struct {
+		name     string
+		lastUsed int64
+		code     string
+		wantStep int64
+		wantOK   bool
+	}{
+		{name: "現在のコード", code: code(step), wantStep: step, wantOK: true},
+		{name: "1つ前のコード", code: code(step - 1), wantStep: step - 1, wantOK: true},
+		{name: "1つ後のコード", code: code(step + 1), wantStep: step + 1, wantOK: true},
+		{name: "2つ前のコード", code: code(step - 2), wantOK: false},
+		{name: "使用済みのコード", lastUsed: step, code: code(step), wantOK: false},
+		{name: "使用済みより後のコード", lastUsed: step, code: code(step + 1), wantStep: step + 1, wantOK: true},
+		{name: "形式不正", code: "12345a", wantOK: false},
+		{name: "桁数不正", code: "12345", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMFA("1", rfc6238Secret, now, tt.lastUsed, now)
+			gotStep, gotOK := m.VerifyCode(tt.code, now)
+			if gotStep != tt.wantStep || gotOK != tt.wantOK {
+				t.Errorf("戻り値不一致 got: (%d, %t) want: (%d, %t)", gotStep, gotOK, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
+
+func Test_mfa_URI(t *testing.T) {
+	m := NewMFA("1", "JBSWY3DPEHPK3PXP", time.Time{}, 0, time.Time{})
+	want := "otpauth://totp/GoBBS:alice@example.com?algorithm=SHA1&digits=6&issuer=GoBBS&period=30&secret=JBSWY3DPEHPK3PXP"
+	if got := m.URI("GoBBS", "alice@example.com"); got != want {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
+	}
+}
+
+func TestEncodeTOTPSecret(t *testing.T) {
+	if got := EncodeTOTPSecret([]byte("12345678901234567890")); got != rfc6238Secret {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, rfc6238Secret)
+	}
+}
+
+func TestHashMFAToken(t *testing.T) {
+	want := "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	if got := HashMFAToken("hello"); got != want {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
+	}
+}
+
+func Test_mfaChallenge_Getters(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	expires := now.Add(5 * time.Minute)
+	c := NewMFAChallenge("hash", "1", 2, expires, now)
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{name: "ID", got: c.ID(), want: "hash"},
+		{name: "UserID", got: c.UserID(), want: "1"},
+		{name: "Attempts", got: c.Attempts(), want: 2},
+		{name: "ExpiresAt", got: c.ExpiresAt(), want: expires},
+		{name: "CreatedAt", got: c.CreatedAt(), want: now},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_mfaChallenge_IsValid(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		challenge MFAChallenge
+		want      bool
+	}{
+		{name: "有効", challenge: NewMFAChallenge("hash", "1", MaxMFAChallengeAttempts-1, now.Add(time.Second), now), want: true},
+		{name: "有効期限切れ", challenge: NewMFAChallenge("hash", "1", 0, now, now), want: false},
+		{name: "試行回数超過", challenge: NewMFAChallenge("hash", "1", MaxMFAChallengeAttempts, now.Add(time.Minute), now), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.challenge.IsValid(now); got != tt.want {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package model
+
+import "time"
+
+type (
+	// Notification 通知
+	// mockgen -source domain/model/notification_model.go -destination mock/mock_model/notification_model_mock.go
+	Notification interface {
+		ID() string
+		UserID() string
+		Kind() NotificationKind
+		ActorID() string
+		TargetID() string
+		ReadAt() time.Time
+		IsRead() bool
+		CreatedAt() time.Time
+	}
+
+	// NotificationKind 通知の種類
+	NotificationKind string
+
+	// notification 通知
+	notification struct {
+		id        string
+		userID    string
+		kind      NotificationKind
+		actorID   string
+		targetID  string
+		readAt    time.Time
+		createdAt time.Time
+	}
+
+	// NotificationPreferences 通知の種類ごとの受信設定
+	NotificationPreferences struct {
+		Reply   bool
+		Quote   bool
+		Mention bool
+	}
+)
+
+const (
+	// NotificationReply 自分のスレッドへの返信
+	NotificationReply NotificationKind = "reply"
+	// NotificationQuote 自分の投稿の引用
+	NotificationQuote NotificationKind = "quote"
+	// NotificationMention @メンション
+	NotificationMention NotificationKind = "mention"
+)
+
+// NewNotification 通知を生成する(未読の場合readAtはゼロ値)
+func NewNotification(
+	id string,
+	userID string,
+	kind NotificationKind,
+	actorID string,
+	targetID string,
+	readAt time.Time,
+	createdAt time.Time) Notification {
+	return &notification{
+		id:        id,
+		userID:    userID,
+		kind:      kind,
+		actorID:   actorID,
+		targetID:  targetID,
+		readAt:    readAt,
+		createdAt: createdAt,
+	}
+}
+
+// ID IDを返す
+func (n *notification) ID() string {
+	return n.id
+}
+
+// UserID 通知先のユーザーのIDを返す
+func (n *notification) UserID() string {
+	return n.userID
+}
+
+// Kind 通知の種類を返す
+func (n *notification) Kind() NotificationKind {
+	return n.kind
+}
+
+// ActorID 通知の契機となったユーザーのIDを返す
+func (n *notification) ActorID() string {
+	return n.actorID
+}
+
+// TargetID 通知対象(投稿など)のIDを返す
+func (n *notification) TargetID() string {
+	return n.targetID
+}
+
+// ReadAt 既読日時を返す
+func (n *notification) ReadAt() time.Time {
+	return n.readAt
+}
+
+// IsRead 既読か判定する
+func (n *notification) IsRead() bool {
+	return !n.readAt.IsZero()
+}
+
+// CreatedAt 通知日時を返す
+func (n *notification) CreatedAt() time.Time {
+	return n.createdAt
+}
+
+// IsValid 通知の種類が定義済みか判定する
+func (k NotificationKind) IsValid() bool {
+	switch k {
+	case NotificationReply, NotificationQuote, NotificationMention:
+		return true
+	}
+	return false
+}
+
+// DefaultNotificationPreferences すべての通知を受け取る受信設定を返す
+func DefaultNotificationPreferences() NotificationPreferences {
+	return NotificationPreferences{Reply: true, Quote: true, Mention: true}
+}
+
+// Allows 通知の種類を受け取る設定か判定する
+func (p NotificationPreferences) Allows(kind NotificationKind) bool {
+	switch kind {
+	case NotificationReply:
+		return p.Reply
+	case NotificationQuote:
+		return p.Quote
+	case NotificationMention:
+		return p.Mention
+	}
+	return false
+}
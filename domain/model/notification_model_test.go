@@ -0,0 +1,96 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewNotification(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	got := NewNotification("1", "2", NotificationReply, "3", "4", time.Time{}, now)
+	want := &notification{
+		id:        "1",
+		userID:    "2",
+		kind:      NotificationReply,
+		actorID:   "3",
+		targetID:  "4",
+		createdAt: now,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewNotification() = %v, want %v", got, want)
+	}
+}
+
+func Test_notification_Getters(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	readAt := now.Add(time.Hour)
+	n := NewNotification("1", "2", NotificationMention, "3", "4", readAt, now)
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{name: "ID", got: n.ID(), want: "1"},
+		{name: "UserID", got: n.UserID(), want: "2"},
+		{name: "Kind", got: n.Kind(), want: NotificationMention},
+		{name: "ActorID", got: n.ActorID(), want: "3"},
+		{name: "TargetID", got: n.TargetID(), want: "4"},
+		{name: "ReadAt", got: n.ReadAt(), want: readAt},
+		{name: "IsRead", got: n.IsRead(), want: true},
+		{name: "IsRead(未読)", got: NewNotification("1", "2", NotificationMention, "3", "4", time.Time{}, now).IsRead(), want: false},
+		{name: "CreatedAt", got: n.CreatedAt(), want: now},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotificationKind_IsValid(t *testing.T) {
+	tests := []struct {
+		kind NotificationKind
+		want bool
+	}{
+		{kind: NotificationReply, want: true},
+		{kind: NotificationQuote, want: true},
+		{kind: NotificationMention, want: true},
+		{kind: "like", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.kind), func(t *testing.T) {
+			if got := tt.kind.IsValid(); got != tt.want {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotificationPreferences_Allows(t *testing.T) {
+	prefs := NotificationPreferences{Reply: true, Quote: false, Mention: true}
+
+	tests := []struct {
+		kind NotificationKind
+		want bool
+	}{
+		{kind: NotificationReply, want: true},
+		{kind: NotificationQuote, want: false},
+		{kind: NotificationMention, want: true},
+		{kind: "like", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.kind), func(t *testing.T) {
+			if got := prefs.Allows(tt.kind); got != tt.want {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+	if got := DefaultNotificationPreferences(); got != (NotificationPreferences{Reply: true, Quote: true, Mention: true}) {
+		t.Errorf("戻り値不一致 got: %#v", got)
+	}
+}
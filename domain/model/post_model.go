@@ -0,0 +1,122 @@
+package model
+
+import "time"
+
+type (
+	// Post 投稿
+	// mockgen -source domain/model/post_model.go -destination mock/mock_model/post_model_mock.go
+	Post interface {
+		ID() string
+		ThreadID() string
+		UserID() string
+		Number() int
+		Body() string
+		BodyHTML() string
+		CreatedAt() time.Time
+		EditedAt() time.Time
+		IsEdited() bool
+		HiddenAt() time.Time
+		HiddenReason() string
+		IsHidden() bool
+	}
+
+	// post 投稿
+	post struct {
+		id           string
+		threadID     string
+		userID       string
+		number       int
+		body         string
+		bodyHTML     string
+		createdAt    time.Time
+		editedAt     time.Time
+		hiddenAt     time.Time
+		hiddenReason string
+	}
+)
+
+// NewPost 投稿を生成する
+func NewPost(
+	id string,
+	threadID string,
+	userID string,
+	number int,
+	body string,
+	bodyHTML string,
+	createdAt time.Time,
+	editedAt time.Time,
+	hiddenAt time.Time,
+	hiddenReason string) Post {
+	return &post{
+		id:           id,
+		threadID:     threadID,
+		userID:       userID,
+		number:       number,
+		body:         body,
+		bodyHTML:     bodyHTML,
+		createdAt:    createdAt,
+		editedAt:     editedAt,
+		hiddenAt:     hiddenAt,
+		hiddenReason: hiddenReason,
+	}
+}
+
+// ID IDを返す
+func (p *post) ID() string {
+	return p.id
+}
+
+// ThreadID 投稿のあるスレッドのIDを返す
+func (p *post) ThreadID() string {
+	return p.threadID
+}
+
+// UserID 投稿したユーザーのIDを返す(退会済みの場合は空)
+func (p *post) UserID() string {
+	return p.userID
+}
+
+// Number スレッド内の投稿番号(1始まり)を返す
+func (p *post) Number() int {
+	return p.number
+}
+
+// Body 本文(マークダウン)を返す
+func (p *post) Body() string {
+	return p.body
+}
+
+// BodyHTML 本文をレンダリングしたサニタイズ済みのHTMLを返す
+func (p *post) BodyHTML() string {
+	return p.bodyHTML
+}
+
+// CreatedAt 投稿日時を返す
+func (p *post) CreatedAt() time.Time {
+	return p.createdAt
+}
+
+// EditedAt 最後に編集した日時を返す(未編集の場合はゼロ値)
+func (p *post) EditedAt() time.Time {
+	return p.editedAt
+}
+
+// IsEdited 投稿後に編集されたか返す
+func (p *post) IsEdited() bool {
+	return !p.editedAt.IsZero()
+}
+
+// HiddenAt モデレーターが非表示にした日時を返す(表示中の場合はゼロ値)
+func (p *post) HiddenAt() time.Time {
+	return p.hiddenAt
+}
+
+// HiddenReason 非表示にした理由を返す
+func (p *post) HiddenReason() string {
+	return p.hiddenReason
+}
+
+// IsHidden モデレーターに非表示にされたか返す
+func (p *post) IsHidden() bool {
+	return !p.hiddenAt.IsZero()
+}
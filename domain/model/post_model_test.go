@@ -0,0 +1,67 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewPost(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	edited := now.Add(time.Minute)
+	hidden := now.Add(time.Hour)
+
+	got := NewPost("1", "2", "3", 4, "body", "<p>body</p>\n", now, edited, hidden, "spam")
+	want := &post{
+		id:           "1",
+		threadID:     "2",
+		userID:       "3",
+		number:       4,
+		body:         "body",
+		bodyHTML:     "<p>body</p>\n",
+		createdAt:    now,
+		editedAt:     edited,
+		hiddenAt:     hidden,
+		hiddenReason: "spam",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewPost() = %v, want %v", got, want)
+	}
+}
+
+func Test_post_Getters(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	edited := now.Add(time.Minute)
+	hidden := now.Add(time.Hour)
+	p := NewPost("1", "2", "3", 4, "body", "<p>body</p>\n", now, edited, hidden, "spam")
+	unedited := NewPost("1", "2", "3", 4, "body", "<p>body</p>\n", now, time.Time{}, time.Time{}, "")
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{name: "ID", got: p.ID(), want: "1"},
+		{name: "ThreadID", got: p.ThreadID(), want: "2"},
+		{name: "UserID", got: p.UserID(), want: "3"},
+		{name: "Number", got: p.Number(), want: 4},
+		{name: "Body", got: p.Body(), want: "body"},
+		{name: "BodyHTML", got: p.BodyHTML(), want: "<p>body</p>\n"},
+		{name: "CreatedAt", got: p.CreatedAt(), want: now},
+		{name: "EditedAt", got: p.EditedAt(), want: edited},
+		{name: "IsEdited(編集済み)", got: p.IsEdited(), want: true},
+		{name: "IsEdited(未編集)", got: unedited.IsEdited(), want: false},
+		{name: "HiddenAt", got: p.HiddenAt(), want: hidden},
+		{name: "HiddenReason", got: p.HiddenReason(), want: "spam"},
+		{name: "IsHidden(非表示)", got: p.IsHidden(), want: true},
+		{name: "IsHidden(表示中)", got: unedited.IsHidden(), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", tt.got, tt.want)
+			}
+		})
+	}
+}
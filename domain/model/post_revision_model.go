@@ -0,0 +1,60 @@
+package model
+
+import "time"
+
+type (
+	// PostRevision 投稿の版(投稿時と編集ごとの本文)
+	// mockgen -source domain/model/post_revision_model.go -destination mock/mock_model/post_revision_model_mock.go
+	PostRevision interface {
+		ID() string
+		PostID() string
+		EditorID() string
+		Body() string
+		CreatedAt() time.Time
+	}
+
+	// postRevision 投稿の版
+	postRevision struct {
+		id        string
+		postID    string
+		editorID  string
+		body      string
+		createdAt time.Time
+	}
+)
+
+// NewPostRevision 投稿の版を生成する
+func NewPostRevision(id string, postID string, editorID string, body string, createdAt time.Time) PostRevision {
+	return &postRevision{
+		id:        id,
+		postID:    postID,
+		editorID:  editorID,
+		body:      body,
+		createdAt: createdAt,
+	}
+}
+
+// ID IDを返す
+func (r *postRevision) ID() string {
+	return r.id
+}
+
+// PostID 版の投稿のIDを返す
+func (r *postRevision) PostID() string {
+	return r.postID
+}
+
+// EditorID 版を作成したユーザーのIDを返す(退会済みの場合は空)
+func (r *postRevision) EditorID() string {
+	return r.editorID
+}
+
+// Body 版の本文(マークダウン)を返す
+func (r *postRevision) Body() string {
+	return r.body
+}
+
+// CreatedAt 版を作成した日時を返す
+func (r *postRevision) CreatedAt() time.Time {
+	return r.createdAt
+}
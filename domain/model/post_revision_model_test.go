@@ -0,0 +1,47 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewPostRevision(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	got := NewPostRevision("1", "2", "3", "body", now)
+	want := &postRevision{
+		id:        "1",
+		postID:    "2",
+		editorID:  "3",
+		body:      "body",
+		createdAt: now,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewPostRevision() = %v, want %v", got, want)
+	}
+}
+
+func Test_postRevision_Getters(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	r := NewPostRevision("1", "2", "3", "body", now)
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{name: "ID", got: r.ID(), want: "1"},
+		{name: "PostID", got: r.PostID(), want: "2"},
+		{name: "EditorID", got: r.EditorID(), want: "3"},
+		{name: "Body", got: r.Body(), want: "body"},
+		{name: "CreatedAt", got: r.CreatedAt(), want: now},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", tt.got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package model
+
+import "time"
+
+type (
+	// Session 発行したトークンごとのログインセッション
+	// mockgen -source domain/model/session_model.go -destination mock/mock_model/session_model_mock.go
+	Session interface {
+		ID() string
+		UserID() string
+		Device() string
+		IP() string
+		UserAgent() string
+		CreatedAt() time.Time
+		LastSeenAt() time.Time
+		ExpiresAt() time.Time
+		RevokedAt() time.Time
+		IsActive(now time.Time) bool
+	}
+
+	// session ログインセッション
+	session struct {
+		id         string
+		userID     string
+		device     string
+		ip         string
+		userAgent  string
+		createdAt  time.Time
+		lastSeenAt time.Time
+		expiresAt  time.Time
+		revokedAt  time.Time
+	}
+)
+
+// NewSession ログインセッションを生成する(失効していない場合revokedAtはゼロ値)
+func NewSession(
+	id string,
+	userID string,
+	device string,
+	ip string,
+	userAgent string,
+	createdAt time.Time,
+	lastSeenAt time.Time,
+	expiresAt time.Time,
+	revokedAt time.Time) Session {
+	return &session{
+		id:         id,
+		userID:     userID,
+		device:     device,
+		ip:         ip,
+		userAgent:  userAgent,
+		createdAt:  createdAt,
+		lastSeenAt: lastSeenAt,
+		expiresAt:  expiresAt,
+		revokedAt:  revokedAt,
+	}
+}
+
+// ID ID(トークンのjti)を返す
+func (s *session) ID() string {
+	return s.id
+}
+
+// UserID ユーザーIDを返す
+func (s *session) UserID() string {
+	return s.userID
+}
+
+// Device User-Agentから判定した端末名を返す
+func (s *session) Device() string {
+	return s.device
+}
+
+// IP ログイン元のIPアドレスを返す
+func (s *session) IP() string {
+	return s.ip
+}
+
+// UserAgent ログイン時のUser-Agentを返す
+func (s *session) UserAgent() string {
+	return s.userAgent
+}
+
+// CreatedAt ログイン日時を返す
+func (s *session) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+// LastSeenAt 最後に認証に使われた日時を返す
+func (s *session) LastSeenAt() time.Time {
+	return s.lastSeenAt
+}
+
+// ExpiresAt トークンの有効期限を返す
+func (s *session) ExpiresAt() time.Time {
+	return s.expiresAt
+}
+
+// RevokedAt 失効した日時を返す
+func (s *session) RevokedAt() time.Time {
+	return s.revokedAt
+}
+
+// IsActive 失効しておらず、有効期限内か判定する
+func (s *session) IsActive(now time.Time) bool {
+	return s.revokedAt.IsZero() && now.Before(s.expiresAt)
+}
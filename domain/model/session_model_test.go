@@ -0,0 +1,70 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_session_Getters(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	lastSeen := now.Add(time.Minute)
+	expires := now.Add(time.Hour)
+	s := NewSession("sid", "1", "Firefox on Linux", "192.0.2.1", "Mozilla/5.0", now, lastSeen, expires, time.Time{})
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{name: "ID", got: s.ID(), want: "sid"},
+		{name: "UserID", got: s.UserID(), want: "1"},
+		{name: "Device", got: s.Device(), want: "Firefox on Linux"},
+		{name: "IP", got: s.IP(), want: "192.0.2.1"},
+		{name: "UserAgent", got: s.UserAgent(), want: "Mozilla/5.0"},
+		{name: "CreatedAt", got: s.CreatedAt(), want: now},
+		{name: "LastSeenAt", got: s.LastSeenAt(), want: lastSeen},
+		{name: "ExpiresAt", got: s.ExpiresAt(), want: expires},
+		{name: "RevokedAt", got: s.RevokedAt(), want: time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_session_IsActive(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		session Session
+		want    bool
+	}{
+		{
+			name:    "有効",
+			session: NewSession("sid", "1", "", "", "", now, now, now.Add(time.Second), time.Time{}),
+			want:    true,
+		},
+		{
+			name:    "有効期限切れ",
+			session: NewSession("sid", "1", "", "", "", now, now, now, time.Time{}),
+			want:    false,
+		},
+		{
+			name:    "失効済み",
+			session: NewSession("sid", "1", "", "", "", now, now, now.Add(time.Hour), now),
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.session.IsActive(now); got != tt.want {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package model
+
+import "time"
+
+type (
+	// Thread スレッド
+	// mockgen -source domain/model/thread_model.go -destination mock/mock_model/thread_model_mock.go
+	Thread interface {
+		ID() string
+		BoardID() string
+		UserID() string
+		Title() string
+		PostCount() int
+		LastPostedAt() time.Time
+		CreatedAt() time.Time
+	}
+
+	// thread スレッド
+	thread struct {
+		id           string
+		boardID      string
+		userID       string
+		title        string
+		postCount    int
+		lastPostedAt time.Time
+		createdAt    time.Time
+	}
+)
+
+// NewThread スレッドを生成する
+func NewThread(
+	id string,
+	boardID string,
+	userID string,
+	title string,
+	postCount int,
+	lastPostedAt time.Time,
+	createdAt time.Time) Thread {
+	return &thread{
+		id:           id,
+		boardID:      boardID,
+		userID:       userID,
+		title:        title,
+		postCount:    postCount,
+		lastPostedAt: lastPostedAt,
+		createdAt:    createdAt,
+	}
+}
+
+// ID IDを返す
+func (t *thread) ID() string {
+	return t.id
+}
+
+// BoardID スレッドのある板のIDを返す
+func (t *thread) BoardID() string {
+	return t.boardID
+}
+
+// UserID スレッドを作成したユーザーのIDを返す(退会済みの場合は空)
+func (t *thread) UserID() string {
+	return t.userID
+}
+
+// Title タイトルを返す
+func (t *thread) Title() string {
+	return t.title
+}
+
+// PostCount 投稿数(最後の投稿の番号)を返す
+func (t *thread) PostCount() int {
+	return t.postCount
+}
+
+// LastPostedAt 最後の投稿日時を返す
+func (t *thread) LastPostedAt() time.Time {
+	return t.lastPostedAt
+}
+
+// CreatedAt 作成日時を返す
+func (t *thread) CreatedAt() time.Time {
+	return t.createdAt
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewThread(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	lastPostedAt := now.Add(time.Hour)
+
+	got := NewThread("1", "2", "3", "title", 4, lastPostedAt, now)
+	want := &thread{
+		id:           "1",
+		boardID:      "2",
+		userID:       "3",
+		title:        "title",
+		postCount:    4,
+		lastPostedAt: lastPostedAt,
+		createdAt:    now,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewThread() = %v, want %v", got, want)
+	}
+}
+
+func Test_thread_Getters(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	lastPostedAt := now.Add(time.Hour)
+	th := NewThread("1", "2", "3", "title", 4, lastPostedAt, now)
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{name: "ID", got: th.ID(), want: "1"},
+		{name: "BoardID", got: th.BoardID(), want: "2"},
+		{name: "UserID", got: th.UserID(), want: "3"},
+		{name: "Title", got: th.Title(), want: "title"},
+		{name: "PostCount", got: th.PostCount(), want: 4},
+		{name: "LastPostedAt", got: th.LastPostedAt(), want: lastPostedAt},
+		{name: "CreatedAt", got: th.CreatedAt(), want: now},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", tt.got, tt.want)
+			}
+		})
+	}
+}
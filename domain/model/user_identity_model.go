@@ -0,0 +1,60 @@
+package model
+
+import "time"
+
+type (
+	// UserIdentity ユーザーに紐づけた外部IdP(OpenID Connect)のアカウント
+	// mockgen -source domain/model/user_identity_model.go -destination mock/mock_model/user_identity_model_mock.go
+	UserIdentity interface {
+		Issuer() string
+		Subject() string
+		UserID() string
+		Email() string
+		CreatedAt() time.Time
+	}
+
+	// userIdentity 外部IdPのアカウント
+	userIdentity struct {
+		issuer    string
+		subject   string
+		userID    string
+		email     string
+		createdAt time.Time
+	}
+)
+
+// NewUserIdentity 外部IdPのアカウントを生成する
+func NewUserIdentity(issuer string, subject string, userID string, email string, createdAt time.Time) UserIdentity {
+	return &userIdentity{
+		issuer:    issuer,
+		subject:   subject,
+		userID:    userID,
+		email:     email,
+		createdAt: createdAt,
+	}
+}
+
+// Issuer IdPの発行者URLを返す
+func (i *userIdentity) Issuer() string {
+	return i.issuer
+}
+
+// Subject IdP内でアカウントを識別するID(sub)を返す
+func (i *userIdentity) Subject() string {
+	return i.subject
+}
+
+// UserID 紐づけたユーザーのIDを返す
+func (i *userIdentity) UserID() string {
+	return i.userID
+}
+
+// Email 紐づけた時点のIdPのメールアドレスを返す
+func (i *userIdentity) Email() string {
+	return i.email
+}
+
+// CreatedAt 紐づけた日時を返す
+func (i *userIdentity) CreatedAt() time.Time {
+	return i.createdAt
+}
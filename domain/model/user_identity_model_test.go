@@ -0,0 +1,31 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_userIdentity_Getters(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	i := NewUserIdentity("https://idp.example.com", "sub-1", "1", "a@example.com", now)
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{name: "Issuer", got: i.Issuer(), want: "https://idp.example.com"},
+		{name: "Subject", got: i.Subject(), want: "sub-1"},
+		{name: "UserID", got: i.UserID(), want: "1"},
+		{name: "Email", got: i.Email(), want: "a@example.com"},
+		{name: "CreatedAt", got: i.CreatedAt(), want: now},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", tt.got, tt.want)
+			}
+		})
+	}
+}
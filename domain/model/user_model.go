@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"math/big"
+	"time"
 
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/scrypt"
@@ -18,6 +19,7 @@ type (
 		Email() string
 		Password() string
 		Salt() string
+		Profile() Profile
 		VerifyPassword(string) (bool, error)
 		EncryptPassword() (string, string, error)
 	}
@@ -29,17 +31,43 @@ type (
 		email    string
 		password string
 		salt     string
+		profile  Profile
+	}
+
+	// Profile ユーザープロフィール
+	Profile struct {
+		DisplayName string
+		Bio         string
+		AvatarURL   string
+		Signature   string
+		JoinedAt    time.Time
+	}
+
+	// UserPatch ユーザーの部分更新(nilの項目は現在の値のまま)
+	UserPatch struct {
+		Name        *string
+		Password    *string
+		DisplayName *string
+		Bio         *string
+		AvatarURL   *string
+		Signature   *string
 	}
 )
 
 // NewUser ユーザーを生成する
 func NewUser(id string, name string, email string, password string, salt string) User {
+	return NewUserWithProfile(id, name, email, password, salt, Profile{})
+}
+
+// NewUserWithProfile プロフィール付きのユーザーを生成する
+func NewUserWithProfile(id string, name string, email string, password string, salt string, profile Profile) User {
 	return &user{
 		id:       id,
 		name:     name,
 		email:    email,
 		password: password,
 		salt:     salt,
+		profile:  profile,
 	}
 }
 
@@ -71,6 +99,11 @@ func (u *user) Salt() string {
 	return u.salt
 }
 
+// Profile プロフィールを返す
+func (u *user) Profile() Profile {
+	return u.profile
+}
+
 // VerifyPassword パスワードを検証する
 func (u *user) VerifyPassword(password string) (bool, error) {
 	cryptPw, err := u.encryptPassword(password, u.salt)
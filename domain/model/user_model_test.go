@@ -3,6 +3,7 @@ package model
 import (
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestUser_ID(t *testing.T) {
@@ -179,6 +180,63 @@ func TestNewUser(t *testing.T) {
 	}
 }
 
+func TestNewUserWithProfile(t *testing.T) {
+	joinedAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	type args struct {
+		id       string
+		name     string
+		email    string
+		password string
+		salt     string
+		profile  Profile
+	}
+	tests := []struct {
+		name string
+		args args
+		want *user
+	}{
+		{
+			name: "正常ケース",
+			args: args{
+				id:       "id",
+				name:     "name",
+				email:    "email",
+				password: "password",
+				salt:     "salt",
+				profile: Profile{
+					DisplayName: "display",
+					Bio:         "bio",
+					AvatarURL:   "https://example.com/a.png",
+					Signature:   "signature",
+					JoinedAt:    joinedAt,
+				},
+			},
+			want: &user{
+				id:       "id",
+				name:     "name",
+				email:    "email",
+				password: "password",
+				salt:     "salt",
+				profile: Profile{
+					DisplayName: "display",
+					Bio:         "bio",
+					AvatarURL:   "https://example.com/a.png",
+					Signature:   "signature",
+					JoinedAt:    joinedAt,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewUserWithProfile(tt.args.id, tt.args.name, tt.args.email, tt.args.password, tt.args.salt, tt.args.profile); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewUserWithProfile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestUser_generateSalt(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -306,3 +364,26 @@ func Test_user_Salt(t *testing.T) {
 		})
 	}
 }
+
+func Test_user_Profile(t *testing.T) {
+	tests := []struct {
+		name string
+		u    *user
+		want Profile
+	}{
+		{
+			name: "正常ケース",
+			u: &user{
+				profile: Profile{DisplayName: "a", Bio: "b"},
+			},
+			want: Profile{DisplayName: "a", Bio: "b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.u.Profile(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("user.Profile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package model
+
+import "time"
+
+type (
+	// Watch スレッド・板のウォッチ
+	// mockgen -source domain/model/watch_model.go -destination mock/mock_model/watch_model_mock.go
+	Watch interface {
+		UserID() string
+		Kind() WatchKind
+		TargetID() string
+		CreatedAt() time.Time
+	}
+
+	// WatchKind ウォッチ対象の種類
+	WatchKind string
+
+	// watch スレッド・板のウォッチ
+	watch struct {
+		userID    string
+		kind      WatchKind
+		targetID  string
+		createdAt time.Time
+	}
+)
+
+const (
+	// WatchThread スレッドのウォッチ
+	WatchThread WatchKind = "thread"
+	// WatchBoard 板のウォッチ
+	WatchBoard WatchKind = "board"
+)
+
+// NewWatch ウォッチを生成する
+func NewWatch(userID string, kind WatchKind, targetID string, createdAt time.Time) Watch {
+	return &watch{
+		userID:    userID,
+		kind:      kind,
+		targetID:  targetID,
+		createdAt: createdAt,
+	}
+}
+
+// UserID ウォッチしているユーザーのIDを返す
+func (w *watch) UserID() string {
+	return w.userID
+}
+
+// Kind ウォッチ対象の種類を返す
+func (w *watch) Kind() WatchKind {
+	return w.kind
+}
+
+// TargetID ウォッチ対象(スレッド・板)のIDを返す
+func (w *watch) TargetID() string {
+	return w.targetID
+}
+
+// CreatedAt ウォッチ開始日時を返す
+func (w *watch) CreatedAt() time.Time {
+	return w.createdAt
+}
+
+// IsValid ウォッチ対象の種類が定義済みか判定する
+func (k WatchKind) IsValid() bool {
+	switch k {
+	case WatchThread, WatchBoard:
+		return true
+	}
+	return false
+}
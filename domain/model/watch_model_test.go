@@ -0,0 +1,48 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_watch_Getters(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	w := NewWatch("1", WatchThread, "10", now)
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{name: "UserID", got: w.UserID(), want: "1"},
+		{name: "Kind", got: w.Kind(), want: WatchThread},
+		{name: "TargetID", got: w.TargetID(), want: "10"},
+		{name: "CreatedAt", got: w.CreatedAt(), want: now},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWatchKind_IsValid(t *testing.T) {
+	tests := []struct {
+		kind WatchKind
+		want bool
+	}{
+		{kind: WatchThread, want: true},
+		{kind: WatchBoard, want: true},
+		{kind: "user", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.kind), func(t *testing.T) {
+			if got := tt.kind.IsValid(); got != tt.want {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package model
+
+import "time"
+
+type (
+	// Webhook 板ごとのWebhook
+	// mockgen -source domain/model/webhook_model.go -destination mock/mock_model/webhook_model_mock.go
+	Webhook interface {
+		ID() string
+		BoardID() string
+		URL() string
+		Secret() string
+		Events() []WebhookEvent
+		CreatedAt() time.Time
+		Subscribes(event WebhookEvent) bool
+	}
+
+	// WebhookDelivery Webhookの配信(送信待ちのアウトボックスと配信ログを兼ねる)
+	WebhookDelivery interface {
+		ID() string
+		WebhookID() string
+		Event() WebhookEvent
+		Payload() []byte
+		Status() WebhookDeliveryStatus
+		Attempts() int
+		NextAttemptAt() time.Time
+		LastStatusCode() int
+		LastError() string
+		CreatedAt() time.Time
+		DeliveredAt() time.Time
+	}
+
+	// WebhookEvent Webhookで通知するイベント
+	WebhookEvent string
+
+	// WebhookDeliveryStatus Webhookの配信状態
+	WebhookDeliveryStatus string
+
+	// webhook 板ごとのWebhook
+	webhook struct {
+		id        string
+		boardID   string
+		url       string
+		secret    string
+		events    []WebhookEvent
+		createdAt time.Time
+	}
+
+	// webhookDelivery Webhookの配信
+	webhookDelivery struct {
+		id             string
+		webhookID      string
+		event          WebhookEvent
+		payload        []byte
+		status         WebhookDeliveryStatus
+		attempts       int
+		nextAttemptAt  time.Time
+		lastStatusCode int
+		lastError      string
+		createdAt      time.Time
+		deliveredAt    time.Time
+	}
+)
+
+const (
+	// WebhookThreadCreated スレッドの作成
+	WebhookThreadCreated WebhookEvent = "thread.created"
+	// WebhookPostCreated 投稿の作成
+	WebhookPostCreated WebhookEvent = "post.created"
+	// WebhookPostHidden 投稿の非表示
+	WebhookPostHidden WebhookEvent = "post.hidden"
+)
+
+const (
+	// WebhookDeliveryPending 送信待ち(再送待ちを含む)
+	WebhookDeliveryPending WebhookDeliveryStatus = "pending"
+	// WebhookDeliverySucceeded 送信成功
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	// WebhookDeliveryFailed 再送上限に達して送信失敗
+	WebhookDeliveryFailed WebhookDeliveryStatus = "failed"
+)
+
+// NewWebhook Webhookを生成する
+func NewWebhook(
+	id string,
+	boardID string,
+	url string,
+	secret string,
+	events []WebhookEvent,
+	createdAt time.Time) Webhook {
+	return &webhook{
+		id:        id,
+		boardID:   boardID,
+		url:       url,
+		secret:    secret,
+		events:    events,
+		createdAt: createdAt,
+	}
+}
+
+// ID IDを返す
+func (w *webhook) ID() string {
+	return w.id
+}
+
+// BoardID 板のIDを返す
+func (w *webhook) BoardID() string {
+	return w.boardID
+}
+
+// URL 送信先URLを返す
+func (w *webhook) URL() string {
+	return w.url
+}
+
+// Secret 署名に使用する秘密鍵を返す
+func (w *webhook) Secret() string {
+	return w.secret
+}
+
+// Events 通知するイベントを返す
+func (w *webhook) Events() []WebhookEvent {
+	return w.events
+}
+
+// CreatedAt 登録日時を返す
+func (w *webhook) CreatedAt() time.Time {
+	return w.createdAt
+}
+
+// Subscribes イベントを通知する設定か判定する
+func (w *webhook) Subscribes(event WebhookEvent) bool {
+	for _, e := range w.events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValid イベントが定義済みか判定する
+func (e WebhookEvent) IsValid() bool {
+	switch e {
+	case WebhookThreadCreated, WebhookPostCreated, WebhookPostHidden:
+		return true
+	}
+	return false
+}
+
+// NewWebhookDelivery Webhookの配信を生成する(未送信の場合deliveredAtはゼロ値)
+func NewWebhookDelivery(
+	id string,
+	webhookID string,
+	event WebhookEvent,
+	payload []byte,
+	status WebhookDeliveryStatus,
+	attempts int,
+	nextAttemptAt time.Time,
+	lastStatusCode int,
+	lastError string,
+	createdAt time.Time,
+	deliveredAt time.Time) WebhookDelivery {
+	return &webhookDelivery{
+		id:             id,
+		webhookID:      webhookID,
+		event:          event,
+		payload:        payload,
+		status:         status,
+		attempts:       attempts,
+		nextAttemptAt:  nextAttemptAt,
+		lastStatusCode: lastStatusCode,
+		lastError:      lastError,
+		createdAt:      createdAt,
+		deliveredAt:    deliveredAt,
+	}
+}
+
+// ID IDを返す
+func (d *webhookDelivery) ID() string {
+	return d.id
+}
+
+// WebhookID WebhookのIDを返す
+func (d *webhookDelivery) WebhookID() string {
+	return d.webhookID
+}
+
+// Event イベントを返す
+func (d *webhookDelivery) Event() WebhookEvent {
+	return d.event
+}
+
+// Payload 送信するJSONを返す
+func (d *webhookDelivery) Payload() []byte {
+	return d.payload
+}
+
+// Status 配信状態を返す
+func (d *webhookDelivery) Status() WebhookDeliveryStatus {
+	return d.status
+}
+
+// Attempts 送信を試行した回数を返す
+func (d *webhookDelivery) Attempts() int {
+	return d.attempts
+}
+
+// NextAttemptAt 次に送信を試行する日時を返す
+func (d *webhookDelivery) NextAttemptAt() time.Time {
+	return d.nextAttemptAt
+}
+
+// LastStatusCode 最後の試行のHTTPステータスコードを返す(応答がない場合は0)
+func (d *webhookDelivery) LastStatusCode() int {
+	return d.lastStatusCode
+}
+
+// LastError 最後の試行のエラーを返す
+func (d *webhookDelivery) LastError() string {
+	return d.lastError
+}
+
+// CreatedAt イベントの発生日時を返す
+func (d *webhookDelivery) CreatedAt() time.Time {
+	return d.createdAt
+}
+
+// DeliveredAt 送信に成功した日時を返す
+func (d *webhookDelivery) DeliveredAt() time.Time {
+	return d.deliveredAt
+}
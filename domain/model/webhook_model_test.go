@@ -0,0 +1,86 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_webhook_Getters(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	events := []WebhookEvent{WebhookThreadCreated, WebhookPostHidden}
+	w := NewWebhook("1", "2", "https://example.com/hook", "secret", events, now)
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{name: "ID", got: w.ID(), want: "1"},
+		{name: "BoardID", got: w.BoardID(), want: "2"},
+		{name: "URL", got: w.URL(), want: "https://example.com/hook"},
+		{name: "Secret", got: w.Secret(), want: "secret"},
+		{name: "Events", got: w.Events(), want: events},
+		{name: "CreatedAt", got: w.CreatedAt(), want: now},
+		{name: "Subscribes", got: w.Subscribes(WebhookPostHidden), want: true},
+		{name: "Subscribes(対象外)", got: w.Subscribes(WebhookPostCreated), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhookEvent_IsValid(t *testing.T) {
+	tests := []struct {
+		event WebhookEvent
+		want  bool
+	}{
+		{event: WebhookThreadCreated, want: true},
+		{event: WebhookPostCreated, want: true},
+		{event: WebhookPostHidden, want: true},
+		{event: "user.created", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.event), func(t *testing.T) {
+			if got := tt.event.IsValid(); got != tt.want {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_webhookDelivery_Getters(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	next := now.Add(time.Minute)
+	delivered := now.Add(time.Hour)
+	d := NewWebhookDelivery("1", "2", WebhookPostCreated, []byte("{}"), WebhookDeliverySucceeded, 3, next, 200, "timeout", now, delivered)
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{name: "ID", got: d.ID(), want: "1"},
+		{name: "WebhookID", got: d.WebhookID(), want: "2"},
+		{name: "Event", got: d.Event(), want: WebhookPostCreated},
+		{name: "Payload", got: d.Payload(), want: []byte("{}")},
+		{name: "Status", got: d.Status(), want: WebhookDeliverySucceeded},
+		{name: "Attempts", got: d.Attempts(), want: 3},
+		{name: "NextAttemptAt", got: d.NextAttemptAt(), want: next},
+		{name: "LastStatusCode", got: d.LastStatusCode(), want: 200},
+		{name: "LastError", got: d.LastError(), want: "timeout"},
+		{name: "CreatedAt", got: d.CreatedAt(), want: now},
+		{name: "DeliveredAt", got: d.DeliveredAt(), want: delivered},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", tt.got, tt.want)
+			}
+		})
+	}
+}
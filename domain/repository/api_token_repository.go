@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"GoBBS/domain/model"
+	"errors"
+	"time"
+)
+
+var (
+	ErrAPITokenNotFound = errors.New("api token not found")
+)
+
+// APIToken 個人用アクセストークンのリポジトリ
+// mockgen -source domain/repository/api_token_repository.go -destination mock/mock_repository/api_token_repository_mock.go
+type APIToken interface {
+	Regist(token model.APIToken) error
+	FindByHash(hash string) (model.APIToken, error)
+	FindActiveByUserID(userID string, now time.Time) ([]model.APIToken, error)
+	Touch(id string, now time.Time) error
+	Delete(userID string, id string) error
+	DeleteExpired(userID string, now time.Time) error
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"GoBBS/domain/model"
+	"errors"
+	"time"
+)
+
+var (
+	ErrAttachmentNotFound = errors.New("attachment not found")
+)
+
+// Attachment 添付ファイルリポジトリ
+// mockgen -source domain/repository/attachment_repository.go -destination mock/mock_repository/attachment_repository_mock.go
+type Attachment interface {
+	FindByID(id string) (model.Attachment, error)
+	Regist(attachment model.Attachment, now time.Time) (string, error)
+}
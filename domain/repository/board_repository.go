@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"GoBBS/domain/model"
+	"errors"
+	"time"
+)
+
+var (
+	ErrBoardNotFound = errors.New("board not found")
+)
+
+// Board 板リポジトリ
+// mockgen -source domain/repository/board_repository.go -destination mock/mock_repository/board_repository_mock.go
+type Board interface {
+	FindAll() ([]model.Board, error)
+	FindByID(id string) (model.Board, error)
+	Regist(board model.Board, now time.Time) (string, error)
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"GoBBS/domain/model"
+	"errors"
+	"time"
+)
+
+var (
+	ErrWatchNotFound              = errors.New("watch not found")
+	ErrDigestSubscriptionNotFound = errors.New("digest subscription not found")
+)
+
+// Digest ウォッチとダイジェストメール配信のリポジトリ
+// mockgen -source domain/repository/digest_repository.go -destination mock/mock_repository/digest_repository_mock.go
+type Digest interface {
+	RegistWatch(watch model.Watch) error
+	DeleteWatch(userID string, kind model.WatchKind, targetID string) error
+	FindWatchesByUserID(userID string) ([]model.Watch, error)
+	FindSubscription(userID string) (model.DigestSubscription, error)
+	FindSubscriptionByToken(token string) (string, model.DigestSubscription, error)
+	SaveSubscription(userID string, subscription model.DigestSubscription, now time.Time) error
+	FindDueUserIDs(frequency model.DigestFrequency, periodKey string) ([]string, error)
+	RegistDelivery(userID string, periodKey string, now time.Time) (bool, error)
+	DeleteDelivery(userID string, periodKey string) error
+}
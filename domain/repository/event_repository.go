@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"GoBBS/domain/model"
+	"time"
+)
+
+// Event ドメインイベントのアウトボックスのリポジトリ
+// mockgen -source domain/repository/event_repository.go -destination mock/mock_repository/event_repository_mock.go
+type Event interface {
+	Regist(event model.Event) (string, error)
+	FindDue(now time.Time, limit int) ([]model.Event, error)
+	Update(event model.Event) error
+}
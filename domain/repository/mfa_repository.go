@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"GoBBS/domain/model"
+	"errors"
+	"time"
+)
+
+var (
+	ErrMFANotFound          = errors.New("mfa not found")
+	ErrRecoveryCodeNotFound = errors.New("recovery code not found")
+	ErrMFAChallengeNotFound = errors.New("mfa challenge not found")
+	ErrMFACodeUsed          = errors.New("mfa code already used")
+)
+
+// MFA 2要素認証のリポジトリ
+// mockgen -source domain/repository/mfa_repository.go -destination mock/mock_repository/mfa_repository_mock.go
+type MFA interface {
+	FindByUserID(userID string) (model.MFA, error)
+	Save(mfa model.MFA) error
+	UpdateLastUsedStep(userID string, step int64) error
+	Delete(userID string) error
+	ReplaceRecoveryCodes(userID string, hashes []string) error
+	UseRecoveryCode(userID string, hash string, now time.Time) error
+	RegistChallenge(challenge model.MFAChallenge) error
+	FindChallenge(id string) (model.MFAChallenge, error)
+	ConsumeChallengeAttempt(id string, now time.Time) error
+	DeleteChallenge(id string) error
+	DeleteExpiredChallenges(userID string, now time.Time) error
+}
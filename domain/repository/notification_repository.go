@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"GoBBS/domain/model"
+	"errors"
+	"time"
+)
+
+var (
+	ErrNotificationNotFound = errors.New("notification not found")
+)
+
+// Notification 通知リポジトリ
+// mockgen -source domain/repository/notification_repository.go -destination mock/mock_repository/notification_repository_mock.go
+type Notification interface {
+	FindByUserID(userID string, beforeID string, limit int) ([]model.Notification, error)
+	CountUnread(userID string) (int, error)
+	Regist(notification model.Notification, now time.Time) (string, error)
+	MarkRead(userID string, id string, now time.Time) error
+	MarkAllRead(userID string, now time.Time) error
+	FindPreferences(userID string) (model.NotificationPreferences, error)
+	SavePreferences(userID string, preferences model.NotificationPreferences, now time.Time) error
+}
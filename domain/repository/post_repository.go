@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"GoBBS/domain/model"
+	"errors"
+	"time"
+)
+
+var (
+	ErrPostNotFound = errors.New("post not found")
+)
+
+// Post 投稿リポジトリ
+// mockgen -source domain/repository/post_repository.go -destination mock/mock_repository/post_repository_mock.go
+type Post interface {
+	FindByThreadID(threadID string, afterNumber int, limit int) ([]model.Post, error)
+	FindByID(id string) (model.Post, error)
+	CountCreated(threadIDs []string, from time.Time, to time.Time) (int, error)
+	Regist(post model.Post, now time.Time) (string, error)
+	Update(post model.Post, now time.Time) error
+	Hide(id string, reason string, now time.Time) error
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"GoBBS/domain/model"
+	"errors"
+	"time"
+)
+
+var (
+	ErrPostRevisionNotFound = errors.New("post revision not found")
+)
+
+// PostRevision 投稿の版リポジトリ
+// mockgen -source domain/repository/post_revision_repository.go -destination mock/mock_repository/post_revision_repository_mock.go
+type PostRevision interface {
+	FindByPostID(postID string) ([]model.PostRevision, error)
+	FindByID(id string) (model.PostRevision, error)
+	Regist(revision model.PostRevision, now time.Time) (string, error)
+}
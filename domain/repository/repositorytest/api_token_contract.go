@@ -0,0 +1,122 @@
+package repositorytest
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+// APITokenRepositoryFactory テストケースごとに空の個人用アクセストークンリポジトリと登録済みの2ユーザーのIDを返す
+type APITokenRepositoryFactory func(t *testing.T) (repository.APIToken, string, string)
+
+// RunAPITokenContract 個人用アクセストークンリポジトリの契約テストを実行する
+func RunAPITokenContract(t *testing.T, newRepo APITokenRepositoryFactory) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	// regist createdAtに発行し、expiresAtまで有効なトークンを登録する(ハッシュはid + "-hash")
+	regist := func(t *testing.T, repo repository.APIToken, id string, userID string, createdAt time.Time, expiresAt time.Time) {
+		t.Helper()
+		token := model.NewAPIToken(id, userID, "ci-bot", id+"-hash", []string{model.APIScopeRead, model.APIScopePost}, expiresAt, time.Time{}, createdAt)
+		if err := repo.Regist(token); err != nil {
+			t.Fatalf("Regist() error = %v", err)
+		}
+	}
+
+	t.Run("登録したトークンをハッシュで取得できる", func(t *testing.T) {
+		repo, userID, _ := newRepo(t)
+		regist(t, repo, "t1", userID, now, now.Add(time.Hour))
+
+		got, err := repo.FindByHash("t1-hash")
+		if err != nil {
+			t.Fatalf("FindByHash() error = %v", err)
+		}
+		if got.ID() != "t1" ||
+			got.UserID() != userID ||
+			got.Name() != "ci-bot" ||
+			got.Hash() != "t1-hash" ||
+			!reflect.DeepEqual(got.Scopes(), []string{model.APIScopeRead, model.APIScopePost}) ||
+			!got.ExpiresAt().Equal(now.Add(time.Hour)) ||
+			!got.LastUsedAt().IsZero() ||
+			!got.CreatedAt().Equal(now) {
+			t.Errorf("FindByHash() = %+v", got)
+		}
+
+		if _, err := repo.FindByHash("unknown"); errors.Cause(err) != repository.ErrAPITokenNotFound {
+			t.Errorf("FindByHash() error = %v, want %v", err, repository.ErrAPITokenNotFound)
+		}
+	})
+
+	t.Run("有効なトークンだけを新しい順に取得できる", func(t *testing.T) {
+		repo, userID, otherID := newRepo(t)
+		regist(t, repo, "old", userID, now.Add(-time.Hour), now.Add(time.Hour))
+		regist(t, repo, "new", userID, now, now.Add(time.Hour))
+		regist(t, repo, "expired", userID, now.Add(-2*time.Hour), now)
+		regist(t, repo, "other", otherID, now, now.Add(time.Hour))
+
+		got, err := repo.FindActiveByUserID(userID, now)
+		if err != nil {
+			t.Fatalf("FindActiveByUserID() error = %v", err)
+		}
+		if len(got) != 2 || got[0].ID() != "new" || got[1].ID() != "old" {
+			t.Errorf("FindActiveByUserID() = %+v, want ids [new old]", got)
+		}
+	})
+
+	t.Run("最終利用日時を更新できる", func(t *testing.T) {
+		repo, userID, _ := newRepo(t)
+		regist(t, repo, "t1", userID, now, now.Add(time.Hour))
+		if err := repo.Touch("t1", now.Add(time.Minute)); err != nil {
+			t.Fatalf("Touch() error = %v", err)
+		}
+
+		got, err := repo.FindByHash("t1-hash")
+		if err != nil {
+			t.Fatalf("FindByHash() error = %v", err)
+		}
+		if !got.LastUsedAt().Equal(now.Add(time.Minute)) || !got.CreatedAt().Equal(now) {
+			t.Errorf("FindByHash() = %+v", got)
+		}
+	})
+
+	t.Run("本人のトークンだけを削除できる", func(t *testing.T) {
+		repo, userID, otherID := newRepo(t)
+		regist(t, repo, "t1", userID, now, now.Add(time.Hour))
+
+		if err := repo.Delete(otherID, "t1"); errors.Cause(err) != repository.ErrAPITokenNotFound {
+			t.Errorf("Delete() error = %v, want %v", err, repository.ErrAPITokenNotFound)
+		}
+		if err := repo.Delete(userID, "t1"); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if err := repo.Delete(userID, "t1"); errors.Cause(err) != repository.ErrAPITokenNotFound {
+			t.Errorf("Delete() error = %v, want %v", err, repository.ErrAPITokenNotFound)
+		}
+		if _, err := repo.FindByHash("t1-hash"); errors.Cause(err) != repository.ErrAPITokenNotFound {
+			t.Errorf("FindByHash() error = %v, want %v", err, repository.ErrAPITokenNotFound)
+		}
+	})
+
+	t.Run("有効期限切れのトークンを削除できる", func(t *testing.T) {
+		repo, userID, otherID := newRepo(t)
+		regist(t, repo, "expired", userID, now.Add(-2*time.Hour), now)
+		regist(t, repo, "active", userID, now, now.Add(time.Hour))
+		regist(t, repo, "other", otherID, now.Add(-2*time.Hour), now)
+		if err := repo.DeleteExpired(userID, now); err != nil {
+			t.Fatalf("DeleteExpired() error = %v", err)
+		}
+
+		if _, err := repo.FindByHash("expired-hash"); errors.Cause(err) != repository.ErrAPITokenNotFound {
+			t.Errorf("FindByHash() error = %v, want %v", err, repository.ErrAPITokenNotFound)
+		}
+		for _, hash := range []string{"active-hash", "other-hash"} {
+			if _, err := repo.FindByHash(hash); err != nil {
+				t.Errorf("FindByHash(%s) error = %v", hash, err)
+			}
+		}
+	})
+}
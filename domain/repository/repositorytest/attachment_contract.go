@@ -0,0 +1,77 @@
+package repositorytest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+// AttachmentRepositoryFactory テストケースごとに空の添付ファイルリポジトリと登録済みユーザーのIDを返す
+type AttachmentRepositoryFactory func(t *testing.T) (repository.Attachment, string)
+
+// RunAttachmentContract 添付ファイルリポジトリの契約テストを実行する
+func RunAttachmentContract(t *testing.T, newRepo AttachmentRepositoryFactory) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("未登録のIDはErrAttachmentNotFound", func(t *testing.T) {
+		repo, _ := newRepo(t)
+
+		got, err := repo.FindByID("999999")
+		if !errors.Is(err, repository.ErrAttachmentNotFound) {
+			t.Errorf("FindByID() error = %v, want %v", err, repository.ErrAttachmentNotFound)
+		}
+		if got != nil {
+			t.Errorf("FindByID() = %v, want nil", got)
+		}
+	})
+
+	t.Run("登録した添付ファイルを取得できる", func(t *testing.T) {
+		repo, userID := newRepo(t)
+
+		id, err := repo.Regist(model.NewAttachment("", userID, "", "a.png", "a-thumb.png", "image/png", 100, 20, 10, time.Time{}), now)
+		if err != nil {
+			t.Fatalf("Regist() error = %v", err)
+		}
+		if id == "" {
+			t.Fatalf("Regist() id is empty")
+		}
+
+		got, err := repo.FindByID(id)
+		if err != nil {
+			t.Fatalf("FindByID() error = %v", err)
+		}
+		want := model.NewAttachment(id, userID, "", "a.png", "a-thumb.png", "image/png", 100, 20, 10, now)
+		if got.ID() != want.ID() ||
+			got.UserID() != want.UserID() ||
+			got.PostID() != want.PostID() ||
+			got.BlobKey() != want.BlobKey() ||
+			got.ThumbnailKey() != want.ThumbnailKey() ||
+			got.ContentType() != want.ContentType() ||
+			got.Size() != want.Size() ||
+			got.Width() != want.Width() ||
+			got.Height() != want.Height() ||
+			!got.CreatedAt().Equal(want.CreatedAt()) {
+			t.Errorf("FindByID() = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("IDは登録ごとに異なる", func(t *testing.T) {
+		repo, userID := newRepo(t)
+
+		first, err := repo.Regist(model.NewAttachment("", userID, "", "a.png", "a-thumb.png", "image/png", 1, 1, 1, time.Time{}), now)
+		if err != nil {
+			t.Fatalf("Regist() error = %v", err)
+		}
+		second, err := repo.Regist(model.NewAttachment("", userID, "", "b.png", "b-thumb.png", "image/png", 1, 1, 1, time.Time{}), now)
+		if err != nil {
+			t.Fatalf("Regist() error = %v", err)
+		}
+		if first == second {
+			t.Errorf("Regist() id = %s, %s, want different", first, second)
+		}
+	})
+}
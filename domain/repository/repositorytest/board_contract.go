@@ -0,0 +1,67 @@
+package repositorytest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+// BoardRepositoryFactory テストケースごとに空の板リポジトリを返す
+type BoardRepositoryFactory func(t *testing.T) repository.Board
+
+// RunBoardContract 板リポジトリの契約テストを実行する
+func RunBoardContract(t *testing.T, newRepo BoardRepositoryFactory) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	// regist 板を登録し、IDを返す
+	regist := func(t *testing.T, repo repository.Board, name string) string {
+		t.Helper()
+		id, err := repo.Regist(model.NewBoard("", name, name+"の説明", time.Time{}), now)
+		if err != nil {
+			t.Fatalf("Regist() error = %v", err)
+		}
+		return id
+	}
+
+	t.Run("登録した板をIDで取得できる", func(t *testing.T) {
+		repo := newRepo(t)
+		id := regist(t, repo, "雑談")
+
+		got, err := repo.FindByID(id)
+		if err != nil {
+			t.Fatalf("FindByID() error = %v", err)
+		}
+		if got.ID() != id ||
+			got.Name() != "雑談" ||
+			got.Description() != "雑談の説明" ||
+			!got.CreatedAt().Equal(now) {
+			t.Errorf("FindByID() = %+v", got)
+		}
+	})
+
+	t.Run("登録した板を登録順に一覧できる", func(t *testing.T) {
+		repo := newRepo(t)
+		first := regist(t, repo, "雑談")
+		second := regist(t, repo, "質問")
+
+		got, err := repo.FindAll()
+		if err != nil {
+			t.Fatalf("FindAll() error = %v", err)
+		}
+		if len(got) != 2 || got[0].ID() != first || got[1].ID() != second {
+			t.Errorf("FindAll() = %+v, want ids [%s %s]", got, first, second)
+		}
+	})
+
+	t.Run("未登録の板は取得できない", func(t *testing.T) {
+		repo := newRepo(t)
+
+		if _, err := repo.FindByID("999999"); !errors.Is(err, repository.ErrBoardNotFound) {
+			t.Errorf("FindByID() error = %v, want %v", err, repository.ErrBoardNotFound)
+		}
+	})
+}
@@ -0,0 +1,155 @@
+package repositorytest
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+// DigestRepositoryFactory テストケースごとに空のダイジェストリポジトリと登録済みの2ユーザーのIDを返す
+type DigestRepositoryFactory func(t *testing.T) (repository.Digest, string, string)
+
+// RunDigestContract ウォッチ・ダイジェストリポジトリの契約テストを実行する
+func RunDigestContract(t *testing.T, newRepo DigestRepositoryFactory) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("登録したウォッチを登録順に取得できる", func(t *testing.T) {
+		repo, userID, otherID := newRepo(t)
+		watches := []model.Watch{
+			model.NewWatch(userID, model.WatchBoard, "1", now),
+			model.NewWatch(userID, model.WatchThread, "10", now.Add(time.Second)),
+			model.NewWatch(otherID, model.WatchThread, "10", now),
+		}
+		for _, w := range watches {
+			if err := repo.RegistWatch(w); err != nil {
+				t.Fatalf("RegistWatch() error = %v", err)
+			}
+		}
+		// 登録済みのウォッチを再登録してもエラーにならない
+		if err := repo.RegistWatch(model.NewWatch(userID, model.WatchBoard, "1", now.Add(time.Hour))); err != nil {
+			t.Fatalf("RegistWatch() error = %v", err)
+		}
+
+		got, err := repo.FindWatchesByUserID(userID)
+		if err != nil {
+			t.Fatalf("FindWatchesByUserID() error = %v", err)
+		}
+		if len(got) != 2 {
+			t.Fatalf("FindWatchesByUserID() = %+v, want 2 watches", got)
+		}
+		for i, w := range watches[:2] {
+			if got[i].UserID() != w.UserID() ||
+				got[i].Kind() != w.Kind() ||
+				got[i].TargetID() != w.TargetID() ||
+				!got[i].CreatedAt().Equal(w.CreatedAt()) {
+				t.Errorf("FindWatchesByUserID()[%d] = %+v, want %+v", i, got[i], w)
+			}
+		}
+	})
+
+	t.Run("ウォッチを削除できる", func(t *testing.T) {
+		repo, userID, _ := newRepo(t)
+		if err := repo.RegistWatch(model.NewWatch(userID, model.WatchThread, "10", now)); err != nil {
+			t.Fatalf("RegistWatch() error = %v", err)
+		}
+
+		if err := repo.DeleteWatch(userID, model.WatchThread, "10"); err != nil {
+			t.Fatalf("DeleteWatch() error = %v", err)
+		}
+		got, err := repo.FindWatchesByUserID(userID)
+		if err != nil {
+			t.Fatalf("FindWatchesByUserID() error = %v", err)
+		}
+		if len(got) != 0 {
+			t.Errorf("FindWatchesByUserID() = %+v, want empty", got)
+		}
+
+		if err := repo.DeleteWatch(userID, model.WatchThread, "10"); !errors.Is(err, repository.ErrWatchNotFound) {
+			t.Errorf("DeleteWatch() error = %v, want %v", err, repository.ErrWatchNotFound)
+		}
+	})
+
+	t.Run("配信設定を保存し、ユーザーとトークンで取得できる", func(t *testing.T) {
+		repo, userID, _ := newRepo(t)
+		if _, err := repo.FindSubscription(userID); !errors.Is(err, repository.ErrDigestSubscriptionNotFound) {
+			t.Fatalf("FindSubscription() error = %v, want %v", err, repository.ErrDigestSubscriptionNotFound)
+		}
+
+		want := model.DigestSubscription{Frequency: model.DigestDaily, Locale: model.DigestLocaleEn, UnsubscribeToken: "token"}
+		if err := repo.SaveSubscription(userID, want, now); err != nil {
+			t.Fatalf("SaveSubscription() error = %v", err)
+		}
+		got, err := repo.FindSubscription(userID)
+		if err != nil {
+			t.Fatalf("FindSubscription() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("FindSubscription() = %+v, want %+v", got, want)
+		}
+
+		gotUserID, got, err := repo.FindSubscriptionByToken("token")
+		if err != nil {
+			t.Fatalf("FindSubscriptionByToken() error = %v", err)
+		}
+		if gotUserID != userID || !reflect.DeepEqual(got, want) {
+			t.Errorf("FindSubscriptionByToken() = %s, %+v, want %s, %+v", gotUserID, got, userID, want)
+		}
+		if _, _, err := repo.FindSubscriptionByToken("unknown"); !errors.Is(err, repository.ErrDigestSubscriptionNotFound) {
+			t.Errorf("FindSubscriptionByToken() error = %v, want %v", err, repository.ErrDigestSubscriptionNotFound)
+		}
+
+		// 上書き保存
+		want.Frequency = model.DigestOff
+		if err := repo.SaveSubscription(userID, want, now); err != nil {
+			t.Fatalf("SaveSubscription() error = %v", err)
+		}
+		if got, _ := repo.FindSubscription(userID); !reflect.DeepEqual(got, want) {
+			t.Errorf("FindSubscription() = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("送信記録は集計期間ごとに一度だけ登録できる", func(t *testing.T) {
+		repo, userID, otherID := newRepo(t)
+		if err := repo.SaveSubscription(userID, model.DigestSubscription{Frequency: model.DigestDaily, Locale: model.DigestLocaleJa, UnsubscribeToken: "a"}, now); err != nil {
+			t.Fatalf("SaveSubscription() error = %v", err)
+		}
+		if err := repo.SaveSubscription(otherID, model.DigestSubscription{Frequency: model.DigestWeekly, Locale: model.DigestLocaleJa, UnsubscribeToken: "b"}, now); err != nil {
+			t.Fatalf("SaveSubscription() error = %v", err)
+		}
+
+		got, err := repo.FindDueUserIDs(model.DigestDaily, "2023-01-02")
+		if err != nil {
+			t.Fatalf("FindDueUserIDs() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, []string{userID}) {
+			t.Fatalf("FindDueUserIDs() = %v, want [%s]", got, userID)
+		}
+
+		registered, err := repo.RegistDelivery(userID, "2023-01-02", now)
+		if err != nil || !registered {
+			t.Fatalf("RegistDelivery() = %v, %v, want true", registered, err)
+		}
+		registered, err = repo.RegistDelivery(userID, "2023-01-02", now)
+		if err != nil || registered {
+			t.Fatalf("RegistDelivery() = %v, %v, want false", registered, err)
+		}
+		if got, _ := repo.FindDueUserIDs(model.DigestDaily, "2023-01-02"); len(got) != 0 {
+			t.Errorf("FindDueUserIDs() = %v, want empty", got)
+		}
+		if got, _ := repo.FindDueUserIDs(model.DigestDaily, "2023-01-03"); !reflect.DeepEqual(got, []string{userID}) {
+			t.Errorf("FindDueUserIDs() = %v, want [%s]", got, userID)
+		}
+
+		if err := repo.DeleteDelivery(userID, "2023-01-02"); err != nil {
+			t.Fatalf("DeleteDelivery() error = %v", err)
+		}
+		if got, _ := repo.FindDueUserIDs(model.DigestDaily, "2023-01-02"); !reflect.DeepEqual(got, []string{userID}) {
+			t.Errorf("FindDueUserIDs() = %v, want [%s]", got, userID)
+		}
+	})
+}
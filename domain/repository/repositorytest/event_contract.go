@@ -0,0 +1,98 @@
+package repositorytest
+
+import (
+	"testing"
+	"time"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+// EventRepositoryFactory テストケースごとに空のイベントリポジトリを返す
+type EventRepositoryFactory func(t *testing.T) repository.Event
+
+// RunEventContract イベントリポジトリの契約テストを実行する
+func RunEventContract(t *testing.T, newRepo EventRepositoryFactory) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	// regist 未配信のイベントを登録し、IDを返す
+	regist := func(t *testing.T, repo repository.Event, nextAttemptAt time.Time) string {
+		t.Helper()
+		id, err := repo.Regist(model.NewEvent("", model.EventUserRegistered, "1", []byte(`{"user_id":"1"}`), 0, nextAttemptAt, "", now, time.Time{}))
+		if err != nil {
+			t.Fatalf("Regist() error = %v", err)
+		}
+		return id
+	}
+
+	t.Run("配信時刻に達した未配信のイベントだけを取得できる", func(t *testing.T) {
+		repo := newRepo(t)
+		later := regist(t, repo, now.Add(-time.Minute))
+		earlier := regist(t, repo, now.Add(-time.Hour))
+		regist(t, repo, now.Add(time.Minute))
+
+		got, err := repo.FindDue(now, 10)
+		if err != nil {
+			t.Fatalf("FindDue() error = %v", err)
+		}
+		if len(got) != 2 || got[0].ID() != earlier || got[1].ID() != later {
+			t.Fatalf("FindDue() = %+v, want ids [%s %s]", got, earlier, later)
+		}
+		e := got[0]
+		if e.Type() != model.EventUserRegistered ||
+			e.AggregateID() != "1" ||
+			string(e.Payload()) != `{"user_id":"1"}` ||
+			e.Attempts() != 0 ||
+			!e.NextAttemptAt().Equal(now.Add(-time.Hour)) ||
+			e.LastError() != "" ||
+			!e.OccurredAt().Equal(now) ||
+			e.IsDispatched() {
+			t.Errorf("FindDue() = %+v", e)
+		}
+
+		got, err = repo.FindDue(now, 1)
+		if err != nil {
+			t.Fatalf("FindDue() error = %v", err)
+		}
+		if len(got) != 1 || got[0].ID() != earlier {
+			t.Errorf("FindDue() = %+v, want id %s", got, earlier)
+		}
+	})
+
+	t.Run("配信済みと再試行待ちのイベントは取得されない", func(t *testing.T) {
+		repo := newRepo(t)
+		dispatched := regist(t, repo, now)
+		retrying := regist(t, repo, now)
+
+		updates := []model.Event{
+			model.NewEvent(dispatched, model.EventUserRegistered, "1", nil, 1, now, "", now, now.Add(time.Second)),
+			model.NewEvent(retrying, model.EventUserRegistered, "1", nil, 1, now.Add(time.Minute), "handler error", now, time.Time{}),
+		}
+		for _, e := range updates {
+			if err := repo.Update(e); err != nil {
+				t.Fatalf("Update() error = %v", err)
+			}
+		}
+
+		due, err := repo.FindDue(now, 10)
+		if err != nil {
+			t.Fatalf("FindDue() error = %v", err)
+		}
+		if len(due) != 0 {
+			t.Errorf("FindDue() = %+v, want empty", due)
+		}
+
+		got, err := repo.FindDue(now.Add(time.Minute), 10)
+		if err != nil {
+			t.Fatalf("FindDue() error = %v", err)
+		}
+		if len(got) != 1 || got[0].ID() != retrying {
+			t.Fatalf("FindDue() = %+v, want id %s", got, retrying)
+		}
+		if e := got[0]; e.Attempts() != 1 ||
+			e.LastError() != "handler error" ||
+			string(e.Payload()) != `{"user_id":"1"}` {
+			t.Errorf("FindDue() = %+v", e)
+		}
+	})
+}
@@ -0,0 +1,195 @@
+package repositorytest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+// MFARepositoryFactory テストケースごとに空の2要素認証リポジトリと登録済みの2ユーザーのIDを返す
+type MFARepositoryFactory func(t *testing.T) (repository.MFA, string, string)
+
+// RunMFAContract 2要素認証リポジトリの契約テストを実行する
+func RunMFAContract(t *testing.T, newRepo MFARepositoryFactory) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("保存した設定を取得でき、再登録で上書きされる", func(t *testing.T) {
+		repo, userID, _ := newRepo(t)
+
+		if _, err := repo.FindByUserID(userID); errors.Cause(err) != repository.ErrMFANotFound {
+			t.Errorf("FindByUserID() error = %v, want %v", err, repository.ErrMFANotFound)
+		}
+
+		if err := repo.Save(model.NewMFA(userID, "SECRET1", time.Time{}, 0, now)); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		if err := repo.Save(model.NewMFA(userID, "SECRET2", time.Time{}, 0, now.Add(time.Minute))); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		got, err := repo.FindByUserID(userID)
+		if err != nil {
+			t.Fatalf("FindByUserID() error = %v", err)
+		}
+		if got.UserID() != userID ||
+			got.Secret() != "SECRET2" ||
+			!got.ConfirmedAt().IsZero() ||
+			got.LastUsedStep() != 0 ||
+			!got.CreatedAt().Equal(now.Add(time.Minute)) {
+			t.Errorf("FindByUserID() = %+v", got)
+		}
+
+		if err := repo.Save(model.NewMFA(userID, "SECRET2", now.Add(2*time.Minute), 100, now.Add(time.Minute))); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		if err := repo.UpdateLastUsedStep(userID, 101); err != nil {
+			t.Fatalf("UpdateLastUsedStep() error = %v", err)
+		}
+		for _, step := range []int64{101, 100} {
+			if err := repo.UpdateLastUsedStep(userID, step); errors.Cause(err) != repository.ErrMFACodeUsed {
+				t.Errorf("UpdateLastUsedStep(%d) error = %v, want %v", step, err, repository.ErrMFACodeUsed)
+			}
+		}
+		got, err = repo.FindByUserID(userID)
+		if err != nil {
+			t.Fatalf("FindByUserID() error = %v", err)
+		}
+		if !got.ConfirmedAt().Equal(now.Add(2*time.Minute)) || got.LastUsedStep() != 101 {
+			t.Errorf("FindByUserID() = %+v", got)
+		}
+	})
+
+	t.Run("リカバリーコードは1回だけ使える", func(t *testing.T) {
+		repo, userID, otherID := newRepo(t)
+		if err := repo.Save(model.NewMFA(userID, "SECRET", now, 0, now)); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		if err := repo.ReplaceRecoveryCodes(userID, []string{"old"}); err != nil {
+			t.Fatalf("ReplaceRecoveryCodes() error = %v", err)
+		}
+		if err := repo.ReplaceRecoveryCodes(userID, []string{"h1", "h2"}); err != nil {
+			t.Fatalf("ReplaceRecoveryCodes() error = %v", err)
+		}
+
+		if err := repo.UseRecoveryCode(userID, "old", now); errors.Cause(err) != repository.ErrRecoveryCodeNotFound {
+			t.Errorf("UseRecoveryCode(old) error = %v, want %v", err, repository.ErrRecoveryCodeNotFound)
+		}
+		if err := repo.UseRecoveryCode(otherID, "h1", now); errors.Cause(err) != repository.ErrRecoveryCodeNotFound {
+			t.Errorf("UseRecoveryCode(other) error = %v, want %v", err, repository.ErrRecoveryCodeNotFound)
+		}
+		if err := repo.UseRecoveryCode(userID, "h1", now); err != nil {
+			t.Fatalf("UseRecoveryCode() error = %v", err)
+		}
+		if err := repo.UseRecoveryCode(userID, "h1", now); errors.Cause(err) != repository.ErrRecoveryCodeNotFound {
+			t.Errorf("UseRecoveryCode(used) error = %v, want %v", err, repository.ErrRecoveryCodeNotFound)
+		}
+		if err := repo.UseRecoveryCode(userID, "h2", now); err != nil {
+			t.Errorf("UseRecoveryCode(h2) error = %v", err)
+		}
+	})
+
+	t.Run("設定を削除するとリカバリーコードも使えなくなる", func(t *testing.T) {
+		repo, userID, _ := newRepo(t)
+		if err := repo.Save(model.NewMFA(userID, "SECRET", now, 0, now)); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		if err := repo.ReplaceRecoveryCodes(userID, []string{"h1"}); err != nil {
+			t.Fatalf("ReplaceRecoveryCodes() error = %v", err)
+		}
+		if err := repo.Delete(userID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+
+		if _, err := repo.FindByUserID(userID); errors.Cause(err) != repository.ErrMFANotFound {
+			t.Errorf("FindByUserID() error = %v, want %v", err, repository.ErrMFANotFound)
+		}
+		if err := repo.UseRecoveryCode(userID, "h1", now); errors.Cause(err) != repository.ErrRecoveryCodeNotFound {
+			t.Errorf("UseRecoveryCode() error = %v, want %v", err, repository.ErrRecoveryCodeNotFound)
+		}
+	})
+
+	t.Run("チャレンジを登録、取得、削除できる", func(t *testing.T) {
+		repo, userID, _ := newRepo(t)
+		if err := repo.RegistChallenge(model.NewMFAChallenge("c1", userID, 0, now.Add(5*time.Minute), now)); err != nil {
+			t.Fatalf("RegistChallenge() error = %v", err)
+		}
+		if err := repo.ConsumeChallengeAttempt("c1", now); err != nil {
+			t.Fatalf("ConsumeChallengeAttempt() error = %v", err)
+		}
+
+		got, err := repo.FindChallenge("c1")
+		if err != nil {
+			t.Fatalf("FindChallenge() error = %v", err)
+		}
+		if got.ID() != "c1" ||
+			got.UserID() != userID ||
+			got.Attempts() != 1 ||
+			!got.ExpiresAt().Equal(now.Add(5*time.Minute)) ||
+			!got.CreatedAt().Equal(now) {
+			t.Errorf("FindChallenge() = %+v", got)
+		}
+
+		if err := repo.DeleteChallenge("c1"); err != nil {
+			t.Fatalf("DeleteChallenge() error = %v", err)
+		}
+		if _, err := repo.FindChallenge("c1"); errors.Cause(err) != repository.ErrMFAChallengeNotFound {
+			t.Errorf("FindChallenge() error = %v, want %v", err, repository.ErrMFAChallengeNotFound)
+		}
+	})
+
+	t.Run("試行回数が上限に達したチャレンジと有効期限切れのチャレンジは試行できない", func(t *testing.T) {
+		repo, userID, _ := newRepo(t)
+		for _, c := range []model.MFAChallenge{
+			model.NewMFAChallenge("last", userID, model.MaxMFAChallengeAttempts-1, now.Add(time.Minute), now),
+			model.NewMFAChallenge("expired", userID, 0, now, now.Add(-5*time.Minute)),
+		} {
+			if err := repo.RegistChallenge(c); err != nil {
+				t.Fatalf("RegistChallenge() error = %v", err)
+			}
+		}
+
+		if err := repo.ConsumeChallengeAttempt("last", now); err != nil {
+			t.Fatalf("ConsumeChallengeAttempt() error = %v", err)
+		}
+		for _, id := range []string{"last", "expired", "unknown"} {
+			if err := repo.ConsumeChallengeAttempt(id, now); errors.Cause(err) != repository.ErrMFAChallengeNotFound {
+				t.Errorf("ConsumeChallengeAttempt(%s) error = %v, want %v", id, err, repository.ErrMFAChallengeNotFound)
+			}
+		}
+		got, err := repo.FindChallenge("last")
+		if err != nil {
+			t.Fatalf("FindChallenge() error = %v", err)
+		}
+		if got.Attempts() != model.MaxMFAChallengeAttempts {
+			t.Errorf("FindChallenge().Attempts() = %d, want %d", got.Attempts(), model.MaxMFAChallengeAttempts)
+		}
+	})
+
+	t.Run("有効期限切れのチャレンジを削除できる", func(t *testing.T) {
+		repo, userID, otherID := newRepo(t)
+		for _, c := range []model.MFAChallenge{
+			model.NewMFAChallenge("expired", userID, 0, now, now.Add(-5*time.Minute)),
+			model.NewMFAChallenge("active", userID, 0, now.Add(time.Minute), now),
+			model.NewMFAChallenge("other", otherID, 0, now, now.Add(-5*time.Minute)),
+		} {
+			if err := repo.RegistChallenge(c); err != nil {
+				t.Fatalf("RegistChallenge() error = %v", err)
+			}
+		}
+		if err := repo.DeleteExpiredChallenges(userID, now); err != nil {
+			t.Fatalf("DeleteExpiredChallenges() error = %v", err)
+		}
+
+		if _, err := repo.FindChallenge("expired"); errors.Cause(err) != repository.ErrMFAChallengeNotFound {
+			t.Errorf("FindChallenge() error = %v, want %v", err, repository.ErrMFAChallengeNotFound)
+		}
+		for _, id := range []string{"active", "other"} {
+			if _, err := repo.FindChallenge(id); err != nil {
+				t.Errorf("FindChallenge(%s) error = %v", id, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,151 @@
+package repositorytest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+// NotificationRepositoryFactory テストケースごとに空の通知リポジトリと登録済みの2ユーザーのIDを返す
+type NotificationRepositoryFactory func(t *testing.T) (repository.Notification, string, string)
+
+// RunNotificationContract 通知リポジトリの契約テストを実行する
+func RunNotificationContract(t *testing.T, newRepo NotificationRepositoryFactory) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	// regist 通知を登録し、IDを返す
+	regist := func(t *testing.T, repo repository.Notification, userID, actorID string, kind model.NotificationKind) string {
+		t.Helper()
+		id, err := repo.Regist(model.NewNotification("", userID, kind, actorID, "10", time.Time{}, time.Time{}), now)
+		if err != nil {
+			t.Fatalf("Regist() error = %v", err)
+		}
+		return id
+	}
+
+	t.Run("登録した通知を新しい順に取得できる", func(t *testing.T) {
+		repo, userID, actorID := newRepo(t)
+		first := regist(t, repo, userID, actorID, model.NotificationReply)
+		second := regist(t, repo, userID, actorID, model.NotificationMention)
+		regist(t, repo, actorID, userID, model.NotificationQuote)
+
+		got, err := repo.FindByUserID(userID, "", 10)
+		if err != nil {
+			t.Fatalf("FindByUserID() error = %v", err)
+		}
+		if len(got) != 2 || got[0].ID() != second || got[1].ID() != first {
+			t.Fatalf("FindByUserID() = %+v, want ids [%s %s]", got, second, first)
+		}
+		n := got[1]
+		if n.UserID() != userID ||
+			n.Kind() != model.NotificationReply ||
+			n.ActorID() != actorID ||
+			n.TargetID() != "10" ||
+			n.IsRead() ||
+			!n.CreatedAt().Equal(now) {
+			t.Errorf("FindByUserID() = %+v", n)
+		}
+	})
+
+	t.Run("件数とbeforeIDで絞り込める", func(t *testing.T) {
+		repo, userID, actorID := newRepo(t)
+		first := regist(t, repo, userID, actorID, model.NotificationReply)
+		second := regist(t, repo, userID, actorID, model.NotificationReply)
+		third := regist(t, repo, userID, actorID, model.NotificationReply)
+
+		got, err := repo.FindByUserID(userID, "", 1)
+		if err != nil {
+			t.Fatalf("FindByUserID() error = %v", err)
+		}
+		if len(got) != 1 || got[0].ID() != third {
+			t.Errorf("FindByUserID() = %+v, want id %s", got, third)
+		}
+
+		got, err = repo.FindByUserID(userID, third, 10)
+		if err != nil {
+			t.Fatalf("FindByUserID() error = %v", err)
+		}
+		if len(got) != 2 || got[0].ID() != second || got[1].ID() != first {
+			t.Errorf("FindByUserID() = %+v, want ids [%s %s]", got, second, first)
+		}
+	})
+
+	t.Run("既読にすると未読数が減る", func(t *testing.T) {
+		repo, userID, actorID := newRepo(t)
+		first := regist(t, repo, userID, actorID, model.NotificationReply)
+		regist(t, repo, userID, actorID, model.NotificationQuote)
+
+		if got, err := repo.CountUnread(userID); err != nil || got != 2 {
+			t.Fatalf("CountUnread() = %d, %v, want 2", got, err)
+		}
+		readAt := now.Add(time.Hour)
+		if err := repo.MarkRead(userID, first, readAt); err != nil {
+			t.Fatalf("MarkRead() error = %v", err)
+		}
+		if got, err := repo.CountUnread(userID); err != nil || got != 1 {
+			t.Errorf("CountUnread() = %d, %v, want 1", got, err)
+		}
+
+		got, err := repo.FindByUserID(userID, "", 10)
+		if err != nil {
+			t.Fatalf("FindByUserID() error = %v", err)
+		}
+		if !got[1].IsRead() || !got[1].ReadAt().Equal(readAt) {
+			t.Errorf("ReadAt() = %v, want %v", got[1].ReadAt(), readAt)
+		}
+
+		if err := repo.MarkAllRead(userID, readAt); err != nil {
+			t.Fatalf("MarkAllRead() error = %v", err)
+		}
+		if got, err := repo.CountUnread(userID); err != nil || got != 0 {
+			t.Errorf("CountUnread() = %d, %v, want 0", got, err)
+		}
+	})
+
+	t.Run("他のユーザーの通知と未登録の通知は既読にできない", func(t *testing.T) {
+		repo, userID, actorID := newRepo(t)
+		id := regist(t, repo, userID, actorID, model.NotificationReply)
+
+		if err := repo.MarkRead(actorID, id, now); !errors.Is(err, repository.ErrNotificationNotFound) {
+			t.Errorf("MarkRead() error = %v, want %v", err, repository.ErrNotificationNotFound)
+		}
+		if err := repo.MarkRead(userID, "999999", now); !errors.Is(err, repository.ErrNotificationNotFound) {
+			t.Errorf("MarkRead() error = %v, want %v", err, repository.ErrNotificationNotFound)
+		}
+		if got, err := repo.CountUnread(userID); err != nil || got != 1 {
+			t.Errorf("CountUnread() = %d, %v, want 1", got, err)
+		}
+	})
+
+	t.Run("受信設定は未設定の場合デフォルトで保存後は保存値", func(t *testing.T) {
+		repo, userID, _ := newRepo(t)
+
+		got, err := repo.FindPreferences(userID)
+		if err != nil {
+			t.Fatalf("FindPreferences() error = %v", err)
+		}
+		if got != model.DefaultNotificationPreferences() {
+			t.Errorf("FindPreferences() = %+v, want %+v", got, model.DefaultNotificationPreferences())
+		}
+
+		for _, want := range []model.NotificationPreferences{
+			{Reply: false, Quote: true, Mention: false},
+			{Reply: true, Quote: false, Mention: true},
+		} {
+			if err := repo.SavePreferences(userID, want, now); err != nil {
+				t.Fatalf("SavePreferences() error = %v", err)
+			}
+			got, err := repo.FindPreferences(userID)
+			if err != nil {
+				t.Fatalf("FindPreferences() error = %v", err)
+			}
+			if got != want {
+				t.Errorf("FindPreferences() = %+v, want %+v", got, want)
+			}
+		}
+	})
+}
@@ -0,0 +1,177 @@
+package repositorytest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+// PostRepositoryFactory テストケースごとに空の投稿リポジトリと、登録済みの2つのスレッドのIDとユーザーのIDを返す
+type PostRepositoryFactory func(t *testing.T) (repository.Post, string, string, string)
+
+// RunPostContract 投稿リポジトリの契約テストを実行する
+func RunPostContract(t *testing.T, newRepo PostRepositoryFactory) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	// regist 投稿を登録し、IDを返す
+	regist := func(t *testing.T, repo repository.Post, threadID, userID string, number int) string {
+		t.Helper()
+		id, err := repo.Regist(model.NewPost("", threadID, userID, number, "body", "<p>body</p>\n", time.Time{}, time.Time{}, time.Time{}, ""), now)
+		if err != nil {
+			t.Fatalf("Regist() error = %v", err)
+		}
+		return id
+	}
+
+	t.Run("登録した投稿をIDで取得できる", func(t *testing.T) {
+		repo, threadID, _, userID := newRepo(t)
+		id := regist(t, repo, threadID, userID, 1)
+
+		got, err := repo.FindByID(id)
+		if err != nil {
+			t.Fatalf("FindByID() error = %v", err)
+		}
+		if got.ID() != id ||
+			got.ThreadID() != threadID ||
+			got.UserID() != userID ||
+			got.Number() != 1 ||
+			got.Body() != "body" ||
+			got.BodyHTML() != "<p>body</p>\n" ||
+			!got.CreatedAt().Equal(now) ||
+			got.IsEdited() ||
+			got.IsHidden() {
+			t.Errorf("FindByID() = %+v", got)
+		}
+	})
+
+	t.Run("スレッドの投稿を番号順に件数とafterNumberで絞り込んで取得できる", func(t *testing.T) {
+		repo, threadID, otherThreadID, userID := newRepo(t)
+		regist(t, repo, threadID, userID, 1)
+		second := regist(t, repo, threadID, userID, 2)
+		third := regist(t, repo, threadID, userID, 3)
+		regist(t, repo, otherThreadID, userID, 1)
+
+		got, err := repo.FindByThreadID(threadID, 1, 10)
+		if err != nil {
+			t.Fatalf("FindByThreadID() error = %v", err)
+		}
+		if len(got) != 2 || got[0].ID() != second || got[1].ID() != third {
+			t.Errorf("FindByThreadID() = %+v, want ids [%s %s]", got, second, third)
+		}
+
+		got, err = repo.FindByThreadID(threadID, 0, 1)
+		if err != nil {
+			t.Fatalf("FindByThreadID() error = %v", err)
+		}
+		if len(got) != 1 || got[0].Number() != 1 {
+			t.Errorf("FindByThreadID() = %+v, want number 1", got)
+		}
+	})
+
+	t.Run("更新した本文と編集日時を取得できる", func(t *testing.T) {
+		repo, threadID, _, userID := newRepo(t)
+		id := regist(t, repo, threadID, userID, 1)
+		edited := now.Add(time.Minute)
+
+		if err := repo.Update(model.NewPost(id, threadID, userID, 1, "edited", "<p>edited</p>\n", now, time.Time{}, time.Time{}, ""), edited); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+
+		got, err := repo.FindByID(id)
+		if err != nil {
+			t.Fatalf("FindByID() error = %v", err)
+		}
+		if got.Body() != "edited" ||
+			got.BodyHTML() != "<p>edited</p>\n" ||
+			got.Number() != 1 ||
+			!got.CreatedAt().Equal(now) ||
+			!got.EditedAt().Equal(edited) {
+			t.Errorf("FindByID() = %+v", got)
+		}
+	})
+
+	t.Run("非表示にした日時と理由を取得でき、編集しても残る", func(t *testing.T) {
+		repo, threadID, _, userID := newRepo(t)
+		id := regist(t, repo, threadID, userID, 1)
+		hidden := now.Add(time.Minute)
+
+		if err := repo.Hide(id, "spam", hidden); err != nil {
+			t.Fatalf("Hide() error = %v", err)
+		}
+		if err := repo.Update(model.NewPost(id, threadID, userID, 1, "edited", "<p>edited</p>\n", now, time.Time{}, time.Time{}, ""), hidden.Add(time.Minute)); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+
+		got, err := repo.FindByID(id)
+		if err != nil {
+			t.Fatalf("FindByID() error = %v", err)
+		}
+		if !got.IsHidden() ||
+			!got.HiddenAt().Equal(hidden) ||
+			got.HiddenReason() != "spam" ||
+			got.Body() != "edited" {
+			t.Errorf("FindByID() = %+v", got)
+		}
+	})
+
+	t.Run("スレッドの非表示でない投稿を作成日時の範囲で数えられる", func(t *testing.T) {
+		repo, threadID, otherThreadID, userID := newRepo(t)
+		for i, p := range []struct {
+			threadID  string
+			createdAt time.Time
+		}{
+			{threadID, now.Add(-time.Second)},
+			{threadID, now},
+			{threadID, now.Add(time.Hour)},
+			{threadID, now.Add(2 * time.Hour)},
+			{otherThreadID, now},
+		} {
+			if _, err := repo.Regist(model.NewPost("", p.threadID, userID, i+1, "body", "<p>body</p>\n", time.Time{}, time.Time{}, time.Time{}, ""), p.createdAt); err != nil {
+				t.Fatalf("Regist() error = %v", err)
+			}
+		}
+		hidden, err := repo.Regist(model.NewPost("", threadID, userID, 6, "body", "<p>body</p>\n", time.Time{}, time.Time{}, time.Time{}, ""), now)
+		if err != nil {
+			t.Fatalf("Regist() error = %v", err)
+		}
+		if err := repo.Hide(hidden, "spam", now); err != nil {
+			t.Fatalf("Hide() error = %v", err)
+		}
+
+		got, err := repo.CountCreated([]string{threadID}, now, now.Add(2*time.Hour))
+		if err != nil {
+			t.Fatalf("CountCreated() error = %v", err)
+		}
+		if got != 2 {
+			t.Errorf("CountCreated() = %d, want 2", got)
+		}
+
+		got, err = repo.CountCreated([]string{threadID, otherThreadID}, now, now.Add(2*time.Hour))
+		if err != nil {
+			t.Fatalf("CountCreated() error = %v", err)
+		}
+		if got != 3 {
+			t.Errorf("CountCreated() = %d, want 3", got)
+		}
+
+		got, err = repo.CountCreated([]string{}, now, now.Add(2*time.Hour))
+		if err != nil {
+			t.Fatalf("CountCreated() error = %v", err)
+		}
+		if got != 0 {
+			t.Errorf("CountCreated() = %d, want 0", got)
+		}
+	})
+
+	t.Run("未登録の投稿は取得できない", func(t *testing.T) {
+		repo, _, _, _ := newRepo(t)
+
+		if _, err := repo.FindByID("999999"); !errors.Is(err, repository.ErrPostNotFound) {
+			t.Errorf("FindByID() error = %v, want %v", err, repository.ErrPostNotFound)
+		}
+	})
+}
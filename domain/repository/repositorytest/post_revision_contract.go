@@ -0,0 +1,69 @@
+package repositorytest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+// PostRevisionRepositoryFactory テストケースごとに空の投稿の版リポジトリと、登録済みの2つの投稿のIDとユーザーのIDを返す
+type PostRevisionRepositoryFactory func(t *testing.T) (repository.PostRevision, string, string, string)
+
+// RunPostRevisionContract 投稿の版リポジトリの契約テストを実行する
+func RunPostRevisionContract(t *testing.T, newRepo PostRevisionRepositoryFactory) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	// regist 版を登録し、IDを返す
+	regist := func(t *testing.T, repo repository.PostRevision, postID, editorID, body string) string {
+		t.Helper()
+		id, err := repo.Regist(model.NewPostRevision("", postID, editorID, body, time.Time{}), now)
+		if err != nil {
+			t.Fatalf("Regist() error = %v", err)
+		}
+		return id
+	}
+
+	t.Run("登録した版をIDで取得できる", func(t *testing.T) {
+		repo, postID, _, userID := newRepo(t)
+		id := regist(t, repo, postID, userID, "body")
+
+		got, err := repo.FindByID(id)
+		if err != nil {
+			t.Fatalf("FindByID() error = %v", err)
+		}
+		if got.ID() != id ||
+			got.PostID() != postID ||
+			got.EditorID() != userID ||
+			got.Body() != "body" ||
+			!got.CreatedAt().Equal(now) {
+			t.Errorf("FindByID() = %+v", got)
+		}
+	})
+
+	t.Run("投稿の版を古い順に取得できる", func(t *testing.T) {
+		repo, postID, otherPostID, userID := newRepo(t)
+		first := regist(t, repo, postID, userID, "first")
+		second := regist(t, repo, postID, "", "second")
+		regist(t, repo, otherPostID, userID, "other")
+
+		got, err := repo.FindByPostID(postID)
+		if err != nil {
+			t.Fatalf("FindByPostID() error = %v", err)
+		}
+		if len(got) != 2 || got[0].ID() != first || got[1].ID() != second || got[1].EditorID() != "" {
+			t.Errorf("FindByPostID() = %+v, want ids [%s %s]", got, first, second)
+		}
+	})
+
+	t.Run("未登録の版は取得できない", func(t *testing.T) {
+		repo, _, _, _ := newRepo(t)
+
+		if _, err := repo.FindByID("999999"); !errors.Is(err, repository.ErrPostRevisionNotFound) {
+			t.Errorf("FindByID() error = %v, want %v", err, repository.ErrPostRevisionNotFound)
+		}
+	})
+}
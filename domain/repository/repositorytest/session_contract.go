@@ -0,0 +1,131 @@
+package repositorytest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+// SessionRepositoryFactory テストケースごとに空のセッションリポジトリと登録済みの2ユーザーのIDを返す
+type SessionRepositoryFactory func(t *testing.T) (repository.Session, string, string)
+
+// RunSessionContract セッションリポジトリの契約テストを実行する
+func RunSessionContract(t *testing.T, newRepo SessionRepositoryFactory) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	// regist createdAtにログインし、expiresAtまで有効なセッションを登録する
+	regist := func(t *testing.T, repo repository.Session, id string, userID string, createdAt time.Time, expiresAt time.Time) {
+		t.Helper()
+		session := model.NewSession(id, userID, "Firefox on Linux", "192.0.2.1", "Mozilla/5.0", createdAt, createdAt, expiresAt, time.Time{})
+		if err := repo.Regist(session); err != nil {
+			t.Fatalf("Regist() error = %v", err)
+		}
+	}
+
+	t.Run("登録したセッションをIDで取得できる", func(t *testing.T) {
+		repo, userID, _ := newRepo(t)
+		regist(t, repo, "s1", userID, now, now.Add(time.Hour))
+
+		got, err := repo.FindByID("s1")
+		if err != nil {
+			t.Fatalf("FindByID() error = %v", err)
+		}
+		if got.ID() != "s1" ||
+			got.UserID() != userID ||
+			got.Device() != "Firefox on Linux" ||
+			got.IP() != "192.0.2.1" ||
+			got.UserAgent() != "Mozilla/5.0" ||
+			!got.CreatedAt().Equal(now) ||
+			!got.LastSeenAt().Equal(now) ||
+			!got.ExpiresAt().Equal(now.Add(time.Hour)) ||
+			!got.RevokedAt().IsZero() {
+			t.Errorf("FindByID() = %+v", got)
+		}
+
+		if _, err := repo.FindByID("unknown"); errors.Cause(err) != repository.ErrSessionNotFound {
+			t.Errorf("FindByID() error = %v, want %v", err, repository.ErrSessionNotFound)
+		}
+	})
+
+	t.Run("有効なセッションだけを新しい順に取得できる", func(t *testing.T) {
+		repo, userID, otherID := newRepo(t)
+		regist(t, repo, "old", userID, now.Add(-time.Hour), now.Add(time.Hour))
+		regist(t, repo, "new", userID, now, now.Add(time.Hour))
+		regist(t, repo, "expired", userID, now.Add(-2*time.Hour), now)
+		regist(t, repo, "revoked", userID, now, now.Add(time.Hour))
+		regist(t, repo, "other", otherID, now, now.Add(time.Hour))
+		if err := repo.Revoke(userID, "revoked", now); err != nil {
+			t.Fatalf("Revoke() error = %v", err)
+		}
+
+		got, err := repo.FindActiveByUserID(userID, now)
+		if err != nil {
+			t.Fatalf("FindActiveByUserID() error = %v", err)
+		}
+		if len(got) != 2 || got[0].ID() != "new" || got[1].ID() != "old" {
+			t.Errorf("FindActiveByUserID() = %+v, want ids [new old]", got)
+		}
+	})
+
+	t.Run("最終利用日時を更新できる", func(t *testing.T) {
+		repo, userID, _ := newRepo(t)
+		regist(t, repo, "s1", userID, now, now.Add(time.Hour))
+		if err := repo.Touch("s1", now.Add(time.Minute)); err != nil {
+			t.Fatalf("Touch() error = %v", err)
+		}
+
+		got, err := repo.FindByID("s1")
+		if err != nil {
+			t.Fatalf("FindByID() error = %v", err)
+		}
+		if !got.LastSeenAt().Equal(now.Add(time.Minute)) || !got.CreatedAt().Equal(now) {
+			t.Errorf("FindByID() = %+v", got)
+		}
+	})
+
+	t.Run("本人の有効なセッションだけを失効できる", func(t *testing.T) {
+		repo, userID, otherID := newRepo(t)
+		regist(t, repo, "s1", userID, now, now.Add(time.Hour))
+
+		if err := repo.Revoke(otherID, "s1", now); errors.Cause(err) != repository.ErrSessionNotFound {
+			t.Errorf("Revoke() error = %v, want %v", err, repository.ErrSessionNotFound)
+		}
+		if err := repo.Revoke(userID, "s1", now.Add(time.Minute)); err != nil {
+			t.Fatalf("Revoke() error = %v", err)
+		}
+		if err := repo.Revoke(userID, "s1", now.Add(time.Hour)); errors.Cause(err) != repository.ErrSessionNotFound {
+			t.Errorf("Revoke() error = %v, want %v", err, repository.ErrSessionNotFound)
+		}
+
+		got, err := repo.FindByID("s1")
+		if err != nil {
+			t.Fatalf("FindByID() error = %v", err)
+		}
+		if !got.RevokedAt().Equal(now.Add(time.Minute)) {
+			t.Errorf("FindByID() revokedAt = %v, want %v", got.RevokedAt(), now.Add(time.Minute))
+		}
+	})
+
+	t.Run("有効期限切れのセッションを削除できる", func(t *testing.T) {
+		repo, userID, otherID := newRepo(t)
+		regist(t, repo, "expired", userID, now.Add(-2*time.Hour), now)
+		regist(t, repo, "active", userID, now, now.Add(time.Hour))
+		regist(t, repo, "other", otherID, now.Add(-2*time.Hour), now)
+		if err := repo.DeleteExpired(userID, now); err != nil {
+			t.Fatalf("DeleteExpired() error = %v", err)
+		}
+
+		if _, err := repo.FindByID("expired"); errors.Cause(err) != repository.ErrSessionNotFound {
+			t.Errorf("FindByID() error = %v, want %v", err, repository.ErrSessionNotFound)
+		}
+		for _, id := range []string{"active", "other"} {
+			if _, err := repo.FindByID(id); err != nil {
+				t.Errorf("FindByID(%s) error = %v", id, err)
+			}
+		}
+	})
+}
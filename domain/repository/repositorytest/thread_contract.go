@@ -0,0 +1,127 @@
+package repositorytest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+// ThreadRepositoryFactory テストケースごとに空のスレッドリポジトリと、登録済みの2つの板のIDとユーザーのIDを返す
+type ThreadRepositoryFactory func(t *testing.T) (repository.Thread, string, string, string)
+
+// RunThreadContract スレッドリポジトリの契約テストを実行する
+func RunThreadContract(t *testing.T, newRepo ThreadRepositoryFactory) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	// regist スレッドを登録し、IDを返す
+	regist := func(t *testing.T, repo repository.Thread, boardID, userID string) string {
+		t.Helper()
+		id, err := repo.Regist(model.NewThread("", boardID, userID, "title", 0, time.Time{}, time.Time{}), now)
+		if err != nil {
+			t.Fatalf("Regist() error = %v", err)
+		}
+		return id
+	}
+
+	t.Run("登録したスレッドをIDで取得できる", func(t *testing.T) {
+		repo, boardID, _, userID := newRepo(t)
+		id := regist(t, repo, boardID, userID)
+
+		got, err := repo.FindByID(id)
+		if err != nil {
+			t.Fatalf("FindByID() error = %v", err)
+		}
+		if got.ID() != id ||
+			got.BoardID() != boardID ||
+			got.UserID() != userID ||
+			got.Title() != "title" ||
+			got.PostCount() != 0 ||
+			!got.LastPostedAt().Equal(now) ||
+			!got.CreatedAt().Equal(now) {
+			t.Errorf("FindByID() = %+v", got)
+		}
+	})
+
+	t.Run("板のスレッドを新しい順に件数とbeforeIDで絞り込んで取得できる", func(t *testing.T) {
+		repo, boardID, otherBoardID, userID := newRepo(t)
+		first := regist(t, repo, boardID, userID)
+		second := regist(t, repo, boardID, userID)
+		third := regist(t, repo, boardID, userID)
+		regist(t, repo, otherBoardID, userID)
+
+		got, err := repo.FindByBoardID(boardID, "", 2)
+		if err != nil {
+			t.Fatalf("FindByBoardID() error = %v", err)
+		}
+		if len(got) != 2 || got[0].ID() != third || got[1].ID() != second {
+			t.Errorf("FindByBoardID() = %+v, want ids [%s %s]", got, third, second)
+		}
+
+		got, err = repo.FindByBoardID(boardID, second, 10)
+		if err != nil {
+			t.Fatalf("FindByBoardID() error = %v", err)
+		}
+		if len(got) != 1 || got[0].ID() != first {
+			t.Errorf("FindByBoardID() = %+v, want ids [%s]", got, first)
+		}
+	})
+
+	t.Run("投稿を追加すると連番の投稿番号と最終投稿日時が更新される", func(t *testing.T) {
+		repo, boardID, _, userID := newRepo(t)
+		id := regist(t, repo, boardID, userID)
+
+		for want := 1; want <= 2; want++ {
+			got, err := repo.AddPost(id, now.Add(time.Duration(want)*time.Hour))
+			if err != nil {
+				t.Fatalf("AddPost() error = %v", err)
+			}
+			if got != want {
+				t.Errorf("AddPost() = %d, want %d", got, want)
+			}
+		}
+
+		thread, err := repo.FindByID(id)
+		if err != nil {
+			t.Fatalf("FindByID() error = %v", err)
+		}
+		if thread.PostCount() != 2 || !thread.LastPostedAt().Equal(now.Add(2*time.Hour)) {
+			t.Errorf("FindByID() = %+v", thread)
+		}
+	})
+
+	t.Run("板のスレッドのうち最終投稿日時がsince以降のものをID順に取得できる", func(t *testing.T) {
+		repo, boardID, otherBoardID, userID := newRepo(t)
+		regist(t, repo, boardID, userID)
+		first := regist(t, repo, boardID, userID)
+		second := regist(t, repo, boardID, userID)
+		other := regist(t, repo, otherBoardID, userID)
+		for _, id := range []string{second, first, other} {
+			if _, err := repo.AddPost(id, now.Add(time.Hour)); err != nil {
+				t.Fatalf("AddPost() error = %v", err)
+			}
+		}
+
+		got, err := repo.FindPostedSince(boardID, now.Add(time.Hour))
+		if err != nil {
+			t.Fatalf("FindPostedSince() error = %v", err)
+		}
+		if len(got) != 2 || got[0].ID() != first || got[1].ID() != second {
+			t.Errorf("FindPostedSince() = %+v, want ids [%s %s]", got, first, second)
+		}
+	})
+
+	t.Run("未登録のスレッドは取得も投稿の追加もできない", func(t *testing.T) {
+		repo, _, _, _ := newRepo(t)
+
+		if _, err := repo.FindByID("999999"); !errors.Is(err, repository.ErrThreadNotFound) {
+			t.Errorf("FindByID() error = %v, want %v", err, repository.ErrThreadNotFound)
+		}
+		if _, err := repo.AddPost("999999", now); !errors.Is(err, repository.ErrThreadNotFound) {
+			t.Errorf("AddPost() error = %v, want %v", err, repository.ErrThreadNotFound)
+		}
+	})
+}
@@ -0,0 +1,223 @@
+// Package repositorytest リポジトリインターフェースの共通契約テスト
+//
+// インメモリ実装とSQL実装の双方で同じ振る舞いをすることを検証する。
+package repositorytest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+// UserRepositoryFactory テストケースごとに空のユーザーリポジトリを生成する
+type UserRepositoryFactory func(t *testing.T) repository.User
+
+// RunUserContract ユーザーリポジトリの契約テストを実行する
+func RunUserContract(t *testing.T, newRepo UserRepositoryFactory) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("未登録のメールアドレスはErrUserNotFound", func(t *testing.T) {
+		repo := newRepo(t)
+
+		got, err := repo.FindByEmail("none@example.com")
+		if !errors.Is(err, repository.ErrUserNotFound) {
+			t.Errorf("FindByEmail() error = %v, want %v", err, repository.ErrUserNotFound)
+		}
+		if got != nil {
+			t.Errorf("FindByEmail() = %v, want nil", got)
+		}
+	})
+
+	t.Run("登録したユーザーを取得できる", func(t *testing.T) {
+		repo := newRepo(t)
+
+		if err := repo.Regist(model.NewUser("", "name", "a@example.com", "password", ""), now); err != nil {
+			t.Fatalf("Regist() error = %v", err)
+		}
+
+		got, err := repo.FindByEmail("a@example.com")
+		if err != nil {
+			t.Fatalf("FindByEmail() error = %v", err)
+		}
+		if got.ID() == "" {
+			t.Errorf("ID() is empty")
+		}
+		if got.Name() != "name" || got.Email() != "a@example.com" {
+			t.Errorf("FindByEmail() = (%s, %s), want (name, a@example.com)", got.Name(), got.Email())
+		}
+		if got.Password() == "password" {
+			t.Errorf("Password() is not encrypted")
+		}
+		if ok, err := got.VerifyPassword("password"); err != nil || !ok {
+			t.Errorf("VerifyPassword() = %v, %v, want true, nil", ok, err)
+		}
+	})
+
+	t.Run("未登録のIDはErrUserNotFound", func(t *testing.T) {
+		repo := newRepo(t)
+
+		got, err := repo.FindByID("999999")
+		if !errors.Is(err, repository.ErrUserNotFound) {
+			t.Errorf("FindByID() error = %v, want %v", err, repository.ErrUserNotFound)
+		}
+		if got != nil {
+			t.Errorf("FindByID() = %v, want nil", got)
+		}
+	})
+
+	t.Run("登録したユーザーをIDで取得できる", func(t *testing.T) {
+		repo := newRepo(t)
+
+		profile := model.Profile{
+			DisplayName: "display",
+			Bio:         "bio",
+			AvatarURL:   "https://example.com/a.png",
+			Signature:   "signature",
+		}
+		if err := repo.Regist(model.NewUserWithProfile("", "name", "a@example.com", "password", "", profile), now); err != nil {
+			t.Fatalf("Regist() error = %v", err)
+		}
+		registered, err := repo.FindByEmail("a@example.com")
+		if err != nil {
+			t.Fatalf("FindByEmail() error = %v", err)
+		}
+
+		got, err := repo.FindByID(registered.ID())
+		if err != nil {
+			t.Fatalf("FindByID() error = %v", err)
+		}
+		if got.ID() != registered.ID() || got.Email() != "a@example.com" {
+			t.Errorf("FindByID() = (%s, %s), want (%s, a@example.com)", got.ID(), got.Email(), registered.ID())
+		}
+
+		gotProfile := got.Profile()
+		if !gotProfile.JoinedAt.Equal(now) {
+			t.Errorf("Profile().JoinedAt = %v, want %v", gotProfile.JoinedAt, now)
+		}
+		gotProfile.JoinedAt = time.Time{}
+		if gotProfile != profile {
+			t.Errorf("Profile() = %+v, want %+v", gotProfile, profile)
+		}
+	})
+
+	t.Run("登録済みのメールアドレスは登録できない", func(t *testing.T) {
+		repo := newRepo(t)
+
+		if err := repo.Regist(model.NewUser("", "name", "a@example.com", "password", ""), now); err != nil {
+			t.Fatalf("Regist() error = %v", err)
+		}
+		if err := repo.Regist(model.NewUser("", "other", "a@example.com", "password", ""), now); err == nil {
+			t.Errorf("Regist() error = nil, want error")
+		}
+	})
+
+	t.Run("更新した名前を取得できる", func(t *testing.T) {
+		repo := newRepo(t)
+
+		if err := repo.Regist(model.NewUser("", "name", "a@example.com", "password", ""), now); err != nil {
+			t.Fatalf("Regist() error = %v", err)
+		}
+		registered, err := repo.FindByEmail("a@example.com")
+		if err != nil {
+			t.Fatalf("FindByEmail() error = %v", err)
+		}
+
+		updateUser := model.NewUserWithProfile(
+			registered.ID(),
+			"updated",
+			registered.Email(),
+			registered.Password(),
+			registered.Salt(),
+			model.Profile{DisplayName: "updated display", Bio: "updated bio"},
+		)
+		if err := repo.Update(updateUser, now.Add(time.Hour)); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+
+		got, err := repo.FindByEmail("a@example.com")
+		if err != nil {
+			t.Fatalf("FindByEmail() error = %v", err)
+		}
+		if got.ID() != registered.ID() || got.Name() != "updated" {
+			t.Errorf("FindByEmail() = (%s, %s), want (%s, updated)", got.ID(), got.Name(), registered.ID())
+		}
+		if got.Salt() != registered.Salt() {
+			t.Errorf("Salt() = %s, want %s", got.Salt(), registered.Salt())
+		}
+		if got.Profile().DisplayName != "updated display" || got.Profile().Bio != "updated bio" {
+			t.Errorf("Profile() = %+v, want updated display, updated bio", got.Profile())
+		}
+		if !got.Profile().JoinedAt.Equal(now) {
+			t.Errorf("Profile().JoinedAt = %v, want %v", got.Profile().JoinedAt, now)
+		}
+	})
+
+	t.Run("削除したユーザーは取得できない", func(t *testing.T) {
+		repo := newRepo(t)
+
+		if err := repo.Regist(model.NewUser("", "name", "a@example.com", "password", ""), now); err != nil {
+			t.Fatalf("Regist() error = %v", err)
+		}
+		registered, err := repo.FindByEmail("a@example.com")
+		if err != nil {
+			t.Fatalf("FindByEmail() error = %v", err)
+		}
+
+		if err := repo.Delete(registered); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if _, err := repo.FindByEmail("a@example.com"); !errors.Is(err, repository.ErrUserNotFound) {
+			t.Errorf("FindByEmail() error = %v, want %v", err, repository.ErrUserNotFound)
+		}
+	})
+
+	t.Run("同じ名前のユーザーをID順に上限件数まで取得できる", func(t *testing.T) {
+		repo := newRepo(t)
+
+		for _, u := range []struct{ name, email string }{
+			{"name", "a@example.com"},
+			{"other", "b@example.com"},
+			{"name", "c@example.com"},
+			{"name", "d@example.com"},
+		} {
+			if err := repo.Regist(model.NewUser("", u.name, u.email, "password", ""), now); err != nil {
+				t.Fatalf("Regist() error = %v", err)
+			}
+		}
+
+		got, err := repo.FindByName("name", 2)
+		if err != nil {
+			t.Fatalf("FindByName() error = %v", err)
+		}
+		if len(got) != 2 {
+			t.Fatalf("len(FindByName()) = %d, want 2", len(got))
+		}
+		if got[0].Email() != "a@example.com" || got[1].Email() != "c@example.com" {
+			t.Errorf("FindByName() = (%s, %s), want (a@example.com, c@example.com)", got[0].Email(), got[1].Email())
+		}
+
+		got, err = repo.FindByName("none", 2)
+		if err != nil {
+			t.Fatalf("FindByName() error = %v", err)
+		}
+		if len(got) != 0 {
+			t.Errorf("len(FindByName()) = %d, want 0", len(got))
+		}
+	})
+
+	t.Run("存在しないユーザーの更新と削除はエラーにならない", func(t *testing.T) {
+		repo := newRepo(t)
+
+		user := model.NewUser("999999", "name", "none@example.com", "password", "salt")
+		if err := repo.Update(user, now); err != nil {
+			t.Errorf("Update() error = %v", err)
+		}
+		if err := repo.Delete(user); err != nil {
+			t.Errorf("Delete() error = %v", err)
+		}
+	})
+}
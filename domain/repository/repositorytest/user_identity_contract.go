@@ -0,0 +1,80 @@
+package repositorytest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+// UserIdentityRepositoryFactory テストケースごとに空の外部アカウントリポジトリと登録済みの2ユーザーのIDを返す
+type UserIdentityRepositoryFactory func(t *testing.T) (repository.UserIdentity, string, string)
+
+// RunUserIdentityContract 外部アカウントリポジトリの契約テストを実行する
+func RunUserIdentityContract(t *testing.T, newRepo UserIdentityRepositoryFactory) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("登録した外部アカウントを発行者とsubjectで取得できる", func(t *testing.T) {
+		repo, userID, _ := newRepo(t)
+		if err := repo.Regist(model.NewUserIdentity("https://idp.example.com", "sub-1", userID, "a@example.com", now)); err != nil {
+			t.Fatalf("Regist() error = %v", err)
+		}
+
+		got, err := repo.FindByIssuerSubject("https://idp.example.com", "sub-1")
+		if err != nil {
+			t.Fatalf("FindByIssuerSubject() error = %v", err)
+		}
+		if got.Issuer() != "https://idp.example.com" ||
+			got.Subject() != "sub-1" ||
+			got.UserID() != userID ||
+			got.Email() != "a@example.com" ||
+			!got.CreatedAt().Equal(now) {
+			t.Errorf("FindByIssuerSubject() = %+v", got)
+		}
+
+		if _, err := repo.FindByIssuerSubject("https://idp.example.com", "unknown"); errors.Cause(err) != repository.ErrUserIdentityNotFound {
+			t.Errorf("FindByIssuerSubject() error = %v, want %v", err, repository.ErrUserIdentityNotFound)
+		}
+	})
+
+	t.Run("subjectが同じでも発行者が違えば別のアカウントとして扱う", func(t *testing.T) {
+		repo, userID, otherID := newRepo(t)
+		if err := repo.Regist(model.NewUserIdentity("https://idp.example.com", "sub-1", userID, "a@example.com", now)); err != nil {
+			t.Fatalf("Regist() error = %v", err)
+		}
+		if err := repo.Regist(model.NewUserIdentity("https://other.example.com", "sub-1", otherID, "b@example.com", now)); err != nil {
+			t.Fatalf("Regist() error = %v", err)
+		}
+
+		got, err := repo.FindByIssuerSubject("https://other.example.com", "sub-1")
+		if err != nil {
+			t.Fatalf("FindByIssuerSubject() error = %v", err)
+		}
+		if got.UserID() != otherID {
+			t.Errorf("FindByIssuerSubject() UserID = %v, want %v", got.UserID(), otherID)
+		}
+		if _, err := repo.FindByIssuerSubject("https://idp.example.com", "sub-2"); errors.Cause(err) != repository.ErrUserIdentityNotFound {
+			t.Errorf("FindByIssuerSubject() error = %v, want %v", err, repository.ErrUserIdentityNotFound)
+		}
+	})
+
+	t.Run("ユーザーに紐づく外部アカウントがあるか判定できる", func(t *testing.T) {
+		repo, userID, otherID := newRepo(t)
+		if err := repo.Regist(model.NewUserIdentity("https://idp.example.com", "sub-1", userID, "a@example.com", now)); err != nil {
+			t.Fatalf("Regist() error = %v", err)
+		}
+
+		for id, want := range map[string]bool{userID: true, otherID: false} {
+			got, err := repo.ExistsByUserID(id)
+			if err != nil {
+				t.Fatalf("ExistsByUserID() error = %v", err)
+			}
+			if got != want {
+				t.Errorf("ExistsByUserID(%s) = %v, want %v", id, got, want)
+			}
+		}
+	})
+}
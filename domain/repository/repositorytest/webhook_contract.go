@@ -0,0 +1,234 @@
+package repositorytest
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+// WebhookRepositoryFactory テストケースごとに空のWebhookリポジトリを返す
+type WebhookRepositoryFactory func(t *testing.T) repository.Webhook
+
+// RunWebhookContract Webhookリポジトリの契約テストを実行する
+func RunWebhookContract(t *testing.T, newRepo WebhookRepositoryFactory) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	events := []model.WebhookEvent{model.WebhookThreadCreated, model.WebhookPostHidden}
+
+	// regist Webhookを登録し、IDを返す
+	regist := func(t *testing.T, repo repository.Webhook, boardID string) string {
+		t.Helper()
+		id, err := repo.Regist(model.NewWebhook("", boardID, "https://example.com/"+boardID, "secret", events, now))
+		if err != nil {
+			t.Fatalf("Regist() error = %v", err)
+		}
+		return id
+	}
+
+	// newDelivery 送信待ちの配信を生成する
+	newDelivery := func(webhookID string, nextAttemptAt time.Time) model.WebhookDelivery {
+		return model.NewWebhookDelivery("", webhookID, model.WebhookPostCreated, []byte(`{"event":"post.created"}`),
+			model.WebhookDeliveryPending, 0, nextAttemptAt, 0, "", now, time.Time{})
+	}
+
+	// registDelivery 送信待ちの配信を別々のドメインイベントの配信として登録し、IDを返す
+	lastEventID := 0
+	registDelivery := func(t *testing.T, repo repository.Webhook, webhookID string, nextAttemptAt time.Time) string {
+		t.Helper()
+		lastEventID++
+		id, registered, err := repo.RegistDelivery(strconv.Itoa(lastEventID), newDelivery(webhookID, nextAttemptAt))
+		if err != nil || !registered {
+			t.Fatalf("RegistDelivery() = %v, %v, want true", registered, err)
+		}
+		return id
+	}
+
+	t.Run("登録したWebhookをIDと板で取得できる", func(t *testing.T) {
+		repo := newRepo(t)
+		first := regist(t, repo, "1")
+		second := regist(t, repo, "1")
+		regist(t, repo, "2")
+
+		got, err := repo.FindByID(first)
+		if err != nil {
+			t.Fatalf("FindByID() error = %v", err)
+		}
+		if got.ID() != first ||
+			got.BoardID() != "1" ||
+			got.URL() != "https://example.com/1" ||
+			got.Secret() != "secret" ||
+			!reflect.DeepEqual(got.Events(), events) ||
+			!got.CreatedAt().Equal(now) {
+			t.Errorf("FindByID() = %+v", got)
+		}
+
+		webhooks, err := repo.FindByBoardID("1")
+		if err != nil {
+			t.Fatalf("FindByBoardID() error = %v", err)
+		}
+		if len(webhooks) != 2 || webhooks[0].ID() != first || webhooks[1].ID() != second {
+			t.Errorf("FindByBoardID() = %+v, want ids [%s %s]", webhooks, first, second)
+		}
+	})
+
+	t.Run("未登録のWebhookはErrWebhookNotFound", func(t *testing.T) {
+		repo := newRepo(t)
+		id := regist(t, repo, "1")
+		if err := repo.Delete(id); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+
+		if _, err := repo.FindByID(id); errors.Cause(err) != repository.ErrWebhookNotFound {
+			t.Errorf("FindByID() error = %v, want %v", err, repository.ErrWebhookNotFound)
+		}
+		if err := repo.Delete(id); errors.Cause(err) != repository.ErrWebhookNotFound {
+			t.Errorf("Delete() error = %v, want %v", err, repository.ErrWebhookNotFound)
+		}
+	})
+
+	t.Run("送信時刻に達した送信待ちの配信だけを取得できる", func(t *testing.T) {
+		repo := newRepo(t)
+		webhookID := regist(t, repo, "1")
+		later := registDelivery(t, repo, webhookID, now.Add(-time.Minute))
+		earlier := registDelivery(t, repo, webhookID, now.Add(-time.Hour))
+		registDelivery(t, repo, webhookID, now.Add(time.Minute))
+
+		got, err := repo.FindDueDeliveries(now, 10)
+		if err != nil {
+			t.Fatalf("FindDueDeliveries() error = %v", err)
+		}
+		if len(got) != 2 || got[0].ID() != earlier || got[1].ID() != later {
+			t.Fatalf("FindDueDeliveries() = %+v, want ids [%s %s]", got, earlier, later)
+		}
+		d := got[0]
+		if d.WebhookID() != webhookID ||
+			d.Event() != model.WebhookPostCreated ||
+			string(d.Payload()) != `{"event":"post.created"}` ||
+			d.Status() != model.WebhookDeliveryPending ||
+			d.Attempts() != 0 ||
+			!d.NextAttemptAt().Equal(now.Add(-time.Hour)) ||
+			d.LastStatusCode() != 0 ||
+			d.LastError() != "" ||
+			!d.CreatedAt().Equal(now) ||
+			!d.DeliveredAt().IsZero() {
+			t.Errorf("FindDueDeliveries() = %+v", d)
+		}
+
+		got, err = repo.FindDueDeliveries(now, 1)
+		if err != nil {
+			t.Fatalf("FindDueDeliveries() error = %v", err)
+		}
+		if len(got) != 1 || got[0].ID() != earlier {
+			t.Errorf("FindDueDeliveries() = %+v, want id %s", got, earlier)
+		}
+	})
+
+	t.Run("更新した配信の状態を取得できる", func(t *testing.T) {
+		repo := newRepo(t)
+		webhookID := regist(t, repo, "1")
+		succeeded := registDelivery(t, repo, webhookID, now)
+		retrying := registDelivery(t, repo, webhookID, now)
+
+		updates := []model.WebhookDelivery{
+			model.NewWebhookDelivery(succeeded, webhookID, model.WebhookPostCreated, nil,
+				model.WebhookDeliverySucceeded, 1, now, 204, "", now, now.Add(time.Second)),
+			model.NewWebhookDelivery(retrying, webhookID, model.WebhookPostCreated, nil,
+				model.WebhookDeliveryPending, 1, now.Add(time.Minute), 503, "unexpected status", now, time.Time{}),
+		}
+		for _, d := range updates {
+			if err := repo.UpdateDelivery(d); err != nil {
+				t.Fatalf("UpdateDelivery() error = %v", err)
+			}
+		}
+
+		due, err := repo.FindDueDeliveries(now, 10)
+		if err != nil {
+			t.Fatalf("FindDueDeliveries() error = %v", err)
+		}
+		if len(due) != 0 {
+			t.Errorf("FindDueDeliveries() = %+v, want empty", due)
+		}
+
+		got, err := repo.FindDeliveriesByWebhookID(webhookID, "", 10)
+		if err != nil {
+			t.Fatalf("FindDeliveriesByWebhookID() error = %v", err)
+		}
+		if len(got) != 2 || got[0].ID() != retrying || got[1].ID() != succeeded {
+			t.Fatalf("FindDeliveriesByWebhookID() = %+v, want ids [%s %s]", got, retrying, succeeded)
+		}
+		if d := got[0]; d.Status() != model.WebhookDeliveryPending ||
+			d.Attempts() != 1 ||
+			!d.NextAttemptAt().Equal(now.Add(time.Minute)) ||
+			d.LastStatusCode() != 503 ||
+			d.LastError() != "unexpected status" ||
+			string(d.Payload()) != `{"event":"post.created"}` {
+			t.Errorf("FindDeliveriesByWebhookID() = %+v", d)
+		}
+		if d := got[1]; d.Status() != model.WebhookDeliverySucceeded ||
+			d.LastStatusCode() != 204 ||
+			!d.DeliveredAt().Equal(now.Add(time.Second)) {
+			t.Errorf("FindDeliveriesByWebhookID() = %+v", d)
+		}
+
+		got, err = repo.FindDeliveriesByWebhookID(webhookID, retrying, 10)
+		if err != nil {
+			t.Fatalf("FindDeliveriesByWebhookID() error = %v", err)
+		}
+		if len(got) != 1 || got[0].ID() != succeeded {
+			t.Errorf("FindDeliveriesByWebhookID() = %+v, want id %s", got, succeeded)
+		}
+	})
+
+	t.Run("同じWebhookへの同じイベントの配信は1件だけ登録される", func(t *testing.T) {
+		repo := newRepo(t)
+		webhookID := regist(t, repo, "1")
+		otherWebhookID := regist(t, repo, "1")
+
+		for _, tt := range []struct {
+			webhookID string
+			want      bool
+		}{
+			{webhookID, true},
+			{webhookID, false},
+			{otherWebhookID, true},
+		} {
+			id, registered, err := repo.RegistDelivery("100", newDelivery(tt.webhookID, now))
+			if err != nil {
+				t.Fatalf("RegistDelivery() error = %v", err)
+			}
+			if registered != tt.want || (id != "") != tt.want {
+				t.Errorf("RegistDelivery() = %q, %v, want registered %v", id, registered, tt.want)
+			}
+		}
+
+		due, err := repo.FindDueDeliveries(now, 10)
+		if err != nil {
+			t.Fatalf("FindDueDeliveries() error = %v", err)
+		}
+		if len(due) != 2 {
+			t.Errorf("len(FindDueDeliveries()) = %d, want 2", len(due))
+		}
+	})
+
+	t.Run("Webhookを削除すると配信も削除される", func(t *testing.T) {
+		repo := newRepo(t)
+		webhookID := regist(t, repo, "1")
+		registDelivery(t, repo, webhookID, now)
+		if err := repo.Delete(webhookID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+
+		due, err := repo.FindDueDeliveries(now, 10)
+		if err != nil {
+			t.Fatalf("FindDueDeliveries() error = %v", err)
+		}
+		if len(due) != 0 {
+			t.Errorf("FindDueDeliveries() = %+v, want empty", due)
+		}
+	})
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"GoBBS/domain/model"
+	"errors"
+	"time"
+)
+
+var (
+	ErrSessionNotFound = errors.New("session not found")
+)
+
+// Session ログインセッションのリポジトリ
+// mockgen -source domain/repository/session_repository.go -destination mock/mock_repository/session_repository_mock.go
+type Session interface {
+	Regist(session model.Session) error
+	FindByID(id string) (model.Session, error)
+	FindActiveByUserID(userID string, now time.Time) ([]model.Session, error)
+	Touch(id string, now time.Time) error
+	Revoke(userID string, id string, now time.Time) error
+	DeleteExpired(userID string, now time.Time) error
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"GoBBS/domain/model"
+	"errors"
+	"time"
+)
+
+var (
+	ErrThreadNotFound = errors.New("thread not found")
+)
+
+// Thread スレッドリポジトリ
+// mockgen -source domain/repository/thread_repository.go -destination mock/mock_repository/thread_repository_mock.go
+type Thread interface {
+	FindByBoardID(boardID string, beforeID string, limit int) ([]model.Thread, error)
+	FindByID(id string) (model.Thread, error)
+	FindPostedSince(boardID string, since time.Time) ([]model.Thread, error)
+	Regist(thread model.Thread, now time.Time) (string, error)
+	AddPost(id string, now time.Time) (int, error)
+}
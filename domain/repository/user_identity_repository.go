@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"GoBBS/domain/model"
+	"errors"
+)
+
+var (
+	ErrUserIdentityNotFound = errors.New("user identity not found")
+)
+
+// UserIdentity 外部IdPのアカウントのリポジトリ
+// mockgen -source domain/repository/user_identity_repository.go -destination mock/mock_repository/user_identity_repository_mock.go
+type UserIdentity interface {
+	FindByIssuerSubject(issuer string, subject string) (model.UserIdentity, error)
+	Regist(identity model.UserIdentity) error
+	ExistsByUserID(userID string) (bool, error)
+}
@@ -13,7 +13,9 @@ var (
 // User ユーザーリポジトリ
 // mockgen -source domain/repository/user_repository.go -destination mock/mock_repository/user_repository_mock.go
 type User interface {
+	FindByID(id string) (model.User, error)
 	FindByEmail(email string) (model.User, error)
+	FindByName(name string, limit int) ([]model.User, error)
 	Regist(user model.User, now time.Time) error
 	Update(user model.User, now time.Time) error
 	Delete(user model.User) error
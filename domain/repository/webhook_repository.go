@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"GoBBS/domain/model"
+	"errors"
+	"time"
+)
+
+var (
+	ErrWebhookNotFound = errors.New("webhook not found")
+)
+
+// Webhook Webhookと配信アウトボックスのリポジトリ
+// mockgen -source domain/repository/webhook_repository.go -destination mock/mock_repository/webhook_repository_mock.go
+type Webhook interface {
+	Regist(webhook model.Webhook) (string, error)
+	FindByID(id string) (model.Webhook, error)
+	FindByBoardID(boardID string) ([]model.Webhook, error)
+	Delete(id string) error
+	RegistDelivery(eventID string, delivery model.WebhookDelivery) (string, bool, error)
+	FindDueDeliveries(now time.Time, limit int) ([]model.WebhookDelivery, error)
+	UpdateDelivery(delivery model.WebhookDelivery) error
+	FindDeliveriesByWebhookID(webhookID string, beforeID string, limit int) ([]model.WebhookDelivery, error)
+}
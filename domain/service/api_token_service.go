@@ -0,0 +1,196 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+type (
+	// APIToken 個人用アクセストークンサービス
+	// mockgen -source domain/service/api_token_service.go -destination mock/mock_service/api_token_service_mock.go
+	APIToken interface {
+		Create(userID string, name string, scopes []string, expiresAt time.Time, now time.Time) (string, model.APIToken, error)
+		Verify(token string, now time.Time) (model.APIToken, error)
+		List(userID string, now time.Time) ([]model.APIToken, error)
+		Revoke(userID string, id string) error
+	}
+
+	// APITokenFactory 個人用アクセストークンサービスファクトリー
+	APITokenFactory interface {
+		NewAPITokenService(repo repository.APIToken) APIToken
+	}
+
+	apiTokenService struct {
+		repo     repository.APIToken
+		newID    func() (string, error)
+		newToken func() (string, error)
+	}
+
+	apiTokenServiceFactory struct{}
+)
+
+var _ APIToken = (*apiTokenService)(nil)
+
+var (
+	ErrAPITokenNameInvalid   = errors.New("api token name invalid")
+	ErrAPITokenScopeInvalid  = errors.New("api token scope invalid")
+	ErrAPITokenExpiryInvalid = errors.New("api token expiry invalid")
+	ErrAPITokenNotFound      = errors.New("api token not found")
+	ErrAPITokenInvalid       = errors.New("api token invalid")
+)
+
+const (
+	// maxAPITokenNameLength トークンの名前の長さの上限(文字数)
+	maxAPITokenNameLength = 100
+	// MaxAPITokenLifetime トークンの有効期間の上限(無期限のトークンは発行しない)
+	MaxAPITokenLifetime = 365 * 24 * time.Hour
+	// apiTokenTouchInterval 最終利用日時を更新する間隔(リクエストごとに更新しないようにする)
+	apiTokenTouchInterval = time.Minute
+)
+
+// NewAPITokenServiceFactory 個人用アクセストークンサービスファクトリーを生成する
+func NewAPITokenServiceFactory() *apiTokenServiceFactory {
+	return &apiTokenServiceFactory{}
+}
+
+// NewAPITokenService 個人用アクセストークンサービスを生成する
+func (f *apiTokenServiceFactory) NewAPITokenService(repo repository.APIToken) APIToken {
+	return &apiTokenService{repo: repo, newID: newAPITokenID, newToken: newAPIToken}
+}
+
+// Create トークンを発行し、トークンと登録したトークンの情報を返す
+// トークンはハッシュだけを保存するため、発行時にしか返せない
+// 有効期限切れのトークンはここで削除する
+func (s *apiTokenService) Create(userID string, name string, scopes []string, expiresAt time.Time, now time.Time) (string, model.APIToken, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxAPITokenNameLength {
+		return "", nil, ErrAPITokenNameInvalid
+	}
+	scopes, ok := normalizeAPIScopes(scopes)
+	if !ok {
+		return "", nil, ErrAPITokenScopeInvalid
+	}
+	if !expiresAt.After(now) || expiresAt.Sub(now) > MaxAPITokenLifetime {
+		return "", nil, ErrAPITokenExpiryInvalid
+	}
+
+	id, err := s.newID()
+	if err != nil {
+		return "", nil, errors.Wrap(err, "Create error")
+	}
+	token, err := s.newToken()
+	if err != nil {
+		return "", nil, errors.Wrap(err, "Create error")
+	}
+
+	if err := s.repo.DeleteExpired(userID, now); err != nil {
+		return "", nil, errors.Wrap(err, "Create error")
+	}
+
+	apiToken := model.NewAPIToken(id, userID, name, model.HashAPIToken(token), scopes, expiresAt, time.Time{}, now)
+	if err := s.repo.Regist(apiToken); err != nil {
+		return "", nil, errors.Wrap(err, "Create error")
+	}
+
+	return token, apiToken, nil
+}
+
+// Verify トークンが有効期限内か検証し、トークンの情報を返す
+// 検証に成功した場合、前回から一定時間経っていれば最終利用日時を更新する
+func (s *apiTokenService) Verify(token string, now time.Time) (model.APIToken, error) {
+	if !model.IsAPIToken(token) {
+		return nil, ErrAPITokenInvalid
+	}
+
+	apiToken, err := s.repo.FindByHash(model.HashAPIToken(token))
+	if errors.Is(err, repository.ErrAPITokenNotFound) {
+		return nil, ErrAPITokenInvalid
+	} else if err != nil {
+		return nil, errors.Wrap(err, "Verify error")
+	}
+	if !apiToken.IsValid(now) {
+		return nil, ErrAPITokenInvalid
+	}
+
+	if now.Sub(apiToken.LastUsedAt()) >= apiTokenTouchInterval {
+		if err := s.repo.Touch(apiToken.ID(), now); err != nil {
+			return nil, errors.Wrap(err, "Verify error")
+		}
+	}
+
+	return apiToken, nil
+}
+
+// List ユーザーの有効なトークンを発行の新しい順に取得する
+func (s *apiTokenService) List(userID string, now time.Time) ([]model.APIToken, error) {
+	tokens, err := s.repo.FindActiveByUserID(userID, now)
+	if err != nil {
+		return nil, errors.Wrap(err, "List error")
+	}
+
+	return tokens, nil
+}
+
+// Revoke ユーザーのトークンを削除する(以降そのトークンは認証に使えない)
+func (s *apiTokenService) Revoke(userID string, id string) error {
+	err := s.repo.Delete(userID, id)
+	if errors.Is(err, repository.ErrAPITokenNotFound) {
+		return ErrAPITokenNotFound
+	} else if err != nil {
+		return errors.Wrap(err, "Revoke error")
+	}
+
+	return nil
+}
+
+// normalizeAPIScopes スコープの重複を除き、model.APIScopesの順に並べる
+// 空の場合、または未知のスコープを含む場合はokにfalseを返す
+func normalizeAPIScopes(scopes []string) ([]string, bool) {
+	requested := map[string]bool{}
+	for _, scope := range scopes {
+		if !model.IsAPIScope(scope) {
+			return nil, false
+		}
+		requested[scope] = true
+	}
+	if len(requested) == 0 {
+		return nil, false
+	}
+
+	normalized := make([]string, 0, len(requested))
+	for _, scope := range model.APIScopes {
+		if requested[scope] {
+			normalized = append(normalized, scope)
+		}
+	}
+	return normalized, true
+}
+
+// newAPITokenID トークンのIDを生成する
+func newAPITokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// newAPIToken 推測できないトークンを生成する
+func newAPIToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return model.APITokenPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
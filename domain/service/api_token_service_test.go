@@ -0,0 +1,293 @@
+package service
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+	"GoBBS/mock/mock_repository"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewAPITokenService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repository.NewMockAPIToken(ctrl)
+	got, ok := NewAPITokenServiceFactory().NewAPITokenService(repo).(*apiTokenService)
+	if !ok || got.repo != repo {
+		t.Fatalf("NewAPITokenService() = %v", got)
+	}
+	if reflect.ValueOf(got.newID).Pointer() != reflect.ValueOf(newAPITokenID).Pointer() {
+		t.Errorf("NewAPITokenService().newID is not newAPITokenID")
+	}
+	if reflect.ValueOf(got.newToken).Pointer() != reflect.ValueOf(newAPIToken).Pointer() {
+		t.Errorf("NewAPITokenService().newToken is not newAPIToken")
+	}
+}
+
+func Test_newAPITokenID(t *testing.T) {
+	a, err := newAPITokenID()
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	b, _ := newAPITokenID()
+	if len(a) != 32 || a == b {
+		t.Errorf("戻り値不一致 got: %#v, %#v", a, b)
+	}
+}
+
+func Test_newAPIToken(t *testing.T) {
+	a, err := newAPIToken()
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	b, _ := newAPIToken()
+	if !model.IsAPIToken(a) || len(a) != len(model.APITokenPrefix)+43 || a == b {
+		t.Errorf("戻り値不一致 got: %#v, %#v", a, b)
+	}
+}
+
+func Test_apiTokenService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	expires := now.Add(30 * 24 * time.Hour)
+	newID := func() (string, error) { return "tid", nil }
+	newToken := func() (string, error) { return "gbp_secret", nil }
+	want := model.NewAPIToken("tid", "1", "ci-bot", model.HashAPIToken("gbp_secret"), []string{"read", "post"}, expires, time.Time{}, now)
+
+	type args struct {
+		name      string
+		scopes    []string
+		expiresAt time.Time
+	}
+	tests := []struct {
+		name      string
+		repo      func() *mock_repository.MockAPIToken
+		args      args
+		wantToken string
+		want      model.APIToken
+		wantErr   error
+	}{
+		{
+			name: "正常ケース(スコープは重複を除き決まった順に並べる)",
+			repo: func() *mock_repository.MockAPIToken {
+				mock := mock_repository.NewMockAPIToken(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().DeleteExpired("1", now).Return(nil),
+					mock.EXPECT().Regist(want).Return(nil),
+				)
+				return mock
+			},
+			args:      args{name: " ci-bot ", scopes: []string{"post", "read", "post"}, expiresAt: expires},
+			wantToken: "gbp_secret",
+			want:      want,
+		},
+		{
+			name:    "異常ケース(名前なし)",
+			repo:    func() *mock_repository.MockAPIToken { return mock_repository.NewMockAPIToken(ctrl) },
+			args:    args{name: " ", scopes: []string{"read"}, expiresAt: expires},
+			wantErr: ErrAPITokenNameInvalid,
+		},
+		{
+			name:    "異常ケース(名前が長すぎる)",
+			repo:    func() *mock_repository.MockAPIToken { return mock_repository.NewMockAPIToken(ctrl) },
+			args:    args{name: strings.Repeat("あ", 101), scopes: []string{"read"}, expiresAt: expires},
+			wantErr: ErrAPITokenNameInvalid,
+		},
+		{
+			name:    "異常ケース(スコープなし)",
+			repo:    func() *mock_repository.MockAPIToken { return mock_repository.NewMockAPIToken(ctrl) },
+			args:    args{name: "ci-bot", expiresAt: expires},
+			wantErr: ErrAPITokenScopeInvalid,
+		},
+		{
+			name:    "異常ケース(未知のスコープ)",
+			repo:    func() *mock_repository.MockAPIToken { return mock_repository.NewMockAPIToken(ctrl) },
+			args:    args{name: "ci-bot", scopes: []string{"read", "admin"}, expiresAt: expires},
+			wantErr: ErrAPITokenScopeInvalid,
+		},
+		{
+			name:    "異常ケース(有効期限が過去)",
+			repo:    func() *mock_repository.MockAPIToken { return mock_repository.NewMockAPIToken(ctrl) },
+			args:    args{name: "ci-bot", scopes: []string{"read"}, expiresAt: now},
+			wantErr: ErrAPITokenExpiryInvalid,
+		},
+		{
+			name:    "異常ケース(有効期間が長すぎる)",
+			repo:    func() *mock_repository.MockAPIToken { return mock_repository.NewMockAPIToken(ctrl) },
+			args:    args{name: "ci-bot", scopes: []string{"read"}, expiresAt: now.Add(MaxAPITokenLifetime + time.Second)},
+			wantErr: ErrAPITokenExpiryInvalid,
+		},
+		{
+			name: "異常ケース(登録エラー)",
+			repo: func() *mock_repository.MockAPIToken {
+				mock := mock_repository.NewMockAPIToken(ctrl)
+				mock.EXPECT().DeleteExpired("1", now).Return(nil)
+				mock.EXPECT().Regist(gomock.Any()).Return(errTest)
+				return mock
+			},
+			args:    args{name: "ci-bot", scopes: []string{"read"}, expiresAt: expires},
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &apiTokenService{repo: tt.repo(), newID: newID, newToken: newToken}
+			token, got, err := s.Create("1", tt.args.name, tt.args.scopes, tt.args.expiresAt, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if token != tt.wantToken || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v, %#v want: %#v, %#v", token, got, tt.wantToken, tt.want)
+			}
+		})
+	}
+}
+
+func Test_apiTokenService_Verify(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	hash := model.HashAPIToken("gbp_secret")
+	token := func(expiresAt time.Time, lastUsedAt time.Time) model.APIToken {
+		return model.NewAPIToken("tid", "1", "ci-bot", hash, []string{"read"}, expiresAt, lastUsedAt, now.Add(-time.Hour))
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		repo    func() *mock_repository.MockAPIToken
+		want    model.APIToken
+		wantErr error
+	}{
+		{
+			name:  "正常ケース(最終利用日時を更新)",
+			token: "gbp_secret",
+			repo: func() *mock_repository.MockAPIToken {
+				mock := mock_repository.NewMockAPIToken(ctrl)
+				mock.EXPECT().FindByHash(hash).Return(token(now.Add(time.Hour), time.Time{}), nil)
+				mock.EXPECT().Touch("tid", now).Return(nil)
+				return mock
+			},
+			want: token(now.Add(time.Hour), time.Time{}),
+		},
+		{
+			name:  "正常ケース(直前に使われた場合は更新しない)",
+			token: "gbp_secret",
+			repo: func() *mock_repository.MockAPIToken {
+				mock := mock_repository.NewMockAPIToken(ctrl)
+				mock.EXPECT().FindByHash(hash).Return(token(now.Add(time.Hour), now.Add(-time.Second)), nil)
+				return mock
+			},
+			want: token(now.Add(time.Hour), now.Add(-time.Second)),
+		},
+		{
+			name:    "異常ケース(形式不正)",
+			token:   "eyJhbGciOiJIUzI1NiJ9.e30.sig",
+			repo:    func() *mock_repository.MockAPIToken { return mock_repository.NewMockAPIToken(ctrl) },
+			wantErr: ErrAPITokenInvalid,
+		},
+		{
+			name:  "異常ケース(トークンなし)",
+			token: "gbp_secret",
+			repo: func() *mock_repository.MockAPIToken {
+				mock := mock_repository.NewMockAPIToken(ctrl)
+				mock.EXPECT().FindByHash(hash).Return(nil, repository.ErrAPITokenNotFound)
+				return mock
+			},
+			wantErr: ErrAPITokenInvalid,
+		},
+		{
+			name:  "異常ケース(有効期限切れ)",
+			token: "gbp_secret",
+			repo: func() *mock_repository.MockAPIToken {
+				mock := mock_repository.NewMockAPIToken(ctrl)
+				mock.EXPECT().FindByHash(hash).Return(token(now, time.Time{}), nil)
+				return mock
+			},
+			wantErr: ErrAPITokenInvalid,
+		},
+		{
+			name:  "異常ケース(取得エラー)",
+			token: "gbp_secret",
+			repo: func() *mock_repository.MockAPIToken {
+				mock := mock_repository.NewMockAPIToken(ctrl)
+				mock.EXPECT().FindByHash(hash).Return(nil, errTest)
+				return mock
+			},
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := (&apiTokenService{repo: tt.repo()}).Verify(tt.token, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_apiTokenService_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tokens := []model.APIToken{model.NewAPIToken("tid", "1", "ci-bot", "hash", []string{"read"}, now.Add(time.Hour), time.Time{}, now)}
+
+	repo := mock_repository.NewMockAPIToken(ctrl)
+	repo.EXPECT().FindActiveByUserID("1", now).Return(tokens, nil)
+	repo.EXPECT().FindActiveByUserID("2", now).Return(nil, errors.New("test"))
+
+	s := &apiTokenService{repo: repo}
+	got, err := s.List("1", now)
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	if !reflect.DeepEqual(got, tokens) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, tokens)
+	}
+	if _, err := s.List("2", now); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
+
+func Test_apiTokenService_Revoke(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+
+	tests := []struct {
+		name    string
+		repoErr error
+		wantErr error
+	}{
+		{name: "正常ケース"},
+		{name: "異常ケース(トークンなし)", repoErr: repository.ErrAPITokenNotFound, wantErr: ErrAPITokenNotFound},
+		{name: "異常ケース(削除エラー)", repoErr: errTest, wantErr: errTest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mock_repository.NewMockAPIToken(ctrl)
+			repo.EXPECT().Delete("1", "tid").Return(tt.repoErr)
+
+			if err := (&apiTokenService{repo: repo}).Revoke("1", "tid"); !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+type (
+	// Attachment 添付ファイルサービス
+	// mockgen -source domain/service/attachment_service.go -destination mock/mock_service/attachment_service_mock.go
+	Attachment interface {
+		FindByID(id string) (model.Attachment, error)
+		Regist(attachment model.Attachment, now time.Time) (model.Attachment, error)
+	}
+
+	// AttachmentFactory 添付ファイルサービスファクトリー
+	AttachmentFactory interface {
+		NewAttachmentService(repo repository.Attachment) Attachment
+	}
+
+	attachmentService struct {
+		repo repository.Attachment
+	}
+
+	attachmentServiceFactory struct{}
+)
+
+var _ Attachment = (*attachmentService)(nil)
+
+var (
+	ErrAttachmentNotFound = errors.New("attachment not found")
+)
+
+// NewAttachmentServiceFactory 添付ファイルサービスファクトリーを生成する
+func NewAttachmentServiceFactory() *attachmentServiceFactory {
+	return &attachmentServiceFactory{}
+}
+
+// NewAttachmentService 添付ファイルサービスを生成する
+func (f *attachmentServiceFactory) NewAttachmentService(repo repository.Attachment) Attachment {
+	return &attachmentService{repo: repo}
+}
+
+// FindByID IDを指定して添付ファイルを取得する
+func (s *attachmentService) FindByID(id string) (model.Attachment, error) {
+	attachment, err := s.repo.FindByID(id)
+	if errors.Is(err, repository.ErrAttachmentNotFound) {
+		return nil, ErrAttachmentNotFound
+	} else if err != nil {
+		return nil, errors.Wrap(err, "FindByID error")
+	}
+
+	return attachment, nil
+}
+
+// Regist 添付ファイルを登録し、登録後の添付ファイルを返す
+func (s *attachmentService) Regist(attachment model.Attachment, now time.Time) (model.Attachment, error) {
+	id, err := s.repo.Regist(attachment, now)
+	if err != nil {
+		return nil, errors.Wrap(err, "Regist error")
+	}
+
+	return s.FindByID(id)
+}
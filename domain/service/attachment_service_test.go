@@ -0,0 +1,145 @@
+package service
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+	"GoBBS/mock/mock_repository"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewAttachmentService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repository.NewMockAttachment(ctrl)
+	want := &attachmentService{repo: repo}
+	if got := NewAttachmentServiceFactory().NewAttachmentService(repo); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewAttachmentService() = %v, want %v", got, want)
+	}
+}
+
+func Test_attachmentService_FindByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	attachment := model.NewAttachment("1", "2", "", "a.png", "a-thumb.png", "image/png", 100, 20, 10, now)
+
+	tests := []struct {
+		name    string
+		s       *attachmentService
+		want    model.Attachment
+		wantErr error
+	}{
+		{
+			name: "正常ケース",
+			s: &attachmentService{
+				repo: func() *mock_repository.MockAttachment {
+					mock := mock_repository.NewMockAttachment(ctrl)
+					mock.EXPECT().FindByID("1").Return(attachment, nil)
+					return mock
+				}(),
+			},
+			want:    attachment,
+			wantErr: nil,
+		},
+		{
+			name: "異常ケース(添付ファイルが未登録)",
+			s: &attachmentService{
+				repo: func() *mock_repository.MockAttachment {
+					mock := mock_repository.NewMockAttachment(ctrl)
+					mock.EXPECT().FindByID("1").Return(nil, repository.ErrAttachmentNotFound)
+					return mock
+				}(),
+			},
+			want:    nil,
+			wantErr: ErrAttachmentNotFound,
+		},
+		{
+			name: "異常ケース(取得失敗)",
+			s: &attachmentService{
+				repo: func() *mock_repository.MockAttachment {
+					mock := mock_repository.NewMockAttachment(ctrl)
+					mock.EXPECT().FindByID("1").Return(nil, errTest)
+					return mock
+				}(),
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.s.FindByID("1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("attachmentService.FindByID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("attachmentService.FindByID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_attachmentService_Regist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	input := model.NewAttachment("", "2", "", "a.png", "a-thumb.png", "image/png", 100, 20, 10, time.Time{})
+	saved := model.NewAttachment("1", "2", "", "a.png", "a-thumb.png", "image/png", 100, 20, 10, now)
+
+	tests := []struct {
+		name    string
+		s       *attachmentService
+		want    model.Attachment
+		wantErr bool
+	}{
+		{
+			name: "正常ケース",
+			s: &attachmentService{
+				repo: func() *mock_repository.MockAttachment {
+					mock := mock_repository.NewMockAttachment(ctrl)
+					gomock.InOrder(
+						mock.EXPECT().Regist(input, now).Return("1", nil),
+						mock.EXPECT().FindByID("1").Return(saved, nil),
+					)
+					return mock
+				}(),
+			},
+			want:    saved,
+			wantErr: false,
+		},
+		{
+			name: "異常ケース(登録失敗)",
+			s: &attachmentService{
+				repo: func() *mock_repository.MockAttachment {
+					mock := mock_repository.NewMockAttachment(ctrl)
+					mock.EXPECT().Regist(input, now).Return("", errors.New("ng"))
+					return mock
+				}(),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.s.Regist(input, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("attachmentService.Regist() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("attachmentService.Regist() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
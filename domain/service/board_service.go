@@ -0,0 +1,96 @@
+package service
+
+import (
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+type (
+	// Board 板サービス
+	// mockgen -source domain/service/board_service.go -destination mock/mock_service/board_service_mock.go
+	Board interface {
+		List() ([]model.Board, error)
+		Find(id string) (model.Board, error)
+		Regist(name string, description string, now time.Time) (model.Board, error)
+	}
+
+	// BoardFactory 板サービスファクトリー
+	BoardFactory interface {
+		NewBoardService(repo repository.Board) Board
+	}
+
+	boardService struct {
+		repo repository.Board
+	}
+
+	boardServiceFactory struct{}
+)
+
+var _ Board = (*boardService)(nil)
+
+var (
+	ErrBoardNotFound           = errors.New("board not found")
+	ErrInvalidBoardName        = errors.New("invalid board name")
+	ErrInvalidBoardDescription = errors.New("invalid board description")
+)
+
+const (
+	// maxBoardNameLength 板の名前の文字数の上限
+	maxBoardNameLength = 100
+	// maxBoardDescriptionLength 板の説明の文字数の上限
+	maxBoardDescriptionLength = 1024
+)
+
+// NewBoardServiceFactory 板サービスファクトリーを生成する
+func NewBoardServiceFactory() *boardServiceFactory {
+	return &boardServiceFactory{}
+}
+
+// NewBoardService 板サービスを生成する
+func (f *boardServiceFactory) NewBoardService(repo repository.Board) Board {
+	return &boardService{repo: repo}
+}
+
+// List 板を登録順に返す
+func (s *boardService) List() ([]model.Board, error) {
+	boards, err := s.repo.FindAll()
+	if err != nil {
+		return nil, errors.Wrap(err, "List error")
+	}
+
+	return boards, nil
+}
+
+// Find IDで板を返す
+func (s *boardService) Find(id string) (model.Board, error) {
+	board, err := s.repo.FindByID(id)
+	if errors.Is(err, repository.ErrBoardNotFound) {
+		return nil, ErrBoardNotFound
+	} else if err != nil {
+		return nil, errors.Wrap(err, "Find error")
+	}
+
+	return board, nil
+}
+
+// Regist 板を登録する
+func (s *boardService) Regist(name string, description string, now time.Time) (model.Board, error) {
+	if !isValidText(name, maxBoardNameLength) {
+		return nil, ErrInvalidBoardName
+	}
+	if utf8.RuneCountInString(description) > maxBoardDescriptionLength {
+		return nil, ErrInvalidBoardDescription
+	}
+
+	id, err := s.repo.Regist(model.NewBoard("", name, description, now), now)
+	if err != nil {
+		return nil, errors.Wrap(err, "Regist error")
+	}
+
+	return model.NewBoard(id, name, description, now), nil
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+	"GoBBS/mock/mock_repository"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewBoardService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repository.NewMockBoard(ctrl)
+	want := &boardService{repo: repo}
+	if got := NewBoardServiceFactory().NewBoardService(repo); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewBoardService() = %v, want %v", got, want)
+	}
+}
+
+func Test_boardService_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	boards := []model.Board{model.NewBoard("1", "雑談", "", time.Time{})}
+
+	tests := []struct {
+		name    string
+		s       *boardService
+		want    []model.Board
+		wantErr error
+	}{
+		{
+			name: "正常ケース",
+			s: &boardService{
+				repo: func() *mock_repository.MockBoard {
+					mock := mock_repository.NewMockBoard(ctrl)
+					mock.EXPECT().FindAll().Return(boards, nil)
+					return mock
+				}(),
+			},
+			want:    boards,
+			wantErr: nil,
+		},
+		{
+			name: "異常ケース",
+			s: &boardService{
+				repo: func() *mock_repository.MockBoard {
+					mock := mock_repository.NewMockBoard(ctrl)
+					mock.EXPECT().FindAll().Return(nil, errTest)
+					return mock
+				}(),
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.s.List()
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_boardService_Find(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	board := model.NewBoard("1", "雑談", "", time.Time{})
+
+	tests := []struct {
+		name    string
+		s       *boardService
+		want    model.Board
+		wantErr error
+	}{
+		{
+			name: "正常ケース",
+			s: &boardService{
+				repo: func() *mock_repository.MockBoard {
+					mock := mock_repository.NewMockBoard(ctrl)
+					mock.EXPECT().FindByID("1").Return(board, nil)
+					return mock
+				}(),
+			},
+			want:    board,
+			wantErr: nil,
+		},
+		{
+			name: "異常ケース(板なし)",
+			s: &boardService{
+				repo: func() *mock_repository.MockBoard {
+					mock := mock_repository.NewMockBoard(ctrl)
+					mock.EXPECT().FindByID("1").Return(nil, repository.ErrBoardNotFound)
+					return mock
+				}(),
+			},
+			want:    nil,
+			wantErr: ErrBoardNotFound,
+		},
+		{
+			name: "異常ケース(取得失敗)",
+			s: &boardService{
+				repo: func() *mock_repository.MockBoard {
+					mock := mock_repository.NewMockBoard(ctrl)
+					mock.EXPECT().FindByID("1").Return(nil, errTest)
+					return mock
+				}(),
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.s.Find("1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_boardService_Regist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		boardName   string
+		description string
+		s           *boardService
+		want        model.Board
+		wantErr     error
+	}{
+		{
+			name:        "正常ケース",
+			boardName:   "雑談",
+			description: "説明",
+			s: &boardService{
+				repo: func() *mock_repository.MockBoard {
+					mock := mock_repository.NewMockBoard(ctrl)
+					mock.EXPECT().Regist(model.NewBoard("", "雑談", "説明", now), now).Return("1", nil)
+					return mock
+				}(),
+			},
+			want:    model.NewBoard("1", "雑談", "説明", now),
+			wantErr: nil,
+		},
+		{
+			name:        "異常ケース(名前が空白のみ)",
+			boardName:   " \n",
+			description: "",
+			s:           &boardService{},
+			want:        nil,
+			wantErr:     ErrInvalidBoardName,
+		},
+		{
+			name:        "異常ケース(名前が長すぎる)",
+			boardName:   strings.Repeat("あ", maxBoardNameLength+1),
+			description: "",
+			s:           &boardService{},
+			want:        nil,
+			wantErr:     ErrInvalidBoardName,
+		},
+		{
+			name:        "異常ケース(説明が長すぎる)",
+			boardName:   "雑談",
+			description: strings.Repeat("あ", maxBoardDescriptionLength+1),
+			s:           &boardService{},
+			want:        nil,
+			wantErr:     ErrInvalidBoardDescription,
+		},
+		{
+			name:        "異常ケース(登録失敗)",
+			boardName:   "雑談",
+			description: "",
+			s: &boardService{
+				repo: func() *mock_repository.MockBoard {
+					mock := mock_repository.NewMockBoard(ctrl)
+					mock.EXPECT().Regist(gomock.Any(), now).Return("", errTest)
+					return mock
+				}(),
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.s.Regist(tt.boardName, tt.description, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+type (
+	// Digest ウォッチ・ダイジェストメールサービス
+	// mockgen -source domain/service/digest_service.go -destination mock/mock_service/digest_service_mock.go
+	Digest interface {
+		Watch(userID string, kind model.WatchKind, targetID string, now time.Time) error
+		Unwatch(userID string, kind model.WatchKind, targetID string) error
+		Watches(userID string) ([]model.Watch, error)
+		Subscription(userID string) (model.DigestSubscription, error)
+		UpdateSubscription(userID string, frequency model.DigestFrequency, locale string, now time.Time) (model.DigestSubscription, error)
+		Unsubscribe(token string, now time.Time) error
+		DueUserIDs(frequency model.DigestFrequency, period model.DigestPeriod) ([]string, error)
+		ClaimDelivery(userID string, period model.DigestPeriod, now time.Time) (bool, error)
+		ReleaseDelivery(userID string, period model.DigestPeriod) error
+	}
+
+	// DigestFactory ウォッチ・ダイジェストメールサービスファクトリー
+	DigestFactory interface {
+		NewDigestService(repo repository.Digest) Digest
+	}
+
+	digestService struct {
+		repo     repository.Digest
+		newToken func() (string, error)
+	}
+
+	digestServiceFactory struct{}
+)
+
+var _ Digest = (*digestService)(nil)
+
+var (
+	ErrInvalidWatchKind        = errors.New("invalid watch kind")
+	ErrWatchNotFound           = errors.New("watch not found")
+	ErrInvalidDigestFrequency  = errors.New("invalid digest frequency")
+	ErrInvalidDigestLocale     = errors.New("invalid digest locale")
+	ErrInvalidUnsubscribeToken = errors.New("invalid unsubscribe token")
+)
+
+// NewDigestServiceFactory ウォッチ・ダイジェストメールサービスファクトリーを生成する
+func NewDigestServiceFactory() *digestServiceFactory {
+	return &digestServiceFactory{}
+}
+
+// NewDigestService ウォッチ・ダイジェストメールサービスを生成する
+func (f *digestServiceFactory) NewDigestService(repo repository.Digest) Digest {
+	return &digestService{repo: repo, newToken: newUnsubscribeToken}
+}
+
+// Watch スレッド・板をウォッチする(ウォッチ済みの場合は何もしない)
+func (s *digestService) Watch(userID string, kind model.WatchKind, targetID string, now time.Time) error {
+	if !kind.IsValid() {
+		return ErrInvalidWatchKind
+	}
+
+	if err := s.repo.RegistWatch(model.NewWatch(userID, kind, targetID, now)); err != nil {
+		return errors.Wrap(err, "Watch error")
+	}
+
+	return nil
+}
+
+// Unwatch ウォッチを解除する
+func (s *digestService) Unwatch(userID string, kind model.WatchKind, targetID string) error {
+	err := s.repo.DeleteWatch(userID, kind, targetID)
+	if errors.Is(err, repository.ErrWatchNotFound) {
+		return ErrWatchNotFound
+	} else if err != nil {
+		return errors.Wrap(err, "Unwatch error")
+	}
+
+	return nil
+}
+
+// Watches ユーザーのウォッチを返す
+func (s *digestService) Watches(userID string) ([]model.Watch, error) {
+	watches, err := s.repo.FindWatchesByUserID(userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "Watches error")
+	}
+
+	return watches, nil
+}
+
+// Subscription ユーザーの配信設定を返す(未設定の場合は配信しない設定)
+func (s *digestService) Subscription(userID string) (model.DigestSubscription, error) {
+	subscription, err := s.repo.FindSubscription(userID)
+	if errors.Is(err, repository.ErrDigestSubscriptionNotFound) {
+		return model.DefaultDigestSubscription(), nil
+	} else if err != nil {
+		return model.DigestSubscription{}, errors.Wrap(err, "Subscription error")
+	}
+
+	return subscription, nil
+}
+
+// UpdateSubscription 配信設定を更新する
+// 配信停止トークンは初回の設定時に発行し、以降は同じものを使い続ける
+func (s *digestService) UpdateSubscription(userID string, frequency model.DigestFrequency, locale string, now time.Time) (model.DigestSubscription, error) {
+	if !frequency.IsValid() {
+		return model.DigestSubscription{}, ErrInvalidDigestFrequency
+	}
+	if !model.IsValidDigestLocale(locale) {
+		return model.DigestSubscription{}, ErrInvalidDigestLocale
+	}
+
+	subscription, err := s.Subscription(userID)
+	if err != nil {
+		return model.DigestSubscription{}, errors.Wrap(err, "UpdateSubscription error")
+	}
+	if subscription.UnsubscribeToken == "" {
+		if subscription.UnsubscribeToken, err = s.newToken(); err != nil {
+			return model.DigestSubscription{}, errors.Wrap(err, "UpdateSubscription error")
+		}
+	}
+	subscription.Frequency = frequency
+	subscription.Locale = locale
+
+	if err := s.repo.SaveSubscription(userID, subscription, now); err != nil {
+		return model.DigestSubscription{}, errors.Wrap(err, "UpdateSubscription error")
+	}
+
+	return subscription, nil
+}
+
+// Unsubscribe 配信停止トークンに対応するユーザーの配信を停止する
+func (s *digestService) Unsubscribe(token string, now time.Time) error {
+	if token == "" {
+		return ErrInvalidUnsubscribeToken
+	}
+
+	userID, subscription, err := s.repo.FindSubscriptionByToken(token)
+	if errors.Is(err, repository.ErrDigestSubscriptionNotFound) {
+		return ErrInvalidUnsubscribeToken
+	} else if err != nil {
+		return errors.Wrap(err, "Unsubscribe error")
+	}
+	if subscription.Frequency == model.DigestOff {
+		return nil
+	}
+
+	subscription.Frequency = model.DigestOff
+	if err := s.repo.SaveSubscription(userID, subscription, now); err != nil {
+		return errors.Wrap(err, "Unsubscribe error")
+	}
+
+	return nil
+}
+
+// DueUserIDs 集計期間のダイジェストが未送信のユーザーのIDを返す
+func (s *digestService) DueUserIDs(frequency model.DigestFrequency, period model.DigestPeriod) ([]string, error) {
+	userIDs, err := s.repo.FindDueUserIDs(frequency, period.Key)
+	if err != nil {
+		return nil, errors.Wrap(err, "DueUserIDs error")
+	}
+
+	return userIDs, nil
+}
+
+// ClaimDelivery 送信前に送信記録を登録し、送信してよいかを返す(他のプロセスが登録済みの場合はfalse)
+func (s *digestService) ClaimDelivery(userID string, period model.DigestPeriod, now time.Time) (bool, error) {
+	claimed, err := s.repo.RegistDelivery(userID, period.Key, now)
+	if err != nil {
+		return false, errors.Wrap(err, "ClaimDelivery error")
+	}
+
+	return claimed, nil
+}
+
+// ReleaseDelivery 送信に失敗した場合に送信記録を取り消し、次回の実行で再送できるようにする
+func (s *digestService) ReleaseDelivery(userID string, period model.DigestPeriod) error {
+	if err := s.repo.DeleteDelivery(userID, period.Key); err != nil {
+		return errors.Wrap(err, "ReleaseDelivery error")
+	}
+
+	return nil
+}
+
+// newUnsubscribeToken 推測できない配信停止トークンを生成する
+func newUnsubscribeToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
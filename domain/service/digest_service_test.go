@@ -0,0 +1,409 @@
+package service
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+	"GoBBS/mock/mock_repository"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewDigestService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repository.NewMockDigest(ctrl)
+	got, ok := NewDigestServiceFactory().NewDigestService(repo).(*digestService)
+	if !ok || got.repo != repo {
+		t.Fatalf("NewDigestService() = %v", got)
+	}
+	if reflect.ValueOf(got.newToken).Pointer() != reflect.ValueOf(newUnsubscribeToken).Pointer() {
+		t.Errorf("NewDigestService().newToken is not newUnsubscribeToken")
+	}
+}
+
+func Test_newUnsubscribeToken(t *testing.T) {
+	a, err := newUnsubscribeToken()
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	b, _ := newUnsubscribeToken()
+	if len(a) != 64 || a == b {
+		t.Errorf("戻り値不一致 got: %#v, %#v", a, b)
+	}
+}
+
+func Test_digestService_Watch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		kind    model.WatchKind
+		s       *digestService
+		wantErr error
+	}{
+		{
+			name: "正常ケース",
+			kind: model.WatchThread,
+			s: &digestService{
+				repo: func() *mock_repository.MockDigest {
+					mock := mock_repository.NewMockDigest(ctrl)
+					mock.EXPECT().RegistWatch(model.NewWatch("1", model.WatchThread, "10", now)).Return(nil)
+					return mock
+				}(),
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "異常ケース(種類不正)",
+			kind:    "user",
+			s:       &digestService{},
+			wantErr: ErrInvalidWatchKind,
+		},
+		{
+			name: "異常ケース(登録失敗)",
+			kind: model.WatchBoard,
+			s: &digestService{
+				repo: func() *mock_repository.MockDigest {
+					mock := mock_repository.NewMockDigest(ctrl)
+					mock.EXPECT().RegistWatch(gomock.Any()).Return(errTest)
+					return mock
+				}(),
+			},
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.s.Watch("1", tt.kind, "10", now); !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_digestService_Unwatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+
+	tests := []struct {
+		name    string
+		err     error
+		wantErr error
+	}{
+		{name: "正常ケース", err: nil, wantErr: nil},
+		{name: "異常ケース(ウォッチなし)", err: repository.ErrWatchNotFound, wantErr: ErrWatchNotFound},
+		{name: "異常ケース(削除失敗)", err: errTest, wantErr: errTest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mock_repository.NewMockDigest(ctrl)
+			repo.EXPECT().DeleteWatch("1", model.WatchThread, "10").Return(tt.err)
+
+			if err := (&digestService{repo: repo}).Unwatch("1", model.WatchThread, "10"); !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_digestService_Watches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	watches := []model.Watch{model.NewWatch("1", model.WatchBoard, "2", time.Time{})}
+
+	repo := mock_repository.NewMockDigest(ctrl)
+	repo.EXPECT().FindWatchesByUserID("1").Return(watches, nil)
+	repo.EXPECT().FindWatchesByUserID("2").Return(nil, errTest)
+	s := &digestService{repo: repo}
+
+	got, err := s.Watches("1")
+	if err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	if !reflect.DeepEqual(got, watches) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, watches)
+	}
+	if _, err := s.Watches("2"); !errors.Is(err, errTest) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", err, errTest)
+	}
+}
+
+func Test_digestService_Subscription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	subscription := model.DigestSubscription{Frequency: model.DigestDaily, Locale: "en", UnsubscribeToken: "token"}
+
+	tests := []struct {
+		name    string
+		found   model.DigestSubscription
+		err     error
+		want    model.DigestSubscription
+		wantErr error
+	}{
+		{name: "正常ケース", found: subscription, want: subscription},
+		{name: "正常ケース(未設定)", err: repository.ErrDigestSubscriptionNotFound, want: model.DefaultDigestSubscription()},
+		{name: "異常ケース", err: errTest, wantErr: errTest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mock_repository.NewMockDigest(ctrl)
+			repo.EXPECT().FindSubscription("1").Return(tt.found, tt.err)
+
+			got, err := (&digestService{repo: repo}).Subscription("1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_digestService_UpdateSubscription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	newToken := func() (string, error) { return "new-token", nil }
+
+	tests := []struct {
+		name      string
+		frequency model.DigestFrequency
+		locale    string
+		s         *digestService
+		want      model.DigestSubscription
+		wantErr   error
+	}{
+		{
+			name:      "正常ケース(初回はトークンを発行)",
+			frequency: model.DigestDaily,
+			locale:    "en",
+			s: &digestService{
+				repo: func() *mock_repository.MockDigest {
+					want := model.DigestSubscription{Frequency: model.DigestDaily, Locale: "en", UnsubscribeToken: "new-token"}
+					mock := mock_repository.NewMockDigest(ctrl)
+					gomock.InOrder(
+						mock.EXPECT().FindSubscription("1").Return(model.DigestSubscription{}, repository.ErrDigestSubscriptionNotFound),
+						mock.EXPECT().SaveSubscription("1", want, now).Return(nil),
+					)
+					return mock
+				}(),
+				newToken: newToken,
+			},
+			want:    model.DigestSubscription{Frequency: model.DigestDaily, Locale: "en", UnsubscribeToken: "new-token"},
+			wantErr: nil,
+		},
+		{
+			name:      "正常ケース(既存のトークンを維持)",
+			frequency: model.DigestWeekly,
+			locale:    "ja",
+			s: &digestService{
+				repo: func() *mock_repository.MockDigest {
+					want := model.DigestSubscription{Frequency: model.DigestWeekly, Locale: "ja", UnsubscribeToken: "token"}
+					mock := mock_repository.NewMockDigest(ctrl)
+					gomock.InOrder(
+						mock.EXPECT().FindSubscription("1").Return(model.DigestSubscription{Frequency: model.DigestOff, Locale: "en", UnsubscribeToken: "token"}, nil),
+						mock.EXPECT().SaveSubscription("1", want, now).Return(nil),
+					)
+					return mock
+				}(),
+				newToken: newToken,
+			},
+			want:    model.DigestSubscription{Frequency: model.DigestWeekly, Locale: "ja", UnsubscribeToken: "token"},
+			wantErr: nil,
+		},
+		{
+			name:      "異常ケース(配信頻度不正)",
+			frequency: "monthly",
+			locale:    "ja",
+			s:         &digestService{},
+			wantErr:   ErrInvalidDigestFrequency,
+		},
+		{
+			name:      "異常ケース(言語不正)",
+			frequency: model.DigestDaily,
+			locale:    "fr",
+			s:         &digestService{},
+			wantErr:   ErrInvalidDigestLocale,
+		},
+		{
+			name:      "異常ケース(トークン発行失敗)",
+			frequency: model.DigestDaily,
+			locale:    "ja",
+			s: &digestService{
+				repo: func() *mock_repository.MockDigest {
+					mock := mock_repository.NewMockDigest(ctrl)
+					mock.EXPECT().FindSubscription("1").Return(model.DigestSubscription{}, repository.ErrDigestSubscriptionNotFound)
+					return mock
+				}(),
+				newToken: func() (string, error) { return "", errTest },
+			},
+			wantErr: errTest,
+		},
+		{
+			name:      "異常ケース(保存失敗)",
+			frequency: model.DigestDaily,
+			locale:    "ja",
+			s: &digestService{
+				repo: func() *mock_repository.MockDigest {
+					mock := mock_repository.NewMockDigest(ctrl)
+					mock.EXPECT().FindSubscription("1").Return(model.DigestSubscription{}, repository.ErrDigestSubscriptionNotFound)
+					mock.EXPECT().SaveSubscription("1", gomock.Any(), now).Return(errTest)
+					return mock
+				}(),
+				newToken: newToken,
+			},
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.s.UpdateSubscription("1", tt.frequency, tt.locale, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_digestService_Unsubscribe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		token   string
+		s       *digestService
+		wantErr error
+	}{
+		{
+			name:  "正常ケース",
+			token: "token",
+			s: &digestService{
+				repo: func() *mock_repository.MockDigest {
+					mock := mock_repository.NewMockDigest(ctrl)
+					gomock.InOrder(
+						mock.EXPECT().FindSubscriptionByToken("token").Return("1", model.DigestSubscription{Frequency: model.DigestDaily, Locale: "ja", UnsubscribeToken: "token"}, nil),
+						mock.EXPECT().SaveSubscription("1", model.DigestSubscription{Frequency: model.DigestOff, Locale: "ja", UnsubscribeToken: "token"}, now).Return(nil),
+					)
+					return mock
+				}(),
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "正常ケース(停止済み)",
+			token: "token",
+			s: &digestService{
+				repo: func() *mock_repository.MockDigest {
+					mock := mock_repository.NewMockDigest(ctrl)
+					mock.EXPECT().FindSubscriptionByToken("token").Return("1", model.DigestSubscription{Frequency: model.DigestOff, Locale: "ja", UnsubscribeToken: "token"}, nil)
+					return mock
+				}(),
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "異常ケース(トークン未指定)",
+			token:   "",
+			s:       &digestService{},
+			wantErr: ErrInvalidUnsubscribeToken,
+		},
+		{
+			name:  "異常ケース(トークン不正)",
+			token: "unknown",
+			s: &digestService{
+				repo: func() *mock_repository.MockDigest {
+					mock := mock_repository.NewMockDigest(ctrl)
+					mock.EXPECT().FindSubscriptionByToken("unknown").Return("", model.DigestSubscription{}, repository.ErrDigestSubscriptionNotFound)
+					return mock
+				}(),
+			},
+			wantErr: ErrInvalidUnsubscribeToken,
+		},
+		{
+			name:  "異常ケース(保存失敗)",
+			token: "token",
+			s: &digestService{
+				repo: func() *mock_repository.MockDigest {
+					mock := mock_repository.NewMockDigest(ctrl)
+					mock.EXPECT().FindSubscriptionByToken("token").Return("1", model.DigestSubscription{Frequency: model.DigestWeekly, UnsubscribeToken: "token"}, nil)
+					mock.EXPECT().SaveSubscription("1", gomock.Any(), now).Return(errTest)
+					return mock
+				}(),
+			},
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.s.Unsubscribe(tt.token, now); !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_digestService_Delivery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	period, _ := model.NewDigestPeriod(model.DigestDaily, now)
+
+	repo := mock_repository.NewMockDigest(ctrl)
+	gomock.InOrder(
+		repo.EXPECT().FindDueUserIDs(model.DigestDaily, "2023-01-02").Return([]string{"1"}, nil),
+		repo.EXPECT().RegistDelivery("1", "2023-01-02", now).Return(true, nil),
+		repo.EXPECT().DeleteDelivery("1", "2023-01-02").Return(nil),
+		repo.EXPECT().FindDueUserIDs(model.DigestDaily, "2023-01-02").Return(nil, errTest),
+		repo.EXPECT().RegistDelivery("1", "2023-01-02", now).Return(false, errTest),
+		repo.EXPECT().DeleteDelivery("1", "2023-01-02").Return(errTest),
+	)
+	s := &digestService{repo: repo}
+
+	userIDs, err := s.DueUserIDs(model.DigestDaily, period)
+	if err != nil || !reflect.DeepEqual(userIDs, []string{"1"}) {
+		t.Errorf("戻り値不一致 got: %#v, %v", userIDs, err)
+	}
+	if claimed, err := s.ClaimDelivery("1", period, now); err != nil || !claimed {
+		t.Errorf("戻り値不一致 got: %#v, %v", claimed, err)
+	}
+	if err := s.ReleaseDelivery("1", period); err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+
+	if _, err := s.DueUserIDs(model.DigestDaily, period); !errors.Is(err, errTest) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", err, errTest)
+	}
+	if _, err := s.ClaimDelivery("1", period, now); !errors.Is(err, errTest) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", err, errTest)
+	}
+	if err := s.ReleaseDelivery("1", period); !errors.Is(err, errTest) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", err, errTest)
+	}
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+type (
+	// Event ドメインイベントサービス
+	// mockgen -source domain/service/event_service.go -destination mock/mock_service/event_service_mock.go
+	Event interface {
+		Emit(eventType model.EventType, aggregateID string, data any, now time.Time) error
+		ClaimDue(now time.Time, limit int) ([]model.Event, error)
+		RecordResult(event model.Event, handleErr error, now time.Time) (model.Event, error)
+	}
+
+	// EventFactory ドメインイベントサービスファクトリー
+	EventFactory interface {
+		NewEventService(repo repository.Event) Event
+	}
+
+	eventService struct {
+		repo repository.Event
+	}
+
+	eventServiceFactory struct{}
+)
+
+var _ Event = (*eventService)(nil)
+
+var (
+	ErrInvalidEventType = errors.New("invalid event type")
+)
+
+const (
+	// eventLease 取得したイベントを他のディスパッチャーが取得しないようにする期間
+	eventLease = time.Minute
+	// eventBaseBackoff 1回目の再配信までの間隔(以降は倍々にする)
+	eventBaseBackoff = 10 * time.Second
+	// eventMaxBackoff 再配信間隔の上限
+	eventMaxBackoff = time.Hour
+)
+
+// NewEventServiceFactory ドメインイベントサービスファクトリーを生成する
+func NewEventServiceFactory() *eventServiceFactory {
+	return &eventServiceFactory{}
+}
+
+// NewEventService ドメインイベントサービスを生成する
+func (f *eventServiceFactory) NewEventService(repo repository.Event) Event {
+	return &eventService{repo: repo}
+}
+
+// Emit イベントをアウトボックスに登録する
+// イベントを発生させた更新と同じtxで呼び出すことで、更新が確定した場合にだけ配信される
+func (s *eventService) Emit(eventType model.EventType, aggregateID string, data any, now time.Time) error {
+	if !eventType.IsValid() {
+		return ErrInvalidEventType
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return errors.Wrap(err, "Emit error")
+	}
+
+	if _, err := s.repo.Regist(model.NewEvent("", eventType, aggregateID, payload, 0, now, "", now, time.Time{})); err != nil {
+		return errors.Wrap(err, "Emit error")
+	}
+
+	return nil
+}
+
+// ClaimDue 配信時刻に達したイベントを取得し、配信中に他のディスパッチャーが取得しないよう次の配信時刻を先送りする
+// 配信結果を記録する前にプロセスが停止した場合は、先送りした時刻に再配信される
+func (s *eventService) ClaimDue(now time.Time, limit int) ([]model.Event, error) {
+	events, err := s.repo.FindDue(now, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "ClaimDue error")
+	}
+
+	for _, e := range events {
+		leased := model.NewEvent(
+			e.ID(),
+			e.Type(),
+			e.AggregateID(),
+			e.Payload(),
+			e.Attempts(),
+			now.Add(eventLease),
+			e.LastError(),
+			e.OccurredAt(),
+			e.DispatchedAt(),
+		)
+		if err := s.repo.Update(leased); err != nil {
+			return nil, errors.Wrap(err, "ClaimDue error")
+		}
+	}
+
+	return events, nil
+}
+
+// RecordResult ハンドラーの処理結果を記録し、更新後のイベントを返す
+// 失敗した場合は配信済みになるまで間隔を倍々にして再配信する(少なくとも1回は配信する)
+func (s *eventService) RecordResult(event model.Event, handleErr error, now time.Time) (model.Event, error) {
+	attempts := event.Attempts() + 1
+	nextAttemptAt := now
+	var dispatchedAt time.Time
+	lastError := ""
+	if handleErr != nil {
+		nextAttemptAt = now.Add(eventBackoff(attempts))
+		lastError = truncateWebhookError(handleErr.Error())
+	} else {
+		dispatchedAt = now
+	}
+
+	updated := model.NewEvent(
+		event.ID(),
+		event.Type(),
+		event.AggregateID(),
+		event.Payload(),
+		attempts,
+		nextAttemptAt,
+		lastError,
+		event.OccurredAt(),
+		dispatchedAt,
+	)
+	if err := s.repo.Update(updated); err != nil {
+		return nil, errors.Wrap(err, "RecordResult error")
+	}
+
+	return updated, nil
+}
+
+// eventBackoff attempts回目の失敗後、次の再配信までの間隔を返す
+func eventBackoff(attempts int) time.Duration {
+	backoff := eventBaseBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= eventMaxBackoff {
+			return eventMaxBackoff
+		}
+	}
+
+	return backoff
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/mock/mock_repository"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewEventService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repository.NewMockEvent(ctrl)
+	want := &eventService{repo: repo}
+	if got := NewEventServiceFactory().NewEventService(repo); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewEventService() = %v, want %v", got, want)
+	}
+}
+
+func Test_eventService_Emit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	data := model.UserRegisteredData{UserID: "1", Name: "name"}
+	payload := []byte(`{"user_id":"1","name":"name"}`)
+
+	tests := []struct {
+		name      string
+		eventType model.EventType
+		repo      func() *mock_repository.MockEvent
+		wantErr   error
+	}{
+		{
+			name:      "正常ケース",
+			eventType: model.EventUserRegistered,
+			repo: func() *mock_repository.MockEvent {
+				mock := mock_repository.NewMockEvent(ctrl)
+				mock.EXPECT().Regist(model.NewEvent("", model.EventUserRegistered, "1", payload, 0, now, "", now, time.Time{})).Return("3", nil)
+				return mock
+			},
+			wantErr: nil,
+		},
+		{
+			name:      "異常ケース(未知のイベント)",
+			eventType: model.EventType("user.deleted"),
+			repo: func() *mock_repository.MockEvent {
+				return mock_repository.NewMockEvent(ctrl)
+			},
+			wantErr: ErrInvalidEventType,
+		},
+		{
+			name:      "異常ケース(登録失敗)",
+			eventType: model.EventUserRegistered,
+			repo: func() *mock_repository.MockEvent {
+				mock := mock_repository.NewMockEvent(ctrl)
+				mock.EXPECT().Regist(gomock.Any()).Return("", errTest)
+				return mock
+			},
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := (&eventService{repo: tt.repo()}).Emit(tt.eventType, "1", data, now); !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+		})
+	}
+
+	var jsonErr *json.UnsupportedTypeError
+	if err := (&eventService{}).Emit(model.EventUserRegistered, "1", make(chan int), now); !errors.As(err, &jsonErr) {
+		t.Errorf("予期せぬエラー(error: %v)", err)
+	}
+}
+
+func Test_eventService_ClaimDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	events := []model.Event{
+		model.NewEvent("1", model.EventUserRegistered, "2", []byte("{}"), 1, now, "handler error", now, time.Time{}),
+	}
+
+	tests := []struct {
+		name    string
+		repo    func() *mock_repository.MockEvent
+		want    []model.Event
+		wantErr error
+	}{
+		{
+			name: "正常ケース(次の配信時刻を先送りする)",
+			repo: func() *mock_repository.MockEvent {
+				mock := mock_repository.NewMockEvent(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().FindDue(now, 10).Return(events, nil),
+					mock.EXPECT().Update(model.NewEvent("1", model.EventUserRegistered, "2", []byte("{}"),
+						1, now.Add(eventLease), "handler error", now, time.Time{})).Return(nil),
+				)
+				return mock
+			},
+			want:    events,
+			wantErr: nil,
+		},
+		{
+			name: "異常ケース(取得失敗)",
+			repo: func() *mock_repository.MockEvent {
+				mock := mock_repository.NewMockEvent(ctrl)
+				mock.EXPECT().FindDue(now, 10).Return(nil, errTest)
+				return mock
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+		{
+			name: "異常ケース(更新失敗)",
+			repo: func() *mock_repository.MockEvent {
+				mock := mock_repository.NewMockEvent(ctrl)
+				mock.EXPECT().FindDue(now, 10).Return(events, nil)
+				mock.EXPECT().Update(gomock.Any()).Return(errTest)
+				return mock
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := (&eventService{repo: tt.repo()}).ClaimDue(now, 10)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_eventService_RecordResult(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	occurred := now.Add(-time.Hour)
+	event := func(attempts int) model.Event {
+		return model.NewEvent("1", model.EventUserRegistered, "2", []byte("{}"), attempts, now, "", occurred, time.Time{})
+	}
+
+	tests := []struct {
+		name      string
+		event     model.Event
+		handleErr error
+		want      model.Event
+	}{
+		{
+			name:  "正常ケース(配信成功)",
+			event: event(0),
+			want:  model.NewEvent("1", model.EventUserRegistered, "2", []byte("{}"), 1, now, "", occurred, now),
+		},
+		{
+			name:      "正常ケース(失敗は間隔を倍々にして再配信する)",
+			event:     event(2),
+			handleErr: errors.New("handler error"),
+			want:      model.NewEvent("1", model.EventUserRegistered, "2", []byte("{}"), 3, now.Add(40*time.Second), "handler error", occurred, time.Time{}),
+		},
+		{
+			name:      "正常ケース(再配信間隔の上限)",
+			event:     event(20),
+			handleErr: errors.New("handler error"),
+			want:      model.NewEvent("1", model.EventUserRegistered, "2", []byte("{}"), 21, now.Add(eventMaxBackoff), "handler error", occurred, time.Time{}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mock_repository.NewMockEvent(ctrl)
+			repo.EXPECT().Update(tt.want).Return(nil)
+
+			got, err := (&eventService{repo: repo}).RecordResult(tt.event, tt.handleErr, now)
+			if err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+
+	repo := mock_repository.NewMockEvent(ctrl)
+	repo.EXPECT().Update(gomock.Any()).Return(errTest)
+	if _, err := (&eventService{repo: repo}).RecordResult(event(0), nil, now); !errors.Is(err, errTest) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", err, errTest)
+	}
+}
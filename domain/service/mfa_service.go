@@ -0,0 +1,308 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+type (
+	// MFA 2要素認証サービス
+	// mockgen -source domain/service/mfa_service.go -destination mock/mock_service/mfa_service_mock.go
+	MFA interface {
+		Enroll(userID string, now time.Time) (model.MFA, error)
+		Confirm(userID string, code string, now time.Time) ([]string, error)
+		Disable(userID string, code string, now time.Time) error
+		IsEnabled(userID string) (bool, error)
+		IssueChallenge(userID string, now time.Time) (string, model.MFAChallenge, error)
+		VerifyChallenge(token string, code string, now time.Time) (string, bool, error)
+	}
+
+	// MFAFactory 2要素認証サービスファクトリー
+	MFAFactory interface {
+		NewMFAService(repo repository.MFA) MFA
+	}
+
+	mfaService struct {
+		repo            repository.MFA
+		newSecret       func() (string, error)
+		newToken        func() (string, error)
+		newRecoveryCode func() (string, error)
+	}
+
+	mfaServiceFactory struct{}
+)
+
+var _ MFA = (*mfaService)(nil)
+
+var (
+	ErrMFAAlreadyEnabled   = errors.New("mfa already enabled")
+	ErrMFANotEnrolled      = errors.New("mfa not enrolled")
+	ErrInvalidMFACode      = errors.New("invalid mfa code")
+	ErrMFAChallengeInvalid = errors.New("mfa challenge invalid")
+)
+
+const (
+	// MFAIssuer 認証アプリに表示する発行者名
+	MFAIssuer = "GoBBS"
+	// mfaChallengeTTL パスワード認証後、確認コードを入力できる時間
+	mfaChallengeTTL = 5 * time.Minute
+	// recoveryCodeCount 発行するリカバリーコードの数
+	recoveryCodeCount = 10
+	// totpSecretBytes TOTPの秘密鍵の長さ(RFC 4226の推奨する160bit)
+	totpSecretBytes = 20
+)
+
+// NewMFAServiceFactory 2要素認証サービスファクトリーを生成する
+func NewMFAServiceFactory() *mfaServiceFactory {
+	return &mfaServiceFactory{}
+}
+
+// NewMFAService 2要素認証サービスを生成する
+func (f *mfaServiceFactory) NewMFAService(repo repository.MFA) MFA {
+	return &mfaService{
+		repo:            repo,
+		newSecret:       newTOTPSecret,
+		newToken:        newMFAChallengeToken,
+		newRecoveryCode: newRecoveryCode,
+	}
+}
+
+// Enroll TOTPの秘密鍵を生成して登録する
+// 最初の確認コードでConfirmするまでは有効にならず、再度呼ぶと秘密鍵を作り直す
+func (s *mfaService) Enroll(userID string, now time.Time) (model.MFA, error) {
+	current, err := s.repo.FindByUserID(userID)
+	if err != nil && !errors.Is(err, repository.ErrMFANotFound) {
+		return nil, errors.Wrap(err, "Enroll error")
+	}
+	if current != nil && current.IsEnabled() {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	secret, err := s.newSecret()
+	if err != nil {
+		return nil, errors.Wrap(err, "Enroll error")
+	}
+
+	mfa := model.NewMFA(userID, secret, time.Time{}, 0, now)
+	if err := s.repo.Save(mfa); err != nil {
+		return nil, errors.Wrap(err, "Enroll error")
+	}
+
+	return mfa, nil
+}
+
+// Confirm 最初の確認コードを検証して2要素認証を有効にし、リカバリーコードを発行する
+// リカバリーコードはハッシュだけを保存するため、平文はこの戻り値でしか得られない
+func (s *mfaService) Confirm(userID string, code string, now time.Time) ([]string, error) {
+	current, err := s.repo.FindByUserID(userID)
+	if errors.Is(err, repository.ErrMFANotFound) {
+		return nil, ErrMFANotEnrolled
+	} else if err != nil {
+		return nil, errors.Wrap(err, "Confirm error")
+	}
+	if current.IsEnabled() {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	step, ok := current.VerifyCode(code, now)
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+
+	if err := s.repo.Save(model.NewMFA(userID, current.Secret(), now, step, current.CreatedAt())); err != nil {
+		return nil, errors.Wrap(err, "Confirm error")
+	}
+
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, err := s.newRecoveryCode()
+		if err != nil {
+			return nil, errors.Wrap(err, "Confirm error")
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, model.HashMFAToken(normalizeRecoveryCode(code)))
+	}
+	if err := s.repo.ReplaceRecoveryCodes(userID, hashes); err != nil {
+		return nil, errors.Wrap(err, "Confirm error")
+	}
+
+	return codes, nil
+}
+
+// Disable 確認コードかリカバリーコードを検証して2要素認証を無効にする
+func (s *mfaService) Disable(userID string, code string, now time.Time) error {
+	current, err := s.repo.FindByUserID(userID)
+	if errors.Is(err, repository.ErrMFANotFound) {
+		return ErrMFANotEnrolled
+	} else if err != nil {
+		return errors.Wrap(err, "Disable error")
+	}
+	if !current.IsEnabled() {
+		return ErrMFANotEnrolled
+	}
+
+	ok, err := s.verifyCode(current, code, now)
+	if err != nil {
+		return errors.Wrap(err, "Disable error")
+	}
+	if !ok {
+		return ErrInvalidMFACode
+	}
+
+	if err := s.repo.Delete(userID); err != nil {
+		return errors.Wrap(err, "Disable error")
+	}
+
+	return nil
+}
+
+// IsEnabled ユーザーが2要素認証を有効にしているか判定する
+func (s *mfaService) IsEnabled(userID string) (bool, error) {
+	current, err := s.repo.FindByUserID(userID)
+	if errors.Is(err, repository.ErrMFANotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Wrap(err, "IsEnabled error")
+	}
+
+	return current.IsEnabled(), nil
+}
+
+// IssueChallenge パスワード認証に成功したユーザーに、確認コードと交換するトークンを発行する
+// トークンはハッシュだけを保存する
+func (s *mfaService) IssueChallenge(userID string, now time.Time) (string, model.MFAChallenge, error) {
+	token, err := s.newToken()
+	if err != nil {
+		return "", nil, errors.Wrap(err, "IssueChallenge error")
+	}
+
+	if err := s.repo.DeleteExpiredChallenges(userID, now); err != nil {
+		return "", nil, errors.Wrap(err, "IssueChallenge error")
+	}
+
+	challenge := model.NewMFAChallenge(model.HashMFAToken(token), userID, 0, now.Add(mfaChallengeTTL), now)
+	if err := s.repo.RegistChallenge(challenge); err != nil {
+		return "", nil, errors.Wrap(err, "IssueChallenge error")
+	}
+
+	return token, challenge, nil
+}
+
+// VerifyChallenge トークンと確認コード(またはリカバリーコード)を検証し、ユーザーIDを返す
+// コードを検証する前に試行回数を増やすため、並行したリクエストでも上限を超えて試行できない
+// コードが誤っていた場合はokにfalseを返す(増やした回数を確定させるためエラーにしない)
+// トークンが無効な場合や試行回数が上限に達した場合はErrMFAChallengeInvalidを返す
+func (s *mfaService) VerifyChallenge(token string, code string, now time.Time) (string, bool, error) {
+	challenge, err := s.repo.FindChallenge(model.HashMFAToken(token))
+	if errors.Is(err, repository.ErrMFAChallengeNotFound) {
+		return "", false, ErrMFAChallengeInvalid
+	} else if err != nil {
+		return "", false, errors.Wrap(err, "VerifyChallenge error")
+	}
+	if !challenge.IsValid(now) {
+		return "", false, ErrMFAChallengeInvalid
+	}
+	err = s.repo.ConsumeChallengeAttempt(challenge.ID(), now)
+	if errors.Is(err, repository.ErrMFAChallengeNotFound) {
+		return "", false, ErrMFAChallengeInvalid
+	} else if err != nil {
+		return "", false, errors.Wrap(err, "VerifyChallenge error")
+	}
+
+	current, err := s.repo.FindByUserID(challenge.UserID())
+	if errors.Is(err, repository.ErrMFANotFound) {
+		return "", false, ErrMFAChallengeInvalid
+	} else if err != nil {
+		return "", false, errors.Wrap(err, "VerifyChallenge error")
+	}
+
+	ok, err := s.verifyCode(current, code, now)
+	if err != nil {
+		return "", false, errors.Wrap(err, "VerifyChallenge error")
+	}
+	if !ok {
+		return "", false, nil
+	}
+
+	if err := s.repo.DeleteChallenge(challenge.ID()); err != nil {
+		return "", false, errors.Wrap(err, "VerifyChallenge error")
+	}
+
+	return challenge.UserID(), true, nil
+}
+
+// verifyCode 6桁の数字は確認コード、それ以外はリカバリーコードとして検証し、使用済みにする
+func (s *mfaService) verifyCode(mfa model.MFA, code string, now time.Time) (bool, error) {
+	if model.IsTOTPCode(code) {
+		step, ok := mfa.VerifyCode(code, now)
+		if !ok {
+			return false, nil
+		}
+		// 同じコードを並行して使った場合は、先に更新した方だけを通す
+		err := s.repo.UpdateLastUsedStep(mfa.UserID(), step)
+		if errors.Is(err, repository.ErrMFACodeUsed) {
+			return false, nil
+		} else if err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	err := s.repo.UseRecoveryCode(mfa.UserID(), model.HashMFAToken(normalizeRecoveryCode(code)), now)
+	if errors.Is(err, repository.ErrRecoveryCodeNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// normalizeRecoveryCode 入力の揺れ(大文字、区切りのハイフンや空白)を取り除く
+func normalizeRecoveryCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(code))
+}
+
+// newTOTPSecret TOTPの秘密鍵を生成する
+func newTOTPSecret() (string, error) {
+	b := make([]byte, totpSecretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return model.EncodeTOTPSecret(b), nil
+}
+
+// newMFAChallengeToken 推測できないチャレンジトークンを生成する
+func newMFAChallengeToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// newRecoveryCode "0123a-4567b"のような書き写しやすいリカバリーコードを生成する
+func newRecoveryCode() (string, error) {
+	b := make([]byte, 5)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	code := hex.EncodeToString(b)
+
+	return code[:5] + "-" + code[5:], nil
+}
@@ -0,0 +1,820 @@
+package service
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+	"GoBBS/interface/inmemory"
+	"GoBBS/mock/mock_repository"
+	"errors"
+	"reflect"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+// mfaTestSecret RFC 6238のテストベクタの秘密鍵
+const mfaTestSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// mfaTestCode 秘密鍵とステップから確認コードを計算する
+func mfaTestCode(t *testing.T, step int64) string {
+	t.Helper()
+	code, err := model.TOTPCode(mfaTestSecret, step)
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	return code
+}
+
+func TestNewMFAService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repository.NewMockMFA(ctrl)
+	got, ok := NewMFAServiceFactory().NewMFAService(repo).(*mfaService)
+	if !ok || got.repo != repo {
+		t.Fatalf("NewMFAService() = %v", got)
+	}
+	if reflect.ValueOf(got.newSecret).Pointer() != reflect.ValueOf(newTOTPSecret).Pointer() {
+		t.Errorf("NewMFAService().newSecret is not newTOTPSecret")
+	}
+	if reflect.ValueOf(got.newToken).Pointer() != reflect.ValueOf(newMFAChallengeToken).Pointer() {
+		t.Errorf("NewMFAService().newToken is not newMFAChallengeToken")
+	}
+	if reflect.ValueOf(got.newRecoveryCode).Pointer() != reflect.ValueOf(newRecoveryCode).Pointer() {
+		t.Errorf("NewMFAService().newRecoveryCode is not newRecoveryCode")
+	}
+}
+
+func Test_newTOTPSecret(t *testing.T) {
+	a, err := newTOTPSecret()
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	b, _ := newTOTPSecret()
+	if len(a) != 32 || a == b {
+		t.Errorf("戻り値不一致 got: %#v, %#v", a, b)
+	}
+	if _, err := model.TOTPCode(a, 1); err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+}
+
+func Test_newMFAChallengeToken(t *testing.T) {
+	a, err := newMFAChallengeToken()
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	b, _ := newMFAChallengeToken()
+	if len(a) != 64 || a == b {
+		t.Errorf("戻り値不一致 got: %#v, %#v", a, b)
+	}
+}
+
+func Test_newRecoveryCode(t *testing.T) {
+	a, err := newRecoveryCode()
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	b, _ := newRecoveryCode()
+	if !regexp.MustCompile(`^[0-9a-f]{5}-[0-9a-f]{5}$`).MatchString(a) || a == b {
+		t.Errorf("戻り値不一致 got: %#v, %#v", a, b)
+	}
+}
+
+func Test_normalizeRecoveryCode(t *testing.T) {
+	for _, code := range []string{"0123a-4567b", "0123A-4567B", "0123a 4567b", "0123a4567b"} {
+		if got := normalizeRecoveryCode(code); got != "0123a4567b" {
+			t.Errorf("戻り値不一致 got: %#v want: %#v", got, "0123a4567b")
+		}
+	}
+}
+
+func Test_mfaService_Enroll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	newSecret := func() (string, error) { return "SECRET", nil }
+
+	tests := []struct {
+		name    string
+		s       *mfaService
+		want    model.MFA
+		wantErr error
+	}{
+		{
+			name: "正常ケース",
+			s: &mfaService{
+				repo: func() *mock_repository.MockMFA {
+					mock := mock_repository.NewMockMFA(ctrl)
+					mock.EXPECT().FindByUserID("1").Return(nil, repository.ErrMFANotFound)
+					mock.EXPECT().Save(model.NewMFA("1", "SECRET", time.Time{}, 0, now)).Return(nil)
+					return mock
+				}(),
+				newSecret: newSecret,
+			},
+			want:    model.NewMFA("1", "SECRET", time.Time{}, 0, now),
+			wantErr: nil,
+		},
+		{
+			name: "正常ケース(確認前の再登録)",
+			s: &mfaService{
+				repo: func() *mock_repository.MockMFA {
+					mock := mock_repository.NewMockMFA(ctrl)
+					mock.EXPECT().FindByUserID("1").Return(model.NewMFA("1", "OLD", time.Time{}, 0, now), nil)
+					mock.EXPECT().Save(model.NewMFA("1", "SECRET", time.Time{}, 0, now)).Return(nil)
+					return mock
+				}(),
+				newSecret: newSecret,
+			},
+			want:    model.NewMFA("1", "SECRET", time.Time{}, 0, now),
+			wantErr: nil,
+		},
+		{
+			name: "異常ケース(有効化済み)",
+			s: &mfaService{
+				repo: func() *mock_repository.MockMFA {
+					mock := mock_repository.NewMockMFA(ctrl)
+					mock.EXPECT().FindByUserID("1").Return(model.NewMFA("1", "OLD", now, 0, now), nil)
+					return mock
+				}(),
+				newSecret: newSecret,
+			},
+			want:    nil,
+			wantErr: ErrMFAAlreadyEnabled,
+		},
+		{
+			name: "異常ケース(取得エラー)",
+			s: &mfaService{
+				repo: func() *mock_repository.MockMFA {
+					mock := mock_repository.NewMockMFA(ctrl)
+					mock.EXPECT().FindByUserID("1").Return(nil, errTest)
+					return mock
+				}(),
+				newSecret: newSecret,
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+		{
+			name: "異常ケース(秘密鍵生成エラー)",
+			s: &mfaService{
+				repo: func() *mock_repository.MockMFA {
+					mock := mock_repository.NewMockMFA(ctrl)
+					mock.EXPECT().FindByUserID("1").Return(nil, repository.ErrMFANotFound)
+					return mock
+				}(),
+				newSecret: func() (string, error) { return "", errTest },
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+		{
+			name: "異常ケース(保存エラー)",
+			s: &mfaService{
+				repo: func() *mock_repository.MockMFA {
+					mock := mock_repository.NewMockMFA(ctrl)
+					mock.EXPECT().FindByUserID("1").Return(nil, repository.ErrMFANotFound)
+					mock.EXPECT().Save(gomock.Any()).Return(errTest)
+					return mock
+				}(),
+				newSecret: newSecret,
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.s.Enroll("1", now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_mfaService_Confirm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	created := time.Unix(1111111000, 0)
+	now := time.Unix(1111111109, 0)
+	step := now.Unix() / 30
+	pending := model.NewMFA("1", mfaTestSecret, time.Time{}, 0, created)
+	newCode := func() (string, error) { return "0123a-4567b", nil }
+	hashes := make([]string, recoveryCodeCount)
+	codes := make([]string, recoveryCodeCount)
+	for i := range hashes {
+		hashes[i] = model.HashMFAToken("0123a4567b")
+		codes[i] = "0123a-4567b"
+	}
+
+	tests := []struct {
+		name    string
+		code    string
+		s       *mfaService
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "正常ケース",
+			code: mfaTestCode(t, step),
+			s: &mfaService{
+				repo: func() *mock_repository.MockMFA {
+					mock := mock_repository.NewMockMFA(ctrl)
+					mock.EXPECT().FindByUserID("1").Return(pending, nil)
+					mock.EXPECT().Save(model.NewMFA("1", mfaTestSecret, now, step, created)).Return(nil)
+					mock.EXPECT().ReplaceRecoveryCodes("1", hashes).Return(nil)
+					return mock
+				}(),
+				newRecoveryCode: newCode,
+			},
+			want:    codes,
+			wantErr: nil,
+		},
+		{
+			name: "異常ケース(未登録)",
+			code: mfaTestCode(t, step),
+			s: &mfaService{
+				repo: func() *mock_repository.MockMFA {
+					mock := mock_repository.NewMockMFA(ctrl)
+					mock.EXPECT().FindByUserID("1").Return(nil, repository.ErrMFANotFound)
+					return mock
+				}(),
+			},
+			want:    nil,
+			wantErr: ErrMFANotEnrolled,
+		},
+		{
+			name: "異常ケース(有効化済み)",
+			code: mfaTestCode(t, step),
+			s: &mfaService{
+				repo: func() *mock_repository.MockMFA {
+					mock := mock_repository.NewMockMFA(ctrl)
+					mock.EXPECT().FindByUserID("1").Return(model.NewMFA("1", mfaTestSecret, created, 0, created), nil)
+					return mock
+				}(),
+			},
+			want:    nil,
+			wantErr: ErrMFAAlreadyEnabled,
+		},
+		{
+			name: "異常ケース(コード不一致)",
+			code: mfaTestCode(t, step-2),
+			s: &mfaService{
+				repo: func() *mock_repository.MockMFA {
+					mock := mock_repository.NewMockMFA(ctrl)
+					mock.EXPECT().FindByUserID("1").Return(pending, nil)
+					return mock
+				}(),
+			},
+			want:    nil,
+			wantErr: ErrInvalidMFACode,
+		},
+		{
+			name: "異常ケース(保存エラー)",
+			code: mfaTestCode(t, step),
+			s: &mfaService{
+				repo: func() *mock_repository.MockMFA {
+					mock := mock_repository.NewMockMFA(ctrl)
+					mock.EXPECT().FindByUserID("1").Return(pending, nil)
+					mock.EXPECT().Save(gomock.Any()).Return(errTest)
+					return mock
+				}(),
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+		{
+			name: "異常ケース(リカバリーコード生成エラー)",
+			code: mfaTestCode(t, step),
+			s: &mfaService{
+				repo: func() *mock_repository.MockMFA {
+					mock := mock_repository.NewMockMFA(ctrl)
+					mock.EXPECT().FindByUserID("1").Return(pending, nil)
+					mock.EXPECT().Save(gomock.Any()).Return(nil)
+					return mock
+				}(),
+				newRecoveryCode: func() (string, error) { return "", errTest },
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+		{
+			name: "異常ケース(リカバリーコード登録エラー)",
+			code: mfaTestCode(t, step),
+			s: &mfaService{
+				repo: func() *mock_repository.MockMFA {
+					mock := mock_repository.NewMockMFA(ctrl)
+					mock.EXPECT().FindByUserID("1").Return(pending, nil)
+					mock.EXPECT().Save(gomock.Any()).Return(nil)
+					mock.EXPECT().ReplaceRecoveryCodes("1", gomock.Any()).Return(errTest)
+					return mock
+				}(),
+				newRecoveryCode: newCode,
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.s.Confirm("1", tt.code, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_mfaService_Disable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	now := time.Unix(1111111109, 0)
+	step := now.Unix() / 30
+	enabled := model.NewMFA("1", mfaTestSecret, now, 0, now)
+
+	tests := []struct {
+		name    string
+		code    string
+		repo    func() *mock_repository.MockMFA
+		wantErr error
+	}{
+		{
+			name: "正常ケース(確認コード)",
+			code: mfaTestCode(t, step),
+			repo: func() *mock_repository.MockMFA {
+				mock := mock_repository.NewMockMFA(ctrl)
+				mock.EXPECT().FindByUserID("1").Return(enabled, nil)
+				mock.EXPECT().UpdateLastUsedStep("1", step).Return(nil)
+				mock.EXPECT().Delete("1").Return(nil)
+				return mock
+			},
+			wantErr: nil,
+		},
+		{
+			name: "正常ケース(リカバリーコード)",
+			code: "0123A-4567B",
+			repo: func() *mock_repository.MockMFA {
+				mock := mock_repository.NewMockMFA(ctrl)
+				mock.EXPECT().FindByUserID("1").Return(enabled, nil)
+				mock.EXPECT().UseRecoveryCode("1", model.HashMFAToken("0123a4567b"), now).Return(nil)
+				mock.EXPECT().Delete("1").Return(nil)
+				return mock
+			},
+			wantErr: nil,
+		},
+		{
+			name: "異常ケース(未登録)",
+			code: mfaTestCode(t, step),
+			repo: func() *mock_repository.MockMFA {
+				mock := mock_repository.NewMockMFA(ctrl)
+				mock.EXPECT().FindByUserID("1").Return(nil, repository.ErrMFANotFound)
+				return mock
+			},
+			wantErr: ErrMFANotEnrolled,
+		},
+		{
+			name: "異常ケース(確認前)",
+			code: mfaTestCode(t, step),
+			repo: func() *mock_repository.MockMFA {
+				mock := mock_repository.NewMockMFA(ctrl)
+				mock.EXPECT().FindByUserID("1").Return(model.NewMFA("1", mfaTestSecret, time.Time{}, 0, now), nil)
+				return mock
+			},
+			wantErr: ErrMFANotEnrolled,
+		},
+		{
+			name: "異常ケース(確認コード不一致)",
+			code: mfaTestCode(t, step+2),
+			repo: func() *mock_repository.MockMFA {
+				mock := mock_repository.NewMockMFA(ctrl)
+				mock.EXPECT().FindByUserID("1").Return(enabled, nil)
+				return mock
+			},
+			wantErr: ErrInvalidMFACode,
+		},
+		{
+			name: "異常ケース(リカバリーコード不一致)",
+			code: "0123a-4567b",
+			repo: func() *mock_repository.MockMFA {
+				mock := mock_repository.NewMockMFA(ctrl)
+				mock.EXPECT().FindByUserID("1").Return(enabled, nil)
+				mock.EXPECT().UseRecoveryCode("1", gomock.Any(), now).Return(repository.ErrRecoveryCodeNotFound)
+				return mock
+			},
+			wantErr: ErrInvalidMFACode,
+		},
+		{
+			name: "異常ケース(リカバリーコード使用エラー)",
+			code: "0123a-4567b",
+			repo: func() *mock_repository.MockMFA {
+				mock := mock_repository.NewMockMFA(ctrl)
+				mock.EXPECT().FindByUserID("1").Return(enabled, nil)
+				mock.EXPECT().UseRecoveryCode("1", gomock.Any(), now).Return(errTest)
+				return mock
+			},
+			wantErr: errTest,
+		},
+		{
+			name: "異常ケース(削除エラー)",
+			code: mfaTestCode(t, step),
+			repo: func() *mock_repository.MockMFA {
+				mock := mock_repository.NewMockMFA(ctrl)
+				mock.EXPECT().FindByUserID("1").Return(enabled, nil)
+				mock.EXPECT().UpdateLastUsedStep("1", step).Return(nil)
+				mock.EXPECT().Delete("1").Return(errTest)
+				return mock
+			},
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &mfaService{repo: tt.repo()}
+			if err := s.Disable("1", tt.code, now); !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_mfaService_IsEnabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		mfa     model.MFA
+		err     error
+		want    bool
+		wantErr error
+	}{
+		{name: "有効", mfa: model.NewMFA("1", "SECRET", now, 0, now), want: true},
+		{name: "確認前", mfa: model.NewMFA("1", "SECRET", time.Time{}, 0, now), want: false},
+		{name: "未登録", err: repository.ErrMFANotFound, want: false},
+		{name: "異常ケース(取得エラー)", err: errTest, want: false, wantErr: errTest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mock_repository.NewMockMFA(ctrl)
+			repo.EXPECT().FindByUserID("1").Return(tt.mfa, tt.err)
+
+			got, err := (&mfaService{repo: repo}).IsEnabled("1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_mfaService_IssueChallenge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	newToken := func() (string, error) { return "token", nil }
+	challenge := model.NewMFAChallenge(model.HashMFAToken("token"), "1", 0, now.Add(mfaChallengeTTL), now)
+
+	tests := []struct {
+		name          string
+		s             *mfaService
+		wantToken     string
+		wantChallenge model.MFAChallenge
+		wantErr       error
+	}{
+		{
+			name: "正常ケース",
+			s: &mfaService{
+				repo: func() *mock_repository.MockMFA {
+					mock := mock_repository.NewMockMFA(ctrl)
+					mock.EXPECT().DeleteExpiredChallenges("1", now).Return(nil)
+					mock.EXPECT().RegistChallenge(challenge).Return(nil)
+					return mock
+				}(),
+				newToken: newToken,
+			},
+			wantToken:     "token",
+			wantChallenge: challenge,
+			wantErr:       nil,
+		},
+		{
+			name:    "異常ケース(トークン生成エラー)",
+			s:       &mfaService{newToken: func() (string, error) { return "", errTest }},
+			wantErr: errTest,
+		},
+		{
+			name: "異常ケース(期限切れチャレンジ削除エラー)",
+			s: &mfaService{
+				repo: func() *mock_repository.MockMFA {
+					mock := mock_repository.NewMockMFA(ctrl)
+					mock.EXPECT().DeleteExpiredChallenges("1", now).Return(errTest)
+					return mock
+				}(),
+				newToken: newToken,
+			},
+			wantErr: errTest,
+		},
+		{
+			name: "異常ケース(登録エラー)",
+			s: &mfaService{
+				repo: func() *mock_repository.MockMFA {
+					mock := mock_repository.NewMockMFA(ctrl)
+					mock.EXPECT().DeleteExpiredChallenges("1", now).Return(nil)
+					mock.EXPECT().RegistChallenge(gomock.Any()).Return(errTest)
+					return mock
+				}(),
+				newToken: newToken,
+			},
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, challenge, err := tt.s.IssueChallenge("1", now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if token != tt.wantToken {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", token, tt.wantToken)
+			}
+			if !reflect.DeepEqual(challenge, tt.wantChallenge) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", challenge, tt.wantChallenge)
+			}
+		})
+	}
+}
+
+func Test_mfaService_VerifyChallenge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	now := time.Unix(1111111109, 0)
+	step := now.Unix() / 30
+	id := model.HashMFAToken("token")
+	challenge := model.NewMFAChallenge(id, "1", 0, now.Add(time.Minute), now)
+	enabled := model.NewMFA("1", mfaTestSecret, now, 0, now)
+
+	tests := []struct {
+		name       string
+		code       string
+		repo       func() *mock_repository.MockMFA
+		wantUserID string
+		wantOK     bool
+		wantErr    error
+	}{
+		{
+			name: "正常ケース(確認コード)",
+			code: mfaTestCode(t, step),
+			repo: func() *mock_repository.MockMFA {
+				mock := mock_repository.NewMockMFA(ctrl)
+				mock.EXPECT().FindChallenge(id).Return(challenge, nil)
+				mock.EXPECT().ConsumeChallengeAttempt(id, now).Return(nil)
+				mock.EXPECT().FindByUserID("1").Return(enabled, nil)
+				mock.EXPECT().UpdateLastUsedStep("1", step).Return(nil)
+				mock.EXPECT().DeleteChallenge(id).Return(nil)
+				return mock
+			},
+			wantUserID: "1",
+			wantOK:     true,
+		},
+		{
+			name: "正常ケース(リカバリーコード)",
+			code: "0123a-4567b",
+			repo: func() *mock_repository.MockMFA {
+				mock := mock_repository.NewMockMFA(ctrl)
+				mock.EXPECT().FindChallenge(id).Return(challenge, nil)
+				mock.EXPECT().ConsumeChallengeAttempt(id, now).Return(nil)
+				mock.EXPECT().FindByUserID("1").Return(enabled, nil)
+				mock.EXPECT().UseRecoveryCode("1", model.HashMFAToken("0123a4567b"), now).Return(nil)
+				mock.EXPECT().DeleteChallenge(id).Return(nil)
+				return mock
+			},
+			wantUserID: "1",
+			wantOK:     true,
+		},
+		{
+			name: "正常ケース(コード不一致)",
+			code: mfaTestCode(t, step-2),
+			repo: func() *mock_repository.MockMFA {
+				mock := mock_repository.NewMockMFA(ctrl)
+				mock.EXPECT().FindChallenge(id).Return(challenge, nil)
+				mock.EXPECT().ConsumeChallengeAttempt(id, now).Return(nil)
+				mock.EXPECT().FindByUserID("1").Return(enabled, nil)
+				return mock
+			},
+			wantOK: false,
+		},
+		{
+			name: "異常ケース(チャレンジなし)",
+			code: mfaTestCode(t, step),
+			repo: func() *mock_repository.MockMFA {
+				mock := mock_repository.NewMockMFA(ctrl)
+				mock.EXPECT().FindChallenge(id).Return(nil, repository.ErrMFAChallengeNotFound)
+				return mock
+			},
+			wantErr: ErrMFAChallengeInvalid,
+		},
+		{
+			name: "異常ケース(試行回数超過)",
+			code: mfaTestCode(t, step),
+			repo: func() *mock_repository.MockMFA {
+				mock := mock_repository.NewMockMFA(ctrl)
+				mock.EXPECT().FindChallenge(id).
+					Return(model.NewMFAChallenge(id, "1", model.MaxMFAChallengeAttempts, now.Add(time.Minute), now), nil)
+				return mock
+			},
+			wantErr: ErrMFAChallengeInvalid,
+		},
+		{
+			name: "異常ケース(2要素認証の無効化後)",
+			code: mfaTestCode(t, step),
+			repo: func() *mock_repository.MockMFA {
+				mock := mock_repository.NewMockMFA(ctrl)
+				mock.EXPECT().FindChallenge(id).Return(challenge, nil)
+				mock.EXPECT().ConsumeChallengeAttempt(id, now).Return(nil)
+				mock.EXPECT().FindByUserID("1").Return(nil, repository.ErrMFANotFound)
+				return mock
+			},
+			wantErr: ErrMFAChallengeInvalid,
+		},
+		{
+			name: "異常ケース(チャレンジ取得エラー)",
+			code: mfaTestCode(t, step),
+			repo: func() *mock_repository.MockMFA {
+				mock := mock_repository.NewMockMFA(ctrl)
+				mock.EXPECT().FindChallenge(id).Return(nil, errTest)
+				return mock
+			},
+			wantErr: errTest,
+		},
+		{
+			name: "正常ケース(並行したリクエストで使用済みのコード)",
+			code: mfaTestCode(t, step),
+			repo: func() *mock_repository.MockMFA {
+				mock := mock_repository.NewMockMFA(ctrl)
+				mock.EXPECT().FindChallenge(id).Return(challenge, nil)
+				mock.EXPECT().ConsumeChallengeAttempt(id, now).Return(nil)
+				mock.EXPECT().FindByUserID("1").Return(enabled, nil)
+				mock.EXPECT().UpdateLastUsedStep("1", step).Return(repository.ErrMFACodeUsed)
+				return mock
+			},
+			wantOK: false,
+		},
+		{
+			name: "異常ケース(並行したリクエストで試行回数超過)",
+			code: mfaTestCode(t, step),
+			repo: func() *mock_repository.MockMFA {
+				mock := mock_repository.NewMockMFA(ctrl)
+				mock.EXPECT().FindChallenge(id).Return(challenge, nil)
+				mock.EXPECT().ConsumeChallengeAttempt(id, now).Return(repository.ErrMFAChallengeNotFound)
+				return mock
+			},
+			wantErr: ErrMFAChallengeInvalid,
+		},
+		{
+			name: "異常ケース(試行回数更新エラー)",
+			code: mfaTestCode(t, step-2),
+			repo: func() *mock_repository.MockMFA {
+				mock := mock_repository.NewMockMFA(ctrl)
+				mock.EXPECT().FindChallenge(id).Return(challenge, nil)
+				mock.EXPECT().ConsumeChallengeAttempt(id, now).Return(errTest)
+				return mock
+			},
+			wantErr: errTest,
+		},
+		{
+			name: "異常ケース(チャレンジ削除エラー)",
+			code: mfaTestCode(t, step),
+			repo: func() *mock_repository.MockMFA {
+				mock := mock_repository.NewMockMFA(ctrl)
+				mock.EXPECT().FindChallenge(id).Return(challenge, nil)
+				mock.EXPECT().ConsumeChallengeAttempt(id, now).Return(nil)
+				mock.EXPECT().FindByUserID("1").Return(enabled, nil)
+				mock.EXPECT().UpdateLastUsedStep("1", step).Return(nil)
+				mock.EXPECT().DeleteChallenge(id).Return(errTest)
+				return mock
+			},
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &mfaService{repo: tt.repo()}
+			userID, ok, err := s.VerifyChallenge("token", tt.code, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if userID != tt.wantUserID || ok != tt.wantOK {
+				t.Errorf("戻り値不一致 got: (%#v, %t) want: (%#v, %t)", userID, ok, tt.wantUserID, tt.wantOK)
+			}
+		})
+	}
+}
+
+func Test_mfaService_VerifyChallenge_Concurrent(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+	step := now.Unix() / 30
+
+	// newService 2要素認証を有効にしたユーザーと、トークンごとのチャレンジを登録したサービスを生成する
+	newService := func(tokens ...string) *mfaService {
+		repo := inmemory.NewMFARepository()
+		if err := repo.Save(model.NewMFA("1", mfaTestSecret, now, 0, now)); err != nil {
+			t.Fatalf("予期せぬエラー(error: %s)", err)
+		}
+		for _, token := range tokens {
+			if err := repo.RegistChallenge(model.NewMFAChallenge(model.HashMFAToken(token), "1", 0, now.Add(time.Minute), now)); err != nil {
+				t.Fatalf("予期せぬエラー(error: %s)", err)
+			}
+		}
+		return &mfaService{repo: repo}
+	}
+
+	t.Run("誤ったコードは並行しても上限回数までしか試行できない", func(t *testing.T) {
+		s := newService("token")
+		code := mfaTestCode(t, step-2)
+
+		var (
+			wg       sync.WaitGroup
+			mu       sync.Mutex
+			tried    int
+			rejected int
+		)
+		for i := 0; i < 4*model.MaxMFAChallengeAttempts; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, ok, err := s.VerifyChallenge("token", code, now)
+				mu.Lock()
+				defer mu.Unlock()
+				switch {
+				case errors.Is(err, ErrMFAChallengeInvalid):
+					rejected++
+				case err == nil && !ok:
+					tried++
+				default:
+					t.Errorf("戻り値不一致 got: (%t, %v)", ok, err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if tried != model.MaxMFAChallengeAttempts || rejected != 3*model.MaxMFAChallengeAttempts {
+			t.Errorf("戻り値不一致 got: (%d, %d) want: (%d, %d)", tried, rejected, model.MaxMFAChallengeAttempts, 3*model.MaxMFAChallengeAttempts)
+		}
+	})
+
+	t.Run("同じ確認コードは並行しても1回しか使えない", func(t *testing.T) {
+		tokens := []string{"token1", "token2", "token3", "token4"}
+		s := newService(tokens...)
+		code := mfaTestCode(t, step)
+
+		var (
+			wg       sync.WaitGroup
+			mu       sync.Mutex
+			accepted int
+		)
+		for _, token := range tokens {
+			wg.Add(1)
+			go func(token string) {
+				defer wg.Done()
+				_, ok, err := s.VerifyChallenge(token, code, now)
+				if err != nil {
+					t.Errorf("予期せぬエラー(error: %s)", err)
+				}
+				if ok {
+					mu.Lock()
+					accepted++
+					mu.Unlock()
+				}
+			}(token)
+		}
+		wg.Wait()
+
+		if accepted != 1 {
+			t.Errorf("戻り値不一致 got: %d want: 1", accepted)
+		}
+	})
+}
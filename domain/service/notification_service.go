@@ -0,0 +1,144 @@
+package service
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+type (
+	// Notification 通知サービス
+	// mockgen -source domain/service/notification_service.go -destination mock/mock_service/notification_service_mock.go
+	Notification interface {
+		List(userID string, beforeID string, limit int) ([]model.Notification, int, error)
+		Notify(notification model.Notification, now time.Time) (bool, error)
+		MarkRead(userID string, id string, now time.Time) error
+		MarkAllRead(userID string, now time.Time) error
+		Preferences(userID string) (model.NotificationPreferences, error)
+		UpdatePreferences(userID string, preferences model.NotificationPreferences, now time.Time) error
+	}
+
+	// NotificationFactory 通知サービスファクトリー
+	NotificationFactory interface {
+		NewNotificationService(repo repository.Notification) Notification
+	}
+
+	notificationService struct {
+		repo repository.Notification
+	}
+
+	notificationServiceFactory struct{}
+)
+
+var _ Notification = (*notificationService)(nil)
+
+var (
+	ErrNotificationNotFound    = errors.New("notification not found")
+	ErrInvalidNotificationKind = errors.New("invalid notification kind")
+)
+
+const (
+	// DefaultNotificationLimit 通知一覧の件数の既定値
+	DefaultNotificationLimit = 50
+	// MaxNotificationLimit 通知一覧の件数の上限
+	MaxNotificationLimit = 100
+)
+
+// NewNotificationServiceFactory 通知サービスファクトリーを生成する
+func NewNotificationServiceFactory() *notificationServiceFactory {
+	return &notificationServiceFactory{}
+}
+
+// NewNotificationService 通知サービスを生成する
+func (f *notificationServiceFactory) NewNotificationService(repo repository.Notification) Notification {
+	return &notificationService{repo: repo}
+}
+
+// List ユーザーの通知を新しい順に取得し、未読数とともに返す
+func (s *notificationService) List(userID string, beforeID string, limit int) ([]model.Notification, int, error) {
+	if limit <= 0 {
+		limit = DefaultNotificationLimit
+	} else if limit > MaxNotificationLimit {
+		limit = MaxNotificationLimit
+	}
+
+	notifications, err := s.repo.FindByUserID(userID, beforeID, limit)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "List error")
+	}
+
+	unread, err := s.repo.CountUnread(userID)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "List error")
+	}
+
+	return notifications, unread, nil
+}
+
+// Notify 通知先の受信設定で許可されている場合に通知を登録し、登録したかを返す
+// 自分自身の操作は通知しない
+func (s *notificationService) Notify(notification model.Notification, now time.Time) (bool, error) {
+	if !notification.Kind().IsValid() {
+		return false, ErrInvalidNotificationKind
+	}
+	if notification.UserID() == notification.ActorID() {
+		return false, nil
+	}
+
+	preferences, err := s.repo.FindPreferences(notification.UserID())
+	if err != nil {
+		return false, errors.Wrap(err, "Notify error")
+	}
+	if !preferences.Allows(notification.Kind()) {
+		return false, nil
+	}
+
+	if _, err := s.repo.Regist(notification, now); err != nil {
+		return false, errors.Wrap(err, "Notify error")
+	}
+
+	return true, nil
+}
+
+// MarkRead ユーザーの通知を既読にする
+func (s *notificationService) MarkRead(userID string, id string, now time.Time) error {
+	err := s.repo.MarkRead(userID, id, now)
+	if errors.Is(err, repository.ErrNotificationNotFound) {
+		return ErrNotificationNotFound
+	} else if err != nil {
+		return errors.Wrap(err, "MarkRead error")
+	}
+
+	return nil
+}
+
+// MarkAllRead ユーザーの通知をすべて既読にする
+func (s *notificationService) MarkAllRead(userID string, now time.Time) error {
+	if err := s.repo.MarkAllRead(userID, now); err != nil {
+		return errors.Wrap(err, "MarkAllRead error")
+	}
+
+	return nil
+}
+
+// Preferences ユーザーの受信設定を返す
+func (s *notificationService) Preferences(userID string) (model.NotificationPreferences, error) {
+	preferences, err := s.repo.FindPreferences(userID)
+	if err != nil {
+		return model.NotificationPreferences{}, errors.Wrap(err, "Preferences error")
+	}
+
+	return preferences, nil
+}
+
+// UpdatePreferences ユーザーの受信設定を更新する
+func (s *notificationService) UpdatePreferences(userID string, preferences model.NotificationPreferences, now time.Time) error {
+	if err := s.repo.SavePreferences(userID, preferences, now); err != nil {
+		return errors.Wrap(err, "UpdatePreferences error")
+	}
+
+	return nil
+}
@@ -0,0 +1,318 @@
+package service
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+	"GoBBS/mock/mock_repository"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewNotificationService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repository.NewMockNotification(ctrl)
+	want := &notificationService{repo: repo}
+	if got := NewNotificationServiceFactory().NewNotificationService(repo); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewNotificationService() = %v, want %v", got, want)
+	}
+}
+
+func Test_notificationService_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	notifications := []model.Notification{
+		model.NewNotification("1", "1", model.NotificationReply, "2", "10", time.Time{}, now),
+	}
+
+	tests := []struct {
+		name       string
+		limit      int
+		s          *notificationService
+		want       []model.Notification
+		wantUnread int
+		wantErr    error
+	}{
+		{
+			name:  "正常ケース",
+			limit: 20,
+			s: &notificationService{
+				repo: func() *mock_repository.MockNotification {
+					mock := mock_repository.NewMockNotification(ctrl)
+					mock.EXPECT().FindByUserID("1", "5", 20).Return(notifications, nil)
+					mock.EXPECT().CountUnread("1").Return(3, nil)
+					return mock
+				}(),
+			},
+			want:       notifications,
+			wantUnread: 3,
+			wantErr:    nil,
+		},
+		{
+			name:  "正常ケース(件数未指定)",
+			limit: 0,
+			s: &notificationService{
+				repo: func() *mock_repository.MockNotification {
+					mock := mock_repository.NewMockNotification(ctrl)
+					mock.EXPECT().FindByUserID("1", "5", DefaultNotificationLimit).Return(notifications, nil)
+					mock.EXPECT().CountUnread("1").Return(1, nil)
+					return mock
+				}(),
+			},
+			want:       notifications,
+			wantUnread: 1,
+			wantErr:    nil,
+		},
+		{
+			name:  "正常ケース(件数上限)",
+			limit: 1000,
+			s: &notificationService{
+				repo: func() *mock_repository.MockNotification {
+					mock := mock_repository.NewMockNotification(ctrl)
+					mock.EXPECT().FindByUserID("1", "5", MaxNotificationLimit).Return(notifications, nil)
+					mock.EXPECT().CountUnread("1").Return(1, nil)
+					return mock
+				}(),
+			},
+			want:       notifications,
+			wantUnread: 1,
+			wantErr:    nil,
+		},
+		{
+			name:  "異常ケース(取得失敗)",
+			limit: 20,
+			s: &notificationService{
+				repo: func() *mock_repository.MockNotification {
+					mock := mock_repository.NewMockNotification(ctrl)
+					mock.EXPECT().FindByUserID("1", "5", 20).Return(nil, errTest)
+					return mock
+				}(),
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+		{
+			name:  "異常ケース(未読数取得失敗)",
+			limit: 20,
+			s: &notificationService{
+				repo: func() *mock_repository.MockNotification {
+					mock := mock_repository.NewMockNotification(ctrl)
+					mock.EXPECT().FindByUserID("1", "5", 20).Return(notifications, nil)
+					mock.EXPECT().CountUnread("1").Return(0, errTest)
+					return mock
+				}(),
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, unread, err := tt.s.List("1", "5", tt.limit)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+			if unread != tt.wantUnread {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", unread, tt.wantUnread)
+			}
+		})
+	}
+}
+
+func Test_notificationService_Notify(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	notification := model.NewNotification("", "1", model.NotificationQuote, "2", "10", time.Time{}, time.Time{})
+
+	tests := []struct {
+		name         string
+		notification model.Notification
+		s            *notificationService
+		want         bool
+		wantErr      error
+	}{
+		{
+			name:         "正常ケース",
+			notification: notification,
+			s: &notificationService{
+				repo: func() *mock_repository.MockNotification {
+					mock := mock_repository.NewMockNotification(ctrl)
+					gomock.InOrder(
+						mock.EXPECT().FindPreferences("1").Return(model.DefaultNotificationPreferences(), nil),
+						mock.EXPECT().Regist(notification, now).Return("1", nil),
+					)
+					return mock
+				}(),
+			},
+			want:    true,
+			wantErr: nil,
+		},
+		{
+			name:         "正常ケース(受信設定で拒否)",
+			notification: notification,
+			s: &notificationService{
+				repo: func() *mock_repository.MockNotification {
+					mock := mock_repository.NewMockNotification(ctrl)
+					mock.EXPECT().FindPreferences("1").Return(model.NotificationPreferences{Reply: true, Mention: true}, nil)
+					return mock
+				}(),
+			},
+			want:    false,
+			wantErr: nil,
+		},
+		{
+			name:         "正常ケース(自分自身の操作)",
+			notification: model.NewNotification("", "1", model.NotificationReply, "1", "10", time.Time{}, time.Time{}),
+			s:            &notificationService{},
+			want:         false,
+			wantErr:      nil,
+		},
+		{
+			name:         "異常ケース(通知の種類不正)",
+			notification: model.NewNotification("", "1", "like", "2", "10", time.Time{}, time.Time{}),
+			s:            &notificationService{},
+			want:         false,
+			wantErr:      ErrInvalidNotificationKind,
+		},
+		{
+			name:         "異常ケース(受信設定取得失敗)",
+			notification: notification,
+			s: &notificationService{
+				repo: func() *mock_repository.MockNotification {
+					mock := mock_repository.NewMockNotification(ctrl)
+					mock.EXPECT().FindPreferences("1").Return(model.NotificationPreferences{}, errTest)
+					return mock
+				}(),
+			},
+			want:    false,
+			wantErr: errTest,
+		},
+		{
+			name:         "異常ケース(登録失敗)",
+			notification: notification,
+			s: &notificationService{
+				repo: func() *mock_repository.MockNotification {
+					mock := mock_repository.NewMockNotification(ctrl)
+					mock.EXPECT().FindPreferences("1").Return(model.DefaultNotificationPreferences(), nil)
+					mock.EXPECT().Regist(notification, now).Return("", errTest)
+					return mock
+				}(),
+			},
+			want:    false,
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.s.Notify(tt.notification, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_notificationService_MarkRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		err     error
+		wantErr error
+	}{
+		{name: "正常ケース", err: nil, wantErr: nil},
+		{name: "異常ケース(通知が未登録)", err: repository.ErrNotificationNotFound, wantErr: ErrNotificationNotFound},
+		{name: "異常ケース(更新失敗)", err: errTest, wantErr: errTest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mock_repository.NewMockNotification(ctrl)
+			repo.EXPECT().MarkRead("1", "5", now).Return(tt.err)
+
+			err := (&notificationService{repo: repo}).MarkRead("1", "5", now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_notificationService_MarkAllRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for _, wantErr := range []error{nil, errTest} {
+		repo := mock_repository.NewMockNotification(ctrl)
+		repo.EXPECT().MarkAllRead("1", now).Return(wantErr)
+
+		if err := (&notificationService{repo: repo}).MarkAllRead("1", now); !errors.Is(err, wantErr) {
+			t.Errorf("戻り値不一致 got: %#v want: %#v", err, wantErr)
+		}
+	}
+}
+
+func Test_notificationService_Preferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	preferences := model.NotificationPreferences{Reply: true}
+
+	repo := mock_repository.NewMockNotification(ctrl)
+	repo.EXPECT().FindPreferences("1").Return(preferences, nil)
+	repo.EXPECT().FindPreferences("2").Return(model.NotificationPreferences{}, errTest)
+	s := &notificationService{repo: repo}
+
+	got, err := s.Preferences("1")
+	if err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	if got != preferences {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, preferences)
+	}
+	if _, err := s.Preferences("2"); !errors.Is(err, errTest) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", err, errTest)
+	}
+}
+
+func Test_notificationService_UpdatePreferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	preferences := model.NotificationPreferences{Mention: true}
+
+	for _, wantErr := range []error{nil, errTest} {
+		repo := mock_repository.NewMockNotification(ctrl)
+		repo.EXPECT().SavePreferences("1", preferences, now).Return(wantErr)
+
+		if err := (&notificationService{repo: repo}).UpdatePreferences("1", preferences, now); !errors.Is(err, wantErr) {
+			t.Errorf("戻り値不一致 got: %#v want: %#v", err, wantErr)
+		}
+	}
+}
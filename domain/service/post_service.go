@@ -0,0 +1,339 @@
+package service
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+type (
+	// Post スレッド・投稿サービス
+	// mockgen -source domain/service/post_service.go -destination mock/mock_service/post_service_mock.go
+	Post interface {
+		Threads(boardID string, beforeID string, limit int) ([]model.Thread, error)
+		Thread(id string) (model.Thread, error)
+		Posts(threadID string, afterNumber int, limit int) ([]model.Post, error)
+		CreateThread(boardID string, userID string, title string, body string, now time.Time) (model.Thread, model.Post, error)
+		Reply(threadID string, userID string, body string, now time.Time) (model.Post, error)
+		Post(id string) (model.Post, error)
+		PostByNumber(threadID string, number int) (model.Post, error)
+		Edit(id string, editorID string, moderator bool, body string, editWindow time.Duration, now time.Time) (model.Post, error)
+		Revisions(postID string) ([]model.PostRevision, error)
+		Revision(postID string, revisionID string) (model.PostRevision, error)
+		Hide(id string, reason string, now time.Time) (model.Post, error)
+	}
+
+	// PostFactory スレッド・投稿サービスファクトリー
+	PostFactory interface {
+		NewPostService(threadRepo repository.Thread, postRepo repository.Post, revisionRepo repository.PostRevision, renderer BodyRenderer) Post
+	}
+
+	// BodyRenderer 投稿の本文(マークダウン)をサニタイズ済みのHTMLに変換する
+	// 引用レスは1からlastNumberまでの投稿番号のみリンクにする
+	BodyRenderer interface {
+		Render(source string, lastNumber int) (string, error)
+	}
+
+	postService struct {
+		threadRepo   repository.Thread
+		postRepo     repository.Post
+		revisionRepo repository.PostRevision
+		renderer     BodyRenderer
+	}
+
+	postServiceFactory struct{}
+)
+
+var _ Post = (*postService)(nil)
+
+var (
+	ErrThreadNotFound        = errors.New("thread not found")
+	ErrPostNotFound          = errors.New("post not found")
+	ErrPostRevisionNotFound  = errors.New("post revision not found")
+	ErrInvalidThreadTitle    = errors.New("invalid thread title")
+	ErrInvalidPostBody       = errors.New("invalid post body")
+	ErrPostEditForbidden     = errors.New("post edit forbidden")
+	ErrPostEditWindowExpired = errors.New("post edit window expired")
+	ErrPostAlreadyHidden     = errors.New("post already hidden")
+	ErrInvalidHideReason     = errors.New("invalid hide reason")
+	ErrPostHidden            = errors.New("post hidden")
+)
+
+const (
+	// DefaultThreadLimit スレッド一覧の件数の既定値
+	DefaultThreadLimit = 50
+	// MaxThreadLimit スレッド一覧の件数の上限
+	MaxThreadLimit = 100
+	// DefaultPostLimit 投稿一覧の件数の既定値
+	DefaultPostLimit = 100
+	// MaxPostLimit 投稿一覧の件数の上限
+	MaxPostLimit = 500
+	// maxThreadTitleLength スレッドのタイトルの文字数の上限
+	maxThreadTitleLength = 255
+	// maxPostBodyLength 投稿の本文の文字数の上限
+	maxPostBodyLength = 10000
+	// maxHideReasonLength 非表示の理由の文字数の上限
+	maxHideReasonLength = 255
+)
+
+// NewPostServiceFactory スレッド・投稿サービスファクトリーを生成する
+func NewPostServiceFactory() *postServiceFactory {
+	return &postServiceFactory{}
+}
+
+// NewPostService スレッド・投稿サービスを生成する
+func (f *postServiceFactory) NewPostService(threadRepo repository.Thread, postRepo repository.Post, revisionRepo repository.PostRevision, renderer BodyRenderer) Post {
+	return &postService{threadRepo: threadRepo, postRepo: postRepo, revisionRepo: revisionRepo, renderer: renderer}
+}
+
+// Threads 板のスレッドを新しい順に返す
+func (s *postService) Threads(boardID string, beforeID string, limit int) ([]model.Thread, error) {
+	if limit <= 0 {
+		limit = DefaultThreadLimit
+	} else if limit > MaxThreadLimit {
+		limit = MaxThreadLimit
+	}
+
+	threads, err := s.threadRepo.FindByBoardID(boardID, beforeID, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "Threads error")
+	}
+
+	return threads, nil
+}
+
+// Thread IDでスレッドを返す
+func (s *postService) Thread(id string) (model.Thread, error) {
+	thread, err := s.threadRepo.FindByID(id)
+	if errors.Is(err, repository.ErrThreadNotFound) {
+		return nil, ErrThreadNotFound
+	} else if err != nil {
+		return nil, errors.Wrap(err, "Thread error")
+	}
+
+	return thread, nil
+}
+
+// Posts スレッドのafterNumberより後の投稿を番号順に返す
+func (s *postService) Posts(threadID string, afterNumber int, limit int) ([]model.Post, error) {
+	if limit <= 0 {
+		limit = DefaultPostLimit
+	} else if limit > MaxPostLimit {
+		limit = MaxPostLimit
+	}
+
+	posts, err := s.postRepo.FindByThreadID(threadID, afterNumber, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "Posts error")
+	}
+
+	return posts, nil
+}
+
+// CreateThread スレッドを作成し、本文を1番目の投稿として登録する
+func (s *postService) CreateThread(boardID string, userID string, title string, body string, now time.Time) (model.Thread, model.Post, error) {
+	if !isValidText(title, maxThreadTitleLength) {
+		return nil, nil, ErrInvalidThreadTitle
+	}
+	if !isValidText(body, maxPostBodyLength) {
+		return nil, nil, ErrInvalidPostBody
+	}
+
+	id, err := s.threadRepo.Regist(model.NewThread("", boardID, userID, title, 0, now, now), now)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "CreateThread error")
+	}
+
+	post, err := s.Reply(id, userID, body, now)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "CreateThread error")
+	}
+
+	return model.NewThread(id, boardID, userID, title, post.Number(), now, now), post, nil
+}
+
+// Reply スレッドに投稿する
+// 投稿番号はスレッドの投稿数を増やして採番し、本文はそれより前の投稿だけを引用できるHTMLにレンダリングして保存する
+// 投稿時の本文を最初の版として記録する
+func (s *postService) Reply(threadID string, userID string, body string, now time.Time) (model.Post, error) {
+	if !isValidText(body, maxPostBodyLength) {
+		return nil, ErrInvalidPostBody
+	}
+
+	number, err := s.threadRepo.AddPost(threadID, now)
+	if errors.Is(err, repository.ErrThreadNotFound) {
+		return nil, ErrThreadNotFound
+	} else if err != nil {
+		return nil, errors.Wrap(err, "Reply error")
+	}
+
+	bodyHTML, err := s.renderer.Render(body, number-1)
+	if err != nil {
+		return nil, errors.Wrap(err, "Reply error")
+	}
+
+	post := model.NewPost("", threadID, userID, number, body, bodyHTML, now, time.Time{}, time.Time{}, "")
+	id, err := s.postRepo.Regist(post, now)
+	if err != nil {
+		return nil, errors.Wrap(err, "Reply error")
+	}
+	if _, err := s.revisionRepo.Regist(model.NewPostRevision("", id, userID, body, now), now); err != nil {
+		return nil, errors.Wrap(err, "Reply error")
+	}
+
+	return model.NewPost(id, threadID, userID, number, body, bodyHTML, now, time.Time{}, time.Time{}, ""), nil
+}
+
+// Post IDで投稿を返す
+func (s *postService) Post(id string) (model.Post, error) {
+	post, err := s.postRepo.FindByID(id)
+	if errors.Is(err, repository.ErrPostNotFound) {
+		return nil, ErrPostNotFound
+	} else if err != nil {
+		return nil, errors.Wrap(err, "Post error")
+	}
+
+	return post, nil
+}
+
+// PostByNumber スレッド内の投稿番号で投稿を返す
+func (s *postService) PostByNumber(threadID string, number int) (model.Post, error) {
+	if number <= 0 {
+		return nil, ErrPostNotFound
+	}
+
+	posts, err := s.postRepo.FindByThreadID(threadID, number-1, 1)
+	if err != nil {
+		return nil, errors.Wrap(err, "PostByNumber error")
+	}
+	if len(posts) == 0 || posts[0].Number() != number {
+		return nil, ErrPostNotFound
+	}
+
+	return posts[0], nil
+}
+
+// Edit 投稿の本文を編集し、新しい版として記録する
+// 投稿者は投稿からeditWindowの間だけ編集でき(非表示にされた投稿は編集できない)、モデレーターはいつでも誰の投稿でも編集できる
+func (s *postService) Edit(id string, editorID string, moderator bool, body string, editWindow time.Duration, now time.Time) (model.Post, error) {
+	if !isValidText(body, maxPostBodyLength) {
+		return nil, ErrInvalidPostBody
+	}
+
+	post, err := s.Post(id)
+	if err != nil {
+		return nil, err
+	}
+	if !moderator {
+		if post.UserID() == "" || post.UserID() != editorID || post.IsHidden() {
+			return nil, ErrPostEditForbidden
+		}
+		if !now.Before(post.CreatedAt().Add(editWindow)) {
+			return nil, ErrPostEditWindowExpired
+		}
+	}
+
+	bodyHTML, err := s.renderer.Render(body, post.Number()-1)
+	if err != nil {
+		return nil, errors.Wrap(err, "Edit error")
+	}
+
+	edited := model.NewPost(post.ID(), post.ThreadID(), post.UserID(), post.Number(), body, bodyHTML, post.CreatedAt(), now, post.HiddenAt(), post.HiddenReason())
+	if err := s.postRepo.Update(edited, now); err != nil {
+		return nil, errors.Wrap(err, "Edit error")
+	}
+	if _, err := s.revisionRepo.Regist(model.NewPostRevision("", post.ID(), editorID, body, now), now); err != nil {
+		return nil, errors.Wrap(err, "Edit error")
+	}
+
+	return edited, nil
+}
+
+// Revisions 投稿の版を古い順に返す
+func (s *postService) Revisions(postID string) ([]model.PostRevision, error) {
+	if err := s.verifyVisible(postID); err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.revisionRepo.FindByPostID(postID)
+	if err != nil {
+		return nil, errors.Wrap(err, "Revisions error")
+	}
+
+	return revisions, nil
+}
+
+// Revision 投稿の版を返す(他の投稿の版は見つからないものとして扱う)
+func (s *postService) Revision(postID string, revisionID string) (model.PostRevision, error) {
+	if err := s.verifyVisible(postID); err != nil {
+		return nil, err
+	}
+
+	revision, err := s.revisionRepo.FindByID(revisionID)
+	if errors.Is(err, repository.ErrPostRevisionNotFound) {
+		return nil, ErrPostRevisionNotFound
+	} else if err != nil {
+		return nil, errors.Wrap(err, "Revision error")
+	}
+	if revision.PostID() != postID {
+		return nil, ErrPostRevisionNotFound
+	}
+
+	return revision, nil
+}
+
+// Hide モデレーターが投稿を非表示にする(理由は空でもよい)
+// 本文と版は残し、表示するときに隠す
+func (s *postService) Hide(id string, reason string, now time.Time) (model.Post, error) {
+	if utf8.RuneCountInString(reason) > maxHideReasonLength {
+		return nil, ErrInvalidHideReason
+	}
+
+	post, err := s.Post(id)
+	if err != nil {
+		return nil, err
+	}
+	if post.IsHidden() {
+		return nil, ErrPostAlreadyHidden
+	}
+
+	if err := s.postRepo.Hide(id, reason, now); err != nil {
+		return nil, errors.Wrap(err, "Hide error")
+	}
+
+	return model.NewPost(
+		post.ID(),
+		post.ThreadID(),
+		post.UserID(),
+		post.Number(),
+		post.Body(),
+		post.BodyHTML(),
+		post.CreatedAt(),
+		post.EditedAt(),
+		now,
+		reason,
+	), nil
+}
+
+// verifyVisible 投稿が存在し、非表示にされていないか確認する(非表示にされた投稿の版は返さない)
+func (s *postService) verifyVisible(postID string) error {
+	post, err := s.Post(postID)
+	if err != nil {
+		return err
+	}
+	if post.IsHidden() {
+		return ErrPostHidden
+	}
+
+	return nil
+}
+
+// isValidText 空白以外の文字を含み、文字数が上限以下か判定する
+func isValidText(s string, maxLength int) bool {
+	return strings.TrimSpace(s) != "" && utf8.RuneCountInString(s) <= maxLength
+}
@@ -0,0 +1,1114 @@
+package service
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+	"GoBBS/interface/inmemory"
+	"GoBBS/mock/mock_repository"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+// renderFunc 関数をレンダラーとして使う(mock_serviceはこのパッケージをインポートするため使えない)
+type renderFunc func(source string, lastNumber int) (string, error)
+
+// Render 関数を呼び出す
+func (f renderFunc) Render(source string, lastNumber int) (string, error) {
+	return f(source, lastNumber)
+}
+
+// numberRenderer 本文と引用可能な最後の投稿番号を並べたHTMLを返すレンダラー
+type numberRenderer struct{}
+
+// Render 本文と引用可能な最後の投稿番号を並べたHTMLを返す
+func (numberRenderer) Render(source string, lastNumber int) (string, error) {
+	return "<p>" + source + "</p>" + strconv.Itoa(lastNumber), nil
+}
+
+func TestNewPostService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	threadRepo := mock_repository.NewMockThread(ctrl)
+	postRepo := mock_repository.NewMockPost(ctrl)
+	revisionRepo := mock_repository.NewMockPostRevision(ctrl)
+	renderer := numberRenderer{}
+	want := &postService{threadRepo: threadRepo, postRepo: postRepo, revisionRepo: revisionRepo, renderer: renderer}
+	if got := NewPostServiceFactory().NewPostService(threadRepo, postRepo, revisionRepo, renderer); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewPostService() = %v, want %v", got, want)
+	}
+}
+
+func Test_postService_Threads(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	threads := []model.Thread{model.NewThread("1", "2", "3", "title", 1, time.Time{}, time.Time{})}
+
+	tests := []struct {
+		name    string
+		limit   int
+		s       *postService
+		want    []model.Thread
+		wantErr error
+	}{
+		{
+			name:  "正常ケース",
+			limit: 20,
+			s: &postService{
+				threadRepo: func() *mock_repository.MockThread {
+					mock := mock_repository.NewMockThread(ctrl)
+					mock.EXPECT().FindByBoardID("2", "5", 20).Return(threads, nil)
+					return mock
+				}(),
+			},
+			want:    threads,
+			wantErr: nil,
+		},
+		{
+			name:  "正常ケース(件数未指定)",
+			limit: 0,
+			s: &postService{
+				threadRepo: func() *mock_repository.MockThread {
+					mock := mock_repository.NewMockThread(ctrl)
+					mock.EXPECT().FindByBoardID("2", "5", DefaultThreadLimit).Return(threads, nil)
+					return mock
+				}(),
+			},
+			want:    threads,
+			wantErr: nil,
+		},
+		{
+			name:  "正常ケース(件数上限)",
+			limit: 1000,
+			s: &postService{
+				threadRepo: func() *mock_repository.MockThread {
+					mock := mock_repository.NewMockThread(ctrl)
+					mock.EXPECT().FindByBoardID("2", "5", MaxThreadLimit).Return(threads, nil)
+					return mock
+				}(),
+			},
+			want:    threads,
+			wantErr: nil,
+		},
+		{
+			name:  "異常ケース",
+			limit: 20,
+			s: &postService{
+				threadRepo: func() *mock_repository.MockThread {
+					mock := mock_repository.NewMockThread(ctrl)
+					mock.EXPECT().FindByBoardID("2", "5", 20).Return(nil, errTest)
+					return mock
+				}(),
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.s.Threads("2", "5", tt.limit)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postService_Thread(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	thread := model.NewThread("1", "2", "3", "title", 1, time.Time{}, time.Time{})
+
+	tests := []struct {
+		name    string
+		s       *postService
+		want    model.Thread
+		wantErr error
+	}{
+		{
+			name: "正常ケース",
+			s: &postService{
+				threadRepo: func() *mock_repository.MockThread {
+					mock := mock_repository.NewMockThread(ctrl)
+					mock.EXPECT().FindByID("1").Return(thread, nil)
+					return mock
+				}(),
+			},
+			want:    thread,
+			wantErr: nil,
+		},
+		{
+			name: "異常ケース(スレッドなし)",
+			s: &postService{
+				threadRepo: func() *mock_repository.MockThread {
+					mock := mock_repository.NewMockThread(ctrl)
+					mock.EXPECT().FindByID("1").Return(nil, repository.ErrThreadNotFound)
+					return mock
+				}(),
+			},
+			want:    nil,
+			wantErr: ErrThreadNotFound,
+		},
+		{
+			name: "異常ケース(取得失敗)",
+			s: &postService{
+				threadRepo: func() *mock_repository.MockThread {
+					mock := mock_repository.NewMockThread(ctrl)
+					mock.EXPECT().FindByID("1").Return(nil, errTest)
+					return mock
+				}(),
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.s.Thread("1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postService_Posts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	posts := []model.Post{model.NewPost("10", "1", "3", 1, "body", "<p>body</p>\n", time.Time{}, time.Time{}, time.Time{}, "")}
+
+	tests := []struct {
+		name    string
+		limit   int
+		s       *postService
+		want    []model.Post
+		wantErr error
+	}{
+		{
+			name:  "正常ケース",
+			limit: 20,
+			s: &postService{
+				postRepo: func() *mock_repository.MockPost {
+					mock := mock_repository.NewMockPost(ctrl)
+					mock.EXPECT().FindByThreadID("1", 5, 20).Return(posts, nil)
+					return mock
+				}(),
+			},
+			want:    posts,
+			wantErr: nil,
+		},
+		{
+			name:  "正常ケース(件数未指定)",
+			limit: 0,
+			s: &postService{
+				postRepo: func() *mock_repository.MockPost {
+					mock := mock_repository.NewMockPost(ctrl)
+					mock.EXPECT().FindByThreadID("1", 5, DefaultPostLimit).Return(posts, nil)
+					return mock
+				}(),
+			},
+			want:    posts,
+			wantErr: nil,
+		},
+		{
+			name:  "正常ケース(件数上限)",
+			limit: 10000,
+			s: &postService{
+				postRepo: func() *mock_repository.MockPost {
+					mock := mock_repository.NewMockPost(ctrl)
+					mock.EXPECT().FindByThreadID("1", 5, MaxPostLimit).Return(posts, nil)
+					return mock
+				}(),
+			},
+			want:    posts,
+			wantErr: nil,
+		},
+		{
+			name:  "異常ケース",
+			limit: 20,
+			s: &postService{
+				postRepo: func() *mock_repository.MockPost {
+					mock := mock_repository.NewMockPost(ctrl)
+					mock.EXPECT().FindByThreadID("1", 5, 20).Return(nil, errTest)
+					return mock
+				}(),
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.s.Posts("1", 5, tt.limit)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postService_Reply(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		body    string
+		s       *postService
+		want    model.Post
+		wantErr error
+	}{
+		{
+			name: "正常ケース",
+			body: ">>2 body",
+			s: &postService{
+				threadRepo: func() *mock_repository.MockThread {
+					mock := mock_repository.NewMockThread(ctrl)
+					mock.EXPECT().AddPost("1", now).Return(3, nil)
+					return mock
+				}(),
+				postRepo: func() *mock_repository.MockPost {
+					mock := mock_repository.NewMockPost(ctrl)
+					mock.EXPECT().Regist(model.NewPost("", "1", "2", 3, ">>2 body", "<p>html</p>\n", now, time.Time{}, time.Time{}, ""), now).Return("10", nil)
+					return mock
+				}(),
+				revisionRepo: func() *mock_repository.MockPostRevision {
+					mock := mock_repository.NewMockPostRevision(ctrl)
+					mock.EXPECT().Regist(model.NewPostRevision("", "10", "2", ">>2 body", now), now).Return("20", nil)
+					return mock
+				}(),
+				renderer: renderFunc(func(source string, lastNumber int) (string, error) {
+					if source != ">>2 body" || lastNumber != 2 {
+						t.Errorf("Render(%q, %d)", source, lastNumber)
+					}
+					return "<p>html</p>\n", nil
+				}),
+			},
+			want:    model.NewPost("10", "1", "2", 3, ">>2 body", "<p>html</p>\n", now, time.Time{}, time.Time{}, ""),
+			wantErr: nil,
+		},
+		{
+			name:    "異常ケース(本文が空白のみ)",
+			body:    " \n",
+			s:       &postService{},
+			want:    nil,
+			wantErr: ErrInvalidPostBody,
+		},
+		{
+			name:    "異常ケース(本文が長すぎる)",
+			body:    strings.Repeat("あ", maxPostBodyLength+1),
+			s:       &postService{},
+			want:    nil,
+			wantErr: ErrInvalidPostBody,
+		},
+		{
+			name: "異常ケース(スレッドなし)",
+			body: "body",
+			s: &postService{
+				threadRepo: func() *mock_repository.MockThread {
+					mock := mock_repository.NewMockThread(ctrl)
+					mock.EXPECT().AddPost("1", now).Return(0, repository.ErrThreadNotFound)
+					return mock
+				}(),
+			},
+			want:    nil,
+			wantErr: ErrThreadNotFound,
+		},
+		{
+			name: "異常ケース(レンダリング失敗)",
+			body: "body",
+			s: &postService{
+				threadRepo: func() *mock_repository.MockThread {
+					mock := mock_repository.NewMockThread(ctrl)
+					mock.EXPECT().AddPost("1", now).Return(1, nil)
+					return mock
+				}(),
+				renderer: renderFunc(func(source string, lastNumber int) (string, error) {
+					if source != "body" || lastNumber != 0 {
+						t.Errorf("Render(%q, %d)", source, lastNumber)
+					}
+					return "", errTest
+				}),
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+		{
+			name: "異常ケース(登録失敗)",
+			body: "body",
+			s: &postService{
+				threadRepo: func() *mock_repository.MockThread {
+					mock := mock_repository.NewMockThread(ctrl)
+					mock.EXPECT().AddPost("1", now).Return(1, nil)
+					return mock
+				}(),
+				postRepo: func() *mock_repository.MockPost {
+					mock := mock_repository.NewMockPost(ctrl)
+					mock.EXPECT().Regist(gomock.Any(), now).Return("", errTest)
+					return mock
+				}(),
+				renderer: renderFunc(func(source string, lastNumber int) (string, error) {
+					if source != "body" || lastNumber != 0 {
+						t.Errorf("Render(%q, %d)", source, lastNumber)
+					}
+					return "<p>body</p>\n", nil
+				}),
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+		{
+			name: "異常ケース(版の登録失敗)",
+			body: "body",
+			s: &postService{
+				threadRepo: func() *mock_repository.MockThread {
+					mock := mock_repository.NewMockThread(ctrl)
+					mock.EXPECT().AddPost("1", now).Return(1, nil)
+					return mock
+				}(),
+				postRepo: func() *mock_repository.MockPost {
+					mock := mock_repository.NewMockPost(ctrl)
+					mock.EXPECT().Regist(gomock.Any(), now).Return("10", nil)
+					return mock
+				}(),
+				revisionRepo: func() *mock_repository.MockPostRevision {
+					mock := mock_repository.NewMockPostRevision(ctrl)
+					mock.EXPECT().Regist(gomock.Any(), now).Return("", errTest)
+					return mock
+				}(),
+				renderer: renderFunc(func(source string, lastNumber int) (string, error) {
+					return "<p>body</p>\n", nil
+				}),
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.s.Reply("1", "2", tt.body, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postService_CreateThread(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		title      string
+		body       string
+		s          *postService
+		wantThread model.Thread
+		wantPost   model.Post
+		wantErr    error
+	}{
+		{
+			name:  "正常ケース",
+			title: "title",
+			body:  "body",
+			s: &postService{
+				threadRepo: func() *mock_repository.MockThread {
+					mock := mock_repository.NewMockThread(ctrl)
+					gomock.InOrder(
+						mock.EXPECT().Regist(model.NewThread("", "2", "3", "title", 0, now, now), now).Return("1", nil),
+						mock.EXPECT().AddPost("1", now).Return(1, nil),
+					)
+					return mock
+				}(),
+				postRepo: func() *mock_repository.MockPost {
+					mock := mock_repository.NewMockPost(ctrl)
+					mock.EXPECT().Regist(model.NewPost("", "1", "3", 1, "body", "<p>body</p>\n", now, time.Time{}, time.Time{}, ""), now).Return("10", nil)
+					return mock
+				}(),
+				revisionRepo: func() *mock_repository.MockPostRevision {
+					mock := mock_repository.NewMockPostRevision(ctrl)
+					mock.EXPECT().Regist(model.NewPostRevision("", "10", "3", "body", now), now).Return("20", nil)
+					return mock
+				}(),
+				renderer: renderFunc(func(source string, lastNumber int) (string, error) {
+					if source != "body" || lastNumber != 0 {
+						t.Errorf("Render(%q, %d)", source, lastNumber)
+					}
+					return "<p>body</p>\n", nil
+				}),
+			},
+			wantThread: model.NewThread("1", "2", "3", "title", 1, now, now),
+			wantPost:   model.NewPost("10", "1", "3", 1, "body", "<p>body</p>\n", now, time.Time{}, time.Time{}, ""),
+			wantErr:    nil,
+		},
+		{
+			name:    "異常ケース(タイトルが空白のみ)",
+			title:   " ",
+			body:    "body",
+			s:       &postService{},
+			wantErr: ErrInvalidThreadTitle,
+		},
+		{
+			name:    "異常ケース(タイトルが長すぎる)",
+			title:   strings.Repeat("あ", maxThreadTitleLength+1),
+			body:    "body",
+			s:       &postService{},
+			wantErr: ErrInvalidThreadTitle,
+		},
+		{
+			name:    "異常ケース(本文が空)",
+			title:   "title",
+			body:    "",
+			s:       &postService{},
+			wantErr: ErrInvalidPostBody,
+		},
+		{
+			name:  "異常ケース(登録失敗)",
+			title: "title",
+			body:  "body",
+			s: &postService{
+				threadRepo: func() *mock_repository.MockThread {
+					mock := mock_repository.NewMockThread(ctrl)
+					mock.EXPECT().Regist(gomock.Any(), now).Return("", errTest)
+					return mock
+				}(),
+			},
+			wantErr: errTest,
+		},
+		{
+			name:  "異常ケース(投稿失敗)",
+			title: "title",
+			body:  "body",
+			s: &postService{
+				threadRepo: func() *mock_repository.MockThread {
+					mock := mock_repository.NewMockThread(ctrl)
+					mock.EXPECT().Regist(gomock.Any(), now).Return("1", nil)
+					mock.EXPECT().AddPost("1", now).Return(0, errTest)
+					return mock
+				}(),
+			},
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			thread, post, err := tt.s.CreateThread("2", "3", tt.title, tt.body, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(thread, tt.wantThread) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", thread, tt.wantThread)
+			}
+			if !reflect.DeepEqual(post, tt.wantPost) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", post, tt.wantPost)
+			}
+		})
+	}
+}
+
+func Test_postService_WithInMemoryRepository(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	s := NewPostServiceFactory().NewPostService(
+		inmemory.NewThreadRepository(),
+		inmemory.NewPostRepository(),
+		inmemory.NewPostRevisionRepository(),
+		numberRenderer{},
+	)
+
+	thread, first, err := s.CreateThread("1", "2", "title", "first", now)
+	if err != nil {
+		t.Fatalf("CreateThread() error = %v", err)
+	}
+	if first.Number() != 1 || first.BodyHTML() != "<p>first</p>0" {
+		t.Errorf("CreateThread() = %+v", first)
+	}
+
+	second, err := s.Reply(thread.ID(), "3", "second", now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("Reply() error = %v", err)
+	}
+	if second.Number() != 2 || second.BodyHTML() != "<p>second</p>1" {
+		t.Errorf("Reply() = %+v", second)
+	}
+
+	got, err := s.Thread(thread.ID())
+	if err != nil {
+		t.Fatalf("Thread() error = %v", err)
+	}
+	if got.PostCount() != 2 || !got.LastPostedAt().Equal(now.Add(time.Minute)) {
+		t.Errorf("Thread() = %+v", got)
+	}
+
+	posts, err := s.Posts(thread.ID(), 1, 0)
+	if err != nil {
+		t.Fatalf("Posts() error = %v", err)
+	}
+	if len(posts) != 1 || posts[0].ID() != second.ID() {
+		t.Errorf("Posts() = %+v, want [%s]", posts, second.ID())
+	}
+
+	if _, err := s.Reply("999", "3", "body", now); !errors.Is(err, ErrThreadNotFound) {
+		t.Errorf("Reply() error = %v, want %v", err, ErrThreadNotFound)
+	}
+
+	edited, err := s.Edit(second.ID(), "3", false, "edited", time.Hour, now.Add(2*time.Minute))
+	if err != nil {
+		t.Fatalf("Edit() error = %v", err)
+	}
+	if edited.BodyHTML() != "<p>edited</p>1" || !edited.EditedAt().Equal(now.Add(2*time.Minute)) {
+		t.Errorf("Edit() = %+v", edited)
+	}
+	if _, err := s.Edit(second.ID(), "2", false, "other", time.Hour, now.Add(3*time.Minute)); !errors.Is(err, ErrPostEditForbidden) {
+		t.Errorf("Edit() error = %v, want %v", err, ErrPostEditForbidden)
+	}
+	if _, err := s.Edit(second.ID(), "2", true, "moderated", time.Hour, now.Add(4*time.Minute)); err != nil {
+		t.Fatalf("Edit() error = %v", err)
+	}
+
+	revisions, err := s.Revisions(second.ID())
+	if err != nil {
+		t.Fatalf("Revisions() error = %v", err)
+	}
+	if len(revisions) != 3 ||
+		revisions[0].Body() != "second" || revisions[0].EditorID() != "3" ||
+		revisions[1].Body() != "edited" || revisions[1].EditorID() != "3" ||
+		revisions[2].Body() != "moderated" || revisions[2].EditorID() != "2" {
+		t.Errorf("Revisions() = %+v", revisions)
+	}
+	if _, err := s.Revision(first.ID(), revisions[0].ID()); !errors.Is(err, ErrPostRevisionNotFound) {
+		t.Errorf("Revision() error = %v, want %v", err, ErrPostRevisionNotFound)
+	}
+
+	hidden, err := s.Hide(second.ID(), "spam", now.Add(5*time.Minute))
+	if err != nil {
+		t.Fatalf("Hide() error = %v", err)
+	}
+	if !hidden.IsHidden() || hidden.HiddenReason() != "spam" {
+		t.Errorf("Hide() = %+v", hidden)
+	}
+	if _, err := s.Hide(second.ID(), "spam", now.Add(6*time.Minute)); !errors.Is(err, ErrPostAlreadyHidden) {
+		t.Errorf("Hide() error = %v, want %v", err, ErrPostAlreadyHidden)
+	}
+	if _, err := s.Edit(second.ID(), "3", false, "again", time.Hour, now.Add(6*time.Minute)); !errors.Is(err, ErrPostEditForbidden) {
+		t.Errorf("Edit() error = %v, want %v", err, ErrPostEditForbidden)
+	}
+	if _, err := s.Revisions(second.ID()); !errors.Is(err, ErrPostHidden) {
+		t.Errorf("Revisions() error = %v, want %v", err, ErrPostHidden)
+	}
+}
+
+func Test_postService_Post(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	post := model.NewPost("10", "1", "2", 1, "body", "<p>body</p>\n", time.Time{}, time.Time{}, time.Time{}, "")
+
+	tests := []struct {
+		name    string
+		err     error
+		want    model.Post
+		wantErr error
+	}{
+		{name: "正常ケース", err: nil, want: post, wantErr: nil},
+		{name: "異常ケース(投稿なし)", err: repository.ErrPostNotFound, want: nil, wantErr: ErrPostNotFound},
+		{name: "異常ケース(取得失敗)", err: errTest, want: nil, wantErr: errTest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			postRepo := mock_repository.NewMockPost(ctrl)
+			if tt.err != nil {
+				postRepo.EXPECT().FindByID("10").Return(nil, tt.err)
+			} else {
+				postRepo.EXPECT().FindByID("10").Return(post, nil)
+			}
+
+			got, err := (&postService{postRepo: postRepo}).Post("10")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postService_PostByNumber(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	post := model.NewPost("12", "1", "2", 3, "body", "<p>body</p>\n", time.Time{}, time.Time{}, time.Time{}, "")
+	next := model.NewPost("13", "1", "2", 4, "body", "<p>body</p>\n", time.Time{}, time.Time{}, time.Time{}, "")
+
+	tests := []struct {
+		name    string
+		number  int
+		posts   []model.Post
+		err     error
+		want    model.Post
+		wantErr error
+	}{
+		{name: "正常ケース", number: 3, posts: []model.Post{post}, want: post},
+		{name: "異常ケース(投稿なし)", number: 3, posts: []model.Post{}, wantErr: ErrPostNotFound},
+		{name: "異常ケース(番号が欠けている)", number: 3, posts: []model.Post{next}, wantErr: ErrPostNotFound},
+		{name: "異常ケース(番号が0)", number: 0, wantErr: ErrPostNotFound},
+		{name: "異常ケース(取得失敗)", number: 3, err: errTest, wantErr: errTest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			postRepo := mock_repository.NewMockPost(ctrl)
+			if tt.number > 0 {
+				postRepo.EXPECT().FindByThreadID("1", tt.number-1, 1).Return(tt.posts, tt.err)
+			}
+
+			got, err := (&postService{postRepo: postRepo}).PostByNumber("1", tt.number)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postService_Edit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	created := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	now := created.Add(10 * time.Minute)
+	post := model.NewPost("10", "1", "2", 3, "body", "<p>body</p>\n", created, time.Time{}, time.Time{}, "")
+	edited := model.NewPost("10", "1", "2", 3, "edited", "<p>edited</p>\n", created, now, time.Time{}, "")
+
+	// findPost 投稿を返すリポジトリのモックを生成する
+	findPost := func(post model.Post) *mock_repository.MockPost {
+		mock := mock_repository.NewMockPost(ctrl)
+		mock.EXPECT().FindByID("10").Return(post, nil)
+		return mock
+	}
+	renderer := renderFunc(func(source string, lastNumber int) (string, error) {
+		if source != "edited" || lastNumber != 2 {
+			t.Errorf("Render(%q, %d)", source, lastNumber)
+		}
+		return "<p>edited</p>\n", nil
+	})
+
+	tests := []struct {
+		name      string
+		editorID  string
+		moderator bool
+		window    time.Duration
+		body      string
+		s         *postService
+		want      model.Post
+		wantErr   error
+	}{
+		{
+			name:     "正常ケース(投稿者が期間内に編集)",
+			editorID: "2",
+			window:   15 * time.Minute,
+			body:     "edited",
+			s: &postService{
+				postRepo: func() *mock_repository.MockPost {
+					mock := findPost(post)
+					mock.EXPECT().Update(edited, now).Return(nil)
+					return mock
+				}(),
+				revisionRepo: func() *mock_repository.MockPostRevision {
+					mock := mock_repository.NewMockPostRevision(ctrl)
+					mock.EXPECT().Regist(model.NewPostRevision("", "10", "2", "edited", now), now).Return("20", nil)
+					return mock
+				}(),
+				renderer: renderer,
+			},
+			want:    edited,
+			wantErr: nil,
+		},
+		{
+			name:      "正常ケース(モデレーターは期間外でも他人の投稿を編集できる)",
+			editorID:  "9",
+			moderator: true,
+			window:    0,
+			body:      "edited",
+			s: &postService{
+				postRepo: func() *mock_repository.MockPost {
+					mock := findPost(post)
+					mock.EXPECT().Update(edited, now).Return(nil)
+					return mock
+				}(),
+				revisionRepo: func() *mock_repository.MockPostRevision {
+					mock := mock_repository.NewMockPostRevision(ctrl)
+					mock.EXPECT().Regist(model.NewPostRevision("", "10", "9", "edited", now), now).Return("20", nil)
+					return mock
+				}(),
+				renderer: renderer,
+			},
+			want:    edited,
+			wantErr: nil,
+		},
+		{
+			name:     "異常ケース(本文が空白のみ)",
+			editorID: "2",
+			window:   15 * time.Minute,
+			body:     " ",
+			s:        &postService{},
+			want:     nil,
+			wantErr:  ErrInvalidPostBody,
+		},
+		{
+			name:     "異常ケース(投稿なし)",
+			editorID: "2",
+			window:   15 * time.Minute,
+			body:     "edited",
+			s: &postService{
+				postRepo: func() *mock_repository.MockPost {
+					mock := mock_repository.NewMockPost(ctrl)
+					mock.EXPECT().FindByID("10").Return(nil, repository.ErrPostNotFound)
+					return mock
+				}(),
+			},
+			want:    nil,
+			wantErr: ErrPostNotFound,
+		},
+		{
+			name:     "異常ケース(他人の投稿)",
+			editorID: "3",
+			window:   15 * time.Minute,
+			body:     "edited",
+			s:        &postService{postRepo: findPost(post)},
+			want:     nil,
+			wantErr:  ErrPostEditForbidden,
+		},
+		{
+			name:     "異常ケース(退会済みユーザーの投稿)",
+			editorID: "",
+			window:   15 * time.Minute,
+			body:     "edited",
+			s:        &postService{postRepo: findPost(model.NewPost("10", "1", "", 3, "body", "<p>body</p>\n", created, time.Time{}, time.Time{}, ""))},
+			want:     nil,
+			wantErr:  ErrPostEditForbidden,
+		},
+		{
+			name:     "異常ケース(非表示にされた投稿)",
+			editorID: "2",
+			window:   15 * time.Minute,
+			body:     "edited",
+			s:        &postService{postRepo: findPost(model.NewPost("10", "1", "2", 3, "body", "<p>body</p>\n", created, time.Time{}, created, "spam"))},
+			want:     nil,
+			wantErr:  ErrPostEditForbidden,
+		},
+		{
+			name:     "異常ケース(編集期間外)",
+			editorID: "2",
+			window:   10 * time.Minute,
+			body:     "edited",
+			s:        &postService{postRepo: findPost(post)},
+			want:     nil,
+			wantErr:  ErrPostEditWindowExpired,
+		},
+		{
+			name:     "異常ケース(更新失敗)",
+			editorID: "2",
+			window:   15 * time.Minute,
+			body:     "edited",
+			s: &postService{
+				postRepo: func() *mock_repository.MockPost {
+					mock := findPost(post)
+					mock.EXPECT().Update(edited, now).Return(errTest)
+					return mock
+				}(),
+				renderer: renderer,
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+		{
+			name:     "異常ケース(版の登録失敗)",
+			editorID: "2",
+			window:   15 * time.Minute,
+			body:     "edited",
+			s: &postService{
+				postRepo: func() *mock_repository.MockPost {
+					mock := findPost(post)
+					mock.EXPECT().Update(edited, now).Return(nil)
+					return mock
+				}(),
+				revisionRepo: func() *mock_repository.MockPostRevision {
+					mock := mock_repository.NewMockPostRevision(ctrl)
+					mock.EXPECT().Regist(gomock.Any(), now).Return("", errTest)
+					return mock
+				}(),
+				renderer: renderer,
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.s.Edit("10", tt.editorID, tt.moderator, tt.body, tt.window, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postService_Revisions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	post := model.NewPost("10", "1", "2", 1, "body", "<p>body</p>\n", time.Time{}, time.Time{}, time.Time{}, "")
+	revisions := []model.PostRevision{model.NewPostRevision("20", "10", "2", "body", time.Time{})}
+
+	tests := []struct {
+		name    string
+		s       *postService
+		want    []model.PostRevision
+		wantErr error
+	}{
+		{
+			name: "正常ケース",
+			s: &postService{
+				postRepo: func() *mock_repository.MockPost {
+					mock := mock_repository.NewMockPost(ctrl)
+					mock.EXPECT().FindByID("10").Return(post, nil)
+					return mock
+				}(),
+				revisionRepo: func() *mock_repository.MockPostRevision {
+					mock := mock_repository.NewMockPostRevision(ctrl)
+					mock.EXPECT().FindByPostID("10").Return(revisions, nil)
+					return mock
+				}(),
+			},
+			want:    revisions,
+			wantErr: nil,
+		},
+		{
+			name: "異常ケース(投稿なし)",
+			s: &postService{
+				postRepo: func() *mock_repository.MockPost {
+					mock := mock_repository.NewMockPost(ctrl)
+					mock.EXPECT().FindByID("10").Return(nil, repository.ErrPostNotFound)
+					return mock
+				}(),
+			},
+			want:    nil,
+			wantErr: ErrPostNotFound,
+		},
+		{
+			name: "異常ケース(非表示にされた投稿)",
+			s: &postService{
+				postRepo: func() *mock_repository.MockPost {
+					mock := mock_repository.NewMockPost(ctrl)
+					mock.EXPECT().FindByID("10").Return(model.NewPost("10", "1", "2", 1, "body", "<p>body</p>\n", time.Time{}, time.Time{}, time.Now(), ""), nil)
+					return mock
+				}(),
+			},
+			want:    nil,
+			wantErr: ErrPostHidden,
+		},
+		{
+			name: "異常ケース(取得失敗)",
+			s: &postService{
+				postRepo: func() *mock_repository.MockPost {
+					mock := mock_repository.NewMockPost(ctrl)
+					mock.EXPECT().FindByID("10").Return(post, nil)
+					return mock
+				}(),
+				revisionRepo: func() *mock_repository.MockPostRevision {
+					mock := mock_repository.NewMockPostRevision(ctrl)
+					mock.EXPECT().FindByPostID("10").Return(nil, errTest)
+					return mock
+				}(),
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.s.Revisions("10")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postService_Revision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	post := model.NewPost("10", "1", "2", 1, "body", "<p>body</p>\n", time.Time{}, time.Time{}, time.Time{}, "")
+	hidden := model.NewPost("10", "1", "2", 1, "body", "<p>body</p>\n", time.Time{}, time.Time{}, time.Now(), "")
+	revision := model.NewPostRevision("20", "10", "2", "body", time.Time{})
+
+	tests := []struct {
+		name     string
+		post     model.Post
+		postErr  error
+		revision model.PostRevision
+		err      error
+		want     model.PostRevision
+		wantErr  error
+	}{
+		{name: "正常ケース", post: post, revision: revision, want: revision, wantErr: nil},
+		{name: "異常ケース(他の投稿の版)", post: post, revision: model.NewPostRevision("20", "11", "2", "body", time.Time{}), want: nil, wantErr: ErrPostRevisionNotFound},
+		{name: "異常ケース(版なし)", post: post, err: repository.ErrPostRevisionNotFound, want: nil, wantErr: ErrPostRevisionNotFound},
+		{name: "異常ケース(取得失敗)", post: post, err: errTest, want: nil, wantErr: errTest},
+		{name: "異常ケース(投稿なし)", postErr: repository.ErrPostNotFound, want: nil, wantErr: ErrPostNotFound},
+		{name: "異常ケース(非表示にされた投稿)", post: hidden, want: nil, wantErr: ErrPostHidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			postRepo := mock_repository.NewMockPost(ctrl)
+			postRepo.EXPECT().FindByID("10").Return(tt.post, tt.postErr)
+			revisionRepo := mock_repository.NewMockPostRevision(ctrl)
+			if tt.postErr == nil && !tt.post.IsHidden() {
+				revisionRepo.EXPECT().FindByID("20").Return(tt.revision, tt.err)
+			}
+
+			got, err := (&postService{postRepo: postRepo, revisionRepo: revisionRepo}).Revision("10", "20")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postService_Hide(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	created := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	now := created.Add(time.Hour)
+	post := model.NewPost("10", "1", "2", 3, "body", "<p>body</p>\n", created, time.Time{}, time.Time{}, "")
+
+	tests := []struct {
+		name    string
+		reason  string
+		s       *postService
+		want    model.Post
+		wantErr error
+	}{
+		{
+			name:   "正常ケース",
+			reason: "spam",
+			s: &postService{
+				postRepo: func() *mock_repository.MockPost {
+					mock := mock_repository.NewMockPost(ctrl)
+					mock.EXPECT().FindByID("10").Return(post, nil)
+					mock.EXPECT().Hide("10", "spam", now).Return(nil)
+					return mock
+				}(),
+			},
+			want:    model.NewPost("10", "1", "2", 3, "body", "<p>body</p>\n", created, time.Time{}, now, "spam"),
+			wantErr: nil,
+		},
+		{
+			name:    "異常ケース(理由が長すぎる)",
+			reason:  strings.Repeat("あ", maxHideReasonLength+1),
+			s:       &postService{},
+			want:    nil,
+			wantErr: ErrInvalidHideReason,
+		},
+		{
+			name:   "異常ケース(投稿なし)",
+			reason: "spam",
+			s: &postService{
+				postRepo: func() *mock_repository.MockPost {
+					mock := mock_repository.NewMockPost(ctrl)
+					mock.EXPECT().FindByID("10").Return(nil, repository.ErrPostNotFound)
+					return mock
+				}(),
+			},
+			want:    nil,
+			wantErr: ErrPostNotFound,
+		},
+		{
+			name:   "異常ケース(非表示済み)",
+			reason: "spam",
+			s: &postService{
+				postRepo: func() *mock_repository.MockPost {
+					mock := mock_repository.NewMockPost(ctrl)
+					mock.EXPECT().FindByID("10").Return(model.NewPost("10", "1", "2", 3, "body", "<p>body</p>\n", created, time.Time{}, created, ""), nil)
+					return mock
+				}(),
+			},
+			want:    nil,
+			wantErr: ErrPostAlreadyHidden,
+		},
+		{
+			name:   "異常ケース(更新失敗)",
+			reason: "spam",
+			s: &postService{
+				postRepo: func() *mock_repository.MockPost {
+					mock := mock_repository.NewMockPost(ctrl)
+					mock.EXPECT().FindByID("10").Return(post, nil)
+					mock.EXPECT().Hide("10", "spam", now).Return(errTest)
+					return mock
+				}(),
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.s.Hide("10", tt.reason, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
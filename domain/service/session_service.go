@@ -0,0 +1,187 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+type (
+	// Session ログインセッションサービス
+	// mockgen -source domain/service/session_service.go -destination mock/mock_service/session_service_mock.go
+	Session interface {
+		Start(userID string, ip string, userAgent string, now time.Time, expiresAt time.Time) (model.Session, error)
+		Verify(id string, userID string, now time.Time) error
+		List(userID string, now time.Time) ([]model.Session, error)
+		Revoke(userID string, id string, now time.Time) error
+	}
+
+	// SessionFactory ログインセッションサービスファクトリー
+	SessionFactory interface {
+		NewSessionService(repo repository.Session) Session
+	}
+
+	sessionService struct {
+		repo  repository.Session
+		newID func() (string, error)
+	}
+
+	sessionServiceFactory struct{}
+)
+
+var _ Session = (*sessionService)(nil)
+
+var (
+	ErrSessionNotFound = errors.New("session not found")
+	ErrSessionRevoked  = errors.New("session revoked")
+)
+
+const (
+	// sessionTouchInterval 最終利用日時を更新する間隔(リクエストごとに更新しないようにする)
+	sessionTouchInterval = time.Minute
+	// maxSessionUserAgentLength 記録するUser-Agentの長さの上限
+	maxSessionUserAgentLength = 512
+	// unknownSessionDevice User-Agentから端末を判定できない場合の端末名
+	unknownSessionDevice = "Unknown"
+)
+
+// NewSessionServiceFactory ログインセッションサービスファクトリーを生成する
+func NewSessionServiceFactory() *sessionServiceFactory {
+	return &sessionServiceFactory{}
+}
+
+// NewSessionService ログインセッションサービスを生成する
+func (f *sessionServiceFactory) NewSessionService(repo repository.Session) Session {
+	return &sessionService{repo: repo, newID: newSessionID}
+}
+
+// Start ログインしたユーザーのセッションを登録する
+// 登録したセッションのIDをトークンのjtiにする
+// 有効期限切れのセッションはここで削除する
+func (s *sessionService) Start(userID string, ip string, userAgent string, now time.Time, expiresAt time.Time) (model.Session, error) {
+	id, err := s.newID()
+	if err != nil {
+		return nil, errors.Wrap(err, "Start error")
+	}
+
+	if err := s.repo.DeleteExpired(userID, now); err != nil {
+		return nil, errors.Wrap(err, "Start error")
+	}
+
+	if len(userAgent) > maxSessionUserAgentLength {
+		userAgent = userAgent[:maxSessionUserAgentLength]
+	}
+	session := model.NewSession(id, userID, sessionDevice(userAgent), ip, userAgent, now, now, expiresAt, time.Time{})
+	if err := s.repo.Regist(session); err != nil {
+		return nil, errors.Wrap(err, "Start error")
+	}
+
+	return session, nil
+}
+
+// Verify セッションが失効しておらず、ユーザーのものか検証する
+// 検証に成功した場合、前回から一定時間経っていれば最終利用日時を更新する
+func (s *sessionService) Verify(id string, userID string, now time.Time) error {
+	session, err := s.repo.FindByID(id)
+	if errors.Is(err, repository.ErrSessionNotFound) {
+		return ErrSessionRevoked
+	} else if err != nil {
+		return errors.Wrap(err, "Verify error")
+	}
+
+	if session.UserID() != userID || !session.IsActive(now) {
+		return ErrSessionRevoked
+	}
+
+	if now.Sub(session.LastSeenAt()) >= sessionTouchInterval {
+		if err := s.repo.Touch(id, now); err != nil {
+			return errors.Wrap(err, "Verify error")
+		}
+	}
+
+	return nil
+}
+
+// List ユーザーの有効なセッションをログインの新しい順に取得する
+func (s *sessionService) List(userID string, now time.Time) ([]model.Session, error) {
+	sessions, err := s.repo.FindActiveByUserID(userID, now)
+	if err != nil {
+		return nil, errors.Wrap(err, "List error")
+	}
+
+	return sessions, nil
+}
+
+// Revoke ユーザーのセッションを失効させる(以降そのセッションのトークンは認証に使えない)
+func (s *sessionService) Revoke(userID string, id string, now time.Time) error {
+	err := s.repo.Revoke(userID, id, now)
+	if errors.Is(err, repository.ErrSessionNotFound) {
+		return ErrSessionNotFound
+	} else if err != nil {
+		return errors.Wrap(err, "Revoke error")
+	}
+
+	return nil
+}
+
+// sessionDevice User-AgentからブラウザとOSを判定し、"Firefox on Linux"のような端末名にする
+func sessionDevice(userAgent string) string {
+	var browser string
+	switch {
+	case strings.Contains(userAgent, "Edg/"):
+		browser = "Edge"
+	case strings.Contains(userAgent, "OPR/"):
+		browser = "Opera"
+	case strings.Contains(userAgent, "Firefox/"):
+		browser = "Firefox"
+	case strings.Contains(userAgent, "Chrome/"):
+		browser = "Chrome"
+	case strings.Contains(userAgent, "Safari/"):
+		browser = "Safari"
+	}
+
+	var os string
+	switch {
+	case strings.Contains(userAgent, "iPhone"):
+		os = "iPhone"
+	case strings.Contains(userAgent, "iPad"):
+		os = "iPad"
+	case strings.Contains(userAgent, "Android"):
+		os = "Android"
+	case strings.Contains(userAgent, "Windows"):
+		os = "Windows"
+	case strings.Contains(userAgent, "Mac OS X"):
+		os = "macOS"
+	case strings.Contains(userAgent, "CrOS"):
+		os = "ChromeOS"
+	case strings.Contains(userAgent, "Linux"):
+		os = "Linux"
+	}
+
+	switch {
+	case browser != "" && os != "":
+		return browser + " on " + os
+	case browser != "":
+		return browser
+	case os != "":
+		return os
+	default:
+		return unknownSessionDevice
+	}
+}
+
+// newSessionID 推測できないセッションIDを生成する
+func newSessionID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,338 @@
+package service
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+	"GoBBS/mock/mock_repository"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewSessionService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repository.NewMockSession(ctrl)
+	got, ok := NewSessionServiceFactory().NewSessionService(repo).(*sessionService)
+	if !ok || got.repo != repo {
+		t.Fatalf("NewSessionService() = %v", got)
+	}
+	if reflect.ValueOf(got.newID).Pointer() != reflect.ValueOf(newSessionID).Pointer() {
+		t.Errorf("NewSessionService().newID is not newSessionID")
+	}
+}
+
+func Test_newSessionID(t *testing.T) {
+	a, err := newSessionID()
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	b, _ := newSessionID()
+	if len(a) != 32 || a == b {
+		t.Errorf("戻り値不一致 got: %#v, %#v", a, b)
+	}
+}
+
+func Test_sessionService_Start(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	expires := now.Add(time.Hour)
+	newID := func() (string, error) { return "sid", nil }
+	ua := "Mozilla/5.0 (X11; Linux x86_64; rv:120.0) Gecko/20100101 Firefox/120.0"
+
+	tests := []struct {
+		name      string
+		userAgent string
+		s         *sessionService
+		want      model.Session
+		wantErr   bool
+	}{
+		{
+			name:      "正常ケース",
+			userAgent: ua,
+			s: &sessionService{
+				repo: func() *mock_repository.MockSession {
+					mock := mock_repository.NewMockSession(ctrl)
+					mock.EXPECT().DeleteExpired("1", now).Return(nil)
+					mock.EXPECT().Regist(model.NewSession("sid", "1", "Firefox on Linux", "192.0.2.1", ua, now, now, expires, time.Time{})).Return(nil)
+					return mock
+				}(),
+				newID: newID,
+			},
+			want:    model.NewSession("sid", "1", "Firefox on Linux", "192.0.2.1", ua, now, now, expires, time.Time{}),
+			wantErr: false,
+		},
+		{
+			name:      "正常ケース(User-Agentの切り詰め)",
+			userAgent: strings.Repeat("a", maxSessionUserAgentLength+1),
+			s: &sessionService{
+				repo: func() *mock_repository.MockSession {
+					mock := mock_repository.NewMockSession(ctrl)
+					mock.EXPECT().DeleteExpired("1", now).Return(nil)
+					mock.EXPECT().Regist(gomock.Any()).Return(nil)
+					return mock
+				}(),
+				newID: newID,
+			},
+			want: model.NewSession("sid", "1", unknownSessionDevice, "192.0.2.1", strings.Repeat("a", maxSessionUserAgentLength),
+				now, now, expires, time.Time{}),
+			wantErr: false,
+		},
+		{
+			name:      "異常ケース(ID生成エラー)",
+			userAgent: ua,
+			s:         &sessionService{newID: func() (string, error) { return "", errTest }},
+			want:      nil,
+			wantErr:   true,
+		},
+		{
+			name:      "異常ケース(期限切れセッション削除エラー)",
+			userAgent: ua,
+			s: &sessionService{
+				repo: func() *mock_repository.MockSession {
+					mock := mock_repository.NewMockSession(ctrl)
+					mock.EXPECT().DeleteExpired("1", now).Return(errTest)
+					return mock
+				}(),
+				newID: newID,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:      "異常ケース(登録エラー)",
+			userAgent: ua,
+			s: &sessionService{
+				repo: func() *mock_repository.MockSession {
+					mock := mock_repository.NewMockSession(ctrl)
+					mock.EXPECT().DeleteExpired("1", now).Return(nil)
+					mock.EXPECT().Regist(gomock.Any()).Return(errTest)
+					return mock
+				}(),
+				newID: newID,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.s.Start("1", "192.0.2.1", tt.userAgent, now, expires)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("予期せぬエラー(error: %s)", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_sessionService_Verify(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	expires := now.Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		s       *sessionService
+		wantErr error
+	}{
+		{
+			name: "正常ケース",
+			s: &sessionService{
+				repo: func() *mock_repository.MockSession {
+					mock := mock_repository.NewMockSession(ctrl)
+					mock.EXPECT().FindByID("sid").Return(model.NewSession("sid", "1", "", "", "", now, now.Add(-time.Second), expires, time.Time{}), nil)
+					return mock
+				}(),
+			},
+			wantErr: nil,
+		},
+		{
+			name: "正常ケース(最終利用日時の更新)",
+			s: &sessionService{
+				repo: func() *mock_repository.MockSession {
+					mock := mock_repository.NewMockSession(ctrl)
+					mock.EXPECT().FindByID("sid").Return(model.NewSession("sid", "1", "", "", "", now, now.Add(-sessionTouchInterval), expires, time.Time{}), nil)
+					mock.EXPECT().Touch("sid", now).Return(nil)
+					return mock
+				}(),
+			},
+			wantErr: nil,
+		},
+		{
+			name: "異常ケース(セッションなし)",
+			s: &sessionService{
+				repo: func() *mock_repository.MockSession {
+					mock := mock_repository.NewMockSession(ctrl)
+					mock.EXPECT().FindByID("sid").Return(nil, repository.ErrSessionNotFound)
+					return mock
+				}(),
+			},
+			wantErr: ErrSessionRevoked,
+		},
+		{
+			name: "異常ケース(失効済み)",
+			s: &sessionService{
+				repo: func() *mock_repository.MockSession {
+					mock := mock_repository.NewMockSession(ctrl)
+					mock.EXPECT().FindByID("sid").Return(model.NewSession("sid", "1", "", "", "", now, now, expires, now), nil)
+					return mock
+				}(),
+			},
+			wantErr: ErrSessionRevoked,
+		},
+		{
+			name: "異常ケース(他のユーザーのセッション)",
+			s: &sessionService{
+				repo: func() *mock_repository.MockSession {
+					mock := mock_repository.NewMockSession(ctrl)
+					mock.EXPECT().FindByID("sid").Return(model.NewSession("sid", "2", "", "", "", now, now, expires, time.Time{}), nil)
+					return mock
+				}(),
+			},
+			wantErr: ErrSessionRevoked,
+		},
+		{
+			name: "異常ケース(取得エラー)",
+			s: &sessionService{
+				repo: func() *mock_repository.MockSession {
+					mock := mock_repository.NewMockSession(ctrl)
+					mock.EXPECT().FindByID("sid").Return(nil, errTest)
+					return mock
+				}(),
+			},
+			wantErr: errTest,
+		},
+		{
+			name: "異常ケース(更新エラー)",
+			s: &sessionService{
+				repo: func() *mock_repository.MockSession {
+					mock := mock_repository.NewMockSession(ctrl)
+					mock.EXPECT().FindByID("sid").Return(model.NewSession("sid", "1", "", "", "", now, now.Add(-time.Hour), expires, time.Time{}), nil)
+					mock.EXPECT().Touch("sid", now).Return(errTest)
+					return mock
+				}(),
+			},
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.s.Verify("sid", "1", now); !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_sessionService_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	sessions := []model.Session{
+		model.NewSession("sid", "1", "", "", "", now, now, now.Add(time.Hour), time.Time{}),
+	}
+
+	repo := mock_repository.NewMockSession(ctrl)
+	repo.EXPECT().FindActiveByUserID("1", now).Return(sessions, nil)
+	repo.EXPECT().FindActiveByUserID("2", now).Return(nil, errTest)
+	s := &sessionService{repo: repo}
+
+	got, err := s.List("1", now)
+	if err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	if !reflect.DeepEqual(got, sessions) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, sessions)
+	}
+
+	if _, err := s.List("2", now); !errors.Is(err, errTest) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", err, errTest)
+	}
+}
+
+func Test_sessionService_Revoke(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		repoErr error
+		wantErr error
+	}{
+		{name: "正常ケース", repoErr: nil, wantErr: nil},
+		{name: "異常ケース(セッションなし)", repoErr: repository.ErrSessionNotFound, wantErr: ErrSessionNotFound},
+		{name: "異常ケース(更新エラー)", repoErr: errTest, wantErr: errTest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mock_repository.NewMockSession(ctrl)
+			repo.EXPECT().Revoke("1", "sid", now).Return(tt.repoErr)
+
+			if err := (&sessionService{repo: repo}).Revoke("1", "sid", now); !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_sessionDevice(t *testing.T) {
+	tests := []struct {
+		userAgent string
+		want      string
+	}{
+		{
+			userAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+			want:      "Chrome on Windows",
+		},
+		{
+			userAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36 Edg/120.0.0.0",
+			want:      "Edge on Windows",
+		},
+		{
+			userAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Mobile/15E148 Safari/604.1",
+			want:      "Safari on iPhone",
+		},
+		{
+			userAgent: "Mozilla/5.0 (Linux; Android 14) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Mobile Safari/537.36",
+			want:      "Chrome on Android",
+		},
+		{
+			userAgent: "Mozilla/5.0 (Macintosh; Intel Mac OS X 14_0) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Safari/605.1.15",
+			want:      "Safari on macOS",
+		},
+		{
+			userAgent: "Mozilla/5.0 (X11; Linux x86_64; rv:120.0) Gecko/20100101 Firefox/120.0",
+			want:      "Firefox on Linux",
+		},
+		{
+			userAgent: "curl/8.0.0",
+			want:      unknownSessionDevice,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := sessionDevice(tt.userAgent); got != tt.want {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+type (
+	// UserIdentity 外部IdPのアカウントサービス
+	// mockgen -source domain/service/user_identity_service.go -destination mock/mock_service/user_identity_service_mock.go
+	UserIdentity interface {
+		Resolve(issuer string, subject string, email string, emailVerified bool, name string, now time.Time) (model.User, bool, error)
+	}
+
+	// UserIdentityFactory 外部IdPのアカウントサービスファクトリー
+	UserIdentityFactory interface {
+		NewUserIdentityService(repo repository.UserIdentity, userRepo repository.User) UserIdentity
+	}
+
+	userIdentityService struct {
+		repo        repository.UserIdentity
+		userRepo    repository.User
+		newPassword func() (string, error)
+	}
+
+	userIdentityServiceFactory struct{}
+)
+
+var _ UserIdentity = (*userIdentityService)(nil)
+
+var (
+	ErrIdentityEmailRequired    = errors.New("identity email required")
+	ErrIdentityEmailNotVerified = errors.New("identity email not verified")
+	ErrIdentityEmailInUse       = errors.New("identity email in use")
+)
+
+// NewUserIdentityServiceFactory 外部IdPのアカウントサービスファクトリーを生成する
+func NewUserIdentityServiceFactory() *userIdentityServiceFactory {
+	return &userIdentityServiceFactory{}
+}
+
+// NewUserIdentityService 外部IdPのアカウントサービスを生成する
+func (f *userIdentityServiceFactory) NewUserIdentityService(repo repository.UserIdentity, userRepo repository.User) UserIdentity {
+	return &userIdentityService{repo: repo, userRepo: userRepo, newPassword: newIdentityPassword}
+}
+
+// Resolve 外部IdPのアカウントでログインするユーザーを返す(createdは新しく登録した場合にtrue)
+// 紐づけ済みの場合はそのユーザーを返し、未紐づけの場合はユーザーを登録して紐づける
+// 同じメールアドレスのユーザーがいる場合は、外部IdPのアカウントで登録したユーザーにのみ紐づける
+// パスワードで登録したユーザーには紐づけない(メールアドレスを先に登録した他人にアカウントを乗っ取られないため)
+// 他人のメールアドレスでアカウントを乗っ取られないよう、IdPで確認済みのメールアドレスに限る
+func (s *userIdentityService) Resolve(issuer string, subject string, email string, emailVerified bool, name string, now time.Time) (model.User, bool, error) {
+	identity, err := s.repo.FindByIssuerSubject(issuer, subject)
+	if err == nil {
+		user, err := s.userRepo.FindByID(identity.UserID())
+		if err != nil {
+			return nil, false, errors.Wrap(err, "Resolve error")
+		}
+		return user, false, nil
+	} else if !errors.Is(err, repository.ErrUserIdentityNotFound) {
+		return nil, false, errors.Wrap(err, "Resolve error")
+	}
+
+	if email == "" {
+		return nil, false, ErrIdentityEmailRequired
+	}
+	if !emailVerified {
+		return nil, false, ErrIdentityEmailNotVerified
+	}
+
+	created := false
+	user, err := s.userRepo.FindByEmail(email)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		if user, err = s.regist(email, name, now); err != nil {
+			return nil, false, err
+		}
+		created = true
+	} else if err != nil {
+		return nil, false, errors.Wrap(err, "Resolve error")
+	} else {
+		linked, err := s.repo.ExistsByUserID(user.ID())
+		if err != nil {
+			return nil, false, errors.Wrap(err, "Resolve error")
+		}
+		if !linked {
+			return nil, false, ErrIdentityEmailInUse
+		}
+	}
+
+	if err := s.repo.Regist(model.NewUserIdentity(issuer, subject, user.ID(), email, now)); err != nil {
+		return nil, false, errors.Wrap(err, "Resolve error")
+	}
+
+	return user, created, nil
+}
+
+// regist 外部IdPのアカウントでログインするユーザーを登録する
+// パスワードは推測できない値にし、パスワードではログインできないようにする
+func (s *userIdentityService) regist(email string, name string, now time.Time) (model.User, error) {
+	password, err := s.newPassword()
+	if err != nil {
+		return nil, errors.Wrap(err, "regist error")
+	}
+	if name == "" {
+		name, _, _ = strings.Cut(email, "@")
+	}
+
+	if err := s.userRepo.Regist(model.NewUser("", name, email, password, ""), now); err != nil {
+		return nil, errors.Wrap(err, "regist error")
+	}
+	user, err := s.userRepo.FindByEmail(email)
+	if err != nil {
+		return nil, errors.Wrap(err, "regist error")
+	}
+
+	return user, nil
+}
+
+// newIdentityPassword 外部IdPのアカウントで登録したユーザーのパスワードを生成する
+func newIdentityPassword() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,235 @@
+package service
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+	"GoBBS/mock/mock_repository"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewUserIdentityService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repository.NewMockUserIdentity(ctrl)
+	userRepo := mock_repository.NewMockUser(ctrl)
+	got, ok := NewUserIdentityServiceFactory().NewUserIdentityService(repo, userRepo).(*userIdentityService)
+	if !ok || got.repo != repo || got.userRepo != userRepo {
+		t.Fatalf("NewUserIdentityService() = %v", got)
+	}
+	if reflect.ValueOf(got.newPassword).Pointer() != reflect.ValueOf(newIdentityPassword).Pointer() {
+		t.Errorf("NewUserIdentityService().newPassword is not newIdentityPassword")
+	}
+}
+
+func Test_newIdentityPassword(t *testing.T) {
+	a, err := newIdentityPassword()
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	b, _ := newIdentityPassword()
+	if len(a) != 43 || a == b {
+		t.Errorf("戻り値不一致 got: %#v, %#v", a, b)
+	}
+}
+
+func Test_userIdentityService_Resolve(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	issuer := "https://idp.example.com"
+	user := model.NewUser("1", "taro", "a@example.com", "pw", "salt")
+	identity := model.NewUserIdentity(issuer, "sub-1", "1", "a@example.com", now)
+	newPassword := func() (string, error) { return "random", nil }
+
+	type args struct {
+		email         string
+		emailVerified bool
+		name          string
+	}
+	tests := []struct {
+		name        string
+		repo        func() *mock_repository.MockUserIdentity
+		userRepo    func() *mock_repository.MockUser
+		args        args
+		want        model.User
+		wantCreated bool
+		wantErr     error
+	}{
+		{
+			name: "正常ケース(紐づけ済み)",
+			repo: func() *mock_repository.MockUserIdentity {
+				mock := mock_repository.NewMockUserIdentity(ctrl)
+				mock.EXPECT().FindByIssuerSubject(issuer, "sub-1").Return(identity, nil)
+				return mock
+			},
+			userRepo: func() *mock_repository.MockUser {
+				mock := mock_repository.NewMockUser(ctrl)
+				mock.EXPECT().FindByID("1").Return(user, nil)
+				return mock
+			},
+			args: args{email: "changed@example.com", emailVerified: false},
+			want: user,
+		},
+		{
+			name: "正常ケース(外部IdPのアカウントで登録した同じメールアドレスのユーザーに紐づけ)",
+			repo: func() *mock_repository.MockUserIdentity {
+				mock := mock_repository.NewMockUserIdentity(ctrl)
+				mock.EXPECT().FindByIssuerSubject(issuer, "sub-1").Return(nil, repository.ErrUserIdentityNotFound)
+				mock.EXPECT().ExistsByUserID("1").Return(true, nil)
+				mock.EXPECT().Regist(identity).Return(nil)
+				return mock
+			},
+			userRepo: func() *mock_repository.MockUser {
+				mock := mock_repository.NewMockUser(ctrl)
+				mock.EXPECT().FindByEmail("a@example.com").Return(user, nil)
+				return mock
+			},
+			args: args{email: "a@example.com", emailVerified: true},
+			want: user,
+		},
+		{
+			name: "正常ケース(ユーザーを登録して紐づけ)",
+			repo: func() *mock_repository.MockUserIdentity {
+				mock := mock_repository.NewMockUserIdentity(ctrl)
+				mock.EXPECT().FindByIssuerSubject(issuer, "sub-1").Return(nil, repository.ErrUserIdentityNotFound)
+				mock.EXPECT().Regist(identity).Return(nil)
+				return mock
+			},
+			userRepo: func() *mock_repository.MockUser {
+				mock := mock_repository.NewMockUser(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().FindByEmail("a@example.com").Return(nil, repository.ErrUserNotFound),
+					mock.EXPECT().Regist(model.NewUser("", "a", "a@example.com", "random", ""), now).Return(nil),
+					mock.EXPECT().FindByEmail("a@example.com").Return(user, nil),
+				)
+				return mock
+			},
+			args:        args{email: "a@example.com", emailVerified: true},
+			want:        user,
+			wantCreated: true,
+		},
+		{
+			name: "正常ケース(IdPの名前で登録)",
+			repo: func() *mock_repository.MockUserIdentity {
+				mock := mock_repository.NewMockUserIdentity(ctrl)
+				mock.EXPECT().FindByIssuerSubject(issuer, "sub-1").Return(nil, repository.ErrUserIdentityNotFound)
+				mock.EXPECT().Regist(identity).Return(nil)
+				return mock
+			},
+			userRepo: func() *mock_repository.MockUser {
+				mock := mock_repository.NewMockUser(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().FindByEmail("a@example.com").Return(nil, repository.ErrUserNotFound),
+					mock.EXPECT().Regist(model.NewUser("", "Taro", "a@example.com", "random", ""), now).Return(nil),
+					mock.EXPECT().FindByEmail("a@example.com").Return(user, nil),
+				)
+				return mock
+			},
+			args:        args{email: "a@example.com", emailVerified: true, name: "Taro"},
+			want:        user,
+			wantCreated: true,
+		},
+		{
+			name: "異常ケース(メールアドレスなし)",
+			repo: func() *mock_repository.MockUserIdentity {
+				mock := mock_repository.NewMockUserIdentity(ctrl)
+				mock.EXPECT().FindByIssuerSubject(issuer, "sub-1").Return(nil, repository.ErrUserIdentityNotFound)
+				return mock
+			},
+			userRepo: func() *mock_repository.MockUser { return mock_repository.NewMockUser(ctrl) },
+			args:     args{emailVerified: true},
+			wantErr:  ErrIdentityEmailRequired,
+		},
+		{
+			name: "異常ケース(メールアドレス未確認)",
+			repo: func() *mock_repository.MockUserIdentity {
+				mock := mock_repository.NewMockUserIdentity(ctrl)
+				mock.EXPECT().FindByIssuerSubject(issuer, "sub-1").Return(nil, repository.ErrUserIdentityNotFound)
+				return mock
+			},
+			userRepo: func() *mock_repository.MockUser { return mock_repository.NewMockUser(ctrl) },
+			args:     args{email: "a@example.com"},
+			wantErr:  ErrIdentityEmailNotVerified,
+		},
+		{
+			name: "異常ケース(外部アカウント取得エラー)",
+			repo: func() *mock_repository.MockUserIdentity {
+				mock := mock_repository.NewMockUserIdentity(ctrl)
+				mock.EXPECT().FindByIssuerSubject(issuer, "sub-1").Return(nil, errTest)
+				return mock
+			},
+			userRepo: func() *mock_repository.MockUser { return mock_repository.NewMockUser(ctrl) },
+			args:     args{email: "a@example.com", emailVerified: true},
+			wantErr:  errTest,
+		},
+		{
+			name: "異常ケース(パスワードで登録した同じメールアドレスのユーザーには紐づけない)",
+			repo: func() *mock_repository.MockUserIdentity {
+				mock := mock_repository.NewMockUserIdentity(ctrl)
+				mock.EXPECT().FindByIssuerSubject(issuer, "sub-1").Return(nil, repository.ErrUserIdentityNotFound)
+				mock.EXPECT().ExistsByUserID("1").Return(false, nil)
+				return mock
+			},
+			userRepo: func() *mock_repository.MockUser {
+				mock := mock_repository.NewMockUser(ctrl)
+				mock.EXPECT().FindByEmail("a@example.com").Return(user, nil)
+				return mock
+			},
+			args:    args{email: "a@example.com", emailVerified: true},
+			wantErr: ErrIdentityEmailInUse,
+		},
+		{
+			name: "異常ケース(紐づけ判定エラー)",
+			repo: func() *mock_repository.MockUserIdentity {
+				mock := mock_repository.NewMockUserIdentity(ctrl)
+				mock.EXPECT().FindByIssuerSubject(issuer, "sub-1").Return(nil, repository.ErrUserIdentityNotFound)
+				mock.EXPECT().ExistsByUserID("1").Return(false, errTest)
+				return mock
+			},
+			userRepo: func() *mock_repository.MockUser {
+				mock := mock_repository.NewMockUser(ctrl)
+				mock.EXPECT().FindByEmail("a@example.com").Return(user, nil)
+				return mock
+			},
+			args:    args{email: "a@example.com", emailVerified: true},
+			wantErr: errTest,
+		},
+		{
+			name: "異常ケース(紐づけエラー)",
+			repo: func() *mock_repository.MockUserIdentity {
+				mock := mock_repository.NewMockUserIdentity(ctrl)
+				mock.EXPECT().FindByIssuerSubject(issuer, "sub-1").Return(nil, repository.ErrUserIdentityNotFound)
+				mock.EXPECT().ExistsByUserID("1").Return(true, nil)
+				mock.EXPECT().Regist(identity).Return(errTest)
+				return mock
+			},
+			userRepo: func() *mock_repository.MockUser {
+				mock := mock_repository.NewMockUser(ctrl)
+				mock.EXPECT().FindByEmail("a@example.com").Return(user, nil)
+				return mock
+			},
+			args:    args{email: "a@example.com", emailVerified: true},
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &userIdentityService{repo: tt.repo(), userRepo: tt.userRepo(), newPassword: newPassword}
+			got, created, err := s.Resolve(issuer, "sub-1", tt.args.email, tt.args.emailVerified, tt.args.name, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) || created != tt.wantCreated {
+				t.Errorf("戻り値不一致 got: %#v, %v want: %#v, %v", got, created, tt.want, tt.wantCreated)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"net/url"
 	"time"
 
 	"github.com/pkg/errors"
@@ -13,10 +14,13 @@ type (
 	// User ユーザーサービス
 	// mockgen -source domain/service/user_service.go -destination mock/mock_service/user_service_mock.go
 	User interface {
+		FindByID(id string) (model.User, error)
+		FindByName(name string) (model.User, error)
 		Authorize(email string, password string) (model.User, error)
 		IsDuplicate(email string) (bool, error)
-		Regist(user model.User, now time.Time) error
-		Update(user model.User, now time.Time) error
+		Regist(user model.User, now time.Time) (model.User, error)
+		Update(email string, patch model.UserPatch, now time.Time) error
+		UpdateAvatar(id string, avatarURL string, now time.Time) (model.User, error)
 		Delete(user model.User) error
 	}
 
@@ -38,8 +42,12 @@ var (
 	ErrAuthorizeFail         = errors.New("authorize failed")
 	ErrUserNotFound          = errors.New("user not found")
 	ErrUserAlreadyRegistered = errors.New("user already registered")
+	ErrInvalidAvatarURL      = errors.New("invalid avatar url")
 )
 
+// maxAvatarURLLength アバター画像のURLの最大長(user.avatar_urlの列長)
+const maxAvatarURLLength = 2048
+
 // NewUserServiceFactory ユーザーサービスファクトリーを生成する
 func NewUserServiceFactory() *userServiceFactory {
 	return &userServiceFactory{}
@@ -50,6 +58,32 @@ func (f *userServiceFactory) NewUserService(repo repository.User) User {
 	return &userService{repo: repo}
 }
 
+// FindByID IDを指定してユーザーを取得する
+func (s *userService) FindByID(id string) (model.User, error) {
+	user, err := s.repo.FindByID(id)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return nil, ErrUserNotFound
+	} else if err != nil {
+		return nil, errors.Wrap(err, "FindByID error")
+	}
+
+	return user, nil
+}
+
+// FindByName 名前が一致するユーザーを取得する
+// 名前は一意ではないため、同じ名前のユーザーが複数いる場合は特定できずErrUserNotFoundを返す
+func (s *userService) FindByName(name string) (model.User, error) {
+	users, err := s.repo.FindByName(name, 2)
+	if err != nil {
+		return nil, errors.Wrap(err, "FindByName error")
+	}
+	if len(users) != 1 {
+		return nil, ErrUserNotFound
+	}
+
+	return users[0], nil
+}
+
 // Authorize ユーザーを認証する
 func (s *userService) Authorize(email string, password string) (model.User, error) {
 	user, err := s.repo.FindByEmail(email)
@@ -76,20 +110,29 @@ func (s *userService) IsDuplicate(email string) (bool, error) {
 	return true, nil
 }
 
-// Regist ユーザーを登録する
-func (s *userService) Regist(user model.User, now time.Time) error {
+// Regist ユーザーを登録し、採番したIDを含む登録後のユーザーを返す
+func (s *userService) Regist(user model.User, now time.Time) (model.User, error) {
 	if duplicate, err := s.IsDuplicate(user.Email()); err != nil {
-		return errors.Wrap(err, "Regist error")
+		return nil, errors.Wrap(err, "Regist error")
 	} else if duplicate {
-		return ErrUserAlreadyRegistered
+		return nil, ErrUserAlreadyRegistered
+	}
+
+	if err := s.repo.Regist(user, now); err != nil {
+		return nil, err
+	}
+
+	registered, err := s.repo.FindByEmail(user.Email())
+	if err != nil {
+		return nil, errors.Wrap(err, "Regist error")
 	}
 
-	return s.repo.Regist(user, now)
+	return registered, nil
 }
 
-// Update ユーザーを更新する
-func (s *userService) Update(user model.User, now time.Time) error {
-	findUser, err := s.repo.FindByEmail(user.Email())
+// Update メールアドレスで指定したユーザーのpatchで指定された項目だけを更新する
+func (s *userService) Update(email string, patch model.UserPatch, now time.Time) error {
+	findUser, err := s.repo.FindByEmail(email)
 	if err != nil {
 		return errors.Wrap(err, "Update error")
 	}
@@ -97,17 +140,66 @@ func (s *userService) Update(user model.User, now time.Time) error {
 		return ErrUserNotFound
 	}
 
-	margedUser := model.NewUser(
+	// 登録日は更新対象外
+	name, password, profile := findUser.Name(), findUser.Password(), findUser.Profile()
+	if patch.Name != nil {
+		name = *patch.Name
+	}
+	if patch.Password != nil {
+		password = *patch.Password
+	}
+	if patch.DisplayName != nil {
+		profile.DisplayName = *patch.DisplayName
+	}
+	if patch.Bio != nil {
+		profile.Bio = *patch.Bio
+	}
+	if patch.AvatarURL != nil && *patch.AvatarURL != profile.AvatarURL {
+		if !validAvatarURL(*patch.AvatarURL) {
+			return ErrInvalidAvatarURL
+		}
+		profile.AvatarURL = *patch.AvatarURL
+	}
+	if patch.Signature != nil {
+		profile.Signature = *patch.Signature
+	}
+
+	margedUser := model.NewUserWithProfile(
 		findUser.ID(),
-		user.Name(),
+		name,
 		findUser.Email(),
-		user.Password(),
+		password,
 		findUser.Salt(),
+		profile,
 	)
 
 	return s.repo.Update(margedUser, now)
 }
 
+// UpdateAvatar アバター画像のURLを更新し、更新前のユーザーを返す
+func (s *userService) UpdateAvatar(id string, avatarURL string, now time.Time) (model.User, error) {
+	findUser, err := s.FindByID(id)
+	if err != nil {
+		return nil, errors.Wrap(err, "UpdateAvatar error")
+	}
+
+	profile := findUser.Profile()
+	profile.AvatarURL = avatarURL
+
+	if err := s.repo.Update(model.NewUserWithProfile(
+		findUser.ID(),
+		findUser.Name(),
+		findUser.Email(),
+		findUser.Password(),
+		findUser.Salt(),
+		profile,
+	), now); err != nil {
+		return nil, errors.Wrap(err, "UpdateAvatar error")
+	}
+
+	return findUser, nil
+}
+
 // Delete ユーザーを削除する
 func (s *userService) Delete(user model.User) error {
 	findUser, err := s.repo.FindByEmail(user.Email())
@@ -128,3 +220,20 @@ func (s *userService) Delete(user model.User) error {
 
 	return s.repo.Delete(margedUser)
 }
+
+// validAvatarURL 利用者が指定できるアバター画像のURLか判定する(空は画像なし)
+// javascript:などのスキームを表示側に渡さないよう、http(s)の絶対URLに限る
+// アップロードで生成したURLはUpdateAvatarで設定し、Updateでは現在の値のままの場合だけ受け付ける
+func validAvatarURL(avatarURL string) bool {
+	if avatarURL == "" {
+		return true
+	}
+	if len(avatarURL) > maxAvatarURLLength {
+		return false
+	}
+	u, err := url.Parse(avatarURL)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" && u.User == nil
+}
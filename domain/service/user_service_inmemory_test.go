@@ -0,0 +1,46 @@
+package service
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/interface/inmemory"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func Test_userService_WithInMemoryRepository(t *testing.T) {
+	now := time.Now()
+	s := NewUserServiceFactory().NewUserService(inmemory.NewUserRepository())
+
+	registered, err := s.Regist(model.NewUser("", "name", "a@example.com", "password", ""), now)
+	if err != nil {
+		t.Fatalf("Regist() error = %v", err)
+	}
+	if registered.ID() == "" || registered.Name() != "name" {
+		t.Errorf("Regist() = %+v", registered)
+	}
+
+	if _, err := s.Regist(model.NewUser("", "other", "a@example.com", "password", ""), now); !errors.Is(err, ErrUserAlreadyRegistered) {
+		t.Errorf("Regist() error = %v, want %v", err, ErrUserAlreadyRegistered)
+	}
+
+	if _, err := s.Authorize("a@example.com", "ng"); !errors.Is(err, ErrAuthorizeFail) {
+		t.Errorf("Authorize() error = %v, want %v", err, ErrAuthorizeFail)
+	}
+
+	user, err := s.Authorize("a@example.com", "password")
+	if err != nil {
+		t.Fatalf("Authorize() error = %v", err)
+	}
+	if user.Name() != "name" {
+		t.Errorf("Authorize().Name() = %s, want name", user.Name())
+	}
+
+	if err := s.Delete(user); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if duplicate, err := s.IsDuplicate("a@example.com"); err != nil || duplicate {
+		t.Errorf("IsDuplicate() = %v, %v, want false, nil", duplicate, err)
+	}
+}
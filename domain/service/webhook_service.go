@@ -0,0 +1,340 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+type (
+	// Webhook Webhookサービス
+	// mockgen -source domain/service/webhook_service.go -destination mock/mock_service/webhook_service_mock.go
+	Webhook interface {
+		Regist(boardID string, rawURL string, events []model.WebhookEvent, now time.Time) (model.Webhook, error)
+		List(boardID string) ([]model.Webhook, error)
+		Find(id string) (model.Webhook, error)
+		Delete(id string) error
+		Deliveries(webhookID string, beforeID string, limit int) ([]model.WebhookDelivery, error)
+		Enqueue(eventID string, boardID string, event model.WebhookEvent, data any, now time.Time) (int, error)
+		ClaimDue(now time.Time, limit int) ([]model.WebhookDelivery, error)
+		RecordAttempt(delivery model.WebhookDelivery, statusCode int, sendErr error, now time.Time) (model.WebhookDelivery, error)
+	}
+
+	// WebhookFactory Webhookサービスファクトリー
+	WebhookFactory interface {
+		NewWebhookService(repo repository.Webhook) Webhook
+	}
+
+	webhookService struct {
+		repo      repository.Webhook
+		newSecret func() (string, error)
+	}
+
+	webhookServiceFactory struct{}
+
+	// webhookPayload 送信するJSONの共通部分
+	webhookPayload struct {
+		Event     model.WebhookEvent `json:"event"`
+		BoardID   string             `json:"board_id"`
+		CreatedAt time.Time          `json:"created_at"`
+		Data      any                `json:"data"`
+	}
+)
+
+var _ Webhook = (*webhookService)(nil)
+
+var (
+	ErrWebhookNotFound     = errors.New("webhook not found")
+	ErrInvalidWebhookURL   = errors.New("invalid webhook url")
+	ErrInvalidWebhookEvent = errors.New("invalid webhook event")
+)
+
+const (
+	// DefaultWebhookDeliveryLimit 配信ログの件数の既定値
+	DefaultWebhookDeliveryLimit = 50
+	// MaxWebhookDeliveryLimit 配信ログの件数の上限
+	MaxWebhookDeliveryLimit = 100
+	// MaxWebhookAttempts 送信を試行する回数の上限
+	MaxWebhookAttempts = 10
+	// webhookLeasePerDelivery 取得した配信を他のディスパッチャーが取得しないようにする期間の、配信1件あたりの長さ
+	// 1件の送信のタイムアウト(10秒)と結果の記録にかかる時間より長くする
+	webhookLeasePerDelivery = 20 * time.Second
+	// webhookBaseBackoff 1回目の再送までの間隔(以降は倍々にする)
+	webhookBaseBackoff = 30 * time.Second
+	// webhookMaxBackoff 再送間隔の上限
+	webhookMaxBackoff = time.Hour
+	// maxWebhookURLLength 送信先URLの長さの上限
+	maxWebhookURLLength = 2048
+	// maxWebhookErrorLength 記録するエラーメッセージの長さの上限
+	maxWebhookErrorLength = 1024
+)
+
+// NewWebhookServiceFactory Webhookサービスファクトリーを生成する
+func NewWebhookServiceFactory() *webhookServiceFactory {
+	return &webhookServiceFactory{}
+}
+
+// NewWebhookService Webhookサービスを生成する
+func (f *webhookServiceFactory) NewWebhookService(repo repository.Webhook) Webhook {
+	return &webhookService{repo: repo, newSecret: newWebhookSecret}
+}
+
+// Regist 板にWebhookを登録する(署名用の秘密鍵はここで発行する)
+func (s *webhookService) Regist(boardID string, rawURL string, events []model.WebhookEvent, now time.Time) (model.Webhook, error) {
+	if !isValidWebhookURL(rawURL) {
+		return nil, ErrInvalidWebhookURL
+	}
+	if len(events) == 0 {
+		return nil, ErrInvalidWebhookEvent
+	}
+	unique := make([]model.WebhookEvent, 0, len(events))
+	for _, e := range events {
+		if !e.IsValid() {
+			return nil, ErrInvalidWebhookEvent
+		}
+		if !containsWebhookEvent(unique, e) {
+			unique = append(unique, e)
+		}
+	}
+
+	secret, err := s.newSecret()
+	if err != nil {
+		return nil, errors.Wrap(err, "Regist error")
+	}
+
+	webhook := model.NewWebhook("", boardID, rawURL, secret, unique, now)
+	id, err := s.repo.Regist(webhook)
+	if err != nil {
+		return nil, errors.Wrap(err, "Regist error")
+	}
+
+	return model.NewWebhook(id, boardID, rawURL, secret, unique, now), nil
+}
+
+// List 板のWebhookを返す
+func (s *webhookService) List(boardID string) ([]model.Webhook, error) {
+	webhooks, err := s.repo.FindByBoardID(boardID)
+	if err != nil {
+		return nil, errors.Wrap(err, "List error")
+	}
+
+	return webhooks, nil
+}
+
+// Find Webhookを返す
+func (s *webhookService) Find(id string) (model.Webhook, error) {
+	webhook, err := s.repo.FindByID(id)
+	if errors.Is(err, repository.ErrWebhookNotFound) {
+		return nil, ErrWebhookNotFound
+	} else if err != nil {
+		return nil, errors.Wrap(err, "Find error")
+	}
+
+	return webhook, nil
+}
+
+// Delete Webhookを削除する(未送信の配信も破棄される)
+func (s *webhookService) Delete(id string) error {
+	err := s.repo.Delete(id)
+	if errors.Is(err, repository.ErrWebhookNotFound) {
+		return ErrWebhookNotFound
+	} else if err != nil {
+		return errors.Wrap(err, "Delete error")
+	}
+
+	return nil
+}
+
+// Deliveries Webhookの配信ログを新しい順に返す
+func (s *webhookService) Deliveries(webhookID string, beforeID string, limit int) ([]model.WebhookDelivery, error) {
+	if limit <= 0 {
+		limit = DefaultWebhookDeliveryLimit
+	} else if limit > MaxWebhookDeliveryLimit {
+		limit = MaxWebhookDeliveryLimit
+	}
+
+	if _, err := s.Find(webhookID); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.repo.FindDeliveriesByWebhookID(webhookID, beforeID, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "Deliveries error")
+	}
+
+	return deliveries, nil
+}
+
+// Enqueue ドメインイベントを通知する設定の板のWebhookごとに配信をアウトボックスに登録し、登録した件数を返す
+// ドメインイベントは少なくとも1回配信されるため、同じイベントが再度転送されてもWebhookごとの配信は1件のみ登録する
+func (s *webhookService) Enqueue(eventID string, boardID string, event model.WebhookEvent, data any, now time.Time) (int, error) {
+	if !event.IsValid() {
+		return 0, ErrInvalidWebhookEvent
+	}
+
+	webhooks, err := s.repo.FindByBoardID(boardID)
+	if err != nil {
+		return 0, errors.Wrap(err, "Enqueue error")
+	}
+
+	var payload []byte
+	count := 0
+	for _, w := range webhooks {
+		if !w.Subscribes(event) {
+			continue
+		}
+		if payload == nil {
+			if payload, err = json.Marshal(webhookPayload{Event: event, BoardID: boardID, CreatedAt: now, Data: data}); err != nil {
+				return 0, errors.Wrap(err, "Enqueue error")
+			}
+		}
+
+		delivery := model.NewWebhookDelivery("", w.ID(), event, payload, model.WebhookDeliveryPending, 0, now, 0, "", now, time.Time{})
+		_, registered, err := s.repo.RegistDelivery(eventID, delivery)
+		if err != nil {
+			return 0, errors.Wrap(err, "Enqueue error")
+		}
+		if registered {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// ClaimDue 送信時刻に達した配信を取得し、送信中に他のディスパッチャーが取得しないよう次の送信時刻を先送りする
+// 取得した配信は順に送信されるため、最後の配信を送信し終えるまで先送りする(取得した件数分の期間)
+// 送信結果を記録する前にプロセスが停止した場合は、先送りした時刻に再送される
+func (s *webhookService) ClaimDue(now time.Time, limit int) ([]model.WebhookDelivery, error) {
+	deliveries, err := s.repo.FindDueDeliveries(now, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "ClaimDue error")
+	}
+
+	leasedUntil := now.Add(time.Duration(len(deliveries)) * webhookLeasePerDelivery)
+	for _, d := range deliveries {
+		leased := model.NewWebhookDelivery(
+			d.ID(),
+			d.WebhookID(),
+			d.Event(),
+			d.Payload(),
+			d.Status(),
+			d.Attempts(),
+			leasedUntil,
+			d.LastStatusCode(),
+			d.LastError(),
+			d.CreatedAt(),
+			d.DeliveredAt(),
+		)
+		if err := s.repo.UpdateDelivery(leased); err != nil {
+			return nil, errors.Wrap(err, "ClaimDue error")
+		}
+	}
+
+	return deliveries, nil
+}
+
+// RecordAttempt 送信結果を記録し、更新後の配信を返す
+// 2xxの応答で成功とし、それ以外は上限まで間隔を倍々にして再送する
+func (s *webhookService) RecordAttempt(delivery model.WebhookDelivery, statusCode int, sendErr error, now time.Time) (model.WebhookDelivery, error) {
+	attempts := delivery.Attempts() + 1
+	status := model.WebhookDeliveryPending
+	nextAttemptAt := now.Add(webhookBackoff(attempts))
+	var deliveredAt time.Time
+	lastError := ""
+	if sendErr != nil {
+		lastError = truncateWebhookError(sendErr.Error())
+	}
+
+	switch {
+	case sendErr == nil && statusCode >= 200 && statusCode < 300:
+		status = model.WebhookDeliverySucceeded
+		nextAttemptAt = now
+		deliveredAt = now
+	case attempts >= MaxWebhookAttempts:
+		status = model.WebhookDeliveryFailed
+		nextAttemptAt = now
+	}
+
+	updated := model.NewWebhookDelivery(
+		delivery.ID(),
+		delivery.WebhookID(),
+		delivery.Event(),
+		delivery.Payload(),
+		status,
+		attempts,
+		nextAttemptAt,
+		statusCode,
+		lastError,
+		delivery.CreatedAt(),
+		deliveredAt,
+	)
+	if err := s.repo.UpdateDelivery(updated); err != nil {
+		return nil, errors.Wrap(err, "RecordAttempt error")
+	}
+
+	return updated, nil
+}
+
+// webhookBackoff attempts回目の失敗後、次の再送までの間隔を返す
+func webhookBackoff(attempts int) time.Duration {
+	backoff := webhookBaseBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= webhookMaxBackoff {
+			return webhookMaxBackoff
+		}
+	}
+
+	return backoff
+}
+
+// isValidWebhookURL 送信先URLがhttp(s)の絶対URLか判定する
+func isValidWebhookURL(rawURL string) bool {
+	if rawURL == "" || len(rawURL) > maxWebhookURLLength {
+		return false
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// containsWebhookEvent イベントが含まれるか判定する
+func containsWebhookEvent(events []model.WebhookEvent, event model.WebhookEvent) bool {
+	for _, e := range events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// truncateWebhookError エラーメッセージを記録できる長さに切り詰める
+func truncateWebhookError(s string) string {
+	if len(s) <= maxWebhookErrorLength {
+		return s
+	}
+	// 途中で切れたマルチバイト文字は除く
+	return strings.ToValidUTF8(s[:maxWebhookErrorLength], "")
+}
+
+// newWebhookSecret 推測できない署名用の秘密鍵を生成する
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
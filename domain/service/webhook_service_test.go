@@ -0,0 +1,603 @@
+package service
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+	"GoBBS/interface/inmemory"
+	"GoBBS/mock/mock_repository"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewWebhookService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repository.NewMockWebhook(ctrl)
+	got, ok := NewWebhookServiceFactory().NewWebhookService(repo).(*webhookService)
+	if !ok || got.repo != repo {
+		t.Fatalf("NewWebhookService() = %v", got)
+	}
+	if reflect.ValueOf(got.newSecret).Pointer() != reflect.ValueOf(newWebhookSecret).Pointer() {
+		t.Errorf("NewWebhookService().newSecret is not newWebhookSecret")
+	}
+}
+
+func Test_newWebhookSecret(t *testing.T) {
+	a, err := newWebhookSecret()
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	b, _ := newWebhookSecret()
+	if len(a) != 64 || a == b {
+		t.Errorf("戻り値不一致 got: %#v, %#v", a, b)
+	}
+}
+
+func Test_webhookService_Regist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	newSecret := func() (string, error) { return "secret", nil }
+
+	tests := []struct {
+		name    string
+		url     string
+		events  []model.WebhookEvent
+		s       *webhookService
+		want    model.Webhook
+		wantErr error
+	}{
+		{
+			name:   "正常ケース(重複したイベントは除く)",
+			url:    "https://example.com/hook",
+			events: []model.WebhookEvent{model.WebhookPostCreated, model.WebhookPostHidden, model.WebhookPostCreated},
+			s: &webhookService{
+				repo: func() *mock_repository.MockWebhook {
+					mock := mock_repository.NewMockWebhook(ctrl)
+					mock.EXPECT().Regist(model.NewWebhook("", "2", "https://example.com/hook", "secret",
+						[]model.WebhookEvent{model.WebhookPostCreated, model.WebhookPostHidden}, now)).Return("1", nil)
+					return mock
+				}(),
+				newSecret: newSecret,
+			},
+			want: model.NewWebhook("1", "2", "https://example.com/hook", "secret",
+				[]model.WebhookEvent{model.WebhookPostCreated, model.WebhookPostHidden}, now),
+			wantErr: nil,
+		},
+		{
+			name:    "異常ケース(URLが相対パス)",
+			url:     "/hook",
+			events:  []model.WebhookEvent{model.WebhookPostCreated},
+			s:       &webhookService{},
+			wantErr: ErrInvalidWebhookURL,
+		},
+		{
+			name:    "異常ケース(URLのスキーム不正)",
+			url:     "ftp://example.com/hook",
+			events:  []model.WebhookEvent{model.WebhookPostCreated},
+			s:       &webhookService{},
+			wantErr: ErrInvalidWebhookURL,
+		},
+		{
+			name:    "異常ケース(URLが長すぎる)",
+			url:     "https://example.com/" + strings.Repeat("a", maxWebhookURLLength),
+			events:  []model.WebhookEvent{model.WebhookPostCreated},
+			s:       &webhookService{},
+			wantErr: ErrInvalidWebhookURL,
+		},
+		{
+			name:    "異常ケース(イベント未指定)",
+			url:     "https://example.com/hook",
+			events:  nil,
+			s:       &webhookService{},
+			wantErr: ErrInvalidWebhookEvent,
+		},
+		{
+			name:    "異常ケース(イベント不正)",
+			url:     "https://example.com/hook",
+			events:  []model.WebhookEvent{model.WebhookPostCreated, "user.created"},
+			s:       &webhookService{},
+			wantErr: ErrInvalidWebhookEvent,
+		},
+		{
+			name:    "異常ケース(秘密鍵の生成失敗)",
+			url:     "https://example.com/hook",
+			events:  []model.WebhookEvent{model.WebhookPostCreated},
+			s:       &webhookService{newSecret: func() (string, error) { return "", errTest }},
+			wantErr: errTest,
+		},
+		{
+			name:   "異常ケース(登録失敗)",
+			url:    "http://example.com/hook",
+			events: []model.WebhookEvent{model.WebhookThreadCreated},
+			s: &webhookService{
+				repo: func() *mock_repository.MockWebhook {
+					mock := mock_repository.NewMockWebhook(ctrl)
+					mock.EXPECT().Regist(gomock.Any()).Return("", errTest)
+					return mock
+				}(),
+				newSecret: newSecret,
+			},
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.s.Regist("2", tt.url, tt.events, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_webhookService_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	webhooks := []model.Webhook{model.NewWebhook("1", "2", "https://example.com/hook", "secret", nil, time.Time{})}
+
+	repo := mock_repository.NewMockWebhook(ctrl)
+	repo.EXPECT().FindByBoardID("2").Return(webhooks, nil)
+	repo.EXPECT().FindByBoardID("3").Return(nil, errTest)
+	s := &webhookService{repo: repo}
+
+	got, err := s.List("2")
+	if err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	if !reflect.DeepEqual(got, webhooks) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, webhooks)
+	}
+	if _, err := s.List("3"); !errors.Is(err, errTest) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", err, errTest)
+	}
+}
+
+func Test_webhookService_Find(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	webhook := model.NewWebhook("1", "2", "https://example.com/hook", "secret", nil, time.Time{})
+
+	tests := []struct {
+		name    string
+		webhook model.Webhook
+		err     error
+		wantErr error
+	}{
+		{name: "正常ケース", webhook: webhook, err: nil, wantErr: nil},
+		{name: "異常ケース(Webhookなし)", webhook: nil, err: repository.ErrWebhookNotFound, wantErr: ErrWebhookNotFound},
+		{name: "異常ケース(取得失敗)", webhook: nil, err: errTest, wantErr: errTest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mock_repository.NewMockWebhook(ctrl)
+			repo.EXPECT().FindByID("1").Return(tt.webhook, tt.err)
+
+			got, err := (&webhookService{repo: repo}).Find("1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.webhook) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.webhook)
+			}
+		})
+	}
+}
+
+func Test_webhookService_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+
+	tests := []struct {
+		name    string
+		err     error
+		wantErr error
+	}{
+		{name: "正常ケース", err: nil, wantErr: nil},
+		{name: "異常ケース(Webhookなし)", err: repository.ErrWebhookNotFound, wantErr: ErrWebhookNotFound},
+		{name: "異常ケース(削除失敗)", err: errTest, wantErr: errTest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mock_repository.NewMockWebhook(ctrl)
+			repo.EXPECT().Delete("1").Return(tt.err)
+
+			if err := (&webhookService{repo: repo}).Delete("1"); !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_webhookService_Deliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	webhook := model.NewWebhook("1", "2", "https://example.com/hook", "secret", nil, time.Time{})
+	deliveries := []model.WebhookDelivery{
+		model.NewWebhookDelivery("3", "1", model.WebhookPostCreated, nil, model.WebhookDeliveryPending, 0, time.Time{}, 0, "", time.Time{}, time.Time{}),
+	}
+
+	tests := []struct {
+		name    string
+		limit   int
+		repo    func() *mock_repository.MockWebhook
+		want    []model.WebhookDelivery
+		wantErr error
+	}{
+		{
+			name:  "正常ケース(件数の既定値)",
+			limit: 0,
+			repo: func() *mock_repository.MockWebhook {
+				mock := mock_repository.NewMockWebhook(ctrl)
+				mock.EXPECT().FindByID("1").Return(webhook, nil)
+				mock.EXPECT().FindDeliveriesByWebhookID("1", "5", DefaultWebhookDeliveryLimit).Return(deliveries, nil)
+				return mock
+			},
+			want:    deliveries,
+			wantErr: nil,
+		},
+		{
+			name:  "正常ケース(件数の上限)",
+			limit: MaxWebhookDeliveryLimit + 1,
+			repo: func() *mock_repository.MockWebhook {
+				mock := mock_repository.NewMockWebhook(ctrl)
+				mock.EXPECT().FindByID("1").Return(webhook, nil)
+				mock.EXPECT().FindDeliveriesByWebhookID("1", "5", MaxWebhookDeliveryLimit).Return(deliveries, nil)
+				return mock
+			},
+			want:    deliveries,
+			wantErr: nil,
+		},
+		{
+			name:  "異常ケース(Webhookなし)",
+			limit: 10,
+			repo: func() *mock_repository.MockWebhook {
+				mock := mock_repository.NewMockWebhook(ctrl)
+				mock.EXPECT().FindByID("1").Return(nil, repository.ErrWebhookNotFound)
+				return mock
+			},
+			want:    nil,
+			wantErr: ErrWebhookNotFound,
+		},
+		{
+			name:  "異常ケース(取得失敗)",
+			limit: 10,
+			repo: func() *mock_repository.MockWebhook {
+				mock := mock_repository.NewMockWebhook(ctrl)
+				mock.EXPECT().FindByID("1").Return(webhook, nil)
+				mock.EXPECT().FindDeliveriesByWebhookID("1", "5", 10).Return(nil, errTest)
+				return mock
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := (&webhookService{repo: tt.repo()}).Deliveries("1", "5", tt.limit)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_webhookService_Enqueue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	webhooks := []model.Webhook{
+		model.NewWebhook("1", "2", "https://example.com/a", "s1", []model.WebhookEvent{model.WebhookPostCreated}, now),
+		model.NewWebhook("3", "2", "https://example.com/b", "s2", []model.WebhookEvent{model.WebhookPostHidden}, now),
+		model.NewWebhook("4", "2", "https://example.com/c", "s3", []model.WebhookEvent{model.WebhookThreadCreated, model.WebhookPostCreated}, now),
+	}
+	payload := []byte(`{"event":"post.created","board_id":"2","created_at":"2023-01-02T03:04:05Z","data":{"id":"10"}}`)
+	data := map[string]string{"id": "10"}
+
+	tests := []struct {
+		name    string
+		event   model.WebhookEvent
+		repo    func() *mock_repository.MockWebhook
+		want    int
+		wantErr error
+	}{
+		{
+			name:  "正常ケース(イベントを通知する設定のWebhookにだけ登録する)",
+			event: model.WebhookPostCreated,
+			repo: func() *mock_repository.MockWebhook {
+				mock := mock_repository.NewMockWebhook(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().FindByBoardID("2").Return(webhooks, nil),
+					mock.EXPECT().RegistDelivery("7", model.NewWebhookDelivery("", "1", model.WebhookPostCreated, payload,
+						model.WebhookDeliveryPending, 0, now, 0, "", now, time.Time{})).Return("10", true, nil),
+					mock.EXPECT().RegistDelivery("7", model.NewWebhookDelivery("", "4", model.WebhookPostCreated, payload,
+						model.WebhookDeliveryPending, 0, now, 0, "", now, time.Time{})).Return("11", true, nil),
+				)
+				return mock
+			},
+			want:    2,
+			wantErr: nil,
+		},
+		{
+			name:  "正常ケース(登録済みの配信は数えない)",
+			event: model.WebhookPostCreated,
+			repo: func() *mock_repository.MockWebhook {
+				mock := mock_repository.NewMockWebhook(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().FindByBoardID("2").Return(webhooks, nil),
+					mock.EXPECT().RegistDelivery("7", gomock.Any()).Return("", false, nil),
+					mock.EXPECT().RegistDelivery("7", gomock.Any()).Return("11", true, nil),
+				)
+				return mock
+			},
+			want:    1,
+			wantErr: nil,
+		},
+		{
+			name:    "異常ケース(イベント不正)",
+			event:   "user.created",
+			repo:    func() *mock_repository.MockWebhook { return mock_repository.NewMockWebhook(ctrl) },
+			want:    0,
+			wantErr: ErrInvalidWebhookEvent,
+		},
+		{
+			name:  "異常ケース(Webhookの取得失敗)",
+			event: model.WebhookPostCreated,
+			repo: func() *mock_repository.MockWebhook {
+				mock := mock_repository.NewMockWebhook(ctrl)
+				mock.EXPECT().FindByBoardID("2").Return(nil, errTest)
+				return mock
+			},
+			want:    0,
+			wantErr: errTest,
+		},
+		{
+			name:  "異常ケース(登録失敗)",
+			event: model.WebhookPostHidden,
+			repo: func() *mock_repository.MockWebhook {
+				mock := mock_repository.NewMockWebhook(ctrl)
+				mock.EXPECT().FindByBoardID("2").Return(webhooks, nil)
+				mock.EXPECT().RegistDelivery("7", gomock.Any()).Return("", false, errTest)
+				return mock
+			},
+			want:    0,
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := (&webhookService{repo: tt.repo()}).Enqueue("7", "2", tt.event, data, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_webhookService_EnqueueMarshalFail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repository.NewMockWebhook(ctrl)
+	repo.EXPECT().FindByBoardID("2").Return([]model.Webhook{
+		model.NewWebhook("1", "2", "https://example.com/a", "s1", []model.WebhookEvent{model.WebhookPostCreated}, time.Time{}),
+	}, nil)
+
+	var jsonErr *json.UnsupportedTypeError
+	if _, err := (&webhookService{repo: repo}).Enqueue("7", "2", model.WebhookPostCreated, make(chan int), time.Time{}); !errors.As(err, &jsonErr) {
+		t.Errorf("予期せぬエラー(error: %v)", err)
+	}
+}
+
+func Test_webhookService_ClaimDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	deliveries := []model.WebhookDelivery{
+		model.NewWebhookDelivery("1", "2", model.WebhookPostCreated, []byte("{}"), model.WebhookDeliveryPending, 1, now, 500, "", now, time.Time{}),
+		model.NewWebhookDelivery("3", "2", model.WebhookPostCreated, []byte("{}"), model.WebhookDeliveryPending, 0, now, 0, "", now, time.Time{}),
+	}
+	// 2件を順に送信し終えるまで先送りする
+	leasedUntil := now.Add(2 * webhookLeasePerDelivery)
+
+	tests := []struct {
+		name    string
+		repo    func() *mock_repository.MockWebhook
+		want    []model.WebhookDelivery
+		wantErr error
+	}{
+		{
+			name: "正常ケース(取得した全ての配信を送信し終えるまで次の送信時刻を先送りする)",
+			repo: func() *mock_repository.MockWebhook {
+				mock := mock_repository.NewMockWebhook(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().FindDueDeliveries(now, 10).Return(deliveries, nil),
+					mock.EXPECT().UpdateDelivery(model.NewWebhookDelivery("1", "2", model.WebhookPostCreated, []byte("{}"),
+						model.WebhookDeliveryPending, 1, leasedUntil, 500, "", now, time.Time{})).Return(nil),
+					mock.EXPECT().UpdateDelivery(model.NewWebhookDelivery("3", "2", model.WebhookPostCreated, []byte("{}"),
+						model.WebhookDeliveryPending, 0, leasedUntil, 0, "", now, time.Time{})).Return(nil),
+				)
+				return mock
+			},
+			want:    deliveries,
+			wantErr: nil,
+		},
+		{
+			name: "異常ケース(取得失敗)",
+			repo: func() *mock_repository.MockWebhook {
+				mock := mock_repository.NewMockWebhook(ctrl)
+				mock.EXPECT().FindDueDeliveries(now, 10).Return(nil, errTest)
+				return mock
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+		{
+			name: "異常ケース(更新失敗)",
+			repo: func() *mock_repository.MockWebhook {
+				mock := mock_repository.NewMockWebhook(ctrl)
+				mock.EXPECT().FindDueDeliveries(now, 10).Return(deliveries, nil)
+				mock.EXPECT().UpdateDelivery(gomock.Any()).Return(errTest)
+				return mock
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := (&webhookService{repo: tt.repo()}).ClaimDue(now, 10)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_webhookService_RecordAttempt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errTest := errors.New("test")
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	created := now.Add(-time.Hour)
+	delivery := func(attempts int) model.WebhookDelivery {
+		return model.NewWebhookDelivery("1", "2", model.WebhookPostCreated, []byte("{}"), model.WebhookDeliveryPending, attempts, now, 0, "", created, time.Time{})
+	}
+
+	tests := []struct {
+		name       string
+		delivery   model.WebhookDelivery
+		statusCode int
+		sendErr    error
+		want       model.WebhookDelivery
+	}{
+		{
+			name:       "正常ケース(送信成功)",
+			delivery:   delivery(0),
+			statusCode: 204,
+			want:       model.NewWebhookDelivery("1", "2", model.WebhookPostCreated, []byte("{}"), model.WebhookDeliverySucceeded, 1, now, 204, "", created, now),
+		},
+		{
+			name:       "正常ケース(2xx以外の応答は再送する)",
+			delivery:   delivery(0),
+			statusCode: 500,
+			want:       model.NewWebhookDelivery("1", "2", model.WebhookPostCreated, []byte("{}"), model.WebhookDeliveryPending, 1, now.Add(30*time.Second), 500, "", created, time.Time{}),
+		},
+		{
+			name:     "正常ケース(送信エラーは間隔を倍々にして再送する)",
+			delivery: delivery(2),
+			sendErr:  errors.New("timeout"),
+			want:     model.NewWebhookDelivery("1", "2", model.WebhookPostCreated, []byte("{}"), model.WebhookDeliveryPending, 3, now.Add(2*time.Minute), 0, "timeout", created, time.Time{}),
+		},
+		{
+			name:       "正常ケース(再送間隔の上限)",
+			delivery:   delivery(MaxWebhookAttempts - 2),
+			statusCode: 503,
+			want:       model.NewWebhookDelivery("1", "2", model.WebhookPostCreated, []byte("{}"), model.WebhookDeliveryPending, MaxWebhookAttempts-1, now.Add(webhookMaxBackoff), 503, "", created, time.Time{}),
+		},
+		{
+			name:       "正常ケース(再送の上限で失敗にする)",
+			delivery:   delivery(MaxWebhookAttempts - 1),
+			statusCode: 503,
+			want:       model.NewWebhookDelivery("1", "2", model.WebhookPostCreated, []byte("{}"), model.WebhookDeliveryFailed, MaxWebhookAttempts, now, 503, "", created, time.Time{}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mock_repository.NewMockWebhook(ctrl)
+			repo.EXPECT().UpdateDelivery(tt.want).Return(nil)
+
+			got, err := (&webhookService{repo: repo}).RecordAttempt(tt.delivery, tt.statusCode, tt.sendErr, now)
+			if err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+
+	repo := mock_repository.NewMockWebhook(ctrl)
+	repo.EXPECT().UpdateDelivery(gomock.Any()).Return(errTest)
+	if _, err := (&webhookService{repo: repo}).RecordAttempt(delivery(0), 200, nil, now); !errors.Is(err, errTest) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", err, errTest)
+	}
+}
+
+func Test_truncateWebhookError(t *testing.T) {
+	long := strings.Repeat("a", maxWebhookErrorLength-1) + "あ"
+	if got := truncateWebhookError(long); got != strings.Repeat("a", maxWebhookErrorLength-1) {
+		t.Errorf("戻り値不一致 got: %#v", got)
+	}
+	if got := truncateWebhookError("error"); got != "error" {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, "error")
+	}
+}
+
+func Test_webhookService_EnqueueSameEventWithInMemoryRepository(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	repo := inmemory.NewWebhookRepository()
+	for _, boardID := range []string{"2", "2", "3"} {
+		if _, err := repo.Regist(model.NewWebhook("", boardID, "https://example.com/"+boardID, "secret", []model.WebhookEvent{model.WebhookPostCreated}, now)); err != nil {
+			t.Fatalf("予期せぬエラー(error: %s)", err)
+		}
+	}
+	s := &webhookService{repo: repo}
+
+	// ドメインイベントの再配信で同じイベントが2回転送されても、Webhookごとの配信は1件のみ
+	for i, want := range []int{2, 0} {
+		got, err := s.Enqueue("7", "2", model.WebhookPostCreated, map[string]string{"id": "10"}, now.Add(time.Duration(i)*time.Minute))
+		if err != nil {
+			t.Fatalf("予期せぬエラー(error: %s)", err)
+		}
+		if got != want {
+			t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
+		}
+	}
+
+	deliveries, err := repo.FindDueDeliveries(now.Add(time.Hour), 10)
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	perWebhook := map[string]int{}
+	for _, d := range deliveries {
+		perWebhook[d.WebhookID()]++
+	}
+	if want := map[string]int{"1": 1, "2": 1}; !reflect.DeepEqual(perWebhook, want) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", perWebhook, want)
+	}
+}
@@ -0,0 +1,68 @@
+package dto
+
+import (
+	"time"
+
+	"GoBBS/domain/model"
+)
+
+// APIToken 個人用アクセストークン
+type APIToken struct {
+	ID     string   `json:"id"`
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+	// Token トークン(発行直後のレスポンスにだけ含める)
+	Token      string     `json:"token,omitempty"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// APITokenRequest 個人用アクセストークンの発行内容
+type APITokenRequest struct {
+	Name      string    `json:"name"`
+	Scopes    []string  `json:"scopes"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// APITokenList 個人用アクセストークン一覧
+type APITokenList struct {
+	Tokens []*APIToken `json:"tokens"`
+}
+
+// NewAPIToken トークンモデルを元にDTOトークンを生成する(トークンは含めない)
+func NewAPIToken(token model.APIToken) *APIToken {
+	t := &APIToken{
+		ID:        token.ID(),
+		Name:      token.Name(),
+		Scopes:    append([]string{}, token.Scopes()...),
+		ExpiresAt: token.ExpiresAt(),
+		CreatedAt: token.CreatedAt(),
+	}
+	if !token.LastUsedAt().IsZero() {
+		lastUsedAt := token.LastUsedAt()
+		t.LastUsedAt = &lastUsedAt
+	}
+
+	return t
+}
+
+// NewCreatedAPIToken 発行直後のトークンモデルとトークンを元にDTOトークンを生成する
+func NewCreatedAPIToken(token string, apiToken model.APIToken) *APIToken {
+	t := NewAPIToken(apiToken)
+	t.Token = token
+
+	return t
+}
+
+// NewAPITokenList トークンモデルの一覧を元にDTOトークン一覧を生成する
+func NewAPITokenList(tokens []model.APIToken) *APITokenList {
+	list := &APITokenList{
+		Tokens: make([]*APIToken, 0, len(tokens)),
+	}
+	for _, t := range tokens {
+		list.Tokens = append(list.Tokens, NewAPIToken(t))
+	}
+
+	return list
+}
@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"GoBBS/domain/model"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewAPITokenList(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	lastUsed := now.Add(time.Minute)
+	expires := now.Add(time.Hour)
+
+	got := NewAPITokenList([]model.APIToken{
+		model.NewAPIToken("t2", "1", "deploy", "hash2", []string{"read", "post"}, expires, lastUsed, now),
+		model.NewAPIToken("t1", "1", "ci-bot", "hash1", []string{"read"}, expires, time.Time{}, now),
+	})
+	want := &APITokenList{
+		Tokens: []*APIToken{
+			{ID: "t2", Name: "deploy", Scopes: []string{"read", "post"}, ExpiresAt: expires, LastUsedAt: &lastUsed, CreatedAt: now},
+			{ID: "t1", Name: "ci-bot", Scopes: []string{"read"}, ExpiresAt: expires, CreatedAt: now},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewAPITokenList() = %v, want %v", got, want)
+	}
+}
+
+func TestNewAPITokenList_Empty(t *testing.T) {
+	got := NewAPITokenList(nil)
+	if got.Tokens == nil || len(got.Tokens) != 0 {
+		t.Errorf("NewAPITokenList() = %v, want empty slice", got.Tokens)
+	}
+}
+
+func TestNewCreatedAPIToken(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	expires := now.Add(time.Hour)
+
+	got := NewCreatedAPIToken("gbp_secret", model.NewAPIToken("t1", "1", "ci-bot", "hash1", []string{"read"}, expires, time.Time{}, now))
+	want := &APIToken{ID: "t1", Name: "ci-bot", Scopes: []string{"read"}, Token: "gbp_secret", ExpiresAt: expires, CreatedAt: now}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewCreatedAPIToken() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"strings"
+	"time"
+
+	"GoBBS/domain/model"
+)
+
+// BlobURLPrefix BlobStoreのオブジェクトを配信するパス
+const BlobURLPrefix = "/blobs/"
+
+// Attachment 添付ファイル
+type Attachment struct {
+	ID           string    `json:"id"`
+	URL          string    `json:"url"`
+	ThumbnailURL string    `json:"thumbnail_url"`
+	ContentType  string    `json:"content_type"`
+	Size         int64     `json:"size"`
+	Width        int       `json:"width"`
+	Height       int       `json:"height"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// NewAttachment 添付ファイルモデルを元にDTO添付ファイルを生成する
+func NewAttachment(attachment model.Attachment) *Attachment {
+	return &Attachment{
+		ID:           attachment.ID(),
+		URL:          BlobURL(attachment.BlobKey()),
+		ThumbnailURL: BlobURL(attachment.ThumbnailKey()),
+		ContentType:  attachment.ContentType(),
+		Size:         attachment.Size(),
+		Width:        attachment.Width(),
+		Height:       attachment.Height(),
+		CreatedAt:    attachment.CreatedAt(),
+	}
+}
+
+// BlobURL キーに対応する配信URLを返す
+func BlobURL(key string) string {
+	return BlobURLPrefix + key
+}
+
+// BlobKey 配信URLからキーを取り出す(BlobStoreのURLでない場合はfalse)
+func BlobKey(url string) (string, bool) {
+	if !strings.HasPrefix(url, BlobURLPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(url, BlobURLPrefix), true
+}
@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"GoBBS/domain/model"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewAttachment(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	got := NewAttachment(model.NewAttachment("1", "2", "", "a.png", "a-thumb.png", "image/png", 100, 20, 10, now))
+	want := &Attachment{
+		ID:           "1",
+		URL:          "/blobs/a.png",
+		ThumbnailURL: "/blobs/a-thumb.png",
+		ContentType:  "image/png",
+		Size:         100,
+		Width:        20,
+		Height:       10,
+		CreatedAt:    now,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewAttachment() = %v, want %v", got, want)
+	}
+}
+
+func TestBlobKey(t *testing.T) {
+	tests := []struct {
+		name   string
+		url    string
+		want   string
+		wantOK bool
+	}{
+		{
+			name:   "BlobStoreのURL",
+			url:    "/blobs/a.png",
+			want:   "a.png",
+			wantOK: true,
+		},
+		{
+			name:   "外部のURL",
+			url:    "https://example.com/a.png",
+			want:   "",
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := BlobKey(tt.url)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("BlobKey() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
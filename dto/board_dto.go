@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"time"
+
+	"GoBBS/domain/model"
+)
+
+// Board 板
+type Board struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// BoardRequest 板の登録内容
+type BoardRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// NewBoard 板モデルを元にDTO板を生成する
+func NewBoard(board model.Board) *Board {
+	return &Board{
+		ID:          board.ID(),
+		Name:        board.Name(),
+		Description: board.Description(),
+		CreatedAt:   board.CreatedAt(),
+	}
+}
+
+// NewBoards 板モデルの一覧を元にDTO板の一覧を生成する
+func NewBoards(boards []model.Board) []*Board {
+	list := make([]*Board, 0, len(boards))
+	for _, b := range boards {
+		list = append(list, NewBoard(b))
+	}
+
+	return list
+}
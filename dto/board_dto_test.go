@@ -0,0 +1,27 @@
+package dto
+
+import (
+	"GoBBS/domain/model"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewBoards(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	got := NewBoards([]model.Board{
+		model.NewBoard("1", "雑談", "", now),
+		model.NewBoard("2", "質問", "説明", now),
+	})
+	want := []*Board{
+		{ID: "1", Name: "雑談", Description: "", CreatedAt: now},
+		{ID: "2", Name: "質問", Description: "説明", CreatedAt: now},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewBoards() = %v, want %v", got, want)
+	}
+	if got := NewBoards(nil); got == nil || len(got) != 0 {
+		t.Errorf("NewBoards() = %v, want empty slice", got)
+	}
+}
@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"time"
+
+	"GoBBS/domain/model"
+)
+
+// Watch ウォッチ
+type Watch struct {
+	Kind      string    `json:"kind"`
+	TargetID  string    `json:"target_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DigestSubscription ダイジェストメールの配信設定
+type DigestSubscription struct {
+	Frequency string `json:"frequency"`
+	Locale    string `json:"locale"`
+}
+
+// NewWatch ウォッチモデルを元にDTOウォッチを生成する
+func NewWatch(watch model.Watch) *Watch {
+	return &Watch{
+		Kind:      string(watch.Kind()),
+		TargetID:  watch.TargetID(),
+		CreatedAt: watch.CreatedAt(),
+	}
+}
+
+// NewWatches ウォッチモデルの一覧を元にDTOウォッチの一覧を生成する
+func NewWatches(watches []model.Watch) []*Watch {
+	list := make([]*Watch, 0, len(watches))
+	for _, w := range watches {
+		list = append(list, NewWatch(w))
+	}
+
+	return list
+}
+
+// NewDigestSubscription 配信設定モデルを元にDTO配信設定を生成する(配信停止トークンは含めない)
+func NewDigestSubscription(subscription model.DigestSubscription) *DigestSubscription {
+	return &DigestSubscription{
+		Frequency: string(subscription.Frequency),
+		Locale:    subscription.Locale,
+	}
+}
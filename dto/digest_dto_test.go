@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"GoBBS/domain/model"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewWatches(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	got := NewWatches([]model.Watch{
+		model.NewWatch("1", model.WatchThread, "10", now),
+		model.NewWatch("1", model.WatchBoard, "2", now),
+	})
+	want := []*Watch{
+		{Kind: "thread", TargetID: "10", CreatedAt: now},
+		{Kind: "board", TargetID: "2", CreatedAt: now},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewWatches() = %v, want %v", got, want)
+	}
+	if got := NewWatches(nil); got == nil || len(got) != 0 {
+		t.Errorf("NewWatches() = %v, want empty slice", got)
+	}
+}
+
+func TestNewDigestSubscription(t *testing.T) {
+	got := NewDigestSubscription(model.DigestSubscription{Frequency: model.DigestWeekly, Locale: "en", UnsubscribeToken: "token"})
+	want := &DigestSubscription{Frequency: "weekly", Locale: "en"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewDigestSubscription() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,16 @@
+package dto
+
+// Error エラーレスポンス
+type Error struct {
+	Error     string `json:"error"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// NewError エラーレスポンスを生成する
+// リクエストIDを含め、ログと突き合わせられるようにする
+func NewError(message string, requestID string) *Error {
+	return &Error{
+		Error:     message,
+		RequestID: requestID,
+	}
+}
@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewError(t *testing.T) {
+	type args struct {
+		message   string
+		requestID string
+	}
+	tests := []struct {
+		name string
+		args args
+		want *Error
+	}{
+		{
+			name: "正常ケース",
+			args: args{
+				message:   "Internal Server Error",
+				requestID: "abc",
+			},
+			want: &Error{
+				Error:     "Internal Server Error",
+				RequestID: "abc",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewError(tt.args.message, tt.args.requestID); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
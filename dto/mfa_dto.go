@@ -0,0 +1,57 @@
+package dto
+
+import "time"
+
+// MFAEnrollment 2要素認証の登録内容(認証アプリに秘密鍵かotpauth URIを登録する)
+type MFAEnrollment struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+// MFACode 確認コードまたはリカバリーコード
+type MFACode struct {
+	Code string `json:"code"`
+}
+
+// MFARecoveryCodes 2要素認証の有効化時に発行したリカバリーコード
+type MFARecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// MFAChallenge パスワード認証に成功し、確認コードの入力が必要なことを示す
+// MFATokenを確認コードと合わせて/login/mfaに送るとトークンを発行する
+type MFAChallenge struct {
+	MFARequired bool      `json:"mfa_required"`
+	MFAToken    string    `json:"mfa_token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// MFALogin 確認コードによるログイン
+type MFALogin struct {
+	MFAToken string `json:"mfa_token"`
+	Code     string `json:"code"`
+}
+
+// NewMFAEnrollment 2要素認証の登録内容を生成する
+func NewMFAEnrollment(secret string, uri string) *MFAEnrollment {
+	return &MFAEnrollment{
+		Secret:     secret,
+		OTPAuthURI: uri,
+	}
+}
+
+// NewMFARecoveryCodes リカバリーコードを生成する
+func NewMFARecoveryCodes(codes []string) *MFARecoveryCodes {
+	return &MFARecoveryCodes{
+		RecoveryCodes: codes,
+	}
+}
+
+// NewMFAChallenge 確認コードの入力が必要なことを示すレスポンスを生成する
+func NewMFAChallenge(token string, expiresAt time.Time) *MFAChallenge {
+	return &MFAChallenge{
+		MFARequired: true,
+		MFAToken:    token,
+		ExpiresAt:   expiresAt,
+	}
+}
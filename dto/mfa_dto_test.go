@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewMFAEnrollment(t *testing.T) {
+	want := &MFAEnrollment{Secret: "SECRET", OTPAuthURI: "otpauth://totp/GoBBS:a?secret=SECRET"}
+	if got := NewMFAEnrollment("SECRET", "otpauth://totp/GoBBS:a?secret=SECRET"); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewMFAEnrollment() = %v, want %v", got, want)
+	}
+}
+
+func TestNewMFARecoveryCodes(t *testing.T) {
+	want := &MFARecoveryCodes{RecoveryCodes: []string{"0123a-4567b"}}
+	if got := NewMFARecoveryCodes([]string{"0123a-4567b"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewMFARecoveryCodes() = %v, want %v", got, want)
+	}
+}
+
+func TestNewMFAChallenge(t *testing.T) {
+	expires := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	want := &MFAChallenge{MFARequired: true, MFAToken: "token", ExpiresAt: expires}
+	if got := NewMFAChallenge("token", expires); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewMFAChallenge() = %v, want %v", got, want)
+	}
+}
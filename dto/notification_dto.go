@@ -0,0 +1,80 @@
+package dto
+
+import (
+	"time"
+
+	"GoBBS/domain/model"
+)
+
+// Notification 通知
+type Notification struct {
+	ID        string     `json:"id"`
+	Kind      string     `json:"kind"`
+	ActorID   string     `json:"actor_id"`
+	TargetID  string     `json:"target_id"`
+	Read      bool       `json:"read"`
+	ReadAt    *time.Time `json:"read_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// NotificationList 通知一覧
+type NotificationList struct {
+	Notifications []*Notification `json:"notifications"`
+	UnreadCount   int             `json:"unread_count"`
+}
+
+// NotificationPreferences 通知の受信設定
+type NotificationPreferences struct {
+	Reply   bool `json:"reply"`
+	Quote   bool `json:"quote"`
+	Mention bool `json:"mention"`
+}
+
+// NewNotification 通知モデルを元にDTO通知を生成する
+func NewNotification(notification model.Notification) *Notification {
+	n := &Notification{
+		ID:        notification.ID(),
+		Kind:      string(notification.Kind()),
+		ActorID:   notification.ActorID(),
+		TargetID:  notification.TargetID(),
+		Read:      notification.IsRead(),
+		CreatedAt: notification.CreatedAt(),
+	}
+	if n.Read {
+		readAt := notification.ReadAt()
+		n.ReadAt = &readAt
+	}
+
+	return n
+}
+
+// NewNotificationList 通知モデルの一覧と未読数を元にDTO通知一覧を生成する
+func NewNotificationList(notifications []model.Notification, unreadCount int) *NotificationList {
+	list := &NotificationList{
+		Notifications: make([]*Notification, 0, len(notifications)),
+		UnreadCount:   unreadCount,
+	}
+	for _, n := range notifications {
+		list.Notifications = append(list.Notifications, NewNotification(n))
+	}
+
+	return list
+}
+
+// NewNotificationPreferences 受信設定モデルを元にDTO受信設定を生成する
+func NewNotificationPreferences(preferences model.NotificationPreferences) *NotificationPreferences {
+	return &NotificationPreferences{
+		Reply:   preferences.Reply,
+		Quote:   preferences.Quote,
+		Mention: preferences.Mention,
+	}
+}
+
+// MapNotificationPreferencesModel DTO受信設定を元に受信設定モデルを生成する
+func (p *NotificationPreferences) MapNotificationPreferencesModel() model.NotificationPreferences {
+	return model.NotificationPreferences{
+		Reply:   p.Reply,
+		Quote:   p.Quote,
+		Mention: p.Mention,
+	}
+}
@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"GoBBS/domain/model"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewNotificationList(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	readAt := now.Add(time.Hour)
+
+	got := NewNotificationList([]model.Notification{
+		model.NewNotification("2", "1", model.NotificationQuote, "3", "20", readAt, now),
+		model.NewNotification("1", "1", model.NotificationReply, "3", "10", time.Time{}, now),
+	}, 1)
+	want := &NotificationList{
+		Notifications: []*Notification{
+			{ID: "2", Kind: "quote", ActorID: "3", TargetID: "20", Read: true, ReadAt: &readAt, CreatedAt: now},
+			{ID: "1", Kind: "reply", ActorID: "3", TargetID: "10", Read: false, ReadAt: nil, CreatedAt: now},
+		},
+		UnreadCount: 1,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewNotificationList() = %v, want %v", got, want)
+	}
+}
+
+func TestNewNotificationList_Empty(t *testing.T) {
+	got := NewNotificationList(nil, 0)
+	if got.Notifications == nil || len(got.Notifications) != 0 {
+		t.Errorf("NewNotificationList() = %v, want empty slice", got.Notifications)
+	}
+}
+
+func TestNotificationPreferences_MapNotificationPreferencesModel(t *testing.T) {
+	want := model.NotificationPreferences{Reply: true, Quote: false, Mention: true}
+	if got := NewNotificationPreferences(want).MapNotificationPreferencesModel(); got != want {
+		t.Errorf("MapNotificationPreferencesModel() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,155 @@
+package dto
+
+import (
+	"time"
+
+	"GoBBS/domain/model"
+)
+
+// Thread スレッド
+type Thread struct {
+	ID           string    `json:"id"`
+	BoardID      string    `json:"board_id"`
+	UserID       string    `json:"user_id"`
+	Title        string    `json:"title"`
+	PostCount    int       `json:"post_count"`
+	LastPostedAt time.Time `json:"last_posted_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Post 投稿(本文はマークダウンとレンダリング済みのHTMLの両方を返す、非表示にされた投稿は本文を返さない)
+type Post struct {
+	ID           string     `json:"id"`
+	ThreadID     string     `json:"thread_id"`
+	UserID       string     `json:"user_id"`
+	Number       int        `json:"number"`
+	Body         string     `json:"body"`
+	BodyHTML     string     `json:"body_html"`
+	CreatedAt    time.Time  `json:"created_at"`
+	Edited       bool       `json:"edited"`
+	EditedAt     *time.Time `json:"edited_at,omitempty"`
+	Hidden       bool       `json:"hidden"`
+	HiddenReason string     `json:"hidden_reason,omitempty"`
+}
+
+// PostRevision 投稿の版(投稿時と編集ごとの本文)
+type PostRevision struct {
+	ID        string    `json:"id"`
+	PostID    string    `json:"post_id"`
+	EditorID  string    `json:"editor_id"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PostDiff 投稿の2つの版の行単位の差分
+type PostDiff struct {
+	From  string          `json:"from"`
+	To    string          `json:"to"`
+	Lines []*PostDiffLine `json:"lines"`
+}
+
+// PostDiffLine 差分の1行(opはequal、insert、deleteのいずれか)
+type PostDiffLine struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+// ThreadPosts スレッドとその投稿
+type ThreadPosts struct {
+	Thread *Thread `json:"thread"`
+	Posts  []*Post `json:"posts"`
+}
+
+// ThreadRequest スレッドの作成内容(本文は1番目の投稿になる)
+type ThreadRequest struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// PostRequest 投稿内容(本文はマークダウン)
+type PostRequest struct {
+	Body string `json:"body"`
+}
+
+// PostHideRequest 投稿の非表示の内容
+type PostHideRequest struct {
+	Reason string `json:"reason"`
+}
+
+// NewThread スレッドモデルを元にDTOスレッドを生成する
+func NewThread(thread model.Thread) *Thread {
+	return &Thread{
+		ID:           thread.ID(),
+		BoardID:      thread.BoardID(),
+		UserID:       thread.UserID(),
+		Title:        thread.Title(),
+		PostCount:    thread.PostCount(),
+		LastPostedAt: thread.LastPostedAt(),
+		CreatedAt:    thread.CreatedAt(),
+	}
+}
+
+// NewThreads スレッドモデルの一覧を元にDTOスレッドの一覧を生成する
+func NewThreads(threads []model.Thread) []*Thread {
+	list := make([]*Thread, 0, len(threads))
+	for _, t := range threads {
+		list = append(list, NewThread(t))
+	}
+
+	return list
+}
+
+// NewPost 投稿モデルを元にDTO投稿を生成する
+func NewPost(post model.Post) *Post {
+	p := &Post{
+		ID:        post.ID(),
+		ThreadID:  post.ThreadID(),
+		UserID:    post.UserID(),
+		Number:    post.Number(),
+		Body:      post.Body(),
+		BodyHTML:  post.BodyHTML(),
+		CreatedAt: post.CreatedAt(),
+	}
+	if post.IsEdited() {
+		editedAt := post.EditedAt()
+		p.Edited = true
+		p.EditedAt = &editedAt
+	}
+	if post.IsHidden() {
+		p.Body = ""
+		p.BodyHTML = ""
+		p.Hidden = true
+		p.HiddenReason = post.HiddenReason()
+	}
+
+	return p
+}
+
+// NewPostRevisions 版モデルの一覧を元にDTO版の一覧を生成する
+func NewPostRevisions(revisions []model.PostRevision) []*PostRevision {
+	list := make([]*PostRevision, 0, len(revisions))
+	for _, r := range revisions {
+		list = append(list, &PostRevision{
+			ID:        r.ID(),
+			PostID:    r.PostID(),
+			EditorID:  r.EditorID(),
+			Body:      r.Body(),
+			CreatedAt: r.CreatedAt(),
+		})
+	}
+
+	return list
+}
+
+// NewThreadPosts スレッドモデルと投稿モデルの一覧を元にDTOスレッドと投稿を生成する
+func NewThreadPosts(thread model.Thread, posts []model.Post) *ThreadPosts {
+	list := &ThreadPosts{
+		Thread: NewThread(thread),
+		Posts:  make([]*Post, 0, len(posts)),
+	}
+	for _, p := range posts {
+		list.Posts = append(list.Posts, NewPost(p))
+	}
+
+	return list
+}
@@ -0,0 +1,106 @@
+package dto
+
+import (
+	"GoBBS/domain/model"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewThreads(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	lastPostedAt := now.Add(time.Hour)
+
+	got := NewThreads([]model.Thread{
+		model.NewThread("2", "1", "3", "title", 5, lastPostedAt, now),
+		model.NewThread("1", "1", "", "退会", 1, now, now),
+	})
+	want := []*Thread{
+		{ID: "2", BoardID: "1", UserID: "3", Title: "title", PostCount: 5, LastPostedAt: lastPostedAt, CreatedAt: now},
+		{ID: "1", BoardID: "1", UserID: "", Title: "退会", PostCount: 1, LastPostedAt: now, CreatedAt: now},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewThreads() = %v, want %v", got, want)
+	}
+	if got := NewThreads(nil); got == nil || len(got) != 0 {
+		t.Errorf("NewThreads() = %v, want empty slice", got)
+	}
+}
+
+func TestNewThreadPosts(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	got := NewThreadPosts(
+		model.NewThread("1", "2", "3", "title", 2, now, now),
+		[]model.Post{
+			model.NewPost("10", "1", "3", 1, "**body**", "<p><strong>body</strong></p>\n", now, time.Time{}, time.Time{}, ""),
+			model.NewPost("11", "1", "4", 2, ">>1", "<p><a href=\"#post-1\" class=\"quote\">&gt;&gt;1</a></p>\n", now, time.Time{}, time.Time{}, ""),
+		},
+	)
+	want := &ThreadPosts{
+		Thread: &Thread{ID: "1", BoardID: "2", UserID: "3", Title: "title", PostCount: 2, LastPostedAt: now, CreatedAt: now},
+		Posts: []*Post{
+			{ID: "10", ThreadID: "1", UserID: "3", Number: 1, Body: "**body**", BodyHTML: "<p><strong>body</strong></p>\n", CreatedAt: now},
+			{ID: "11", ThreadID: "1", UserID: "4", Number: 2, Body: ">>1", BodyHTML: "<p><a href=\"#post-1\" class=\"quote\">&gt;&gt;1</a></p>\n", CreatedAt: now},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewThreadPosts() = %v, want %v", got, want)
+	}
+	if got := NewThreadPosts(model.NewThread("1", "2", "3", "title", 0, now, now), nil); got.Posts == nil || len(got.Posts) != 0 {
+		t.Errorf("NewThreadPosts() = %v, want empty slice", got.Posts)
+	}
+}
+
+func TestNewPost(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	editedAt := now.Add(time.Minute)
+
+	tests := []struct {
+		name string
+		post model.Post
+		want *Post
+	}{
+		{
+			name: "正常ケース(未編集)",
+			post: model.NewPost("10", "1", "3", 1, "body", "<p>body</p>\n", now, time.Time{}, time.Time{}, ""),
+			want: &Post{ID: "10", ThreadID: "1", UserID: "3", Number: 1, Body: "body", BodyHTML: "<p>body</p>\n", CreatedAt: now},
+		},
+		{
+			name: "正常ケース(編集済み)",
+			post: model.NewPost("10", "1", "3", 1, "edited", "<p>edited</p>\n", now, editedAt, time.Time{}, ""),
+			want: &Post{ID: "10", ThreadID: "1", UserID: "3", Number: 1, Body: "edited", BodyHTML: "<p>edited</p>\n", CreatedAt: now, Edited: true, EditedAt: &editedAt},
+		},
+		{
+			name: "正常ケース(非表示)",
+			post: model.NewPost("10", "1", "3", 1, "spam", "<p>spam</p>\n", now, time.Time{}, editedAt, "広告"),
+			want: &Post{ID: "10", ThreadID: "1", UserID: "3", Number: 1, CreatedAt: now, Hidden: true, HiddenReason: "広告"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewPost(tt.post); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewPost() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewPostRevisions(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	got := NewPostRevisions([]model.PostRevision{
+		model.NewPostRevision("20", "10", "3", "body", now),
+		model.NewPostRevision("21", "10", "", "edited", now.Add(time.Minute)),
+	})
+	want := []*PostRevision{
+		{ID: "20", PostID: "10", EditorID: "3", Body: "body", CreatedAt: now},
+		{ID: "21", PostID: "10", EditorID: "", Body: "edited", CreatedAt: now.Add(time.Minute)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewPostRevisions() = %v, want %v", got, want)
+	}
+	if got := NewPostRevisions(nil); got == nil || len(got) != 0 {
+		t.Errorf("NewPostRevisions() = %v, want empty slice", got)
+	}
+}
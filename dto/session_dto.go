@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"time"
+
+	"GoBBS/domain/model"
+)
+
+// Session ログインセッション
+type Session struct {
+	ID         string    `json:"id"`
+	Device     string    `json:"device"`
+	IP         string    `json:"ip"`
+	UserAgent  string    `json:"user_agent"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	// Current リクエストの認証に使ったセッションか
+	Current bool `json:"current"`
+}
+
+// SessionList ログインセッション一覧
+type SessionList struct {
+	Sessions []*Session `json:"sessions"`
+}
+
+// NewSession セッションモデルを元にDTOセッションを生成する
+func NewSession(session model.Session, current bool) *Session {
+	return &Session{
+		ID:         session.ID(),
+		Device:     session.Device(),
+		IP:         session.IP(),
+		UserAgent:  session.UserAgent(),
+		CreatedAt:  session.CreatedAt(),
+		LastSeenAt: session.LastSeenAt(),
+		ExpiresAt:  session.ExpiresAt(),
+		Current:    current,
+	}
+}
+
+// NewSessionList セッションモデルの一覧を元にDTOセッション一覧を生成する
+// currentIDのセッションをリクエストの認証に使ったセッションとする
+func NewSessionList(sessions []model.Session, currentID string) *SessionList {
+	list := &SessionList{
+		Sessions: make([]*Session, 0, len(sessions)),
+	}
+	for _, s := range sessions {
+		list.Sessions = append(list.Sessions, NewSession(s, s.ID() == currentID))
+	}
+
+	return list
+}
@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"GoBBS/domain/model"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewSessionList(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	lastSeen := now.Add(time.Minute)
+	expires := now.Add(time.Hour)
+
+	got := NewSessionList([]model.Session{
+		model.NewSession("s2", "1", "Safari on iPhone", "192.0.2.2", "ua2", now, lastSeen, expires, time.Time{}),
+		model.NewSession("s1", "1", "Firefox on Linux", "192.0.2.1", "ua1", now, now, expires, time.Time{}),
+	}, "s1")
+	want := &SessionList{
+		Sessions: []*Session{
+			{ID: "s2", Device: "Safari on iPhone", IP: "192.0.2.2", UserAgent: "ua2", CreatedAt: now, LastSeenAt: lastSeen, ExpiresAt: expires, Current: false},
+			{ID: "s1", Device: "Firefox on Linux", IP: "192.0.2.1", UserAgent: "ua1", CreatedAt: now, LastSeenAt: now, ExpiresAt: expires, Current: true},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewSessionList() = %v, want %v", got, want)
+	}
+}
+
+func TestNewSessionList_Empty(t *testing.T) {
+	got := NewSessionList(nil, "")
+	if got.Sessions == nil || len(got.Sessions) != 0 {
+		t.Errorf("NewSessionList() = %v, want empty slice", got.Sessions)
+	}
+}
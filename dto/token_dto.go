@@ -11,3 +11,16 @@ func NewToken(token string) *Token {
 		Token: token,
 	}
 }
+
+// CSRFToken クッキーでログインした場合に返すCSRFトークン
+// 状態を変更するリクエストのX-CSRF-Tokenヘッダーに付ける
+type CSRFToken struct {
+	CSRFToken string `json:"csrf_token"`
+}
+
+// NewCSRFToken CSRFトークンを生成する
+func NewCSRFToken(csrfToken string) *CSRFToken {
+	return &CSRFToken{
+		CSRFToken: csrfToken,
+	}
+}
@@ -32,3 +32,31 @@ func TestNewToken(t *testing.T) {
 		})
 	}
 }
+
+func TestNewCSRFToken(t *testing.T) {
+	type args struct {
+		csrfToken string
+	}
+	tests := []struct {
+		name string
+		args args
+		want *CSRFToken
+	}{
+		{
+			name: "正常ケース",
+			args: args{
+				csrfToken: "csrf",
+			},
+			want: &CSRFToken{
+				CSRFToken: "csrf",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewCSRFToken(tt.args.csrfToken); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewCSRFToken() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
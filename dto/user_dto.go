@@ -1,30 +1,113 @@
 package dto
 
 import (
+	"time"
+
 	"GoBBS/domain/model"
 )
 
 // User ユーザー
 type User struct {
-	ID       string `json:"id"`
-	Name     string `json:"name"`
-	Email    string `json:"email"`
-	Password string `json:"password"`
-	Salt     string `json:"-"`
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Email       string    `json:"email"`
+	Password    string    `json:"password"`
+	Salt        string    `json:"-"`
+	DisplayName string    `json:"display_name"`
+	Bio         string    `json:"bio"`
+	AvatarURL   string    `json:"avatar_url"`
+	Signature   string    `json:"signature"`
+	JoinedAt    time.Time `json:"joined_at"`
+}
+
+// UserUpdateRequest ユーザーの部分更新リクエスト(省略した項目は更新しない)
+type UserUpdateRequest struct {
+	Email       string  `json:"email"`
+	Name        *string `json:"name"`
+	Password    *string `json:"password"`
+	DisplayName *string `json:"display_name"`
+	Bio         *string `json:"bio"`
+	AvatarURL   *string `json:"avatar_url"`
+	Signature   *string `json:"signature"`
+}
+
+// PublicUser 公開プロフィール(メールアドレス・パスワード・ソルトを含まない)
+type PublicUser struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	DisplayName string    `json:"display_name"`
+	Bio         string    `json:"bio"`
+	AvatarURL   string    `json:"avatar_url"`
+	Signature   string    `json:"signature"`
+	JoinedAt    time.Time `json:"joined_at"`
+}
+
+// PrivateUser 本人向けプロフィール(パスワード・ソルトを含まない)
+type PrivateUser struct {
+	PublicUser
+	Email string `json:"email"`
 }
 
 // NewUser ユーザーモデルを元にDTOユーザーを生成する
 func NewUser(user model.User) *User {
-	return &User{
+	u := &User{
 		ID:       user.ID(),
 		Name:     user.Name(),
 		Email:    user.Email(),
 		Password: user.Password(),
 		Salt:     user.Salt(),
 	}
+
+	profile := user.Profile()
+	u.DisplayName = profile.DisplayName
+	u.Bio = profile.Bio
+	u.AvatarURL = profile.AvatarURL
+	u.Signature = profile.Signature
+	u.JoinedAt = profile.JoinedAt
+
+	return u
 }
 
 // MapUserModel DTOユーザーの情報を元にユーザーモデルを生成する
 func (u *User) MapUserModel() model.User {
-	return model.NewUser(u.ID, u.Name, u.Email, u.Password, u.Salt)
+	return model.NewUserWithProfile(u.ID, u.Name, u.Email, u.Password, u.Salt, model.Profile{
+		DisplayName: u.DisplayName,
+		Bio:         u.Bio,
+		AvatarURL:   u.AvatarURL,
+		Signature:   u.Signature,
+		JoinedAt:    u.JoinedAt,
+	})
+}
+
+// Public 公開プロフィールを返す
+func (u *User) Public() *PublicUser {
+	return &PublicUser{
+		ID:          u.ID,
+		Name:        u.Name,
+		DisplayName: u.DisplayName,
+		Bio:         u.Bio,
+		AvatarURL:   u.AvatarURL,
+		Signature:   u.Signature,
+		JoinedAt:    u.JoinedAt,
+	}
+}
+
+// Private 本人向けプロフィールを返す
+func (u *User) Private() *PrivateUser {
+	return &PrivateUser{
+		PublicUser: *u.Public(),
+		Email:      u.Email,
+	}
+}
+
+// MapUserPatch 部分更新リクエストを元にユーザーの部分更新を生成する
+func (r *UserUpdateRequest) MapUserPatch() model.UserPatch {
+	return model.UserPatch{
+		Name:        r.Name,
+		Password:    r.Password,
+		DisplayName: r.DisplayName,
+		Bio:         r.Bio,
+		AvatarURL:   r.AvatarURL,
+		Signature:   r.Signature,
+	}
 }
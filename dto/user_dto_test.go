@@ -3,8 +3,11 @@ package dto
 import (
 	"GoBBS/domain/model"
 	"GoBBS/mock/mock_model"
+	"encoding/json"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 )
@@ -32,16 +35,22 @@ func TestNewUser(t *testing.T) {
 						mock.EXPECT().Email().Return("email"),
 						mock.EXPECT().Password().Return("password"),
 						mock.EXPECT().Salt().Return("salt"),
+						mock.EXPECT().Profile().Return(model.Profile{
+							DisplayName: "display",
+							Bio:         "bio",
+						}),
 					)
 					return mock
 				}(),
 			},
 			want: &User{
-				ID:       "id",
-				Name:     "name",
-				Email:    "email",
-				Password: "password",
-				Salt:     "salt",
+				ID:          "id",
+				Name:        "name",
+				Email:       "email",
+				Password:    "password",
+				Salt:        "salt",
+				DisplayName: "display",
+				Bio:         "bio",
 			},
 		},
 	}
@@ -80,3 +89,136 @@ func TestUser_MapUserModel(t *testing.T) {
 		})
 	}
 }
+
+func TestUser_Public(t *testing.T) {
+	joinedAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		u    *User
+		want *PublicUser
+	}{
+		{
+			name: "正常ケース",
+			u: &User{
+				ID:          "id",
+				Name:        "name",
+				Email:       "email",
+				Password:    "password",
+				Salt:        "salt",
+				DisplayName: "display",
+				Bio:         "bio",
+				AvatarURL:   "avatar",
+				Signature:   "signature",
+				JoinedAt:    joinedAt,
+			},
+			want: &PublicUser{
+				ID:          "id",
+				Name:        "name",
+				DisplayName: "display",
+				Bio:         "bio",
+				AvatarURL:   "avatar",
+				Signature:   "signature",
+				JoinedAt:    joinedAt,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.u.Public()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("User.Public() = %v, want %v", got, tt.want)
+			}
+
+			b, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("予期せぬエラー(error: %s)", err)
+			}
+			for _, key := range []string{"email", "password", "salt"} {
+				if strings.Contains(string(b), `"`+key+`"`) {
+					t.Errorf("公開プロフィールに%sが含まれている: %s", key, b)
+				}
+			}
+		})
+	}
+}
+
+func TestUser_Private(t *testing.T) {
+	tests := []struct {
+		name string
+		u    *User
+		want *PrivateUser
+	}{
+		{
+			name: "正常ケース",
+			u: &User{
+				ID:       "id",
+				Name:     "name",
+				Email:    "email",
+				Password: "password",
+				Salt:     "salt",
+			},
+			want: &PrivateUser{
+				PublicUser: PublicUser{
+					ID:   "id",
+					Name: "name",
+				},
+				Email: "email",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.u.Private()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("User.Private() = %v, want %v", got, tt.want)
+			}
+
+			b, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("予期せぬエラー(error: %s)", err)
+			}
+			for _, key := range []string{"password", "salt"} {
+				if strings.Contains(string(b), `"`+key+`"`) {
+					t.Errorf("本人向けプロフィールに%sが含まれている: %s", key, b)
+				}
+			}
+		})
+	}
+}
+
+func TestUserUpdateRequest_MapUserPatch(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want func() model.UserPatch
+	}{
+		{
+			name: "正常ケース(省略した項目はnil、空文字は空に更新)",
+			body: `{"email":"email","display_name":"display","bio":""}`,
+			want: func() model.UserPatch {
+				display, bio := "display", ""
+				return model.UserPatch{DisplayName: &display, Bio: &bio}
+			},
+		},
+		{
+			name: "正常ケース(全ての項目)",
+			body: `{"email":"email","name":"name","password":"pw","display_name":"d","bio":"b","avatar_url":"https://example.com/a.png","signature":"s"}`,
+			want: func() model.UserPatch {
+				name, pw, d, b, a, sig := "name", "pw", "d", "b", "https://example.com/a.png", "s"
+				return model.UserPatch{Name: &name, Password: &pw, DisplayName: &d, Bio: &b, AvatarURL: &a, Signature: &sig}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req UserUpdateRequest
+			if err := json.Unmarshal([]byte(tt.body), &req); err != nil {
+				t.Fatalf("予期せぬエラー(error: %s)", err)
+			}
+			if got := req.MapUserPatch(); !reflect.DeepEqual(got, tt.want()) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want())
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"GoBBS/domain/model"
+)
+
+// Webhook Webhook
+type Webhook struct {
+	ID        string    `json:"id"`
+	BoardID   string    `json:"board_id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WebhookRequest Webhookの登録内容
+type WebhookRequest struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+}
+
+// WebhookDelivery Webhookの配信ログ
+type WebhookDelivery struct {
+	ID             string          `json:"id"`
+	Event          string          `json:"event"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at"`
+	LastStatusCode int             `json:"last_status_code,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at"`
+}
+
+// NewWebhook Webhookモデルを元にDTOWebhookを生成する(署名用の秘密鍵は含めない)
+func NewWebhook(webhook model.Webhook) *Webhook {
+	events := make([]string, 0, len(webhook.Events()))
+	for _, e := range webhook.Events() {
+		events = append(events, string(e))
+	}
+
+	return &Webhook{
+		ID:        webhook.ID(),
+		BoardID:   webhook.BoardID(),
+		URL:       webhook.URL(),
+		Events:    events,
+		CreatedAt: webhook.CreatedAt(),
+	}
+}
+
+// NewCreatedWebhook 登録直後のWebhookモデルを元に、署名用の秘密鍵を含むDTOWebhookを生成する
+func NewCreatedWebhook(webhook model.Webhook) *Webhook {
+	w := NewWebhook(webhook)
+	w.Secret = webhook.Secret()
+
+	return w
+}
+
+// NewWebhooks Webhookモデルの一覧を元にDTOWebhookの一覧を生成する
+func NewWebhooks(webhooks []model.Webhook) []*Webhook {
+	list := make([]*Webhook, 0, len(webhooks))
+	for _, w := range webhooks {
+		list = append(list, NewWebhook(w))
+	}
+
+	return list
+}
+
+// MapWebhookEvents 登録内容のイベントをWebhookイベントモデルに変換する
+func (r *WebhookRequest) MapWebhookEvents() []model.WebhookEvent {
+	events := make([]model.WebhookEvent, 0, len(r.Events))
+	for _, e := range r.Events {
+		events = append(events, model.WebhookEvent(e))
+	}
+
+	return events
+}
+
+// NewWebhookDelivery 配信モデルを元にDTO配信ログを生成する
+func NewWebhookDelivery(delivery model.WebhookDelivery) *WebhookDelivery {
+	d := &WebhookDelivery{
+		ID:             delivery.ID(),
+		Event:          string(delivery.Event()),
+		Payload:        json.RawMessage(delivery.Payload()),
+		Status:         string(delivery.Status()),
+		Attempts:       delivery.Attempts(),
+		LastStatusCode: delivery.LastStatusCode(),
+		LastError:      delivery.LastError(),
+		CreatedAt:      delivery.CreatedAt(),
+	}
+	if delivery.Status() == model.WebhookDeliveryPending {
+		nextAttemptAt := delivery.NextAttemptAt()
+		d.NextAttemptAt = &nextAttemptAt
+	}
+	if !delivery.DeliveredAt().IsZero() {
+		deliveredAt := delivery.DeliveredAt()
+		d.DeliveredAt = &deliveredAt
+	}
+
+	return d
+}
+
+// NewWebhookDeliveries 配信モデルの一覧を元にDTO配信ログの一覧を生成する
+func NewWebhookDeliveries(deliveries []model.WebhookDelivery) []*WebhookDelivery {
+	list := make([]*WebhookDelivery, 0, len(deliveries))
+	for _, d := range deliveries {
+		list = append(list, NewWebhookDelivery(d))
+	}
+
+	return list
+}
@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"GoBBS/domain/model"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewWebhooks(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	got := NewWebhooks([]model.Webhook{
+		model.NewWebhook("1", "2", "https://example.com/hook", "secret", []model.WebhookEvent{model.WebhookThreadCreated, model.WebhookPostHidden}, now),
+	})
+	want := []*Webhook{
+		{ID: "1", BoardID: "2", URL: "https://example.com/hook", Events: []string{"thread.created", "post.hidden"}, CreatedAt: now},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewWebhooks() = %v, want %v", got, want)
+	}
+	if got := NewWebhooks(nil); got == nil || len(got) != 0 {
+		t.Errorf("NewWebhooks() = %v, want empty slice", got)
+	}
+}
+
+func TestNewCreatedWebhook(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	got := NewCreatedWebhook(model.NewWebhook("1", "2", "https://example.com/hook", "secret", []model.WebhookEvent{model.WebhookPostCreated}, now))
+	want := &Webhook{ID: "1", BoardID: "2", URL: "https://example.com/hook", Events: []string{"post.created"}, Secret: "secret", CreatedAt: now}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewCreatedWebhook() = %v, want %v", got, want)
+	}
+}
+
+func TestWebhookRequest_MapWebhookEvents(t *testing.T) {
+	r := &WebhookRequest{URL: "https://example.com/hook", Events: []string{"post.created", "post.hidden"}}
+	want := []model.WebhookEvent{model.WebhookPostCreated, model.WebhookPostHidden}
+	if got := r.MapWebhookEvents(); !reflect.DeepEqual(got, want) {
+		t.Errorf("MapWebhookEvents() = %v, want %v", got, want)
+	}
+}
+
+func TestNewWebhookDeliveries(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	next := now.Add(time.Minute)
+	delivered := now.Add(time.Second)
+
+	got := NewWebhookDeliveries([]model.WebhookDelivery{
+		model.NewWebhookDelivery("2", "1", model.WebhookPostCreated, []byte(`{"event":"post.created"}`), model.WebhookDeliveryPending, 1, next, 503, "", now, time.Time{}),
+		model.NewWebhookDelivery("1", "1", model.WebhookPostHidden, []byte(`{}`), model.WebhookDeliverySucceeded, 2, now, 200, "", now, delivered),
+	})
+	want := []*WebhookDelivery{
+		{ID: "2", Event: "post.created", Payload: json.RawMessage(`{"event":"post.created"}`), Status: "pending", Attempts: 1, NextAttemptAt: &next, LastStatusCode: 503, CreatedAt: now},
+		{ID: "1", Event: "post.hidden", Payload: json.RawMessage(`{}`), Status: "succeeded", Attempts: 2, LastStatusCode: 200, CreatedAt: now, DeliveredAt: &delivered},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewWebhookDeliveries() = %v, want %v", got, want)
+	}
+	if got := NewWebhookDeliveries(nil); got == nil || len(got) != 0 {
+		t.Errorf("NewWebhookDeliveries() = %v, want empty slice", got)
+	}
+}
@@ -0,0 +1,157 @@
+package dao
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+
+	"github.com/pkg/errors"
+)
+
+// APITokenDAO APITokenDAO
+type APITokenDAO struct {
+	tx *sql.Tx
+}
+
+var _ repository.APIToken = (*APITokenDAO)(nil)
+
+// NewAPITokenDAO APITokenDAOを生成する
+func NewAPITokenDAO(tx *sql.Tx) *APITokenDAO {
+	return &APITokenDAO{
+		tx: tx,
+	}
+}
+
+// apiTokenColumns トークン取得時のカラム
+const apiTokenColumns = "id, user_id, name, token_hash, scopes, expires_at, last_used_at, created_at"
+
+// Regist トークンを登録する
+func (a *APITokenDAO) Regist(token model.APIToken) error {
+	if _, err := a.tx.Exec(`
+		insert into api_token (id, user_id, name, token_hash, scopes, expires_at, created_at)
+		values(?, ?, ?, ?, ?, ?, ?)
+	`,
+		token.ID(),
+		token.UserID(),
+		token.Name(),
+		token.Hash(),
+		strings.Join(token.Scopes(), ","),
+		token.ExpiresAt(),
+		token.CreatedAt(),
+	); err != nil {
+		return errors.Wrap(err, "Regist error")
+	}
+
+	return nil
+}
+
+// FindByHash ハッシュからトークンを取得する
+func (a *APITokenDAO) FindByHash(hash string) (model.APIToken, error) {
+	token, err := scanAPIToken(a.tx.QueryRow("select "+apiTokenColumns+" from api_token where token_hash = ?", hash))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrAPITokenNotFound
+	} else if err != nil {
+		return nil, errors.Wrap(err, "FindByHash error")
+	}
+
+	return token, nil
+}
+
+// FindActiveByUserID ユーザーの有効なトークンを発行の新しい順に取得する
+func (a *APITokenDAO) FindActiveByUserID(userID string, now time.Time) ([]model.APIToken, error) {
+	rows, err := a.tx.Query(`
+		select `+apiTokenColumns+` from api_token
+		where user_id = ? and expires_at > ?
+		order by created_at desc, id desc
+	`,
+		userID,
+		now,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "FindActiveByUserID error")
+	}
+	defer rows.Close()
+
+	tokens := []model.APIToken{}
+	for rows.Next() {
+		token, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "FindActiveByUserID error")
+		}
+		tokens = append(tokens, token)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "FindActiveByUserID error")
+	}
+
+	return tokens, nil
+}
+
+// Touch 最終利用日時を更新する
+func (a *APITokenDAO) Touch(id string, now time.Time) error {
+	if _, err := a.tx.Exec("update api_token set last_used_at = ? where id = ?", now, id); err != nil {
+		return errors.Wrap(err, "Touch error")
+	}
+
+	return nil
+}
+
+// Delete ユーザーのトークンを削除する
+func (a *APITokenDAO) Delete(userID string, id string) error {
+	result, err := a.tx.Exec("delete from api_token where id = ? and user_id = ?", id, userID)
+	if err != nil {
+		return errors.Wrap(err, "Delete error")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "Delete error")
+	}
+	if affected == 0 {
+		return repository.ErrAPITokenNotFound
+	}
+
+	return nil
+}
+
+// DeleteExpired ユーザーの有効期限切れのトークンを削除する
+func (a *APITokenDAO) DeleteExpired(userID string, now time.Time) error {
+	if _, err := a.tx.Exec("delete from api_token where user_id = ? and expires_at <= ?", userID, now); err != nil {
+		return errors.Wrap(err, "DeleteExpired error")
+	}
+
+	return nil
+}
+
+// scanAPIToken 1行分のトークンを読み込む
+func scanAPIToken(row rowScanner) (model.APIToken, error) {
+	var (
+		id         string
+		userID     string
+		name       string
+		hash       string
+		scopes     string
+		expiresAt  time.Time
+		lastUsedAt sql.NullTime
+		createdAt  time.Time
+	)
+	if err := row.Scan(&id, &userID, &name, &hash, &scopes, &expiresAt, &lastUsedAt, &createdAt); err != nil {
+		return nil, err
+	}
+
+	return model.NewAPIToken(id, userID, name, hash, splitAPIScopes(scopes), expiresAt, lastUsedAt.Time, createdAt), nil
+}
+
+// splitAPIScopes カンマ区切りの文字列をスコープに戻す
+func splitAPIScopes(s string) []string {
+	scopes := []string{}
+	for _, scope := range strings.Split(s, ",") {
+		if scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
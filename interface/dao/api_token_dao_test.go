@@ -0,0 +1,174 @@
+package dao
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+const (
+	apiTokenInsertQuery       = "insert into api_token (id, user_id, name, token_hash, scopes, expires_at, created_at) values(?, ?, ?, ?, ?, ?, ?)"
+	apiTokenSelectQuery       = "select id, user_id, name, token_hash, scopes, expires_at, last_used_at, created_at from api_token where token_hash = ?"
+	apiTokenSelectActiveQuery = "select id, user_id, name, token_hash, scopes, expires_at, last_used_at, created_at from api_token where user_id = ? and expires_at > ? order by created_at desc, id desc"
+	apiTokenTouchQuery        = "update api_token set last_used_at = ? where id = ?"
+	apiTokenDeleteQuery       = "delete from api_token where id = ? and user_id = ?"
+	apiTokenDeleteExpiredQry  = "delete from api_token where user_id = ? and expires_at <= ?"
+)
+
+var apiTokenColumnNames = []string{"id", "user_id", "name", "token_hash", "scopes", "expires_at", "last_used_at", "created_at"}
+
+func TestNewAPITokenDAO(t *testing.T) {
+	tx := &sql.Tx{}
+	if got := NewAPITokenDAO(tx); !reflect.DeepEqual(got, &APITokenDAO{tx: tx}) {
+		t.Errorf("NewAPITokenDAO() = %v, want %v", got, &APITokenDAO{tx: tx})
+	}
+}
+
+func TestAPITokenDAO_Regist(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	expires := now.Add(time.Hour)
+	tx, mock := newMockTx(t)
+	mock.ExpectExec(apiTokenInsertQuery).
+		WithArgs("tid", "1", "ci-bot", "hash", "read,post", expires, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(apiTokenInsertQuery).
+		WithArgs("tid", "1", "ci-bot", "hash", "read,post", expires, now).
+		WillReturnError(errors.New("ng"))
+
+	token := model.NewAPIToken("tid", "1", "ci-bot", "hash", []string{"read", "post"}, expires, time.Time{}, now)
+	if err := NewAPITokenDAO(tx).Regist(token); err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	if err := NewAPITokenDAO(tx).Regist(token); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
+
+func TestAPITokenDAO_FindByHash(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	expires := now.Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		want    model.APIToken
+		wantErr error
+	}{
+		{
+			name:    "正常ケース",
+			rows:    sqlmock.NewRows(apiTokenColumnNames).AddRow("tid", "1", "ci-bot", "hash", "read,post", expires, nil, now),
+			want:    model.NewAPIToken("tid", "1", "ci-bot", "hash", []string{"read", "post"}, expires, time.Time{}, now),
+			wantErr: nil,
+		},
+		{
+			name:    "正常ケース(使用済み)",
+			rows:    sqlmock.NewRows(apiTokenColumnNames).AddRow("tid", "1", "ci-bot", "hash", "moderate", expires, now, now),
+			want:    model.NewAPIToken("tid", "1", "ci-bot", "hash", []string{"moderate"}, expires, now, now),
+			wantErr: nil,
+		},
+		{
+			name:    "異常ケース(トークンなし)",
+			rows:    sqlmock.NewRows(apiTokenColumnNames),
+			want:    nil,
+			wantErr: repository.ErrAPITokenNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, mock := newMockTx(t)
+			mock.ExpectQuery(apiTokenSelectQuery).WithArgs("hash").WillReturnRows(tt.rows)
+
+			got, err := NewAPITokenDAO(tx).FindByHash("hash")
+			if err != tt.wantErr {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPITokenDAO_FindActiveByUserID(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	expires := now.Add(time.Hour)
+	tx, mock := newMockTx(t)
+	mock.ExpectQuery(apiTokenSelectActiveQuery).
+		WithArgs("1", now).
+		WillReturnRows(sqlmock.NewRows(apiTokenColumnNames).
+			AddRow("t2", "1", "deploy", "hash2", "post", expires, now, now).
+			AddRow("t1", "1", "ci-bot", "hash1", "read", expires, nil, now)).
+		RowsWillBeClosed()
+	mock.ExpectQuery(apiTokenSelectActiveQuery).WithArgs("2", now).WillReturnError(errors.New("ng"))
+
+	got, err := NewAPITokenDAO(tx).FindActiveByUserID("1", now)
+	if err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	want := []model.APIToken{
+		model.NewAPIToken("t2", "1", "deploy", "hash2", []string{"post"}, expires, now, now),
+		model.NewAPIToken("t1", "1", "ci-bot", "hash1", []string{"read"}, expires, time.Time{}, now),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
+	}
+
+	if _, err := NewAPITokenDAO(tx).FindActiveByUserID("2", now); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
+
+func TestAPITokenDAO_Touch(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tx, mock := newMockTx(t)
+	mock.ExpectExec(apiTokenTouchQuery).WithArgs(now, "tid").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(apiTokenTouchQuery).WithArgs(now, "tid").WillReturnError(errors.New("ng"))
+
+	if err := NewAPITokenDAO(tx).Touch("tid", now); err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	if err := NewAPITokenDAO(tx).Touch("tid", now); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
+
+func TestAPITokenDAO_Delete(t *testing.T) {
+	tests := []struct {
+		name    string
+		result  sql.Result
+		wantErr error
+	}{
+		{name: "正常ケース", result: sqlmock.NewResult(0, 1), wantErr: nil},
+		{name: "異常ケース(トークンなし)", result: sqlmock.NewResult(0, 0), wantErr: repository.ErrAPITokenNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, mock := newMockTx(t)
+			mock.ExpectExec(apiTokenDeleteQuery).WithArgs("tid", "1").WillReturnResult(tt.result)
+
+			if err := NewAPITokenDAO(tx).Delete("1", "tid"); err != tt.wantErr {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAPITokenDAO_DeleteExpired(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tx, mock := newMockTx(t)
+	mock.ExpectExec(apiTokenDeleteExpiredQry).WithArgs("1", now).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(apiTokenDeleteExpiredQry).WithArgs("1", now).WillReturnError(errors.New("ng"))
+
+	if err := NewAPITokenDAO(tx).DeleteExpired("1", now); err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	if err := NewAPITokenDAO(tx).DeleteExpired("1", now); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
@@ -0,0 +1,117 @@
+package dao
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+
+	"github.com/pkg/errors"
+)
+
+// AttachmentDAO 添付ファイルDAO
+type AttachmentDAO struct {
+	tx *sql.Tx
+}
+
+var _ repository.Attachment = (*AttachmentDAO)(nil)
+
+// NewAttachmentDAO 添付ファイルDAOを生成する
+func NewAttachmentDAO(tx *sql.Tx) *AttachmentDAO {
+	return &AttachmentDAO{
+		tx: tx,
+	}
+}
+
+// FindByID IDを指定して添付ファイルを取得する
+func (a *AttachmentDAO) FindByID(id string) (model.Attachment, error) {
+	rows, err := a.tx.Query(`
+		select id, user_id, post_id, blob_key, thumbnail_key, content_type, size, width, height, created_at
+		from attachment where id = ?
+	`, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "FindByID error")
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, repository.ErrAttachmentNotFound
+	}
+
+	var (
+		attachmentID string
+		userID       string
+		postID       sql.NullString
+		blobKey      string
+		thumbnailKey string
+		contentType  string
+		size         int64
+		width        int
+		height       int
+		createdAt    time.Time
+	)
+	if err := rows.Scan(
+		&attachmentID,
+		&userID,
+		&postID,
+		&blobKey,
+		&thumbnailKey,
+		&contentType,
+		&size,
+		&width,
+		&height,
+		&createdAt,
+	); err != nil {
+		return nil, errors.Wrap(err, "FindByID error")
+	}
+
+	return model.NewAttachment(
+		attachmentID,
+		userID,
+		postID.String,
+		blobKey,
+		thumbnailKey,
+		contentType,
+		size,
+		width,
+		height,
+		createdAt,
+	), nil
+}
+
+// Regist 添付ファイルを登録し、採番したIDを返す
+func (a *AttachmentDAO) Regist(attachment model.Attachment, now time.Time) (string, error) {
+	stmt, err := a.tx.Prepare(`
+		insert into attachment (user_id, post_id, blob_key, thumbnail_key, content_type, size, width, height, created_at)
+		values(?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return "", errors.Wrap(err, "Regist error")
+	}
+	defer stmt.Close()
+
+	postID := sql.NullString{String: attachment.PostID(), Valid: attachment.PostID() != ""}
+	result, err := stmt.Exec(
+		attachment.UserID(),
+		postID,
+		attachment.BlobKey(),
+		attachment.ThumbnailKey(),
+		attachment.ContentType(),
+		attachment.Size(),
+		attachment.Width(),
+		attachment.Height(),
+		now,
+	)
+	if err != nil {
+		return "", errors.Wrap(err, "Regist error")
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return "", errors.Wrap(err, "Regist error")
+	}
+
+	return strconv.FormatInt(id, 10), nil
+}
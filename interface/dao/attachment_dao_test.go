@@ -0,0 +1,186 @@
+package dao
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+const (
+	attachmentSelectQuery = "select id, user_id, post_id, blob_key, thumbnail_key, content_type, size, width, height, created_at from attachment where id = ?"
+	attachmentInsertQuery = "insert into attachment (user_id, post_id, blob_key, thumbnail_key, content_type, size, width, height, created_at) values(?, ?, ?, ?, ?, ?, ?, ?, ?)"
+)
+
+var attachmentColumns = []string{"id", "user_id", "post_id", "blob_key", "thumbnail_key", "content_type", "size", "width", "height", "created_at"}
+
+func TestNewAttachmentDAO(t *testing.T) {
+	tx := &sql.Tx{}
+	if got := NewAttachmentDAO(tx); !reflect.DeepEqual(got, &AttachmentDAO{tx: tx}) {
+		t.Errorf("NewAttachmentDAO() = %v, want %v", got, &AttachmentDAO{tx: tx})
+	}
+}
+
+func TestAttachmentDAO_FindByIDSuccess(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("sqlmockの生成に失敗(error: %s)", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("txの生成に失敗(error: %s)", err)
+	}
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	mock.ExpectQuery(attachmentSelectQuery).
+		WithArgs("1").
+		WillReturnRows(sqlmock.NewRows(attachmentColumns).
+			AddRow("1", "2", nil, "a.png", "a-thumb.png", "image/png", 100, 20, 10, now)).
+		RowsWillBeClosed()
+
+	got, err := NewAttachmentDAO(tx).FindByID("1")
+	if err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+
+	want := model.NewAttachment("1", "2", "", "a.png", "a-thumb.png", "image/png", 100, 20, 10, now)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("予期せぬDB操作(error: %s)", err)
+	}
+}
+
+func TestAttachmentDAO_FindByIDNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("sqlmockの生成に失敗(error: %s)", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("txの生成に失敗(error: %s)", err)
+	}
+
+	mock.ExpectQuery(attachmentSelectQuery).
+		WithArgs("1").
+		WillReturnRows(sqlmock.NewRows(attachmentColumns)).
+		RowsWillBeClosed()
+
+	got, err := NewAttachmentDAO(tx).FindByID("1")
+	if err != repository.ErrAttachmentNotFound {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	if got != nil {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, nil)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("予期せぬDB操作(error: %s)", err)
+	}
+}
+
+func TestAttachmentDAO_FindByIDQueryFail(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("sqlmockの生成に失敗(error: %s)", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("txの生成に失敗(error: %s)", err)
+	}
+
+	mock.ExpectQuery(attachmentSelectQuery).
+		WithArgs("1").
+		WillReturnError(errors.New("ng"))
+
+	if _, err := NewAttachmentDAO(tx).FindByID("1"); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("予期せぬDB操作(error: %s)", err)
+	}
+}
+
+func TestAttachmentDAO_RegistSuccess(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("sqlmockの生成に失敗(error: %s)", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("txの生成に失敗(error: %s)", err)
+	}
+
+	now := time.Now()
+	mock.ExpectPrepare(attachmentInsertQuery).WillBeClosed()
+	mock.ExpectExec(attachmentInsertQuery).
+		WithArgs("2", sql.NullString{}, "a.png", "a-thumb.png", "image/png", int64(100), 20, 10, now).
+		WillReturnResult(sqlmock.NewResult(5, 1))
+
+	got, err := NewAttachmentDAO(tx).Regist(
+		model.NewAttachment("", "2", "", "a.png", "a-thumb.png", "image/png", 100, 20, 10, time.Time{}),
+		now,
+	)
+	if err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	if got != "5" {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, "5")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("予期せぬDB操作(error: %s)", err)
+	}
+}
+
+func TestAttachmentDAO_RegistFail(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("sqlmockの生成に失敗(error: %s)", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("txの生成に失敗(error: %s)", err)
+	}
+
+	mock.ExpectPrepare(attachmentInsertQuery).WillBeClosed()
+	mock.ExpectExec(attachmentInsertQuery).WillReturnError(errors.New("ng"))
+
+	got, err := NewAttachmentDAO(tx).Regist(
+		model.NewAttachment("", "2", "", "a.png", "a-thumb.png", "image/png", 100, 20, 10, time.Time{}),
+		time.Now(),
+	)
+	if err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+	if got != "" {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, "")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("予期せぬDB操作(error: %s)", err)
+	}
+}
@@ -0,0 +1,99 @@
+package dao
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+
+	"github.com/pkg/errors"
+)
+
+// BoardDAO 板DAO
+type BoardDAO struct {
+	tx *sql.Tx
+}
+
+var _ repository.Board = (*BoardDAO)(nil)
+
+// NewBoardDAO 板DAOを生成する
+func NewBoardDAO(tx *sql.Tx) *BoardDAO {
+	return &BoardDAO{
+		tx: tx,
+	}
+}
+
+// boardColumns 板取得時のカラム
+const boardColumns = "id, name, description, created_at"
+
+// FindAll 板を登録順に取得する
+func (b *BoardDAO) FindAll() ([]model.Board, error) {
+	rows, err := b.tx.Query("select " + boardColumns + " from board order by id")
+	if err != nil {
+		return nil, errors.Wrap(err, "FindAll error")
+	}
+	defer rows.Close()
+
+	boards := []model.Board{}
+	for rows.Next() {
+		board, err := scanBoard(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "FindAll error")
+		}
+		boards = append(boards, board)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "FindAll error")
+	}
+
+	return boards, nil
+}
+
+// FindByID IDで板を取得する
+func (b *BoardDAO) FindByID(id string) (model.Board, error) {
+	board, err := scanBoard(b.tx.QueryRow("select "+boardColumns+" from board where id = ?", id))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrBoardNotFound
+	} else if err != nil {
+		return nil, errors.Wrap(err, "FindByID error")
+	}
+
+	return board, nil
+}
+
+// Regist 板を登録し、採番したIDを返す
+func (b *BoardDAO) Regist(board model.Board, now time.Time) (string, error) {
+	result, err := b.tx.Exec(
+		"insert into board (name, description, created_at) values(?, ?, ?)",
+		board.Name(),
+		board.Description(),
+		now,
+	)
+	if err != nil {
+		return "", errors.Wrap(err, "Regist error")
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return "", errors.Wrap(err, "Regist error")
+	}
+
+	return strconv.FormatInt(id, 10), nil
+}
+
+// scanBoard 1行分の板を読み込む
+func scanBoard(row rowScanner) (model.Board, error) {
+	var (
+		id          string
+		name        string
+		description string
+		createdAt   time.Time
+	)
+	if err := row.Scan(&id, &name, &description, &createdAt); err != nil {
+		return nil, err
+	}
+
+	return model.NewBoard(id, name, description, createdAt), nil
+}
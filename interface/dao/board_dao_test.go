@@ -0,0 +1,112 @@
+package dao
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+const (
+	boardSelectAllQuery = "select id, name, description, created_at from board order by id"
+	boardSelectQuery    = "select id, name, description, created_at from board where id = ?"
+	boardInsertQuery    = "insert into board (name, description, created_at) values(?, ?, ?)"
+)
+
+var boardColumnNames = []string{"id", "name", "description", "created_at"}
+
+func TestNewBoardDAO(t *testing.T) {
+	tx := &sql.Tx{}
+	if got := NewBoardDAO(tx); !reflect.DeepEqual(got, &BoardDAO{tx: tx}) {
+		t.Errorf("NewBoardDAO() = %v, want %v", got, &BoardDAO{tx: tx})
+	}
+}
+
+func TestBoardDAO_FindAll(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tx, mock := newMockTx(t)
+	mock.ExpectQuery(boardSelectAllQuery).
+		WillReturnRows(sqlmock.NewRows(boardColumnNames).
+			AddRow("1", "雑談", "", now).
+			AddRow("2", "質問", "説明", now)).
+		RowsWillBeClosed()
+	mock.ExpectQuery(boardSelectAllQuery).WillReturnError(errors.New("ng"))
+
+	got, err := NewBoardDAO(tx).FindAll()
+	if err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	want := []model.Board{
+		model.NewBoard("1", "雑談", "", now),
+		model.NewBoard("2", "質問", "説明", now),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
+	}
+
+	if _, err := NewBoardDAO(tx).FindAll(); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
+
+func TestBoardDAO_FindByID(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		want    model.Board
+		wantErr error
+	}{
+		{
+			name:    "正常ケース",
+			rows:    sqlmock.NewRows(boardColumnNames).AddRow("1", "雑談", "説明", now),
+			want:    model.NewBoard("1", "雑談", "説明", now),
+			wantErr: nil,
+		},
+		{
+			name:    "異常ケース(板なし)",
+			rows:    sqlmock.NewRows(boardColumnNames),
+			want:    nil,
+			wantErr: repository.ErrBoardNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, mock := newMockTx(t)
+			mock.ExpectQuery(boardSelectQuery).WithArgs("1").WillReturnRows(tt.rows)
+
+			got, err := NewBoardDAO(tx).FindByID("1")
+			if err != tt.wantErr {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBoardDAO_Regist(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tx, mock := newMockTx(t)
+	mock.ExpectExec(boardInsertQuery).WithArgs("雑談", "説明", now).WillReturnResult(sqlmock.NewResult(3, 1))
+	mock.ExpectExec(boardInsertQuery).WithArgs("質問", "", now).WillReturnError(errors.New("ng"))
+
+	got, err := NewBoardDAO(tx).Regist(model.NewBoard("", "雑談", "説明", time.Time{}), now)
+	if err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	if got != "3" {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, "3")
+	}
+
+	if _, err := NewBoardDAO(tx).Regist(model.NewBoard("", "質問", "", time.Time{}), now); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
@@ -0,0 +1,235 @@
+package dao
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+	"GoBBS/domain/repository/repositorytest"
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql"
+)
+
+// testDSNEnv 契約テストで使用するMySQLのDSNを指定する環境変数
+// 例: user:password@tcp(localhost:3306)/bbs?parseTime=true
+const testDSNEnv = "GOBBS_TEST_DSN"
+
+// openTestDB 契約テスト用のDBを開く(未指定の場合はテストをスキップする)
+func openTestDB(t *testing.T) *sql.DB {
+	dsn := os.Getenv(testDSNEnv)
+	if dsn == "" {
+		t.Skipf("%sが未指定のためスキップ", testDSNEnv)
+	}
+
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		t.Fatalf("DBのオープンに失敗(error: %s)", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+// beginTestTx テストケースごとのトランザクションを開始し、終了時にロールバックする
+func beginTestTx(t *testing.T, db *sql.DB) *sql.Tx {
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("txの生成に失敗(error: %s)", err)
+	}
+	t.Cleanup(func() { tx.Rollback() })
+
+	return tx
+}
+
+// registTestUser 外部キー用のユーザーを登録し、IDを返す
+func registTestUser(t *testing.T, tx *sql.Tx, email string) string {
+	userDAO := NewUserDAO(tx)
+	if err := userDAO.Regist(model.NewUser("", "name", email, "password", ""), time.Now()); err != nil {
+		t.Fatalf("ユーザーの登録に失敗(error: %s)", err)
+	}
+	user, err := userDAO.FindByEmail(email)
+	if err != nil {
+		t.Fatalf("ユーザーの取得に失敗(error: %s)", err)
+	}
+
+	return user.ID()
+}
+
+// registTestBoard 外部キー用の板を登録し、IDを返す
+func registTestBoard(t *testing.T, tx *sql.Tx, name string) string {
+	id, err := NewBoardDAO(tx).Regist(model.NewBoard("", name, "", time.Time{}), time.Now())
+	if err != nil {
+		t.Fatalf("板の登録に失敗(error: %s)", err)
+	}
+
+	return id
+}
+
+// registTestThread 外部キー用のスレッドを登録し、IDを返す
+func registTestThread(t *testing.T, tx *sql.Tx, boardID string, userID string) string {
+	id, err := NewThreadDAO(tx).Regist(model.NewThread("", boardID, userID, "title", 0, time.Time{}, time.Time{}), time.Now())
+	if err != nil {
+		t.Fatalf("スレッドの登録に失敗(error: %s)", err)
+	}
+
+	return id
+}
+
+// registTestPost 外部キー用の投稿を登録し、IDを返す
+func registTestPost(t *testing.T, tx *sql.Tx, threadID string, userID string, number int) string {
+	id, err := NewPostDAO(tx).Regist(model.NewPost("", threadID, userID, number, "body", "<p>body</p>\n", time.Time{}, time.Time{}, time.Time{}, ""), time.Now())
+	if err != nil {
+		t.Fatalf("投稿の登録に失敗(error: %s)", err)
+	}
+
+	return id
+}
+
+func TestUserDAO_Contract(t *testing.T) {
+	db := openTestDB(t)
+
+	repositorytest.RunUserContract(t, func(t *testing.T) repository.User {
+		return NewUserDAO(beginTestTx(t, db))
+	})
+}
+
+func TestAttachmentDAO_Contract(t *testing.T) {
+	db := openTestDB(t)
+
+	repositorytest.RunAttachmentContract(t, func(t *testing.T) (repository.Attachment, string) {
+		tx := beginTestTx(t, db)
+		return NewAttachmentDAO(tx), registTestUser(t, tx, "contract@example.com")
+	})
+}
+
+func TestNotificationDAO_Contract(t *testing.T) {
+	db := openTestDB(t)
+
+	repositorytest.RunNotificationContract(t, func(t *testing.T) (repository.Notification, string, string) {
+		tx := beginTestTx(t, db)
+		return NewNotificationDAO(tx),
+			registTestUser(t, tx, "contract@example.com"),
+			registTestUser(t, tx, "contract-actor@example.com")
+	})
+}
+
+func TestDigestDAO_Contract(t *testing.T) {
+	db := openTestDB(t)
+
+	repositorytest.RunDigestContract(t, func(t *testing.T) (repository.Digest, string, string) {
+		tx := beginTestTx(t, db)
+		return NewDigestDAO(tx),
+			registTestUser(t, tx, "contract@example.com"),
+			registTestUser(t, tx, "contract-other@example.com")
+	})
+}
+
+func TestWebhookDAO_Contract(t *testing.T) {
+	db := openTestDB(t)
+
+	repositorytest.RunWebhookContract(t, func(t *testing.T) repository.Webhook {
+		return NewWebhookDAO(beginTestTx(t, db))
+	})
+}
+
+func TestEventDAO_Contract(t *testing.T) {
+	db := openTestDB(t)
+
+	repositorytest.RunEventContract(t, func(t *testing.T) repository.Event {
+		return NewEventDAO(beginTestTx(t, db))
+	})
+}
+
+func TestSessionDAO_Contract(t *testing.T) {
+	db := openTestDB(t)
+
+	repositorytest.RunSessionContract(t, func(t *testing.T) (repository.Session, string, string) {
+		tx := beginTestTx(t, db)
+		return NewSessionDAO(tx),
+			registTestUser(t, tx, "contract@example.com"),
+			registTestUser(t, tx, "contract-other@example.com")
+	})
+}
+
+func TestMFADAO_Contract(t *testing.T) {
+	db := openTestDB(t)
+
+	repositorytest.RunMFAContract(t, func(t *testing.T) (repository.MFA, string, string) {
+		tx := beginTestTx(t, db)
+		return NewMFADAO(tx),
+			registTestUser(t, tx, "contract@example.com"),
+			registTestUser(t, tx, "contract-other@example.com")
+	})
+}
+
+func TestUserIdentityDAO_Contract(t *testing.T) {
+	db := openTestDB(t)
+
+	repositorytest.RunUserIdentityContract(t, func(t *testing.T) (repository.UserIdentity, string, string) {
+		tx := beginTestTx(t, db)
+		return NewUserIdentityDAO(tx),
+			registTestUser(t, tx, "contract@example.com"),
+			registTestUser(t, tx, "contract-other@example.com")
+	})
+}
+
+func TestAPITokenDAO_Contract(t *testing.T) {
+	db := openTestDB(t)
+
+	repositorytest.RunAPITokenContract(t, func(t *testing.T) (repository.APIToken, string, string) {
+		tx := beginTestTx(t, db)
+		return NewAPITokenDAO(tx),
+			registTestUser(t, tx, "contract@example.com"),
+			registTestUser(t, tx, "contract-other@example.com")
+	})
+}
+
+func TestBoardDAO_Contract(t *testing.T) {
+	db := openTestDB(t)
+
+	repositorytest.RunBoardContract(t, func(t *testing.T) repository.Board {
+		return NewBoardDAO(beginTestTx(t, db))
+	})
+}
+
+func TestThreadDAO_Contract(t *testing.T) {
+	db := openTestDB(t)
+
+	repositorytest.RunThreadContract(t, func(t *testing.T) (repository.Thread, string, string, string) {
+		tx := beginTestTx(t, db)
+		return NewThreadDAO(tx),
+			registTestBoard(t, tx, "contract"),
+			registTestBoard(t, tx, "contract-other"),
+			registTestUser(t, tx, "contract@example.com")
+	})
+}
+
+func TestPostDAO_Contract(t *testing.T) {
+	db := openTestDB(t)
+
+	repositorytest.RunPostContract(t, func(t *testing.T) (repository.Post, string, string, string) {
+		tx := beginTestTx(t, db)
+		userID := registTestUser(t, tx, "contract@example.com")
+		boardID := registTestBoard(t, tx, "contract")
+		return NewPostDAO(tx),
+			registTestThread(t, tx, boardID, userID),
+			registTestThread(t, tx, boardID, userID),
+			userID
+	})
+}
+
+func TestPostRevisionDAO_Contract(t *testing.T) {
+	db := openTestDB(t)
+
+	repositorytest.RunPostRevisionContract(t, func(t *testing.T) (repository.PostRevision, string, string, string) {
+		tx := beginTestTx(t, db)
+		userID := registTestUser(t, tx, "contract@example.com")
+		threadID := registTestThread(t, tx, registTestBoard(t, tx, "contract"), userID)
+		return NewPostRevisionDAO(tx),
+			registTestPost(t, tx, threadID, userID, 1),
+			registTestPost(t, tx, threadID, userID, 2),
+			userID
+	})
+}
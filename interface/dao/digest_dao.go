@@ -0,0 +1,218 @@
+package dao
+
+import (
+	"database/sql"
+	"time"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+
+	"github.com/pkg/errors"
+)
+
+// DigestDAO ウォッチ・ダイジェストDAO
+type DigestDAO struct {
+	tx *sql.Tx
+}
+
+var _ repository.Digest = (*DigestDAO)(nil)
+
+// NewDigestDAO ウォッチ・ダイジェストDAOを生成する
+func NewDigestDAO(tx *sql.Tx) *DigestDAO {
+	return &DigestDAO{
+		tx: tx,
+	}
+}
+
+// RegistWatch ウォッチを登録する(登録済みの場合は何もしない)
+func (d *DigestDAO) RegistWatch(watch model.Watch) error {
+	if _, err := d.tx.Exec(
+		"insert ignore into watch (user_id, kind, target_id, created_at) values(?, ?, ?, ?)",
+		watch.UserID(),
+		string(watch.Kind()),
+		watch.TargetID(),
+		watch.CreatedAt(),
+	); err != nil {
+		return errors.Wrap(err, "RegistWatch error")
+	}
+
+	return nil
+}
+
+// DeleteWatch ウォッチを削除する
+func (d *DigestDAO) DeleteWatch(userID string, kind model.WatchKind, targetID string) error {
+	result, err := d.tx.Exec(
+		"delete from watch where user_id = ? and kind = ? and target_id = ?",
+		userID,
+		string(kind),
+		targetID,
+	)
+	if err != nil {
+		return errors.Wrap(err, "DeleteWatch error")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "DeleteWatch error")
+	}
+	if affected == 0 {
+		return repository.ErrWatchNotFound
+	}
+
+	return nil
+}
+
+// FindWatchesByUserID ユーザーのウォッチを登録順に取得する
+func (d *DigestDAO) FindWatchesByUserID(userID string) ([]model.Watch, error) {
+	rows, err := d.tx.Query(
+		"select user_id, kind, target_id, created_at from watch where user_id = ? order by created_at, kind, target_id",
+		userID,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "FindWatchesByUserID error")
+	}
+	defer rows.Close()
+
+	watches := []model.Watch{}
+	for rows.Next() {
+		var (
+			ownerID   string
+			kind      string
+			targetID  string
+			createdAt time.Time
+		)
+		if err := rows.Scan(&ownerID, &kind, &targetID, &createdAt); err != nil {
+			return nil, errors.Wrap(err, "FindWatchesByUserID error")
+		}
+		watches = append(watches, model.NewWatch(ownerID, model.WatchKind(kind), targetID, createdAt))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "FindWatchesByUserID error")
+	}
+
+	return watches, nil
+}
+
+// FindSubscription 配信設定を取得する
+func (d *DigestDAO) FindSubscription(userID string) (model.DigestSubscription, error) {
+	var (
+		subscription model.DigestSubscription
+		frequency    string
+	)
+	err := d.tx.QueryRow(
+		"select frequency, locale, unsubscribe_token from digest_subscription where user_id = ?",
+		userID,
+	).Scan(&frequency, &subscription.Locale, &subscription.UnsubscribeToken)
+	if err == sql.ErrNoRows {
+		return model.DigestSubscription{}, repository.ErrDigestSubscriptionNotFound
+	} else if err != nil {
+		return model.DigestSubscription{}, errors.Wrap(err, "FindSubscription error")
+	}
+	subscription.Frequency = model.DigestFrequency(frequency)
+
+	return subscription, nil
+}
+
+// FindSubscriptionByToken 配信停止トークンから配信設定とユーザーのIDを取得する
+func (d *DigestDAO) FindSubscriptionByToken(token string) (string, model.DigestSubscription, error) {
+	var (
+		userID       string
+		subscription model.DigestSubscription
+		frequency    string
+	)
+	err := d.tx.QueryRow(
+		"select user_id, frequency, locale, unsubscribe_token from digest_subscription where unsubscribe_token = ?",
+		token,
+	).Scan(&userID, &frequency, &subscription.Locale, &subscription.UnsubscribeToken)
+	if err == sql.ErrNoRows {
+		return "", model.DigestSubscription{}, repository.ErrDigestSubscriptionNotFound
+	} else if err != nil {
+		return "", model.DigestSubscription{}, errors.Wrap(err, "FindSubscriptionByToken error")
+	}
+	subscription.Frequency = model.DigestFrequency(frequency)
+
+	return userID, subscription, nil
+}
+
+// SaveSubscription 配信設定を保存する
+func (d *DigestDAO) SaveSubscription(userID string, subscription model.DigestSubscription, now time.Time) error {
+	if _, err := d.tx.Exec(`
+		insert into digest_subscription (user_id, frequency, locale, unsubscribe_token, updated_at)
+		values(?, ?, ?, ?, ?)
+		on duplicate key update frequency = values(frequency), locale = values(locale), unsubscribe_token = values(unsubscribe_token), updated_at = values(updated_at)
+	`,
+		userID,
+		string(subscription.Frequency),
+		subscription.Locale,
+		subscription.UnsubscribeToken,
+		now,
+	); err != nil {
+		return errors.Wrap(err, "SaveSubscription error")
+	}
+
+	return nil
+}
+
+// FindDueUserIDs 配信頻度が一致し、集計期間のダイジェストが未送信のユーザーのIDを取得する
+func (d *DigestDAO) FindDueUserIDs(frequency model.DigestFrequency, periodKey string) ([]string, error) {
+	rows, err := d.tx.Query(`
+		select s.user_id from digest_subscription s
+		left join digest_delivery d on d.user_id = s.user_id and d.period_key = ?
+		where s.frequency = ? and d.user_id is null
+		order by s.user_id
+	`,
+		periodKey,
+		string(frequency),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "FindDueUserIDs error")
+	}
+	defer rows.Close()
+
+	userIDs := []string{}
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, errors.Wrap(err, "FindDueUserIDs error")
+		}
+		userIDs = append(userIDs, userID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "FindDueUserIDs error")
+	}
+
+	return userIDs, nil
+}
+
+// RegistDelivery 送信記録を登録する(登録済みの場合はfalse)
+func (d *DigestDAO) RegistDelivery(userID string, periodKey string, now time.Time) (bool, error) {
+	result, err := d.tx.Exec(
+		"insert ignore into digest_delivery (user_id, period_key, sent_at) values(?, ?, ?)",
+		userID,
+		periodKey,
+		now,
+	)
+	if err != nil {
+		return false, errors.Wrap(err, "RegistDelivery error")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "RegistDelivery error")
+	}
+
+	return affected == 1, nil
+}
+
+// DeleteDelivery 送信記録を削除する
+func (d *DigestDAO) DeleteDelivery(userID string, periodKey string) error {
+	if _, err := d.tx.Exec(
+		"delete from digest_delivery where user_id = ? and period_key = ?",
+		userID,
+		periodKey,
+	); err != nil {
+		return errors.Wrap(err, "DeleteDelivery error")
+	}
+
+	return nil
+}
@@ -0,0 +1,237 @@
+package dao
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+const (
+	watchInsertQuery        = "insert ignore into watch (user_id, kind, target_id, created_at) values(?, ?, ?, ?)"
+	watchDeleteQuery        = "delete from watch where user_id = ? and kind = ? and target_id = ?"
+	watchSelectQuery        = "select user_id, kind, target_id, created_at from watch where user_id = ? order by created_at, kind, target_id"
+	subscriptionSelectQuery = "select frequency, locale, unsubscribe_token from digest_subscription where user_id = ?"
+	subscriptionTokenQuery  = "select user_id, frequency, locale, unsubscribe_token from digest_subscription where unsubscribe_token = ?"
+	subscriptionUpsertQuery = "insert into digest_subscription (user_id, frequency, locale, unsubscribe_token, updated_at) values(?, ?, ?, ?, ?) on duplicate key update frequency = values(frequency), locale = values(locale), unsubscribe_token = values(unsubscribe_token), updated_at = values(updated_at)"
+	digestDueQuery          = "select s.user_id from digest_subscription s left join digest_delivery d on d.user_id = s.user_id and d.period_key = ? where s.frequency = ? and d.user_id is null order by s.user_id"
+	deliveryInsertQuery     = "insert ignore into digest_delivery (user_id, period_key, sent_at) values(?, ?, ?)"
+	deliveryDeleteQuery     = "delete from digest_delivery where user_id = ? and period_key = ?"
+)
+
+func TestNewDigestDAO(t *testing.T) {
+	tx := &sql.Tx{}
+	if got := NewDigestDAO(tx); !reflect.DeepEqual(got, &DigestDAO{tx: tx}) {
+		t.Errorf("NewDigestDAO() = %v, want %v", got, &DigestDAO{tx: tx})
+	}
+}
+
+func TestDigestDAO_RegistWatch(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tx, mock := newMockTx(t)
+	mock.ExpectExec(watchInsertQuery).
+		WithArgs("1", "thread", "10", now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(watchInsertQuery).
+		WithArgs("1", "board", "2", now).
+		WillReturnError(errors.New("ng"))
+
+	if err := NewDigestDAO(tx).RegistWatch(model.NewWatch("1", model.WatchThread, "10", now)); err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	if err := NewDigestDAO(tx).RegistWatch(model.NewWatch("1", model.WatchBoard, "2", now)); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
+
+func TestDigestDAO_DeleteWatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		result  sql.Result
+		wantErr error
+	}{
+		{name: "正常ケース", result: sqlmock.NewResult(0, 1), wantErr: nil},
+		{name: "異常ケース(ウォッチなし)", result: sqlmock.NewResult(0, 0), wantErr: repository.ErrWatchNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, mock := newMockTx(t)
+			mock.ExpectExec(watchDeleteQuery).WithArgs("1", "thread", "10").WillReturnResult(tt.result)
+
+			if err := NewDigestDAO(tx).DeleteWatch("1", model.WatchThread, "10"); err != tt.wantErr {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDigestDAO_FindWatchesByUserID(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tx, mock := newMockTx(t)
+	mock.ExpectQuery(watchSelectQuery).
+		WithArgs("1").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "kind", "target_id", "created_at"}).
+			AddRow("1", "board", "2", now).
+			AddRow("1", "thread", "10", now)).
+		RowsWillBeClosed()
+	mock.ExpectQuery(watchSelectQuery).WithArgs("2").WillReturnError(errors.New("ng"))
+
+	got, err := NewDigestDAO(tx).FindWatchesByUserID("1")
+	if err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	want := []model.Watch{
+		model.NewWatch("1", model.WatchBoard, "2", now),
+		model.NewWatch("1", model.WatchThread, "10", now),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
+	}
+
+	if _, err := NewDigestDAO(tx).FindWatchesByUserID("2"); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
+
+func TestDigestDAO_FindSubscription(t *testing.T) {
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		want    model.DigestSubscription
+		wantErr error
+	}{
+		{
+			name:    "正常ケース",
+			rows:    sqlmock.NewRows([]string{"frequency", "locale", "unsubscribe_token"}).AddRow("daily", "en", "token"),
+			want:    model.DigestSubscription{Frequency: model.DigestDaily, Locale: "en", UnsubscribeToken: "token"},
+			wantErr: nil,
+		},
+		{
+			name:    "異常ケース(未設定)",
+			rows:    sqlmock.NewRows([]string{"frequency", "locale", "unsubscribe_token"}),
+			want:    model.DigestSubscription{},
+			wantErr: repository.ErrDigestSubscriptionNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, mock := newMockTx(t)
+			mock.ExpectQuery(subscriptionSelectQuery).WithArgs("1").WillReturnRows(tt.rows)
+
+			got, err := NewDigestDAO(tx).FindSubscription("1")
+			if err != tt.wantErr {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDigestDAO_FindSubscriptionByToken(t *testing.T) {
+	tx, mock := newMockTx(t)
+	mock.ExpectQuery(subscriptionTokenQuery).
+		WithArgs("token").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "frequency", "locale", "unsubscribe_token"}).AddRow("1", "weekly", "ja", "token"))
+	mock.ExpectQuery(subscriptionTokenQuery).
+		WithArgs("unknown").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "frequency", "locale", "unsubscribe_token"}))
+
+	userID, got, err := NewDigestDAO(tx).FindSubscriptionByToken("token")
+	if err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	want := model.DigestSubscription{Frequency: model.DigestWeekly, Locale: "ja", UnsubscribeToken: "token"}
+	if userID != "1" || got != want {
+		t.Errorf("戻り値不一致 got: %#v, %#v want: %#v, %#v", userID, got, "1", want)
+	}
+
+	if _, _, err := NewDigestDAO(tx).FindSubscriptionByToken("unknown"); err != repository.ErrDigestSubscriptionNotFound {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", err, repository.ErrDigestSubscriptionNotFound)
+	}
+}
+
+func TestDigestDAO_SaveSubscription(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tx, mock := newMockTx(t)
+	mock.ExpectExec(subscriptionUpsertQuery).
+		WithArgs("1", "daily", "ja", "token", now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := NewDigestDAO(tx).SaveSubscription("1", model.DigestSubscription{Frequency: model.DigestDaily, Locale: "ja", UnsubscribeToken: "token"}, now); err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+}
+
+func TestDigestDAO_FindDueUserIDs(t *testing.T) {
+	tx, mock := newMockTx(t)
+	mock.ExpectQuery(digestDueQuery).
+		WithArgs("2023-01-02", "daily").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow("1").AddRow("3")).
+		RowsWillBeClosed()
+	mock.ExpectQuery(digestDueQuery).
+		WithArgs("2023-W01", "weekly").
+		WillReturnError(errors.New("ng"))
+
+	got, err := NewDigestDAO(tx).FindDueUserIDs(model.DigestDaily, "2023-01-02")
+	if err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	if want := []string{"1", "3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
+	}
+
+	if _, err := NewDigestDAO(tx).FindDueUserIDs(model.DigestWeekly, "2023-W01"); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
+
+func TestDigestDAO_RegistDelivery(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		result sql.Result
+		want   bool
+	}{
+		{name: "正常ケース(未送信)", result: sqlmock.NewResult(0, 1), want: true},
+		{name: "正常ケース(送信済み)", result: sqlmock.NewResult(0, 0), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, mock := newMockTx(t)
+			mock.ExpectExec(deliveryInsertQuery).WithArgs("1", "2023-01-02", now).WillReturnResult(tt.result)
+
+			got, err := NewDigestDAO(tx).RegistDelivery("1", "2023-01-02", now)
+			if err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+			if got != tt.want {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDigestDAO_DeleteDelivery(t *testing.T) {
+	tx, mock := newMockTx(t)
+	mock.ExpectExec(deliveryDeleteQuery).
+		WithArgs("1", "2023-01-02").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(deliveryDeleteQuery).
+		WithArgs("1", "2023-01-03").
+		WillReturnError(errors.New("ng"))
+
+	if err := NewDigestDAO(tx).DeleteDelivery("1", "2023-01-02"); err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	if err := NewDigestDAO(tx).DeleteDelivery("1", "2023-01-03"); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
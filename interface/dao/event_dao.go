@@ -0,0 +1,132 @@
+package dao
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+
+	"github.com/pkg/errors"
+)
+
+// EventDAO ドメインイベントのアウトボックスDAO
+type EventDAO struct {
+	tx *sql.Tx
+}
+
+var _ repository.Event = (*EventDAO)(nil)
+
+// NewEventDAO ドメインイベントのアウトボックスDAOを生成する
+func NewEventDAO(tx *sql.Tx) *EventDAO {
+	return &EventDAO{
+		tx: tx,
+	}
+}
+
+// eventColumns イベント取得時のカラム
+const eventColumns = "id, type, aggregate_id, payload, attempts, next_attempt_at, last_error, occurred_at, dispatched_at"
+
+// Regist イベントをアウトボックスに登録し、採番したIDを返す
+func (e *EventDAO) Regist(event model.Event) (string, error) {
+	result, err := e.tx.Exec(`
+		insert into event_outbox (type, aggregate_id, payload, attempts, next_attempt_at, occurred_at)
+		values(?, ?, ?, ?, ?, ?)
+	`,
+		string(event.Type()),
+		event.AggregateID(),
+		event.Payload(),
+		event.Attempts(),
+		event.NextAttemptAt(),
+		event.OccurredAt(),
+	)
+	if err != nil {
+		return "", errors.Wrap(err, "Regist error")
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return "", errors.Wrap(err, "Regist error")
+	}
+
+	return strconv.FormatInt(id, 10), nil
+}
+
+// FindDue 配信時刻に達した未配信のイベントを取得する
+// 複数のディスパッチャーが同じイベントを取得しないよう、他のtxがロック中の行は読み飛ばす
+func (e *EventDAO) FindDue(now time.Time, limit int) ([]model.Event, error) {
+	rows, err := e.tx.Query(`
+		select `+eventColumns+` from event_outbox
+		where dispatched_at is null and next_attempt_at <= ?
+		order by next_attempt_at, id limit ?
+		for update skip locked
+	`,
+		now,
+		limit,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "FindDue error")
+	}
+	defer rows.Close()
+
+	events := []model.Event{}
+	for rows.Next() {
+		var (
+			id            string
+			eventType     string
+			aggregateID   string
+			payload       []byte
+			attempts      int
+			nextAttemptAt time.Time
+			lastError     sql.NullString
+			occurredAt    time.Time
+			dispatchedAt  sql.NullTime
+		)
+		if err := rows.Scan(
+			&id,
+			&eventType,
+			&aggregateID,
+			&payload,
+			&attempts,
+			&nextAttemptAt,
+			&lastError,
+			&occurredAt,
+			&dispatchedAt,
+		); err != nil {
+			return nil, errors.Wrap(err, "FindDue error")
+		}
+		events = append(events, model.NewEvent(
+			id,
+			model.EventType(eventType),
+			aggregateID,
+			payload,
+			attempts,
+			nextAttemptAt,
+			lastError.String,
+			occurredAt,
+			dispatchedAt.Time,
+		))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "FindDue error")
+	}
+
+	return events, nil
+}
+
+// Update イベントの配信状態を更新する
+func (e *EventDAO) Update(event model.Event) error {
+	if _, err := e.tx.Exec(
+		"update event_outbox set attempts = ?, next_attempt_at = ?, last_error = ?, dispatched_at = ? where id = ?",
+		event.Attempts(),
+		event.NextAttemptAt(),
+		sql.NullString{String: event.LastError(), Valid: event.LastError() != ""},
+		sql.NullTime{Time: event.DispatchedAt(), Valid: event.IsDispatched()},
+		event.ID(),
+	); err != nil {
+		return errors.Wrap(err, "Update error")
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package dao
+
+import (
+	"GoBBS/domain/model"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+const (
+	eventInsertQuery = "insert into event_outbox (type, aggregate_id, payload, attempts, next_attempt_at, occurred_at) values(?, ?, ?, ?, ?, ?)"
+	eventDueQuery    = "select id, type, aggregate_id, payload, attempts, next_attempt_at, last_error, occurred_at, dispatched_at from event_outbox where dispatched_at is null and next_attempt_at <= ? order by next_attempt_at, id limit ? for update skip locked"
+	eventUpdateQuery = "update event_outbox set attempts = ?, next_attempt_at = ?, last_error = ?, dispatched_at = ? where id = ?"
+)
+
+func TestNewEventDAO(t *testing.T) {
+	tx := &sql.Tx{}
+	if got := NewEventDAO(tx); !reflect.DeepEqual(got, &EventDAO{tx: tx}) {
+		t.Errorf("NewEventDAO() = %v, want %v", got, &EventDAO{tx: tx})
+	}
+}
+
+func TestEventDAO_Regist(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tx, mock := newMockTx(t)
+	mock.ExpectExec(eventInsertQuery).
+		WithArgs("user.registered", "1", []byte(`{"user_id":"1"}`), 0, now, now).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+	mock.ExpectExec(eventInsertQuery).
+		WithArgs("post.created", "2", []byte(`{}`), 0, now, now).
+		WillReturnError(errors.New("ng"))
+
+	got, err := NewEventDAO(tx).Regist(model.NewEvent("", model.EventUserRegistered, "1", []byte(`{"user_id":"1"}`), 0, now, "", now, time.Time{}))
+	if err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	if got != "3" {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, "3")
+	}
+
+	if _, err := NewEventDAO(tx).Regist(model.NewEvent("", model.EventPostCreated, "2", []byte(`{}`), 0, now, "", now, time.Time{})); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
+
+func TestEventDAO_FindDue(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tx, mock := newMockTx(t)
+	mock.ExpectQuery(eventDueQuery).
+		WithArgs(now, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "type", "aggregate_id", "payload", "attempts", "next_attempt_at", "last_error", "occurred_at", "dispatched_at"}).
+			AddRow("1", "user.registered", "5", []byte("{}"), 0, now, nil, now, nil).
+			AddRow("2", "post.hidden", "7", []byte("{}"), 3, now, "handler error", now, nil)).
+		RowsWillBeClosed()
+	mock.ExpectQuery(eventDueQuery).WithArgs(now, 20).WillReturnError(errors.New("ng"))
+
+	got, err := NewEventDAO(tx).FindDue(now, 10)
+	if err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	want := []model.Event{
+		model.NewEvent("1", model.EventUserRegistered, "5", []byte("{}"), 0, now, "", now, time.Time{}),
+		model.NewEvent("2", model.EventPostHidden, "7", []byte("{}"), 3, now, "handler error", now, time.Time{}),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
+	}
+
+	if _, err := NewEventDAO(tx).FindDue(now, 20); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
+
+func TestEventDAO_Update(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	next := now.Add(time.Minute)
+	tx, mock := newMockTx(t)
+	mock.ExpectExec(eventUpdateQuery).
+		WithArgs(1, now, nil, now, "1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(eventUpdateQuery).
+		WithArgs(2, next, "handler error", nil, "2").
+		WillReturnError(errors.New("ng"))
+
+	if err := NewEventDAO(tx).Update(model.NewEvent("1", model.EventUserRegistered, "5", nil, 1, now, "", now, now)); err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	if err := NewEventDAO(tx).Update(model.NewEvent("2", model.EventUserRegistered, "5", nil, 2, next, "handler error", now, time.Time{})); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
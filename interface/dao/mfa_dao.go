@@ -0,0 +1,220 @@
+package dao
+
+import (
+	"database/sql"
+	"time"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+
+	"github.com/pkg/errors"
+)
+
+// MFADAO MFADAO
+type MFADAO struct {
+	tx *sql.Tx
+}
+
+var _ repository.MFA = (*MFADAO)(nil)
+
+// NewMFADAO MFADAOを生成する
+func NewMFADAO(tx *sql.Tx) *MFADAO {
+	return &MFADAO{
+		tx: tx,
+	}
+}
+
+// FindByUserID ユーザーの2要素認証の設定を取得する
+func (m *MFADAO) FindByUserID(userID string) (model.MFA, error) {
+	var (
+		secret       string
+		confirmedAt  sql.NullTime
+		lastUsedStep int64
+		createdAt    time.Time
+	)
+	err := m.tx.QueryRow(
+		"select secret, confirmed_at, last_used_step, created_at from user_mfa where user_id = ?",
+		userID,
+	).Scan(&secret, &confirmedAt, &lastUsedStep, &createdAt)
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrMFANotFound
+	} else if err != nil {
+		return nil, errors.Wrap(err, "FindByUserID error")
+	}
+
+	return model.NewMFA(userID, secret, confirmedAt.Time, lastUsedStep, createdAt), nil
+}
+
+// Save 2要素認証の設定を登録または更新する
+func (m *MFADAO) Save(mfa model.MFA) error {
+	if _, err := m.tx.Exec(`
+		insert into user_mfa (user_id, secret, confirmed_at, last_used_step, created_at)
+		values(?, ?, ?, ?, ?)
+		on duplicate key update secret = values(secret), confirmed_at = values(confirmed_at), last_used_step = values(last_used_step), created_at = values(created_at)
+	`,
+		mfa.UserID(),
+		mfa.Secret(),
+		sql.NullTime{Time: mfa.ConfirmedAt(), Valid: mfa.IsEnabled()},
+		mfa.LastUsedStep(),
+		mfa.CreatedAt(),
+	); err != nil {
+		return errors.Wrap(err, "Save error")
+	}
+
+	return nil
+}
+
+// UpdateLastUsedStep 最後に使われた確認コードのステップを更新する
+// 同じか古いステップで更新しようとした場合(同じコードを並行して使った場合を含む)はErrMFACodeUsedを返す
+func (m *MFADAO) UpdateLastUsedStep(userID string, step int64) error {
+	result, err := m.tx.Exec(
+		"update user_mfa set last_used_step = ? where user_id = ? and last_used_step < ?",
+		step,
+		userID,
+		step,
+	)
+	if err != nil {
+		return errors.Wrap(err, "UpdateLastUsedStep error")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "UpdateLastUsedStep error")
+	}
+	if affected == 0 {
+		return repository.ErrMFACodeUsed
+	}
+
+	return nil
+}
+
+// Delete 2要素認証の設定とリカバリーコードを削除する
+func (m *MFADAO) Delete(userID string) error {
+	if _, err := m.tx.Exec("delete from user_mfa_recovery_code where user_id = ?", userID); err != nil {
+		return errors.Wrap(err, "Delete error")
+	}
+	if _, err := m.tx.Exec("delete from user_mfa where user_id = ?", userID); err != nil {
+		return errors.Wrap(err, "Delete error")
+	}
+
+	return nil
+}
+
+// ReplaceRecoveryCodes ユーザーのリカバリーコード(ハッシュ)を入れ替える
+func (m *MFADAO) ReplaceRecoveryCodes(userID string, hashes []string) error {
+	if _, err := m.tx.Exec("delete from user_mfa_recovery_code where user_id = ?", userID); err != nil {
+		return errors.Wrap(err, "ReplaceRecoveryCodes error")
+	}
+	for _, hash := range hashes {
+		if _, err := m.tx.Exec("insert into user_mfa_recovery_code (user_id, code_hash) values(?, ?)", userID, hash); err != nil {
+			return errors.Wrap(err, "ReplaceRecoveryCodes error")
+		}
+	}
+
+	return nil
+}
+
+// UseRecoveryCode 未使用のリカバリーコードを使用済みにする
+func (m *MFADAO) UseRecoveryCode(userID string, hash string, now time.Time) error {
+	result, err := m.tx.Exec(
+		"update user_mfa_recovery_code set used_at = ? where user_id = ? and code_hash = ? and used_at is null",
+		now,
+		userID,
+		hash,
+	)
+	if err != nil {
+		return errors.Wrap(err, "UseRecoveryCode error")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "UseRecoveryCode error")
+	}
+	if affected == 0 {
+		return repository.ErrRecoveryCodeNotFound
+	}
+
+	return nil
+}
+
+// RegistChallenge チャレンジを登録する
+func (m *MFADAO) RegistChallenge(challenge model.MFAChallenge) error {
+	if _, err := m.tx.Exec(`
+		insert into mfa_challenge (id, user_id, attempts, expires_at, created_at)
+		values(?, ?, ?, ?, ?)
+	`,
+		challenge.ID(),
+		challenge.UserID(),
+		challenge.Attempts(),
+		challenge.ExpiresAt(),
+		challenge.CreatedAt(),
+	); err != nil {
+		return errors.Wrap(err, "RegistChallenge error")
+	}
+
+	return nil
+}
+
+// FindChallenge IDからチャレンジを取得する
+func (m *MFADAO) FindChallenge(id string) (model.MFAChallenge, error) {
+	var (
+		userID    string
+		attempts  int
+		expiresAt time.Time
+		createdAt time.Time
+	)
+	err := m.tx.QueryRow(
+		"select user_id, attempts, expires_at, created_at from mfa_challenge where id = ?",
+		id,
+	).Scan(&userID, &attempts, &expiresAt, &createdAt)
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrMFAChallengeNotFound
+	} else if err != nil {
+		return nil, errors.Wrap(err, "FindChallenge error")
+	}
+
+	return model.NewMFAChallenge(id, userID, attempts, expiresAt, createdAt), nil
+}
+
+// ConsumeChallengeAttempt 有効なチャレンジの試行回数を1増やす
+// 試行回数が上限に達した、または有効期限切れのチャレンジはErrMFAChallengeNotFoundを返す
+// 条件付きの更新で行をロックするため、並行したリクエストも上限を超えて試行できない
+func (m *MFADAO) ConsumeChallengeAttempt(id string, now time.Time) error {
+	result, err := m.tx.Exec(
+		"update mfa_challenge set attempts = attempts + 1 where id = ? and attempts < ? and expires_at > ?",
+		id,
+		model.MaxMFAChallengeAttempts,
+		now,
+	)
+	if err != nil {
+		return errors.Wrap(err, "ConsumeChallengeAttempt error")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "ConsumeChallengeAttempt error")
+	}
+	if affected == 0 {
+		return repository.ErrMFAChallengeNotFound
+	}
+
+	return nil
+}
+
+// DeleteChallenge チャレンジを削除する
+func (m *MFADAO) DeleteChallenge(id string) error {
+	if _, err := m.tx.Exec("delete from mfa_challenge where id = ?", id); err != nil {
+		return errors.Wrap(err, "DeleteChallenge error")
+	}
+
+	return nil
+}
+
+// DeleteExpiredChallenges ユーザーの有効期限切れのチャレンジを削除する
+func (m *MFADAO) DeleteExpiredChallenges(userID string, now time.Time) error {
+	if _, err := m.tx.Exec("delete from mfa_challenge where user_id = ? and expires_at <= ?", userID, now); err != nil {
+		return errors.Wrap(err, "DeleteExpiredChallenges error")
+	}
+
+	return nil
+}
@@ -0,0 +1,291 @@
+package dao
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+const (
+	mfaSelectQuery              = "select secret, confirmed_at, last_used_step, created_at from user_mfa where user_id = ?"
+	mfaUpsertQuery              = "insert into user_mfa (user_id, secret, confirmed_at, last_used_step, created_at) values(?, ?, ?, ?, ?) on duplicate key update secret = values(secret), confirmed_at = values(confirmed_at), last_used_step = values(last_used_step), created_at = values(created_at)"
+	mfaUpdateStepQuery          = "update user_mfa set last_used_step = ? where user_id = ? and last_used_step < ?"
+	mfaDeleteQuery              = "delete from user_mfa where user_id = ?"
+	recoveryCodeDeleteQuery     = "delete from user_mfa_recovery_code where user_id = ?"
+	recoveryCodeInsertQuery     = "insert into user_mfa_recovery_code (user_id, code_hash) values(?, ?)"
+	recoveryCodeUseQuery        = "update user_mfa_recovery_code set used_at = ? where user_id = ? and code_hash = ? and used_at is null"
+	challengeInsertQuery        = "insert into mfa_challenge (id, user_id, attempts, expires_at, created_at) values(?, ?, ?, ?, ?)"
+	challengeSelectQuery        = "select user_id, attempts, expires_at, created_at from mfa_challenge where id = ?"
+	challengeConsumeQuery       = "update mfa_challenge set attempts = attempts + 1 where id = ? and attempts < ? and expires_at > ?"
+	challengeDeleteQuery        = "delete from mfa_challenge where id = ?"
+	challengeDeleteExpiredQuery = "delete from mfa_challenge where user_id = ? and expires_at <= ?"
+)
+
+func TestNewMFADAO(t *testing.T) {
+	tx := &sql.Tx{}
+	if got := NewMFADAO(tx); !reflect.DeepEqual(got, &MFADAO{tx: tx}) {
+		t.Errorf("NewMFADAO() = %v, want %v", got, &MFADAO{tx: tx})
+	}
+}
+
+func TestMFADAO_FindByUserID(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	columns := []string{"secret", "confirmed_at", "last_used_step", "created_at"}
+
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		want    model.MFA
+		wantErr error
+	}{
+		{
+			name:    "正常ケース",
+			rows:    sqlmock.NewRows(columns).AddRow("SECRET", now, 10, now),
+			want:    model.NewMFA("1", "SECRET", now, 10, now),
+			wantErr: nil,
+		},
+		{
+			name:    "正常ケース(確認前)",
+			rows:    sqlmock.NewRows(columns).AddRow("SECRET", nil, 0, now),
+			want:    model.NewMFA("1", "SECRET", time.Time{}, 0, now),
+			wantErr: nil,
+		},
+		{
+			name:    "異常ケース(未登録)",
+			rows:    sqlmock.NewRows(columns),
+			want:    nil,
+			wantErr: repository.ErrMFANotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, mock := newMockTx(t)
+			mock.ExpectQuery(mfaSelectQuery).WithArgs("1").WillReturnRows(tt.rows)
+
+			got, err := NewMFADAO(tx).FindByUserID("1")
+			if err != tt.wantErr {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMFADAO_Save(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tx, mock := newMockTx(t)
+	mock.ExpectExec(mfaUpsertQuery).
+		WithArgs("1", "SECRET", sql.NullTime{}, int64(0), now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(mfaUpsertQuery).
+		WithArgs("1", "SECRET", sql.NullTime{Time: now, Valid: true}, int64(10), now).
+		WillReturnError(errors.New("ng"))
+
+	if err := NewMFADAO(tx).Save(model.NewMFA("1", "SECRET", time.Time{}, 0, now)); err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	if err := NewMFADAO(tx).Save(model.NewMFA("1", "SECRET", now, 10, now)); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
+
+func TestMFADAO_UpdateLastUsedStep(t *testing.T) {
+	tests := []struct {
+		name    string
+		result  sql.Result
+		wantErr error
+	}{
+		{name: "正常ケース", result: sqlmock.NewResult(0, 1), wantErr: nil},
+		{name: "異常ケース(使用済みのステップ)", result: sqlmock.NewResult(0, 0), wantErr: repository.ErrMFACodeUsed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, mock := newMockTx(t)
+			mock.ExpectExec(mfaUpdateStepQuery).WithArgs(int64(10), "1", int64(10)).WillReturnResult(tt.result)
+
+			if err := NewMFADAO(tx).UpdateLastUsedStep("1", 10); err != tt.wantErr {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+		})
+	}
+
+	tx, mock := newMockTx(t)
+	mock.ExpectExec(mfaUpdateStepQuery).WithArgs(int64(10), "1", int64(10)).WillReturnError(errors.New("ng"))
+	if err := NewMFADAO(tx).UpdateLastUsedStep("1", 10); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
+
+func TestMFADAO_Delete(t *testing.T) {
+	tx, mock := newMockTx(t)
+	mock.ExpectExec(recoveryCodeDeleteQuery).WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 10))
+	mock.ExpectExec(mfaDeleteQuery).WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(recoveryCodeDeleteQuery).WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(mfaDeleteQuery).WithArgs("1").WillReturnError(errors.New("ng"))
+
+	if err := NewMFADAO(tx).Delete("1"); err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	if err := NewMFADAO(tx).Delete("1"); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
+
+func TestMFADAO_ReplaceRecoveryCodes(t *testing.T) {
+	tx, mock := newMockTx(t)
+	mock.ExpectExec(recoveryCodeDeleteQuery).WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(recoveryCodeInsertQuery).WithArgs("1", "h1").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(recoveryCodeInsertQuery).WithArgs("1", "h2").WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectExec(recoveryCodeDeleteQuery).WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(recoveryCodeInsertQuery).WithArgs("1", "h1").WillReturnError(errors.New("ng"))
+
+	if err := NewMFADAO(tx).ReplaceRecoveryCodes("1", []string{"h1", "h2"}); err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	if err := NewMFADAO(tx).ReplaceRecoveryCodes("1", []string{"h1", "h2"}); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
+
+func TestMFADAO_UseRecoveryCode(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		result  sql.Result
+		wantErr error
+	}{
+		{name: "正常ケース", result: sqlmock.NewResult(0, 1), wantErr: nil},
+		{name: "異常ケース(未使用のコードなし)", result: sqlmock.NewResult(0, 0), wantErr: repository.ErrRecoveryCodeNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, mock := newMockTx(t)
+			mock.ExpectExec(recoveryCodeUseQuery).WithArgs(now, "1", "hash").WillReturnResult(tt.result)
+
+			if err := NewMFADAO(tx).UseRecoveryCode("1", "hash", now); err != tt.wantErr {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMFADAO_RegistChallenge(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	expires := now.Add(5 * time.Minute)
+	tx, mock := newMockTx(t)
+	mock.ExpectExec(challengeInsertQuery).WithArgs("hash", "1", 0, expires, now).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(challengeInsertQuery).WithArgs("hash", "1", 0, expires, now).WillReturnError(errors.New("ng"))
+
+	challenge := model.NewMFAChallenge("hash", "1", 0, expires, now)
+	if err := NewMFADAO(tx).RegistChallenge(challenge); err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	if err := NewMFADAO(tx).RegistChallenge(challenge); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
+
+func TestMFADAO_FindChallenge(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	expires := now.Add(5 * time.Minute)
+	columns := []string{"user_id", "attempts", "expires_at", "created_at"}
+
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		want    model.MFAChallenge
+		wantErr error
+	}{
+		{
+			name:    "正常ケース",
+			rows:    sqlmock.NewRows(columns).AddRow("1", 2, expires, now),
+			want:    model.NewMFAChallenge("hash", "1", 2, expires, now),
+			wantErr: nil,
+		},
+		{
+			name:    "異常ケース(チャレンジなし)",
+			rows:    sqlmock.NewRows(columns),
+			want:    nil,
+			wantErr: repository.ErrMFAChallengeNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, mock := newMockTx(t)
+			mock.ExpectQuery(challengeSelectQuery).WithArgs("hash").WillReturnRows(tt.rows)
+
+			got, err := NewMFADAO(tx).FindChallenge("hash")
+			if err != tt.wantErr {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMFADAO_ConsumeChallengeAttempt(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		result  sql.Result
+		wantErr error
+	}{
+		{name: "正常ケース", result: sqlmock.NewResult(0, 1), wantErr: nil},
+		{name: "異常ケース(試行回数超過・有効期限切れ)", result: sqlmock.NewResult(0, 0), wantErr: repository.ErrMFAChallengeNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, mock := newMockTx(t)
+			mock.ExpectExec(challengeConsumeQuery).WithArgs("hash", model.MaxMFAChallengeAttempts, now).WillReturnResult(tt.result)
+
+			if err := NewMFADAO(tx).ConsumeChallengeAttempt("hash", now); err != tt.wantErr {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+		})
+	}
+
+	tx, mock := newMockTx(t)
+	mock.ExpectExec(challengeConsumeQuery).WithArgs("hash", model.MaxMFAChallengeAttempts, now).WillReturnError(errors.New("ng"))
+	if err := NewMFADAO(tx).ConsumeChallengeAttempt("hash", now); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
+
+func TestMFADAO_DeleteChallenge(t *testing.T) {
+	tx, mock := newMockTx(t)
+	mock.ExpectExec(challengeDeleteQuery).WithArgs("hash").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(challengeDeleteQuery).WithArgs("hash").WillReturnError(errors.New("ng"))
+
+	if err := NewMFADAO(tx).DeleteChallenge("hash"); err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	if err := NewMFADAO(tx).DeleteChallenge("hash"); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
+
+func TestMFADAO_DeleteExpiredChallenges(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tx, mock := newMockTx(t)
+	mock.ExpectExec(challengeDeleteExpiredQuery).WithArgs("1", now).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(challengeDeleteExpiredQuery).WithArgs("1", now).WillReturnError(errors.New("ng"))
+
+	if err := NewMFADAO(tx).DeleteExpiredChallenges("1", now); err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	if err := NewMFADAO(tx).DeleteExpiredChallenges("1", now); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
@@ -0,0 +1,191 @@
+package dao
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+
+	"github.com/pkg/errors"
+)
+
+// NotificationDAO 通知DAO
+type NotificationDAO struct {
+	tx *sql.Tx
+}
+
+var _ repository.Notification = (*NotificationDAO)(nil)
+
+// NewNotificationDAO 通知DAOを生成する
+func NewNotificationDAO(tx *sql.Tx) *NotificationDAO {
+	return &NotificationDAO{
+		tx: tx,
+	}
+}
+
+// notificationColumns 通知取得時のカラム
+const notificationColumns = "id, user_id, kind, actor_id, target_id, read_at, created_at"
+
+// FindByUserID ユーザーの通知を新しい順に取得する(beforeIDを指定した場合はそれより前のもの)
+func (n *NotificationDAO) FindByUserID(userID string, beforeID string, limit int) ([]model.Notification, error) {
+	query := "select " + notificationColumns + " from notification where user_id = ? order by id desc limit ?"
+	args := []any{userID, limit}
+	if beforeID != "" {
+		query = "select " + notificationColumns + " from notification where user_id = ? and id < ? order by id desc limit ?"
+		args = []any{userID, beforeID, limit}
+	}
+
+	rows, err := n.tx.Query(query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "FindByUserID error")
+	}
+	defer rows.Close()
+
+	notifications := []model.Notification{}
+	for rows.Next() {
+		var (
+			id        string
+			ownerID   string
+			kind      string
+			actorID   sql.NullString
+			targetID  string
+			readAt    sql.NullTime
+			createdAt time.Time
+		)
+		if err := rows.Scan(&id, &ownerID, &kind, &actorID, &targetID, &readAt, &createdAt); err != nil {
+			return nil, errors.Wrap(err, "FindByUserID error")
+		}
+		notifications = append(notifications, model.NewNotification(
+			id,
+			ownerID,
+			model.NotificationKind(kind),
+			actorID.String,
+			targetID,
+			readAt.Time,
+			createdAt,
+		))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "FindByUserID error")
+	}
+
+	return notifications, nil
+}
+
+// CountUnread ユーザーの未読の通知数を返す
+func (n *NotificationDAO) CountUnread(userID string) (int, error) {
+	var count int
+	if err := n.tx.QueryRow(
+		"select count(*) from notification where user_id = ? and read_at is null",
+		userID,
+	).Scan(&count); err != nil {
+		return 0, errors.Wrap(err, "CountUnread error")
+	}
+
+	return count, nil
+}
+
+// Regist 通知を登録し、採番したIDを返す
+func (n *NotificationDAO) Regist(notification model.Notification, now time.Time) (string, error) {
+	stmt, err := n.tx.Prepare(`
+		insert into notification (user_id, kind, actor_id, target_id, created_at)
+		values(?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return "", errors.Wrap(err, "Regist error")
+	}
+	defer stmt.Close()
+
+	actorID := sql.NullString{String: notification.ActorID(), Valid: notification.ActorID() != ""}
+	result, err := stmt.Exec(
+		notification.UserID(),
+		string(notification.Kind()),
+		actorID,
+		notification.TargetID(),
+		now,
+	)
+	if err != nil {
+		return "", errors.Wrap(err, "Regist error")
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return "", errors.Wrap(err, "Regist error")
+	}
+
+	return strconv.FormatInt(id, 10), nil
+}
+
+// MarkRead ユーザーの通知を既読にする
+func (n *NotificationDAO) MarkRead(userID string, id string, now time.Time) error {
+	var readAt sql.NullTime
+	err := n.tx.QueryRow(
+		"select read_at from notification where id = ? and user_id = ?",
+		id,
+		userID,
+	).Scan(&readAt)
+	if err == sql.ErrNoRows {
+		return repository.ErrNotificationNotFound
+	} else if err != nil {
+		return errors.Wrap(err, "MarkRead error")
+	}
+	if readAt.Valid {
+		return nil
+	}
+
+	if _, err := n.tx.Exec("update notification set read_at = ? where id = ?", now, id); err != nil {
+		return errors.Wrap(err, "MarkRead error")
+	}
+
+	return nil
+}
+
+// MarkAllRead ユーザーの通知をすべて既読にする
+func (n *NotificationDAO) MarkAllRead(userID string, now time.Time) error {
+	if _, err := n.tx.Exec(
+		"update notification set read_at = ? where user_id = ? and read_at is null",
+		now,
+		userID,
+	); err != nil {
+		return errors.Wrap(err, "MarkAllRead error")
+	}
+
+	return nil
+}
+
+// FindPreferences 受信設定を取得する(未設定の場合はデフォルト)
+func (n *NotificationDAO) FindPreferences(userID string) (model.NotificationPreferences, error) {
+	var preferences model.NotificationPreferences
+	err := n.tx.QueryRow(
+		"select reply, quote, mention from notification_preference where user_id = ?",
+		userID,
+	).Scan(&preferences.Reply, &preferences.Quote, &preferences.Mention)
+	if err == sql.ErrNoRows {
+		return model.DefaultNotificationPreferences(), nil
+	} else if err != nil {
+		return model.NotificationPreferences{}, errors.Wrap(err, "FindPreferences error")
+	}
+
+	return preferences, nil
+}
+
+// SavePreferences 受信設定を保存する
+func (n *NotificationDAO) SavePreferences(userID string, preferences model.NotificationPreferences, now time.Time) error {
+	if _, err := n.tx.Exec(`
+		insert into notification_preference (user_id, reply, quote, mention, updated_at)
+		values(?, ?, ?, ?, ?)
+		on duplicate key update reply = values(reply), quote = values(quote), mention = values(mention), updated_at = values(updated_at)
+	`,
+		userID,
+		preferences.Reply,
+		preferences.Quote,
+		preferences.Mention,
+		now,
+	); err != nil {
+		return errors.Wrap(err, "SavePreferences error")
+	}
+
+	return nil
+}
@@ -0,0 +1,280 @@
+package dao
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+const (
+	notificationSelectQuery       = "select id, user_id, kind, actor_id, target_id, read_at, created_at from notification where user_id = ? order by id desc limit ?"
+	notificationSelectBeforeQuery = "select id, user_id, kind, actor_id, target_id, read_at, created_at from notification where user_id = ? and id < ? order by id desc limit ?"
+	notificationCountQuery        = "select count(*) from notification where user_id = ? and read_at is null"
+	notificationInsertQuery       = "insert into notification (user_id, kind, actor_id, target_id, created_at) values(?, ?, ?, ?, ?)"
+	notificationReadAtQuery       = "select read_at from notification where id = ? and user_id = ?"
+	notificationMarkReadQuery     = "update notification set read_at = ? where id = ?"
+	notificationMarkAllReadQuery  = "update notification set read_at = ? where user_id = ? and read_at is null"
+	preferenceSelectQuery         = "select reply, quote, mention from notification_preference where user_id = ?"
+	preferenceUpsertQuery         = "insert into notification_preference (user_id, reply, quote, mention, updated_at) values(?, ?, ?, ?, ?) on duplicate key update reply = values(reply), quote = values(quote), mention = values(mention), updated_at = values(updated_at)"
+)
+
+var notificationColumnNames = []string{"id", "user_id", "kind", "actor_id", "target_id", "read_at", "created_at"}
+
+// newMockTx sqlmockのtxを生成する
+func newMockTx(t *testing.T) (*sql.Tx, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("sqlmockの生成に失敗(error: %s)", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("txの生成に失敗(error: %s)", err)
+	}
+	t.Cleanup(func() {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("予期せぬDB操作(error: %s)", err)
+		}
+	})
+
+	return tx, mock
+}
+
+func TestNewNotificationDAO(t *testing.T) {
+	tx := &sql.Tx{}
+	if got := NewNotificationDAO(tx); !reflect.DeepEqual(got, &NotificationDAO{tx: tx}) {
+		t.Errorf("NewNotificationDAO() = %v, want %v", got, &NotificationDAO{tx: tx})
+	}
+}
+
+func TestNotificationDAO_FindByUserIDSuccess(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	readAt := now.Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		beforeID string
+		expect   func(mock sqlmock.Sqlmock) *sqlmock.ExpectedQuery
+	}{
+		{
+			name:     "正常ケース",
+			beforeID: "",
+			expect: func(mock sqlmock.Sqlmock) *sqlmock.ExpectedQuery {
+				return mock.ExpectQuery(notificationSelectQuery).WithArgs("1", 10)
+			},
+		},
+		{
+			name:     "正常ケース(beforeID指定)",
+			beforeID: "5",
+			expect: func(mock sqlmock.Sqlmock) *sqlmock.ExpectedQuery {
+				return mock.ExpectQuery(notificationSelectBeforeQuery).WithArgs("1", "5", 10)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, mock := newMockTx(t)
+			tt.expect(mock).
+				WillReturnRows(sqlmock.NewRows(notificationColumnNames).
+					AddRow("3", "1", "mention", nil, "10", nil, now).
+					AddRow("2", "1", "reply", "2", "11", readAt, now)).
+				RowsWillBeClosed()
+
+			got, err := NewNotificationDAO(tx).FindByUserID("1", tt.beforeID, 10)
+			if err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+
+			want := []model.Notification{
+				model.NewNotification("3", "1", model.NotificationMention, "", "10", time.Time{}, now),
+				model.NewNotification("2", "1", model.NotificationReply, "2", "11", readAt, now),
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
+			}
+		})
+	}
+}
+
+func TestNotificationDAO_FindByUserIDQueryFail(t *testing.T) {
+	tx, mock := newMockTx(t)
+	mock.ExpectQuery(notificationSelectQuery).WithArgs("1", 10).WillReturnError(errors.New("ng"))
+
+	if _, err := NewNotificationDAO(tx).FindByUserID("1", "", 10); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
+
+func TestNotificationDAO_CountUnread(t *testing.T) {
+	tx, mock := newMockTx(t)
+	mock.ExpectQuery(notificationCountQuery).
+		WithArgs("1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(notificationCountQuery).
+		WithArgs("2").
+		WillReturnError(errors.New("ng"))
+
+	got, err := NewNotificationDAO(tx).CountUnread("1")
+	if err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	if got != 3 {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, 3)
+	}
+
+	if _, err := NewNotificationDAO(tx).CountUnread("2"); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
+
+func TestNotificationDAO_RegistSuccess(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tx, mock := newMockTx(t)
+	mock.ExpectPrepare(notificationInsertQuery).
+		ExpectExec().
+		WithArgs("1", "quote", sql.NullString{String: "2", Valid: true}, "10", now).
+		WillReturnResult(sqlmock.NewResult(5, 1))
+
+	got, err := NewNotificationDAO(tx).Regist(model.NewNotification("", "1", model.NotificationQuote, "2", "10", time.Time{}, time.Time{}), now)
+	if err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	if got != "5" {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, "5")
+	}
+}
+
+func TestNotificationDAO_RegistFail(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tx, mock := newMockTx(t)
+	mock.ExpectPrepare(notificationInsertQuery).
+		ExpectExec().
+		WillReturnError(errors.New("ng"))
+
+	if _, err := NewNotificationDAO(tx).Regist(model.NewNotification("", "1", model.NotificationQuote, "", "10", time.Time{}, time.Time{}), now); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
+
+func TestNotificationDAO_MarkRead(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		expect  func(mock sqlmock.Sqlmock)
+		wantErr error
+	}{
+		{
+			name: "正常ケース(未読)",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(notificationReadAtQuery).
+					WithArgs("5", "1").
+					WillReturnRows(sqlmock.NewRows([]string{"read_at"}).AddRow(nil))
+				mock.ExpectExec(notificationMarkReadQuery).
+					WithArgs(now, "5").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: nil,
+		},
+		{
+			name: "正常ケース(既読)",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(notificationReadAtQuery).
+					WithArgs("5", "1").
+					WillReturnRows(sqlmock.NewRows([]string{"read_at"}).AddRow(now))
+			},
+			wantErr: nil,
+		},
+		{
+			name: "異常ケース(通知なし)",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(notificationReadAtQuery).
+					WithArgs("5", "1").
+					WillReturnRows(sqlmock.NewRows([]string{"read_at"}))
+			},
+			wantErr: repository.ErrNotificationNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, mock := newMockTx(t)
+			tt.expect(mock)
+
+			if err := NewNotificationDAO(tx).MarkRead("1", "5", now); err != tt.wantErr {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNotificationDAO_MarkAllRead(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tx, mock := newMockTx(t)
+	mock.ExpectExec(notificationMarkAllReadQuery).
+		WithArgs(now, "1").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(notificationMarkAllReadQuery).
+		WithArgs(now, "2").
+		WillReturnError(errors.New("ng"))
+
+	if err := NewNotificationDAO(tx).MarkAllRead("1", now); err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	if err := NewNotificationDAO(tx).MarkAllRead("2", now); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
+
+func TestNotificationDAO_FindPreferences(t *testing.T) {
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		want    model.NotificationPreferences
+		wantErr bool
+	}{
+		{
+			name: "正常ケース",
+			rows: sqlmock.NewRows([]string{"reply", "quote", "mention"}).AddRow(true, false, true),
+			want: model.NotificationPreferences{Reply: true, Quote: false, Mention: true},
+		},
+		{
+			name: "正常ケース(未設定)",
+			rows: sqlmock.NewRows([]string{"reply", "quote", "mention"}),
+			want: model.DefaultNotificationPreferences(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, mock := newMockTx(t)
+			mock.ExpectQuery(preferenceSelectQuery).WithArgs("1").WillReturnRows(tt.rows)
+
+			got, err := NewNotificationDAO(tx).FindPreferences("1")
+			if err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+			if got != tt.want {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotificationDAO_SavePreferences(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tx, mock := newMockTx(t)
+	mock.ExpectExec(preferenceUpsertQuery).
+		WithArgs("1", true, false, true, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := NewNotificationDAO(tx).SavePreferences("1", model.NotificationPreferences{Reply: true, Quote: false, Mention: true}, now); err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+}
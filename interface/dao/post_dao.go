@@ -0,0 +1,167 @@
+package dao
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+
+	"github.com/pkg/errors"
+)
+
+// PostDAO 投稿DAO
+type PostDAO struct {
+	tx *sql.Tx
+}
+
+var _ repository.Post = (*PostDAO)(nil)
+
+// NewPostDAO 投稿DAOを生成する
+func NewPostDAO(tx *sql.Tx) *PostDAO {
+	return &PostDAO{
+		tx: tx,
+	}
+}
+
+// postColumns 投稿取得時のカラム
+const postColumns = "id, thread_id, user_id, number, body, body_html, created_at, edited_at, hidden_at, hidden_reason"
+
+// FindByThreadID スレッドの投稿を番号順に取得する(afterNumberより後のもの)
+func (p *PostDAO) FindByThreadID(threadID string, afterNumber int, limit int) ([]model.Post, error) {
+	rows, err := p.tx.Query(
+		"select "+postColumns+" from post where thread_id = ? and number > ? order by number limit ?",
+		threadID,
+		afterNumber,
+		limit,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "FindByThreadID error")
+	}
+	defer rows.Close()
+
+	posts := []model.Post{}
+	for rows.Next() {
+		post, err := scanPost(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "FindByThreadID error")
+		}
+		posts = append(posts, post)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "FindByThreadID error")
+	}
+
+	return posts, nil
+}
+
+// FindByID IDで投稿を取得する
+func (p *PostDAO) FindByID(id string) (model.Post, error) {
+	post, err := scanPost(p.tx.QueryRow("select "+postColumns+" from post where id = ?", id))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrPostNotFound
+	} else if err != nil {
+		return nil, errors.Wrap(err, "FindByID error")
+	}
+
+	return post, nil
+}
+
+// CountCreated スレッドの非表示でない投稿のうち、作成日時がfrom以上to未満のものを数える
+func (p *PostDAO) CountCreated(threadIDs []string, from time.Time, to time.Time) (int, error) {
+	if len(threadIDs) == 0 {
+		return 0, nil
+	}
+
+	args := make([]any, 0, len(threadIDs)+2)
+	for _, id := range threadIDs {
+		args = append(args, id)
+	}
+	args = append(args, from, to)
+
+	var count int
+	if err := p.tx.QueryRow(
+		"select count(*) from post where thread_id in (?"+strings.Repeat(", ?", len(threadIDs)-1)+") and created_at >= ? and created_at < ? and hidden_at is null",
+		args...,
+	).Scan(&count); err != nil {
+		return 0, errors.Wrap(err, "CountCreated error")
+	}
+
+	return count, nil
+}
+
+// Regist 投稿を登録し、採番したIDを返す
+func (p *PostDAO) Regist(post model.Post, now time.Time) (string, error) {
+	userID := sql.NullString{String: post.UserID(), Valid: post.UserID() != ""}
+	result, err := p.tx.Exec(
+		"insert into post (thread_id, user_id, number, body, body_html, created_at) values(?, ?, ?, ?, ?, ?)",
+		post.ThreadID(),
+		userID,
+		post.Number(),
+		post.Body(),
+		post.BodyHTML(),
+		now,
+	)
+	if err != nil {
+		return "", errors.Wrap(err, "Regist error")
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return "", errors.Wrap(err, "Regist error")
+	}
+
+	return strconv.FormatInt(id, 10), nil
+}
+
+// Update 投稿の本文を更新し、編集日時を記録する
+func (p *PostDAO) Update(post model.Post, now time.Time) error {
+	if _, err := p.tx.Exec(
+		"update post set body = ?, body_html = ?, edited_at = ? where id = ?",
+		post.Body(),
+		post.BodyHTML(),
+		now,
+		post.ID(),
+	); err != nil {
+		return errors.Wrap(err, "Update error")
+	}
+
+	return nil
+}
+
+// Hide 投稿を非表示にする(本文は版とともに残す)
+func (p *PostDAO) Hide(id string, reason string, now time.Time) error {
+	if _, err := p.tx.Exec(
+		"update post set hidden_at = ?, hidden_reason = ? where id = ?",
+		now,
+		reason,
+		id,
+	); err != nil {
+		return errors.Wrap(err, "Hide error")
+	}
+
+	return nil
+}
+
+// scanPost 1行分の投稿を読み込む
+func scanPost(row rowScanner) (model.Post, error) {
+	var (
+		id           string
+		threadID     string
+		userID       sql.NullString
+		number       int
+		body         string
+		bodyHTML     string
+		createdAt    time.Time
+		editedAt     sql.NullTime
+		hiddenAt     sql.NullTime
+		hiddenReason string
+	)
+	if err := row.Scan(&id, &threadID, &userID, &number, &body, &bodyHTML, &createdAt, &editedAt, &hiddenAt, &hiddenReason); err != nil {
+		return nil, err
+	}
+
+	return model.NewPost(id, threadID, userID.String, number, body, bodyHTML, createdAt, editedAt.Time, hiddenAt.Time, hiddenReason), nil
+}
@@ -0,0 +1,191 @@
+package dao
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+const (
+	postSelectByThreadQuery = "select id, thread_id, user_id, number, body, body_html, created_at, edited_at, hidden_at, hidden_reason from post where thread_id = ? and number > ? order by number limit ?"
+	postSelectQuery         = "select id, thread_id, user_id, number, body, body_html, created_at, edited_at, hidden_at, hidden_reason from post where id = ?"
+	postInsertQuery         = "insert into post (thread_id, user_id, number, body, body_html, created_at) values(?, ?, ?, ?, ?, ?)"
+	postUpdateQuery         = "update post set body = ?, body_html = ?, edited_at = ? where id = ?"
+	postHideQuery           = "update post set hidden_at = ?, hidden_reason = ? where id = ?"
+	postCountCreatedQuery   = "select count(*) from post where thread_id in (?, ?) and created_at >= ? and created_at < ? and hidden_at is null"
+)
+
+var postColumnNames = []string{"id", "thread_id", "user_id", "number", "body", "body_html", "created_at", "edited_at", "hidden_at", "hidden_reason"}
+
+func TestNewPostDAO(t *testing.T) {
+	tx := &sql.Tx{}
+	if got := NewPostDAO(tx); !reflect.DeepEqual(got, &PostDAO{tx: tx}) {
+		t.Errorf("NewPostDAO() = %v, want %v", got, &PostDAO{tx: tx})
+	}
+}
+
+func TestPostDAO_FindByThreadID(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	edited := now.Add(time.Minute)
+	hidden := now.Add(time.Hour)
+	tx, mock := newMockTx(t)
+	mock.ExpectQuery(postSelectByThreadQuery).
+		WithArgs("1", 0, 10).
+		WillReturnRows(sqlmock.NewRows(postColumnNames).
+			AddRow("10", "1", "2", 1, "body", "<p>body</p>\n", now, nil, nil, "").
+			AddRow("11", "1", nil, 2, "退会", "<p>退会</p>\n", now, edited, hidden, "spam")).
+		RowsWillBeClosed()
+	mock.ExpectQuery(postSelectByThreadQuery).WithArgs("2", 0, 10).WillReturnError(errors.New("ng"))
+
+	got, err := NewPostDAO(tx).FindByThreadID("1", 0, 10)
+	if err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	want := []model.Post{
+		model.NewPost("10", "1", "2", 1, "body", "<p>body</p>\n", now, time.Time{}, time.Time{}, ""),
+		model.NewPost("11", "1", "", 2, "退会", "<p>退会</p>\n", now, edited, hidden, "spam"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
+	}
+
+	if _, err := NewPostDAO(tx).FindByThreadID("2", 0, 10); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
+
+func TestPostDAO_FindByID(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		want    model.Post
+		wantErr error
+	}{
+		{
+			name:    "正常ケース",
+			rows:    sqlmock.NewRows(postColumnNames).AddRow("10", "1", "2", 1, "body", "<p>body</p>\n", now, nil, nil, ""),
+			want:    model.NewPost("10", "1", "2", 1, "body", "<p>body</p>\n", now, time.Time{}, time.Time{}, ""),
+			wantErr: nil,
+		},
+		{
+			name:    "異常ケース(投稿なし)",
+			rows:    sqlmock.NewRows(postColumnNames),
+			want:    nil,
+			wantErr: repository.ErrPostNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, mock := newMockTx(t)
+			mock.ExpectQuery(postSelectQuery).WithArgs("10").WillReturnRows(tt.rows)
+
+			got, err := NewPostDAO(tx).FindByID("10")
+			if err != tt.wantErr {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostDAO_CountCreated(t *testing.T) {
+	from := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	tx, mock := newMockTx(t)
+	mock.ExpectQuery(postCountCreatedQuery).
+		WithArgs("1", "2", from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(3))
+	mock.ExpectQuery(postCountCreatedQuery).
+		WithArgs("1", "2", from, to).
+		WillReturnError(errors.New("ng"))
+
+	got, err := NewPostDAO(tx).CountCreated([]string{"1", "2"}, from, to)
+	if err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	if got != 3 {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, 3)
+	}
+
+	if _, err := NewPostDAO(tx).CountCreated([]string{"1", "2"}, from, to); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+
+	// スレッドの指定がない場合は問い合わせない
+	if got, err := NewPostDAO(tx).CountCreated([]string{}, from, to); err != nil || got != 0 {
+		t.Errorf("戻り値不一致 got: %#v, %v want: 0, nil", got, err)
+	}
+}
+
+func TestPostDAO_Regist(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tx, mock := newMockTx(t)
+	mock.ExpectExec(postInsertQuery).
+		WithArgs("1", sql.NullString{String: "2", Valid: true}, 3, "body", "<p>body</p>\n", now).
+		WillReturnResult(sqlmock.NewResult(10, 1))
+	mock.ExpectExec(postInsertQuery).
+		WithArgs("1", sql.NullString{String: "2", Valid: true}, 3, "body", "<p>body</p>\n", now).
+		WillReturnError(errors.New("ng"))
+
+	post := model.NewPost("", "1", "2", 3, "body", "<p>body</p>\n", time.Time{}, time.Time{}, time.Time{}, "")
+	got, err := NewPostDAO(tx).Regist(post, now)
+	if err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	if got != "10" {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, "10")
+	}
+
+	if _, err := NewPostDAO(tx).Regist(post, now); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
+
+func TestPostDAO_Update(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tx, mock := newMockTx(t)
+	mock.ExpectExec(postUpdateQuery).
+		WithArgs("edited", "<p>edited</p>\n", now, "10").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(postUpdateQuery).
+		WithArgs("edited", "<p>edited</p>\n", now, "10").
+		WillReturnError(errors.New("ng"))
+
+	post := model.NewPost("10", "1", "2", 3, "edited", "<p>edited</p>\n", time.Time{}, time.Time{}, time.Time{}, "")
+	if err := NewPostDAO(tx).Update(post, now); err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+
+	if err := NewPostDAO(tx).Update(post, now); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
+
+func TestPostDAO_Hide(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tx, mock := newMockTx(t)
+	mock.ExpectExec(postHideQuery).
+		WithArgs(now, "spam", "10").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(postHideQuery).
+		WithArgs(now, "spam", "10").
+		WillReturnError(errors.New("ng"))
+
+	if err := NewPostDAO(tx).Hide("10", "spam", now); err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+
+	if err := NewPostDAO(tx).Hide("10", "spam", now); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
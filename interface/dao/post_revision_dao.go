@@ -0,0 +1,102 @@
+package dao
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+
+	"github.com/pkg/errors"
+)
+
+// PostRevisionDAO 投稿の版DAO
+type PostRevisionDAO struct {
+	tx *sql.Tx
+}
+
+var _ repository.PostRevision = (*PostRevisionDAO)(nil)
+
+// NewPostRevisionDAO 投稿の版DAOを生成する
+func NewPostRevisionDAO(tx *sql.Tx) *PostRevisionDAO {
+	return &PostRevisionDAO{
+		tx: tx,
+	}
+}
+
+// postRevisionColumns 版取得時のカラム
+const postRevisionColumns = "id, post_id, editor_id, body, created_at"
+
+// FindByPostID 投稿の版を古い順に取得する
+func (p *PostRevisionDAO) FindByPostID(postID string) ([]model.PostRevision, error) {
+	rows, err := p.tx.Query("select "+postRevisionColumns+" from post_revision where post_id = ? order by id", postID)
+	if err != nil {
+		return nil, errors.Wrap(err, "FindByPostID error")
+	}
+	defer rows.Close()
+
+	revisions := []model.PostRevision{}
+	for rows.Next() {
+		revision, err := scanPostRevision(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "FindByPostID error")
+		}
+		revisions = append(revisions, revision)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "FindByPostID error")
+	}
+
+	return revisions, nil
+}
+
+// FindByID IDで版を取得する
+func (p *PostRevisionDAO) FindByID(id string) (model.PostRevision, error) {
+	revision, err := scanPostRevision(p.tx.QueryRow("select "+postRevisionColumns+" from post_revision where id = ?", id))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrPostRevisionNotFound
+	} else if err != nil {
+		return nil, errors.Wrap(err, "FindByID error")
+	}
+
+	return revision, nil
+}
+
+// Regist 版を登録し、採番したIDを返す
+func (p *PostRevisionDAO) Regist(revision model.PostRevision, now time.Time) (string, error) {
+	editorID := sql.NullString{String: revision.EditorID(), Valid: revision.EditorID() != ""}
+	result, err := p.tx.Exec(
+		"insert into post_revision (post_id, editor_id, body, created_at) values(?, ?, ?, ?)",
+		revision.PostID(),
+		editorID,
+		revision.Body(),
+		now,
+	)
+	if err != nil {
+		return "", errors.Wrap(err, "Regist error")
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return "", errors.Wrap(err, "Regist error")
+	}
+
+	return strconv.FormatInt(id, 10), nil
+}
+
+// scanPostRevision 1行分の版を読み込む
+func scanPostRevision(row rowScanner) (model.PostRevision, error) {
+	var (
+		id        string
+		postID    string
+		editorID  sql.NullString
+		body      string
+		createdAt time.Time
+	)
+	if err := row.Scan(&id, &postID, &editorID, &body, &createdAt); err != nil {
+		return nil, err
+	}
+
+	return model.NewPostRevision(id, postID, editorID.String, body, createdAt), nil
+}
@@ -0,0 +1,118 @@
+package dao
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+const (
+	postRevisionSelectByPostQuery = "select id, post_id, editor_id, body, created_at from post_revision where post_id = ? order by id"
+	postRevisionSelectQuery       = "select id, post_id, editor_id, body, created_at from post_revision where id = ?"
+	postRevisionInsertQuery       = "insert into post_revision (post_id, editor_id, body, created_at) values(?, ?, ?, ?)"
+)
+
+var postRevisionColumnNames = []string{"id", "post_id", "editor_id", "body", "created_at"}
+
+func TestNewPostRevisionDAO(t *testing.T) {
+	tx := &sql.Tx{}
+	if got := NewPostRevisionDAO(tx); !reflect.DeepEqual(got, &PostRevisionDAO{tx: tx}) {
+		t.Errorf("NewPostRevisionDAO() = %v, want %v", got, &PostRevisionDAO{tx: tx})
+	}
+}
+
+func TestPostRevisionDAO_FindByPostID(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tx, mock := newMockTx(t)
+	mock.ExpectQuery(postRevisionSelectByPostQuery).
+		WithArgs("10").
+		WillReturnRows(sqlmock.NewRows(postRevisionColumnNames).
+			AddRow("1", "10", "2", "body", now).
+			AddRow("2", "10", nil, "edited", now)).
+		RowsWillBeClosed()
+	mock.ExpectQuery(postRevisionSelectByPostQuery).WithArgs("11").WillReturnError(errors.New("ng"))
+
+	got, err := NewPostRevisionDAO(tx).FindByPostID("10")
+	if err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	want := []model.PostRevision{
+		model.NewPostRevision("1", "10", "2", "body", now),
+		model.NewPostRevision("2", "10", "", "edited", now),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
+	}
+
+	if _, err := NewPostRevisionDAO(tx).FindByPostID("11"); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
+
+func TestPostRevisionDAO_FindByID(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		want    model.PostRevision
+		wantErr error
+	}{
+		{
+			name:    "正常ケース",
+			rows:    sqlmock.NewRows(postRevisionColumnNames).AddRow("1", "10", "2", "body", now),
+			want:    model.NewPostRevision("1", "10", "2", "body", now),
+			wantErr: nil,
+		},
+		{
+			name:    "異常ケース(版なし)",
+			rows:    sqlmock.NewRows(postRevisionColumnNames),
+			want:    nil,
+			wantErr: repository.ErrPostRevisionNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, mock := newMockTx(t)
+			mock.ExpectQuery(postRevisionSelectQuery).WithArgs("1").WillReturnRows(tt.rows)
+
+			got, err := NewPostRevisionDAO(tx).FindByID("1")
+			if err != tt.wantErr {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostRevisionDAO_Regist(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tx, mock := newMockTx(t)
+	mock.ExpectExec(postRevisionInsertQuery).
+		WithArgs("10", sql.NullString{String: "2", Valid: true}, "body", now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(postRevisionInsertQuery).
+		WithArgs("10", sql.NullString{String: "2", Valid: true}, "body", now).
+		WillReturnError(errors.New("ng"))
+
+	revision := model.NewPostRevision("", "10", "2", "body", time.Time{})
+	got, err := NewPostRevisionDAO(tx).Regist(revision, now)
+	if err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	if got != "1" {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, "1")
+	}
+
+	if _, err := NewPostRevisionDAO(tx).Regist(revision, now); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
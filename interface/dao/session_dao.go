@@ -0,0 +1,147 @@
+package dao
+
+import (
+	"database/sql"
+	"time"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+
+	"github.com/pkg/errors"
+)
+
+// SessionDAO SessionDAO
+type SessionDAO struct {
+	tx *sql.Tx
+}
+
+var _ repository.Session = (*SessionDAO)(nil)
+
+// NewSessionDAO SessionDAOを生成する
+func NewSessionDAO(tx *sql.Tx) *SessionDAO {
+	return &SessionDAO{
+		tx: tx,
+	}
+}
+
+// sessionColumns セッション取得時のカラム
+const sessionColumns = "id, user_id, device, ip, user_agent, created_at, last_seen_at, expires_at, revoked_at"
+
+// Regist セッションを登録する
+func (s *SessionDAO) Regist(session model.Session) error {
+	if _, err := s.tx.Exec(`
+		insert into session (id, user_id, device, ip, user_agent, created_at, last_seen_at, expires_at)
+		values(?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		session.ID(),
+		session.UserID(),
+		session.Device(),
+		session.IP(),
+		session.UserAgent(),
+		session.CreatedAt(),
+		session.LastSeenAt(),
+		session.ExpiresAt(),
+	); err != nil {
+		return errors.Wrap(err, "Regist error")
+	}
+
+	return nil
+}
+
+// FindByID IDからセッションを取得する
+func (s *SessionDAO) FindByID(id string) (model.Session, error) {
+	session, err := scanSession(s.tx.QueryRow("select "+sessionColumns+" from session where id = ?", id))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrSessionNotFound
+	} else if err != nil {
+		return nil, errors.Wrap(err, "FindByID error")
+	}
+
+	return session, nil
+}
+
+// FindActiveByUserID ユーザーの有効なセッションをログインの新しい順に取得する
+func (s *SessionDAO) FindActiveByUserID(userID string, now time.Time) ([]model.Session, error) {
+	rows, err := s.tx.Query(`
+		select `+sessionColumns+` from session
+		where user_id = ? and revoked_at is null and expires_at > ?
+		order by created_at desc, id desc
+	`,
+		userID,
+		now,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "FindActiveByUserID error")
+	}
+	defer rows.Close()
+
+	sessions := []model.Session{}
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "FindActiveByUserID error")
+		}
+		sessions = append(sessions, session)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "FindActiveByUserID error")
+	}
+
+	return sessions, nil
+}
+
+// Touch 最終利用日時を更新する
+func (s *SessionDAO) Touch(id string, now time.Time) error {
+	if _, err := s.tx.Exec("update session set last_seen_at = ? where id = ?", now, id); err != nil {
+		return errors.Wrap(err, "Touch error")
+	}
+
+	return nil
+}
+
+// Revoke ユーザーの失効していないセッションを失効させる
+func (s *SessionDAO) Revoke(userID string, id string, now time.Time) error {
+	result, err := s.tx.Exec("update session set revoked_at = ? where id = ? and user_id = ? and revoked_at is null", now, id, userID)
+	if err != nil {
+		return errors.Wrap(err, "Revoke error")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "Revoke error")
+	}
+	if affected == 0 {
+		return repository.ErrSessionNotFound
+	}
+
+	return nil
+}
+
+// DeleteExpired ユーザーの有効期限切れのセッションを削除する
+func (s *SessionDAO) DeleteExpired(userID string, now time.Time) error {
+	if _, err := s.tx.Exec("delete from session where user_id = ? and expires_at <= ?", userID, now); err != nil {
+		return errors.Wrap(err, "DeleteExpired error")
+	}
+
+	return nil
+}
+
+// scanSession 1行分のセッションを読み込む
+func scanSession(row rowScanner) (model.Session, error) {
+	var (
+		id         string
+		userID     string
+		device     string
+		ip         string
+		userAgent  string
+		createdAt  time.Time
+		lastSeenAt time.Time
+		expiresAt  time.Time
+		revokedAt  sql.NullTime
+	)
+	if err := row.Scan(&id, &userID, &device, &ip, &userAgent, &createdAt, &lastSeenAt, &expiresAt, &revokedAt); err != nil {
+		return nil, err
+	}
+
+	return model.NewSession(id, userID, device, ip, userAgent, createdAt, lastSeenAt, expiresAt, revokedAt.Time), nil
+}
@@ -0,0 +1,176 @@
+package dao
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+const (
+	sessionInsertQuery       = "insert into session (id, user_id, device, ip, user_agent, created_at, last_seen_at, expires_at) values(?, ?, ?, ?, ?, ?, ?, ?)"
+	sessionSelectQuery       = "select id, user_id, device, ip, user_agent, created_at, last_seen_at, expires_at, revoked_at from session where id = ?"
+	sessionSelectActiveQuery = "select id, user_id, device, ip, user_agent, created_at, last_seen_at, expires_at, revoked_at from session where user_id = ? and revoked_at is null and expires_at > ? order by created_at desc, id desc"
+	sessionTouchQuery        = "update session set last_seen_at = ? where id = ?"
+	sessionRevokeQuery       = "update session set revoked_at = ? where id = ? and user_id = ? and revoked_at is null"
+	sessionDeleteExpiredQry  = "delete from session where user_id = ? and expires_at <= ?"
+)
+
+var sessionColumnNames = []string{"id", "user_id", "device", "ip", "user_agent", "created_at", "last_seen_at", "expires_at", "revoked_at"}
+
+func TestNewSessionDAO(t *testing.T) {
+	tx := &sql.Tx{}
+	if got := NewSessionDAO(tx); !reflect.DeepEqual(got, &SessionDAO{tx: tx}) {
+		t.Errorf("NewSessionDAO() = %v, want %v", got, &SessionDAO{tx: tx})
+	}
+}
+
+func TestSessionDAO_Regist(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	expires := now.Add(time.Hour)
+	tx, mock := newMockTx(t)
+	mock.ExpectExec(sessionInsertQuery).
+		WithArgs("sid", "1", "Firefox on Linux", "192.0.2.1", "Mozilla/5.0", now, now, expires).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(sessionInsertQuery).
+		WithArgs("sid", "1", "Firefox on Linux", "192.0.2.1", "Mozilla/5.0", now, now, expires).
+		WillReturnError(errors.New("ng"))
+
+	session := model.NewSession("sid", "1", "Firefox on Linux", "192.0.2.1", "Mozilla/5.0", now, now, expires, time.Time{})
+	if err := NewSessionDAO(tx).Regist(session); err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	if err := NewSessionDAO(tx).Regist(session); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
+
+func TestSessionDAO_FindByID(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	expires := now.Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		want    model.Session
+		wantErr error
+	}{
+		{
+			name:    "正常ケース",
+			rows:    sqlmock.NewRows(sessionColumnNames).AddRow("sid", "1", "Firefox on Linux", "192.0.2.1", "Mozilla/5.0", now, now, expires, nil),
+			want:    model.NewSession("sid", "1", "Firefox on Linux", "192.0.2.1", "Mozilla/5.0", now, now, expires, time.Time{}),
+			wantErr: nil,
+		},
+		{
+			name:    "正常ケース(失効済み)",
+			rows:    sqlmock.NewRows(sessionColumnNames).AddRow("sid", "1", "Firefox on Linux", "192.0.2.1", "Mozilla/5.0", now, now, expires, now),
+			want:    model.NewSession("sid", "1", "Firefox on Linux", "192.0.2.1", "Mozilla/5.0", now, now, expires, now),
+			wantErr: nil,
+		},
+		{
+			name:    "異常ケース(セッションなし)",
+			rows:    sqlmock.NewRows(sessionColumnNames),
+			want:    nil,
+			wantErr: repository.ErrSessionNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, mock := newMockTx(t)
+			mock.ExpectQuery(sessionSelectQuery).WithArgs("sid").WillReturnRows(tt.rows)
+
+			got, err := NewSessionDAO(tx).FindByID("sid")
+			if err != tt.wantErr {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSessionDAO_FindActiveByUserID(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	expires := now.Add(time.Hour)
+	tx, mock := newMockTx(t)
+	mock.ExpectQuery(sessionSelectActiveQuery).
+		WithArgs("1", now).
+		WillReturnRows(sqlmock.NewRows(sessionColumnNames).
+			AddRow("s2", "1", "Safari on iPhone", "192.0.2.2", "ua2", now, now, expires, nil).
+			AddRow("s1", "1", "Firefox on Linux", "192.0.2.1", "ua1", now, now, expires, nil)).
+		RowsWillBeClosed()
+	mock.ExpectQuery(sessionSelectActiveQuery).WithArgs("2", now).WillReturnError(errors.New("ng"))
+
+	got, err := NewSessionDAO(tx).FindActiveByUserID("1", now)
+	if err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	want := []model.Session{
+		model.NewSession("s2", "1", "Safari on iPhone", "192.0.2.2", "ua2", now, now, expires, time.Time{}),
+		model.NewSession("s1", "1", "Firefox on Linux", "192.0.2.1", "ua1", now, now, expires, time.Time{}),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
+	}
+
+	if _, err := NewSessionDAO(tx).FindActiveByUserID("2", now); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
+
+func TestSessionDAO_Touch(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tx, mock := newMockTx(t)
+	mock.ExpectExec(sessionTouchQuery).WithArgs(now, "sid").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(sessionTouchQuery).WithArgs(now, "sid").WillReturnError(errors.New("ng"))
+
+	if err := NewSessionDAO(tx).Touch("sid", now); err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	if err := NewSessionDAO(tx).Touch("sid", now); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
+
+func TestSessionDAO_Revoke(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		result  sql.Result
+		wantErr error
+	}{
+		{name: "正常ケース", result: sqlmock.NewResult(0, 1), wantErr: nil},
+		{name: "異常ケース(セッションなし)", result: sqlmock.NewResult(0, 0), wantErr: repository.ErrSessionNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, mock := newMockTx(t)
+			mock.ExpectExec(sessionRevokeQuery).WithArgs(now, "sid", "1").WillReturnResult(tt.result)
+
+			if err := NewSessionDAO(tx).Revoke("1", "sid", now); err != tt.wantErr {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSessionDAO_DeleteExpired(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tx, mock := newMockTx(t)
+	mock.ExpectExec(sessionDeleteExpiredQry).WithArgs("1", now).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(sessionDeleteExpiredQry).WithArgs("1", now).WillReturnError(errors.New("ng"))
+
+	if err := NewSessionDAO(tx).DeleteExpired("1", now); err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	if err := NewSessionDAO(tx).DeleteExpired("1", now); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
@@ -0,0 +1,163 @@
+package dao
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+
+	"github.com/pkg/errors"
+)
+
+// ThreadDAO スレッドDAO
+type ThreadDAO struct {
+	tx *sql.Tx
+}
+
+var _ repository.Thread = (*ThreadDAO)(nil)
+
+// NewThreadDAO スレッドDAOを生成する
+func NewThreadDAO(tx *sql.Tx) *ThreadDAO {
+	return &ThreadDAO{
+		tx: tx,
+	}
+}
+
+// threadColumns スレッド取得時のカラム
+const threadColumns = "id, board_id, user_id, title, post_count, last_posted_at, created_at"
+
+// FindByBoardID 板のスレッドを新しい順に取得する(beforeIDを指定した場合はそれより前のもの)
+func (t *ThreadDAO) FindByBoardID(boardID string, beforeID string, limit int) ([]model.Thread, error) {
+	query := "select " + threadColumns + " from thread where board_id = ? order by id desc limit ?"
+	args := []any{boardID, limit}
+	if beforeID != "" {
+		query = "select " + threadColumns + " from thread where board_id = ? and id < ? order by id desc limit ?"
+		args = []any{boardID, beforeID, limit}
+	}
+
+	rows, err := t.tx.Query(query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "FindByBoardID error")
+	}
+	defer rows.Close()
+
+	threads := []model.Thread{}
+	for rows.Next() {
+		thread, err := scanThread(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "FindByBoardID error")
+		}
+		threads = append(threads, thread)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "FindByBoardID error")
+	}
+
+	return threads, nil
+}
+
+// FindByID IDでスレッドを取得する
+func (t *ThreadDAO) FindByID(id string) (model.Thread, error) {
+	thread, err := scanThread(t.tx.QueryRow("select "+threadColumns+" from thread where id = ?", id))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrThreadNotFound
+	} else if err != nil {
+		return nil, errors.Wrap(err, "FindByID error")
+	}
+
+	return thread, nil
+}
+
+// FindPostedSince 板のスレッドのうち最終投稿日時がsince以降のものをID順に取得する
+func (t *ThreadDAO) FindPostedSince(boardID string, since time.Time) ([]model.Thread, error) {
+	rows, err := t.tx.Query("select "+threadColumns+" from thread where board_id = ? and last_posted_at >= ? order by id", boardID, since)
+	if err != nil {
+		return nil, errors.Wrap(err, "FindPostedSince error")
+	}
+	defer rows.Close()
+
+	threads := []model.Thread{}
+	for rows.Next() {
+		thread, err := scanThread(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "FindPostedSince error")
+		}
+		threads = append(threads, thread)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "FindPostedSince error")
+	}
+
+	return threads, nil
+}
+
+// Regist 投稿のないスレッドを登録し、採番したIDを返す
+func (t *ThreadDAO) Regist(thread model.Thread, now time.Time) (string, error) {
+	userID := sql.NullString{String: thread.UserID(), Valid: thread.UserID() != ""}
+	result, err := t.tx.Exec(
+		"insert into thread (board_id, user_id, title, post_count, last_posted_at, created_at) values(?, ?, ?, 0, ?, ?)",
+		thread.BoardID(),
+		userID,
+		thread.Title(),
+		now,
+		now,
+	)
+	if err != nil {
+		return "", errors.Wrap(err, "Regist error")
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return "", errors.Wrap(err, "Regist error")
+	}
+
+	return strconv.FormatInt(id, 10), nil
+}
+
+// AddPost スレッドの投稿数を増やして最終投稿日時を更新し、追加する投稿の番号を返す
+// 更新した行はトランザクションの終了までロックされるため、同じスレッドへの投稿で番号は重複しない
+func (t *ThreadDAO) AddPost(id string, now time.Time) (int, error) {
+	result, err := t.tx.Exec(
+		"update thread set post_count = post_count + 1, last_posted_at = ? where id = ?",
+		now,
+		id,
+	)
+	if err != nil {
+		return 0, errors.Wrap(err, "AddPost error")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "AddPost error")
+	}
+	if affected == 0 {
+		return 0, repository.ErrThreadNotFound
+	}
+
+	var number int
+	if err := t.tx.QueryRow("select post_count from thread where id = ?", id).Scan(&number); err != nil {
+		return 0, errors.Wrap(err, "AddPost error")
+	}
+
+	return number, nil
+}
+
+// scanThread 1行分のスレッドを読み込む
+func scanThread(row rowScanner) (model.Thread, error) {
+	var (
+		id           string
+		boardID      string
+		userID       sql.NullString
+		title        string
+		postCount    int
+		lastPostedAt time.Time
+		createdAt    time.Time
+	)
+	if err := row.Scan(&id, &boardID, &userID, &title, &postCount, &lastPostedAt, &createdAt); err != nil {
+		return nil, err
+	}
+
+	return model.NewThread(id, boardID, userID.String, title, postCount, lastPostedAt, createdAt), nil
+}
@@ -0,0 +1,230 @@
+package dao
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+const (
+	threadSelectByBoardQuery       = "select id, board_id, user_id, title, post_count, last_posted_at, created_at from thread where board_id = ? order by id desc limit ?"
+	threadSelectByBoardBeforeQuery = "select id, board_id, user_id, title, post_count, last_posted_at, created_at from thread where board_id = ? and id < ? order by id desc limit ?"
+	threadSelectQuery              = "select id, board_id, user_id, title, post_count, last_posted_at, created_at from thread where id = ?"
+	threadSelectPostedSinceQuery   = "select id, board_id, user_id, title, post_count, last_posted_at, created_at from thread where board_id = ? and last_posted_at >= ? order by id"
+	threadInsertQuery              = "insert into thread (board_id, user_id, title, post_count, last_posted_at, created_at) values(?, ?, ?, 0, ?, ?)"
+	threadAddPostQuery             = "update thread set post_count = post_count + 1, last_posted_at = ? where id = ?"
+	threadPostCountQuery           = "select post_count from thread where id = ?"
+)
+
+var threadColumnNames = []string{"id", "board_id", "user_id", "title", "post_count", "last_posted_at", "created_at"}
+
+func TestNewThreadDAO(t *testing.T) {
+	tx := &sql.Tx{}
+	if got := NewThreadDAO(tx); !reflect.DeepEqual(got, &ThreadDAO{tx: tx}) {
+		t.Errorf("NewThreadDAO() = %v, want %v", got, &ThreadDAO{tx: tx})
+	}
+}
+
+func TestThreadDAO_FindByBoardID(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		beforeID string
+		expect   func(mock sqlmock.Sqlmock) *sqlmock.ExpectedQuery
+	}{
+		{
+			name:     "正常ケース",
+			beforeID: "",
+			expect: func(mock sqlmock.Sqlmock) *sqlmock.ExpectedQuery {
+				return mock.ExpectQuery(threadSelectByBoardQuery).WithArgs("1", 10)
+			},
+		},
+		{
+			name:     "正常ケース(beforeID指定)",
+			beforeID: "5",
+			expect: func(mock sqlmock.Sqlmock) *sqlmock.ExpectedQuery {
+				return mock.ExpectQuery(threadSelectByBoardBeforeQuery).WithArgs("1", "5", 10)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, mock := newMockTx(t)
+			tt.expect(mock).
+				WillReturnRows(sqlmock.NewRows(threadColumnNames).
+					AddRow("4", "1", "2", "title", 3, now, now).
+					AddRow("3", "1", nil, "退会", 1, now, now)).
+				RowsWillBeClosed()
+
+			got, err := NewThreadDAO(tx).FindByBoardID("1", tt.beforeID, 10)
+			if err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+			want := []model.Thread{
+				model.NewThread("4", "1", "2", "title", 3, now, now),
+				model.NewThread("3", "1", "", "退会", 1, now, now),
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
+			}
+		})
+	}
+
+	t.Run("異常ケース", func(t *testing.T) {
+		tx, mock := newMockTx(t)
+		mock.ExpectQuery(threadSelectByBoardQuery).WithArgs("1", 10).WillReturnError(errors.New("ng"))
+
+		if _, err := NewThreadDAO(tx).FindByBoardID("1", "", 10); err == nil {
+			t.Errorf("予期せぬ正常終了")
+		}
+	})
+}
+
+func TestThreadDAO_FindByID(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		want    model.Thread
+		wantErr error
+	}{
+		{
+			name:    "正常ケース",
+			rows:    sqlmock.NewRows(threadColumnNames).AddRow("1", "2", "3", "title", 4, now, now),
+			want:    model.NewThread("1", "2", "3", "title", 4, now, now),
+			wantErr: nil,
+		},
+		{
+			name:    "異常ケース(スレッドなし)",
+			rows:    sqlmock.NewRows(threadColumnNames),
+			want:    nil,
+			wantErr: repository.ErrThreadNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, mock := newMockTx(t)
+			mock.ExpectQuery(threadSelectQuery).WithArgs("1").WillReturnRows(tt.rows)
+
+			got, err := NewThreadDAO(tx).FindByID("1")
+			if err != tt.wantErr {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestThreadDAO_FindPostedSince(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tx, mock := newMockTx(t)
+	mock.ExpectQuery(threadSelectPostedSinceQuery).
+		WithArgs("1", now).
+		WillReturnRows(sqlmock.NewRows(threadColumnNames).
+			AddRow("3", "1", "2", "title", 3, now, now).
+			AddRow("4", "1", nil, "退会", 1, now, now)).
+		RowsWillBeClosed()
+	mock.ExpectQuery(threadSelectPostedSinceQuery).WithArgs("2", now).WillReturnError(errors.New("ng"))
+
+	got, err := NewThreadDAO(tx).FindPostedSince("1", now)
+	if err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	want := []model.Thread{
+		model.NewThread("3", "1", "2", "title", 3, now, now),
+		model.NewThread("4", "1", "", "退会", 1, now, now),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
+	}
+
+	if _, err := NewThreadDAO(tx).FindPostedSince("2", now); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
+
+func TestThreadDAO_Regist(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tx, mock := newMockTx(t)
+	mock.ExpectExec(threadInsertQuery).
+		WithArgs("1", sql.NullString{String: "2", Valid: true}, "title", now, now).
+		WillReturnResult(sqlmock.NewResult(7, 1))
+	mock.ExpectExec(threadInsertQuery).
+		WithArgs("1", sql.NullString{String: "2", Valid: true}, "title", now, now).
+		WillReturnError(errors.New("ng"))
+
+	thread := model.NewThread("", "1", "2", "title", 0, time.Time{}, time.Time{})
+	got, err := NewThreadDAO(tx).Regist(thread, now)
+	if err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	if got != "7" {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, "7")
+	}
+
+	if _, err := NewThreadDAO(tx).Regist(thread, now); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
+
+func TestThreadDAO_AddPost(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		expect  func(mock sqlmock.Sqlmock)
+		want    int
+		wantErr error
+	}{
+		{
+			name: "正常ケース",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(threadAddPostQuery).WithArgs(now, "1").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(threadPostCountQuery).WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"post_count"}).AddRow(5))
+			},
+			want:    5,
+			wantErr: nil,
+		},
+		{
+			name: "異常ケース(スレッドなし)",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(threadAddPostQuery).WithArgs(now, "1").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			want:    0,
+			wantErr: repository.ErrThreadNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, mock := newMockTx(t)
+			tt.expect(mock)
+
+			got, err := NewThreadDAO(tx).AddPost("1", now)
+			if err != tt.wantErr {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("異常ケース(更新失敗)", func(t *testing.T) {
+		tx, mock := newMockTx(t)
+		mock.ExpectExec(threadAddPostQuery).WithArgs(now, "1").WillReturnError(errors.New("ng"))
+
+		if _, err := NewThreadDAO(tx).AddPost("1", now); err == nil {
+			t.Errorf("予期せぬ正常終了")
+		}
+	})
+}
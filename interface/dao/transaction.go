@@ -1,21 +1,35 @@
 package dao
 
 import (
+	"context"
 	"database/sql"
 
 	"github.com/pkg/errors"
+
+	"GoBBS/interface/metrics"
+	"GoBBS/interface/tracing"
 )
 
+var tracer = tracing.Tracer("GoBBS/interface/dao")
+
 // ExecWithTx DB操作をトランザクションで実行する
-func ExecWithTx[T any](db *sql.DB, f func(tx *sql.Tx) (T, error)) (result T, err error) {
+// トランザクションのスパンを記録し、トランザクション内のSQL文のスパンはその子になる
+func ExecWithTx[T any](ctx context.Context, db *sql.DB, f func(tx *sql.Tx) (T, error)) (result T, err error) {
 	var resultZeroValue T
 
-	tx, err := db.Begin()
+	ctx, span := tracer.Start(ctx, "ExecWithTx")
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
+	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
 		return
 	}
 	defer func() {
 		if err != nil {
+			metrics.DBTransactions.WithLabelValues(metrics.TxRollback).Inc()
 			result = resultZeroValue
 			if rbErr := tx.Rollback(); rbErr != nil {
 				err = errors.Wrapf(err, "rollback error(%s)", rbErr.Error())
@@ -28,7 +42,9 @@ func ExecWithTx[T any](db *sql.DB, f func(tx *sql.Tx) (T, error)) (result T, err
 		return
 	}
 
-	err = tx.Commit()
+	if err = tx.Commit(); err == nil {
+		metrics.DBTransactions.WithLabelValues(metrics.TxCommit).Inc()
+	}
 
 	return
 }
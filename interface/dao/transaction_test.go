@@ -1,12 +1,15 @@
 package dao
 
 import (
+	"GoBBS/interface/metrics"
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
 func TestExecWithTx_Success(t *testing.T) {
@@ -19,7 +22,8 @@ func TestExecWithTx_Success(t *testing.T) {
 	mock.ExpectBegin()
 	mock.ExpectCommit()
 
-	got, err := ExecWithTx(db, func(tx *sql.Tx) (string, error) {
+	commits := testutil.ToFloat64(metrics.DBTransactions.WithLabelValues(metrics.TxCommit))
+	got, err := ExecWithTx(context.Background(), db, func(tx *sql.Tx) (string, error) {
 		return "ok", nil
 	})
 
@@ -27,6 +31,10 @@ func TestExecWithTx_Success(t *testing.T) {
 		t.Errorf("予期せぬエラー(error: %s)", err)
 	}
 
+	if got := testutil.ToFloat64(metrics.DBTransactions.WithLabelValues(metrics.TxCommit)); got != commits+1 {
+		t.Errorf("コミット数不一致 got: %#v want: %#v", got, commits+1)
+	}
+
 	want := "ok"
 	if got != want {
 		t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
@@ -46,7 +54,7 @@ func TestExecWithTx_BeginFail(t *testing.T) {
 
 	mock.ExpectBegin().WillReturnError(errors.New("ng"))
 
-	got, err := ExecWithTx(db, func(tx *sql.Tx) (string, error) {
+	got, err := ExecWithTx(context.Background(), db, func(tx *sql.Tx) (string, error) {
 		return "ok", nil
 	})
 
@@ -74,7 +82,8 @@ func TestExecWithTx_FuncFail(t *testing.T) {
 	mock.ExpectBegin()
 	mock.ExpectRollback()
 
-	got, err := ExecWithTx(db, func(tx *sql.Tx) (string, error) {
+	rollbacks := testutil.ToFloat64(metrics.DBTransactions.WithLabelValues(metrics.TxRollback))
+	got, err := ExecWithTx(context.Background(), db, func(tx *sql.Tx) (string, error) {
 		return "", errors.New("ng")
 	})
 
@@ -82,6 +91,10 @@ func TestExecWithTx_FuncFail(t *testing.T) {
 		t.Errorf("予期せぬ正常終了")
 	}
 
+	if got := testutil.ToFloat64(metrics.DBTransactions.WithLabelValues(metrics.TxRollback)); got != rollbacks+1 {
+		t.Errorf("ロールバック数不一致 got: %#v want: %#v", got, rollbacks+1)
+	}
+
 	want := ""
 	if got != want {
 		t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
@@ -102,7 +115,7 @@ func TestExecWithTx_FuncFailRollbackFail(t *testing.T) {
 	mock.ExpectBegin()
 	mock.ExpectRollback().WillReturnError(errors.New("ng"))
 
-	got, err := ExecWithTx(db, func(tx *sql.Tx) (string, error) {
+	got, err := ExecWithTx(context.Background(), db, func(tx *sql.Tx) (string, error) {
 		return "", errors.New("ng")
 	})
 
@@ -130,7 +143,7 @@ func TestExecWithTx_CommitFail(t *testing.T) {
 	mock.ExpectBegin()
 	mock.ExpectCommit().WillReturnError(errors.New("ng"))
 
-	got, err := ExecWithTx(db, func(tx *sql.Tx) (string, error) {
+	got, err := ExecWithTx(context.Background(), db, func(tx *sql.Tx) (string, error) {
 		return "ok", nil
 	})
 	fmt.Printf("%#v\n", mock)
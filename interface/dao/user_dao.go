@@ -25,29 +25,87 @@ func NewUserDAO(tx *sql.Tx) *UserDAO {
 	}
 }
 
+// userColumns ユーザー取得時のカラム
+const userColumns = "id, name, email, password, salt, display_name, bio, avatar_url, signature, created_at"
+
+// FindByID IDを指定してユーザーを取得する
+func (u *UserDAO) FindByID(id string) (model.User, error) {
+	return u.findOne("FindByID error", "select "+userColumns+" from user where id = ?", id)
+}
+
 // FindByEmail メールアドレスを指定してユーザーを取得する
 func (u *UserDAO) FindByEmail(email string) (model.User, error) {
-	rows, err := u.tx.Query("select id, name, email, password, salt from user where email = ?", email)
+	return u.findOne("FindByEmail error", "select "+userColumns+" from user where email = ?", email)
+}
+
+// FindByName 名前が一致するユーザーをID順にlimit件まで取得する(名前は一意ではない)
+func (u *UserDAO) FindByName(name string, limit int) ([]model.User, error) {
+	rows, err := u.tx.Query("select "+userColumns+" from user where name = ? order by id limit ?", name, limit)
 	if err != nil {
-		return nil, errors.Wrap(err, "FindByEmail error")
+		return nil, errors.Wrap(err, "FindByName error")
+	}
+	defer rows.Close()
+
+	users := []model.User{}
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "FindByName error")
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "FindByName error")
+	}
+
+	return users, nil
+}
+
+// findOne クエリに一致するユーザーを1件取得する
+func (u *UserDAO) findOne(errMessage string, query string, args ...any) (model.User, error) {
+	rows, err := u.tx.Query(query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, errMessage)
 	}
 	defer rows.Close()
 
-	var user dto.User
 	if rows.Next() {
-		if err := rows.Scan(&user.ID, &user.Name, &user.Email, &user.Password, &user.Salt); err != nil {
-			return nil, errors.Wrap(err, "FindByEmail error")
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, errMessage)
 		}
-		return user.MapUserModel(), nil
+		return user, nil
 	}
+
 	return nil, repository.ErrUserNotFound
 }
 
+// scanUser 1行分のユーザーを読み込む
+func scanUser(row rowScanner) (model.User, error) {
+	var user dto.User
+	if err := row.Scan(
+		&user.ID,
+		&user.Name,
+		&user.Email,
+		&user.Password,
+		&user.Salt,
+		&user.DisplayName,
+		&user.Bio,
+		&user.AvatarURL,
+		&user.Signature,
+		&user.JoinedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return user.MapUserModel(), nil
+}
+
 // Regist ユーザーを登録する
 func (u *UserDAO) Regist(user model.User, now time.Time) error {
 	stmt, err := u.tx.Prepare(`
-		insert into user (email, name, password, salt, created_at, updated_at)
-		values(?, ?, ?, ?, ?, ?)
+		insert into user (email, name, password, salt, display_name, bio, avatar_url, signature, created_at, updated_at)
+		values(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
 		return errors.Wrap(err, "Regist error")
@@ -58,11 +116,16 @@ func (u *UserDAO) Regist(user model.User, now time.Time) error {
 	if err != nil {
 		return errors.Wrap(err, "Regist error")
 	}
+	profile := user.Profile()
 	if _, err = stmt.Exec(
 		user.Email(),
 		user.Name(),
 		cryptPw,
 		salt,
+		profile.DisplayName,
+		profile.Bio,
+		profile.AvatarURL,
+		profile.Signature,
 		now,
 		now,
 	); err != nil {
@@ -80,15 +143,24 @@ func (u *UserDAO) Update(user model.User, now time.Time) error {
 	}
 	rows.Close()
 
-	stmt, err := u.tx.Prepare("update user set name = ?, password = ?, updated_at = ? where id = ?")
+	stmt, err := u.tx.Prepare(`
+		update user
+		set name = ?, password = ?, display_name = ?, bio = ?, avatar_url = ?, signature = ?, updated_at = ?
+		where id = ?
+	`)
 	if err != nil {
 		return errors.Wrap(err, "Update error")
 	}
 	defer stmt.Close()
 
+	profile := user.Profile()
 	if _, err := stmt.Exec(
 		user.Name(),
 		user.Password(),
+		profile.DisplayName,
+		profile.Bio,
+		profile.AvatarURL,
+		profile.Signature,
 		now,
 		user.ID(),
 	); err != nil {
@@ -49,17 +49,19 @@ func TestUserDAO_FindByEmailSuccess(t *testing.T) {
 	}
 	defer db.Close()
 
+	joinedAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
 	mock.ExpectBegin()
 	tx, err := db.Begin()
 	if err != nil {
 		t.Fatalf("txの生成に失敗(error: %s)", err)
 	}
 
-	mock.ExpectQuery("select id, name, email, password, salt from user where email = ?").
+	mock.ExpectQuery("select id, name, email, password, salt, display_name, bio, avatar_url, signature, created_at from user where email = ?").
 		WithArgs("email").
 		WillReturnRows(
-			sqlmock.NewRows([]string{"id", "name", "email", "password", "salt"}).
-				AddRow("1", "example 1", "email@email.com", "examplepas", "salt")).
+			sqlmock.NewRows([]string{"id", "name", "email", "password", "salt", "display_name", "bio", "avatar_url", "signature", "created_at"}).
+				AddRow("1", "example 1", "email@email.com", "examplepas", "salt", "display", "bio", "avatar", "signature", joinedAt)).
 		RowsWillBeClosed()
 
 	dao := NewUserDAO(tx)
@@ -68,7 +70,13 @@ func TestUserDAO_FindByEmailSuccess(t *testing.T) {
 		t.Errorf("予期せぬエラー(error: %s)", err)
 	}
 
-	want := model.NewUser("1", "example 1", "email@email.com", "examplepas", "salt")
+	want := model.NewUserWithProfile("1", "example 1", "email@email.com", "examplepas", "salt", model.Profile{
+		DisplayName: "display",
+		Bio:         "bio",
+		AvatarURL:   "avatar",
+		Signature:   "signature",
+		JoinedAt:    joinedAt,
+	})
 	if !reflect.DeepEqual(got, want) {
 		t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
 	}
@@ -91,7 +99,7 @@ func TestUserDAO_FindByEmailNotFound(t *testing.T) {
 		t.Fatalf("txの生成に失敗(error: %s)", err)
 	}
 
-	mock.ExpectQuery("select id, name, email, password, salt from user where email = ?").
+	mock.ExpectQuery("select id, name, email, password, salt, display_name, bio, avatar_url, signature, created_at from user where email = ?").
 		WithArgs("email").
 		WillReturnRows(
 			sqlmock.NewRows([]string{"id", "name", "email", "password"})).
@@ -125,7 +133,7 @@ func TestUserDAO_FindByEmailScanFail(t *testing.T) {
 		t.Fatalf("txの生成に失敗(error: %s", err)
 	}
 
-	mock.ExpectQuery("select id, name, email, password, salt from user where email = ?").
+	mock.ExpectQuery("select id, name, email, password, salt, display_name, bio, avatar_url, signature, created_at from user where email = ?").
 		WithArgs("email").
 		WillReturnRows(
 			sqlmock.NewRows([]string{"id", "name", "email", "password"}).
@@ -161,7 +169,7 @@ func TestUserDAO_FindByEmailQueryFail(t *testing.T) {
 		t.Fatalf("txの生成に失敗(error: %s", err)
 	}
 
-	mock.ExpectQuery("select id, name, email, password, salt from user where email = ?").
+	mock.ExpectQuery("select id, name, email, password, salt, display_name, bio, avatar_url, signature, created_at from user where email = ?").
 		WithArgs("email").
 		WillReturnError(errors.New("ng"))
 
@@ -180,6 +188,166 @@ func TestUserDAO_FindByEmailQueryFail(t *testing.T) {
 	}
 }
 
+func TestUserDAO_FindByIDSuccess(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmockの生成に失敗(error: %s)", err)
+	}
+	defer db.Close()
+
+	joinedAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("txの生成に失敗(error: %s)", err)
+	}
+
+	mock.ExpectQuery("select id, name, email, password, salt, display_name, bio, avatar_url, signature, created_at from user where id = ?").
+		WithArgs("1").
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name", "email", "password", "salt", "display_name", "bio", "avatar_url", "signature", "created_at"}).
+				AddRow("1", "example 1", "email@email.com", "examplepas", "salt", "display", "bio", "avatar", "signature", joinedAt)).
+		RowsWillBeClosed()
+
+	dao := NewUserDAO(tx)
+	got, err := dao.FindByID("1")
+	if err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+
+	want := model.NewUserWithProfile("1", "example 1", "email@email.com", "examplepas", "salt", model.Profile{
+		DisplayName: "display",
+		Bio:         "bio",
+		AvatarURL:   "avatar",
+		Signature:   "signature",
+		JoinedAt:    joinedAt,
+	})
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("予期せぬDB操作(error: %s)", err)
+	}
+}
+
+func TestUserDAO_FindByIDNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmockの生成に失敗(error: %s)", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("txの生成に失敗(error: %s)", err)
+	}
+
+	mock.ExpectQuery("select id, name, email, password, salt, display_name, bio, avatar_url, signature, created_at from user where id = ?").
+		WithArgs("1").
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name", "email", "password", "salt", "display_name", "bio", "avatar_url", "signature", "created_at"})).
+		RowsWillBeClosed()
+
+	dao := NewUserDAO(tx)
+	got, err := dao.FindByID("1")
+	if err != repository.ErrUserNotFound {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+
+	if got != nil {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, nil)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("予期せぬDB操作(error: %s)", err)
+	}
+}
+
+func TestUserDAO_FindByNameSuccess(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmockの生成に失敗(error: %s)", err)
+	}
+	defer db.Close()
+
+	joinedAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("txの生成に失敗(error: %s)", err)
+	}
+
+	mock.ExpectQuery("select id, name, email, password, salt, display_name, bio, avatar_url, signature, created_at from user where name = \\? order by id limit \\?").
+		WithArgs("example", 2).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name", "email", "password", "salt", "display_name", "bio", "avatar_url", "signature", "created_at"}).
+				AddRow("1", "example", "email1@email.com", "examplepas", "salt", "display", "bio", "avatar", "signature", joinedAt).
+				AddRow("3", "example", "email3@email.com", "examplepas", "salt", "", "", "", "", joinedAt)).
+		RowsWillBeClosed()
+
+	dao := NewUserDAO(tx)
+	got, err := dao.FindByName("example", 2)
+	if err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+
+	want := []model.User{
+		model.NewUserWithProfile("1", "example", "email1@email.com", "examplepas", "salt", model.Profile{
+			DisplayName: "display",
+			Bio:         "bio",
+			AvatarURL:   "avatar",
+			Signature:   "signature",
+			JoinedAt:    joinedAt,
+		}),
+		model.NewUserWithProfile("3", "example", "email3@email.com", "examplepas", "salt", model.Profile{
+			JoinedAt: joinedAt,
+		}),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("予期せぬDB操作(error: %s)", err)
+	}
+}
+
+func TestUserDAO_FindByNameQueryFail(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmockの生成に失敗(error: %s)", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("txの生成に失敗(error: %s)", err)
+	}
+
+	mock.ExpectQuery("select id, name, email, password, salt, display_name, bio, avatar_url, signature, created_at from user where name = \\? order by id limit \\?").
+		WithArgs("example", 2).
+		WillReturnError(errors.New("query error"))
+
+	dao := NewUserDAO(tx)
+	got, err := dao.FindByName("example", 2)
+	if err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+
+	if got != nil {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, nil)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("予期せぬDB操作(error: %s)", err)
+	}
+}
+
 func TestUserDAO_RegistSuccess(t *testing.T) {
 	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	if err != nil {
@@ -195,13 +363,13 @@ func TestUserDAO_RegistSuccess(t *testing.T) {
 
 	now := time.Now()
 	mock.ExpectPrepare(`
-		insert into user (email, name, password, salt, created_at, updated_at)
-		values(?, ?, ?, ?, ?, ?)
+		insert into user (email, name, password, salt, display_name, bio, avatar_url, signature, created_at, updated_at)
+		values(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`).
 		WillBeClosed()
 
-	mock.ExpectExec("insert into user (email, name, password, salt, created_at, updated_at) values(?, ?, ?, ?, ?, ?)").
-		WithArgs("email@email.com", "example 1", "examplepas", "examplesalt", now, now).
+	mock.ExpectExec("insert into user (email, name, password, salt, display_name, bio, avatar_url, signature, created_at, updated_at) values(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)").
+		WithArgs("email@email.com", "example 1", "examplepas", "examplesalt", "display", "bio", "avatar", "signature", now, now).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	ctrl := gomock.NewController(t)
@@ -210,6 +378,12 @@ func TestUserDAO_RegistSuccess(t *testing.T) {
 	mockUser := mock_model.NewMockUser(ctrl)
 	gomock.InOrder(
 		mockUser.EXPECT().EncryptPassword().Return("examplepas", "examplesalt", nil),
+		mockUser.EXPECT().Profile().Return(model.Profile{
+			DisplayName: "display",
+			Bio:         "bio",
+			AvatarURL:   "avatar",
+			Signature:   "signature",
+		}),
 		mockUser.EXPECT().Email().Return("email@email.com"),
 		mockUser.EXPECT().Name().Return("example 1"),
 	)
@@ -238,13 +412,13 @@ func TestUserDAO_RegistInsertFail(t *testing.T) {
 
 	now := time.Now()
 	mock.ExpectPrepare(`
-		insert into user (email, name, password, salt, created_at, updated_at)
-		values(?, ?, ?, ?, ?, ?)
+		insert into user (email, name, password, salt, display_name, bio, avatar_url, signature, created_at, updated_at)
+		values(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`).
 		WillBeClosed()
 
-	mock.ExpectExec("insert into user (email, name, password, salt, created_at, updated_at) values(?, ?, ?, ?, ?, ?)").
-		WithArgs("email@email.com", "example 1", "examplepas", "examplesalt", now, now).
+	mock.ExpectExec("insert into user (email, name, password, salt, display_name, bio, avatar_url, signature, created_at, updated_at) values(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)").
+		WithArgs("email@email.com", "example 1", "examplepas", "examplesalt", "display", "bio", "avatar", "signature", now, now).
 		WillReturnError(errors.New("ng"))
 
 	ctrl := gomock.NewController(t)
@@ -253,6 +427,12 @@ func TestUserDAO_RegistInsertFail(t *testing.T) {
 	mockUser := mock_model.NewMockUser(ctrl)
 	gomock.InOrder(
 		mockUser.EXPECT().EncryptPassword().Return("examplepas", "examplesalt", nil),
+		mockUser.EXPECT().Profile().Return(model.Profile{
+			DisplayName: "display",
+			Bio:         "bio",
+			AvatarURL:   "avatar",
+			Signature:   "signature",
+		}),
 		mockUser.EXPECT().Email().Return("email@email.com"),
 		mockUser.EXPECT().Name().Return("example 1"),
 	)
@@ -282,8 +462,8 @@ func TestUserDAO_RegistPrepareFail(t *testing.T) {
 
 	now := time.Now()
 	mock.ExpectPrepare(`
-		insert into user (email, name, password, salt, created_at, updated_at)
-		values(?, ?, ?, ?, ?, ?)
+		insert into user (email, name, password, salt, display_name, bio, avatar_url, signature, created_at, updated_at)
+		values(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`).
 		WillReturnError(errors.New("ng"))
 
@@ -320,12 +500,12 @@ func TestUserDAO_UpdateSuccess(t *testing.T) {
 			AddRow("1", "example 1", "email@email.com", "examplepas")).
 		RowsWillBeClosed()
 
-	mock.ExpectPrepare(`update user set name = ?, password = ?, updated_at = ? where id = ?`).
+	mock.ExpectPrepare(`update user set name = ?, password = ?, display_name = ?, bio = ?, avatar_url = ?, signature = ?, updated_at = ? where id = ?`).
 		WillBeClosed()
 
 	now := time.Now()
-	mock.ExpectExec("update user set name = ?, password = ?, updated_at = ? where id = ?").
-		WithArgs("example 1", "examplepas", now, "1").
+	mock.ExpectExec("update user set name = ?, password = ?, display_name = ?, bio = ?, avatar_url = ?, signature = ?, updated_at = ? where id = ?").
+		WithArgs("example 1", "examplepas", "display", "bio", "avatar", "signature", now, "1").
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	ctrl := gomock.NewController(t)
@@ -334,6 +514,12 @@ func TestUserDAO_UpdateSuccess(t *testing.T) {
 	mockUser := mock_model.NewMockUser(ctrl)
 	gomock.InOrder(
 		mockUser.EXPECT().ID().Return("1"),
+		mockUser.EXPECT().Profile().Return(model.Profile{
+			DisplayName: "display",
+			Bio:         "bio",
+			AvatarURL:   "avatar",
+			Signature:   "signature",
+		}),
 		mockUser.EXPECT().Name().Return("example 1"),
 		mockUser.EXPECT().Password().Return("examplepas"),
 		mockUser.EXPECT().ID().Return("1"),
@@ -367,12 +553,12 @@ func TestUserDAO_UpdateFail(t *testing.T) {
 			AddRow("1", "example 1", "email@email.com", "examplepas")).
 		RowsWillBeClosed()
 
-	mock.ExpectPrepare(`update user set name = ?, password = ?, updated_at = ? where id = ?`).
+	mock.ExpectPrepare(`update user set name = ?, password = ?, display_name = ?, bio = ?, avatar_url = ?, signature = ?, updated_at = ? where id = ?`).
 		WillBeClosed()
 
 	now := time.Now()
-	mock.ExpectExec("update user set name = ?, password = ?, updated_at = ? where id = ?").
-		WithArgs("example 1", "examplepas", now, "1").
+	mock.ExpectExec("update user set name = ?, password = ?, display_name = ?, bio = ?, avatar_url = ?, signature = ?, updated_at = ? where id = ?").
+		WithArgs("example 1", "examplepas", "display", "bio", "avatar", "signature", now, "1").
 		WillReturnError(errors.New("ng"))
 
 	ctrl := gomock.NewController(t)
@@ -381,6 +567,12 @@ func TestUserDAO_UpdateFail(t *testing.T) {
 	mockUser := mock_model.NewMockUser(ctrl)
 	gomock.InOrder(
 		mockUser.EXPECT().ID().Return("1"),
+		mockUser.EXPECT().Profile().Return(model.Profile{
+			DisplayName: "display",
+			Bio:         "bio",
+			AvatarURL:   "avatar",
+			Signature:   "signature",
+		}),
 		mockUser.EXPECT().Name().Return("example 1"),
 		mockUser.EXPECT().Password().Return("examplepas"),
 		mockUser.EXPECT().ID().Return("1"),
@@ -414,7 +606,7 @@ func TestUserDAO_UpdatePrepareFail(t *testing.T) {
 			AddRow("1", "example 1", "email@email.com", "examplepas")).
 		RowsWillBeClosed()
 
-	mock.ExpectPrepare(`update user set name = ?, password = ?, updated_at = ? where id = ?`).
+	mock.ExpectPrepare(`update user set name = ?, password = ?, display_name = ?, bio = ?, avatar_url = ?, signature = ?, updated_at = ? where id = ?`).
 		WillReturnError(errors.New("ng"))
 
 	ctrl := gomock.NewController(t)
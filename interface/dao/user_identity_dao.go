@@ -0,0 +1,77 @@
+package dao
+
+import (
+	"database/sql"
+	"time"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+
+	"github.com/pkg/errors"
+)
+
+// UserIdentityDAO UserIdentityDAO
+type UserIdentityDAO struct {
+	tx *sql.Tx
+}
+
+var _ repository.UserIdentity = (*UserIdentityDAO)(nil)
+
+// NewUserIdentityDAO UserIdentityDAOを生成する
+func NewUserIdentityDAO(tx *sql.Tx) *UserIdentityDAO {
+	return &UserIdentityDAO{
+		tx: tx,
+	}
+}
+
+// FindByIssuerSubject 発行者とsubjectから外部アカウントを取得する
+func (u *UserIdentityDAO) FindByIssuerSubject(issuer string, subject string) (model.UserIdentity, error) {
+	var (
+		userID    string
+		email     string
+		createdAt time.Time
+	)
+	err := u.tx.QueryRow(
+		"select user_id, email, created_at from user_identity where issuer = ? and subject = ?",
+		issuer,
+		subject,
+	).Scan(&userID, &email, &createdAt)
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrUserIdentityNotFound
+	} else if err != nil {
+		return nil, errors.Wrap(err, "FindByIssuerSubject error")
+	}
+
+	return model.NewUserIdentity(issuer, subject, userID, email, createdAt), nil
+}
+
+// Regist 外部アカウントを登録する
+func (u *UserIdentityDAO) Regist(identity model.UserIdentity) error {
+	if _, err := u.tx.Exec(`
+		insert into user_identity (issuer, subject, user_id, email, created_at)
+		values(?, ?, ?, ?, ?)
+	`,
+		identity.Issuer(),
+		identity.Subject(),
+		identity.UserID(),
+		identity.Email(),
+		identity.CreatedAt(),
+	); err != nil {
+		return errors.Wrap(err, "Regist error")
+	}
+
+	return nil
+}
+
+// ExistsByUserID ユーザーに紐づく外部アカウントがあるか判定する
+func (u *UserIdentityDAO) ExistsByUserID(userID string) (bool, error) {
+	var exists bool
+	if err := u.tx.QueryRow(
+		"select exists(select 1 from user_identity where user_id = ?)",
+		userID,
+	).Scan(&exists); err != nil {
+		return false, errors.Wrap(err, "ExistsByUserID error")
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,121 @@
+package dao
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+const (
+	userIdentitySelectQuery = "select user_id, email, created_at from user_identity where issuer = ? and subject = ?"
+	userIdentityInsertQuery = "insert into user_identity (issuer, subject, user_id, email, created_at) values(?, ?, ?, ?, ?)"
+	userIdentityExistsQuery = "select exists(select 1 from user_identity where user_id = ?)"
+)
+
+func TestNewUserIdentityDAO(t *testing.T) {
+	tx := &sql.Tx{}
+	if got := NewUserIdentityDAO(tx); !reflect.DeepEqual(got, &UserIdentityDAO{tx: tx}) {
+		t.Errorf("NewUserIdentityDAO() = %v, want %v", got, &UserIdentityDAO{tx: tx})
+	}
+}
+
+func TestUserIdentityDAO_FindByIssuerSubject(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	columns := []string{"user_id", "email", "created_at"}
+
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		err     error
+		want    model.UserIdentity
+		wantErr bool
+		errIs   error
+	}{
+		{
+			name: "正常ケース",
+			rows: sqlmock.NewRows(columns).AddRow("1", "a@example.com", now),
+			want: model.NewUserIdentity("https://idp.example.com", "sub-1", "1", "a@example.com", now),
+		},
+		{
+			name:    "異常ケース(外部アカウントなし)",
+			rows:    sqlmock.NewRows(columns),
+			wantErr: true,
+			errIs:   repository.ErrUserIdentityNotFound,
+		},
+		{
+			name:    "異常ケース(DBエラー)",
+			err:     errors.New("ng"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, mock := newMockTx(t)
+			expect := mock.ExpectQuery(userIdentitySelectQuery).WithArgs("https://idp.example.com", "sub-1")
+			if tt.err != nil {
+				expect.WillReturnError(tt.err)
+			} else {
+				expect.WillReturnRows(tt.rows)
+			}
+
+			got, err := NewUserIdentityDAO(tx).FindByIssuerSubject("https://idp.example.com", "sub-1")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("予期せぬエラー(error: %s)", err)
+			}
+			if tt.errIs != nil && err != tt.errIs {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.errIs)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserIdentityDAO_Regist(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tx, mock := newMockTx(t)
+	mock.ExpectExec(userIdentityInsertQuery).
+		WithArgs("https://idp.example.com", "sub-1", "1", "a@example.com", now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(userIdentityInsertQuery).
+		WithArgs("https://idp.example.com", "sub-1", "1", "a@example.com", now).
+		WillReturnError(errors.New("ng"))
+
+	identity := model.NewUserIdentity("https://idp.example.com", "sub-1", "1", "a@example.com", now)
+	if err := NewUserIdentityDAO(tx).Regist(identity); err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	if err := NewUserIdentityDAO(tx).Regist(identity); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
+
+func TestUserIdentityDAO_ExistsByUserID(t *testing.T) {
+	tx, mock := newMockTx(t)
+	mock.ExpectQuery(userIdentityExistsQuery).WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(userIdentityExistsQuery).WithArgs("2").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectQuery(userIdentityExistsQuery).WithArgs("3").WillReturnError(errors.New("ng"))
+
+	for _, tt := range []struct {
+		userID string
+		want   bool
+	}{{"1", true}, {"2", false}} {
+		got, err := NewUserIdentityDAO(tx).ExistsByUserID(tt.userID)
+		if err != nil {
+			t.Fatalf("予期せぬエラー(error: %s)", err)
+		}
+		if got != tt.want {
+			t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+		}
+	}
+	if _, err := NewUserIdentityDAO(tx).ExistsByUserID("3"); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
@@ -0,0 +1,310 @@
+package dao
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+
+	"github.com/pkg/errors"
+)
+
+// WebhookDAO WebhookDAO
+type WebhookDAO struct {
+	tx *sql.Tx
+}
+
+var _ repository.Webhook = (*WebhookDAO)(nil)
+
+// NewWebhookDAO WebhookDAOを生成する
+func NewWebhookDAO(tx *sql.Tx) *WebhookDAO {
+	return &WebhookDAO{
+		tx: tx,
+	}
+}
+
+const (
+	// webhookColumns Webhook取得時のカラム
+	webhookColumns = "id, board_id, url, secret, events, created_at"
+	// webhookDeliveryColumns 配信取得時のカラム
+	webhookDeliveryColumns = "id, webhook_id, event, payload, status, attempts, next_attempt_at, last_status_code, last_error, created_at, delivered_at"
+)
+
+// Regist Webhookを登録し、採番したIDを返す
+func (w *WebhookDAO) Regist(webhook model.Webhook) (string, error) {
+	result, err := w.tx.Exec(
+		"insert into webhook (board_id, url, secret, events, created_at) values(?, ?, ?, ?, ?)",
+		webhook.BoardID(),
+		webhook.URL(),
+		webhook.Secret(),
+		joinWebhookEvents(webhook.Events()),
+		webhook.CreatedAt(),
+	)
+	if err != nil {
+		return "", errors.Wrap(err, "Regist error")
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return "", errors.Wrap(err, "Regist error")
+	}
+
+	return strconv.FormatInt(id, 10), nil
+}
+
+// FindByID IDからWebhookを取得する
+func (w *WebhookDAO) FindByID(id string) (model.Webhook, error) {
+	webhook, err := scanWebhook(w.tx.QueryRow("select "+webhookColumns+" from webhook where id = ?", id))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrWebhookNotFound
+	} else if err != nil {
+		return nil, errors.Wrap(err, "FindByID error")
+	}
+
+	return webhook, nil
+}
+
+// FindByBoardID 板のWebhookを登録順に取得する
+func (w *WebhookDAO) FindByBoardID(boardID string) ([]model.Webhook, error) {
+	rows, err := w.tx.Query("select "+webhookColumns+" from webhook where board_id = ? order by id", boardID)
+	if err != nil {
+		return nil, errors.Wrap(err, "FindByBoardID error")
+	}
+	defer rows.Close()
+
+	webhooks := []model.Webhook{}
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "FindByBoardID error")
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "FindByBoardID error")
+	}
+
+	return webhooks, nil
+}
+
+// Delete Webhookを削除する(配信は外部キーにより削除される)
+func (w *WebhookDAO) Delete(id string) error {
+	result, err := w.tx.Exec("delete from webhook where id = ?", id)
+	if err != nil {
+		return errors.Wrap(err, "Delete error")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "Delete error")
+	}
+	if affected == 0 {
+		return repository.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// RegistDelivery ドメインイベントの配信をアウトボックスに登録し、採番したIDを返す
+// 同じWebhookへの同じイベントの配信が登録済みの場合は登録せずfalseを返す
+func (w *WebhookDAO) RegistDelivery(eventID string, delivery model.WebhookDelivery) (string, bool, error) {
+	result, err := w.tx.Exec(`
+		insert ignore into webhook_delivery (webhook_id, event_id, event, payload, status, attempts, next_attempt_at, created_at)
+		values(?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		delivery.WebhookID(),
+		eventID,
+		string(delivery.Event()),
+		delivery.Payload(),
+		string(delivery.Status()),
+		delivery.Attempts(),
+		delivery.NextAttemptAt(),
+		delivery.CreatedAt(),
+	)
+	if err != nil {
+		return "", false, errors.Wrap(err, "RegistDelivery error")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return "", false, errors.Wrap(err, "RegistDelivery error")
+	}
+	if affected == 0 {
+		return "", false, nil
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return "", false, errors.Wrap(err, "RegistDelivery error")
+	}
+
+	return strconv.FormatInt(id, 10), true, nil
+}
+
+// FindDueDeliveries 送信時刻に達した送信待ちの配信を取得する
+// 複数のディスパッチャーが同じ配信を取得しないよう、他のtxがロック中の行は読み飛ばす
+func (w *WebhookDAO) FindDueDeliveries(now time.Time, limit int) ([]model.WebhookDelivery, error) {
+	rows, err := w.tx.Query(`
+		select `+webhookDeliveryColumns+` from webhook_delivery
+		where status = ? and next_attempt_at <= ?
+		order by next_attempt_at, id limit ?
+		for update skip locked
+	`,
+		string(model.WebhookDeliveryPending),
+		now,
+		limit,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "FindDueDeliveries error")
+	}
+
+	deliveries, err := scanWebhookDeliveries(rows)
+	if err != nil {
+		return nil, errors.Wrap(err, "FindDueDeliveries error")
+	}
+
+	return deliveries, nil
+}
+
+// UpdateDelivery 配信の状態を更新する
+func (w *WebhookDAO) UpdateDelivery(delivery model.WebhookDelivery) error {
+	if _, err := w.tx.Exec(`
+		update webhook_delivery
+		set status = ?, attempts = ?, next_attempt_at = ?, last_status_code = ?, last_error = ?, delivered_at = ?
+		where id = ?
+	`,
+		string(delivery.Status()),
+		delivery.Attempts(),
+		delivery.NextAttemptAt(),
+		sql.NullInt64{Int64: int64(delivery.LastStatusCode()), Valid: delivery.LastStatusCode() != 0},
+		sql.NullString{String: delivery.LastError(), Valid: delivery.LastError() != ""},
+		sql.NullTime{Time: delivery.DeliveredAt(), Valid: !delivery.DeliveredAt().IsZero()},
+		delivery.ID(),
+	); err != nil {
+		return errors.Wrap(err, "UpdateDelivery error")
+	}
+
+	return nil
+}
+
+// FindDeliveriesByWebhookID Webhookの配信を新しい順に取得する(beforeIDを指定した場合はそれより前のもの)
+func (w *WebhookDAO) FindDeliveriesByWebhookID(webhookID string, beforeID string, limit int) ([]model.WebhookDelivery, error) {
+	query := "select " + webhookDeliveryColumns + " from webhook_delivery where webhook_id = ? order by id desc limit ?"
+	args := []any{webhookID, limit}
+	if beforeID != "" {
+		query = "select " + webhookDeliveryColumns + " from webhook_delivery where webhook_id = ? and id < ? order by id desc limit ?"
+		args = []any{webhookID, beforeID, limit}
+	}
+
+	rows, err := w.tx.Query(query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "FindDeliveriesByWebhookID error")
+	}
+
+	deliveries, err := scanWebhookDeliveries(rows)
+	if err != nil {
+		return nil, errors.Wrap(err, "FindDeliveriesByWebhookID error")
+	}
+
+	return deliveries, nil
+}
+
+// rowScanner *sql.Rowと*sql.Rowsの共通部分
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanWebhook 1行分のWebhookを読み込む
+func scanWebhook(row rowScanner) (model.Webhook, error) {
+	var (
+		id        string
+		boardID   string
+		url       string
+		secret    string
+		events    string
+		createdAt time.Time
+	)
+	if err := row.Scan(&id, &boardID, &url, &secret, &events, &createdAt); err != nil {
+		return nil, err
+	}
+
+	return model.NewWebhook(id, boardID, url, secret, splitWebhookEvents(events), createdAt), nil
+}
+
+// scanWebhookDeliveries 配信を読み込み、rowsを閉じる
+func scanWebhookDeliveries(rows *sql.Rows) ([]model.WebhookDelivery, error) {
+	defer rows.Close()
+
+	deliveries := []model.WebhookDelivery{}
+	for rows.Next() {
+		var (
+			id             string
+			webhookID      string
+			event          string
+			payload        []byte
+			status         string
+			attempts       int
+			nextAttemptAt  time.Time
+			lastStatusCode sql.NullInt64
+			lastError      sql.NullString
+			createdAt      time.Time
+			deliveredAt    sql.NullTime
+		)
+		if err := rows.Scan(
+			&id,
+			&webhookID,
+			&event,
+			&payload,
+			&status,
+			&attempts,
+			&nextAttemptAt,
+			&lastStatusCode,
+			&lastError,
+			&createdAt,
+			&deliveredAt,
+		); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, model.NewWebhookDelivery(
+			id,
+			webhookID,
+			model.WebhookEvent(event),
+			payload,
+			model.WebhookDeliveryStatus(status),
+			attempts,
+			nextAttemptAt,
+			int(lastStatusCode.Int64),
+			lastError.String,
+			createdAt,
+			deliveredAt.Time,
+		))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// joinWebhookEvents イベントをカンマ区切りの文字列にする
+func joinWebhookEvents(events []model.WebhookEvent) string {
+	s := make([]string, len(events))
+	for i, e := range events {
+		s[i] = string(e)
+	}
+	return strings.Join(s, ",")
+}
+
+// splitWebhookEvents カンマ区切りの文字列をイベントに戻す
+func splitWebhookEvents(s string) []model.WebhookEvent {
+	events := []model.WebhookEvent{}
+	for _, e := range strings.Split(s, ",") {
+		if e != "" {
+			events = append(events, model.WebhookEvent(e))
+		}
+	}
+	return events
+}
@@ -0,0 +1,293 @@
+package dao
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+const (
+	webhookInsertQuery             = "insert into webhook (board_id, url, secret, events, created_at) values(?, ?, ?, ?, ?)"
+	webhookSelectQuery             = "select id, board_id, url, secret, events, created_at from webhook where id = ?"
+	webhookSelectByBoardQuery      = "select id, board_id, url, secret, events, created_at from webhook where board_id = ? order by id"
+	webhookDeleteQuery             = "delete from webhook where id = ?"
+	webhookDeliveryInsertQuery     = "insert ignore into webhook_delivery (webhook_id, event_id, event, payload, status, attempts, next_attempt_at, created_at) values(?, ?, ?, ?, ?, ?, ?, ?)"
+	webhookDeliveryDueQuery        = "select id, webhook_id, event, payload, status, attempts, next_attempt_at, last_status_code, last_error, created_at, delivered_at from webhook_delivery where status = ? and next_attempt_at <= ? order by next_attempt_at, id limit ? for update skip locked"
+	webhookDeliveryUpdateQuery     = "update webhook_delivery set status = ?, attempts = ?, next_attempt_at = ?, last_status_code = ?, last_error = ?, delivered_at = ? where id = ?"
+	webhookDeliverySelectQuery     = "select id, webhook_id, event, payload, status, attempts, next_attempt_at, last_status_code, last_error, created_at, delivered_at from webhook_delivery where webhook_id = ? order by id desc limit ?"
+	webhookDeliverySelectBeforeQry = "select id, webhook_id, event, payload, status, attempts, next_attempt_at, last_status_code, last_error, created_at, delivered_at from webhook_delivery where webhook_id = ? and id < ? order by id desc limit ?"
+)
+
+var (
+	webhookColumnNames         = []string{"id", "board_id", "url", "secret", "events", "created_at"}
+	webhookDeliveryColumnNames = []string{"id", "webhook_id", "event", "payload", "status", "attempts", "next_attempt_at", "last_status_code", "last_error", "created_at", "delivered_at"}
+)
+
+func TestNewWebhookDAO(t *testing.T) {
+	tx := &sql.Tx{}
+	if got := NewWebhookDAO(tx); !reflect.DeepEqual(got, &WebhookDAO{tx: tx}) {
+		t.Errorf("NewWebhookDAO() = %v, want %v", got, &WebhookDAO{tx: tx})
+	}
+}
+
+func TestWebhookDAO_Regist(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tx, mock := newMockTx(t)
+	mock.ExpectExec(webhookInsertQuery).
+		WithArgs("2", "https://example.com/hook", "secret", "thread.created,post.hidden", now).
+		WillReturnResult(sqlmock.NewResult(5, 1))
+	mock.ExpectExec(webhookInsertQuery).
+		WithArgs("3", "https://example.com/hook", "secret", "post.created", now).
+		WillReturnError(errors.New("ng"))
+
+	webhook := model.NewWebhook("", "2", "https://example.com/hook", "secret", []model.WebhookEvent{model.WebhookThreadCreated, model.WebhookPostHidden}, now)
+	got, err := NewWebhookDAO(tx).Regist(webhook)
+	if err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	if got != "5" {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, "5")
+	}
+
+	webhook = model.NewWebhook("", "3", "https://example.com/hook", "secret", []model.WebhookEvent{model.WebhookPostCreated}, now)
+	if _, err := NewWebhookDAO(tx).Regist(webhook); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
+
+func TestWebhookDAO_FindByID(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		want    model.Webhook
+		wantErr error
+	}{
+		{
+			name: "正常ケース",
+			rows: sqlmock.NewRows(webhookColumnNames).AddRow("1", "2", "https://example.com/hook", "secret", "thread.created,post.created", now),
+			want: model.NewWebhook("1", "2", "https://example.com/hook", "secret",
+				[]model.WebhookEvent{model.WebhookThreadCreated, model.WebhookPostCreated}, now),
+			wantErr: nil,
+		},
+		{
+			name:    "異常ケース(Webhookなし)",
+			rows:    sqlmock.NewRows(webhookColumnNames),
+			want:    nil,
+			wantErr: repository.ErrWebhookNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, mock := newMockTx(t)
+			mock.ExpectQuery(webhookSelectQuery).WithArgs("1").WillReturnRows(tt.rows)
+
+			got, err := NewWebhookDAO(tx).FindByID("1")
+			if err != tt.wantErr {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhookDAO_FindByBoardID(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tx, mock := newMockTx(t)
+	mock.ExpectQuery(webhookSelectByBoardQuery).
+		WithArgs("2").
+		WillReturnRows(sqlmock.NewRows(webhookColumnNames).
+			AddRow("1", "2", "https://example.com/a", "s1", "post.hidden", now).
+			AddRow("3", "2", "https://example.com/b", "s2", "", now)).
+		RowsWillBeClosed()
+	mock.ExpectQuery(webhookSelectByBoardQuery).WithArgs("3").WillReturnError(errors.New("ng"))
+
+	got, err := NewWebhookDAO(tx).FindByBoardID("2")
+	if err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	want := []model.Webhook{
+		model.NewWebhook("1", "2", "https://example.com/a", "s1", []model.WebhookEvent{model.WebhookPostHidden}, now),
+		model.NewWebhook("3", "2", "https://example.com/b", "s2", []model.WebhookEvent{}, now),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
+	}
+
+	if _, err := NewWebhookDAO(tx).FindByBoardID("3"); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
+
+func TestWebhookDAO_Delete(t *testing.T) {
+	tests := []struct {
+		name    string
+		result  sql.Result
+		wantErr error
+	}{
+		{name: "正常ケース", result: sqlmock.NewResult(0, 1), wantErr: nil},
+		{name: "異常ケース(Webhookなし)", result: sqlmock.NewResult(0, 0), wantErr: repository.ErrWebhookNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, mock := newMockTx(t)
+			mock.ExpectExec(webhookDeleteQuery).WithArgs("1").WillReturnResult(tt.result)
+
+			if err := NewWebhookDAO(tx).Delete("1"); err != tt.wantErr {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWebhookDAO_RegistDelivery(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tx, mock := newMockTx(t)
+	mock.ExpectExec(webhookDeliveryInsertQuery).
+		WithArgs("1", "9", "post.created", []byte(`{"event":"post.created"}`), "pending", 0, now, now).
+		WillReturnResult(sqlmock.NewResult(7, 1))
+	mock.ExpectExec(webhookDeliveryInsertQuery).
+		WithArgs("1", "9", "post.created", []byte(`{"event":"post.created"}`), "pending", 0, now, now).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(webhookDeliveryInsertQuery).
+		WithArgs("1", "9", "post.created", []byte(`{"event":"post.created"}`), "pending", 0, now, now).
+		WillReturnError(errors.New("ng"))
+
+	delivery := model.NewWebhookDelivery("", "1", model.WebhookPostCreated, []byte(`{"event":"post.created"}`),
+		model.WebhookDeliveryPending, 0, now, 0, "", now, time.Time{})
+	got, registered, err := NewWebhookDAO(tx).RegistDelivery("9", delivery)
+	if err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	if got != "7" || !registered {
+		t.Errorf("戻り値不一致 got: %#v, %v want: %#v, true", got, registered, "7")
+	}
+
+	// 同じWebhookへの同じイベントの配信は登録済み
+	got, registered, err = NewWebhookDAO(tx).RegistDelivery("9", delivery)
+	if err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	if got != "" || registered {
+		t.Errorf("戻り値不一致 got: %#v, %v want: \"\", false", got, registered)
+	}
+
+	if _, _, err := NewWebhookDAO(tx).RegistDelivery("9", delivery); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
+
+func TestWebhookDAO_FindDueDeliveries(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tx, mock := newMockTx(t)
+	mock.ExpectQuery(webhookDeliveryDueQuery).
+		WithArgs("pending", now, 10).
+		WillReturnRows(sqlmock.NewRows(webhookDeliveryColumnNames).
+			AddRow("1", "2", "post.created", []byte("{}"), "pending", 0, now, nil, nil, now, nil).
+			AddRow("3", "2", "post.hidden", []byte("{}"), "pending", 2, now, 500, "server error", now, nil)).
+		RowsWillBeClosed()
+	mock.ExpectQuery(webhookDeliveryDueQuery).WithArgs("pending", now, 20).WillReturnError(errors.New("ng"))
+
+	got, err := NewWebhookDAO(tx).FindDueDeliveries(now, 10)
+	if err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	want := []model.WebhookDelivery{
+		model.NewWebhookDelivery("1", "2", model.WebhookPostCreated, []byte("{}"), model.WebhookDeliveryPending, 0, now, 0, "", now, time.Time{}),
+		model.NewWebhookDelivery("3", "2", model.WebhookPostHidden, []byte("{}"), model.WebhookDeliveryPending, 2, now, 500, "server error", now, time.Time{}),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
+	}
+
+	if _, err := NewWebhookDAO(tx).FindDueDeliveries(now, 20); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+}
+
+func TestWebhookDAO_UpdateDelivery(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	next := now.Add(time.Minute)
+
+	tests := []struct {
+		name     string
+		delivery model.WebhookDelivery
+		args     []driver.Value
+	}{
+		{
+			name:     "正常ケース(送信成功)",
+			delivery: model.NewWebhookDelivery("1", "2", model.WebhookPostCreated, nil, model.WebhookDeliverySucceeded, 1, now, 200, "", now, now),
+			args:     []driver.Value{"succeeded", 1, now, int64(200), nil, now, "1"},
+		},
+		{
+			name:     "正常ケース(再送待ち)",
+			delivery: model.NewWebhookDelivery("1", "2", model.WebhookPostCreated, nil, model.WebhookDeliveryPending, 2, next, 0, "timeout", now, time.Time{}),
+			args:     []driver.Value{"pending", 2, next, nil, "timeout", nil, "1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, mock := newMockTx(t)
+			mock.ExpectExec(webhookDeliveryUpdateQuery).WithArgs(tt.args...).WillReturnResult(sqlmock.NewResult(0, 1))
+
+			if err := NewWebhookDAO(tx).UpdateDelivery(tt.delivery); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+}
+
+func TestWebhookDAO_FindDeliveriesByWebhookID(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		beforeID string
+		expect   func(mock sqlmock.Sqlmock) *sqlmock.ExpectedQuery
+	}{
+		{
+			name:     "正常ケース",
+			beforeID: "",
+			expect: func(mock sqlmock.Sqlmock) *sqlmock.ExpectedQuery {
+				return mock.ExpectQuery(webhookDeliverySelectQuery).WithArgs("2", 10)
+			},
+		},
+		{
+			name:     "正常ケース(beforeID指定)",
+			beforeID: "5",
+			expect: func(mock sqlmock.Sqlmock) *sqlmock.ExpectedQuery {
+				return mock.ExpectQuery(webhookDeliverySelectBeforeQry).WithArgs("2", "5", 10)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, mock := newMockTx(t)
+			tt.expect(mock).
+				WillReturnRows(sqlmock.NewRows(webhookDeliveryColumnNames).
+					AddRow("4", "2", "thread.created", []byte("{}"), "failed", 8, now, 404, "", now, nil)).
+				RowsWillBeClosed()
+
+			got, err := NewWebhookDAO(tx).FindDeliveriesByWebhookID("2", tt.beforeID, 10)
+			if err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+			want := []model.WebhookDelivery{
+				model.NewWebhookDelivery("4", "2", model.WebhookThreadCreated, []byte("{}"), model.WebhookDeliveryFailed, 8, now, 404, "", now, time.Time{}),
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package digest
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+	"GoBBS/interface/dao"
+)
+
+// ActivitySource ウォッチ対象の期間内の新着を集計する
+// mockgen -source interface/digest/activity.go -destination mock/mock_digest/activity_mock.go
+type ActivitySource interface {
+	Find(ctx context.Context, watches []model.Watch, from time.Time, to time.Time) ([]Item, error)
+}
+
+// postActivitySource 投稿の作成日時から新着を集計する
+type postActivitySource struct {
+	db      *sql.DB
+	baseURL string
+}
+
+var _ ActivitySource = (*postActivitySource)(nil)
+
+// NewPostActivitySource 投稿の作成日時から新着を集計する集計元を生成する
+// baseURLは新着のURLの生成に使用する
+func NewPostActivitySource(db *sql.DB, baseURL string) *postActivitySource {
+	return &postActivitySource{db: db, baseURL: baseURL}
+}
+
+// Find ウォッチ対象ごとにfrom以上to未満に作成された非表示でない投稿を数え、新着のあるものをウォッチ順に返す
+func (s *postActivitySource) Find(ctx context.Context, watches []model.Watch, from time.Time, to time.Time) ([]Item, error) {
+	return dao.ExecWithTx(
+		ctx,
+		s.db,
+		func(tx *sql.Tx) ([]Item, error) {
+			return findItems(dao.NewBoardDAO(tx), dao.NewThreadDAO(tx), dao.NewPostDAO(tx), s.baseURL, watches, from, to)
+		},
+	)
+}
+
+// findItems ウォッチ対象ごとの新着を集計する
+// 板の新着は期間内に投稿があったスレッドの投稿の合計とし、削除されたスレッド・板のウォッチは無視する
+func findItems(
+	boardRepo repository.Board,
+	threadRepo repository.Thread,
+	postRepo repository.Post,
+	baseURL string,
+	watches []model.Watch,
+	from time.Time,
+	to time.Time) ([]Item, error) {
+	items := []Item{}
+	for _, w := range watches {
+		var (
+			title     string
+			url       string
+			threadIDs []string
+		)
+		switch w.Kind() {
+		case model.WatchThread:
+			thread, err := threadRepo.FindByID(w.TargetID())
+			if errors.Is(err, repository.ErrThreadNotFound) {
+				continue
+			} else if err != nil {
+				return nil, errors.Wrap(err, "findItems error")
+			}
+			if thread.LastPostedAt().Before(from) {
+				continue
+			}
+			title, url, threadIDs = thread.Title(), baseURL+"/threads/"+thread.ID()+"/posts", []string{thread.ID()}
+		case model.WatchBoard:
+			board, err := boardRepo.FindByID(w.TargetID())
+			if errors.Is(err, repository.ErrBoardNotFound) {
+				continue
+			} else if err != nil {
+				return nil, errors.Wrap(err, "findItems error")
+			}
+			threads, err := threadRepo.FindPostedSince(board.ID(), from)
+			if err != nil {
+				return nil, errors.Wrap(err, "findItems error")
+			}
+			title, url = board.Name(), baseURL+"/boards/"+board.ID()+"/threads"
+			for _, t := range threads {
+				threadIDs = append(threadIDs, t.ID())
+			}
+		default:
+			continue
+		}
+
+		newPosts, err := postRepo.CountCreated(threadIDs, from, to)
+		if err != nil {
+			return nil, errors.Wrap(err, "findItems error")
+		}
+		if newPosts == 0 {
+			continue
+		}
+		items = append(items, Item{Kind: w.Kind(), TargetID: w.TargetID(), Title: title, NewPosts: newPosts, URL: url})
+	}
+
+	return items, nil
+}
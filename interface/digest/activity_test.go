@@ -0,0 +1,90 @@
+package digest
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/interface/inmemory"
+	"GoBBS/mock/mock_repository"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func Test_findItems(t *testing.T) {
+	from := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	boardRepo := inmemory.NewBoardRepository()
+	threadRepo := inmemory.NewThreadRepository()
+	postRepo := inmemory.NewPostRepository()
+
+	boardID, err := boardRepo.Regist(model.NewBoard("", "雑談", "", time.Time{}), from.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	// regist スレッドを作成し、postedAtの日時に投稿する
+	regist := func(title string, postedAt ...time.Time) string {
+		id, err := threadRepo.Regist(model.NewThread("", boardID, "1", title, 0, time.Time{}, time.Time{}), from.Add(-time.Hour))
+		if err != nil {
+			t.Fatalf("予期せぬエラー(error: %s)", err)
+		}
+		for _, at := range postedAt {
+			number, err := threadRepo.AddPost(id, at)
+			if err != nil {
+				t.Fatalf("予期せぬエラー(error: %s)", err)
+			}
+			if _, err := postRepo.Regist(model.NewPost("", id, "1", number, "body", "<p>body</p>\n", time.Time{}, time.Time{}, time.Time{}, ""), at); err != nil {
+				t.Fatalf("予期せぬエラー(error: %s)", err)
+			}
+		}
+		return id
+	}
+	active := regist("新着あり", from.Add(-time.Minute), from, from.Add(time.Hour))
+	quiet := regist("新着なし", from.Add(-time.Minute))
+	later := regist("期間後のみ", to)
+	regist("別スレッド", from.Add(2*time.Hour))
+
+	watches := []model.Watch{
+		model.NewWatch("2", model.WatchThread, quiet, from),
+		model.NewWatch("2", model.WatchThread, active, from),
+		model.NewWatch("2", model.WatchThread, later, from),
+		model.NewWatch("2", model.WatchThread, "999", from),
+		model.NewWatch("2", model.WatchBoard, boardID, from),
+		model.NewWatch("2", model.WatchBoard, "999", from),
+	}
+
+	got, err := findItems(boardRepo, threadRepo, postRepo, "https://bbs.example.com", watches, from, to)
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	want := []Item{
+		{Kind: model.WatchThread, TargetID: active, Title: "新着あり", NewPosts: 2, URL: "https://bbs.example.com/threads/" + active + "/posts"},
+		{Kind: model.WatchBoard, TargetID: boardID, Title: "雑談", NewPosts: 3, URL: "https://bbs.example.com/boards/" + boardID + "/threads"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
+	}
+}
+
+func Test_findItemsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	from := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	errTest := errors.New("test")
+
+	threadRepo := mock_repository.NewMockThread(ctrl)
+	threadRepo.EXPECT().FindByID("10").Return(nil, errTest)
+
+	got, err := findItems(mock_repository.NewMockBoard(ctrl), threadRepo, mock_repository.NewMockPost(ctrl), "", []model.Watch{
+		model.NewWatch("2", model.WatchThread, "10", from),
+	}, from, from.Add(24*time.Hour))
+	if !errors.Is(err, errTest) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", err, errTest)
+	}
+	if got != nil {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, nil)
+	}
+}
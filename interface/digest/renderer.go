@@ -0,0 +1,83 @@
+package digest
+
+import (
+	"bytes"
+	"embed"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+)
+
+// Renderer ダイジェストメールの件名と本文を生成する
+// mockgen -source interface/digest/renderer.go -destination mock/mock_digest/renderer_mock.go
+type Renderer interface {
+	Render(locale string, data Data) (string, string, error)
+}
+
+// Data ダイジェストメールのテンプレートに渡す値
+type Data struct {
+	Name           string
+	Frequency      model.DigestFrequency
+	From           time.Time
+	To             time.Time
+	Items          []Item
+	UnsubscribeURL string
+}
+
+// Item ウォッチ対象ごとの新着
+type Item struct {
+	Kind     model.WatchKind
+	TargetID string
+	Title    string
+	NewPosts int
+	URL      string
+}
+
+// templateRenderer 言語ごとのテンプレートでダイジェストメールを生成する
+type templateRenderer struct {
+	templates map[string]*template.Template
+}
+
+var _ Renderer = (*templateRenderer)(nil)
+
+var ErrUnsupportedLocale = errors.New("unsupported digest locale")
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// NewRenderer 組み込みのテンプレート(ja, en)を読み込んだレンダラーを生成する
+func NewRenderer() (*templateRenderer, error) {
+	templates := make(map[string]*template.Template)
+	for _, locale := range []string{model.DigestLocaleJa, model.DigestLocaleEn} {
+		tmpl, err := template.ParseFS(templateFS, "templates/"+locale+".tmpl")
+		if err != nil {
+			return nil, errors.Wrap(err, "NewRenderer error")
+		}
+		templates[locale] = tmpl
+	}
+
+	return &templateRenderer{templates: templates}, nil
+}
+
+// Render 言語を指定して件名と本文を生成する
+func (r *templateRenderer) Render(locale string, data Data) (string, string, error) {
+	tmpl, ok := r.templates[locale]
+	if !ok {
+		return "", "", ErrUnsupportedLocale
+	}
+
+	var subject, body bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return "", "", errors.Wrap(err, "Render error")
+	}
+	if err := tmpl.ExecuteTemplate(&body, "body", data); err != nil {
+		return "", "", errors.Wrap(err, "Render error")
+	}
+
+	// 件名はヘッダに使用するため改行を取り除く
+	return strings.Join(strings.Fields(subject.String()), " "), body.String(), nil
+}
@@ -0,0 +1,93 @@
+package digest
+
+import (
+	"GoBBS/domain/model"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTemplateRenderer_Render(t *testing.T) {
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+
+	data := Data{
+		Name:      "taro",
+		Frequency: model.DigestDaily,
+		From:      time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:        time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		Items: []Item{
+			{Kind: model.WatchThread, TargetID: "10", Title: "Go言語スレ", NewPosts: 3, URL: "https://example.com/threads/10"},
+			{Kind: model.WatchBoard, TargetID: "2", Title: "プログラミング", NewPosts: 1, URL: "https://example.com/boards/2"},
+		},
+		UnsubscribeURL: "https://example.com/digest/unsubscribe?token=abc",
+	}
+
+	tests := []struct {
+		locale      string
+		wantSubject string
+		wantBody    []string
+	}{
+		{
+			locale:      model.DigestLocaleJa,
+			wantSubject: "[GoBBS] 今日のウォッチ中のスレッド・板の新着(2件)",
+			wantBody: []string{
+				"taro さん",
+				"2023/01/01 00:00 から 2023/01/02 00:00 まで",
+				"■ スレッド「Go言語スレ」 新着 3 件\n  https://example.com/threads/10",
+				"■ 板「プログラミング」 新着 1 件\n  https://example.com/boards/2",
+				"https://example.com/digest/unsubscribe?token=abc",
+			},
+		},
+		{
+			locale:      model.DigestLocaleEn,
+			wantSubject: "[GoBBS] Your daily digest of watched threads and boards (2 updated)",
+			wantBody: []string{
+				"Hi taro,",
+				"between Jan 1, 2023 00:00 and Jan 2, 2023 00:00",
+				"* Thread \"Go言語スレ\": 3 new posts\n  https://example.com/threads/10",
+				"* Board \"プログラミング\": 1 new post\n  https://example.com/boards/2",
+				"https://example.com/digest/unsubscribe?token=abc",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			subject, body, err := r.Render(tt.locale, data)
+			if err != nil {
+				t.Fatalf("予期せぬエラー(error: %s)", err)
+			}
+			if subject != tt.wantSubject {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", subject, tt.wantSubject)
+			}
+			for _, want := range tt.wantBody {
+				if !strings.Contains(body, want) {
+					t.Errorf("本文に%#vが含まれない body: %s", want, body)
+				}
+			}
+		})
+	}
+}
+
+func TestTemplateRenderer_RenderWeeklyJa(t *testing.T) {
+	r, _ := NewRenderer()
+
+	subject, _, err := r.Render(model.DigestLocaleJa, Data{Frequency: model.DigestWeekly})
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	if want := "[GoBBS] 今週のウォッチ中のスレッド・板の新着(0件)"; subject != want {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", subject, want)
+	}
+}
+
+func TestTemplateRenderer_RenderUnsupportedLocale(t *testing.T) {
+	r, _ := NewRenderer()
+
+	if _, _, err := r.Render("fr", Data{}); !errors.Is(err, ErrUnsupportedLocale) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", err, ErrUnsupportedLocale)
+	}
+}
@@ -0,0 +1,199 @@
+package gateway
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"GoBBS/interface/pubsub"
+)
+
+// client ゲートウェイに接続中のクライアント
+type client struct {
+	g      *gateway
+	conn   *websocket.Conn
+	userID string
+	limits Limits
+	send   chan serverMessage
+	bucket *tokenBucket
+
+	closeOnce sync.Once
+	done      chan struct{}
+
+	mu   sync.Mutex
+	subs map[string]pubsub.Subscription
+}
+
+// readLoop クライアントのメッセージを受信して処理する
+// 受信エラー、制限超過、切断で終了する
+func (c *client) readLoop() {
+	pongWait := c.g.pingInterval * 2
+	c.conn.SetReadLimit(c.limits.MaxMessageBytes)
+	_ = c.conn.SetReadDeadline(c.g.now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(c.g.now().Add(pongWait))
+	})
+
+	for {
+		_, data, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		if !c.bucket.allow(c.g.now()) {
+			c.closeWithError(websocket.ClosePolicyViolation, errRateLimit)
+			return
+		}
+
+		var msg clientMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			c.enqueue(serverMessage{Type: typeError, Error: errInvalidMessage})
+			continue
+		}
+		c.handle(msg)
+	}
+}
+
+// handle クライアントのメッセージを処理する
+func (c *client) handle(msg clientMessage) {
+	if !topicPattern.MatchString(msg.Topic) {
+		c.enqueue(serverMessage{Type: typeError, Topic: msg.Topic, Error: errInvalidTopic})
+		return
+	}
+
+	switch msg.Type {
+	case typeSubscribe:
+		c.subscribe(msg.Topic)
+	case typeUnsubscribe:
+		c.unsubscribe(msg.Topic)
+		c.enqueue(serverMessage{Type: typeUnsubscribed, Topic: msg.Topic})
+	case typeTyping:
+		c.mu.Lock()
+		_, ok := c.subs[msg.Topic]
+		c.mu.Unlock()
+		if !ok {
+			c.enqueue(serverMessage{Type: typeError, Topic: msg.Topic, Error: errNotSubscribed})
+			return
+		}
+		c.g.broadcastTyping(msg.Topic, c)
+	default:
+		c.enqueue(serverMessage{Type: typeError, Error: errInvalidMessage})
+	}
+}
+
+// subscribe トピックを購読する
+func (c *client) subscribe(topic string) {
+	c.mu.Lock()
+	if _, ok := c.subs[topic]; ok {
+		c.mu.Unlock()
+		c.enqueue(serverMessage{Type: typeSubscribed, Topic: topic})
+		return
+	}
+	if len(c.subs) >= c.limits.MaxSubscriptions {
+		c.mu.Unlock()
+		c.enqueue(serverMessage{Type: typeError, Topic: topic, Error: errSubscriptionLimit})
+		return
+	}
+	sub := c.g.hub.Subscribe(topic, 0)
+	c.subs[topic] = sub
+	c.mu.Unlock()
+
+	c.enqueue(serverMessage{Type: typeSubscribed, Topic: topic})
+	c.g.join(topic, c)
+	go c.forward(topic, sub)
+}
+
+// unsubscribe トピックの購読を解除する
+func (c *client) unsubscribe(topic string) {
+	c.mu.Lock()
+	sub, ok := c.subs[topic]
+	delete(c.subs, topic)
+	c.mu.Unlock()
+
+	if ok {
+		sub.Close()
+		c.g.leave(topic, c)
+	}
+}
+
+// unsubscribeAll すべての購読を解除する
+func (c *client) unsubscribeAll() {
+	c.mu.Lock()
+	topics := make([]string, 0, len(c.subs))
+	for topic := range c.subs {
+		topics = append(topics, topic)
+	}
+	c.mu.Unlock()
+
+	for _, topic := range topics {
+		c.unsubscribe(topic)
+	}
+}
+
+// forward 購読したイベントをクライアントへ転送する
+func (c *client) forward(topic string, sub pubsub.Subscription) {
+	for event := range sub.Events() {
+		c.enqueue(newEventMessage(event))
+	}
+
+	// 購読中のままハブから切断された場合は未読超過のため接続を閉じる
+	c.mu.Lock()
+	current, ok := c.subs[topic]
+	c.mu.Unlock()
+	if ok && current == sub {
+		c.close()
+	}
+}
+
+// enqueue 送信キューにメッセージを追加する
+// キューが溢れた場合は接続を閉じる
+func (c *client) enqueue(msg serverMessage) {
+	select {
+	case <-c.done:
+	case c.send <- msg:
+	default:
+		c.close()
+	}
+}
+
+// writeLoop 送信キューのメッセージと死活監視のPingを送信する
+func (c *client) writeLoop() {
+	ticker := time.NewTicker(c.g.pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case msg := <-c.send:
+			_ = c.conn.SetWriteDeadline(c.g.now().Add(c.g.writeTimeout))
+			if err := c.conn.WriteJSON(msg); err != nil {
+				c.close()
+				return
+			}
+		case <-ticker.C:
+			deadline := c.g.now().Add(c.g.writeTimeout)
+			if err := c.conn.WriteControl(websocket.PingMessage, nil, deadline); err != nil {
+				c.close()
+				return
+			}
+		}
+	}
+}
+
+// closeWithError エラーを通知して接続を閉じる
+func (c *client) closeWithError(code int, reason string) {
+	deadline := c.g.now().Add(c.g.writeTimeout)
+	_ = c.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), deadline)
+	c.close()
+}
+
+// close 接続を閉じる
+func (c *client) close() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		_ = c.conn.Close()
+	})
+}
@@ -0,0 +1,183 @@
+package gateway
+
+import (
+	"encoding/json"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"GoBBS/interface/pubsub"
+)
+
+type (
+	// Gateway 板・スレッドのイベントを多重化して配信するWebSocketゲートウェイ
+	// mockgen -source interface/gateway/gateway.go -destination mock/mock_gateway/gateway_mock.go
+	Gateway interface {
+		Serve(conn *websocket.Conn, userID string)
+	}
+
+	// Limits 接続ごとの制限(設定の再読み込み後は新しい接続に適用する)
+	Limits struct {
+		// MaxSubscriptions 同時に購読できるトピック数
+		MaxSubscriptions int
+		// MessagesPerSecond クライアントから受け付けるメッセージの平均レート
+		MessagesPerSecond float64
+		// MessageBurst 一時的に受け付けるメッセージ数
+		MessageBurst int
+		// MaxMessageBytes クライアントから受け付けるメッセージの最大バイト数
+		MaxMessageBytes int64
+		// SendBuffer 未送信メッセージ数の上限、超過した接続は切断する
+		SendBuffer int
+	}
+
+	// gateway WebSocketゲートウェイ
+	gateway struct {
+		hub          pubsub.Hub
+		limits       func() Limits
+		pingInterval time.Duration
+		writeTimeout time.Duration
+		now          func() time.Time
+
+		mu       sync.Mutex
+		presence map[string]map[*client]struct{}
+	}
+
+	// clientMessage クライアントから受信するメッセージ
+	clientMessage struct {
+		Type  string `json:"type"`
+		Topic string `json:"topic"`
+	}
+
+	// serverMessage クライアントへ送信するメッセージ
+	serverMessage struct {
+		Type   string          `json:"type"`
+		Topic  string          `json:"topic,omitempty"`
+		ID     uint64          `json:"id,omitempty"`
+		Event  string          `json:"event,omitempty"`
+		Data   json.RawMessage `json:"data,omitempty"`
+		Count  *int            `json:"count,omitempty"`
+		UserID string          `json:"user_id,omitempty"`
+		Error  string          `json:"error,omitempty"`
+	}
+)
+
+var _ Gateway = (*gateway)(nil)
+
+// メッセージ種別
+const (
+	typeSubscribe    = "subscribe"
+	typeUnsubscribe  = "unsubscribe"
+	typeTyping       = "typing"
+	typeSubscribed   = "subscribed"
+	typeUnsubscribed = "unsubscribed"
+	typeEvent        = "event"
+	typePresence     = "presence"
+	typeError        = "error"
+)
+
+// エラーメッセージ
+const (
+	errInvalidMessage    = "invalid message"
+	errInvalidTopic      = "invalid topic"
+	errNotSubscribed     = "not subscribed"
+	errSubscriptionLimit = "subscription limit exceeded"
+	errRateLimit         = "rate limit exceeded"
+)
+
+// topicPattern 購読可能なトピック
+var topicPattern = regexp.MustCompile(`^(board|thread):[0-9A-Za-z_-]{1,64}$`)
+
+// NewGateway WebSocketゲートウェイを生成する
+// limitsは接続ごとに呼び出し、再読み込みした設定の制限を新しい接続に適用する
+func NewGateway(hub pubsub.Hub, limits func() Limits) *gateway {
+	return &gateway{
+		hub:          hub,
+		limits:       limits,
+		pingInterval: 30 * time.Second,
+		writeTimeout: 10 * time.Second,
+		now:          time.Now,
+		presence:     map[string]map[*client]struct{}{},
+	}
+}
+
+// Serve 接続が閉じられるまでクライアントのメッセージを処理する
+func (g *gateway) Serve(conn *websocket.Conn, userID string) {
+	limits := g.limits()
+	c := &client{
+		g:      g,
+		conn:   conn,
+		userID: userID,
+		limits: limits,
+		send:   make(chan serverMessage, limits.SendBuffer),
+		done:   make(chan struct{}),
+		subs:   map[string]pubsub.Subscription{},
+		bucket: newTokenBucket(limits.MessagesPerSecond, limits.MessageBurst, g.now()),
+	}
+
+	go c.writeLoop()
+	c.readLoop()
+	c.close()
+	c.unsubscribeAll()
+}
+
+// join トピックの在室者に追加し、在室数を通知する
+func (g *gateway) join(topic string, c *client) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	clients, ok := g.presence[topic]
+	if !ok {
+		clients = map[*client]struct{}{}
+		g.presence[topic] = clients
+	}
+	clients[c] = struct{}{}
+	g.broadcastPresence(topic)
+}
+
+// leave トピックの在室者から削除し、在室数を通知する
+func (g *gateway) leave(topic string, c *client) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	clients := g.presence[topic]
+	delete(clients, c)
+	if len(clients) == 0 {
+		delete(g.presence, topic)
+		return
+	}
+	g.broadcastPresence(topic)
+}
+
+// broadcastPresence トピックの在室者に在室数を通知する
+// 呼び出し元でロックを取得していること
+func (g *gateway) broadcastPresence(topic string) {
+	clients := g.presence[topic]
+	count := len(clients)
+	for c := range clients {
+		c.enqueue(serverMessage{Type: typePresence, Topic: topic, Count: &count})
+	}
+}
+
+// broadcastTyping 送信者以外のトピックの在室者に入力中であることを通知する
+func (g *gateway) broadcastTyping(topic string, sender *client) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for c := range g.presence[topic] {
+		if c != sender {
+			c.enqueue(serverMessage{Type: typeTyping, Topic: topic, UserID: sender.userID})
+		}
+	}
+}
+
+// newEventMessage ハブのイベントから送信メッセージを生成する
+// JSONでないデータは文字列として送信する
+func newEventMessage(event pubsub.Event) serverMessage {
+	data := json.RawMessage(event.Data)
+	if !json.Valid(data) {
+		data, _ = json.Marshal(string(event.Data))
+	}
+	return serverMessage{Type: typeEvent, Topic: event.Topic, ID: event.ID, Event: event.Type, Data: data}
+}
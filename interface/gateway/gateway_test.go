@@ -0,0 +1,301 @@
+package gateway
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"GoBBS/interface/pubsub"
+)
+
+// testLimits テスト用の接続ごとの制限
+func testLimits() Limits {
+	return Limits{
+		MaxSubscriptions:  50,
+		MessagesPerSecond: 5,
+		MessageBurst:      20,
+		MaxMessageBytes:   4096,
+		SendBuffer:        256,
+	}
+}
+
+// newTestServer クエリのuser_idで接続するゲートウェイのテストサーバーを生成する
+func newTestServer(t *testing.T, g *gateway) *httptest.Server {
+	t.Helper()
+
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("予期せぬエラー(error: %s)", err)
+			return
+		}
+		g.Serve(conn, r.URL.Query().Get("user_id"))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// dial テストサーバーに接続する
+func dial(t *testing.T, server *httptest.Server, userID string) *websocket.Conn {
+	t.Helper()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/?user_id=" + userID
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// send メッセージを送信する
+func send(t *testing.T, conn *websocket.Conn, msg string) {
+	t.Helper()
+
+	if err := conn.WriteMessage(websocket.TextMessage, []byte(msg)); err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+}
+
+// expect 次に受信するメッセージを検証する
+func expect(t *testing.T, conn *websocket.Conn, want string) {
+	t.Helper()
+
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var got, wantMsg map[string]any
+	if err := conn.ReadJSON(&got); err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	if err := json.Unmarshal([]byte(want), &wantMsg); err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	if !reflect.DeepEqual(got, wantMsg) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, wantMsg)
+	}
+}
+
+func TestGateway_subscribe(t *testing.T) {
+	hub := pubsub.NewMemoryHub(10, 10, 10)
+	server := newTestServer(t, NewGateway(hub, testLimits))
+	conn := dial(t, server, "1")
+
+	send(t, conn, `{"type":"subscribe","topic":"thread:1"}`)
+	expect(t, conn, `{"type":"subscribed","topic":"thread:1"}`)
+	expect(t, conn, `{"type":"presence","topic":"thread:1","count":1}`)
+
+	hub.Publish(pubsub.ThreadTopic("1"), "post.created", []byte(`{"id":"1"}`))
+	hub.Publish(pubsub.BoardTopic("1"), "thread.created", []byte(`{"id":"2"}`))
+	hub.Publish(pubsub.ThreadTopic("1"), "post.hidden", []byte(`{"id":"3"}`))
+	expect(t, conn, `{"type":"event","topic":"thread:1","id":1,"event":"post.created","data":{"id":"1"}}`)
+	expect(t, conn, `{"type":"event","topic":"thread:1","id":3,"event":"post.hidden","data":{"id":"3"}}`)
+
+	send(t, conn, `{"type":"unsubscribe","topic":"thread:1"}`)
+	expect(t, conn, `{"type":"unsubscribed","topic":"thread:1"}`)
+	hub.Publish(pubsub.ThreadTopic("1"), "post.created", []byte(`{"id":"4"}`))
+	send(t, conn, `{"type":"subscribe","topic":"board:1"}`)
+	expect(t, conn, `{"type":"subscribed","topic":"board:1"}`)
+}
+
+func TestGateway_presenceAndTyping(t *testing.T) {
+	hub := pubsub.NewMemoryHub(10, 10, 10)
+	server := newTestServer(t, NewGateway(hub, testLimits))
+	conn1 := dial(t, server, "1")
+	conn2 := dial(t, server, "2")
+
+	send(t, conn1, `{"type":"subscribe","topic":"thread:1"}`)
+	expect(t, conn1, `{"type":"subscribed","topic":"thread:1"}`)
+	expect(t, conn1, `{"type":"presence","topic":"thread:1","count":1}`)
+
+	send(t, conn2, `{"type":"subscribe","topic":"thread:1"}`)
+	expect(t, conn2, `{"type":"subscribed","topic":"thread:1"}`)
+	expect(t, conn2, `{"type":"presence","topic":"thread:1","count":2}`)
+	expect(t, conn1, `{"type":"presence","topic":"thread:1","count":2}`)
+
+	send(t, conn2, `{"type":"typing","topic":"thread:1"}`)
+	expect(t, conn1, `{"type":"typing","topic":"thread:1","user_id":"2"}`)
+
+	conn2.Close()
+	expect(t, conn1, `{"type":"presence","topic":"thread:1","count":1}`)
+}
+
+func TestGateway_invalidMessage(t *testing.T) {
+	server := newTestServer(t, NewGateway(pubsub.NewMemoryHub(10, 10, 10), testLimits))
+	conn := dial(t, server, "1")
+
+	tests := []struct {
+		name string
+		msg  string
+		want string
+	}{
+		{
+			name: "異常ケース(JSON不正)",
+			msg:  `{`,
+			want: `{"type":"error","error":"invalid message"}`,
+		},
+		{
+			name: "異常ケース(種別不正)",
+			msg:  `{"type":"publish","topic":"thread:1"}`,
+			want: `{"type":"error","error":"invalid message"}`,
+		},
+		{
+			name: "異常ケース(トピック不正)",
+			msg:  `{"type":"subscribe","topic":"user:1"}`,
+			want: `{"type":"error","topic":"user:1","error":"invalid topic"}`,
+		},
+		{
+			name: "異常ケース(未購読のトピックへの入力中通知)",
+			msg:  `{"type":"typing","topic":"thread:1"}`,
+			want: `{"type":"error","topic":"thread:1","error":"not subscribed"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			send(t, conn, tt.msg)
+			expect(t, conn, tt.want)
+		})
+	}
+}
+
+func TestGateway_subscriptionLimit(t *testing.T) {
+	limits := testLimits()
+	limits.MaxSubscriptions = 1
+	server := newTestServer(t, NewGateway(pubsub.NewMemoryHub(10, 10, 10), func() Limits { return limits }))
+	conn := dial(t, server, "1")
+
+	send(t, conn, `{"type":"subscribe","topic":"board:1"}`)
+	expect(t, conn, `{"type":"subscribed","topic":"board:1"}`)
+	expect(t, conn, `{"type":"presence","topic":"board:1","count":1}`)
+
+	send(t, conn, `{"type":"subscribe","topic":"board:1"}`)
+	expect(t, conn, `{"type":"subscribed","topic":"board:1"}`)
+
+	send(t, conn, `{"type":"subscribe","topic":"board:2"}`)
+	expect(t, conn, `{"type":"error","topic":"board:2","error":"subscription limit exceeded"}`)
+}
+
+func TestGateway_reloadLimits(t *testing.T) {
+	var mu sync.Mutex
+	limits := testLimits()
+	limits.MaxSubscriptions = 1
+	server := newTestServer(t, NewGateway(pubsub.NewMemoryHub(10, 10, 10), func() Limits {
+		mu.Lock()
+		defer mu.Unlock()
+		return limits
+	}))
+	before := dial(t, server, "1")
+	send(t, before, `{"type":"subscribe","topic":"board:1"}`)
+	expect(t, before, `{"type":"subscribed","topic":"board:1"}`)
+	expect(t, before, `{"type":"presence","topic":"board:1","count":1}`)
+
+	// 再読み込み後の制限は新しい接続にだけ適用する
+	mu.Lock()
+	limits.MaxSubscriptions = 2
+	mu.Unlock()
+	after := dial(t, server, "2")
+	send(t, after, `{"type":"subscribe","topic":"board:2"}`)
+	expect(t, after, `{"type":"subscribed","topic":"board:2"}`)
+	expect(t, after, `{"type":"presence","topic":"board:2","count":1}`)
+	send(t, after, `{"type":"subscribe","topic":"board:3"}`)
+	expect(t, after, `{"type":"subscribed","topic":"board:3"}`)
+	expect(t, after, `{"type":"presence","topic":"board:3","count":1}`)
+
+	send(t, before, `{"type":"subscribe","topic":"board:4"}`)
+	expect(t, before, `{"type":"error","topic":"board:4","error":"subscription limit exceeded"}`)
+}
+
+func TestGateway_rateLimit(t *testing.T) {
+	limits := testLimits()
+	limits.MessagesPerSecond = 0
+	limits.MessageBurst = 1
+	server := newTestServer(t, NewGateway(pubsub.NewMemoryHub(10, 10, 10), func() Limits { return limits }))
+	conn := dial(t, server, "1")
+
+	send(t, conn, `{"type":"subscribe","topic":"user:1"}`)
+	expect(t, conn, `{"type":"error","topic":"user:1","error":"invalid topic"}`)
+
+	send(t, conn, `{"type":"subscribe","topic":"user:1"}`)
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, _, err := conn.ReadMessage()
+	if !websocket.IsCloseError(err, websocket.ClosePolicyViolation) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", err, websocket.ClosePolicyViolation)
+	}
+}
+
+// droppedHub 購読直後に未読超過で切断するハブ
+type droppedHub struct {
+	pubsub.Hub
+}
+
+// droppedSubscription 切断済みの購読
+type droppedSubscription struct {
+	events chan pubsub.Event
+}
+
+func (h droppedHub) Subscribe(topic string, lastEventID uint64) pubsub.Subscription {
+	events := make(chan pubsub.Event)
+	close(events)
+	return &droppedSubscription{events: events}
+}
+
+func (s *droppedSubscription) Replay() []pubsub.Event      { return nil }
+func (s *droppedSubscription) Events() <-chan pubsub.Event { return s.events }
+func (s *droppedSubscription) Close()                      {}
+
+func TestGateway_droppedSubscription(t *testing.T) {
+	server := newTestServer(t, NewGateway(droppedHub{}, testLimits))
+	conn := dial(t, server, "1")
+
+	send(t, conn, `{"type":"subscribe","topic":"thread:1"}`)
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			if _, ok := err.(*websocket.CloseError); !ok && strings.Contains(err.Error(), "timeout") {
+				t.Errorf("接続が閉じられていない(error: %s)", err)
+			}
+			return
+		}
+	}
+}
+
+func Test_newEventMessage(t *testing.T) {
+	got := newEventMessage(pubsub.Event{ID: 1, Topic: "thread:1", Type: "post.created", Data: []byte("text")})
+	want := serverMessage{Type: typeEvent, Topic: "thread:1", ID: 1, Event: "post.created", Data: json.RawMessage(`"text"`)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
+	}
+}
+
+func Test_tokenBucket_allow(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := newTokenBucket(1, 2, now)
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want bool
+	}{
+		{name: "正常ケース(バースト1回目)", now: now, want: true},
+		{name: "正常ケース(バースト2回目)", now: now, want: true},
+		{name: "異常ケース(バースト超過)", now: now, want: false},
+		{name: "異常ケース(補充前)", now: now.Add(500 * time.Millisecond), want: false},
+		{name: "正常ケース(補充後)", now: now.Add(time.Second), want: true},
+		{name: "正常ケース(上限まで補充)", now: now.Add(time.Hour), want: true},
+		{name: "正常ケース(上限まで補充後2回目)", now: now.Add(time.Hour), want: true},
+		{name: "異常ケース(上限まで補充後超過)", now: now.Add(time.Hour), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := b.allow(tt.now); got != tt.want {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/service"
+	"GoBBS/dto"
+	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/interface/middleware"
+	"GoBBS/interface/middleware/middlewarehelper"
+	"GoBBS/usecase"
+)
+
+type apiTokenHandler struct {
+	uc     usecase.APIToken
+	userUC usecase.User
+}
+
+// NewAPITokenHandler 個人用アクセストークンハンドラーを生成する
+// トークンの発行と削除はログインセッションでのみ行える(トークン自身では操作できない)
+func NewAPITokenHandler(apiTokenUseCase usecase.APIToken, userUseCase usecase.User) *apiTokenHandler {
+	return &apiTokenHandler{
+		uc:     apiTokenUseCase,
+		userUC: userUseCase,
+	}
+}
+
+// RegistHandlerFunc ハンドラー登録
+func (h *apiTokenHandler) RegistHandlerFunc() {
+	http.HandleFunc(
+		"/me/tokens",
+		middlewarehelper.Apply(
+			handlerctx.NewAPIContext,
+			h.tokens,
+			middleware.NewAuth(h.userUC).VerifyAuth,
+		),
+	)
+
+	http.HandleFunc(
+		"/me/tokens/",
+		middlewarehelper.Apply(
+			handlerctx.NewAPIContext,
+			h.revoke,
+			middleware.NewAuth(h.userUC).VerifyAuth,
+			middleware.NewPathParam("/me/tokens/:id").Parse,
+		),
+	)
+}
+
+// tokens 有効なトークンの一覧取得(GET)、発行(POST)
+// 発行したトークンはこのレスポンスでしか返さない
+func (h *apiTokenHandler) tokens(c handlerctx.APIContext) error {
+	switch c.RequestMethod() {
+	case http.MethodGet:
+		list, err := h.uc.List(c.RequestContext(), c.UserID(), time.Now())
+		if err != nil {
+			slog.ErrorContext(c.RequestContext(), "api token list error", "error", err)
+			c.WriteStatusCode(http.StatusInternalServerError)
+			return nil
+		}
+		return c.WriteResponseJSON(http.StatusOK, list)
+	case http.MethodPost:
+		var req dto.APITokenRequest
+		if err := json.NewDecoder(c.RequestBody()).Decode(&req); err != nil {
+			c.WriteStatusCode(http.StatusBadRequest)
+			return nil
+		}
+		token, err := h.uc.Create(c.RequestContext(), c.UserID(), &req, time.Now())
+		if err != nil {
+			if errors.Is(err, service.ErrAPITokenNameInvalid) ||
+				errors.Is(err, service.ErrAPITokenScopeInvalid) ||
+				errors.Is(err, service.ErrAPITokenExpiryInvalid) {
+				c.WriteStatusCode(http.StatusBadRequest)
+				return nil
+			}
+			slog.ErrorContext(c.RequestContext(), "api token create error", "error", err)
+			c.WriteStatusCode(http.StatusInternalServerError)
+			return nil
+		}
+		return c.WriteResponseJSON(http.StatusCreated, token)
+	default:
+		c.WriteStatusCode(http.StatusMethodNotAllowed)
+		return nil
+	}
+}
+
+// revoke トークンを削除する
+func (h *apiTokenHandler) revoke(c handlerctx.APIContext) error {
+	if c.RequestMethod() != http.MethodDelete {
+		c.WriteStatusCode(http.StatusMethodNotAllowed)
+		return nil
+	}
+
+	if err := h.uc.Revoke(c.RequestContext(), c.UserID(), c.PathParam()); err != nil {
+		if errors.Is(err, service.ErrAPITokenNotFound) {
+			c.WriteStatusCode(http.StatusNotFound)
+			return nil
+		}
+		slog.ErrorContext(c.RequestContext(), "api token revoke error", "error", err)
+		c.WriteStatusCode(http.StatusInternalServerError)
+		return nil
+	}
+
+	c.WriteStatusCode(http.StatusNoContent)
+	return nil
+}
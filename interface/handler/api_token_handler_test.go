@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"GoBBS/domain/service"
+	"GoBBS/dto"
+	"GoBBS/mock/mock_usecase"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewAPITokenHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUC := mock_usecase.NewMockAPIToken(ctrl)
+	mockUserUC := mock_usecase.NewMockUser(ctrl)
+
+	want := &apiTokenHandler{uc: mockUC, userUC: mockUserUC}
+	if got := NewAPITokenHandler(mockUC, mockUserUC); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewAPITokenHandler() = %v, want %v", got, want)
+	}
+}
+
+func Test_apiTokenHandler_RegistHandlerFunc(t *testing.T) {
+	h := &apiTokenHandler{}
+	h.RegistHandlerFunc()
+}
+
+func Test_apiTokenHandler_tokens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expires := time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)
+	body := `{"name":"ci-bot","scopes":["read","post"],"expires_at":"2023-02-01T00:00:00Z"}`
+	req := &dto.APITokenRequest{Name: "ci-bot", Scopes: []string{"read", "post"}, ExpiresAt: expires}
+	created := &dto.APIToken{ID: "t1", Name: "ci-bot", Scopes: []string{"read", "post"}, Token: "gbp_secret", ExpiresAt: expires}
+	list := &dto.APITokenList{Tokens: []*dto.APIToken{{ID: "t1", Name: "ci-bot", Scopes: []string{"read"}, ExpiresAt: expires}}}
+
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{name: "正常ケース(一覧取得)", method: http.MethodGet, wantStatus: http.StatusOK},
+		{name: "異常ケース(一覧取得失敗)", method: http.MethodGet, err: errors.New("test"), wantStatus: http.StatusInternalServerError},
+		{name: "正常ケース(発行)", method: http.MethodPost, body: body, wantStatus: http.StatusCreated},
+		{name: "異常ケース(JSON不正)", method: http.MethodPost, body: `{`, wantStatus: http.StatusBadRequest},
+		{name: "異常ケース(名前不正)", method: http.MethodPost, body: body, err: service.ErrAPITokenNameInvalid, wantStatus: http.StatusBadRequest},
+		{name: "異常ケース(スコープ不正)", method: http.MethodPost, body: body, err: service.ErrAPITokenScopeInvalid, wantStatus: http.StatusBadRequest},
+		{name: "異常ケース(有効期限不正)", method: http.MethodPost, body: body, err: service.ErrAPITokenExpiryInvalid, wantStatus: http.StatusBadRequest},
+		{name: "異常ケース(発行失敗)", method: http.MethodPost, body: body, err: errors.New("test"), wantStatus: http.StatusInternalServerError},
+		{name: "異常ケース(メソッド不正)", method: http.MethodDelete, wantStatus: http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUC := mock_usecase.NewMockAPIToken(ctrl)
+			mock := newMockAPIContext(ctrl)
+			calls := []*gomock.Call{mock.EXPECT().RequestMethod().Return(tt.method)}
+			switch {
+			case tt.method == http.MethodGet && tt.err != nil:
+				calls = append(calls,
+					mock.EXPECT().UserID().Return("1"),
+					mockUC.EXPECT().List(gomock.Any(), "1", gomock.Any()).Return(nil, tt.err),
+					mock.EXPECT().WriteStatusCode(tt.wantStatus),
+				)
+			case tt.method == http.MethodGet:
+				calls = append(calls,
+					mock.EXPECT().UserID().Return("1"),
+					mockUC.EXPECT().List(gomock.Any(), "1", gomock.Any()).Return(list, nil),
+					mock.EXPECT().WriteResponseJSON(tt.wantStatus, list),
+				)
+			case tt.method == http.MethodPost && tt.body != body:
+				calls = append(calls,
+					mock.EXPECT().RequestBody().Return(io.NopCloser(strings.NewReader(tt.body))),
+					mock.EXPECT().WriteStatusCode(tt.wantStatus),
+				)
+			case tt.method == http.MethodPost && tt.err != nil:
+				calls = append(calls,
+					mock.EXPECT().RequestBody().Return(io.NopCloser(strings.NewReader(tt.body))),
+					mock.EXPECT().UserID().Return("1"),
+					mockUC.EXPECT().Create(gomock.Any(), "1", req, gomock.Any()).Return(nil, tt.err),
+					mock.EXPECT().WriteStatusCode(tt.wantStatus),
+				)
+			case tt.method == http.MethodPost:
+				calls = append(calls,
+					mock.EXPECT().RequestBody().Return(io.NopCloser(strings.NewReader(tt.body))),
+					mock.EXPECT().UserID().Return("1"),
+					mockUC.EXPECT().Create(gomock.Any(), "1", req, gomock.Any()).Return(created, nil),
+					mock.EXPECT().WriteResponseJSON(tt.wantStatus, created),
+				)
+			default:
+				calls = append(calls, mock.EXPECT().WriteStatusCode(tt.wantStatus))
+			}
+			gomock.InOrder(calls...)
+
+			if err := (&apiTokenHandler{uc: mockUC}).tokens(mock); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+}
+
+func Test_apiTokenHandler_revoke(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		method     string
+		err        error
+		wantStatus int
+	}{
+		{name: "正常ケース", method: http.MethodDelete, wantStatus: http.StatusNoContent},
+		{name: "異常ケース(メソッド不正)", method: http.MethodPost, wantStatus: http.StatusMethodNotAllowed},
+		{name: "異常ケース(トークンが未登録)", method: http.MethodDelete, err: service.ErrAPITokenNotFound, wantStatus: http.StatusNotFound},
+		{name: "異常ケース(削除失敗)", method: http.MethodDelete, err: errors.New("test"), wantStatus: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUC := mock_usecase.NewMockAPIToken(ctrl)
+			mock := newMockAPIContext(ctrl)
+			calls := []*gomock.Call{mock.EXPECT().RequestMethod().Return(tt.method)}
+			if tt.method == http.MethodDelete {
+				calls = append(calls,
+					mock.EXPECT().UserID().Return("1"),
+					mock.EXPECT().PathParam().Return("t1"),
+					mockUC.EXPECT().Revoke(gomock.Any(), "1", "t1").Return(tt.err),
+				)
+			}
+			calls = append(calls, mock.EXPECT().WriteStatusCode(tt.wantStatus))
+			gomock.InOrder(calls...)
+
+			if err := (&apiTokenHandler{uc: mockUC}).revoke(mock); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/service"
+	"GoBBS/dto"
+	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/interface/middleware"
+	"GoBBS/interface/middleware/middlewarehelper"
+	"GoBBS/usecase"
+)
+
+type boardHandler struct {
+	uc             usecase.Board
+	postUC         usecase.Post
+	userUC         usecase.User
+	adminUserIDs   []string
+	authMiddleware middleware.Auth
+}
+
+// NewBoardHandler 板ハンドラーを生成する(板の作成は管理者のみ)
+func NewBoardHandler(boardUseCase usecase.Board, postUseCase usecase.Post, userUseCase usecase.User, adminUserIDs []string) *boardHandler {
+	return &boardHandler{
+		uc:             boardUseCase,
+		postUC:         postUseCase,
+		userUC:         userUseCase,
+		adminUserIDs:   adminUserIDs,
+		authMiddleware: middleware.NewAuth(userUseCase),
+	}
+}
+
+// RegistHandlerFunc ハンドラー登録
+func (h *boardHandler) RegistHandlerFunc() {
+	http.HandleFunc(
+		"/boards",
+		middlewarehelper.Apply(
+			handlerctx.NewAPIContext,
+			h.list,
+			middleware.NewAuth(h.userUC).VerifyScope(model.APIScopeRead),
+		),
+	)
+
+	http.HandleFunc(
+		"/admin/boards",
+		middlewarehelper.Apply(
+			handlerctx.NewAPIContext,
+			h.regist,
+			middleware.NewAuth(h.userUC).VerifyAuth,
+			middleware.NewAdmin(h.adminUserIDs).VerifyAdmin,
+		),
+	)
+
+	http.HandleFunc(
+		"/boards/",
+		middlewarehelper.Apply(
+			handlerctx.NewAPIContext,
+			h.threads,
+			middleware.NewPathParam("/boards/:id/threads").Parse,
+		),
+	)
+}
+
+// list 板の一覧取得
+func (h *boardHandler) list(c handlerctx.APIContext) error {
+	if c.RequestMethod() != http.MethodGet {
+		c.WriteStatusCode(http.StatusMethodNotAllowed)
+		return nil
+	}
+
+	boards, err := h.uc.List(c.RequestContext())
+	if err != nil {
+		slog.ErrorContext(c.RequestContext(), "board list error", "error", err)
+		c.WriteStatusCode(http.StatusInternalServerError)
+		return nil
+	}
+
+	return c.WriteResponseJSON(http.StatusOK, boards)
+}
+
+// regist 板の作成
+func (h *boardHandler) regist(c handlerctx.APIContext) error {
+	if c.RequestMethod() != http.MethodPost {
+		c.WriteStatusCode(http.StatusMethodNotAllowed)
+		return nil
+	}
+
+	var req dto.BoardRequest
+	if err := json.NewDecoder(c.RequestBody()).Decode(&req); err != nil {
+		c.WriteStatusCode(http.StatusBadRequest)
+		return nil
+	}
+
+	board, err := h.uc.Regist(c.RequestContext(), &req, time.Now())
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidBoardName) || errors.Is(err, service.ErrInvalidBoardDescription) {
+			c.WriteStatusCode(http.StatusBadRequest)
+			return nil
+		}
+		slog.ErrorContext(c.RequestContext(), "board regist error", "error", err)
+		c.WriteStatusCode(http.StatusInternalServerError)
+		return nil
+	}
+
+	return c.WriteResponseJSON(http.StatusCreated, board)
+}
+
+// threads 板のスレッド一覧取得(GET、読み取り権限)とスレッド作成(POST、投稿権限)
+func (h *boardHandler) threads(c handlerctx.APIContext) error {
+	switch c.RequestMethod() {
+	case http.MethodGet:
+		return h.authMiddleware.VerifyScope(model.APIScopeRead)(h.listThreads)(c)
+	case http.MethodPost:
+		return h.authMiddleware.VerifyScope(model.APIScopePost)(h.createThread)(c)
+	default:
+		c.WriteStatusCode(http.StatusMethodNotAllowed)
+		return nil
+	}
+}
+
+// listThreads スレッド一覧の取得(beforeより前に作成されたスレッドをlimit件まで新しい順に返す)
+func (h *boardHandler) listThreads(c handlerctx.APIContext) error {
+	query := c.URL().Query()
+	limit := 0
+	if v := query.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			c.WriteStatusCode(http.StatusBadRequest)
+			return nil
+		}
+		limit = n
+	}
+
+	threads, err := h.postUC.Threads(c.RequestContext(), c.PathParam(), query.Get("before"), limit)
+	if err != nil {
+		if errors.Is(err, service.ErrBoardNotFound) {
+			c.WriteStatusCode(http.StatusNotFound)
+			return nil
+		}
+		slog.ErrorContext(c.RequestContext(), "thread list error", "error", err)
+		c.WriteStatusCode(http.StatusInternalServerError)
+		return nil
+	}
+
+	return c.WriteResponseJSON(http.StatusOK, threads)
+}
+
+// createThread スレッドの作成(最初の投稿を含む)
+func (h *boardHandler) createThread(c handlerctx.APIContext) error {
+	var req dto.ThreadRequest
+	if err := json.NewDecoder(c.RequestBody()).Decode(&req); err != nil {
+		c.WriteStatusCode(http.StatusBadRequest)
+		return nil
+	}
+
+	thread, err := h.postUC.CreateThread(c.RequestContext(), c.UserID(), c.PathParam(), &req, time.Now())
+	if err != nil {
+		if errors.Is(err, service.ErrBoardNotFound) {
+			c.WriteStatusCode(http.StatusNotFound)
+			return nil
+		}
+		if errors.Is(err, service.ErrInvalidThreadTitle) || errors.Is(err, service.ErrInvalidPostBody) {
+			c.WriteStatusCode(http.StatusBadRequest)
+			return nil
+		}
+		slog.ErrorContext(c.RequestContext(), "thread create error", "error", err)
+		c.WriteStatusCode(http.StatusInternalServerError)
+		return nil
+	}
+
+	return c.WriteResponseJSON(http.StatusCreated, thread)
+}
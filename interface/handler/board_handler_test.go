@@ -0,0 +1,389 @@
+package handler
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/domain/service"
+	"GoBBS/dto"
+	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/interface/middleware"
+	"GoBBS/interface/middleware/middlewarehelper"
+	"GoBBS/mock/mock_middleware"
+	"GoBBS/mock/mock_usecase"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+// passScope 権限の確認を通過させる認証ミドルウェアのモックを生成する
+func passScope(ctrl *gomock.Controller, scope string) *mock_middleware.MockAuth {
+	mock := mock_middleware.NewMockAuth(ctrl)
+	mock.EXPECT().VerifyScope(scope).Return(func(next middlewarehelper.HandlerFunc) middlewarehelper.HandlerFunc {
+		return next
+	})
+	return mock
+}
+
+func TestNewBoardHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUC := mock_usecase.NewMockBoard(ctrl)
+	mockPostUC := mock_usecase.NewMockPost(ctrl)
+	mockUserUC := mock_usecase.NewMockUser(ctrl)
+	adminUserIDs := []string{"1"}
+
+	want := &boardHandler{
+		uc:             mockUC,
+		postUC:         mockPostUC,
+		userUC:         mockUserUC,
+		adminUserIDs:   adminUserIDs,
+		authMiddleware: middleware.NewAuth(mockUserUC),
+	}
+	if got := NewBoardHandler(mockUC, mockPostUC, mockUserUC, adminUserIDs); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewBoardHandler() = %v, want %v", got, want)
+	}
+}
+
+func Test_boardHandler_RegistHandlerFunc(t *testing.T) {
+	h := &boardHandler{}
+	h.RegistHandlerFunc()
+}
+
+func Test_boardHandler_list(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	boards := []*dto.Board{{ID: "1", Name: "雑談"}}
+
+	tests := []struct {
+		name string
+		h    *boardHandler
+		c    func() handlerctx.APIContext
+	}{
+		{
+			name: "正常ケース",
+			h: &boardHandler{
+				uc: func() *mock_usecase.MockBoard {
+					mock := mock_usecase.NewMockBoard(ctrl)
+					mock.EXPECT().List(gomock.Any()).Return(boards, nil)
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().WriteResponseJSON(http.StatusOK, boards),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(取得失敗)",
+			h: &boardHandler{
+				uc: func() *mock_usecase.MockBoard {
+					mock := mock_usecase.NewMockBoard(ctrl)
+					mock.EXPECT().List(gomock.Any()).Return(nil, errors.New("test"))
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().WriteStatusCode(http.StatusInternalServerError),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(メソッド不正)",
+			h:    &boardHandler{},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().WriteStatusCode(http.StatusMethodNotAllowed),
+				)
+				return mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.h.list(tt.c()); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+}
+
+func Test_boardHandler_regist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	body := `{"name":"雑談","description":"なんでも"}`
+	req := &dto.BoardRequest{Name: "雑談", Description: "なんでも"}
+	created := &dto.Board{ID: "1", Name: "雑談", Description: "なんでも"}
+
+	tests := []struct {
+		name string
+		h    *boardHandler
+		c    func() handlerctx.APIContext
+	}{
+		{
+			name: "正常ケース",
+			h: &boardHandler{
+				uc: func() *mock_usecase.MockBoard {
+					mock := mock_usecase.NewMockBoard(ctrl)
+					mock.EXPECT().Regist(gomock.Any(), req, gomock.Any()).Return(created, nil)
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().RequestBody().Return(io.NopCloser(strings.NewReader(body))),
+					mock.EXPECT().WriteResponseJSON(http.StatusCreated, created),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(JSON不正)",
+			h:    &boardHandler{},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().RequestBody().Return(io.NopCloser(strings.NewReader(`{`))),
+					mock.EXPECT().WriteStatusCode(http.StatusBadRequest),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(名前不正)",
+			h: &boardHandler{
+				uc: func() *mock_usecase.MockBoard {
+					mock := mock_usecase.NewMockBoard(ctrl)
+					mock.EXPECT().Regist(gomock.Any(), req, gomock.Any()).Return(nil, service.ErrInvalidBoardName)
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().RequestBody().Return(io.NopCloser(strings.NewReader(body))),
+					mock.EXPECT().WriteStatusCode(http.StatusBadRequest),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(登録失敗)",
+			h: &boardHandler{
+				uc: func() *mock_usecase.MockBoard {
+					mock := mock_usecase.NewMockBoard(ctrl)
+					mock.EXPECT().Regist(gomock.Any(), req, gomock.Any()).Return(nil, errors.New("test"))
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().RequestBody().Return(io.NopCloser(strings.NewReader(body))),
+					mock.EXPECT().WriteStatusCode(http.StatusInternalServerError),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(メソッド不正)",
+			h:    &boardHandler{},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().WriteStatusCode(http.StatusMethodNotAllowed),
+				)
+				return mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.h.regist(tt.c()); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+}
+
+func Test_boardHandler_threads(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	threads := []*dto.Thread{{ID: "9", BoardID: "1", Title: "タイトル"}}
+	threadBody := `{"title":"タイトル","body":"本文"}`
+	threadReq := &dto.ThreadRequest{Title: "タイトル", Body: "本文"}
+	created := &dto.ThreadPosts{Thread: &dto.Thread{ID: "9", BoardID: "1", Title: "タイトル"}}
+
+	tests := []struct {
+		name string
+		h    *boardHandler
+		c    func() handlerctx.APIContext
+	}{
+		{
+			name: "正常ケース(一覧取得)",
+			h: &boardHandler{
+				authMiddleware: passScope(ctrl, model.APIScopeRead),
+				postUC: func() *mock_usecase.MockPost {
+					mock := mock_usecase.NewMockPost(ctrl)
+					mock.EXPECT().Threads(gomock.Any(), "1", "10", 20).Return(threads, nil)
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().URL().Return(&url.URL{RawQuery: "before=10&limit=20"}),
+					mock.EXPECT().PathParam().Return("1"),
+					mock.EXPECT().WriteResponseJSON(http.StatusOK, threads),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(limit不正)",
+			h: &boardHandler{
+				authMiddleware: passScope(ctrl, model.APIScopeRead),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().URL().Return(&url.URL{RawQuery: "limit=0"}),
+					mock.EXPECT().WriteStatusCode(http.StatusBadRequest),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(板なし)",
+			h: &boardHandler{
+				authMiddleware: passScope(ctrl, model.APIScopeRead),
+				postUC: func() *mock_usecase.MockPost {
+					mock := mock_usecase.NewMockPost(ctrl)
+					mock.EXPECT().Threads(gomock.Any(), "1", "", 0).Return(nil, service.ErrBoardNotFound)
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().URL().Return(&url.URL{}),
+					mock.EXPECT().PathParam().Return("1"),
+					mock.EXPECT().WriteStatusCode(http.StatusNotFound),
+				)
+				return mock
+			},
+		},
+		{
+			name: "正常ケース(スレッド作成)",
+			h: &boardHandler{
+				authMiddleware: passScope(ctrl, model.APIScopePost),
+				postUC: func() *mock_usecase.MockPost {
+					mock := mock_usecase.NewMockPost(ctrl)
+					mock.EXPECT().CreateThread(gomock.Any(), "2", "1", threadReq, gomock.Any()).Return(created, nil)
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().RequestBody().Return(io.NopCloser(strings.NewReader(threadBody))),
+					mock.EXPECT().UserID().Return("2"),
+					mock.EXPECT().PathParam().Return("1"),
+					mock.EXPECT().WriteResponseJSON(http.StatusCreated, created),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(タイトル不正)",
+			h: &boardHandler{
+				authMiddleware: passScope(ctrl, model.APIScopePost),
+				postUC: func() *mock_usecase.MockPost {
+					mock := mock_usecase.NewMockPost(ctrl)
+					mock.EXPECT().CreateThread(gomock.Any(), "2", "1", threadReq, gomock.Any()).Return(nil, service.ErrInvalidThreadTitle)
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().RequestBody().Return(io.NopCloser(strings.NewReader(threadBody))),
+					mock.EXPECT().UserID().Return("2"),
+					mock.EXPECT().PathParam().Return("1"),
+					mock.EXPECT().WriteStatusCode(http.StatusBadRequest),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(作成失敗)",
+			h: &boardHandler{
+				authMiddleware: passScope(ctrl, model.APIScopePost),
+				postUC: func() *mock_usecase.MockPost {
+					mock := mock_usecase.NewMockPost(ctrl)
+					mock.EXPECT().CreateThread(gomock.Any(), "2", "1", threadReq, gomock.Any()).Return(nil, errors.New("test"))
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().RequestBody().Return(io.NopCloser(strings.NewReader(threadBody))),
+					mock.EXPECT().UserID().Return("2"),
+					mock.EXPECT().PathParam().Return("1"),
+					mock.EXPECT().WriteStatusCode(http.StatusInternalServerError),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(メソッド不正)",
+			h:    &boardHandler{},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodDelete),
+					mock.EXPECT().WriteStatusCode(http.StatusMethodNotAllowed),
+				)
+				return mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.h.threads(tt.c()); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/service"
+	"GoBBS/dto"
+	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/interface/middleware"
+	"GoBBS/interface/middleware/middlewarehelper"
+	"GoBBS/usecase"
+)
+
+type digestHandler struct {
+	uc     usecase.Digest
+	userUC usecase.User
+}
+
+// unsubscribePage 配信停止の確認ページ
+// メールのリンクの先読みで配信停止されないよう、GETでは停止せず同じURL(トークン付き)にPOSTするフォームを返す
+const unsubscribePage = `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>GoBBS</title></head>
+<body>
+<form method="post">
+<p>ダイジェストメールの配信を停止しますか? / Unsubscribe from digest emails?</p>
+<button type="submit">配信を停止する / Unsubscribe</button>
+</form>
+</body>
+</html>
+`
+
+// NewDigestHandler ウォッチ・ダイジェストメールハンドラーを生成する
+func NewDigestHandler(digestUseCase usecase.Digest, userUseCase usecase.User) *digestHandler {
+	return &digestHandler{
+		uc:     digestUseCase,
+		userUC: userUseCase,
+	}
+}
+
+// RegistHandlerFunc ハンドラー登録
+func (h *digestHandler) RegistHandlerFunc() {
+	http.HandleFunc(
+		"/me/watches",
+		middlewarehelper.Apply(
+			handlerctx.NewAPIContext,
+			h.watches,
+			middleware.NewAuth(h.userUC).VerifyAuth,
+		),
+	)
+
+	http.HandleFunc(
+		"/me/watches/",
+		middlewarehelper.Apply(
+			handlerctx.NewAPIContext,
+			h.unwatch,
+			middleware.NewAuth(h.userUC).VerifyAuth,
+			middleware.NewPathParam("/me/watches/:topic").Parse,
+		),
+	)
+
+	http.HandleFunc(
+		"/me/digest",
+		middlewarehelper.Apply(
+			handlerctx.NewAPIContext,
+			h.subscription,
+			middleware.NewAuth(h.userUC).VerifyAuth,
+		),
+	)
+
+	http.HandleFunc(
+		usecase.DigestUnsubscribePath,
+		middlewarehelper.Apply(
+			handlerctx.NewAPIContext,
+			h.unsubscribe,
+		),
+	)
+}
+
+// watches ウォッチの一覧取得(GET)、ウォッチの登録(POST)
+func (h *digestHandler) watches(c handlerctx.APIContext) error {
+	switch c.RequestMethod() {
+	case http.MethodGet:
+		watches, err := h.uc.Watches(c.RequestContext(), c.UserID())
+		if err != nil {
+			slog.ErrorContext(c.RequestContext(), "digest watches error", "error", err)
+			c.WriteStatusCode(http.StatusInternalServerError)
+			return nil
+		}
+		return c.WriteResponseJSON(http.StatusOK, watches)
+	case http.MethodPost:
+		var watch dto.Watch
+		if err := json.NewDecoder(c.RequestBody()).Decode(&watch); err != nil || watch.TargetID == "" {
+			c.WriteStatusCode(http.StatusBadRequest)
+			return nil
+		}
+		if err := h.uc.Watch(c.RequestContext(), c.UserID(), watch.Kind, watch.TargetID, time.Now()); err != nil {
+			if errors.Is(err, service.ErrInvalidWatchKind) {
+				c.WriteStatusCode(http.StatusBadRequest)
+				return nil
+			}
+			slog.ErrorContext(c.RequestContext(), "digest watch error", "error", err)
+			c.WriteStatusCode(http.StatusInternalServerError)
+			return nil
+		}
+		c.WriteStatusCode(http.StatusNoContent)
+		return nil
+	default:
+		c.WriteStatusCode(http.StatusMethodNotAllowed)
+		return nil
+	}
+}
+
+// unwatch ウォッチの解除(パスパラメータは"thread:10"のように種類とIDを指定する)
+func (h *digestHandler) unwatch(c handlerctx.APIContext) error {
+	if c.RequestMethod() != http.MethodDelete {
+		c.WriteStatusCode(http.StatusMethodNotAllowed)
+		return nil
+	}
+
+	kind, targetID, ok := strings.Cut(c.PathParam(), ":")
+	if !ok || targetID == "" {
+		c.WriteStatusCode(http.StatusBadRequest)
+		return nil
+	}
+
+	if err := h.uc.Unwatch(c.RequestContext(), c.UserID(), kind, targetID); err != nil {
+		if errors.Is(err, service.ErrWatchNotFound) {
+			c.WriteStatusCode(http.StatusNotFound)
+			return nil
+		}
+		slog.ErrorContext(c.RequestContext(), "digest unwatch error", "error", err)
+		c.WriteStatusCode(http.StatusInternalServerError)
+		return nil
+	}
+
+	c.WriteStatusCode(http.StatusNoContent)
+	return nil
+}
+
+// subscription ダイジェストメールの配信設定の取得(GET)、更新(PUT)
+func (h *digestHandler) subscription(c handlerctx.APIContext) error {
+	switch c.RequestMethod() {
+	case http.MethodGet:
+		subscription, err := h.uc.Subscription(c.RequestContext(), c.UserID())
+		if err != nil {
+			slog.ErrorContext(c.RequestContext(), "digest subscription error", "error", err)
+			c.WriteStatusCode(http.StatusInternalServerError)
+			return nil
+		}
+		return c.WriteResponseJSON(http.StatusOK, subscription)
+	case http.MethodPut:
+		var subscription dto.DigestSubscription
+		if err := json.NewDecoder(c.RequestBody()).Decode(&subscription); err != nil {
+			c.WriteStatusCode(http.StatusBadRequest)
+			return nil
+		}
+		updated, err := h.uc.UpdateSubscription(c.RequestContext(), c.UserID(), &subscription, time.Now())
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidDigestFrequency) || errors.Is(err, service.ErrInvalidDigestLocale) {
+				c.WriteStatusCode(http.StatusBadRequest)
+				return nil
+			}
+			slog.ErrorContext(c.RequestContext(), "digest update subscription error", "error", err)
+			c.WriteStatusCode(http.StatusInternalServerError)
+			return nil
+		}
+		return c.WriteResponseJSON(http.StatusOK, updated)
+	default:
+		c.WriteStatusCode(http.StatusMethodNotAllowed)
+		return nil
+	}
+}
+
+// unsubscribe 配信停止トークンによる配信停止
+// GETは確認ページを返し、POST(RFC 8058のワンクリック配信停止を含む)で停止する
+func (h *digestHandler) unsubscribe(c handlerctx.APIContext) error {
+	switch c.RequestMethod() {
+	case http.MethodGet:
+		return c.WriteResponseStream(http.StatusOK, "text/html; charset=utf-8", strings.NewReader(unsubscribePage))
+	case http.MethodPost:
+		if err := h.uc.Unsubscribe(c.RequestContext(), c.URL().Query().Get("token"), time.Now()); err != nil {
+			if errors.Is(err, service.ErrInvalidUnsubscribeToken) {
+				c.WriteStatusCode(http.StatusNotFound)
+				return nil
+			}
+			slog.ErrorContext(c.RequestContext(), "digest unsubscribe error", "error", err)
+			c.WriteStatusCode(http.StatusInternalServerError)
+			return nil
+		}
+		c.WriteStatusCode(http.StatusNoContent)
+		return nil
+	default:
+		c.WriteStatusCode(http.StatusMethodNotAllowed)
+		return nil
+	}
+}
@@ -0,0 +1,468 @@
+package handler
+
+import (
+	"GoBBS/domain/service"
+	"GoBBS/dto"
+	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/mock/mock_usecase"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewDigestHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUC := mock_usecase.NewMockDigest(ctrl)
+	mockUserUC := mock_usecase.NewMockUser(ctrl)
+
+	want := &digestHandler{uc: mockUC, userUC: mockUserUC}
+	if got := NewDigestHandler(mockUC, mockUserUC); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewDigestHandler() = %v, want %v", got, want)
+	}
+}
+
+func Test_digestHandler_RegistHandlerFunc(t *testing.T) {
+	h := &digestHandler{}
+	h.RegistHandlerFunc()
+}
+
+func Test_digestHandler_watches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	watches := []*dto.Watch{{Kind: "thread", TargetID: "10"}}
+
+	tests := []struct {
+		name string
+		h    *digestHandler
+		c    func() handlerctx.APIContext
+	}{
+		{
+			name: "正常ケース(一覧)",
+			h: &digestHandler{
+				uc: func() *mock_usecase.MockDigest {
+					mock := mock_usecase.NewMockDigest(ctrl)
+					mock.EXPECT().Watches(gomock.Any(), "1").Return(watches, nil)
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().UserID().Return("1"),
+					mock.EXPECT().WriteResponseJSON(http.StatusOK, watches),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(一覧取得失敗)",
+			h: &digestHandler{
+				uc: func() *mock_usecase.MockDigest {
+					mock := mock_usecase.NewMockDigest(ctrl)
+					mock.EXPECT().Watches(gomock.Any(), "1").Return(nil, errors.New("test"))
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().UserID().Return("1"),
+					mock.EXPECT().WriteStatusCode(http.StatusInternalServerError),
+				)
+				return mock
+			},
+		},
+		{
+			name: "正常ケース(登録)",
+			h: &digestHandler{
+				uc: func() *mock_usecase.MockDigest {
+					mock := mock_usecase.NewMockDigest(ctrl)
+					mock.EXPECT().Watch(gomock.Any(), "1", "thread", "10", gomock.Any()).Return(nil)
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().RequestBody().Return(io.NopCloser(strings.NewReader(`{"kind":"thread","target_id":"10"}`))),
+					mock.EXPECT().UserID().Return("1"),
+					mock.EXPECT().WriteStatusCode(http.StatusNoContent),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(対象ID未指定)",
+			h:    &digestHandler{},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().RequestBody().Return(io.NopCloser(strings.NewReader(`{"kind":"thread"}`))),
+					mock.EXPECT().WriteStatusCode(http.StatusBadRequest),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(種類不正)",
+			h: &digestHandler{
+				uc: func() *mock_usecase.MockDigest {
+					mock := mock_usecase.NewMockDigest(ctrl)
+					mock.EXPECT().Watch(gomock.Any(), "1", "user", "10", gomock.Any()).Return(service.ErrInvalidWatchKind)
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().RequestBody().Return(io.NopCloser(strings.NewReader(`{"kind":"user","target_id":"10"}`))),
+					mock.EXPECT().UserID().Return("1"),
+					mock.EXPECT().WriteStatusCode(http.StatusBadRequest),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(登録失敗)",
+			h: &digestHandler{
+				uc: func() *mock_usecase.MockDigest {
+					mock := mock_usecase.NewMockDigest(ctrl)
+					mock.EXPECT().Watch(gomock.Any(), "1", "board", "2", gomock.Any()).Return(errors.New("test"))
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().RequestBody().Return(io.NopCloser(strings.NewReader(`{"kind":"board","target_id":"2"}`))),
+					mock.EXPECT().UserID().Return("1"),
+					mock.EXPECT().WriteStatusCode(http.StatusInternalServerError),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(メソッド不正)",
+			h:    &digestHandler{},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPut),
+					mock.EXPECT().WriteStatusCode(http.StatusMethodNotAllowed),
+				)
+				return mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.h.watches(tt.c()); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+}
+
+func Test_digestHandler_unwatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		topic      string
+		err        error
+		wantStatus int
+	}{
+		{name: "正常ケース", topic: "thread:10", err: nil, wantStatus: http.StatusNoContent},
+		{name: "異常ケース(ウォッチなし)", topic: "thread:10", err: service.ErrWatchNotFound, wantStatus: http.StatusNotFound},
+		{name: "異常ケース(削除失敗)", topic: "thread:10", err: errors.New("test"), wantStatus: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUC := mock_usecase.NewMockDigest(ctrl)
+			mock := newMockAPIContext(ctrl)
+			gomock.InOrder(
+				mock.EXPECT().RequestMethod().Return(http.MethodDelete),
+				mock.EXPECT().PathParam().Return(tt.topic),
+				mock.EXPECT().UserID().Return("1"),
+				mockUC.EXPECT().Unwatch(gomock.Any(), "1", "thread", "10").Return(tt.err),
+				mock.EXPECT().WriteStatusCode(tt.wantStatus),
+			)
+
+			if err := (&digestHandler{uc: mockUC}).unwatch(mock); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+
+	for _, topic := range []string{"thread", "thread:"} {
+		t.Run("異常ケース(パス不正 "+topic+")", func(t *testing.T) {
+			mock := newMockAPIContext(ctrl)
+			gomock.InOrder(
+				mock.EXPECT().RequestMethod().Return(http.MethodDelete),
+				mock.EXPECT().PathParam().Return(topic),
+				mock.EXPECT().WriteStatusCode(http.StatusBadRequest),
+			)
+
+			if err := (&digestHandler{}).unwatch(mock); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+
+	t.Run("異常ケース(メソッド不正)", func(t *testing.T) {
+		mock := newMockAPIContext(ctrl)
+		gomock.InOrder(
+			mock.EXPECT().RequestMethod().Return(http.MethodGet),
+			mock.EXPECT().WriteStatusCode(http.StatusMethodNotAllowed),
+		)
+
+		if err := (&digestHandler{}).unwatch(mock); err != nil {
+			t.Errorf("予期せぬエラー(error: %s)", err)
+		}
+	})
+}
+
+func Test_digestHandler_subscription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	subscription := &dto.DigestSubscription{Frequency: "daily", Locale: "ja"}
+
+	tests := []struct {
+		name string
+		h    *digestHandler
+		c    func() handlerctx.APIContext
+	}{
+		{
+			name: "正常ケース(取得)",
+			h: &digestHandler{
+				uc: func() *mock_usecase.MockDigest {
+					mock := mock_usecase.NewMockDigest(ctrl)
+					mock.EXPECT().Subscription(gomock.Any(), "1").Return(subscription, nil)
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().UserID().Return("1"),
+					mock.EXPECT().WriteResponseJSON(http.StatusOK, subscription),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(取得失敗)",
+			h: &digestHandler{
+				uc: func() *mock_usecase.MockDigest {
+					mock := mock_usecase.NewMockDigest(ctrl)
+					mock.EXPECT().Subscription(gomock.Any(), "1").Return(nil, errors.New("test"))
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().UserID().Return("1"),
+					mock.EXPECT().WriteStatusCode(http.StatusInternalServerError),
+				)
+				return mock
+			},
+		},
+		{
+			name: "正常ケース(更新)",
+			h: &digestHandler{
+				uc: func() *mock_usecase.MockDigest {
+					mock := mock_usecase.NewMockDigest(ctrl)
+					mock.EXPECT().UpdateSubscription(gomock.Any(), "1", subscription, gomock.Any()).Return(subscription, nil)
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPut),
+					mock.EXPECT().RequestBody().Return(io.NopCloser(strings.NewReader(`{"frequency":"daily","locale":"ja"}`))),
+					mock.EXPECT().UserID().Return("1"),
+					mock.EXPECT().WriteResponseJSON(http.StatusOK, subscription),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(リクエストボディ不正)",
+			h:    &digestHandler{},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPut),
+					mock.EXPECT().RequestBody().Return(io.NopCloser(strings.NewReader(`{`))),
+					mock.EXPECT().WriteStatusCode(http.StatusBadRequest),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(配信頻度不正)",
+			h: &digestHandler{
+				uc: func() *mock_usecase.MockDigest {
+					mock := mock_usecase.NewMockDigest(ctrl)
+					mock.EXPECT().UpdateSubscription(gomock.Any(), "1", gomock.Any(), gomock.Any()).Return(nil, service.ErrInvalidDigestFrequency)
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPut),
+					mock.EXPECT().RequestBody().Return(io.NopCloser(strings.NewReader(`{"frequency":"monthly","locale":"ja"}`))),
+					mock.EXPECT().UserID().Return("1"),
+					mock.EXPECT().WriteStatusCode(http.StatusBadRequest),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(言語不正)",
+			h: &digestHandler{
+				uc: func() *mock_usecase.MockDigest {
+					mock := mock_usecase.NewMockDigest(ctrl)
+					mock.EXPECT().UpdateSubscription(gomock.Any(), "1", gomock.Any(), gomock.Any()).Return(nil, service.ErrInvalidDigestLocale)
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPut),
+					mock.EXPECT().RequestBody().Return(io.NopCloser(strings.NewReader(`{"frequency":"daily","locale":"fr"}`))),
+					mock.EXPECT().UserID().Return("1"),
+					mock.EXPECT().WriteStatusCode(http.StatusBadRequest),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(更新失敗)",
+			h: &digestHandler{
+				uc: func() *mock_usecase.MockDigest {
+					mock := mock_usecase.NewMockDigest(ctrl)
+					mock.EXPECT().UpdateSubscription(gomock.Any(), "1", gomock.Any(), gomock.Any()).Return(nil, errors.New("test"))
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPut),
+					mock.EXPECT().RequestBody().Return(io.NopCloser(strings.NewReader(`{"frequency":"daily","locale":"ja"}`))),
+					mock.EXPECT().UserID().Return("1"),
+					mock.EXPECT().WriteStatusCode(http.StatusInternalServerError),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(メソッド不正)",
+			h:    &digestHandler{},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().WriteStatusCode(http.StatusMethodNotAllowed),
+				)
+				return mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.h.subscription(tt.c()); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+}
+
+func Test_digestHandler_unsubscribe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	unsubscribeURL := &url.URL{Path: "/digest/unsubscribe", RawQuery: "token=abc"}
+
+	t.Run("正常ケース(確認ページ)", func(t *testing.T) {
+		mock := newMockAPIContext(ctrl)
+		gomock.InOrder(
+			mock.EXPECT().RequestMethod().Return(http.MethodGet),
+			mock.EXPECT().WriteResponseStream(http.StatusOK, "text/html; charset=utf-8", gomock.Any()).DoAndReturn(
+				func(_ int, _ string, body io.Reader) error {
+					b, _ := io.ReadAll(body)
+					if !strings.Contains(string(b), `<form method="post">`) {
+						t.Errorf("確認ページにフォームがない body: %s", b)
+					}
+					return nil
+				},
+			),
+		)
+
+		if err := (&digestHandler{}).unsubscribe(mock); err != nil {
+			t.Errorf("予期せぬエラー(error: %s)", err)
+		}
+	})
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "正常ケース(配信停止)", err: nil, wantStatus: http.StatusNoContent},
+		{name: "異常ケース(トークン不正)", err: service.ErrInvalidUnsubscribeToken, wantStatus: http.StatusNotFound},
+		{name: "異常ケース(更新失敗)", err: errors.New("test"), wantStatus: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUC := mock_usecase.NewMockDigest(ctrl)
+			mock := newMockAPIContext(ctrl)
+			gomock.InOrder(
+				mock.EXPECT().RequestMethod().Return(http.MethodPost),
+				mock.EXPECT().URL().Return(unsubscribeURL),
+				mockUC.EXPECT().Unsubscribe(gomock.Any(), "abc", gomock.Any()).Return(tt.err),
+				mock.EXPECT().WriteStatusCode(tt.wantStatus),
+			)
+
+			if err := (&digestHandler{uc: mockUC}).unsubscribe(mock); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+
+	t.Run("異常ケース(メソッド不正)", func(t *testing.T) {
+		mock := newMockAPIContext(ctrl)
+		gomock.InOrder(
+			mock.EXPECT().RequestMethod().Return(http.MethodDelete),
+			mock.EXPECT().WriteStatusCode(http.StatusMethodNotAllowed),
+		)
+
+		if err := (&digestHandler{}).unsubscribe(mock); err != nil {
+			t.Errorf("予期せぬエラー(error: %s)", err)
+		}
+	})
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/websocket"
+
+	"GoBBS/interface/gateway"
+	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/interface/metrics"
+	"GoBBS/interface/middleware"
+	"GoBBS/interface/middleware/middlewarehelper"
+	"GoBBS/usecase"
+)
+
+type gatewayHandler struct {
+	gateway  gateway.Gateway
+	userUC   usecase.User
+	upgrader *websocket.Upgrader
+}
+
+// NewGatewayHandler WebSocketゲートウェイのハンドラーを生成する
+// allowOriginsは同一オリジン以外に接続を許可するオリジンを返す(設定の再読み込みを反映するため接続ごとに呼び出す)
+func NewGatewayHandler(g gateway.Gateway, userUseCase usecase.User, allowOrigins func() []string) *gatewayHandler {
+	return &gatewayHandler{
+		gateway: g,
+		userUC:  userUseCase,
+		upgrader: &websocket.Upgrader{
+			Subprotocols: []string{middleware.WebSocketAuthProtocol},
+			CheckOrigin:  checkOrigin(allowOrigins),
+		},
+	}
+}
+
+// RegistHandlerFunc ハンドラー登録
+func (h *gatewayHandler) RegistHandlerFunc() {
+	http.HandleFunc(
+		"/ws",
+		middlewarehelper.Apply(
+			handlerctx.NewAPIContext,
+			h.connect,
+			middleware.NewAuth(h.userUC).VerifyWebSocketAuth,
+		),
+	)
+}
+
+// connect WebSocketに切り替え、切断までゲートウェイで処理する
+func (h *gatewayHandler) connect(c handlerctx.APIContext) error {
+	if c.RequestMethod() != http.MethodGet {
+		c.WriteStatusCode(http.StatusMethodNotAllowed)
+		return nil
+	}
+
+	conn, err := c.UpgradeWebSocket(h.upgrader)
+	if err != nil {
+		// エラーレスポンスはupgraderが書き込み済み
+		return nil
+	}
+
+	metrics.ActiveConnections.WithLabelValues(metrics.ConnectionWebSocket).Inc()
+	defer metrics.ActiveConnections.WithLabelValues(metrics.ConnectionWebSocket).Dec()
+
+	h.gateway.Serve(conn, c.UserID())
+	return nil
+}
+
+// checkOrigin 同一オリジンまたは許可されたオリジンからの接続か判定する関数を返す
+func checkOrigin(allowOrigins func() []string) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" || middleware.MatchOrigin(allowOrigins(), origin) {
+			return true
+		}
+		u, err := url.Parse(origin)
+		if err != nil {
+			return false
+		}
+		return u.Host == r.Host
+	}
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/mock/mock_gateway"
+	"GoBBS/mock/mock_handler/mock_handlerctx"
+	"GoBBS/mock/mock_usecase"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/websocket"
+)
+
+func TestNewGatewayHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGateway := mock_gateway.NewMockGateway(ctrl)
+	mockUserUC := mock_usecase.NewMockUser(ctrl)
+
+	got := NewGatewayHandler(mockGateway, mockUserUC, func() []string { return []string{"http://localhost"} })
+	if got.gateway != mockGateway || got.userUC != mockUserUC {
+		t.Errorf("NewGatewayHandler() = %v", got)
+	}
+	if len(got.upgrader.Subprotocols) != 1 || got.upgrader.Subprotocols[0] != "bearer" {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got.upgrader.Subprotocols, []string{"bearer"})
+	}
+}
+
+func Test_gatewayHandler_RegistHandlerFunc(t *testing.T) {
+	h := &gatewayHandler{}
+	h.RegistHandlerFunc()
+}
+
+func Test_gatewayHandler_connect(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	conn := &websocket.Conn{}
+	upgrader := &websocket.Upgrader{}
+
+	tests := []struct {
+		name string
+		h    *gatewayHandler
+		c    handlerctx.APIContext
+	}{
+		{
+			name: "正常ケース",
+			h: &gatewayHandler{
+				gateway: func() *mock_gateway.MockGateway {
+					mock := mock_gateway.NewMockGateway(ctrl)
+					mock.EXPECT().Serve(conn, "1")
+					return mock
+				}(),
+				upgrader: upgrader,
+			},
+			c: func() *mock_handlerctx.MockAPIContext {
+				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().UpgradeWebSocket(upgrader).Return(conn, nil),
+					mock.EXPECT().UserID().Return("1"),
+				)
+				return mock
+			}(),
+		},
+		{
+			name: "異常ケース(メソッド不正)",
+			h:    &gatewayHandler{},
+			c: func() *mock_handlerctx.MockAPIContext {
+				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().WriteStatusCode(http.StatusMethodNotAllowed),
+				)
+				return mock
+			}(),
+		},
+		{
+			name: "異常ケース(プロトコル切り替え失敗)",
+			h:    &gatewayHandler{upgrader: upgrader},
+			c: func() *mock_handlerctx.MockAPIContext {
+				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().UpgradeWebSocket(upgrader).Return(nil, errors.New("error")),
+				)
+				return mock
+			}(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.h.connect(tt.c); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+}
+
+func Test_checkOrigin(t *testing.T) {
+	tests := []struct {
+		name         string
+		allowOrigins []string
+		origin       string
+		want         bool
+	}{
+		{name: "正常ケース(Originなし)", allowOrigins: []string{"http://localhost"}, origin: "", want: true},
+		{name: "正常ケース(許可オリジン)", allowOrigins: []string{"http://localhost"}, origin: "http://localhost", want: true},
+		{name: "正常ケース(全オリジン許可)", allowOrigins: []string{"*"}, origin: "http://example.org", want: true},
+		{name: "正常ケース(同一オリジン)", allowOrigins: []string{"http://localhost"}, origin: "http://example.com", want: true},
+		{name: "正常ケース(許可オリジンのパターン)", allowOrigins: []string{"https://*.example.org"}, origin: "https://app.example.org", want: true},
+		{name: "異常ケース(許可されていないオリジン)", allowOrigins: []string{"http://localhost"}, origin: "http://example.org", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "http://example.com/ws", nil)
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+			if got := checkOrigin(func() []string { return tt.allowOrigins })(r); got != tt.want {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
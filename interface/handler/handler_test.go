@@ -0,0 +1,16 @@
+package handler
+
+import (
+	"GoBBS/mock/mock_handler/mock_handlerctx"
+	"context"
+
+	"github.com/golang/mock/gomock"
+)
+
+// newMockAPIContext APIコンテキストのモックを生成する
+// ユースケースやログ出力に渡すリクエストのコンテキストは呼び出し回数・順序を問わない
+func newMockAPIContext(ctrl *gomock.Controller) *mock_handlerctx.MockAPIContext {
+	mock := mock_handlerctx.NewMockAPIContext(ctrl)
+	mock.EXPECT().RequestContext().Return(context.Background()).AnyTimes()
+	return mock
+}
@@ -1,10 +1,18 @@
 package handlerctx
 
 import (
+	"GoBBS/interface/logging"
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
+	"strings"
+
+	"github.com/gorilla/websocket"
 )
 
 type (
@@ -13,13 +21,31 @@ type (
 	APIContext interface {
 		WriteStatusCode(int)
 		WriteResponseJSON(int, any) error
+		WriteResponseStream(int, string, io.Reader) error
+		StartEventStream() error
+		WriteEvent(string, string, string) error
+		WriteEventComment(string) error
+		RequestContext() context.Context
+		SetRequestContext(context.Context)
+		UpgradeWebSocket(*websocket.Upgrader) (*websocket.Conn, error)
 		RequestHeader() http.Header
 		PathParam() string
 		SetPathParam(string)
+		UserID() string
+		SetUserID(string)
+		SessionID() string
+		SetSessionID(string)
 		URL() *url.URL
 		RequestBody() io.ReadCloser
 		RequestMethod() string
 		AddResponseHeader(string, string)
+		RequestCookie(string) (*http.Cookie, error)
+		SetCookie(*http.Cookie)
+		RemoteIP() string
+		RequestID() string
+		SetRequestID(string)
+		Route() string
+		StatusCode() int
 	}
 
 	// APIContextFactory APIコンテキストファクトリー
@@ -29,6 +55,10 @@ type (
 	apiContext struct {
 		jsonMarshal func(any) ([]byte, error)
 		pathParam   string
+		userID      string
+		sessionID   string
+		requestID   string
+		statusCode  int
 		request     *http.Request
 		response    http.ResponseWriter
 	}
@@ -36,6 +66,8 @@ type (
 
 var _ APIContext = (*apiContext)(nil)
 
+var ErrStreamingUnsupported = errors.New("streaming unsupported")
+
 // NewAPIContext APIコンテキストを生成する
 func NewAPIContext(w http.ResponseWriter, r *http.Request) APIContext {
 	return &apiContext{
@@ -55,8 +87,51 @@ func (c *apiContext) SetPathParam(param string) {
 	c.pathParam = param
 }
 
+// UserID 認証済みユーザーのIDを返す
+func (c *apiContext) UserID() string {
+	return c.userID
+}
+
+// SetUserID 認証済みユーザーのIDをセットする
+func (c *apiContext) SetUserID(userID string) {
+	c.userID = userID
+}
+
+// SessionID 認証に使ったトークンのログインセッションのIDを返す
+func (c *apiContext) SessionID() string {
+	return c.sessionID
+}
+
+// SetSessionID 認証に使ったトークンのログインセッションのIDをセットする
+func (c *apiContext) SetSessionID(sessionID string) {
+	c.sessionID = sessionID
+}
+
+// RequestID リクエストIDを返す
+func (c *apiContext) RequestID() string {
+	return c.requestID
+}
+
+// SetRequestID リクエストIDをセットし、リクエストのコンテキストに紐づける
+// 以降RequestContextを渡して出力したログにはリクエストIDが付与される
+func (c *apiContext) SetRequestID(id string) {
+	c.requestID = id
+	c.request = c.request.WithContext(logging.WithRequestID(c.request.Context(), id))
+}
+
+// Route リクエストに一致したルーティングのパターンを返す
+func (c *apiContext) Route() string {
+	return c.request.Pattern
+}
+
+// StatusCode 書き込んだレスポンスのステータスコードを返す(未書き込みの場合は0)
+func (c *apiContext) StatusCode() int {
+	return c.statusCode
+}
+
 // WriteStatusCode レスポンスのステータスコードをセットする
 func (c *apiContext) WriteStatusCode(statusCode int) {
+	c.statusCode = statusCode
 	c.response.WriteHeader(statusCode)
 }
 
@@ -67,6 +142,7 @@ func (c *apiContext) WriteResponseJSON(statusCode int, json any) error {
 		return err
 	}
 
+	c.statusCode = statusCode
 	c.response.WriteHeader(statusCode)
 	c.response.Header().Add("Content-Type", "application/json")
 	c.response.Write(jsonByte)
@@ -74,6 +150,94 @@ func (c *apiContext) WriteResponseJSON(statusCode int, json any) error {
 	return nil
 }
 
+// WriteResponseStream レスポンスのステータスコード、コンテントタイプ、ボディをセットする
+func (c *apiContext) WriteResponseStream(statusCode int, contentType string, body io.Reader) error {
+	c.response.Header().Set("Content-Type", contentType)
+	c.statusCode = statusCode
+	c.response.WriteHeader(statusCode)
+
+	_, err := io.Copy(c.response, body)
+	return err
+}
+
+// StartEventStream Server-Sent Eventsのレスポンスを開始する
+func (c *apiContext) StartEventStream() error {
+	if _, ok := c.response.(http.Flusher); !ok {
+		return ErrStreamingUnsupported
+	}
+
+	c.response.Header().Set("Content-Type", "text/event-stream")
+	c.response.Header().Set("Cache-Control", "no-cache")
+	// リバースプロキシでのバッファリングを無効にする
+	c.response.Header().Set("X-Accel-Buffering", "no")
+	c.statusCode = http.StatusOK
+	c.response.WriteHeader(http.StatusOK)
+
+	return c.flush()
+}
+
+// WriteEvent Server-Sent Eventsのイベントを書き込み、送信する
+// IDとイベント名は空の場合は省略する
+func (c *apiContext) WriteEvent(id string, event string, data string) error {
+	var b strings.Builder
+	if id != "" {
+		fmt.Fprintf(&b, "id: %s\n", strings.NewReplacer("\r", "", "\n", "").Replace(id))
+	}
+	if event != "" {
+		fmt.Fprintf(&b, "event: %s\n", strings.NewReplacer("\r", "", "\n", "").Replace(event))
+	}
+	for _, line := range strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n") {
+		fmt.Fprintf(&b, "data: %s\n", line)
+	}
+	b.WriteString("\n")
+
+	if _, err := io.WriteString(c.response, b.String()); err != nil {
+		return err
+	}
+	return c.flush()
+}
+
+// WriteEventComment Server-Sent Eventsのコメントを書き込み、送信する
+// 接続を維持するために使用する
+func (c *apiContext) WriteEventComment(comment string) error {
+	if _, err := fmt.Fprintf(c.response, ": %s\n\n", strings.NewReplacer("\r", "", "\n", "").Replace(comment)); err != nil {
+		return err
+	}
+	return c.flush()
+}
+
+// flush 書き込み済みのレスポンスを送信する
+func (c *apiContext) flush() error {
+	flusher, ok := c.response.(http.Flusher)
+	if !ok {
+		return ErrStreamingUnsupported
+	}
+	flusher.Flush()
+	return nil
+}
+
+// RequestContext リクエストのコンテキストを返す
+// クライアントが切断した場合はキャンセルされる
+func (c *apiContext) RequestContext() context.Context {
+	return c.request.Context()
+}
+
+// SetRequestContext リクエストのコンテキストを差し替える
+// ミドルウェアがトレースのスパンなどを紐づけたコンテキストを以降の処理に渡すために使用する
+func (c *apiContext) SetRequestContext(ctx context.Context) {
+	c.request = c.request.WithContext(ctx)
+}
+
+// UpgradeWebSocket WebSocketへプロトコルを切り替える
+// 失敗した場合はupgraderがエラーレスポンスを書き込む
+func (c *apiContext) UpgradeWebSocket(upgrader *websocket.Upgrader) (*websocket.Conn, error) {
+	conn, err := upgrader.Upgrade(c.response, c.request, nil)
+	if err == nil {
+		c.statusCode = http.StatusSwitchingProtocols
+	}
+	return conn, err
+}
+
 // RequestHeader リクエストヘッダーを返す
 func (c *apiContext) RequestHeader() http.Header {
 	return c.request.Header
@@ -98,3 +262,23 @@ func (c *apiContext) RequestMethod() string {
 func (c *apiContext) AddResponseHeader(key string, value string) {
 	c.response.Header().Add(key, value)
 }
+
+// RequestCookie リクエストのクッキーを返す(ない場合はhttp.ErrNoCookie)
+func (c *apiContext) RequestCookie(name string) (*http.Cookie, error) {
+	return c.request.Cookie(name)
+}
+
+// SetCookie レスポンスにクッキーを設定する
+func (c *apiContext) SetCookie(cookie *http.Cookie) {
+	http.SetCookie(c.response, cookie)
+}
+
+// RemoteIP 接続元のIPアドレスを返す
+// X-Forwarded-Forは偽装できるため使わない
+func (c *apiContext) RemoteIP() string {
+	host, _, err := net.SplitHostPort(c.request.RemoteAddr)
+	if err != nil {
+		return c.request.RemoteAddr
+	}
+	return host
+}
@@ -1,7 +1,9 @@
 package handlerctx
 
 import (
+	"GoBBS/interface/logging"
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
@@ -9,7 +11,10 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/gorilla/websocket"
 )
 
 func TestNewAPIContext(t *testing.T) {
@@ -116,6 +121,118 @@ func Test_apiContext_SetPathParam(t *testing.T) {
 	}
 }
 
+func Test_apiContext_UserID(t *testing.T) {
+	tests := []struct {
+		name string
+		c    *apiContext
+		want string
+	}{
+		{
+			name: "正常ケース",
+			c: &apiContext{
+				userID: "1",
+			},
+			want: "1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.UserID(); got != tt.want {
+				t.Errorf("apiContext.UserID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_apiContext_SetUserID(t *testing.T) {
+	type args struct {
+		userID string
+	}
+	tests := []struct {
+		name           string
+		c              *apiContext
+		args           args
+		wantAPIContext *apiContext
+	}{
+		{
+			name: "正常ケース",
+			c:    &apiContext{},
+			args: args{
+				userID: "1",
+			},
+			wantAPIContext: &apiContext{
+				userID: "1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.c.SetUserID(tt.args.userID)
+
+			if !reflect.DeepEqual(tt.c, tt.wantAPIContext) {
+				t.Errorf("apiContext = %#v, want %#v", tt.c, tt.wantAPIContext)
+			}
+		})
+	}
+}
+
+func Test_apiContext_SessionID(t *testing.T) {
+	c := &apiContext{}
+	c.SetSessionID("sid")
+
+	if got := c.SessionID(); got != "sid" {
+		t.Errorf("apiContext.SessionID() = %v, want %v", got, "sid")
+	}
+}
+
+func Test_apiContext_SetRequestID(t *testing.T) {
+	c := &apiContext{request: httptest.NewRequest(http.MethodGet, "/", nil)}
+	c.SetRequestID("abc")
+
+	if got := c.RequestID(); got != "abc" {
+		t.Errorf("apiContext.RequestID() = %v, want %v", got, "abc")
+	}
+	if got := logging.RequestID(c.RequestContext()); got != "abc" {
+		t.Errorf("logging.RequestID() = %v, want %v", got, "abc")
+	}
+}
+
+func Test_apiContext_Route(t *testing.T) {
+	mux := http.NewServeMux()
+	got := ""
+	mux.HandleFunc("/boards/", func(w http.ResponseWriter, r *http.Request) {
+		got = (&apiContext{request: r}).Route()
+	})
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/boards/1", nil))
+
+	if got != "/boards/" {
+		t.Errorf("apiContext.Route() = %v, want %v", got, "/boards/")
+	}
+}
+
+func Test_apiContext_StatusCode(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(*apiContext)
+		want  int
+	}{
+		{name: "正常ケース(未書き込み)", write: func(c *apiContext) {}, want: 0},
+		{name: "正常ケース(WriteStatusCode)", write: func(c *apiContext) { c.WriteStatusCode(http.StatusNoContent) }, want: http.StatusNoContent},
+		{name: "正常ケース(WriteResponseJSON)", write: func(c *apiContext) { c.WriteResponseJSON(http.StatusCreated, "a") }, want: http.StatusCreated},
+		{name: "正常ケース(WriteResponseStream)", write: func(c *apiContext) { c.WriteResponseStream(http.StatusOK, "text/plain", strings.NewReader("a")) }, want: http.StatusOK},
+		{name: "正常ケース(StartEventStream)", write: func(c *apiContext) { c.StartEventStream() }, want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &apiContext{jsonMarshal: json.Marshal, response: httptest.NewRecorder()}
+			tt.write(c)
+			if got := c.StatusCode(); got != tt.want {
+				t.Errorf("apiContext.StatusCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_apiContext_WriteStatusCode(t *testing.T) {
 	type args struct {
 		statusCode int
@@ -216,6 +333,151 @@ func Test_apiContext_WriteResponseJSON(t *testing.T) {
 	}
 }
 
+func Test_apiContext_WriteResponseStream(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	c := &apiContext{response: recorder}
+
+	if err := c.WriteResponseStream(http.StatusOK, "image/png", bytes.NewBufferString("data")); err != nil {
+		t.Errorf("apiContext.WriteResponseStream() error = %v", err)
+	}
+	if recorder.Code != http.StatusOK {
+		t.Errorf("StatusCode = %v, want %v", recorder.Code, http.StatusOK)
+	}
+	if got := recorder.Header().Get("Content-Type"); got != "image/png" {
+		t.Errorf("Content-Type = %v, want image/png", got)
+	}
+	if got := recorder.Body.String(); got != "data" {
+		t.Errorf("Body = %v, want data", got)
+	}
+}
+
+func Test_apiContext_StartEventStream(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	c := &apiContext{response: recorder}
+
+	if err := c.StartEventStream(); err != nil {
+		t.Errorf("apiContext.StartEventStream() error = %v", err)
+	}
+	if recorder.Code != http.StatusOK {
+		t.Errorf("StatusCode = %v, want %v", recorder.Code, http.StatusOK)
+	}
+	if got := recorder.Header().Get("Content-Type"); got != "text/event-stream" {
+		t.Errorf("Content-Type = %v, want text/event-stream", got)
+	}
+	if !recorder.Flushed {
+		t.Error("レスポンスが送信されていない")
+	}
+}
+
+func Test_apiContext_StartEventStream_unsupported(t *testing.T) {
+	c := &apiContext{response: struct{ http.ResponseWriter }{httptest.NewRecorder()}}
+
+	if err := c.StartEventStream(); err != ErrStreamingUnsupported {
+		t.Errorf("apiContext.StartEventStream() error = %v, want %v", err, ErrStreamingUnsupported)
+	}
+}
+
+func Test_apiContext_WriteEvent(t *testing.T) {
+	tests := []struct {
+		name  string
+		id    string
+		event string
+		data  string
+		want  string
+	}{
+		{
+			name:  "正常ケース",
+			id:    "1",
+			event: "post.created",
+			data:  `{"id":"1"}`,
+			want:  "id: 1\nevent: post.created\ndata: {\"id\":\"1\"}\n\n",
+		},
+		{
+			name: "正常ケース(複数行のデータ)",
+			data: "a\r\nb\nc",
+			want: "data: a\ndata: b\ndata: c\n\n",
+		},
+		{
+			name:  "正常ケース(IDとイベント名の改行除去)",
+			id:    "1\n2",
+			event: "a\r\nb",
+			data:  "",
+			want:  "id: 12\nevent: ab\ndata: \n\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			c := &apiContext{response: recorder}
+
+			if err := c.WriteEvent(tt.id, tt.event, tt.data); err != nil {
+				t.Errorf("apiContext.WriteEvent() error = %v", err)
+			}
+			if got := recorder.Body.String(); got != tt.want {
+				t.Errorf("Body = %#v, want %#v", got, tt.want)
+			}
+			if !recorder.Flushed {
+				t.Error("レスポンスが送信されていない")
+			}
+		})
+	}
+}
+
+func Test_apiContext_WriteEventComment(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	c := &apiContext{response: recorder}
+
+	if err := c.WriteEventComment("keep-alive"); err != nil {
+		t.Errorf("apiContext.WriteEventComment() error = %v", err)
+	}
+	if got := recorder.Body.String(); got != ": keep-alive\n\n" {
+		t.Errorf("Body = %#v, want %#v", got, ": keep-alive\n\n")
+	}
+}
+
+func Test_apiContext_RequestContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c := &apiContext{request: httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)}
+
+	if got := c.RequestContext(); got != ctx {
+		t.Errorf("apiContext.RequestContext() = %v, want %v", got, ctx)
+	}
+}
+
+func Test_apiContext_SetRequestContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c := &apiContext{request: httptest.NewRequest(http.MethodGet, "/", nil)}
+	c.SetRequestContext(ctx)
+
+	if got := c.RequestContext(); got != ctx {
+		t.Errorf("apiContext.RequestContext() = %v, want %v", got, ctx)
+	}
+}
+
+func Test_apiContext_UpgradeWebSocket(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c := &apiContext{response: w, request: r}
+		conn, err := c.UpgradeWebSocket(&websocket.Upgrader{})
+		if err != nil {
+			t.Errorf("apiContext.UpgradeWebSocket() error = %v", err)
+			return
+		}
+		conn.Close()
+		if c.StatusCode() != http.StatusSwitchingProtocols {
+			t.Errorf("apiContext.StatusCode() = %v, want %v", c.StatusCode(), http.StatusSwitchingProtocols)
+		}
+	}))
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	conn.Close()
+}
+
 func Test_apiContext_RequestHeader(t *testing.T) {
 	tests := []struct {
 		name string
@@ -367,3 +629,55 @@ func Test_apiContext_AddResponseHeader(t *testing.T) {
 		})
 	}
 }
+
+func Test_apiContext_RequestCookie(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.AddCookie(&http.Cookie{Name: "name", Value: "value"})
+	c := &apiContext{request: r}
+
+	got, err := c.RequestCookie("name")
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	if got.Value != "value" {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got.Value, "value")
+	}
+
+	if _, err := c.RequestCookie("none"); err != http.ErrNoCookie {
+		t.Errorf("apiContext.RequestCookie() error = %v, want %v", err, http.ErrNoCookie)
+	}
+}
+
+func Test_apiContext_SetCookie(t *testing.T) {
+	c := &apiContext{response: httptest.NewRecorder()}
+
+	c.SetCookie(&http.Cookie{Name: "name", Value: "value", Path: "/", HttpOnly: true})
+
+	want := http.Header{"Set-Cookie": {"name=value; Path=/; HttpOnly"}}
+	if !reflect.DeepEqual(c.response.Header(), want) {
+		t.Errorf("apiContext.SetCookie() = %v, want %v", c.response.Header(), want)
+	}
+}
+
+func Test_apiContext_RemoteIP(t *testing.T) {
+	tests := []struct {
+		name       string
+		remoteAddr string
+		want       string
+	}{
+		{name: "IPv4", remoteAddr: "192.0.2.1:1234", want: "192.0.2.1"},
+		{name: "IPv6", remoteAddr: "[2001:db8::1]:1234", want: "2001:db8::1"},
+		{name: "ポートなし", remoteAddr: "192.0.2.1", want: "192.0.2.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = tt.remoteAddr
+			c := &apiContext{request: r}
+
+			if got := c.RemoteIP(); got != tt.want {
+				t.Errorf("apiContext.RemoteIP() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
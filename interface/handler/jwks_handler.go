@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"net/http"
+
+	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/interface/middleware/middlewarehelper"
+	"GoBBS/interface/security"
+)
+
+type jwksHandler struct {
+	keys security.KeySet
+}
+
+// jwksCacheControl 公開鍵のキャッシュ期間(鍵の追加が反映されるまでの最大時間)
+const jwksCacheControl = "public, max-age=300"
+
+// NewJWKSHandler トークン検証用の公開鍵ハンドラーを生成する
+func NewJWKSHandler(keys security.KeySet) *jwksHandler {
+	return &jwksHandler{keys: keys}
+}
+
+// RegistHandlerFunc ハンドラー登録
+func (h *jwksHandler) RegistHandlerFunc() {
+	http.HandleFunc(
+		"/.well-known/jwks.json",
+		middlewarehelper.Apply(
+			handlerctx.NewAPIContext,
+			h.jwks,
+		),
+	)
+}
+
+// jwks 他のサービスがトークンを検証するための公開鍵の一覧(JWKS)を返す
+func (h *jwksHandler) jwks(c handlerctx.APIContext) error {
+	if c.RequestMethod() != http.MethodGet {
+		c.WriteStatusCode(http.StatusMethodNotAllowed)
+		return nil
+	}
+
+	c.AddResponseHeader("Cache-Control", jwksCacheControl)
+	return c.WriteResponseJSON(http.StatusOK, h.keys.JWKS())
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/interface/security"
+	"GoBBS/mock/mock_security"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewJWKSHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockKeys := mock_security.NewMockKeySet(ctrl)
+
+	want := &jwksHandler{keys: mockKeys}
+	if got := NewJWKSHandler(mockKeys); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewJWKSHandler() = %v, want %v", got, want)
+	}
+}
+
+func Test_jwksHandler_RegistHandlerFunc(t *testing.T) {
+	h := &jwksHandler{}
+	h.RegistHandlerFunc()
+}
+
+func Test_jwksHandler_jwks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	jwks := &security.JWKS{Keys: []*security.JWK{{Kty: "OKP", Kid: "2025-01", Alg: "EdDSA", Use: "sig", Crv: "Ed25519", X: "x"}}}
+
+	tests := []struct {
+		name string
+		h    *jwksHandler
+		c    func() handlerctx.APIContext
+	}{
+		{
+			name: "正常ケース",
+			h: &jwksHandler{
+				keys: func() *mock_security.MockKeySet {
+					mock := mock_security.NewMockKeySet(ctrl)
+					mock.EXPECT().JWKS().Return(jwks)
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().AddResponseHeader("Cache-Control", jwksCacheControl),
+					mock.EXPECT().WriteResponseJSON(http.StatusOK, jwks),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(メソッド不正)",
+			h:    &jwksHandler{},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().WriteStatusCode(http.StatusMethodNotAllowed),
+				)
+				return mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.h.jwks(tt.c()); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/service"
+	"GoBBS/dto"
+	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/interface/middleware"
+	"GoBBS/interface/middleware/middlewarehelper"
+	"GoBBS/usecase"
+)
+
+type mfaHandler struct {
+	uc     usecase.MFA
+	userUC usecase.User
+}
+
+// NewMFAHandler 2要素認証ハンドラーを生成する
+func NewMFAHandler(mfaUseCase usecase.MFA, userUseCase usecase.User) *mfaHandler {
+	return &mfaHandler{
+		uc:     mfaUseCase,
+		userUC: userUseCase,
+	}
+}
+
+// RegistHandlerFunc ハンドラー登録
+func (h *mfaHandler) RegistHandlerFunc() {
+	http.HandleFunc(
+		"/me/mfa",
+		middlewarehelper.Apply(
+			handlerctx.NewAPIContext,
+			h.mfa,
+			middleware.NewAuth(h.userUC).VerifyAuth,
+		),
+	)
+
+	http.HandleFunc(
+		"/me/mfa/confirm",
+		middlewarehelper.Apply(
+			handlerctx.NewAPIContext,
+			h.confirm,
+			middleware.NewAuth(h.userUC).VerifyAuth,
+		),
+	)
+}
+
+// mfa 2要素認証の登録(POST)と無効化(DELETE)
+func (h *mfaHandler) mfa(c handlerctx.APIContext) error {
+	switch c.RequestMethod() {
+	case http.MethodPost:
+		return h.enroll(c)
+	case http.MethodDelete:
+		return h.disable(c)
+	default:
+		c.WriteStatusCode(http.StatusMethodNotAllowed)
+		return nil
+	}
+}
+
+// enroll TOTPの秘密鍵を生成し、認証アプリに登録するotpauth URIを返す
+func (h *mfaHandler) enroll(c handlerctx.APIContext) error {
+	enrollment, err := h.uc.Enroll(c.RequestContext(), c.UserID(), time.Now())
+	if err != nil {
+		if errors.Is(err, service.ErrMFAAlreadyEnabled) {
+			c.WriteStatusCode(http.StatusConflict)
+			return nil
+		}
+		slog.ErrorContext(c.RequestContext(), "mfa enroll error", "error", err)
+		c.WriteStatusCode(http.StatusInternalServerError)
+		return nil
+	}
+
+	return c.WriteResponseJSON(http.StatusOK, enrollment)
+}
+
+// confirm 最初の確認コードで2要素認証を有効にし、リカバリーコードを返す
+func (h *mfaHandler) confirm(c handlerctx.APIContext) error {
+	if c.RequestMethod() != http.MethodPost {
+		c.WriteStatusCode(http.StatusMethodNotAllowed)
+		return nil
+	}
+
+	var req dto.MFACode
+	if err := json.NewDecoder(c.RequestBody()).Decode(&req); err != nil || req.Code == "" {
+		c.WriteStatusCode(http.StatusBadRequest)
+		return nil
+	}
+
+	codes, err := h.uc.Confirm(c.RequestContext(), c.UserID(), req.Code, time.Now())
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidMFACode):
+			c.WriteStatusCode(http.StatusBadRequest)
+		case errors.Is(err, service.ErrMFANotEnrolled):
+			c.WriteStatusCode(http.StatusNotFound)
+		case errors.Is(err, service.ErrMFAAlreadyEnabled):
+			c.WriteStatusCode(http.StatusConflict)
+		default:
+			slog.ErrorContext(c.RequestContext(), "mfa confirm error", "error", err)
+			c.WriteStatusCode(http.StatusInternalServerError)
+		}
+		return nil
+	}
+
+	return c.WriteResponseJSON(http.StatusOK, codes)
+}
+
+// disable 確認コードかリカバリーコードで2要素認証を無効にする
+func (h *mfaHandler) disable(c handlerctx.APIContext) error {
+	var req dto.MFACode
+	if err := json.NewDecoder(c.RequestBody()).Decode(&req); err != nil || req.Code == "" {
+		c.WriteStatusCode(http.StatusBadRequest)
+		return nil
+	}
+
+	if err := h.uc.Disable(c.RequestContext(), c.UserID(), req.Code, time.Now()); err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidMFACode):
+			c.WriteStatusCode(http.StatusBadRequest)
+		case errors.Is(err, service.ErrMFANotEnrolled):
+			c.WriteStatusCode(http.StatusNotFound)
+		default:
+			slog.ErrorContext(c.RequestContext(), "mfa disable error", "error", err)
+			c.WriteStatusCode(http.StatusInternalServerError)
+		}
+		return nil
+	}
+
+	c.WriteStatusCode(http.StatusNoContent)
+	return nil
+}
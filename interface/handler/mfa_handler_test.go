@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"GoBBS/domain/service"
+	"GoBBS/dto"
+	"GoBBS/mock/mock_usecase"
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewMFAHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUC := mock_usecase.NewMockMFA(ctrl)
+	mockUserUC := mock_usecase.NewMockUser(ctrl)
+
+	want := &mfaHandler{uc: mockUC, userUC: mockUserUC}
+	if got := NewMFAHandler(mockUC, mockUserUC); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewMFAHandler() = %v, want %v", got, want)
+	}
+}
+
+func Test_mfaHandler_RegistHandlerFunc(t *testing.T) {
+	h := &mfaHandler{}
+	h.RegistHandlerFunc()
+}
+
+func Test_mfaHandler_enroll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	enrollment := dto.NewMFAEnrollment("SECRET", "otpauth://totp/GoBBS:alice?secret=SECRET")
+
+	tests := []struct {
+		name       string
+		method     string
+		err        error
+		wantStatus int
+	}{
+		{name: "正常ケース", method: http.MethodPost, wantStatus: http.StatusOK},
+		{name: "異常ケース(メソッド不正)", method: http.MethodGet, wantStatus: http.StatusMethodNotAllowed},
+		{name: "異常ケース(有効化済み)", method: http.MethodPost, err: service.ErrMFAAlreadyEnabled, wantStatus: http.StatusConflict},
+		{name: "異常ケース(登録失敗)", method: http.MethodPost, err: errors.New("test"), wantStatus: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUC := mock_usecase.NewMockMFA(ctrl)
+			mock := newMockAPIContext(ctrl)
+			calls := []*gomock.Call{mock.EXPECT().RequestMethod().Return(tt.method)}
+			switch {
+			case tt.method != http.MethodPost:
+				calls = append(calls, mock.EXPECT().WriteStatusCode(tt.wantStatus))
+			case tt.err != nil:
+				calls = append(calls,
+					mock.EXPECT().UserID().Return("1"),
+					mockUC.EXPECT().Enroll(gomock.Any(), "1", gomock.Any()).Return(nil, tt.err),
+					mock.EXPECT().WriteStatusCode(tt.wantStatus),
+				)
+			default:
+				calls = append(calls,
+					mock.EXPECT().UserID().Return("1"),
+					mockUC.EXPECT().Enroll(gomock.Any(), "1", gomock.Any()).Return(enrollment, nil),
+					mock.EXPECT().WriteResponseJSON(tt.wantStatus, enrollment),
+				)
+			}
+			gomock.InOrder(calls...)
+
+			if err := (&mfaHandler{uc: mockUC}).mfa(mock); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+}
+
+func Test_mfaHandler_confirm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	codes := dto.NewMFARecoveryCodes([]string{"0123a-4567b"})
+
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{name: "正常ケース", method: http.MethodPost, body: `{"code":"123456"}`, wantStatus: http.StatusOK},
+		{name: "異常ケース(メソッド不正)", method: http.MethodGet, wantStatus: http.StatusMethodNotAllowed},
+		{name: "異常ケース(リクエストボディ不正)", method: http.MethodPost, body: `{`, wantStatus: http.StatusBadRequest},
+		{name: "異常ケース(コードなし)", method: http.MethodPost, body: `{}`, wantStatus: http.StatusBadRequest},
+		{name: "異常ケース(コード不一致)", method: http.MethodPost, body: `{"code":"123456"}`, err: service.ErrInvalidMFACode, wantStatus: http.StatusBadRequest},
+		{name: "異常ケース(未登録)", method: http.MethodPost, body: `{"code":"123456"}`, err: service.ErrMFANotEnrolled, wantStatus: http.StatusNotFound},
+		{name: "異常ケース(有効化済み)", method: http.MethodPost, body: `{"code":"123456"}`, err: service.ErrMFAAlreadyEnabled, wantStatus: http.StatusConflict},
+		{name: "異常ケース(有効化失敗)", method: http.MethodPost, body: `{"code":"123456"}`, err: errors.New("test"), wantStatus: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUC := mock_usecase.NewMockMFA(ctrl)
+			mock := newMockAPIContext(ctrl)
+			calls := []*gomock.Call{mock.EXPECT().RequestMethod().Return(tt.method)}
+			if tt.method == http.MethodPost {
+				calls = append(calls, mock.EXPECT().RequestBody().Return(io.NopCloser(bytes.NewBufferString(tt.body))))
+			}
+			switch {
+			case tt.wantStatus == http.StatusOK:
+				calls = append(calls,
+					mock.EXPECT().UserID().Return("1"),
+					mockUC.EXPECT().Confirm(gomock.Any(), "1", "123456", gomock.Any()).Return(codes, nil),
+					mock.EXPECT().WriteResponseJSON(tt.wantStatus, codes),
+				)
+			case tt.err != nil:
+				calls = append(calls,
+					mock.EXPECT().UserID().Return("1"),
+					mockUC.EXPECT().Confirm(gomock.Any(), "1", "123456", gomock.Any()).Return(nil, tt.err),
+					mock.EXPECT().WriteStatusCode(tt.wantStatus),
+				)
+			default:
+				calls = append(calls, mock.EXPECT().WriteStatusCode(tt.wantStatus))
+			}
+			gomock.InOrder(calls...)
+
+			if err := (&mfaHandler{uc: mockUC}).confirm(mock); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+}
+
+func Test_mfaHandler_disable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{name: "正常ケース", body: `{"code":"0123a-4567b"}`, wantStatus: http.StatusNoContent},
+		{name: "異常ケース(リクエストボディ不正)", body: `{`, wantStatus: http.StatusBadRequest},
+		{name: "異常ケース(コードなし)", body: `{}`, wantStatus: http.StatusBadRequest},
+		{name: "異常ケース(コード不一致)", body: `{"code":"0123a-4567b"}`, err: service.ErrInvalidMFACode, wantStatus: http.StatusBadRequest},
+		{name: "異常ケース(未登録)", body: `{"code":"0123a-4567b"}`, err: service.ErrMFANotEnrolled, wantStatus: http.StatusNotFound},
+		{name: "異常ケース(無効化失敗)", body: `{"code":"0123a-4567b"}`, err: errors.New("test"), wantStatus: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUC := mock_usecase.NewMockMFA(ctrl)
+			mock := newMockAPIContext(ctrl)
+			calls := []*gomock.Call{
+				mock.EXPECT().RequestMethod().Return(http.MethodDelete),
+				mock.EXPECT().RequestBody().Return(io.NopCloser(bytes.NewBufferString(tt.body))),
+			}
+			if tt.err != nil || tt.wantStatus == http.StatusNoContent {
+				calls = append(calls,
+					mock.EXPECT().UserID().Return("1"),
+					mockUC.EXPECT().Disable(gomock.Any(), "1", "0123a-4567b", gomock.Any()).Return(tt.err),
+				)
+			}
+			calls = append(calls, mock.EXPECT().WriteStatusCode(tt.wantStatus))
+			gomock.InOrder(calls...)
+
+			if err := (&mfaHandler{uc: mockUC}).mfa(mock); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+}
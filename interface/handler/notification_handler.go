@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/service"
+	"GoBBS/dto"
+	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/interface/middleware"
+	"GoBBS/interface/middleware/middlewarehelper"
+	"GoBBS/usecase"
+)
+
+type notificationHandler struct {
+	uc     usecase.Notification
+	userUC usecase.User
+}
+
+// NewNotificationHandler 通知ハンドラーを生成する
+func NewNotificationHandler(notificationUseCase usecase.Notification, userUseCase usecase.User) *notificationHandler {
+	return &notificationHandler{
+		uc:     notificationUseCase,
+		userUC: userUseCase,
+	}
+}
+
+// RegistHandlerFunc ハンドラー登録
+func (h *notificationHandler) RegistHandlerFunc() {
+	http.HandleFunc(
+		"/notifications",
+		middlewarehelper.Apply(
+			handlerctx.NewAPIContext,
+			h.list,
+			middleware.NewAuth(h.userUC).VerifyScope(model.APIScopeRead),
+		),
+	)
+
+	http.HandleFunc(
+		"/notifications/read",
+		middlewarehelper.Apply(
+			handlerctx.NewAPIContext,
+			h.markAllRead,
+			middleware.NewAuth(h.userUC).VerifyScope(model.APIScopePost),
+		),
+	)
+
+	http.HandleFunc(
+		"/notifications/",
+		middlewarehelper.Apply(
+			handlerctx.NewAPIContext,
+			h.markRead,
+			middleware.NewAuth(h.userUC).VerifyScope(model.APIScopePost),
+			middleware.NewPathParam("/notifications/:id/read").Parse,
+		),
+	)
+
+	http.HandleFunc(
+		"/me/notification-preferences",
+		middlewarehelper.Apply(
+			handlerctx.NewAPIContext,
+			h.preferences,
+			middleware.NewAuth(h.userUC).VerifyAuth,
+		),
+	)
+}
+
+// list 通知一覧と未読数の取得(beforeより古い通知をlimit件まで新しい順に返す)
+func (h *notificationHandler) list(c handlerctx.APIContext) error {
+	if c.RequestMethod() != http.MethodGet {
+		c.WriteStatusCode(http.StatusMethodNotAllowed)
+		return nil
+	}
+
+	query := c.URL().Query()
+	limit := 0
+	if v := query.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			c.WriteStatusCode(http.StatusBadRequest)
+			return nil
+		}
+		limit = n
+	}
+
+	list, err := h.uc.List(c.RequestContext(), c.UserID(), query.Get("before"), limit)
+	if err != nil {
+		slog.ErrorContext(c.RequestContext(), "notification list error", "error", err)
+		c.WriteStatusCode(http.StatusInternalServerError)
+		return nil
+	}
+
+	return c.WriteResponseJSON(http.StatusOK, list)
+}
+
+// markRead 通知を既読にする
+func (h *notificationHandler) markRead(c handlerctx.APIContext) error {
+	if c.RequestMethod() != http.MethodPost {
+		c.WriteStatusCode(http.StatusMethodNotAllowed)
+		return nil
+	}
+
+	if err := h.uc.MarkRead(c.RequestContext(), c.UserID(), c.PathParam(), time.Now()); err != nil {
+		if errors.Is(err, service.ErrNotificationNotFound) {
+			c.WriteStatusCode(http.StatusNotFound)
+			return nil
+		}
+		slog.ErrorContext(c.RequestContext(), "notification mark read error", "error", err)
+		c.WriteStatusCode(http.StatusInternalServerError)
+		return nil
+	}
+
+	c.WriteStatusCode(http.StatusNoContent)
+	return nil
+}
+
+// markAllRead 未読の通知をすべて既読にする
+func (h *notificationHandler) markAllRead(c handlerctx.APIContext) error {
+	if c.RequestMethod() != http.MethodPost {
+		c.WriteStatusCode(http.StatusMethodNotAllowed)
+		return nil
+	}
+
+	if err := h.uc.MarkAllRead(c.RequestContext(), c.UserID(), time.Now()); err != nil {
+		slog.ErrorContext(c.RequestContext(), "notification mark all read error", "error", err)
+		c.WriteStatusCode(http.StatusInternalServerError)
+		return nil
+	}
+
+	c.WriteStatusCode(http.StatusNoContent)
+	return nil
+}
+
+// preferences 通知の受信設定の取得(GET)、更新(PUT)
+func (h *notificationHandler) preferences(c handlerctx.APIContext) error {
+	switch c.RequestMethod() {
+	case http.MethodGet:
+		preferences, err := h.uc.Preferences(c.RequestContext(), c.UserID())
+		if err != nil {
+			slog.ErrorContext(c.RequestContext(), "notification preferences error", "error", err)
+			c.WriteStatusCode(http.StatusInternalServerError)
+			return nil
+		}
+		return c.WriteResponseJSON(http.StatusOK, preferences)
+	case http.MethodPut:
+		var preferences dto.NotificationPreferences
+		if err := json.NewDecoder(c.RequestBody()).Decode(&preferences); err != nil {
+			c.WriteStatusCode(http.StatusBadRequest)
+			return nil
+		}
+		if err := h.uc.UpdatePreferences(c.RequestContext(), c.UserID(), &preferences, time.Now()); err != nil {
+			slog.ErrorContext(c.RequestContext(), "notification update preferences error", "error", err)
+			c.WriteStatusCode(http.StatusInternalServerError)
+			return nil
+		}
+		return c.WriteResponseJSON(http.StatusOK, &preferences)
+	default:
+		c.WriteStatusCode(http.StatusMethodNotAllowed)
+		return nil
+	}
+}
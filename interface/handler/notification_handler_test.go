@@ -0,0 +1,345 @@
+package handler
+
+import (
+	"GoBBS/domain/service"
+	"GoBBS/dto"
+	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/mock/mock_usecase"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewNotificationHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUC := mock_usecase.NewMockNotification(ctrl)
+	mockUserUC := mock_usecase.NewMockUser(ctrl)
+
+	want := &notificationHandler{uc: mockUC, userUC: mockUserUC}
+	if got := NewNotificationHandler(mockUC, mockUserUC); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewNotificationHandler() = %v, want %v", got, want)
+	}
+}
+
+func Test_notificationHandler_RegistHandlerFunc(t *testing.T) {
+	h := &notificationHandler{}
+	h.RegistHandlerFunc()
+}
+
+func Test_notificationHandler_list(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	list := &dto.NotificationList{Notifications: []*dto.Notification{{ID: "3", Kind: "reply"}}, UnreadCount: 1}
+
+	tests := []struct {
+		name string
+		h    *notificationHandler
+		c    func() handlerctx.APIContext
+	}{
+		{
+			name: "正常ケース",
+			h: &notificationHandler{
+				uc: func() *mock_usecase.MockNotification {
+					mock := mock_usecase.NewMockNotification(ctrl)
+					mock.EXPECT().List(gomock.Any(), "1", "5", 20).Return(list, nil)
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().URL().Return(&url.URL{Path: "/notifications", RawQuery: "before=5&limit=20"}),
+					mock.EXPECT().UserID().Return("1"),
+					mock.EXPECT().WriteResponseJSON(http.StatusOK, list),
+				)
+				return mock
+			},
+		},
+		{
+			name: "正常ケース(件数未指定)",
+			h: &notificationHandler{
+				uc: func() *mock_usecase.MockNotification {
+					mock := mock_usecase.NewMockNotification(ctrl)
+					mock.EXPECT().List(gomock.Any(), "1", "", 0).Return(list, nil)
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().URL().Return(&url.URL{Path: "/notifications"}),
+					mock.EXPECT().UserID().Return("1"),
+					mock.EXPECT().WriteResponseJSON(http.StatusOK, list),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(メソッド不正)",
+			h:    &notificationHandler{},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().WriteStatusCode(http.StatusMethodNotAllowed),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(件数不正)",
+			h:    &notificationHandler{},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().URL().Return(&url.URL{Path: "/notifications", RawQuery: "limit=0"}),
+					mock.EXPECT().WriteStatusCode(http.StatusBadRequest),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(取得失敗)",
+			h: &notificationHandler{
+				uc: func() *mock_usecase.MockNotification {
+					mock := mock_usecase.NewMockNotification(ctrl)
+					mock.EXPECT().List(gomock.Any(), "1", "", 0).Return(nil, errors.New("test"))
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().URL().Return(&url.URL{Path: "/notifications"}),
+					mock.EXPECT().UserID().Return("1"),
+					mock.EXPECT().WriteStatusCode(http.StatusInternalServerError),
+				)
+				return mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.h.list(tt.c()); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+}
+
+func Test_notificationHandler_markRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		method     string
+		err        error
+		wantStatus int
+	}{
+		{name: "正常ケース", method: http.MethodPost, err: nil, wantStatus: http.StatusNoContent},
+		{name: "異常ケース(メソッド不正)", method: http.MethodGet, wantStatus: http.StatusMethodNotAllowed},
+		{name: "異常ケース(通知が未登録)", method: http.MethodPost, err: service.ErrNotificationNotFound, wantStatus: http.StatusNotFound},
+		{name: "異常ケース(更新失敗)", method: http.MethodPost, err: errors.New("test"), wantStatus: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUC := mock_usecase.NewMockNotification(ctrl)
+			mock := newMockAPIContext(ctrl)
+			if tt.method == http.MethodPost {
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(tt.method),
+					mock.EXPECT().UserID().Return("1"),
+					mock.EXPECT().PathParam().Return("5"),
+					mockUC.EXPECT().MarkRead(gomock.Any(), "1", "5", gomock.Any()).Return(tt.err),
+					mock.EXPECT().WriteStatusCode(tt.wantStatus),
+				)
+			} else {
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(tt.method),
+					mock.EXPECT().WriteStatusCode(tt.wantStatus),
+				)
+			}
+
+			if err := (&notificationHandler{uc: mockUC}).markRead(mock); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+}
+
+func Test_notificationHandler_markAllRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		method     string
+		err        error
+		wantStatus int
+	}{
+		{name: "正常ケース", method: http.MethodPost, err: nil, wantStatus: http.StatusNoContent},
+		{name: "異常ケース(メソッド不正)", method: http.MethodGet, wantStatus: http.StatusMethodNotAllowed},
+		{name: "異常ケース(更新失敗)", method: http.MethodPost, err: errors.New("test"), wantStatus: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUC := mock_usecase.NewMockNotification(ctrl)
+			mock := newMockAPIContext(ctrl)
+			if tt.method == http.MethodPost {
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(tt.method),
+					mock.EXPECT().UserID().Return("1"),
+					mockUC.EXPECT().MarkAllRead(gomock.Any(), "1", gomock.Any()).Return(tt.err),
+					mock.EXPECT().WriteStatusCode(tt.wantStatus),
+				)
+			} else {
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(tt.method),
+					mock.EXPECT().WriteStatusCode(tt.wantStatus),
+				)
+			}
+
+			if err := (&notificationHandler{uc: mockUC}).markAllRead(mock); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+}
+
+func Test_notificationHandler_preferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	preferences := &dto.NotificationPreferences{Reply: true, Quote: false, Mention: true}
+
+	tests := []struct {
+		name string
+		h    *notificationHandler
+		c    func() handlerctx.APIContext
+	}{
+		{
+			name: "正常ケース(取得)",
+			h: &notificationHandler{
+				uc: func() *mock_usecase.MockNotification {
+					mock := mock_usecase.NewMockNotification(ctrl)
+					mock.EXPECT().Preferences(gomock.Any(), "1").Return(preferences, nil)
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().UserID().Return("1"),
+					mock.EXPECT().WriteResponseJSON(http.StatusOK, preferences),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(取得失敗)",
+			h: &notificationHandler{
+				uc: func() *mock_usecase.MockNotification {
+					mock := mock_usecase.NewMockNotification(ctrl)
+					mock.EXPECT().Preferences(gomock.Any(), "1").Return(nil, errors.New("test"))
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().UserID().Return("1"),
+					mock.EXPECT().WriteStatusCode(http.StatusInternalServerError),
+				)
+				return mock
+			},
+		},
+		{
+			name: "正常ケース(更新)",
+			h: &notificationHandler{
+				uc: func() *mock_usecase.MockNotification {
+					mock := mock_usecase.NewMockNotification(ctrl)
+					mock.EXPECT().UpdatePreferences(gomock.Any(), "1", preferences, gomock.Any()).Return(nil)
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPut),
+					mock.EXPECT().RequestBody().Return(io.NopCloser(strings.NewReader(`{"reply":true,"quote":false,"mention":true}`))),
+					mock.EXPECT().UserID().Return("1"),
+					mock.EXPECT().WriteResponseJSON(http.StatusOK, preferences),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(リクエストボディ不正)",
+			h:    &notificationHandler{},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPut),
+					mock.EXPECT().RequestBody().Return(io.NopCloser(strings.NewReader(`{`))),
+					mock.EXPECT().WriteStatusCode(http.StatusBadRequest),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(更新失敗)",
+			h: &notificationHandler{
+				uc: func() *mock_usecase.MockNotification {
+					mock := mock_usecase.NewMockNotification(ctrl)
+					mock.EXPECT().UpdatePreferences(gomock.Any(), "1", preferences, gomock.Any()).Return(errors.New("test"))
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPut),
+					mock.EXPECT().RequestBody().Return(io.NopCloser(strings.NewReader(`{"reply":true,"mention":true}`))),
+					mock.EXPECT().UserID().Return("1"),
+					mock.EXPECT().WriteStatusCode(http.StatusInternalServerError),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(メソッド不正)",
+			h:    &notificationHandler{},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodDelete),
+					mock.EXPECT().WriteStatusCode(http.StatusMethodNotAllowed),
+				)
+				return mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.h.preferences(tt.c()); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+}
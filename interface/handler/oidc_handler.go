@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/service"
+	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/interface/metrics"
+	"GoBBS/interface/middleware"
+	"GoBBS/interface/middleware/middlewarehelper"
+	"GoBBS/interface/oidc"
+	"GoBBS/usecase"
+)
+
+type oidcHandler struct {
+	provider     oidc.Provider
+	userUC       usecase.User
+	postLoginURL string
+}
+
+const (
+	// oidcCookieName 認可リクエストのstate、nonce、code_verifierをコールバックまで保持するクッキー
+	oidcCookieName = "gobbs_oidc"
+	// oidcCookiePath 認可リクエストのクッキーを送るパス(コールバックでのみ使う)
+	oidcCookiePath = "/oidc/callback"
+	// oidcCookieMaxAge IdPでのログインを待つ時間(秒)
+	oidcCookieMaxAge = 10 * 60
+)
+
+// NewOIDCHandler OpenID Connectのログインハンドラーを生成する
+// postLoginURLはログイン後にブラウザを戻すURL
+func NewOIDCHandler(provider oidc.Provider, userUseCase usecase.User, postLoginURL string) *oidcHandler {
+	return &oidcHandler{
+		provider:     provider,
+		userUC:       userUseCase,
+		postLoginURL: postLoginURL,
+	}
+}
+
+// RegistHandlerFunc ハンドラー登録
+func (h *oidcHandler) RegistHandlerFunc() {
+	http.HandleFunc(
+		"/oidc/login",
+		middlewarehelper.Apply(
+			handlerctx.NewAPIContext,
+			h.login,
+		),
+	)
+
+	http.HandleFunc(
+		"/oidc/callback",
+		middlewarehelper.Apply(
+			handlerctx.NewAPIContext,
+			h.callback,
+		),
+	)
+}
+
+// login 認可リクエストを生成してIdPへリダイレクトする
+func (h *oidcHandler) login(c handlerctx.APIContext) error {
+	if c.RequestMethod() != http.MethodGet {
+		c.WriteStatusCode(http.StatusMethodNotAllowed)
+		return nil
+	}
+
+	req, err := oidc.NewAuthRequest()
+	if err != nil {
+		slog.ErrorContext(c.RequestContext(), "oidc auth request error", "error", err)
+		c.WriteStatusCode(http.StatusInternalServerError)
+		return nil
+	}
+	authURL, err := h.provider.AuthCodeURL(c.RequestContext(), req)
+	if err != nil {
+		slog.ErrorContext(c.RequestContext(), "oidc auth code url error", "error", err)
+		c.WriteStatusCode(http.StatusBadGateway)
+		return nil
+	}
+
+	c.SetCookie(newOIDCCookie(req.Encode(), oidcCookieMaxAge))
+	c.AddResponseHeader("Location", authURL)
+	c.WriteStatusCode(http.StatusFound)
+	return nil
+}
+
+// callback IdPから戻った認可コードをIDトークンと交換してログインする
+// ログインに成功した場合はセッションのクッキーを設定してpostLoginURLへリダイレクトする
+// 2要素認証を有効にしている場合はpostLoginURLのフラグメントでチャレンジのトークンを渡し、/login/mfaで確認コードと交換させる
+func (h *oidcHandler) callback(c handlerctx.APIContext) error {
+	if c.RequestMethod() != http.MethodGet {
+		c.WriteStatusCode(http.StatusMethodNotAllowed)
+		return nil
+	}
+
+	// 認可リクエストのクッキーは1回だけ使う
+	cookie, err := c.RequestCookie(oidcCookieName)
+	c.SetCookie(newOIDCCookie("", -1))
+	if err != nil {
+		c.WriteStatusCode(http.StatusBadRequest)
+		return nil
+	}
+	req, err := oidc.ParseAuthRequest(cookie.Value)
+	if err != nil {
+		c.WriteStatusCode(http.StatusBadRequest)
+		return nil
+	}
+
+	query := c.URL().Query()
+	if subtle.ConstantTimeCompare([]byte(query.Get("state")), []byte(req.State)) != 1 {
+		slog.WarnContext(c.RequestContext(), "oidc state mismatch")
+		c.WriteStatusCode(http.StatusBadRequest)
+		return nil
+	}
+	if idpErr := query.Get("error"); idpErr != "" || query.Get("code") == "" {
+		metrics.Logins.WithLabelValues(metrics.LoginFailure).Inc()
+		slog.WarnContext(c.RequestContext(), "oidc authorization error", "error", idpErr, "description", query.Get("error_description"))
+		c.WriteStatusCode(http.StatusUnauthorized)
+		return nil
+	}
+
+	identity, err := h.provider.Exchange(c.RequestContext(), query.Get("code"), req)
+	if err != nil {
+		if errors.Is(err, oidc.ErrDiscovery) {
+			slog.ErrorContext(c.RequestContext(), "oidc exchange error", "error", err)
+			c.WriteStatusCode(http.StatusBadGateway)
+			return nil
+		}
+		metrics.Logins.WithLabelValues(metrics.LoginFailure).Inc()
+		slog.WarnContext(c.RequestContext(), "oidc exchange error", "error", err)
+		c.WriteStatusCode(http.StatusUnauthorized)
+		return nil
+	}
+
+	token, challenge, err := h.userUC.AuthorizeExternal(c.RequestContext(), identity, c.RemoteIP(), c.RequestHeader().Get("User-Agent"))
+	if err != nil {
+		if errors.Is(err, service.ErrIdentityEmailRequired) || errors.Is(err, service.ErrIdentityEmailNotVerified) {
+			metrics.Logins.WithLabelValues(metrics.LoginFailure).Inc()
+			slog.WarnContext(c.RequestContext(), "oidc authorize error", "error", err)
+			c.WriteStatusCode(http.StatusForbidden)
+			return nil
+		}
+		if errors.Is(err, service.ErrIdentityEmailInUse) {
+			// パスワードで登録したユーザーとは自動で紐づけない
+			metrics.Logins.WithLabelValues(metrics.LoginFailure).Inc()
+			slog.WarnContext(c.RequestContext(), "oidc authorize error", "error", err)
+			c.WriteStatusCode(http.StatusConflict)
+			return nil
+		}
+		slog.ErrorContext(c.RequestContext(), "oidc authorize error", "error", err)
+		c.WriteStatusCode(http.StatusInternalServerError)
+		return nil
+	}
+
+	location := h.postLoginURL
+	if challenge != nil {
+		metrics.Logins.WithLabelValues(metrics.LoginMFARequired).Inc()
+		location += "#" + url.Values{"mfa_token": {challenge.MFAToken}}.Encode()
+	} else {
+		metrics.Logins.WithLabelValues(metrics.LoginSuccess).Inc()
+		for _, cookie := range middleware.NewSessionCookies(token, middleware.NewCSRFToken()) {
+			c.SetCookie(cookie)
+		}
+	}
+
+	c.AddResponseHeader("Location", location)
+	c.WriteStatusCode(http.StatusFound)
+	return nil
+}
+
+// newOIDCCookie 認可リクエストのクッキーを返す(maxAgeが負の場合は削除する)
+// IdPからのリダイレクト(トップレベルのGET)で送られるよう、SameSiteはLaxにする
+func newOIDCCookie(value string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     oidcCookieName,
+		Value:    value,
+		Path:     oidcCookiePath,
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
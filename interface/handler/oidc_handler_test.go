@@ -0,0 +1,263 @@
+package handler
+
+import (
+	"GoBBS/domain/service"
+	"GoBBS/dto"
+	"GoBBS/interface/middleware"
+	"GoBBS/interface/oidc"
+	"GoBBS/mock/mock_handler/mock_handlerctx"
+	"GoBBS/mock/mock_oidc"
+	"GoBBS/mock/mock_usecase"
+	"errors"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewOIDCHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := mock_oidc.NewMockProvider(ctrl)
+	mockUserUC := mock_usecase.NewMockUser(ctrl)
+
+	want := &oidcHandler{provider: provider, userUC: mockUserUC, postLoginURL: "https://bbs.example.com/"}
+	if got := NewOIDCHandler(provider, mockUserUC, "https://bbs.example.com/"); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewOIDCHandler() = %v, want %v", got, want)
+	}
+}
+
+func Test_oidcHandler_RegistHandlerFunc(t *testing.T) {
+	h := &oidcHandler{}
+	h.RegistHandlerFunc()
+}
+
+func Test_oidcHandler_login(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		method     string
+		err        error
+		wantStatus int
+	}{
+		{name: "正常ケース", method: http.MethodGet, wantStatus: http.StatusFound},
+		{name: "異常ケース(メソッド不正)", method: http.MethodPost, wantStatus: http.StatusMethodNotAllowed},
+		{name: "異常ケース(ディスカバリー失敗)", method: http.MethodGet, err: oidc.ErrDiscovery, wantStatus: http.StatusBadGateway},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := mock_oidc.NewMockProvider(ctrl)
+			mock := newMockAPIContext(ctrl)
+			calls := []*gomock.Call{mock.EXPECT().RequestMethod().Return(tt.method)}
+			switch {
+			case tt.method != http.MethodGet:
+				calls = append(calls, mock.EXPECT().WriteStatusCode(tt.wantStatus))
+			case tt.err != nil:
+				calls = append(calls,
+					provider.EXPECT().AuthCodeURL(gomock.Any(), gomock.Any()).Return("", tt.err),
+					mock.EXPECT().WriteStatusCode(tt.wantStatus),
+				)
+			default:
+				var req *oidc.AuthRequest
+				calls = append(calls,
+					provider.EXPECT().AuthCodeURL(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(_ any, r *oidc.AuthRequest) (string, error) {
+							req = r
+							return "https://idp.example.com/authorize?state=" + r.State, nil
+						}),
+					mock.EXPECT().SetCookie(gomock.Any()).Do(func(cookie *http.Cookie) {
+						if cookie.Name != oidcCookieName || cookie.Value != req.Encode() || cookie.Path != oidcCookiePath ||
+							cookie.MaxAge != oidcCookieMaxAge || !cookie.HttpOnly || !cookie.Secure || cookie.SameSite != http.SameSiteLaxMode {
+							t.Errorf("クッキー不一致 got: %#v", cookie)
+						}
+					}),
+					mock.EXPECT().AddResponseHeader("Location", gomock.Any()).Do(func(_ string, location string) {
+						if location != "https://idp.example.com/authorize?state="+req.State {
+							t.Errorf("リダイレクト先不一致 got: %#v", location)
+						}
+					}),
+					mock.EXPECT().WriteStatusCode(tt.wantStatus),
+				)
+			}
+			gomock.InOrder(calls...)
+
+			if err := (&oidcHandler{provider: provider}).login(mock); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+}
+
+func Test_oidcHandler_callback(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := &oidc.AuthRequest{State: "state", Nonce: "nonce", CodeVerifier: "verifier"}
+	stateCookie := &http.Cookie{Name: oidcCookieName, Value: req.Encode()}
+	identity := &oidc.Identity{Issuer: "https://idp.example.com", Subject: "sub-1", Email: "a@example.com", EmailVerified: true}
+	callbackURL := func(query string) *url.URL {
+		u, _ := url.Parse("https://bbs.example.com/oidc/callback?" + query)
+		return u
+	}
+
+	// start クッキーを削除し、stateを検証するまでの呼び出し
+	start := func(mock *mock_handlerctx.MockAPIContext, cookie *http.Cookie, query string) []*gomock.Call {
+		calls := []*gomock.Call{mock.EXPECT().RequestMethod().Return(http.MethodGet)}
+		if cookie == nil {
+			return append(calls,
+				mock.EXPECT().RequestCookie(oidcCookieName).Return(nil, http.ErrNoCookie),
+				mock.EXPECT().SetCookie(newOIDCCookie("", -1)),
+			)
+		}
+		return append(calls,
+			mock.EXPECT().RequestCookie(oidcCookieName).Return(cookie, nil),
+			mock.EXPECT().SetCookie(newOIDCCookie("", -1)),
+			mock.EXPECT().URL().Return(callbackURL(query)),
+		)
+	}
+	// authorize 認可コードを交換してログインするまでの呼び出し
+	authorize := func(mock *mock_handlerctx.MockAPIContext, provider *mock_oidc.MockProvider, uc *mock_usecase.MockUser,
+		token string, challenge *dto.MFAChallenge, err error) []*gomock.Call {
+		return []*gomock.Call{
+			provider.EXPECT().Exchange(gomock.Any(), "code", req).Return(identity, nil),
+			mock.EXPECT().RemoteIP().Return("192.0.2.1"),
+			mock.EXPECT().RequestHeader().Return(http.Header{"User-Agent": {"ua"}}),
+			uc.EXPECT().AuthorizeExternal(gomock.Any(), identity, "192.0.2.1", "ua").Return(token, challenge, err),
+		}
+	}
+
+	tests := []struct {
+		name  string
+		calls func(mock *mock_handlerctx.MockAPIContext, provider *mock_oidc.MockProvider, uc *mock_usecase.MockUser) []*gomock.Call
+	}{
+		{
+			name: "正常ケース",
+			calls: func(mock *mock_handlerctx.MockAPIContext, provider *mock_oidc.MockProvider, uc *mock_usecase.MockUser) []*gomock.Call {
+				calls := append(start(mock, stateCookie, "state=state&code=code"), authorize(mock, provider, uc, "token", nil, nil)...)
+				return append(calls,
+					mock.EXPECT().SetCookie(gomock.Any()).Do(func(cookie *http.Cookie) {
+						if cookie.Name != middleware.SessionCookieName || cookie.Value != "token" {
+							t.Errorf("クッキー不一致 got: %#v", cookie)
+						}
+					}),
+					mock.EXPECT().SetCookie(gomock.Any()).Do(func(cookie *http.Cookie) {
+						if cookie.Name != middleware.CSRFCookieName || cookie.Value == "" {
+							t.Errorf("クッキー不一致 got: %#v", cookie)
+						}
+					}),
+					mock.EXPECT().AddResponseHeader("Location", "https://bbs.example.com/"),
+					mock.EXPECT().WriteStatusCode(http.StatusFound),
+				)
+			},
+		},
+		{
+			name: "正常ケース(2要素認証)",
+			calls: func(mock *mock_handlerctx.MockAPIContext, provider *mock_oidc.MockProvider, uc *mock_usecase.MockUser) []*gomock.Call {
+				challenge := &dto.MFAChallenge{MFARequired: true, MFAToken: "mfa-token"}
+				calls := append(start(mock, stateCookie, "state=state&code=code"), authorize(mock, provider, uc, "", challenge, nil)...)
+				return append(calls,
+					mock.EXPECT().AddResponseHeader("Location", "https://bbs.example.com/#mfa_token=mfa-token"),
+					mock.EXPECT().WriteStatusCode(http.StatusFound),
+				)
+			},
+		},
+		{
+			name: "異常ケース(メソッド不正)",
+			calls: func(mock *mock_handlerctx.MockAPIContext, provider *mock_oidc.MockProvider, uc *mock_usecase.MockUser) []*gomock.Call {
+				return []*gomock.Call{
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().WriteStatusCode(http.StatusMethodNotAllowed),
+				}
+			},
+		},
+		{
+			name: "異常ケース(クッキーなし)",
+			calls: func(mock *mock_handlerctx.MockAPIContext, provider *mock_oidc.MockProvider, uc *mock_usecase.MockUser) []*gomock.Call {
+				return append(start(mock, nil, ""), mock.EXPECT().WriteStatusCode(http.StatusBadRequest))
+			},
+		},
+		{
+			name: "異常ケース(クッキー不正)",
+			calls: func(mock *mock_handlerctx.MockAPIContext, provider *mock_oidc.MockProvider, uc *mock_usecase.MockUser) []*gomock.Call {
+				return []*gomock.Call{
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().RequestCookie(oidcCookieName).Return(&http.Cookie{Name: oidcCookieName, Value: "broken"}, nil),
+					mock.EXPECT().SetCookie(newOIDCCookie("", -1)),
+					mock.EXPECT().WriteStatusCode(http.StatusBadRequest),
+				}
+			},
+		},
+		{
+			name: "異常ケース(state不一致)",
+			calls: func(mock *mock_handlerctx.MockAPIContext, provider *mock_oidc.MockProvider, uc *mock_usecase.MockUser) []*gomock.Call {
+				return append(start(mock, stateCookie, "state=other&code=code"), mock.EXPECT().WriteStatusCode(http.StatusBadRequest))
+			},
+		},
+		{
+			name: "異常ケース(IdPでの認可エラー)",
+			calls: func(mock *mock_handlerctx.MockAPIContext, provider *mock_oidc.MockProvider, uc *mock_usecase.MockUser) []*gomock.Call {
+				return append(start(mock, stateCookie, "state=state&error=access_denied"), mock.EXPECT().WriteStatusCode(http.StatusUnauthorized))
+			},
+		},
+		{
+			name: "異常ケース(IDトークン不正)",
+			calls: func(mock *mock_handlerctx.MockAPIContext, provider *mock_oidc.MockProvider, uc *mock_usecase.MockUser) []*gomock.Call {
+				return append(start(mock, stateCookie, "state=state&code=code"),
+					provider.EXPECT().Exchange(gomock.Any(), "code", req).Return(nil, oidc.ErrInvalidIDToken),
+					mock.EXPECT().WriteStatusCode(http.StatusUnauthorized),
+				)
+			},
+		},
+		{
+			name: "異常ケース(ディスカバリー失敗)",
+			calls: func(mock *mock_handlerctx.MockAPIContext, provider *mock_oidc.MockProvider, uc *mock_usecase.MockUser) []*gomock.Call {
+				return append(start(mock, stateCookie, "state=state&code=code"),
+					provider.EXPECT().Exchange(gomock.Any(), "code", req).Return(nil, oidc.ErrDiscovery),
+					mock.EXPECT().WriteStatusCode(http.StatusBadGateway),
+				)
+			},
+		},
+		{
+			name: "異常ケース(メールアドレス未確認)",
+			calls: func(mock *mock_handlerctx.MockAPIContext, provider *mock_oidc.MockProvider, uc *mock_usecase.MockUser) []*gomock.Call {
+				calls := append(start(mock, stateCookie, "state=state&code=code"),
+					authorize(mock, provider, uc, "", nil, service.ErrIdentityEmailNotVerified)...)
+				return append(calls, mock.EXPECT().WriteStatusCode(http.StatusForbidden))
+			},
+		},
+		{
+			name: "異常ケース(パスワードで登録したユーザーと同じメールアドレス)",
+			calls: func(mock *mock_handlerctx.MockAPIContext, provider *mock_oidc.MockProvider, uc *mock_usecase.MockUser) []*gomock.Call {
+				calls := append(start(mock, stateCookie, "state=state&code=code"),
+					authorize(mock, provider, uc, "", nil, service.ErrIdentityEmailInUse)...)
+				return append(calls, mock.EXPECT().WriteStatusCode(http.StatusConflict))
+			},
+		},
+		{
+			name: "異常ケース(ログイン失敗)",
+			calls: func(mock *mock_handlerctx.MockAPIContext, provider *mock_oidc.MockProvider, uc *mock_usecase.MockUser) []*gomock.Call {
+				calls := append(start(mock, stateCookie, "state=state&code=code"),
+					authorize(mock, provider, uc, "", nil, errors.New("test"))...)
+				return append(calls, mock.EXPECT().WriteStatusCode(http.StatusInternalServerError))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := mock_oidc.NewMockProvider(ctrl)
+			mockUC := mock_usecase.NewMockUser(ctrl)
+			mock := newMockAPIContext(ctrl)
+			gomock.InOrder(tt.calls(mock, provider, mockUC)...)
+
+			h := &oidcHandler{provider: provider, userUC: mockUC, postLoginURL: "https://bbs.example.com/"}
+			if err := h.callback(mock); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+}
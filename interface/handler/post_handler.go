@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/service"
+	"GoBBS/dto"
+	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/interface/middleware"
+	"GoBBS/interface/middleware/middlewarehelper"
+	"GoBBS/interface/textdiff"
+	"GoBBS/usecase"
+)
+
+type postHandler struct {
+	uc             usecase.Post
+	adminUserIDs   []string
+	authMiddleware middleware.Auth
+}
+
+// NewPostHandler 投稿ハンドラーを生成する(管理者はモデレーターとして投稿を編集・非表示にできる)
+func NewPostHandler(postUseCase usecase.Post, userUseCase usecase.User, adminUserIDs []string) *postHandler {
+	return &postHandler{
+		uc:             postUseCase,
+		adminUserIDs:   adminUserIDs,
+		authMiddleware: middleware.NewAuth(userUseCase),
+	}
+}
+
+// RegistHandlerFunc ハンドラー登録
+func (h *postHandler) RegistHandlerFunc() {
+	http.HandleFunc(
+		"/posts/",
+		middlewarehelper.Apply(
+			handlerctx.NewAPIContext,
+			h.route,
+		),
+	)
+}
+
+// route 投稿の編集(/posts/:id)、版の一覧(/posts/:id/revisions)、版の差分(/posts/:id/diff)、非表示(/posts/:id/hide)の振り分け
+// パスパラメータミドルウェアはパスの階層数が一致するパターンしか扱えないため、ここでパターンを選ぶ
+func (h *postHandler) route(c handlerctx.APIContext) error {
+	path := c.URL().Path
+	switch {
+	case strings.HasSuffix(path, "/revisions"):
+		return middleware.NewPathParam("/posts/:id/revisions").Parse(h.onlyGet(h.revisions))(c)
+	case strings.HasSuffix(path, "/diff"):
+		return middleware.NewPathParam("/posts/:id/diff").Parse(h.onlyGet(h.diff))(c)
+	case strings.HasSuffix(path, "/hide"):
+		return middleware.NewPathParam("/posts/:id/hide").Parse(h.hidePost)(c)
+	default:
+		return middleware.NewPathParam("/posts/:id").Parse(h.post)(c)
+	}
+}
+
+// post 投稿の編集(PUT、投稿権限)
+func (h *postHandler) post(c handlerctx.APIContext) error {
+	if c.RequestMethod() != http.MethodPut {
+		c.WriteStatusCode(http.StatusMethodNotAllowed)
+		return nil
+	}
+	return h.authMiddleware.VerifyScope(model.APIScopePost)(h.edit)(c)
+}
+
+// hidePost 投稿の非表示(POST、管理権限を持つ管理者のみ)
+func (h *postHandler) hidePost(c handlerctx.APIContext) error {
+	if c.RequestMethod() != http.MethodPost {
+		c.WriteStatusCode(http.StatusMethodNotAllowed)
+		return nil
+	}
+	return h.authMiddleware.VerifyScope(model.APIScopeModerate)(middleware.NewAdmin(h.adminUserIDs).VerifyAdmin(h.hide))(c)
+}
+
+// onlyGet GETのみ受け付ける(読み取り権限)
+func (h *postHandler) onlyGet(next middlewarehelper.HandlerFunc) middlewarehelper.HandlerFunc {
+	return func(c handlerctx.APIContext) error {
+		if c.RequestMethod() != http.MethodGet {
+			c.WriteStatusCode(http.StatusMethodNotAllowed)
+			return nil
+		}
+		return h.authMiddleware.VerifyScope(model.APIScopeRead)(next)(c)
+	}
+}
+
+// edit 投稿の編集(投稿者は編集期間内のみ、管理者はいつでも編集できる)
+func (h *postHandler) edit(c handlerctx.APIContext) error {
+	var req dto.PostRequest
+	if err := json.NewDecoder(c.RequestBody()).Decode(&req); err != nil {
+		c.WriteStatusCode(http.StatusBadRequest)
+		return nil
+	}
+
+	userID := c.UserID()
+	post, err := h.uc.Edit(c.RequestContext(), userID, c.PathParam(), slices.Contains(h.adminUserIDs, userID), &req, time.Now())
+	if err != nil {
+		if errors.Is(err, service.ErrPostNotFound) {
+			c.WriteStatusCode(http.StatusNotFound)
+			return nil
+		}
+		if errors.Is(err, service.ErrInvalidPostBody) {
+			c.WriteStatusCode(http.StatusBadRequest)
+			return nil
+		}
+		if errors.Is(err, service.ErrPostEditForbidden) || errors.Is(err, service.ErrPostEditWindowExpired) {
+			c.WriteStatusCode(http.StatusForbidden)
+			return nil
+		}
+		slog.ErrorContext(c.RequestContext(), "post edit error", "error", err)
+		c.WriteStatusCode(http.StatusInternalServerError)
+		return nil
+	}
+
+	return c.WriteResponseJSON(http.StatusOK, post)
+}
+
+// revisions 投稿の版の一覧
+func (h *postHandler) revisions(c handlerctx.APIContext) error {
+	revisions, err := h.uc.Revisions(c.RequestContext(), c.PathParam())
+	if err != nil {
+		if errors.Is(err, service.ErrPostNotFound) || errors.Is(err, service.ErrPostHidden) {
+			c.WriteStatusCode(http.StatusNotFound)
+			return nil
+		}
+		slog.ErrorContext(c.RequestContext(), "post revisions error", "error", err)
+		c.WriteStatusCode(http.StatusInternalServerError)
+		return nil
+	}
+
+	return c.WriteResponseJSON(http.StatusOK, revisions)
+}
+
+// diff 投稿の2つの版(クエリのfromとto)の差分
+func (h *postHandler) diff(c handlerctx.APIContext) error {
+	query := c.URL().Query()
+	from, to := query.Get("from"), query.Get("to")
+	if from == "" || to == "" {
+		c.WriteStatusCode(http.StatusBadRequest)
+		return nil
+	}
+
+	diff, err := h.uc.Diff(c.RequestContext(), c.PathParam(), from, to)
+	if err != nil {
+		if errors.Is(err, service.ErrPostNotFound) || errors.Is(err, service.ErrPostHidden) || errors.Is(err, service.ErrPostRevisionNotFound) {
+			c.WriteStatusCode(http.StatusNotFound)
+			return nil
+		}
+		if errors.Is(err, textdiff.ErrTooManyLines) {
+			c.WriteStatusCode(http.StatusUnprocessableEntity)
+			return nil
+		}
+		slog.ErrorContext(c.RequestContext(), "post diff error", "error", err)
+		c.WriteStatusCode(http.StatusInternalServerError)
+		return nil
+	}
+
+	return c.WriteResponseJSON(http.StatusOK, diff)
+}
+
+// hide 投稿の非表示(本文は版とともに残す)
+func (h *postHandler) hide(c handlerctx.APIContext) error {
+	var req dto.PostHideRequest
+	if err := json.NewDecoder(c.RequestBody()).Decode(&req); err != nil {
+		c.WriteStatusCode(http.StatusBadRequest)
+		return nil
+	}
+
+	post, err := h.uc.Hide(c.RequestContext(), c.PathParam(), &req, time.Now())
+	if err != nil {
+		if errors.Is(err, service.ErrPostNotFound) {
+			c.WriteStatusCode(http.StatusNotFound)
+			return nil
+		}
+		if errors.Is(err, service.ErrInvalidHideReason) {
+			c.WriteStatusCode(http.StatusBadRequest)
+			return nil
+		}
+		if errors.Is(err, service.ErrPostAlreadyHidden) {
+			c.WriteStatusCode(http.StatusConflict)
+			return nil
+		}
+		slog.ErrorContext(c.RequestContext(), "post hide error", "error", err)
+		c.WriteStatusCode(http.StatusInternalServerError)
+		return nil
+	}
+
+	return c.WriteResponseJSON(http.StatusOK, post)
+}
@@ -0,0 +1,473 @@
+package handler
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/domain/service"
+	"GoBBS/dto"
+	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/interface/middleware"
+	"GoBBS/interface/textdiff"
+	"GoBBS/mock/mock_usecase"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewPostHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUC := mock_usecase.NewMockPost(ctrl)
+	mockUserUC := mock_usecase.NewMockUser(ctrl)
+	adminUserIDs := []string{"1"}
+
+	want := &postHandler{uc: mockUC, adminUserIDs: adminUserIDs, authMiddleware: middleware.NewAuth(mockUserUC)}
+	if got := NewPostHandler(mockUC, mockUserUC, adminUserIDs); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewPostHandler() = %v, want %v", got, want)
+	}
+}
+
+func Test_postHandler_RegistHandlerFunc(t *testing.T) {
+	h := &postHandler{}
+	h.RegistHandlerFunc()
+}
+
+func Test_postHandler_route(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name string
+		c    func() handlerctx.APIContext
+	}{
+		{
+			name: "正常ケース(編集)",
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				mock.EXPECT().URL().Return(&url.URL{Path: "/posts/1"}).AnyTimes()
+				gomock.InOrder(
+					mock.EXPECT().SetPathParam("1"),
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().WriteStatusCode(http.StatusMethodNotAllowed),
+				)
+				return mock
+			},
+		},
+		{
+			name: "正常ケース(版の一覧)",
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				mock.EXPECT().URL().Return(&url.URL{Path: "/posts/1/revisions"}).AnyTimes()
+				gomock.InOrder(
+					mock.EXPECT().SetPathParam("1"),
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().WriteStatusCode(http.StatusMethodNotAllowed),
+				)
+				return mock
+			},
+		},
+		{
+			name: "正常ケース(差分)",
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				mock.EXPECT().URL().Return(&url.URL{Path: "/posts/1/diff"}).AnyTimes()
+				gomock.InOrder(
+					mock.EXPECT().SetPathParam("1"),
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().WriteStatusCode(http.StatusMethodNotAllowed),
+				)
+				return mock
+			},
+		},
+		{
+			name: "正常ケース(非表示)",
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				mock.EXPECT().URL().Return(&url.URL{Path: "/posts/1/hide"}).AnyTimes()
+				gomock.InOrder(
+					mock.EXPECT().SetPathParam("1"),
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().WriteStatusCode(http.StatusMethodNotAllowed),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(パス不正)",
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				mock.EXPECT().URL().Return(&url.URL{Path: "/posts/1/2/diff"}).AnyTimes()
+				mock.EXPECT().WriteStatusCode(http.StatusBadRequest)
+				return mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := (&postHandler{}).route(tt.c()); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+}
+
+func Test_postHandler_edit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	body := `{"body":"編集"}`
+	req := &dto.PostRequest{Body: "編集"}
+	edited := &dto.Post{ID: "31", Body: "編集", Edited: true}
+
+	tests := []struct {
+		name   string
+		userID string
+		uc     func() *mock_usecase.MockPost
+		status int
+	}{
+		{
+			name:   "正常ケース(投稿者)",
+			userID: "2",
+			uc: func() *mock_usecase.MockPost {
+				mock := mock_usecase.NewMockPost(ctrl)
+				mock.EXPECT().Edit(gomock.Any(), "2", "31", false, req, gomock.Any()).Return(edited, nil)
+				return mock
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:   "正常ケース(管理者)",
+			userID: "1",
+			uc: func() *mock_usecase.MockPost {
+				mock := mock_usecase.NewMockPost(ctrl)
+				mock.EXPECT().Edit(gomock.Any(), "1", "31", true, req, gomock.Any()).Return(edited, nil)
+				return mock
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:   "異常ケース(投稿なし)",
+			userID: "2",
+			uc: func() *mock_usecase.MockPost {
+				mock := mock_usecase.NewMockPost(ctrl)
+				mock.EXPECT().Edit(gomock.Any(), "2", "31", false, req, gomock.Any()).Return(nil, service.ErrPostNotFound)
+				return mock
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "異常ケース(本文不正)",
+			userID: "2",
+			uc: func() *mock_usecase.MockPost {
+				mock := mock_usecase.NewMockPost(ctrl)
+				mock.EXPECT().Edit(gomock.Any(), "2", "31", false, req, gomock.Any()).Return(nil, service.ErrInvalidPostBody)
+				return mock
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "異常ケース(他人の投稿)",
+			userID: "3",
+			uc: func() *mock_usecase.MockPost {
+				mock := mock_usecase.NewMockPost(ctrl)
+				mock.EXPECT().Edit(gomock.Any(), "3", "31", false, req, gomock.Any()).Return(nil, service.ErrPostEditForbidden)
+				return mock
+			},
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "異常ケース(編集期間外)",
+			userID: "2",
+			uc: func() *mock_usecase.MockPost {
+				mock := mock_usecase.NewMockPost(ctrl)
+				mock.EXPECT().Edit(gomock.Any(), "2", "31", false, req, gomock.Any()).Return(nil, service.ErrPostEditWindowExpired)
+				return mock
+			},
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "異常ケース(編集失敗)",
+			userID: "2",
+			uc: func() *mock_usecase.MockPost {
+				mock := mock_usecase.NewMockPost(ctrl)
+				mock.EXPECT().Edit(gomock.Any(), "2", "31", false, req, gomock.Any()).Return(nil, errors.New("test"))
+				return mock
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &postHandler{uc: tt.uc(), adminUserIDs: []string{"1"}, authMiddleware: passScope(ctrl, model.APIScopePost)}
+			c := newMockAPIContext(ctrl)
+			calls := []*gomock.Call{
+				c.EXPECT().RequestMethod().Return(http.MethodPut),
+				c.EXPECT().RequestBody().Return(io.NopCloser(strings.NewReader(body))),
+				c.EXPECT().UserID().Return(tt.userID),
+				c.EXPECT().PathParam().Return("31"),
+			}
+			if tt.status == http.StatusOK {
+				calls = append(calls, c.EXPECT().WriteResponseJSON(http.StatusOK, edited))
+			} else {
+				calls = append(calls, c.EXPECT().WriteStatusCode(tt.status))
+			}
+			gomock.InOrder(calls...)
+
+			if err := h.post(c); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+
+	t.Run("異常ケース(JSON不正)", func(t *testing.T) {
+		h := &postHandler{authMiddleware: passScope(ctrl, model.APIScopePost)}
+		c := newMockAPIContext(ctrl)
+		gomock.InOrder(
+			c.EXPECT().RequestMethod().Return(http.MethodPut),
+			c.EXPECT().RequestBody().Return(io.NopCloser(strings.NewReader(`{`))),
+			c.EXPECT().WriteStatusCode(http.StatusBadRequest),
+		)
+
+		if err := h.post(c); err != nil {
+			t.Errorf("予期せぬエラー(error: %s)", err)
+		}
+	})
+}
+
+func Test_postHandler_revisions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	revisions := []*dto.PostRevision{{ID: "20", PostID: "31", Body: "本文"}}
+
+	tests := []struct {
+		name   string
+		uc     func() *mock_usecase.MockPost
+		status int
+	}{
+		{
+			name: "正常ケース",
+			uc: func() *mock_usecase.MockPost {
+				mock := mock_usecase.NewMockPost(ctrl)
+				mock.EXPECT().Revisions(gomock.Any(), "31").Return(revisions, nil)
+				return mock
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "異常ケース(投稿なし)",
+			uc: func() *mock_usecase.MockPost {
+				mock := mock_usecase.NewMockPost(ctrl)
+				mock.EXPECT().Revisions(gomock.Any(), "31").Return(nil, service.ErrPostNotFound)
+				return mock
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name: "異常ケース(非表示にされた投稿)",
+			uc: func() *mock_usecase.MockPost {
+				mock := mock_usecase.NewMockPost(ctrl)
+				mock.EXPECT().Revisions(gomock.Any(), "31").Return(nil, service.ErrPostHidden)
+				return mock
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name: "異常ケース(取得失敗)",
+			uc: func() *mock_usecase.MockPost {
+				mock := mock_usecase.NewMockPost(ctrl)
+				mock.EXPECT().Revisions(gomock.Any(), "31").Return(nil, errors.New("test"))
+				return mock
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &postHandler{uc: tt.uc(), authMiddleware: passScope(ctrl, model.APIScopeRead)}
+			c := newMockAPIContext(ctrl)
+			calls := []*gomock.Call{
+				c.EXPECT().RequestMethod().Return(http.MethodGet),
+				c.EXPECT().PathParam().Return("31"),
+			}
+			if tt.status == http.StatusOK {
+				calls = append(calls, c.EXPECT().WriteResponseJSON(http.StatusOK, revisions))
+			} else {
+				calls = append(calls, c.EXPECT().WriteStatusCode(tt.status))
+			}
+			gomock.InOrder(calls...)
+
+			if err := h.onlyGet(h.revisions)(c); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+}
+
+func Test_postHandler_diff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	diff := &dto.PostDiff{From: "20", To: "21", Lines: []*dto.PostDiffLine{{Op: "insert", Text: "追記"}}}
+
+	tests := []struct {
+		name   string
+		query  string
+		uc     func() *mock_usecase.MockPost
+		status int
+	}{
+		{
+			name:  "正常ケース",
+			query: "from=20&to=21",
+			uc: func() *mock_usecase.MockPost {
+				mock := mock_usecase.NewMockPost(ctrl)
+				mock.EXPECT().Diff(gomock.Any(), "31", "20", "21").Return(diff, nil)
+				return mock
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:   "異常ケース(版の指定なし)",
+			query:  "from=20",
+			uc:     func() *mock_usecase.MockPost { return nil },
+			status: http.StatusBadRequest,
+		},
+		{
+			name:  "異常ケース(版なし)",
+			query: "from=20&to=99",
+			uc: func() *mock_usecase.MockPost {
+				mock := mock_usecase.NewMockPost(ctrl)
+				mock.EXPECT().Diff(gomock.Any(), "31", "20", "99").Return(nil, service.ErrPostRevisionNotFound)
+				return mock
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:  "異常ケース(行数超過)",
+			query: "from=20&to=21",
+			uc: func() *mock_usecase.MockPost {
+				mock := mock_usecase.NewMockPost(ctrl)
+				mock.EXPECT().Diff(gomock.Any(), "31", "20", "21").Return(nil, textdiff.ErrTooManyLines)
+				return mock
+			},
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			name:  "異常ケース(取得失敗)",
+			query: "from=20&to=21",
+			uc: func() *mock_usecase.MockPost {
+				mock := mock_usecase.NewMockPost(ctrl)
+				mock.EXPECT().Diff(gomock.Any(), "31", "20", "21").Return(nil, errors.New("test"))
+				return mock
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &postHandler{uc: tt.uc(), authMiddleware: passScope(ctrl, model.APIScopeRead)}
+			c := newMockAPIContext(ctrl)
+			calls := []*gomock.Call{
+				c.EXPECT().RequestMethod().Return(http.MethodGet),
+				c.EXPECT().URL().Return(&url.URL{RawQuery: tt.query}),
+			}
+			switch tt.status {
+			case http.StatusOK:
+				calls = append(calls, c.EXPECT().PathParam().Return("31"), c.EXPECT().WriteResponseJSON(http.StatusOK, diff))
+			case http.StatusBadRequest:
+				calls = append(calls, c.EXPECT().WriteStatusCode(tt.status))
+			default:
+				calls = append(calls, c.EXPECT().PathParam().Return("31"), c.EXPECT().WriteStatusCode(tt.status))
+			}
+			gomock.InOrder(calls...)
+
+			if err := h.onlyGet(h.diff)(c); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+}
+
+func Test_postHandler_hide(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	body := `{"reason":"広告"}`
+	req := &dto.PostHideRequest{Reason: "広告"}
+	hidden := &dto.Post{ID: "31", Hidden: true, HiddenReason: "広告"}
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "正常ケース", err: nil, status: http.StatusOK},
+		{name: "異常ケース(投稿なし)", err: service.ErrPostNotFound, status: http.StatusNotFound},
+		{name: "異常ケース(理由不正)", err: service.ErrInvalidHideReason, status: http.StatusBadRequest},
+		{name: "異常ケース(非表示済み)", err: service.ErrPostAlreadyHidden, status: http.StatusConflict},
+		{name: "異常ケース(非表示失敗)", err: errors.New("test"), status: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := mock_usecase.NewMockPost(ctrl)
+			if tt.err != nil {
+				uc.EXPECT().Hide(gomock.Any(), "31", req, gomock.Any()).Return(nil, tt.err)
+			} else {
+				uc.EXPECT().Hide(gomock.Any(), "31", req, gomock.Any()).Return(hidden, nil)
+			}
+			h := &postHandler{uc: uc, adminUserIDs: []string{"1"}, authMiddleware: passScope(ctrl, model.APIScopeModerate)}
+			c := newMockAPIContext(ctrl)
+			calls := []*gomock.Call{
+				c.EXPECT().RequestMethod().Return(http.MethodPost),
+				c.EXPECT().UserID().Return("1"),
+				c.EXPECT().RequestBody().Return(io.NopCloser(strings.NewReader(body))),
+				c.EXPECT().PathParam().Return("31"),
+			}
+			if tt.err == nil {
+				calls = append(calls, c.EXPECT().WriteResponseJSON(http.StatusOK, hidden))
+			} else {
+				calls = append(calls, c.EXPECT().WriteStatusCode(tt.status))
+			}
+			gomock.InOrder(calls...)
+
+			if err := h.hidePost(c); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+
+	t.Run("異常ケース(管理者以外)", func(t *testing.T) {
+		h := &postHandler{adminUserIDs: []string{"1"}, authMiddleware: passScope(ctrl, model.APIScopeModerate)}
+		c := newMockAPIContext(ctrl)
+		gomock.InOrder(
+			c.EXPECT().RequestMethod().Return(http.MethodPost),
+			c.EXPECT().UserID().Return("2"),
+			c.EXPECT().WriteStatusCode(http.StatusForbidden),
+		)
+
+		if err := h.hidePost(c); err != nil {
+			t.Errorf("予期せぬエラー(error: %s)", err)
+		}
+	})
+
+	t.Run("異常ケース(JSON不正)", func(t *testing.T) {
+		h := &postHandler{adminUserIDs: []string{"1"}, authMiddleware: passScope(ctrl, model.APIScopeModerate)}
+		c := newMockAPIContext(ctrl)
+		gomock.InOrder(
+			c.EXPECT().RequestMethod().Return(http.MethodPost),
+			c.EXPECT().UserID().Return("1"),
+			c.EXPECT().RequestBody().Return(io.NopCloser(strings.NewReader(`{`))),
+			c.EXPECT().WriteStatusCode(http.StatusBadRequest),
+		)
+
+		if err := h.hidePost(c); err != nil {
+			t.Errorf("予期せぬエラー(error: %s)", err)
+		}
+	})
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/service"
+	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/interface/middleware"
+	"GoBBS/interface/middleware/middlewarehelper"
+	"GoBBS/usecase"
+)
+
+type sessionHandler struct {
+	uc     usecase.Session
+	userUC usecase.User
+}
+
+// NewSessionHandler ログインセッションハンドラーを生成する
+func NewSessionHandler(sessionUseCase usecase.Session, userUseCase usecase.User) *sessionHandler {
+	return &sessionHandler{
+		uc:     sessionUseCase,
+		userUC: userUseCase,
+	}
+}
+
+// RegistHandlerFunc ハンドラー登録
+func (h *sessionHandler) RegistHandlerFunc() {
+	http.HandleFunc(
+		"/me/sessions",
+		middlewarehelper.Apply(
+			handlerctx.NewAPIContext,
+			h.list,
+			middleware.NewAuth(h.userUC).VerifyAuth,
+		),
+	)
+
+	http.HandleFunc(
+		"/me/sessions/",
+		middlewarehelper.Apply(
+			handlerctx.NewAPIContext,
+			h.revoke,
+			middleware.NewAuth(h.userUC).VerifyAuth,
+			middleware.NewPathParam("/me/sessions/:id").Parse,
+		),
+	)
+}
+
+// list 有効なログインセッションの一覧を取得する
+func (h *sessionHandler) list(c handlerctx.APIContext) error {
+	if c.RequestMethod() != http.MethodGet {
+		c.WriteStatusCode(http.StatusMethodNotAllowed)
+		return nil
+	}
+
+	list, err := h.uc.List(c.RequestContext(), c.UserID(), c.SessionID(), time.Now())
+	if err != nil {
+		slog.ErrorContext(c.RequestContext(), "session list error", "error", err)
+		c.WriteStatusCode(http.StatusInternalServerError)
+		return nil
+	}
+
+	return c.WriteResponseJSON(http.StatusOK, list)
+}
+
+// revoke ログインセッションを失効させる
+// リクエストの認証に使ったセッションを失効させた場合はセッションのクッキーも削除する
+func (h *sessionHandler) revoke(c handlerctx.APIContext) error {
+	if c.RequestMethod() != http.MethodDelete {
+		c.WriteStatusCode(http.StatusMethodNotAllowed)
+		return nil
+	}
+
+	id := c.PathParam()
+	if err := h.uc.Revoke(c.RequestContext(), c.UserID(), id, time.Now()); err != nil {
+		if errors.Is(err, service.ErrSessionNotFound) {
+			c.WriteStatusCode(http.StatusNotFound)
+			return nil
+		}
+		slog.ErrorContext(c.RequestContext(), "session revoke error", "error", err)
+		c.WriteStatusCode(http.StatusInternalServerError)
+		return nil
+	}
+
+	if id == c.SessionID() {
+		for _, cookie := range middleware.ExpiredSessionCookies() {
+			c.SetCookie(cookie)
+		}
+	}
+	c.WriteStatusCode(http.StatusNoContent)
+	return nil
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"GoBBS/domain/service"
+	"GoBBS/dto"
+	"GoBBS/interface/middleware"
+	"GoBBS/mock/mock_usecase"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewSessionHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUC := mock_usecase.NewMockSession(ctrl)
+	mockUserUC := mock_usecase.NewMockUser(ctrl)
+
+	want := &sessionHandler{uc: mockUC, userUC: mockUserUC}
+	if got := NewSessionHandler(mockUC, mockUserUC); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewSessionHandler() = %v, want %v", got, want)
+	}
+}
+
+func Test_sessionHandler_RegistHandlerFunc(t *testing.T) {
+	h := &sessionHandler{}
+	h.RegistHandlerFunc()
+}
+
+func Test_sessionHandler_list(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	list := &dto.SessionList{Sessions: []*dto.Session{{ID: "s1", Device: "Firefox on Linux", Current: true}}}
+
+	tests := []struct {
+		name       string
+		method     string
+		list       *dto.SessionList
+		err        error
+		wantStatus int
+	}{
+		{name: "正常ケース", method: http.MethodGet, list: list, wantStatus: http.StatusOK},
+		{name: "異常ケース(メソッド不正)", method: http.MethodPost, wantStatus: http.StatusMethodNotAllowed},
+		{name: "異常ケース(取得失敗)", method: http.MethodGet, err: errors.New("test"), wantStatus: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUC := mock_usecase.NewMockSession(ctrl)
+			mock := newMockAPIContext(ctrl)
+			switch {
+			case tt.method != http.MethodGet:
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(tt.method),
+					mock.EXPECT().WriteStatusCode(tt.wantStatus),
+				)
+			case tt.err != nil:
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(tt.method),
+					mock.EXPECT().UserID().Return("1"),
+					mock.EXPECT().SessionID().Return("s1"),
+					mockUC.EXPECT().List(gomock.Any(), "1", "s1", gomock.Any()).Return(nil, tt.err),
+					mock.EXPECT().WriteStatusCode(tt.wantStatus),
+				)
+			default:
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(tt.method),
+					mock.EXPECT().UserID().Return("1"),
+					mock.EXPECT().SessionID().Return("s1"),
+					mockUC.EXPECT().List(gomock.Any(), "1", "s1", gomock.Any()).Return(tt.list, nil),
+					mock.EXPECT().WriteResponseJSON(tt.wantStatus, tt.list),
+				)
+			}
+
+			if err := (&sessionHandler{uc: mockUC}).list(mock); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+}
+
+func Test_sessionHandler_revoke(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name        string
+		method      string
+		current     string
+		err         error
+		wantCookies bool
+		wantStatus  int
+	}{
+		{name: "正常ケース", method: http.MethodDelete, current: "s2", wantStatus: http.StatusNoContent},
+		{name: "正常ケース(認証に使ったセッション)", method: http.MethodDelete, current: "s1", wantCookies: true, wantStatus: http.StatusNoContent},
+		{name: "異常ケース(メソッド不正)", method: http.MethodPost, wantStatus: http.StatusMethodNotAllowed},
+		{name: "異常ケース(セッションが未登録)", method: http.MethodDelete, err: service.ErrSessionNotFound, wantStatus: http.StatusNotFound},
+		{name: "異常ケース(失効失敗)", method: http.MethodDelete, err: errors.New("test"), wantStatus: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUC := mock_usecase.NewMockSession(ctrl)
+			mock := newMockAPIContext(ctrl)
+			calls := []*gomock.Call{mock.EXPECT().RequestMethod().Return(tt.method)}
+			if tt.method == http.MethodDelete {
+				calls = append(calls,
+					mock.EXPECT().PathParam().Return("s1"),
+					mock.EXPECT().UserID().Return("1"),
+					mockUC.EXPECT().Revoke(gomock.Any(), "1", "s1", gomock.Any()).Return(tt.err),
+				)
+				if tt.err == nil {
+					calls = append(calls, mock.EXPECT().SessionID().Return(tt.current))
+				}
+			}
+			if tt.wantCookies {
+				for _, cookie := range middleware.ExpiredSessionCookies() {
+					calls = append(calls, mock.EXPECT().SetCookie(cookie))
+				}
+			}
+			calls = append(calls, mock.EXPECT().WriteStatusCode(tt.wantStatus))
+			gomock.InOrder(calls...)
+
+			if err := (&sessionHandler{uc: mockUC}).revoke(mock); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+}
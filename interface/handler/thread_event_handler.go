@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"GoBBS/domain/model"
+	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/interface/metrics"
+	"GoBBS/interface/middleware"
+	"GoBBS/interface/middleware/middlewarehelper"
+	"GoBBS/interface/pubsub"
+	"GoBBS/usecase"
+)
+
+type threadEventHandler struct {
+	hub       pubsub.Hub
+	userUC    usecase.User
+	keepAlive time.Duration
+}
+
+// defaultKeepAlive 接続維持のコメントを送信する間隔
+const defaultKeepAlive = 30 * time.Second
+
+// NewThreadEventHandler スレッドのイベント配信ハンドラーを生成する
+func NewThreadEventHandler(hub pubsub.Hub, userUseCase usecase.User) *threadEventHandler {
+	return &threadEventHandler{
+		hub:       hub,
+		userUC:    userUseCase,
+		keepAlive: defaultKeepAlive,
+	}
+}
+
+// Handler /threads/:id/eventsを処理するハンドラーを返す
+// /threads/はスレッドハンドラーが登録し、イベント配信のパスをこのハンドラーに振り分ける
+func (h *threadEventHandler) Handler() middlewarehelper.HandlerFunc {
+	return middleware.NewAuth(h.userUC).VerifyScope(model.APIScopeRead)(
+		middleware.NewPathParam("/threads/:id/events").Parse(h.events),
+	)
+}
+
+// events スレッドの新規投稿、編集、非表示をServer-Sent Eventsで配信する
+func (h *threadEventHandler) events(c handlerctx.APIContext) error {
+	if c.RequestMethod() != http.MethodGet {
+		c.WriteStatusCode(http.StatusMethodNotAllowed)
+		return nil
+	}
+
+	// 不正なLast-Event-IDは指定なしとして扱う
+	lastEventID, _ := strconv.ParseUint(c.RequestHeader().Get("Last-Event-ID"), 10, 64)
+
+	sub := h.hub.Subscribe(pubsub.ThreadTopic(c.PathParam()), lastEventID)
+	defer sub.Close()
+
+	if err := c.StartEventStream(); err != nil {
+		return err
+	}
+	metrics.ActiveConnections.WithLabelValues(metrics.ConnectionSSE).Inc()
+	defer metrics.ActiveConnections.WithLabelValues(metrics.ConnectionSSE).Dec()
+
+	// 以降の書き込みエラーはクライアントの切断のため、エラーとせず終了する
+	for _, event := range sub.Replay() {
+		if err := writeEvent(c, event); err != nil {
+			return nil
+		}
+	}
+
+	ticker := time.NewTicker(h.keepAlive)
+	defer ticker.Stop()
+
+	done := c.RequestContext().Done()
+	for {
+		select {
+		case <-done:
+			return nil
+		case event, ok := <-sub.Events():
+			if !ok {
+				// 未読が溜まりハブから切断された場合、クライアントの再接続で再送する
+				return nil
+			}
+			if err := writeEvent(c, event); err != nil {
+				return nil
+			}
+		case <-ticker.C:
+			if err := c.WriteEventComment("keep-alive"); err != nil {
+				return nil
+			}
+		}
+	}
+}
+
+// writeEvent ハブのイベントを書き込む
+func writeEvent(c handlerctx.APIContext, event pubsub.Event) error {
+	return c.WriteEvent(strconv.FormatUint(event.ID, 10), event.Type, string(event.Data))
+}
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/interface/pubsub"
+	"GoBBS/mock/mock_handler/mock_handlerctx"
+	"GoBBS/mock/mock_usecase"
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewThreadEventHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hub := pubsub.NewMemoryHub(10, 10, 10)
+	mockUserUC := mock_usecase.NewMockUser(ctrl)
+
+	want := &threadEventHandler{hub: hub, userUC: mockUserUC, keepAlive: defaultKeepAlive}
+	if got := NewThreadEventHandler(hub, mockUserUC); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewThreadEventHandler() = %v, want %v", got, want)
+	}
+}
+
+func Test_threadEventHandler_Handler(t *testing.T) {
+	h := &threadEventHandler{}
+	if h.Handler() == nil {
+		t.Error("ハンドラーが生成されていない")
+	}
+}
+
+func Test_threadEventHandler_events(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name      string
+		keepAlive time.Duration
+		hub       func() pubsub.Hub
+		c         func(hub pubsub.Hub, ctx context.Context, cancel context.CancelFunc) handlerctx.APIContext
+		wantErr   bool
+	}{
+		{
+			name:      "正常ケース(再送と配信)",
+			keepAlive: time.Hour,
+			hub: func() pubsub.Hub {
+				hub := pubsub.NewMemoryHub(10, 10, 10)
+				hub.Publish(pubsub.ThreadTopic("1"), "post.created", []byte("1"))
+				hub.Publish(pubsub.ThreadTopic("1"), "post.created", []byte("2"))
+				return hub
+			},
+			c: func(hub pubsub.Hub, ctx context.Context, cancel context.CancelFunc) handlerctx.APIContext {
+				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().RequestHeader().Return(http.Header{"Last-Event-Id": {"1"}}),
+					mock.EXPECT().PathParam().Return("1"),
+					mock.EXPECT().StartEventStream().Return(nil),
+					mock.EXPECT().WriteEvent("2", "post.created", "2").DoAndReturn(func(string, string, string) error {
+						hub.Publish(pubsub.ThreadTopic("1"), "post.hidden", []byte("3"))
+						return nil
+					}),
+					mock.EXPECT().RequestContext().Return(ctx),
+					mock.EXPECT().WriteEvent("3", "post.hidden", "3").DoAndReturn(func(string, string, string) error {
+						cancel()
+						return nil
+					}),
+				)
+				return mock
+			},
+			wantErr: false,
+		},
+		{
+			name:      "正常ケース(接続維持)",
+			keepAlive: time.Millisecond,
+			hub: func() pubsub.Hub {
+				return pubsub.NewMemoryHub(10, 10, 10)
+			},
+			c: func(hub pubsub.Hub, ctx context.Context, cancel context.CancelFunc) handlerctx.APIContext {
+				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().RequestHeader().Return(http.Header{}),
+					mock.EXPECT().PathParam().Return("1"),
+					mock.EXPECT().StartEventStream().Return(nil),
+					mock.EXPECT().RequestContext().Return(ctx),
+					mock.EXPECT().WriteEventComment("keep-alive").DoAndReturn(func(string) error {
+						cancel()
+						return nil
+					}),
+				)
+				return mock
+			},
+			wantErr: false,
+		},
+		{
+			name:      "正常ケース(未読超過による切断)",
+			keepAlive: time.Hour,
+			hub: func() pubsub.Hub {
+				return pubsub.NewMemoryHub(10, 0, 10)
+			},
+			c: func(hub pubsub.Hub, ctx context.Context, cancel context.CancelFunc) handlerctx.APIContext {
+				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().RequestHeader().Return(http.Header{}),
+					mock.EXPECT().PathParam().Return("1"),
+					mock.EXPECT().StartEventStream().DoAndReturn(func() error {
+						hub.Publish(pubsub.ThreadTopic("1"), "post.created", nil)
+						return nil
+					}),
+					mock.EXPECT().RequestContext().Return(ctx),
+				)
+				return mock
+			},
+			wantErr: false,
+		},
+		{
+			name:      "異常ケース(メソッド不正)",
+			keepAlive: time.Hour,
+			hub: func() pubsub.Hub {
+				return pubsub.NewMemoryHub(10, 10, 10)
+			},
+			c: func(hub pubsub.Hub, ctx context.Context, cancel context.CancelFunc) handlerctx.APIContext {
+				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().WriteStatusCode(http.StatusMethodNotAllowed),
+				)
+				return mock
+			},
+			wantErr: false,
+		},
+		{
+			name:      "異常ケース(ストリーミング非対応)",
+			keepAlive: time.Hour,
+			hub: func() pubsub.Hub {
+				return pubsub.NewMemoryHub(10, 10, 10)
+			},
+			c: func(hub pubsub.Hub, ctx context.Context, cancel context.CancelFunc) handlerctx.APIContext {
+				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().RequestHeader().Return(http.Header{"Last-Event-Id": {"ng"}}),
+					mock.EXPECT().PathParam().Return("1"),
+					mock.EXPECT().StartEventStream().Return(handlerctx.ErrStreamingUnsupported),
+				)
+				return mock
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			hub := tt.hub()
+			h := &threadEventHandler{hub: hub, keepAlive: tt.keepAlive}
+			if err := h.events(tt.c(hub, ctx, cancel)); (err != nil) != tt.wantErr {
+				t.Errorf("threadEventHandler.events() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
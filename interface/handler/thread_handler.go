@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/service"
+	"GoBBS/dto"
+	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/interface/middleware"
+	"GoBBS/interface/middleware/middlewarehelper"
+	"GoBBS/usecase"
+)
+
+type threadHandler struct {
+	uc             usecase.Post
+	authMiddleware middleware.Auth
+	events         middlewarehelper.HandlerFunc
+}
+
+// NewThreadHandler スレッドハンドラーを生成する
+// eventsは/threads/:id/eventsを処理するハンドラー(認証、パスパラメータの解析を含む)
+func NewThreadHandler(postUseCase usecase.Post, userUseCase usecase.User, events middlewarehelper.HandlerFunc) *threadHandler {
+	return &threadHandler{
+		uc:             postUseCase,
+		authMiddleware: middleware.NewAuth(userUseCase),
+		events:         events,
+	}
+}
+
+// RegistHandlerFunc ハンドラー登録
+func (h *threadHandler) RegistHandlerFunc() {
+	http.HandleFunc(
+		"/threads/",
+		middlewarehelper.Apply(
+			handlerctx.NewAPIContext,
+			h.thread,
+		),
+	)
+}
+
+// thread 投稿の取得・投稿(/threads/:id/posts)とイベント配信(/threads/:id/events)の振り分け
+// パスパラメータミドルウェアはパスの階層数が一致するパターンしか扱えないため、ここでパターンを選ぶ
+func (h *threadHandler) thread(c handlerctx.APIContext) error {
+	if strings.HasSuffix(c.URL().Path, "/events") {
+		return h.events(c)
+	}
+	return middleware.NewPathParam("/threads/:id/posts").Parse(h.posts)(c)
+}
+
+// posts 投稿の取得(GET、読み取り権限)と返信(POST、投稿権限)
+func (h *threadHandler) posts(c handlerctx.APIContext) error {
+	switch c.RequestMethod() {
+	case http.MethodGet:
+		return h.authMiddleware.VerifyScope(model.APIScopeRead)(h.list)(c)
+	case http.MethodPost:
+		return h.authMiddleware.VerifyScope(model.APIScopePost)(h.reply)(c)
+	default:
+		c.WriteStatusCode(http.StatusMethodNotAllowed)
+		return nil
+	}
+}
+
+// list スレッドと投稿の取得(投稿番号がafterより後の投稿をlimit件まで古い順に返す)
+func (h *threadHandler) list(c handlerctx.APIContext) error {
+	query := c.URL().Query()
+	after := 0
+	if v := query.Get("after"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			c.WriteStatusCode(http.StatusBadRequest)
+			return nil
+		}
+		after = n
+	}
+	limit := 0
+	if v := query.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			c.WriteStatusCode(http.StatusBadRequest)
+			return nil
+		}
+		limit = n
+	}
+
+	posts, err := h.uc.Posts(c.RequestContext(), c.PathParam(), after, limit)
+	if err != nil {
+		if errors.Is(err, service.ErrThreadNotFound) {
+			c.WriteStatusCode(http.StatusNotFound)
+			return nil
+		}
+		slog.ErrorContext(c.RequestContext(), "post list error", "error", err)
+		c.WriteStatusCode(http.StatusInternalServerError)
+		return nil
+	}
+
+	return c.WriteResponseJSON(http.StatusOK, posts)
+}
+
+// reply スレッドへの返信
+func (h *threadHandler) reply(c handlerctx.APIContext) error {
+	var req dto.PostRequest
+	if err := json.NewDecoder(c.RequestBody()).Decode(&req); err != nil {
+		c.WriteStatusCode(http.StatusBadRequest)
+		return nil
+	}
+
+	post, err := h.uc.Reply(c.RequestContext(), c.UserID(), c.PathParam(), &req, time.Now())
+	if err != nil {
+		if errors.Is(err, service.ErrThreadNotFound) {
+			c.WriteStatusCode(http.StatusNotFound)
+			return nil
+		}
+		if errors.Is(err, service.ErrInvalidPostBody) {
+			c.WriteStatusCode(http.StatusBadRequest)
+			return nil
+		}
+		slog.ErrorContext(c.RequestContext(), "post reply error", "error", err)
+		c.WriteStatusCode(http.StatusInternalServerError)
+		return nil
+	}
+
+	return c.WriteResponseJSON(http.StatusCreated, post)
+}
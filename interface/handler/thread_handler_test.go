@@ -0,0 +1,247 @@
+package handler
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/domain/service"
+	"GoBBS/dto"
+	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/interface/middleware"
+	"GoBBS/mock/mock_usecase"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewThreadHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUC := mock_usecase.NewMockPost(ctrl)
+	mockUserUC := mock_usecase.NewMockUser(ctrl)
+
+	want := &threadHandler{uc: mockUC, authMiddleware: middleware.NewAuth(mockUserUC)}
+	if got := NewThreadHandler(mockUC, mockUserUC, nil); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewThreadHandler() = %v, want %v", got, want)
+	}
+}
+
+func Test_threadHandler_RegistHandlerFunc(t *testing.T) {
+	h := &threadHandler{}
+	h.RegistHandlerFunc()
+}
+
+func Test_threadHandler_thread(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("正常ケース(イベント配信)", func(t *testing.T) {
+		called := false
+		h := &threadHandler{events: func(c handlerctx.APIContext) error {
+			called = true
+			return nil
+		}}
+		c := newMockAPIContext(ctrl)
+		c.EXPECT().URL().Return(&url.URL{Path: "/threads/1/events"}).AnyTimes()
+
+		if err := h.thread(c); err != nil {
+			t.Errorf("予期せぬエラー(error: %s)", err)
+		}
+		if !called {
+			t.Error("イベント配信のハンドラーが呼ばれていない")
+		}
+	})
+
+	t.Run("正常ケース(投稿)", func(t *testing.T) {
+		h := &threadHandler{}
+		c := newMockAPIContext(ctrl)
+		c.EXPECT().URL().Return(&url.URL{Path: "/threads/1/posts"}).AnyTimes()
+		gomock.InOrder(
+			c.EXPECT().SetPathParam("1"),
+			c.EXPECT().RequestMethod().Return(http.MethodPut),
+			c.EXPECT().WriteStatusCode(http.StatusMethodNotAllowed),
+		)
+
+		if err := h.thread(c); err != nil {
+			t.Errorf("予期せぬエラー(error: %s)", err)
+		}
+	})
+
+	t.Run("異常ケース(パス不正)", func(t *testing.T) {
+		h := &threadHandler{}
+		c := newMockAPIContext(ctrl)
+		c.EXPECT().URL().Return(&url.URL{Path: "/threads/1/posts/2"}).AnyTimes()
+		c.EXPECT().WriteStatusCode(http.StatusBadRequest)
+
+		if err := h.thread(c); err != nil {
+			t.Errorf("予期せぬエラー(error: %s)", err)
+		}
+	})
+}
+
+func Test_threadHandler_posts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	posts := &dto.ThreadPosts{Thread: &dto.Thread{ID: "9"}, Posts: []*dto.Post{{ID: "31", Number: 2}}}
+	replyBody := `{"body":"返信"}`
+	replyReq := &dto.PostRequest{Body: "返信"}
+	created := &dto.Post{ID: "31", ThreadID: "9", Number: 2, Body: "返信"}
+
+	tests := []struct {
+		name string
+		h    *threadHandler
+		c    func() handlerctx.APIContext
+	}{
+		{
+			name: "正常ケース(取得)",
+			h: &threadHandler{
+				authMiddleware: passScope(ctrl, model.APIScopeRead),
+				uc: func() *mock_usecase.MockPost {
+					mock := mock_usecase.NewMockPost(ctrl)
+					mock.EXPECT().Posts(gomock.Any(), "9", 1, 50).Return(posts, nil)
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().URL().Return(&url.URL{RawQuery: "after=1&limit=50"}),
+					mock.EXPECT().PathParam().Return("9"),
+					mock.EXPECT().WriteResponseJSON(http.StatusOK, posts),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(after不正)",
+			h: &threadHandler{
+				authMiddleware: passScope(ctrl, model.APIScopeRead),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().URL().Return(&url.URL{RawQuery: "after=-1"}),
+					mock.EXPECT().WriteStatusCode(http.StatusBadRequest),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(スレッドなし)",
+			h: &threadHandler{
+				authMiddleware: passScope(ctrl, model.APIScopeRead),
+				uc: func() *mock_usecase.MockPost {
+					mock := mock_usecase.NewMockPost(ctrl)
+					mock.EXPECT().Posts(gomock.Any(), "9", 0, 0).Return(nil, service.ErrThreadNotFound)
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().URL().Return(&url.URL{}),
+					mock.EXPECT().PathParam().Return("9"),
+					mock.EXPECT().WriteStatusCode(http.StatusNotFound),
+				)
+				return mock
+			},
+		},
+		{
+			name: "正常ケース(返信)",
+			h: &threadHandler{
+				authMiddleware: passScope(ctrl, model.APIScopePost),
+				uc: func() *mock_usecase.MockPost {
+					mock := mock_usecase.NewMockPost(ctrl)
+					mock.EXPECT().Reply(gomock.Any(), "2", "9", replyReq, gomock.Any()).Return(created, nil)
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().RequestBody().Return(io.NopCloser(strings.NewReader(replyBody))),
+					mock.EXPECT().UserID().Return("2"),
+					mock.EXPECT().PathParam().Return("9"),
+					mock.EXPECT().WriteResponseJSON(http.StatusCreated, created),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(JSON不正)",
+			h: &threadHandler{
+				authMiddleware: passScope(ctrl, model.APIScopePost),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().RequestBody().Return(io.NopCloser(strings.NewReader(`{`))),
+					mock.EXPECT().WriteStatusCode(http.StatusBadRequest),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(本文不正)",
+			h: &threadHandler{
+				authMiddleware: passScope(ctrl, model.APIScopePost),
+				uc: func() *mock_usecase.MockPost {
+					mock := mock_usecase.NewMockPost(ctrl)
+					mock.EXPECT().Reply(gomock.Any(), "2", "9", replyReq, gomock.Any()).Return(nil, service.ErrInvalidPostBody)
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().RequestBody().Return(io.NopCloser(strings.NewReader(replyBody))),
+					mock.EXPECT().UserID().Return("2"),
+					mock.EXPECT().PathParam().Return("9"),
+					mock.EXPECT().WriteStatusCode(http.StatusBadRequest),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(返信失敗)",
+			h: &threadHandler{
+				authMiddleware: passScope(ctrl, model.APIScopePost),
+				uc: func() *mock_usecase.MockPost {
+					mock := mock_usecase.NewMockPost(ctrl)
+					mock.EXPECT().Reply(gomock.Any(), "2", "9", replyReq, gomock.Any()).Return(nil, errors.New("test"))
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().RequestBody().Return(io.NopCloser(strings.NewReader(replyBody))),
+					mock.EXPECT().UserID().Return("2"),
+					mock.EXPECT().PathParam().Return("9"),
+					mock.EXPECT().WriteStatusCode(http.StatusInternalServerError),
+				)
+				return mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.h.posts(tt.c()); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package handler
+
+import (
+	"io"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/service"
+	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/interface/imaging"
+	"GoBBS/interface/middleware"
+	"GoBBS/interface/middleware/middlewarehelper"
+	"GoBBS/interface/storage"
+	"GoBBS/usecase"
+)
+
+type uploadHandler struct {
+	uc             usecase.Upload
+	userUC         usecase.User
+	authMiddleware middleware.Auth
+}
+
+const (
+	// uploadFormField アップロードファイルのフォーム名
+	uploadFormField = "file"
+	// maxAvatarBytes アバター画像の最大バイト数
+	maxAvatarBytes = 2 << 20
+	// maxAttachmentBytes 添付画像の最大バイト数
+	maxAttachmentBytes = 10 << 20
+	// multipartOverhead ファイル以外のマルチパートの境界やヘッダに許容するバイト数
+	multipartOverhead = 64 << 10
+)
+
+var (
+	errNotMultipart    = errors.New("request is not multipart/form-data")
+	errUploadNotFound  = errors.New("upload file not found")
+	errUploadTooLarge  = errors.New("upload file too large")
+	errUploadBodyLimit = errors.New("request body too large")
+)
+
+// NewUploadHandler アップロードハンドラーを生成する
+func NewUploadHandler(uploadUseCase usecase.Upload, userUseCase usecase.User) *uploadHandler {
+	return &uploadHandler{
+		uc:             uploadUseCase,
+		userUC:         userUseCase,
+		authMiddleware: middleware.NewAuth(userUseCase),
+	}
+}
+
+// RegistHandlerFunc ハンドラー登録
+func (h *uploadHandler) RegistHandlerFunc() {
+	http.HandleFunc(
+		"/me/avatar",
+		middlewarehelper.Apply(
+			handlerctx.NewAPIContext,
+			h.avatar,
+			middleware.NewAuth(h.userUC).VerifyAuth,
+		),
+	)
+
+	http.HandleFunc(
+		"/attachments",
+		middlewarehelper.Apply(
+			handlerctx.NewAPIContext,
+			h.attachment,
+			middleware.NewAuth(h.userUC).VerifyScope(model.APIScopePost),
+		),
+	)
+
+	http.HandleFunc(
+		"/blobs/",
+		middlewarehelper.Apply(
+			handlerctx.NewAPIContext,
+			h.blob,
+			middleware.NewPathParam("/blobs/:key").Parse,
+		),
+	)
+}
+
+// avatar アバター画像アップロード
+func (h *uploadHandler) avatar(c handlerctx.APIContext) error {
+	if c.RequestMethod() != http.MethodPost {
+		c.WriteStatusCode(http.StatusMethodNotAllowed)
+		return nil
+	}
+
+	data, err := readUploadFile(c, maxAvatarBytes)
+	if err != nil {
+		h.writeUploadError(c, err)
+		return nil
+	}
+
+	user, err := h.uc.UploadAvatar(c.RequestContext(), c.UserID(), data, time.Now())
+	if err != nil {
+		h.writeUploadError(c, err)
+		return nil
+	}
+
+	return c.WriteResponseJSON(http.StatusOK, user.Private())
+}
+
+// attachment 添付画像アップロード
+func (h *uploadHandler) attachment(c handlerctx.APIContext) error {
+	if c.RequestMethod() != http.MethodPost {
+		c.WriteStatusCode(http.StatusMethodNotAllowed)
+		return nil
+	}
+
+	data, err := readUploadFile(c, maxAttachmentBytes)
+	if err != nil {
+		h.writeUploadError(c, err)
+		return nil
+	}
+
+	attachment, err := h.uc.UploadAttachment(c.RequestContext(), c.UserID(), data, time.Now())
+	if err != nil {
+		h.writeUploadError(c, err)
+		return nil
+	}
+
+	return c.WriteResponseJSON(http.StatusCreated, attachment)
+}
+
+// blob 保存済みオブジェクトの配信
+func (h *uploadHandler) blob(c handlerctx.APIContext) error {
+	if c.RequestMethod() != http.MethodGet {
+		c.WriteStatusCode(http.StatusMethodNotAllowed)
+		return nil
+	}
+
+	body, contentType, err := h.uc.OpenBlob(c.RequestContext(), c.PathParam())
+	if err != nil {
+		if errors.Is(err, storage.ErrBlobNotFound) || errors.Is(err, storage.ErrInvalidBlobKey) {
+			c.WriteStatusCode(http.StatusNotFound)
+			return nil
+		}
+		slog.ErrorContext(c.RequestContext(), "open blob error", "error", err)
+		c.WriteStatusCode(http.StatusInternalServerError)
+		return nil
+	}
+	defer body.Close()
+
+	// キーは推測できない値で採番し内容を書き換えないため、長期間キャッシュさせる
+	c.AddResponseHeader("Cache-Control", "public, max-age=31536000, immutable")
+	c.AddResponseHeader("X-Content-Type-Options", "nosniff")
+
+	return c.WriteResponseStream(http.StatusOK, contentType, body)
+}
+
+// writeUploadError アップロードのエラーに対応するステータスコードを返す
+func (h *uploadHandler) writeUploadError(c handlerctx.APIContext, err error) {
+	switch {
+	case errors.Is(err, errUploadTooLarge), errors.Is(err, errUploadBodyLimit):
+		c.WriteStatusCode(http.StatusRequestEntityTooLarge)
+	case errors.Is(err, errNotMultipart), errors.Is(err, errUploadNotFound):
+		c.WriteStatusCode(http.StatusBadRequest)
+	case errors.Is(err, imaging.ErrUnsupportedType):
+		c.WriteStatusCode(http.StatusUnsupportedMediaType)
+	case errors.Is(err, imaging.ErrTooLarge):
+		c.WriteStatusCode(http.StatusUnprocessableEntity)
+	case errors.Is(err, service.ErrUserNotFound):
+		c.WriteStatusCode(http.StatusNotFound)
+	default:
+		slog.ErrorContext(c.RequestContext(), "upload error", "error", err)
+		c.WriteStatusCode(http.StatusInternalServerError)
+	}
+}
+
+// readUploadFile マルチパートのリクエストボディからアップロードファイルを読み込む
+func readUploadFile(c handlerctx.APIContext, maxBytes int64) ([]byte, error) {
+	mediaType, params, err := mime.ParseMediaType(c.RequestHeader().Get("Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" || params["boundary"] == "" {
+		return nil, errNotMultipart
+	}
+
+	body := &limitReader{r: c.RequestBody(), n: maxBytes + multipartOverhead, err: errUploadBodyLimit}
+	reader := multipart.NewReader(body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil, errUploadNotFound
+		}
+		if err != nil {
+			if errors.Is(err, errUploadBodyLimit) {
+				return nil, errUploadBodyLimit
+			}
+			return nil, errors.Wrap(errNotMultipart, err.Error())
+		}
+
+		if part.FormName() != uploadFormField {
+			part.Close()
+			continue
+		}
+
+		data, err := io.ReadAll(&limitReader{r: part, n: maxBytes, err: errUploadTooLarge})
+		part.Close()
+		if err != nil {
+			if errors.Is(err, errUploadTooLarge) || errors.Is(err, errUploadBodyLimit) {
+				return nil, errUploadTooLarge
+			}
+			return nil, errors.Wrap(errNotMultipart, err.Error())
+		}
+
+		return data, nil
+	}
+}
+
+// limitReader nバイトを超えて読み込もうとした場合にerrを返すReader
+type limitReader struct {
+	r   io.Reader
+	n   int64
+	err error
+}
+
+// Read 読み込む
+func (l *limitReader) Read(p []byte) (int, error) {
+	if l.n < 0 {
+		return 0, l.err
+	}
+	// 上限ちょうどのデータを許容するため1バイト多く読み、超過を検出する
+	if int64(len(p)) > l.n+1 {
+		p = p[:l.n+1]
+	}
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	if l.n < 0 {
+		return n, l.err
+	}
+	return n, err
+}
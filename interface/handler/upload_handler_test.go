@@ -0,0 +1,404 @@
+package handler
+
+import (
+	"GoBBS/domain/service"
+	"GoBBS/dto"
+	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/interface/imaging"
+	"GoBBS/interface/middleware"
+	"GoBBS/interface/storage"
+	"GoBBS/mock/mock_handler/mock_handlerctx"
+	"GoBBS/mock/mock_usecase"
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+)
+
+// multipartBody フォーム名fieldにdataを持つマルチパートのボディとContent-Typeを生成する
+func multipartBody(t *testing.T, field string, data []byte) (io.ReadCloser, http.Header) {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	w := multipart.NewWriter(buf)
+	if err := w.WriteField("comment", "test"); err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	part, err := w.CreateFormFile(field, "test.png")
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	if _, err := part.Write(data); err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+
+	header := http.Header{}
+	header.Set("Content-Type", w.FormDataContentType())
+	return io.NopCloser(buf), header
+}
+
+func TestNewUploadHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUploadUC := mock_usecase.NewMockUpload(ctrl)
+	mockUserUC := mock_usecase.NewMockUser(ctrl)
+
+	want := &uploadHandler{
+		uc:             mockUploadUC,
+		userUC:         mockUserUC,
+		authMiddleware: middleware.NewAuth(mockUserUC),
+	}
+	if got := NewUploadHandler(mockUploadUC, mockUserUC); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewUploadHandler() = %v, want %v", got, want)
+	}
+}
+
+func Test_uploadHandler_RegistHandlerFunc(t *testing.T) {
+	h := &uploadHandler{}
+	h.RegistHandlerFunc()
+}
+
+func Test_uploadHandler_avatar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := &dto.User{ID: "1", Name: "name", Email: "a@example.com", AvatarURL: "/blobs/avatar-1.png"}
+
+	tests := []struct {
+		name string
+		h    *uploadHandler
+		c    func(t *testing.T) handlerctx.APIContext
+	}{
+		{
+			name: "正常ケース",
+			h: &uploadHandler{
+				uc: func() *mock_usecase.MockUpload {
+					mock := mock_usecase.NewMockUpload(ctrl)
+					mock.EXPECT().UploadAvatar(gomock.Any(), "1", []byte("image"), gomock.Any()).Return(user, nil)
+					return mock
+				}(),
+			},
+			c: func(t *testing.T) handlerctx.APIContext {
+				body, header := multipartBody(t, uploadFormField, []byte("image"))
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().RequestHeader().Return(header),
+					mock.EXPECT().RequestBody().Return(body),
+					mock.EXPECT().UserID().Return("1"),
+					mock.EXPECT().WriteResponseJSON(http.StatusOK, user.Private()),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(メソッド不正)",
+			h:    &uploadHandler{},
+			c: func(t *testing.T) handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().WriteStatusCode(http.StatusMethodNotAllowed),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(マルチパートでない)",
+			h:    &uploadHandler{},
+			c: func(t *testing.T) handlerctx.APIContext {
+				header := http.Header{}
+				header.Set("Content-Type", "application/json")
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().RequestHeader().Return(header),
+					mock.EXPECT().WriteStatusCode(http.StatusBadRequest),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(ファイルなし)",
+			h:    &uploadHandler{},
+			c: func(t *testing.T) handlerctx.APIContext {
+				body, header := multipartBody(t, "other", []byte("image"))
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().RequestHeader().Return(header),
+					mock.EXPECT().RequestBody().Return(body),
+					mock.EXPECT().WriteStatusCode(http.StatusBadRequest),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(ファイルサイズ超過)",
+			h:    &uploadHandler{},
+			c: func(t *testing.T) handlerctx.APIContext {
+				body, header := multipartBody(t, uploadFormField, make([]byte, maxAvatarBytes+1))
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().RequestHeader().Return(header),
+					mock.EXPECT().RequestBody().Return(body),
+					mock.EXPECT().WriteStatusCode(http.StatusRequestEntityTooLarge),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(非対応の画像形式)",
+			h: &uploadHandler{
+				uc: func() *mock_usecase.MockUpload {
+					mock := mock_usecase.NewMockUpload(ctrl)
+					mock.EXPECT().UploadAvatar(gomock.Any(), "1", gomock.Any(), gomock.Any()).Return(nil, errors.WithStack(imaging.ErrUnsupportedType))
+					return mock
+				}(),
+			},
+			c: func(t *testing.T) handlerctx.APIContext {
+				body, header := multipartBody(t, uploadFormField, []byte("text"))
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().RequestHeader().Return(header),
+					mock.EXPECT().RequestBody().Return(body),
+					mock.EXPECT().UserID().Return("1"),
+					mock.EXPECT().WriteStatusCode(http.StatusUnsupportedMediaType),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(ユーザーなし)",
+			h: &uploadHandler{
+				uc: func() *mock_usecase.MockUpload {
+					mock := mock_usecase.NewMockUpload(ctrl)
+					mock.EXPECT().UploadAvatar(gomock.Any(), "1", gomock.Any(), gomock.Any()).Return(nil, service.ErrUserNotFound)
+					return mock
+				}(),
+			},
+			c: func(t *testing.T) handlerctx.APIContext {
+				body, header := multipartBody(t, uploadFormField, []byte("image"))
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().RequestHeader().Return(header),
+					mock.EXPECT().RequestBody().Return(body),
+					mock.EXPECT().UserID().Return("1"),
+					mock.EXPECT().WriteStatusCode(http.StatusNotFound),
+				)
+				return mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.h.avatar(tt.c(t)); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+}
+
+func Test_uploadHandler_attachment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	attachment := &dto.Attachment{ID: "1", URL: "/blobs/attachment-1.png"}
+
+	tests := []struct {
+		name string
+		h    *uploadHandler
+		c    func(t *testing.T) handlerctx.APIContext
+	}{
+		{
+			name: "正常ケース",
+			h: &uploadHandler{
+				uc: func() *mock_usecase.MockUpload {
+					mock := mock_usecase.NewMockUpload(ctrl)
+					mock.EXPECT().UploadAttachment(gomock.Any(), "1", []byte("image"), gomock.Any()).Return(attachment, nil)
+					return mock
+				}(),
+			},
+			c: func(t *testing.T) handlerctx.APIContext {
+				body, header := multipartBody(t, uploadFormField, []byte("image"))
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().RequestHeader().Return(header),
+					mock.EXPECT().RequestBody().Return(body),
+					mock.EXPECT().UserID().Return("1"),
+					mock.EXPECT().WriteResponseJSON(http.StatusCreated, attachment),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(メソッド不正)",
+			h:    &uploadHandler{},
+			c: func(t *testing.T) handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().WriteStatusCode(http.StatusMethodNotAllowed),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(画像サイズ超過)",
+			h: &uploadHandler{
+				uc: func() *mock_usecase.MockUpload {
+					mock := mock_usecase.NewMockUpload(ctrl)
+					mock.EXPECT().UploadAttachment(gomock.Any(), "1", gomock.Any(), gomock.Any()).Return(nil, errors.WithStack(imaging.ErrTooLarge))
+					return mock
+				}(),
+			},
+			c: func(t *testing.T) handlerctx.APIContext {
+				body, header := multipartBody(t, uploadFormField, []byte("image"))
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().RequestHeader().Return(header),
+					mock.EXPECT().RequestBody().Return(body),
+					mock.EXPECT().UserID().Return("1"),
+					mock.EXPECT().WriteStatusCode(http.StatusUnprocessableEntity),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(想定外のエラー)",
+			h: &uploadHandler{
+				uc: func() *mock_usecase.MockUpload {
+					mock := mock_usecase.NewMockUpload(ctrl)
+					mock.EXPECT().UploadAttachment(gomock.Any(), "1", gomock.Any(), gomock.Any()).Return(nil, errors.New("error"))
+					return mock
+				}(),
+			},
+			c: func(t *testing.T) handlerctx.APIContext {
+				body, header := multipartBody(t, uploadFormField, []byte("image"))
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().RequestHeader().Return(header),
+					mock.EXPECT().RequestBody().Return(body),
+					mock.EXPECT().UserID().Return("1"),
+					mock.EXPECT().WriteStatusCode(http.StatusInternalServerError),
+				)
+				return mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.h.attachment(tt.c(t)); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+}
+
+func Test_uploadHandler_blob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	body := io.NopCloser(strings.NewReader("image"))
+
+	tests := []struct {
+		name string
+		h    *uploadHandler
+		c    handlerctx.APIContext
+	}{
+		{
+			name: "正常ケース",
+			h: &uploadHandler{
+				uc: func() *mock_usecase.MockUpload {
+					mock := mock_usecase.NewMockUpload(ctrl)
+					mock.EXPECT().OpenBlob(gomock.Any(), "avatar-1.png").Return(body, "image/png", nil)
+					return mock
+				}(),
+			},
+			c: func() *mock_handlerctx.MockAPIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().PathParam().Return("avatar-1.png"),
+					mock.EXPECT().AddResponseHeader("Cache-Control", "public, max-age=31536000, immutable"),
+					mock.EXPECT().AddResponseHeader("X-Content-Type-Options", "nosniff"),
+					mock.EXPECT().WriteResponseStream(http.StatusOK, "image/png", body),
+				)
+				return mock
+			}(),
+		},
+		{
+			name: "異常ケース(メソッド不正)",
+			h:    &uploadHandler{},
+			c: func() *mock_handlerctx.MockAPIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().WriteStatusCode(http.StatusMethodNotAllowed),
+				)
+				return mock
+			}(),
+		},
+		{
+			name: "異常ケース(オブジェクトなし)",
+			h: &uploadHandler{
+				uc: func() *mock_usecase.MockUpload {
+					mock := mock_usecase.NewMockUpload(ctrl)
+					mock.EXPECT().OpenBlob(gomock.Any(), "avatar-2.png").Return(nil, "", errors.WithStack(storage.ErrBlobNotFound))
+					return mock
+				}(),
+			},
+			c: func() *mock_handlerctx.MockAPIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().PathParam().Return("avatar-2.png"),
+					mock.EXPECT().WriteStatusCode(http.StatusNotFound),
+				)
+				return mock
+			}(),
+		},
+		{
+			name: "異常ケース(キー不正)",
+			h: &uploadHandler{
+				uc: func() *mock_usecase.MockUpload {
+					mock := mock_usecase.NewMockUpload(ctrl)
+					mock.EXPECT().OpenBlob(gomock.Any(), "..").Return(nil, "", errors.WithStack(storage.ErrInvalidBlobKey))
+					return mock
+				}(),
+			},
+			c: func() *mock_handlerctx.MockAPIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().PathParam().Return(".."),
+					mock.EXPECT().WriteStatusCode(http.StatusNotFound),
+				)
+				return mock
+			}(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.h.blob(tt.c); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+}
@@ -3,43 +3,34 @@ package handler
 import (
 	"encoding/json"
 	"io"
-	"log"
+	"log/slog"
 	"net/http"
 	"time"
 
 	"github.com/pkg/errors"
 
+	"GoBBS/domain/model"
 	"GoBBS/domain/service"
 	"GoBBS/dto"
 	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/interface/metrics"
 	"GoBBS/interface/middleware"
 	"GoBBS/interface/middleware/middlewarehelper"
 	"GoBBS/usecase"
 )
 
 type userHandler struct {
-	corsAllowOrigin  string
-	corsAllowMethods []string
-	corsAllowHeaders []string
-	corsAllowMaxAge  int
-	uc               usecase.User
-	authMiddleware   middleware.Auth
+	uc             usecase.User
+	sessionUC      usecase.Session
+	authMiddleware middleware.Auth
 }
 
 // NewUserHandler ユーザーハンドラーを生成する
-func NewUserHandler(
-	usecase usecase.User,
-	corsAllowOrigin string,
-	corsAllowMethods []string,
-	corsAllowHeaders []string,
-	corsAllowMaxAge int) *userHandler {
+func NewUserHandler(usecase usecase.User, sessionUseCase usecase.Session) *userHandler {
 	return &userHandler{
-		corsAllowOrigin:  corsAllowOrigin,
-		corsAllowMethods: corsAllowMethods,
-		corsAllowHeaders: corsAllowHeaders,
-		corsAllowMaxAge:  corsAllowMaxAge,
-		uc:               usecase,
-		authMiddleware:   middleware.NewAuth(usecase),
+		uc:             usecase,
+		sessionUC:      sessionUseCase,
+		authMiddleware: middleware.NewAuth(usecase),
 	}
 }
 
@@ -50,12 +41,6 @@ func (h *userHandler) RegistHandlerFunc() {
 		middlewarehelper.Apply(
 			handlerctx.NewAPIContext,
 			h.new,
-			middleware.NewCORS(
-				h.corsAllowOrigin,
-				h.corsAllowMethods,
-				h.corsAllowHeaders,
-				h.corsAllowMaxAge,
-			).AddResponseHeader,
 		),
 	)
 
@@ -63,12 +48,20 @@ func (h *userHandler) RegistHandlerFunc() {
 		"/users/",
 		middlewarehelper.Apply(
 			handlerctx.NewAPIContext,
-			h.edit,
-			middleware.NewAuth(h.uc).VerifyAuth,
+			h.member,
 			middleware.NewPathParam("/users/:id").Parse,
 		),
 	)
 
+	http.HandleFunc(
+		"/me",
+		middlewarehelper.Apply(
+			handlerctx.NewAPIContext,
+			h.me,
+			middleware.NewAuth(h.uc).VerifyScope(model.APIScopeRead),
+		),
+	)
+
 	http.HandleFunc(
 		"/login",
 		middlewarehelper.Apply(
@@ -76,13 +69,34 @@ func (h *userHandler) RegistHandlerFunc() {
 			h.auth,
 		),
 	)
+
+	http.HandleFunc(
+		"/login/mfa",
+		middlewarehelper.Apply(
+			handlerctx.NewAPIContext,
+			h.loginMFA,
+		),
+	)
+
+	http.HandleFunc(
+		"/logout",
+		middlewarehelper.Apply(
+			handlerctx.NewAPIContext,
+			h.logout,
+			middleware.NewAuth(h.uc).VerifyAuth,
+		),
+	)
 }
 
+// loginModeCookie ログイン時にトークンをクッキーで受け取るモード(/login?mode=cookie)
+// ブラウザでトークンをJavaScriptから読める場所に保存せずに済む
+const loginModeCookie = "cookie"
+
 // new 新規作成
 func (h *userHandler) new(c handlerctx.APIContext) error {
 	user, err := h.getUserFromReqBody(c.RequestBody())
 	if err != nil {
-		log.Printf("get user error : %v", err)
+		slog.WarnContext(c.RequestContext(), "get user error", "error", err)
 		c.WriteStatusCode(http.StatusBadRequest)
 		return nil
 	}
@@ -97,26 +111,83 @@ func (h *userHandler) new(c handlerctx.APIContext) error {
 	return nil
 }
 
-// edit 編集
-func (h *userHandler) edit(c handlerctx.APIContext) error {
-	user, err := h.getUserFromReqBody(c.RequestBody())
-	if err != nil {
-		log.Printf("get user error : %v", err)
+// member ユーザー個別(取得は認証不要、更新・削除は認証が必要)
+func (h *userHandler) member(c handlerctx.APIContext) error {
+	if c.RequestMethod() == http.MethodGet {
+		return h.show(c)
+	}
+
+	return h.authMiddleware.VerifyAuth(h.edit)(c)
+}
+
+// show 公開プロフィール取得
+func (h *userHandler) show(c handlerctx.APIContext) error {
+	userID := c.PathParam()
+	if userID == "" {
 		c.WriteStatusCode(http.StatusBadRequest)
 		return nil
 	}
 
+	user, err := h.uc.FindByID(c.RequestContext(), userID)
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			c.WriteStatusCode(http.StatusNotFound)
+			return nil
+		}
+		slog.ErrorContext(c.RequestContext(), "find user error", "error", err)
+		c.WriteStatusCode(http.StatusInternalServerError)
+		return nil
+	}
+
+	return c.WriteResponseJSON(http.StatusOK, user.Public())
+}
+
+// me 本人プロフィール取得
+func (h *userHandler) me(c handlerctx.APIContext) error {
+	if c.RequestMethod() != http.MethodGet {
+		c.WriteStatusCode(http.StatusMethodNotAllowed)
+		return nil
+	}
+
+	user, err := h.uc.FindByID(c.RequestContext(), c.UserID())
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			c.WriteStatusCode(http.StatusNotFound)
+			return nil
+		}
+		slog.ErrorContext(c.RequestContext(), "find user error", "error", err)
+		c.WriteStatusCode(http.StatusInternalServerError)
+		return nil
+	}
+
+	return c.WriteResponseJSON(http.StatusOK, user.Private())
+}
+
+// edit 編集(更新は省略した項目を現在の値のままにする部分更新)
+func (h *userHandler) edit(c handlerctx.APIContext) error {
 	userID := c.PathParam()
 	if userID == "" {
 		c.WriteStatusCode(http.StatusBadRequest)
 		return nil
 	}
-	user.ID = userID
 
 	switch c.RequestMethod() {
 	case http.MethodPut:
-		h.update(c, user)
+		var req dto.UserUpdateRequest
+		if err := json.NewDecoder(c.RequestBody()).Decode(&req); err != nil {
+			slog.WarnContext(c.RequestContext(), "get user error", "error", err)
+			c.WriteStatusCode(http.StatusBadRequest)
+			return nil
+		}
+		h.update(c, req)
 	case http.MethodDelete:
+		user, err := h.getUserFromReqBody(c.RequestBody())
+		if err != nil {
+			slog.WarnContext(c.RequestContext(), "get user error", "error", err)
+			c.WriteStatusCode(http.StatusBadRequest)
+			return nil
+		}
+		user.ID = userID
 		h.delete(c, user)
 	default:
 		c.WriteStatusCode(http.StatusMethodNotAllowed)
@@ -129,7 +200,7 @@ func (h *userHandler) edit(c handlerctx.APIContext) error {
 func (h *userHandler) auth(c handlerctx.APIContext) error {
 	user, err := h.getUserFromReqBody(c.RequestBody())
 	if err != nil {
-		log.Printf("get user error : %v", err)
+		slog.WarnContext(c.RequestContext(), "get user error", "error", err)
 		c.WriteStatusCode(http.StatusBadRequest)
 		return nil
 	}
@@ -146,8 +217,8 @@ func (h *userHandler) auth(c handlerctx.APIContext) error {
 
 // regist ユーザー登録
 func (h *userHandler) regist(c handlerctx.APIContext, user dto.User) {
-	if err := h.uc.Regist(&user, time.Now()); err != nil {
-		log.Printf("regist error : %v", err)
+	if err := h.uc.Regist(c.RequestContext(), &user, time.Now()); err != nil {
+		slog.ErrorContext(c.RequestContext(), "regist error", "error", err)
 		if errors.Is(err, service.ErrUserAlreadyRegistered) {
 			c.WriteStatusCode(http.StatusBadRequest)
 			return
@@ -160,13 +231,13 @@ func (h *userHandler) regist(c handlerctx.APIContext, user dto.User) {
 }
 
 // update ユーザー更新
-func (h *userHandler) update(c handlerctx.APIContext, user dto.User) {
-	if err := h.uc.Update(&user, time.Now()); err != nil {
-		if errors.Is(err, service.ErrUserNotFound) {
+func (h *userHandler) update(c handlerctx.APIContext, req dto.UserUpdateRequest) {
+	if err := h.uc.Update(c.RequestContext(), &req, time.Now()); err != nil {
+		if errors.Is(err, service.ErrUserNotFound) || errors.Is(err, service.ErrInvalidAvatarURL) {
 			c.WriteStatusCode(http.StatusBadRequest)
 			return
 		}
-		log.Printf("update error: %v", err)
+		slog.ErrorContext(c.RequestContext(), "update error", "error", err)
 		c.WriteStatusCode(http.StatusInternalServerError)
 		return
 	}
@@ -176,13 +247,13 @@ func (h *userHandler) update(c handlerctx.APIContext, user dto.User) {
 
 // delete ユーザー削除
 func (h *userHandler) delete(c handlerctx.APIContext, user dto.User) {
-	if err := h.uc.Delete(&user); err != nil {
+	if err := h.uc.Delete(c.RequestContext(), &user); err != nil {
 		if errors.Is(err, service.ErrUserNotFound) {
 			c.WriteStatusCode(http.StatusBadRequest)
 			return
 		}
 
-		log.Printf("delete error: %v", err)
+		slog.ErrorContext(c.RequestContext(), "delete error", "error", err)
 		c.WriteStatusCode(http.StatusInternalServerError)
 		return
 	}
@@ -191,18 +262,92 @@ func (h *userHandler) delete(c handlerctx.APIContext, user dto.User) {
 }
 
 // login ログイン
+// 2要素認証を有効にしているユーザーにはトークンの代わりにチャレンジを返す
 func (h *userHandler) login(c handlerctx.APIContext, user dto.User) error {
-	token, err := h.uc.Authorize(user.Email, user.Password)
+	token, challenge, err := h.uc.Authorize(c.RequestContext(), user.Email, user.Password, c.RemoteIP(), c.RequestHeader().Get("User-Agent"))
 	if err != nil {
-		log.Printf("login authorize error: %v", err)
+		metrics.Logins.WithLabelValues(metrics.LoginFailure).Inc()
+		slog.WarnContext(c.RequestContext(), "login authorize error", "error", err)
 		c.WriteStatusCode(http.StatusUnauthorized)
 		return nil
 	}
+	if challenge != nil {
+		metrics.Logins.WithLabelValues(metrics.LoginMFARequired).Inc()
+		return c.WriteResponseJSON(http.StatusOK, challenge)
+	}
+	metrics.Logins.WithLabelValues(metrics.LoginSuccess).Inc()
+
+	return h.writeToken(c, token)
+}
+
+// loginMFA /loginで受け取ったチャレンジのトークンと確認コードを交換してログインする
+// クッキーで受け取る場合は/loginと同じく?mode=cookieを付ける
+func (h *userHandler) loginMFA(c handlerctx.APIContext) error {
+	if c.RequestMethod() != http.MethodPost {
+		c.WriteStatusCode(http.StatusMethodNotAllowed)
+		return nil
+	}
+
+	var req dto.MFALogin
+	if err := json.NewDecoder(c.RequestBody()).Decode(&req); err != nil || req.MFAToken == "" || req.Code == "" {
+		c.WriteStatusCode(http.StatusBadRequest)
+		return nil
+	}
+
+	token, err := h.uc.VerifyMFA(c.RequestContext(), req.MFAToken, req.Code, c.RemoteIP(), c.RequestHeader().Get("User-Agent"))
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidMFACode) || errors.Is(err, service.ErrMFAChallengeInvalid) {
+			metrics.Logins.WithLabelValues(metrics.LoginFailure).Inc()
+			slog.WarnContext(c.RequestContext(), "login mfa error", "error", err)
+			c.WriteStatusCode(http.StatusUnauthorized)
+			return nil
+		}
+		slog.ErrorContext(c.RequestContext(), "login mfa error", "error", err)
+		c.WriteStatusCode(http.StatusInternalServerError)
+		return nil
+	}
+	metrics.Logins.WithLabelValues(metrics.LoginSuccess).Inc()
+
+	return h.writeToken(c, token)
+}
+
+// writeToken トークンをレスポンスボディ、またはクッキー(?mode=cookie)で返す
+func (h *userHandler) writeToken(c handlerctx.APIContext, token string) error {
+	if c.URL().Query().Get("mode") == loginModeCookie {
+		csrfToken := middleware.NewCSRFToken()
+		for _, cookie := range middleware.NewSessionCookies(token, csrfToken) {
+			c.SetCookie(cookie)
+		}
+		return c.WriteResponseJSON(http.StatusOK, dto.NewCSRFToken(csrfToken))
+	}
 
 	jsonToken := dto.NewToken(token)
 	return c.WriteResponseJSON(http.StatusOK, jsonToken)
 }
 
+// logout 認証に使ったログインセッションを失効させ、セッションのクッキーを削除する
+// 失効させるため、トークンをヘッダーで送る場合も漏れたトークンは使えなくなる
+func (h *userHandler) logout(c handlerctx.APIContext) error {
+	if c.RequestMethod() != http.MethodPost {
+		c.WriteStatusCode(http.StatusMethodNotAllowed)
+		return nil
+	}
+
+	// 失効できなかった場合はクッキーを残し、ログアウトできたと誤解させない
+	err := h.sessionUC.Revoke(c.RequestContext(), c.UserID(), c.SessionID(), time.Now())
+	if err != nil && !errors.Is(err, service.ErrSessionNotFound) {
+		slog.ErrorContext(c.RequestContext(), "logout error", "error", err)
+		c.WriteStatusCode(http.StatusInternalServerError)
+		return nil
+	}
+
+	for _, cookie := range middleware.ExpiredSessionCookies() {
+		c.SetCookie(cookie)
+	}
+	c.WriteStatusCode(http.StatusNoContent)
+	return nil
+}
+
 // getUserFromReqBody リクエストボディからユーザー情報を取得する
 func (h *userHandler) getUserFromReqBody(body io.ReadCloser) (dto.User, error) {
 	var user dto.User
@@ -4,18 +4,23 @@ import (
 	"GoBBS/domain/service"
 	"GoBBS/dto"
 	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/interface/metrics"
 	"GoBBS/interface/middleware"
 	"GoBBS/mock/mock_handler/mock_handlerctx"
+	"GoBBS/mock/mock_middleware"
 	"GoBBS/mock/mock_usecase"
 	"GoBBS/usecase"
 	"bytes"
 	"io"
 	"net/http"
+	"net/url"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
 func TestNewUserHandler(t *testing.T) {
@@ -23,13 +28,11 @@ func TestNewUserHandler(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUC := mock_usecase.NewMockUser(ctrl)
+	mockSessionUC := mock_usecase.NewMockSession(ctrl)
 
 	type args struct {
-		usecase          usecase.User
-		corsAllowOrigin  string
-		corsAllowMethods []string
-		corsAllowHeaders []string
-		corsAllowMaxAge  int
+		usecase        usecase.User
+		sessionUseCase usecase.Session
 	}
 	tests := []struct {
 		name string
@@ -39,25 +42,19 @@ func TestNewUserHandler(t *testing.T) {
 		{
 			name: "正常ケース",
 			args: args{
-				usecase:          mockUC,
-				corsAllowOrigin:  "a",
-				corsAllowMethods: []string{"b", "c"},
-				corsAllowHeaders: []string{"d", "e"},
-				corsAllowMaxAge:  1,
+				usecase:        mockUC,
+				sessionUseCase: mockSessionUC,
 			},
 			want: &userHandler{
-				uc:               mockUC,
-				authMiddleware:   middleware.NewAuth(mockUC),
-				corsAllowOrigin:  "a",
-				corsAllowMethods: []string{"b", "c"},
-				corsAllowHeaders: []string{"d", "e"},
-				corsAllowMaxAge:  1,
+				uc:             mockUC,
+				sessionUC:      mockSessionUC,
+				authMiddleware: middleware.NewAuth(mockUC),
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewUserHandler(tt.args.usecase, tt.args.corsAllowOrigin, tt.args.corsAllowMethods, tt.args.corsAllowHeaders, tt.args.corsAllowMaxAge); !reflect.DeepEqual(got, tt.want) {
+			if got := NewUserHandler(tt.args.usecase, tt.args.sessionUseCase); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewUserHandler() = %v, want %v", got, tt.want)
 			}
 		})
@@ -98,7 +95,7 @@ func Test_userHandler_new(t *testing.T) {
 			name: "正常ケース",
 			args: args{
 				c: func() *mock_handlerctx.MockAPIContext {
-					mock := mock_handlerctx.NewMockAPIContext(ctrl)
+					mock := newMockAPIContext(ctrl)
 					gomock.InOrder(
 						mock.EXPECT().RequestBody().Return(io.NopCloser(bytes.NewBufferString(`{"id":"1"}`))),
 						mock.EXPECT().RequestMethod().Return(http.MethodPost),
@@ -110,7 +107,7 @@ func Test_userHandler_new(t *testing.T) {
 			h: &userHandler{
 				uc: func() *mock_usecase.MockUser {
 					mock := mock_usecase.NewMockUser(ctrl)
-					mock.EXPECT().Regist(gomock.Any(), gomock.Any()).Return(nil)
+					mock.EXPECT().Regist(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 					return mock
 				}(),
 			},
@@ -120,7 +117,7 @@ func Test_userHandler_new(t *testing.T) {
 			name: "異常ケース(メソッド不正)",
 			args: args{
 				c: func() *mock_handlerctx.MockAPIContext {
-					mock := mock_handlerctx.NewMockAPIContext(ctrl)
+					mock := newMockAPIContext(ctrl)
 					gomock.InOrder(
 						mock.EXPECT().RequestBody().Return(io.NopCloser(bytes.NewBufferString(`{"id":"1"}`))),
 						mock.EXPECT().RequestMethod().Return(http.MethodPatch),
@@ -135,7 +132,7 @@ func Test_userHandler_new(t *testing.T) {
 			name: "異常ケース(リクエストボディ読み込みエラー)",
 			args: args{
 				c: func() *mock_handlerctx.MockAPIContext {
-					mock := mock_handlerctx.NewMockAPIContext(ctrl)
+					mock := newMockAPIContext(ctrl)
 					gomock.InOrder(
 						mock.EXPECT().RequestBody().Return(io.NopCloser(bytes.NewBufferString(`{`))),
 						mock.EXPECT().WriteStatusCode(http.StatusBadRequest),
@@ -172,11 +169,11 @@ func Test_userHandler_edit(t *testing.T) {
 			name: "正常ケース(ユーザー更新)",
 			args: args{
 				c: func() *mock_handlerctx.MockAPIContext {
-					mock := mock_handlerctx.NewMockAPIContext(ctrl)
+					mock := newMockAPIContext(ctrl)
 					gomock.InOrder(
-						mock.EXPECT().RequestBody().Return(io.NopCloser(bytes.NewBufferString(`{"id":"1"}`))),
 						mock.EXPECT().PathParam().Return("1"),
 						mock.EXPECT().RequestMethod().Return(http.MethodPut),
+						mock.EXPECT().RequestBody().Return(io.NopCloser(bytes.NewBufferString(`{"email":"email","bio":""}`))),
 						mock.EXPECT().WriteStatusCode(http.StatusOK),
 					)
 					return mock
@@ -185,7 +182,8 @@ func Test_userHandler_edit(t *testing.T) {
 			h: &userHandler{
 				uc: func() *mock_usecase.MockUser {
 					mock := mock_usecase.NewMockUser(ctrl)
-					mock.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+					bio := ""
+					mock.EXPECT().Update(gomock.Any(), &dto.UserUpdateRequest{Email: "email", Bio: &bio}, gomock.Any()).Return(nil)
 					return mock
 				}(),
 			},
@@ -195,11 +193,11 @@ func Test_userHandler_edit(t *testing.T) {
 			name: "正常ケース(ユーザー削除)",
 			args: args{
 				c: func() *mock_handlerctx.MockAPIContext {
-					mock := mock_handlerctx.NewMockAPIContext(ctrl)
+					mock := newMockAPIContext(ctrl)
 					gomock.InOrder(
-						mock.EXPECT().RequestBody().Return(io.NopCloser(bytes.NewBufferString(`{"id":"1"}`))),
 						mock.EXPECT().PathParam().Return("1"),
 						mock.EXPECT().RequestMethod().Return(http.MethodDelete),
+						mock.EXPECT().RequestBody().Return(io.NopCloser(bytes.NewBufferString(`{"id":"1"}`))),
 						mock.EXPECT().WriteStatusCode(http.StatusOK),
 					)
 					return mock
@@ -208,7 +206,7 @@ func Test_userHandler_edit(t *testing.T) {
 			h: &userHandler{
 				uc: func() *mock_usecase.MockUser {
 					mock := mock_usecase.NewMockUser(ctrl)
-					mock.EXPECT().Delete(gomock.Any()).Return(nil)
+					mock.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
 					return mock
 				}(),
 			},
@@ -218,9 +216,8 @@ func Test_userHandler_edit(t *testing.T) {
 			name: "異常ケース(メソッド不正)",
 			args: args{
 				c: func() *mock_handlerctx.MockAPIContext {
-					mock := mock_handlerctx.NewMockAPIContext(ctrl)
+					mock := newMockAPIContext(ctrl)
 					gomock.InOrder(
-						mock.EXPECT().RequestBody().Return(io.NopCloser(bytes.NewBufferString(`{"id":"1"}`))),
 						mock.EXPECT().PathParam().Return("1"),
 						mock.EXPECT().RequestMethod().Return(http.MethodPatch),
 						mock.EXPECT().WriteStatusCode(http.StatusMethodNotAllowed),
@@ -235,9 +232,8 @@ func Test_userHandler_edit(t *testing.T) {
 			name: "異常ケース(パスパラメータ不正)",
 			args: args{
 				c: func() *mock_handlerctx.MockAPIContext {
-					mock := mock_handlerctx.NewMockAPIContext(ctrl)
+					mock := newMockAPIContext(ctrl)
 					gomock.InOrder(
-						mock.EXPECT().RequestBody().Return(io.NopCloser(bytes.NewBufferString(`{"id":"1"}`))),
 						mock.EXPECT().PathParam().Return(""),
 						mock.EXPECT().WriteStatusCode(http.StatusBadRequest),
 					)
@@ -251,8 +247,10 @@ func Test_userHandler_edit(t *testing.T) {
 			name: "異常ケース(リクエストボディ読み込みエラー)",
 			args: args{
 				c: func() *mock_handlerctx.MockAPIContext {
-					mock := mock_handlerctx.NewMockAPIContext(ctrl)
+					mock := newMockAPIContext(ctrl)
 					gomock.InOrder(
+						mock.EXPECT().PathParam().Return("1"),
+						mock.EXPECT().RequestMethod().Return(http.MethodPut),
 						mock.EXPECT().RequestBody().Return(io.NopCloser(bytes.NewBufferString(`{`))),
 						mock.EXPECT().WriteStatusCode(http.StatusBadRequest),
 					)
@@ -289,10 +287,13 @@ func Test_userHandler_auth(t *testing.T) {
 			name: "正常ケース",
 			args: args{
 				c: func() *mock_handlerctx.MockAPIContext {
-					mock := mock_handlerctx.NewMockAPIContext(ctrl)
+					mock := newMockAPIContext(ctrl)
 					gomock.InOrder(
 						mock.EXPECT().RequestBody().Return(io.NopCloser(bytes.NewBufferString(`{"id":"1"}`))),
 						mock.EXPECT().RequestMethod().Return(http.MethodPost),
+						mock.EXPECT().RemoteIP().Return("192.0.2.1"),
+						mock.EXPECT().RequestHeader().Return(http.Header{"User-Agent": {"ua"}}),
+						mock.EXPECT().URL().Return(&url.URL{Path: "/login"}),
 						mock.EXPECT().WriteResponseJSON(http.StatusOK, dto.NewToken("abc")),
 					)
 					return mock
@@ -301,7 +302,7 @@ func Test_userHandler_auth(t *testing.T) {
 			h: &userHandler{
 				uc: func() *mock_usecase.MockUser {
 					mock := mock_usecase.NewMockUser(ctrl)
-					mock.EXPECT().Authorize(gomock.Any(), gomock.Any()).Return("abc", nil)
+					mock.EXPECT().Authorize(gomock.Any(), gomock.Any(), gomock.Any(), "192.0.2.1", "ua").Return("abc", nil, nil)
 					return mock
 				}(),
 			},
@@ -311,7 +312,7 @@ func Test_userHandler_auth(t *testing.T) {
 			name: "異常ケース(メソッド不正)",
 			args: args{
 				c: func() *mock_handlerctx.MockAPIContext {
-					mock := mock_handlerctx.NewMockAPIContext(ctrl)
+					mock := newMockAPIContext(ctrl)
 					gomock.InOrder(
 						mock.EXPECT().RequestBody().Return(io.NopCloser(bytes.NewBufferString(`{"id":"1"}`))),
 						mock.EXPECT().RequestMethod().Return(http.MethodPatch),
@@ -327,7 +328,7 @@ func Test_userHandler_auth(t *testing.T) {
 			name: "異常ケース(リクエストボディ読み込みエラー)",
 			args: args{
 				c: func() *mock_handlerctx.MockAPIContext {
-					mock := mock_handlerctx.NewMockAPIContext(ctrl)
+					mock := newMockAPIContext(ctrl)
 					gomock.InOrder(
 						mock.EXPECT().RequestBody().Return(io.NopCloser(bytes.NewBufferString(`{`))),
 						mock.EXPECT().WriteStatusCode(http.StatusBadRequest),
@@ -366,13 +367,13 @@ func Test_userHandler_regist(t *testing.T) {
 			h: &userHandler{
 				uc: func() *mock_usecase.MockUser {
 					mock := mock_usecase.NewMockUser(ctrl)
-					mock.EXPECT().Regist(gomock.Any(), gomock.Any()).Return(nil)
+					mock.EXPECT().Regist(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 					return mock
 				}(),
 			},
 			args: args{
 				c: func() *mock_handlerctx.MockAPIContext {
-					mock := mock_handlerctx.NewMockAPIContext(ctrl)
+					mock := newMockAPIContext(ctrl)
 					mock.EXPECT().WriteStatusCode(http.StatusOK)
 					return mock
 				}(),
@@ -383,13 +384,13 @@ func Test_userHandler_regist(t *testing.T) {
 			h: &userHandler{
 				uc: func() *mock_usecase.MockUser {
 					mock := mock_usecase.NewMockUser(ctrl)
-					mock.EXPECT().Regist(gomock.Any(), gomock.Any()).Return(service.ErrUserAlreadyRegistered)
+					mock.EXPECT().Regist(gomock.Any(), gomock.Any(), gomock.Any()).Return(service.ErrUserAlreadyRegistered)
 					return mock
 				}(),
 			},
 			args: args{
 				c: func() *mock_handlerctx.MockAPIContext {
-					mock := mock_handlerctx.NewMockAPIContext(ctrl)
+					mock := newMockAPIContext(ctrl)
 					mock.EXPECT().WriteStatusCode(http.StatusBadRequest)
 					return mock
 				}(),
@@ -400,13 +401,13 @@ func Test_userHandler_regist(t *testing.T) {
 			h: &userHandler{
 				uc: func() *mock_usecase.MockUser {
 					mock := mock_usecase.NewMockUser(ctrl)
-					mock.EXPECT().Regist(gomock.Any(), gomock.Any()).Return(errors.New("ng"))
+					mock.EXPECT().Regist(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("ng"))
 					return mock
 				}(),
 			},
 			args: args{
 				c: func() *mock_handlerctx.MockAPIContext {
-					mock := mock_handlerctx.NewMockAPIContext(ctrl)
+					mock := newMockAPIContext(ctrl)
 					mock.EXPECT().WriteStatusCode(http.StatusInternalServerError)
 					return mock
 				}(),
@@ -425,8 +426,8 @@ func Test_userHandler_update(t *testing.T) {
 	defer ctrl.Finish()
 
 	type args struct {
-		c    handlerctx.APIContext
-		user dto.User
+		c   handlerctx.APIContext
+		req dto.UserUpdateRequest
 	}
 	tests := []struct {
 		name string
@@ -438,13 +439,13 @@ func Test_userHandler_update(t *testing.T) {
 			h: &userHandler{
 				uc: func() *mock_usecase.MockUser {
 					mock := mock_usecase.NewMockUser(ctrl)
-					mock.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+					mock.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 					return mock
 				}(),
 			},
 			args: args{
 				c: func() *mock_handlerctx.MockAPIContext {
-					mock := mock_handlerctx.NewMockAPIContext(ctrl)
+					mock := newMockAPIContext(ctrl)
 					mock.EXPECT().WriteStatusCode(http.StatusOK)
 					return mock
 				}(),
@@ -455,13 +456,30 @@ func Test_userHandler_update(t *testing.T) {
 			h: &userHandler{
 				uc: func() *mock_usecase.MockUser {
 					mock := mock_usecase.NewMockUser(ctrl)
-					mock.EXPECT().Update(gomock.Any(), gomock.Any()).Return(service.ErrUserNotFound)
+					mock.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(service.ErrUserNotFound)
+					return mock
+				}(),
+			},
+			args: args{
+				c: func() *mock_handlerctx.MockAPIContext {
+					mock := newMockAPIContext(ctrl)
+					mock.EXPECT().WriteStatusCode(http.StatusBadRequest)
+					return mock
+				}(),
+			},
+		},
+		{
+			name: "異常ケース(アバター画像URL不正)",
+			h: &userHandler{
+				uc: func() *mock_usecase.MockUser {
+					mock := mock_usecase.NewMockUser(ctrl)
+					mock.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(service.ErrInvalidAvatarURL)
 					return mock
 				}(),
 			},
 			args: args{
 				c: func() *mock_handlerctx.MockAPIContext {
-					mock := mock_handlerctx.NewMockAPIContext(ctrl)
+					mock := newMockAPIContext(ctrl)
 					mock.EXPECT().WriteStatusCode(http.StatusBadRequest)
 					return mock
 				}(),
@@ -472,13 +490,13 @@ func Test_userHandler_update(t *testing.T) {
 			h: &userHandler{
 				uc: func() *mock_usecase.MockUser {
 					mock := mock_usecase.NewMockUser(ctrl)
-					mock.EXPECT().Update(gomock.Any(), gomock.Any()).Return(errors.New("ng"))
+					mock.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("ng"))
 					return mock
 				}(),
 			},
 			args: args{
 				c: func() *mock_handlerctx.MockAPIContext {
-					mock := mock_handlerctx.NewMockAPIContext(ctrl)
+					mock := newMockAPIContext(ctrl)
 					mock.EXPECT().WriteStatusCode(http.StatusInternalServerError)
 					return mock
 				}(),
@@ -487,7 +505,7 @@ func Test_userHandler_update(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.h.update(tt.args.c, tt.args.user)
+			tt.h.update(tt.args.c, tt.args.req)
 		})
 	}
 }
@@ -510,13 +528,13 @@ func Test_userHandler_delete(t *testing.T) {
 			h: &userHandler{
 				uc: func() *mock_usecase.MockUser {
 					mock := mock_usecase.NewMockUser(ctrl)
-					mock.EXPECT().Delete(gomock.Any()).Return(nil)
+					mock.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
 					return mock
 				}(),
 			},
 			args: args{
 				c: func() *mock_handlerctx.MockAPIContext {
-					mock := mock_handlerctx.NewMockAPIContext(ctrl)
+					mock := newMockAPIContext(ctrl)
 					mock.EXPECT().WriteStatusCode(http.StatusOK)
 					return mock
 				}(),
@@ -527,13 +545,13 @@ func Test_userHandler_delete(t *testing.T) {
 			h: &userHandler{
 				uc: func() *mock_usecase.MockUser {
 					mock := mock_usecase.NewMockUser(ctrl)
-					mock.EXPECT().Delete(gomock.Any()).Return(service.ErrUserNotFound)
+					mock.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(service.ErrUserNotFound)
 					return mock
 				}(),
 			},
 			args: args{
 				c: func() *mock_handlerctx.MockAPIContext {
-					mock := mock_handlerctx.NewMockAPIContext(ctrl)
+					mock := newMockAPIContext(ctrl)
 					mock.EXPECT().WriteStatusCode(http.StatusBadRequest)
 					return mock
 				}(),
@@ -544,13 +562,13 @@ func Test_userHandler_delete(t *testing.T) {
 			h: &userHandler{
 				uc: func() *mock_usecase.MockUser {
 					mock := mock_usecase.NewMockUser(ctrl)
-					mock.EXPECT().Delete(gomock.Any()).Return(errors.New("ng"))
+					mock.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(errors.New("ng"))
 					return mock
 				}(),
 			},
 			args: args{
 				c: func() *mock_handlerctx.MockAPIContext {
-					mock := mock_handlerctx.NewMockAPIContext(ctrl)
+					mock := newMockAPIContext(ctrl)
 					mock.EXPECT().WriteStatusCode(http.StatusInternalServerError)
 					return mock
 				}(),
@@ -577,49 +595,555 @@ func Test_userHandler_login(t *testing.T) {
 		h       *userHandler
 		args    args
 		wantErr bool
+		// wantLogin 加算されるログイン数の結果ラベル
+		wantLogin string
 	}{
 		{
 			name: "正常ケース",
 			h: &userHandler{
 				uc: func() *mock_usecase.MockUser {
 					mock := mock_usecase.NewMockUser(ctrl)
-					mock.EXPECT().Authorize(gomock.Any(), gomock.Any()).Return("abc", nil)
+					mock.EXPECT().Authorize(gomock.Any(), gomock.Any(), gomock.Any(), "192.0.2.1", "ua").Return("abc", nil, nil)
 					return mock
 				}(),
 			},
 			args: args{
 				c: func() *mock_handlerctx.MockAPIContext {
-					mock := mock_handlerctx.NewMockAPIContext(ctrl)
-					mock.EXPECT().WriteResponseJSON(http.StatusOK, dto.NewToken("abc")).Return(nil)
+					mock := newMockAPIContext(ctrl)
+					gomock.InOrder(
+						mock.EXPECT().RemoteIP().Return("192.0.2.1"),
+						mock.EXPECT().RequestHeader().Return(http.Header{"User-Agent": {"ua"}}),
+						mock.EXPECT().URL().Return(&url.URL{Path: "/login"}),
+						mock.EXPECT().WriteResponseJSON(http.StatusOK, dto.NewToken("abc")).Return(nil),
+					)
 					return mock
 				}(),
 			},
-			wantErr: false,
+			wantErr:   false,
+			wantLogin: metrics.LoginSuccess,
+		},
+		{
+			name: "正常ケース(クッキー)",
+			h: &userHandler{
+				uc: func() *mock_usecase.MockUser {
+					mock := mock_usecase.NewMockUser(ctrl)
+					mock.EXPECT().Authorize(gomock.Any(), gomock.Any(), gomock.Any(), "192.0.2.1", "ua").Return("abc", nil, nil)
+					return mock
+				}(),
+			},
+			args: args{
+				c: func() *mock_handlerctx.MockAPIContext {
+					mock := newMockAPIContext(ctrl)
+					cookies := map[string]*http.Cookie{}
+					gomock.InOrder(
+						mock.EXPECT().RemoteIP().Return("192.0.2.1"),
+						mock.EXPECT().RequestHeader().Return(http.Header{"User-Agent": {"ua"}}),
+						mock.EXPECT().URL().Return(&url.URL{Path: "/login", RawQuery: "mode=cookie"}),
+						mock.EXPECT().SetCookie(gomock.Any()).Do(func(cookie *http.Cookie) {
+							cookies[cookie.Name] = cookie
+						}).Times(2),
+						mock.EXPECT().WriteResponseJSON(http.StatusOK, gomock.Any()).DoAndReturn(func(_ int, body any) error {
+							session := cookies[middleware.SessionCookieName]
+							if session == nil || session.Value != "abc" || !session.HttpOnly || !session.Secure {
+								t.Errorf("セッションのクッキー不正 got: %#v", session)
+							}
+							csrf := cookies[middleware.CSRFCookieName]
+							if csrf == nil || csrf.Value == "" || csrf.HttpOnly {
+								t.Errorf("CSRFトークンのクッキー不正 got: %#v", csrf)
+							} else if want := dto.NewCSRFToken(csrf.Value); !reflect.DeepEqual(body, want) {
+								t.Errorf("戻り値不一致 got: %#v want: %#v", body, want)
+							}
+							return nil
+						}),
+					)
+					return mock
+				}(),
+			},
+			wantErr:   false,
+			wantLogin: metrics.LoginSuccess,
+		},
+		{
+			name: "正常ケース(2要素認証)",
+			h: &userHandler{
+				uc: func() *mock_usecase.MockUser {
+					mock := mock_usecase.NewMockUser(ctrl)
+					mock.EXPECT().Authorize(gomock.Any(), gomock.Any(), gomock.Any(), "192.0.2.1", "ua").
+						Return("", dto.NewMFAChallenge("mfa-token", time.Time{}), nil)
+					return mock
+				}(),
+			},
+			args: args{
+				c: func() *mock_handlerctx.MockAPIContext {
+					mock := newMockAPIContext(ctrl)
+					gomock.InOrder(
+						mock.EXPECT().RemoteIP().Return("192.0.2.1"),
+						mock.EXPECT().RequestHeader().Return(http.Header{"User-Agent": {"ua"}}),
+						mock.EXPECT().WriteResponseJSON(http.StatusOK, dto.NewMFAChallenge("mfa-token", time.Time{})).Return(nil),
+					)
+					return mock
+				}(),
+			},
+			wantErr:   false,
+			wantLogin: metrics.LoginMFARequired,
 		},
 		{
 			name: "異常ケース(認証エラー)",
 			h: &userHandler{
 				uc: func() *mock_usecase.MockUser {
 					mock := mock_usecase.NewMockUser(ctrl)
-					mock.EXPECT().Authorize(gomock.Any(), gomock.Any()).Return("", errors.New("ng"))
+					mock.EXPECT().Authorize(gomock.Any(), gomock.Any(), gomock.Any(), "192.0.2.1", "ua").Return("", nil, errors.New("ng"))
 					return mock
 				}(),
 			},
 			args: args{
 				c: func() *mock_handlerctx.MockAPIContext {
-					mock := mock_handlerctx.NewMockAPIContext(ctrl)
-					mock.EXPECT().WriteStatusCode(http.StatusUnauthorized)
+					mock := newMockAPIContext(ctrl)
+					gomock.InOrder(
+						mock.EXPECT().RemoteIP().Return("192.0.2.1"),
+						mock.EXPECT().RequestHeader().Return(http.Header{"User-Agent": {"ua"}}),
+						mock.EXPECT().WriteStatusCode(http.StatusUnauthorized),
+					)
 					return mock
 				}(),
 			},
-			wantErr: false,
+			wantErr:   false,
+			wantLogin: metrics.LoginFailure,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			before := testutil.ToFloat64(metrics.Logins.WithLabelValues(tt.wantLogin))
 			if err := tt.h.login(tt.args.c, tt.args.user); (err != nil) != tt.wantErr {
 				t.Errorf("userHandler.login() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			if got := testutil.ToFloat64(metrics.Logins.WithLabelValues(tt.wantLogin)); got != before+1 {
+				t.Errorf("ログイン数不一致 got: %#v want: %#v", got, before+1)
+			}
+		})
+	}
+}
+
+func Test_userHandler_loginMFA(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	body := `{"mfa_token":"mfa-token","code":"123456"}`
+
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		err        error
+		wantStatus int
+		// wantLogin 加算されるログイン数の結果ラベル(空の場合は加算されない)
+		wantLogin string
+	}{
+		{name: "正常ケース", method: http.MethodPost, body: body, wantStatus: http.StatusOK, wantLogin: metrics.LoginSuccess},
+		{name: "異常ケース(メソッド不正)", method: http.MethodGet, wantStatus: http.StatusMethodNotAllowed},
+		{name: "異常ケース(リクエストボディ不正)", method: http.MethodPost, body: `{`, wantStatus: http.StatusBadRequest},
+		{name: "異常ケース(コードなし)", method: http.MethodPost, body: `{"mfa_token":"mfa-token"}`, wantStatus: http.StatusBadRequest},
+		{
+			name:       "異常ケース(コード不一致)",
+			method:     http.MethodPost,
+			body:       body,
+			err:        service.ErrInvalidMFACode,
+			wantStatus: http.StatusUnauthorized,
+			wantLogin:  metrics.LoginFailure,
+		},
+		{
+			name:       "異常ケース(チャレンジ無効)",
+			method:     http.MethodPost,
+			body:       body,
+			err:        service.ErrMFAChallengeInvalid,
+			wantStatus: http.StatusUnauthorized,
+			wantLogin:  metrics.LoginFailure,
+		},
+		{name: "異常ケース(検証失敗)", method: http.MethodPost, body: body, err: errors.New("ng"), wantStatus: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUC := mock_usecase.NewMockUser(ctrl)
+			mock := newMockAPIContext(ctrl)
+			calls := []*gomock.Call{mock.EXPECT().RequestMethod().Return(tt.method)}
+			if tt.method == http.MethodPost {
+				calls = append(calls, mock.EXPECT().RequestBody().Return(io.NopCloser(bytes.NewBufferString(tt.body))))
+			}
+			switch {
+			case tt.wantStatus == http.StatusOK:
+				calls = append(calls,
+					mock.EXPECT().RemoteIP().Return("192.0.2.1"),
+					mock.EXPECT().RequestHeader().Return(http.Header{"User-Agent": {"ua"}}),
+					mockUC.EXPECT().VerifyMFA(gomock.Any(), "mfa-token", "123456", "192.0.2.1", "ua").Return("abc", nil),
+					mock.EXPECT().URL().Return(&url.URL{Path: "/login/mfa"}),
+					mock.EXPECT().WriteResponseJSON(http.StatusOK, dto.NewToken("abc")).Return(nil),
+				)
+			case tt.err != nil:
+				calls = append(calls,
+					mock.EXPECT().RemoteIP().Return("192.0.2.1"),
+					mock.EXPECT().RequestHeader().Return(http.Header{"User-Agent": {"ua"}}),
+					mockUC.EXPECT().VerifyMFA(gomock.Any(), "mfa-token", "123456", "192.0.2.1", "ua").Return("", tt.err),
+					mock.EXPECT().WriteStatusCode(tt.wantStatus),
+				)
+			default:
+				calls = append(calls, mock.EXPECT().WriteStatusCode(tt.wantStatus))
+			}
+			gomock.InOrder(calls...)
+
+			var before float64
+			if tt.wantLogin != "" {
+				before = testutil.ToFloat64(metrics.Logins.WithLabelValues(tt.wantLogin))
+			}
+			if err := (&userHandler{uc: mockUC}).loginMFA(mock); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+			if tt.wantLogin != "" {
+				if got := testutil.ToFloat64(metrics.Logins.WithLabelValues(tt.wantLogin)); got != before+1 {
+					t.Errorf("ログイン数不一致 got: %#v want: %#v", got, before+1)
+				}
+			}
+		})
+	}
+}
+
+func Test_userHandler_member(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type args struct {
+		c handlerctx.APIContext
+	}
+	tests := []struct {
+		name    string
+		h       *userHandler
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "正常ケース(取得は認証なし)",
+			h: &userHandler{
+				uc: func() *mock_usecase.MockUser {
+					mock := mock_usecase.NewMockUser(ctrl)
+					mock.EXPECT().FindByID(gomock.Any(), "1").Return(&dto.User{ID: "1"}, nil)
+					return mock
+				}(),
+			},
+			args: args{
+				c: func() *mock_handlerctx.MockAPIContext {
+					mock := newMockAPIContext(ctrl)
+					gomock.InOrder(
+						mock.EXPECT().RequestMethod().Return(http.MethodGet),
+						mock.EXPECT().PathParam().Return("1"),
+						mock.EXPECT().WriteResponseJSON(http.StatusOK, &dto.PublicUser{ID: "1"}).Return(nil),
+					)
+					return mock
+				}(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常ケース(更新は認証あり)",
+			h: &userHandler{
+				authMiddleware: func() *mock_middleware.MockAuth {
+					mock := mock_middleware.NewMockAuth(ctrl)
+					mock.EXPECT().VerifyAuth(gomock.Any()).Return(func(c handlerctx.APIContext) error {
+						c.WriteStatusCode(http.StatusUnauthorized)
+						return nil
+					})
+					return mock
+				}(),
+			},
+			args: args{
+				c: func() *mock_handlerctx.MockAPIContext {
+					mock := newMockAPIContext(ctrl)
+					gomock.InOrder(
+						mock.EXPECT().RequestMethod().Return(http.MethodPut),
+						mock.EXPECT().WriteStatusCode(http.StatusUnauthorized),
+					)
+					return mock
+				}(),
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.h.member(tt.args.c); (err != nil) != tt.wantErr {
+				t.Errorf("userHandler.member() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_userHandler_show(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type args struct {
+		c handlerctx.APIContext
+	}
+	tests := []struct {
+		name    string
+		h       *userHandler
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "正常ケース",
+			h: &userHandler{
+				uc: func() *mock_usecase.MockUser {
+					mock := mock_usecase.NewMockUser(ctrl)
+					mock.EXPECT().FindByID(gomock.Any(), "1").Return(&dto.User{
+						ID:          "1",
+						Name:        "name",
+						Email:       "email",
+						Password:    "password",
+						Salt:        "salt",
+						DisplayName: "display",
+					}, nil)
+					return mock
+				}(),
+			},
+			args: args{
+				c: func() *mock_handlerctx.MockAPIContext {
+					mock := newMockAPIContext(ctrl)
+					gomock.InOrder(
+						mock.EXPECT().PathParam().Return("1"),
+						mock.EXPECT().WriteResponseJSON(http.StatusOK, &dto.PublicUser{
+							ID:          "1",
+							Name:        "name",
+							DisplayName: "display",
+						}).Return(nil),
+					)
+					return mock
+				}(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "異常ケース(パスパラメータなし)",
+			args: args{
+				c: func() *mock_handlerctx.MockAPIContext {
+					mock := newMockAPIContext(ctrl)
+					gomock.InOrder(
+						mock.EXPECT().PathParam().Return(""),
+						mock.EXPECT().WriteStatusCode(http.StatusBadRequest),
+					)
+					return mock
+				}(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "異常ケース(ユーザーが未登録)",
+			h: &userHandler{
+				uc: func() *mock_usecase.MockUser {
+					mock := mock_usecase.NewMockUser(ctrl)
+					mock.EXPECT().FindByID(gomock.Any(), "1").Return(nil, service.ErrUserNotFound)
+					return mock
+				}(),
+			},
+			args: args{
+				c: func() *mock_handlerctx.MockAPIContext {
+					mock := newMockAPIContext(ctrl)
+					gomock.InOrder(
+						mock.EXPECT().PathParam().Return("1"),
+						mock.EXPECT().WriteStatusCode(http.StatusNotFound),
+					)
+					return mock
+				}(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "異常ケース(ユーザー取得失敗)",
+			h: &userHandler{
+				uc: func() *mock_usecase.MockUser {
+					mock := mock_usecase.NewMockUser(ctrl)
+					mock.EXPECT().FindByID(gomock.Any(), "1").Return(nil, errors.New("ng"))
+					return mock
+				}(),
+			},
+			args: args{
+				c: func() *mock_handlerctx.MockAPIContext {
+					mock := newMockAPIContext(ctrl)
+					gomock.InOrder(
+						mock.EXPECT().PathParam().Return("1"),
+						mock.EXPECT().WriteStatusCode(http.StatusInternalServerError),
+					)
+					return mock
+				}(),
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.h.show(tt.args.c); (err != nil) != tt.wantErr {
+				t.Errorf("userHandler.show() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_userHandler_me(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type args struct {
+		c handlerctx.APIContext
+	}
+	tests := []struct {
+		name    string
+		h       *userHandler
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "正常ケース",
+			h: &userHandler{
+				uc: func() *mock_usecase.MockUser {
+					mock := mock_usecase.NewMockUser(ctrl)
+					mock.EXPECT().FindByID(gomock.Any(), "1").Return(&dto.User{
+						ID:       "1",
+						Name:     "name",
+						Email:    "email",
+						Password: "password",
+						Salt:     "salt",
+					}, nil)
+					return mock
+				}(),
+			},
+			args: args{
+				c: func() *mock_handlerctx.MockAPIContext {
+					mock := newMockAPIContext(ctrl)
+					gomock.InOrder(
+						mock.EXPECT().RequestMethod().Return(http.MethodGet),
+						mock.EXPECT().UserID().Return("1"),
+						mock.EXPECT().WriteResponseJSON(http.StatusOK, &dto.PrivateUser{
+							PublicUser: dto.PublicUser{ID: "1", Name: "name"},
+							Email:      "email",
+						}).Return(nil),
+					)
+					return mock
+				}(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "異常ケース(メソッド不正)",
+			args: args{
+				c: func() *mock_handlerctx.MockAPIContext {
+					mock := newMockAPIContext(ctrl)
+					gomock.InOrder(
+						mock.EXPECT().RequestMethod().Return(http.MethodPost),
+						mock.EXPECT().WriteStatusCode(http.StatusMethodNotAllowed),
+					)
+					return mock
+				}(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "異常ケース(ユーザーが未登録)",
+			h: &userHandler{
+				uc: func() *mock_usecase.MockUser {
+					mock := mock_usecase.NewMockUser(ctrl)
+					mock.EXPECT().FindByID(gomock.Any(), "1").Return(nil, service.ErrUserNotFound)
+					return mock
+				}(),
+			},
+			args: args{
+				c: func() *mock_handlerctx.MockAPIContext {
+					mock := newMockAPIContext(ctrl)
+					gomock.InOrder(
+						mock.EXPECT().RequestMethod().Return(http.MethodGet),
+						mock.EXPECT().UserID().Return("1"),
+						mock.EXPECT().WriteStatusCode(http.StatusNotFound),
+					)
+					return mock
+				}(),
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.h.me(tt.args.c); (err != nil) != tt.wantErr {
+				t.Errorf("userHandler.me() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_userHandler_logout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cookies := middleware.ExpiredSessionCookies()
+
+	tests := []struct {
+		name  string
+		calls func(mock *mock_handlerctx.MockAPIContext, sessionUC *mock_usecase.MockSession) []*gomock.Call
+	}{
+		{
+			name: "正常ケース",
+			calls: func(mock *mock_handlerctx.MockAPIContext, sessionUC *mock_usecase.MockSession) []*gomock.Call {
+				return []*gomock.Call{
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().UserID().Return("1"),
+					mock.EXPECT().SessionID().Return("s1"),
+					sessionUC.EXPECT().Revoke(gomock.Any(), "1", "s1", gomock.Any()).Return(nil),
+					mock.EXPECT().SetCookie(cookies[0]),
+					mock.EXPECT().SetCookie(cookies[1]),
+					mock.EXPECT().WriteStatusCode(http.StatusNoContent),
+				}
+			},
+		},
+		{
+			name: "正常ケース(失効済みのセッション)",
+			calls: func(mock *mock_handlerctx.MockAPIContext, sessionUC *mock_usecase.MockSession) []*gomock.Call {
+				return []*gomock.Call{
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().UserID().Return("1"),
+					mock.EXPECT().SessionID().Return("s1"),
+					sessionUC.EXPECT().Revoke(gomock.Any(), "1", "s1", gomock.Any()).Return(service.ErrSessionNotFound),
+					mock.EXPECT().SetCookie(cookies[0]),
+					mock.EXPECT().SetCookie(cookies[1]),
+					mock.EXPECT().WriteStatusCode(http.StatusNoContent),
+				}
+			},
+		},
+		{
+			name: "異常ケース(メソッド不正)",
+			calls: func(mock *mock_handlerctx.MockAPIContext, sessionUC *mock_usecase.MockSession) []*gomock.Call {
+				return []*gomock.Call{
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().WriteStatusCode(http.StatusMethodNotAllowed),
+				}
+			},
+		},
+		{
+			name: "異常ケース(失効失敗)",
+			calls: func(mock *mock_handlerctx.MockAPIContext, sessionUC *mock_usecase.MockSession) []*gomock.Call {
+				return []*gomock.Call{
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().UserID().Return("1"),
+					mock.EXPECT().SessionID().Return("s1"),
+					sessionUC.EXPECT().Revoke(gomock.Any(), "1", "s1", gomock.Any()).Return(errors.New("test")),
+					mock.EXPECT().WriteStatusCode(http.StatusInternalServerError),
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newMockAPIContext(ctrl)
+			sessionUC := mock_usecase.NewMockSession(ctrl)
+			gomock.InOrder(tt.calls(mock, sessionUC)...)
+			h := &userHandler{sessionUC: sessionUC}
+			if err := h.logout(mock); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
 		})
 	}
 }
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/service"
+	"GoBBS/dto"
+	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/interface/middleware"
+	"GoBBS/interface/middleware/middlewarehelper"
+	"GoBBS/usecase"
+)
+
+type webhookHandler struct {
+	uc           usecase.Webhook
+	userUC       usecase.User
+	adminUserIDs []string
+}
+
+// NewWebhookHandler Webhookハンドラーを生成する(管理者のみ操作できる)
+func NewWebhookHandler(webhookUseCase usecase.Webhook, userUseCase usecase.User, adminUserIDs []string) *webhookHandler {
+	return &webhookHandler{
+		uc:           webhookUseCase,
+		userUC:       userUseCase,
+		adminUserIDs: adminUserIDs,
+	}
+}
+
+// RegistHandlerFunc ハンドラー登録
+func (h *webhookHandler) RegistHandlerFunc() {
+	http.HandleFunc(
+		"/admin/boards/",
+		middlewarehelper.Apply(
+			handlerctx.NewAPIContext,
+			h.boardWebhooks,
+			middleware.NewAuth(h.userUC).VerifyAuth,
+			middleware.NewAdmin(h.adminUserIDs).VerifyAdmin,
+			middleware.NewPathParam("/admin/boards/:id/webhooks").Parse,
+		),
+	)
+
+	http.HandleFunc(
+		"/admin/webhooks/",
+		middlewarehelper.Apply(
+			handlerctx.NewAPIContext,
+			h.webhook,
+			middleware.NewAuth(h.userUC).VerifyAuth,
+			middleware.NewAdmin(h.adminUserIDs).VerifyAdmin,
+		),
+	)
+}
+
+// boardWebhooks 板のWebhookの一覧取得(GET)、登録(POST)
+func (h *webhookHandler) boardWebhooks(c handlerctx.APIContext) error {
+	switch c.RequestMethod() {
+	case http.MethodGet:
+		webhooks, err := h.uc.List(c.RequestContext(), c.PathParam())
+		if err != nil {
+			slog.ErrorContext(c.RequestContext(), "webhook list error", "error", err)
+			c.WriteStatusCode(http.StatusInternalServerError)
+			return nil
+		}
+		return c.WriteResponseJSON(http.StatusOK, webhooks)
+	case http.MethodPost:
+		var req dto.WebhookRequest
+		if err := json.NewDecoder(c.RequestBody()).Decode(&req); err != nil {
+			c.WriteStatusCode(http.StatusBadRequest)
+			return nil
+		}
+		webhook, err := h.uc.Regist(c.RequestContext(), c.PathParam(), &req, time.Now())
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidWebhookURL) || errors.Is(err, service.ErrInvalidWebhookEvent) {
+				c.WriteStatusCode(http.StatusBadRequest)
+				return nil
+			}
+			slog.ErrorContext(c.RequestContext(), "webhook regist error", "error", err)
+			c.WriteStatusCode(http.StatusInternalServerError)
+			return nil
+		}
+		return c.WriteResponseJSON(http.StatusCreated, webhook)
+	default:
+		c.WriteStatusCode(http.StatusMethodNotAllowed)
+		return nil
+	}
+}
+
+// webhook Webhookの削除(/admin/webhooks/:id)と配信ログの取得(/admin/webhooks/:id/deliveries)の振り分け
+// パスパラメータミドルウェアはパスの階層数が一致するパターンしか扱えないため、ここでパターンを選ぶ
+func (h *webhookHandler) webhook(c handlerctx.APIContext) error {
+	if strings.HasSuffix(c.URL().Path, "/deliveries") {
+		return middleware.NewPathParam("/admin/webhooks/:id/deliveries").Parse(h.deliveries)(c)
+	}
+	return middleware.NewPathParam("/admin/webhooks/:id").Parse(h.delete)(c)
+}
+
+// delete Webhookの削除
+func (h *webhookHandler) delete(c handlerctx.APIContext) error {
+	if c.RequestMethod() != http.MethodDelete {
+		c.WriteStatusCode(http.StatusMethodNotAllowed)
+		return nil
+	}
+
+	if err := h.uc.Delete(c.RequestContext(), c.PathParam()); err != nil {
+		if errors.Is(err, service.ErrWebhookNotFound) {
+			c.WriteStatusCode(http.StatusNotFound)
+			return nil
+		}
+		slog.ErrorContext(c.RequestContext(), "webhook delete error", "error", err)
+		c.WriteStatusCode(http.StatusInternalServerError)
+		return nil
+	}
+
+	c.WriteStatusCode(http.StatusNoContent)
+	return nil
+}
+
+// deliveries 配信ログの取得(beforeより古い配信をlimit件まで新しい順に返す)
+func (h *webhookHandler) deliveries(c handlerctx.APIContext) error {
+	if c.RequestMethod() != http.MethodGet {
+		c.WriteStatusCode(http.StatusMethodNotAllowed)
+		return nil
+	}
+
+	query := c.URL().Query()
+	limit := 0
+	if v := query.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			c.WriteStatusCode(http.StatusBadRequest)
+			return nil
+		}
+		limit = n
+	}
+
+	deliveries, err := h.uc.Deliveries(c.RequestContext(), c.PathParam(), query.Get("before"), limit)
+	if err != nil {
+		if errors.Is(err, service.ErrWebhookNotFound) {
+			c.WriteStatusCode(http.StatusNotFound)
+			return nil
+		}
+		slog.ErrorContext(c.RequestContext(), "webhook deliveries error", "error", err)
+		c.WriteStatusCode(http.StatusInternalServerError)
+		return nil
+	}
+
+	return c.WriteResponseJSON(http.StatusOK, deliveries)
+}
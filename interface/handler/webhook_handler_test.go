@@ -0,0 +1,424 @@
+package handler
+
+import (
+	"GoBBS/domain/service"
+	"GoBBS/dto"
+	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/mock/mock_usecase"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewWebhookHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUC := mock_usecase.NewMockWebhook(ctrl)
+	mockUserUC := mock_usecase.NewMockUser(ctrl)
+
+	want := &webhookHandler{uc: mockUC, userUC: mockUserUC, adminUserIDs: []string{"1"}}
+	if got := NewWebhookHandler(mockUC, mockUserUC, []string{"1"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewWebhookHandler() = %v, want %v", got, want)
+	}
+}
+
+func Test_webhookHandler_RegistHandlerFunc(t *testing.T) {
+	h := &webhookHandler{}
+	h.RegistHandlerFunc()
+}
+
+func Test_webhookHandler_boardWebhooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	webhooks := []*dto.Webhook{{ID: "1", BoardID: "2", URL: "https://example.com/hook", Events: []string{"post.created"}}}
+	created := &dto.Webhook{ID: "1", BoardID: "2", URL: "https://example.com/hook", Events: []string{"post.created"}, Secret: "secret"}
+	body := `{"url":"https://example.com/hook","events":["post.created"]}`
+	req := &dto.WebhookRequest{URL: "https://example.com/hook", Events: []string{"post.created"}}
+
+	tests := []struct {
+		name string
+		h    *webhookHandler
+		c    func() handlerctx.APIContext
+	}{
+		{
+			name: "正常ケース(一覧取得)",
+			h: &webhookHandler{
+				uc: func() *mock_usecase.MockWebhook {
+					mock := mock_usecase.NewMockWebhook(ctrl)
+					mock.EXPECT().List(gomock.Any(), "2").Return(webhooks, nil)
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().PathParam().Return("2"),
+					mock.EXPECT().WriteResponseJSON(http.StatusOK, webhooks),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(一覧取得失敗)",
+			h: &webhookHandler{
+				uc: func() *mock_usecase.MockWebhook {
+					mock := mock_usecase.NewMockWebhook(ctrl)
+					mock.EXPECT().List(gomock.Any(), "2").Return(nil, errors.New("test"))
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().PathParam().Return("2"),
+					mock.EXPECT().WriteStatusCode(http.StatusInternalServerError),
+				)
+				return mock
+			},
+		},
+		{
+			name: "正常ケース(登録)",
+			h: &webhookHandler{
+				uc: func() *mock_usecase.MockWebhook {
+					mock := mock_usecase.NewMockWebhook(ctrl)
+					mock.EXPECT().Regist(gomock.Any(), "2", req, gomock.Any()).Return(created, nil)
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().RequestBody().Return(io.NopCloser(strings.NewReader(body))),
+					mock.EXPECT().PathParam().Return("2"),
+					mock.EXPECT().WriteResponseJSON(http.StatusCreated, created),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(JSON不正)",
+			h:    &webhookHandler{},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().RequestBody().Return(io.NopCloser(strings.NewReader(`{`))),
+					mock.EXPECT().WriteStatusCode(http.StatusBadRequest),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(URL不正)",
+			h: &webhookHandler{
+				uc: func() *mock_usecase.MockWebhook {
+					mock := mock_usecase.NewMockWebhook(ctrl)
+					mock.EXPECT().Regist(gomock.Any(), "2", req, gomock.Any()).Return(nil, service.ErrInvalidWebhookURL)
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().RequestBody().Return(io.NopCloser(strings.NewReader(body))),
+					mock.EXPECT().PathParam().Return("2"),
+					mock.EXPECT().WriteStatusCode(http.StatusBadRequest),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(イベント不正)",
+			h: &webhookHandler{
+				uc: func() *mock_usecase.MockWebhook {
+					mock := mock_usecase.NewMockWebhook(ctrl)
+					mock.EXPECT().Regist(gomock.Any(), "2", req, gomock.Any()).Return(nil, service.ErrInvalidWebhookEvent)
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().RequestBody().Return(io.NopCloser(strings.NewReader(body))),
+					mock.EXPECT().PathParam().Return("2"),
+					mock.EXPECT().WriteStatusCode(http.StatusBadRequest),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(登録失敗)",
+			h: &webhookHandler{
+				uc: func() *mock_usecase.MockWebhook {
+					mock := mock_usecase.NewMockWebhook(ctrl)
+					mock.EXPECT().Regist(gomock.Any(), "2", req, gomock.Any()).Return(nil, errors.New("test"))
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().RequestBody().Return(io.NopCloser(strings.NewReader(body))),
+					mock.EXPECT().PathParam().Return("2"),
+					mock.EXPECT().WriteStatusCode(http.StatusInternalServerError),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(メソッド不正)",
+			h:    &webhookHandler{},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodDelete),
+					mock.EXPECT().WriteStatusCode(http.StatusMethodNotAllowed),
+				)
+				return mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.h.boardWebhooks(tt.c()); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+}
+
+func Test_webhookHandler_webhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	deliveries := []*dto.WebhookDelivery{}
+
+	tests := []struct {
+		name string
+		h    *webhookHandler
+		c    func() handlerctx.APIContext
+	}{
+		{
+			name: "正常ケース(削除)",
+			h: &webhookHandler{
+				uc: func() *mock_usecase.MockWebhook {
+					mock := mock_usecase.NewMockWebhook(ctrl)
+					mock.EXPECT().Delete(gomock.Any(), "1").Return(nil)
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				u := &url.URL{Path: "/admin/webhooks/1"}
+				mock.EXPECT().URL().Return(u).AnyTimes()
+				gomock.InOrder(
+					mock.EXPECT().SetPathParam("1"),
+					mock.EXPECT().RequestMethod().Return(http.MethodDelete),
+					mock.EXPECT().PathParam().Return("1"),
+					mock.EXPECT().WriteStatusCode(http.StatusNoContent),
+				)
+				return mock
+			},
+		},
+		{
+			name: "正常ケース(配信ログ)",
+			h: &webhookHandler{
+				uc: func() *mock_usecase.MockWebhook {
+					mock := mock_usecase.NewMockWebhook(ctrl)
+					mock.EXPECT().Deliveries(gomock.Any(), "1", "", 0).Return(deliveries, nil)
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				u := &url.URL{Path: "/admin/webhooks/1/deliveries"}
+				mock.EXPECT().URL().Return(u).AnyTimes()
+				gomock.InOrder(
+					mock.EXPECT().SetPathParam("1"),
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().PathParam().Return("1"),
+					mock.EXPECT().WriteResponseJSON(http.StatusOK, deliveries),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(パス不正)",
+			h:    &webhookHandler{},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				u := &url.URL{Path: "/admin/webhooks/1/unknown"}
+				mock.EXPECT().URL().Return(u).AnyTimes()
+				mock.EXPECT().WriteStatusCode(http.StatusBadRequest)
+				return mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.h.webhook(tt.c()); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+}
+
+func Test_webhookHandler_delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "正常ケース", err: nil, status: http.StatusNoContent},
+		{name: "異常ケース(Webhookなし)", err: service.ErrWebhookNotFound, status: http.StatusNotFound},
+		{name: "異常ケース(削除失敗)", err: errors.New("test"), status: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := mock_usecase.NewMockWebhook(ctrl)
+			uc.EXPECT().Delete(gomock.Any(), "1").Return(tt.err)
+			c := newMockAPIContext(ctrl)
+			gomock.InOrder(
+				c.EXPECT().RequestMethod().Return(http.MethodDelete),
+				c.EXPECT().PathParam().Return("1"),
+				c.EXPECT().WriteStatusCode(tt.status),
+			)
+
+			if err := (&webhookHandler{uc: uc}).delete(c); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+
+	c := newMockAPIContext(ctrl)
+	gomock.InOrder(
+		c.EXPECT().RequestMethod().Return(http.MethodGet),
+		c.EXPECT().WriteStatusCode(http.StatusMethodNotAllowed),
+	)
+	if err := (&webhookHandler{}).delete(c); err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+}
+
+func Test_webhookHandler_deliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	deliveries := []*dto.WebhookDelivery{{ID: "3", Event: "post.created", Status: "succeeded"}}
+
+	tests := []struct {
+		name string
+		h    *webhookHandler
+		c    func() handlerctx.APIContext
+	}{
+		{
+			name: "正常ケース",
+			h: &webhookHandler{
+				uc: func() *mock_usecase.MockWebhook {
+					mock := mock_usecase.NewMockWebhook(ctrl)
+					mock.EXPECT().Deliveries(gomock.Any(), "1", "5", 20).Return(deliveries, nil)
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().URL().Return(&url.URL{Path: "/admin/webhooks/1/deliveries", RawQuery: "before=5&limit=20"}),
+					mock.EXPECT().PathParam().Return("1"),
+					mock.EXPECT().WriteResponseJSON(http.StatusOK, deliveries),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(メソッド不正)",
+			h:    &webhookHandler{},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().WriteStatusCode(http.StatusMethodNotAllowed),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(件数不正)",
+			h:    &webhookHandler{},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().URL().Return(&url.URL{Path: "/admin/webhooks/1/deliveries", RawQuery: "limit=x"}),
+					mock.EXPECT().WriteStatusCode(http.StatusBadRequest),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(Webhookなし)",
+			h: &webhookHandler{
+				uc: func() *mock_usecase.MockWebhook {
+					mock := mock_usecase.NewMockWebhook(ctrl)
+					mock.EXPECT().Deliveries(gomock.Any(), "1", "", 0).Return(nil, service.ErrWebhookNotFound)
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().URL().Return(&url.URL{Path: "/admin/webhooks/1/deliveries"}),
+					mock.EXPECT().PathParam().Return("1"),
+					mock.EXPECT().WriteStatusCode(http.StatusNotFound),
+				)
+				return mock
+			},
+		},
+		{
+			name: "異常ケース(取得失敗)",
+			h: &webhookHandler{
+				uc: func() *mock_usecase.MockWebhook {
+					mock := mock_usecase.NewMockWebhook(ctrl)
+					mock.EXPECT().Deliveries(gomock.Any(), "1", "", 0).Return(nil, errors.New("test"))
+					return mock
+				}(),
+			},
+			c: func() handlerctx.APIContext {
+				mock := newMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().URL().Return(&url.URL{Path: "/admin/webhooks/1/deliveries"}),
+					mock.EXPECT().PathParam().Return("1"),
+					mock.EXPECT().WriteStatusCode(http.StatusInternalServerError),
+				)
+				return mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.h.deliveries(tt.c()); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+}
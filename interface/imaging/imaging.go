@@ -0,0 +1,152 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"golang.org/x/image/draw"
+)
+
+// Image 検証済みの画像
+type Image struct {
+	ContentType string
+	Width       int
+	Height      int
+	image       image.Image
+}
+
+// Limits 画像の制限
+type Limits struct {
+	MaxWidth  int
+	MaxHeight int
+	// MaxPixels 総画素数の上限(デコード後のメモリ量を抑える、0は無制限)
+	MaxPixels int
+}
+
+var (
+	ErrUnsupportedType = errors.New("unsupported image type")
+	ErrTooLarge        = errors.New("image dimensions too large")
+)
+
+// supportedTypes 受け付けるコンテントタイプ
+var supportedTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+// Load データの先頭からコンテントタイプを判定し、寸法を検証してデコードする
+func Load(data []byte, limits Limits) (*Image, error) {
+	// 拡張子やリクエストヘッダは信用せず内容から判定する
+	contentType := http.DetectContentType(data)
+	if !supportedTypes[contentType] {
+		return nil, ErrUnsupportedType
+	}
+
+	// デコード前にヘッダのみで寸法を確認し、巨大な画像の展開を避ける
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrap(ErrUnsupportedType, err.Error())
+	}
+	if config.Width <= 0 || config.Height <= 0 ||
+		config.Width > limits.MaxWidth || config.Height > limits.MaxHeight ||
+		(limits.MaxPixels > 0 && config.Width*config.Height > limits.MaxPixels) {
+		return nil, ErrTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrap(ErrUnsupportedType, err.Error())
+	}
+
+	return &Image{
+		ContentType: contentType,
+		Width:       config.Width,
+		Height:      config.Height,
+		image:       img,
+	}, nil
+}
+
+// Thumbnail 長辺がsize以下になるよう縮小した画像をエンコードして返す
+// JPEGはJPEG、それ以外はPNGでエンコードする
+func (i *Image) Thumbnail(size int) ([]byte, string, error) {
+	width, height := fit(i.Width, i.Height, size)
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), i.image, i.image.Bounds(), draw.Over, nil)
+
+	var buf bytes.Buffer
+	if i.ContentType == "image/jpeg" {
+		if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 85}); err != nil {
+			return nil, "", errors.Wrap(err, "Thumbnail error")
+		}
+		return buf.Bytes(), "image/jpeg", nil
+	}
+
+	if err := png.Encode(&buf, dst); err != nil {
+		return nil, "", errors.Wrap(err, "Thumbnail error")
+	}
+	return buf.Bytes(), "image/png", nil
+}
+
+// Encode デコードした画像を元の形式で再エンコードして返す
+// アップロードされたデータのEXIF(位置情報など)やコメントなどのメタデータを保存しないために使う
+// GIFは先頭のフレームのみエンコードする
+func (i *Image) Encode() ([]byte, string, error) {
+	var buf bytes.Buffer
+	switch i.ContentType {
+	case "image/jpeg":
+		if err := jpeg.Encode(&buf, i.image, &jpeg.Options{Quality: 90}); err != nil {
+			return nil, "", errors.Wrap(err, "Encode error")
+		}
+	case "image/gif":
+		if err := gif.Encode(&buf, i.image, nil); err != nil {
+			return nil, "", errors.Wrap(err, "Encode error")
+		}
+	default:
+		if err := png.Encode(&buf, i.image); err != nil {
+			return nil, "", errors.Wrap(err, "Encode error")
+		}
+	}
+	return buf.Bytes(), i.ContentType, nil
+}
+
+// Extension コンテントタイプに対応する拡張子を返す
+func Extension(contentType string) string {
+	switch contentType {
+	case "image/jpeg":
+		return ".jpg"
+	case "image/png":
+		return ".png"
+	case "image/gif":
+		return ".gif"
+	default:
+		return ""
+	}
+}
+
+// fit アスペクト比を保って長辺がsize以下になる寸法を返す(拡大はしない)
+func fit(width int, height int, size int) (int, int) {
+	if width <= size && height <= size {
+		return width, height
+	}
+
+	if width >= height {
+		h := height * size / width
+		if h < 1 {
+			h = 1
+		}
+		return size, h
+	}
+
+	w := width * size / height
+	if w < 1 {
+		w = 1
+	}
+	return w, size
+}
@@ -0,0 +1,232 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func encodePNG(t *testing.T, width int, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("PNGのエンコードに失敗(error: %s)", err)
+	}
+	return buf.Bytes()
+}
+
+func encodeJPEG(t *testing.T, width int, height int) []byte {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height)), nil); err != nil {
+		t.Fatalf("JPEGのエンコードに失敗(error: %s)", err)
+	}
+	return buf.Bytes()
+}
+
+func TestLoad(t *testing.T) {
+	limits := Limits{MaxWidth: 100, MaxHeight: 100, MaxPixels: 5000}
+
+	tests := []struct {
+		name            string
+		data            []byte
+		wantContentType string
+		wantWidth       int
+		wantHeight      int
+		wantErr         error
+	}{
+		{
+			name:            "正常ケース(PNG)",
+			data:            encodePNG(t, 40, 20),
+			wantContentType: "image/png",
+			wantWidth:       40,
+			wantHeight:      20,
+		},
+		{
+			name:            "正常ケース(JPEG)",
+			data:            encodeJPEG(t, 10, 30),
+			wantContentType: "image/jpeg",
+			wantWidth:       10,
+			wantHeight:      30,
+		},
+		{
+			name:    "異常ケース(画像以外)",
+			data:    []byte("<html><script>alert(1)</script></html>"),
+			wantErr: ErrUnsupportedType,
+		},
+		{
+			name:    "異常ケース(寸法超過)",
+			data:    encodePNG(t, 101, 10),
+			wantErr: ErrTooLarge,
+		},
+		{
+			name:    "異常ケース(総画素数超過)",
+			data:    encodePNG(t, 100, 60),
+			wantErr: ErrTooLarge,
+		},
+		{
+			name:    "異常ケース(壊れた画像)",
+			data:    encodePNG(t, 10, 10)[:40],
+			wantErr: ErrUnsupportedType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Load(tt.data, limits)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.ContentType != tt.wantContentType || got.Width != tt.wantWidth || got.Height != tt.wantHeight {
+				t.Errorf("Load() = (%s, %d, %d), want (%s, %d, %d)",
+					got.ContentType, got.Width, got.Height,
+					tt.wantContentType, tt.wantWidth, tt.wantHeight)
+			}
+		})
+	}
+}
+
+func TestImage_Thumbnail(t *testing.T) {
+	tests := []struct {
+		name            string
+		data            []byte
+		size            int
+		wantContentType string
+		wantWidth       int
+		wantHeight      int
+	}{
+		{
+			name:            "縮小(横長PNG)",
+			data:            encodePNG(t, 80, 40),
+			size:            20,
+			wantContentType: "image/png",
+			wantWidth:       20,
+			wantHeight:      10,
+		},
+		{
+			name:            "縮小(縦長JPEG)",
+			data:            encodeJPEG(t, 30, 90),
+			size:            30,
+			wantContentType: "image/jpeg",
+			wantWidth:       10,
+			wantHeight:      30,
+		},
+		{
+			name:            "拡大しない",
+			data:            encodePNG(t, 8, 4),
+			size:            20,
+			wantContentType: "image/png",
+			wantWidth:       8,
+			wantHeight:      4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img, err := Load(tt.data, Limits{MaxWidth: 1000, MaxHeight: 1000})
+			if err != nil {
+				t.Fatalf("予期せぬエラー(error: %s)", err)
+			}
+
+			got, contentType, err := img.Thumbnail(tt.size)
+			if err != nil {
+				t.Fatalf("Thumbnail() error = %v", err)
+			}
+			if contentType != tt.wantContentType {
+				t.Errorf("Thumbnail() contentType = %v, want %v", contentType, tt.wantContentType)
+			}
+
+			config, _, err := image.DecodeConfig(bytes.NewReader(got))
+			if err != nil {
+				t.Fatalf("サムネイルのデコードに失敗(error: %s)", err)
+			}
+			if config.Width != tt.wantWidth || config.Height != tt.wantHeight {
+				t.Errorf("Thumbnail() = %dx%d, want %dx%d", config.Width, config.Height, tt.wantWidth, tt.wantHeight)
+			}
+		})
+	}
+}
+
+// withEXIF JPEGの先頭(SOIの直後)にEXIFのAPP1セグメントを挿入する
+func withEXIF(data []byte, payload string) []byte {
+	body := append([]byte("Exif\x00\x00"), payload...)
+	segment := []byte{0xFF, 0xE1, byte((len(body) + 2) >> 8), byte(len(body) + 2)}
+	segment = append(segment, body...)
+	return append(append(append([]byte{}, data[:2]...), segment...), data[2:]...)
+}
+
+func TestImage_Encode(t *testing.T) {
+	tests := []struct {
+		name            string
+		data            []byte
+		wantContentType string
+	}{
+		{
+			name:            "正常ケース(JPEGのEXIFを除く)",
+			data:            withEXIF(encodeJPEG(t, 30, 20), "GPS 35.6812N 139.7671E"),
+			wantContentType: "image/jpeg",
+		},
+		{
+			name:            "正常ケース(PNG)",
+			data:            encodePNG(t, 30, 20),
+			wantContentType: "image/png",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img, err := Load(tt.data, Limits{MaxWidth: 100, MaxHeight: 100})
+			if err != nil {
+				t.Fatalf("予期せぬエラー(error: %s)", err)
+			}
+
+			got, contentType, err := img.Encode()
+			if err != nil {
+				t.Fatalf("予期せぬエラー(error: %s)", err)
+			}
+			if contentType != tt.wantContentType {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", contentType, tt.wantContentType)
+			}
+			if bytes.Contains(got, []byte("Exif")) || bytes.Contains(got, []byte("GPS")) {
+				t.Errorf("メタデータが残っている")
+			}
+
+			config, _, err := image.DecodeConfig(bytes.NewReader(got))
+			if err != nil {
+				t.Fatalf("再エンコードした画像のデコードに失敗(error: %s)", err)
+			}
+			if config.Width != 30 || config.Height != 20 {
+				t.Errorf("Encode() = %dx%d, want 30x20", config.Width, config.Height)
+			}
+		})
+	}
+}
+
+func TestExtension(t *testing.T) {
+	tests := []struct {
+		contentType string
+		want        string
+	}{
+		{contentType: "image/jpeg", want: ".jpg"},
+		{contentType: "image/png", want: ".png"},
+		{contentType: "image/gif", want: ".gif"},
+		{contentType: "text/html", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.contentType, func(t *testing.T) {
+			if got := Extension(tt.contentType); got != tt.want {
+				t.Errorf("Extension() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
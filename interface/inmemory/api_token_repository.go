@@ -0,0 +1,122 @@
+package inmemory
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+// APITokenRepository インメモリの個人用アクセストークンリポジトリ
+type APITokenRepository struct {
+	mu     sync.RWMutex
+	tokens map[string]model.APIToken
+}
+
+var _ repository.APIToken = (*APITokenRepository)(nil)
+
+// NewAPITokenRepository インメモリの個人用アクセストークンリポジトリを生成する
+func NewAPITokenRepository() *APITokenRepository {
+	return &APITokenRepository{
+		tokens: make(map[string]model.APIToken),
+	}
+}
+
+// Regist トークンを登録する
+func (r *APITokenRepository) Regist(token model.APIToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tokens[token.ID()] = token
+
+	return nil
+}
+
+// FindByHash ハッシュからトークンを取得する
+func (r *APITokenRepository) FindByHash(hash string) (model.APIToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, t := range r.tokens {
+		if t.Hash() == hash {
+			return t, nil
+		}
+	}
+
+	return nil, repository.ErrAPITokenNotFound
+}
+
+// FindActiveByUserID ユーザーの有効なトークンを発行の新しい順に取得する
+func (r *APITokenRepository) FindActiveByUserID(userID string, now time.Time) ([]model.APIToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tokens := []model.APIToken{}
+	for _, t := range r.tokens {
+		if t.UserID() == userID && t.IsValid(now) {
+			tokens = append(tokens, t)
+		}
+	}
+	sort.Slice(tokens, func(i, j int) bool {
+		a, b := tokens[i], tokens[j]
+		if !a.CreatedAt().Equal(b.CreatedAt()) {
+			return a.CreatedAt().After(b.CreatedAt())
+		}
+		return a.ID() > b.ID()
+	})
+
+	return tokens, nil
+}
+
+// Touch 最終利用日時を更新する
+func (r *APITokenRepository) Touch(id string, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, ok := r.tokens[id]
+	if !ok {
+		return nil
+	}
+	r.tokens[id] = model.NewAPIToken(
+		t.ID(),
+		t.UserID(),
+		t.Name(),
+		t.Hash(),
+		t.Scopes(),
+		t.ExpiresAt(),
+		now,
+		t.CreatedAt(),
+	)
+
+	return nil
+}
+
+// Delete ユーザーのトークンを削除する
+func (r *APITokenRepository) Delete(userID string, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, ok := r.tokens[id]
+	if !ok || t.UserID() != userID {
+		return repository.ErrAPITokenNotFound
+	}
+	delete(r.tokens, id)
+
+	return nil
+}
+
+// DeleteExpired ユーザーの有効期限切れのトークンを削除する
+func (r *APITokenRepository) DeleteExpired(userID string, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, t := range r.tokens {
+		if t.UserID() == userID && !t.IsValid(now) {
+			delete(r.tokens, id)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,13 @@
+package inmemory
+
+import (
+	"GoBBS/domain/repository"
+	"GoBBS/domain/repository/repositorytest"
+	"testing"
+)
+
+func TestAPITokenRepository_Contract(t *testing.T) {
+	repositorytest.RunAPITokenContract(t, func(t *testing.T) (repository.APIToken, string, string) {
+		return NewAPITokenRepository(), "1", "2"
+	})
+}
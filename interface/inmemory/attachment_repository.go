@@ -0,0 +1,60 @@
+package inmemory
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+// AttachmentRepository インメモリの添付ファイルリポジトリ
+type AttachmentRepository struct {
+	mu          sync.RWMutex
+	lastID      int
+	attachments map[string]model.Attachment
+}
+
+var _ repository.Attachment = (*AttachmentRepository)(nil)
+
+// NewAttachmentRepository インメモリの添付ファイルリポジトリを生成する
+func NewAttachmentRepository() *AttachmentRepository {
+	return &AttachmentRepository{
+		attachments: make(map[string]model.Attachment),
+	}
+}
+
+// FindByID IDを指定して添付ファイルを取得する
+func (r *AttachmentRepository) FindByID(id string) (model.Attachment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if attachment, ok := r.attachments[id]; ok {
+		return attachment, nil
+	}
+	return nil, repository.ErrAttachmentNotFound
+}
+
+// Regist 添付ファイルを登録し、採番したIDを返す
+func (r *AttachmentRepository) Regist(attachment model.Attachment, now time.Time) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+	id := strconv.Itoa(r.lastID)
+	r.attachments[id] = model.NewAttachment(
+		id,
+		attachment.UserID(),
+		attachment.PostID(),
+		attachment.BlobKey(),
+		attachment.ThumbnailKey(),
+		attachment.ContentType(),
+		attachment.Size(),
+		attachment.Width(),
+		attachment.Height(),
+		now,
+	)
+
+	return id, nil
+}
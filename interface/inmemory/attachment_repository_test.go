@@ -0,0 +1,13 @@
+package inmemory
+
+import (
+	"GoBBS/domain/repository"
+	"GoBBS/domain/repository/repositorytest"
+	"testing"
+)
+
+func TestAttachmentRepository_Contract(t *testing.T) {
+	repositorytest.RunAttachmentContract(t, func(t *testing.T) (repository.Attachment, string) {
+		return NewAttachmentRepository(), "1"
+	})
+}
@@ -0,0 +1,70 @@
+package inmemory
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+// BoardRepository インメモリの板リポジトリ
+type BoardRepository struct {
+	mu     sync.RWMutex
+	lastID int
+	boards map[string]model.Board
+}
+
+var _ repository.Board = (*BoardRepository)(nil)
+
+// NewBoardRepository インメモリの板リポジトリを生成する
+func NewBoardRepository() *BoardRepository {
+	return &BoardRepository{
+		boards: make(map[string]model.Board),
+	}
+}
+
+// FindAll 板を登録順に取得する
+func (r *BoardRepository) FindAll() ([]model.Board, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	boards := make([]model.Board, 0, len(r.boards))
+	for _, b := range r.boards {
+		boards = append(boards, b)
+	}
+	sort.Slice(boards, func(i, j int) bool {
+		a, _ := strconv.Atoi(boards[i].ID())
+		b, _ := strconv.Atoi(boards[j].ID())
+		return a < b
+	})
+
+	return boards, nil
+}
+
+// FindByID IDで板を取得する
+func (r *BoardRepository) FindByID(id string) (model.Board, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	b, ok := r.boards[id]
+	if !ok {
+		return nil, repository.ErrBoardNotFound
+	}
+
+	return b, nil
+}
+
+// Regist 板を登録し、採番したIDを返す
+func (r *BoardRepository) Regist(board model.Board, now time.Time) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+	id := strconv.Itoa(r.lastID)
+	r.boards[id] = model.NewBoard(id, board.Name(), board.Description(), now)
+
+	return id, nil
+}
@@ -0,0 +1,13 @@
+package inmemory
+
+import (
+	"GoBBS/domain/repository"
+	"GoBBS/domain/repository/repositorytest"
+	"testing"
+)
+
+func TestBoardRepository_Contract(t *testing.T) {
+	repositorytest.RunBoardContract(t, func(t *testing.T) repository.Board {
+		return NewBoardRepository()
+	})
+}
@@ -0,0 +1,174 @@
+package inmemory
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+// DigestRepository インメモリのウォッチ・ダイジェストリポジトリ
+type DigestRepository struct {
+	mu            sync.RWMutex
+	watches       map[watchKey]model.Watch
+	subscriptions map[string]model.DigestSubscription
+	deliveries    map[deliveryKey]time.Time
+}
+
+// watchKey ウォッチの一意キー
+type watchKey struct {
+	userID   string
+	kind     model.WatchKind
+	targetID string
+}
+
+// deliveryKey 送信記録の一意キー
+type deliveryKey struct {
+	userID    string
+	periodKey string
+}
+
+var _ repository.Digest = (*DigestRepository)(nil)
+
+// NewDigestRepository インメモリのウォッチ・ダイジェストリポジトリを生成する
+func NewDigestRepository() *DigestRepository {
+	return &DigestRepository{
+		watches:       make(map[watchKey]model.Watch),
+		subscriptions: make(map[string]model.DigestSubscription),
+		deliveries:    make(map[deliveryKey]time.Time),
+	}
+}
+
+// RegistWatch ウォッチを登録する(登録済みの場合は何もしない)
+func (r *DigestRepository) RegistWatch(watch model.Watch) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := watchKey{userID: watch.UserID(), kind: watch.Kind(), targetID: watch.TargetID()}
+	if _, ok := r.watches[key]; !ok {
+		r.watches[key] = watch
+	}
+
+	return nil
+}
+
+// DeleteWatch ウォッチを削除する
+func (r *DigestRepository) DeleteWatch(userID string, kind model.WatchKind, targetID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := watchKey{userID: userID, kind: kind, targetID: targetID}
+	if _, ok := r.watches[key]; !ok {
+		return repository.ErrWatchNotFound
+	}
+	delete(r.watches, key)
+
+	return nil
+}
+
+// FindWatchesByUserID ユーザーのウォッチを登録順に取得する
+func (r *DigestRepository) FindWatchesByUserID(userID string) ([]model.Watch, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	watches := []model.Watch{}
+	for _, w := range r.watches {
+		if w.UserID() == userID {
+			watches = append(watches, w)
+		}
+	}
+	sort.Slice(watches, func(i, j int) bool {
+		if !watches[i].CreatedAt().Equal(watches[j].CreatedAt()) {
+			return watches[i].CreatedAt().Before(watches[j].CreatedAt())
+		}
+		if watches[i].Kind() != watches[j].Kind() {
+			return watches[i].Kind() < watches[j].Kind()
+		}
+		return watches[i].TargetID() < watches[j].TargetID()
+	})
+
+	return watches, nil
+}
+
+// FindSubscription 配信設定を取得する
+func (r *DigestRepository) FindSubscription(userID string) (model.DigestSubscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	subscription, ok := r.subscriptions[userID]
+	if !ok {
+		return model.DigestSubscription{}, repository.ErrDigestSubscriptionNotFound
+	}
+
+	return subscription, nil
+}
+
+// FindSubscriptionByToken 配信停止トークンから配信設定とユーザーのIDを取得する
+func (r *DigestRepository) FindSubscriptionByToken(token string) (string, model.DigestSubscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for userID, subscription := range r.subscriptions {
+		if token != "" && subscription.UnsubscribeToken == token {
+			return userID, subscription, nil
+		}
+	}
+
+	return "", model.DigestSubscription{}, repository.ErrDigestSubscriptionNotFound
+}
+
+// SaveSubscription 配信設定を保存する
+func (r *DigestRepository) SaveSubscription(userID string, subscription model.DigestSubscription, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.subscriptions[userID] = subscription
+
+	return nil
+}
+
+// FindDueUserIDs 配信頻度が一致し、集計期間のダイジェストが未送信のユーザーのIDを取得する
+func (r *DigestRepository) FindDueUserIDs(frequency model.DigestFrequency, periodKey string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	userIDs := []string{}
+	for userID, subscription := range r.subscriptions {
+		if subscription.Frequency != frequency {
+			continue
+		}
+		if _, sent := r.deliveries[deliveryKey{userID: userID, periodKey: periodKey}]; sent {
+			continue
+		}
+		userIDs = append(userIDs, userID)
+	}
+	sort.Strings(userIDs)
+
+	return userIDs, nil
+}
+
+// RegistDelivery 送信記録を登録する(登録済みの場合はfalse)
+func (r *DigestRepository) RegistDelivery(userID string, periodKey string, now time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := deliveryKey{userID: userID, periodKey: periodKey}
+	if _, ok := r.deliveries[key]; ok {
+		return false, nil
+	}
+	r.deliveries[key] = now
+
+	return true, nil
+}
+
+// DeleteDelivery 送信記録を削除する
+func (r *DigestRepository) DeleteDelivery(userID string, periodKey string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.deliveries, deliveryKey{userID: userID, periodKey: periodKey})
+
+	return nil
+}
@@ -0,0 +1,13 @@
+package inmemory
+
+import (
+	"GoBBS/domain/repository"
+	"GoBBS/domain/repository/repositorytest"
+	"testing"
+)
+
+func TestDigestRepository_Contract(t *testing.T) {
+	repositorytest.RunDigestContract(t, func(t *testing.T) (repository.Digest, string, string) {
+		return NewDigestRepository(), "1", "2"
+	})
+}
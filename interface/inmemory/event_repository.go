@@ -0,0 +1,98 @@
+package inmemory
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+// EventRepository インメモリのイベントアウトボックス
+type EventRepository struct {
+	mu     sync.RWMutex
+	lastID int
+	events map[string]model.Event
+}
+
+var _ repository.Event = (*EventRepository)(nil)
+
+// NewEventRepository インメモリのイベントアウトボックスを生成する
+func NewEventRepository() *EventRepository {
+	return &EventRepository{
+		events: make(map[string]model.Event),
+	}
+}
+
+// Regist イベントを登録し、採番したIDを返す
+func (r *EventRepository) Regist(event model.Event) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+	id := strconv.Itoa(r.lastID)
+	r.events[id] = model.NewEvent(
+		id,
+		event.Type(),
+		event.AggregateID(),
+		event.Payload(),
+		event.Attempts(),
+		event.NextAttemptAt(),
+		"",
+		event.OccurredAt(),
+		time.Time{},
+	)
+
+	return id, nil
+}
+
+// FindDue 配信時刻に達した未配信のイベントを取得する
+func (r *EventRepository) FindDue(now time.Time, limit int) ([]model.Event, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	events := []model.Event{}
+	for _, e := range r.events {
+		if !e.IsDispatched() && !e.NextAttemptAt().After(now) {
+			events = append(events, e)
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		a, b := events[i], events[j]
+		if !a.NextAttemptAt().Equal(b.NextAttemptAt()) {
+			return a.NextAttemptAt().Before(b.NextAttemptAt())
+		}
+		return numericID(a.ID()) < numericID(b.ID())
+	})
+	if len(events) > limit {
+		events = events[:limit]
+	}
+
+	return events, nil
+}
+
+// Update イベントの配信状態を更新する
+func (r *EventRepository) Update(event model.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e, ok := r.events[event.ID()]
+	if !ok {
+		return nil
+	}
+	r.events[e.ID()] = model.NewEvent(
+		e.ID(),
+		e.Type(),
+		e.AggregateID(),
+		e.Payload(),
+		event.Attempts(),
+		event.NextAttemptAt(),
+		event.LastError(),
+		e.OccurredAt(),
+		event.DispatchedAt(),
+	)
+
+	return nil
+}
@@ -0,0 +1,13 @@
+package inmemory
+
+import (
+	"GoBBS/domain/repository"
+	"GoBBS/domain/repository/repositorytest"
+	"testing"
+)
+
+func TestEventRepository_Contract(t *testing.T) {
+	repositorytest.RunEventContract(t, func(t *testing.T) repository.Event {
+		return NewEventRepository()
+	})
+}
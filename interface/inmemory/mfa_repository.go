@@ -0,0 +1,167 @@
+package inmemory
+
+import (
+	"sync"
+	"time"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+// MFARepository インメモリの2要素認証リポジトリ
+type MFARepository struct {
+	mu            sync.RWMutex
+	mfas          map[string]model.MFA
+	recoveryCodes map[string]map[string]bool
+	challenges    map[string]model.MFAChallenge
+}
+
+var _ repository.MFA = (*MFARepository)(nil)
+
+// NewMFARepository インメモリの2要素認証リポジトリを生成する
+func NewMFARepository() *MFARepository {
+	return &MFARepository{
+		mfas:          make(map[string]model.MFA),
+		recoveryCodes: make(map[string]map[string]bool),
+		challenges:    make(map[string]model.MFAChallenge),
+	}
+}
+
+// FindByUserID ユーザーの2要素認証の設定を取得する
+func (r *MFARepository) FindByUserID(userID string) (model.MFA, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	m, ok := r.mfas[userID]
+	if !ok {
+		return nil, repository.ErrMFANotFound
+	}
+
+	return m, nil
+}
+
+// Save 2要素認証の設定を登録または更新する
+func (r *MFARepository) Save(mfa model.MFA) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.mfas[mfa.UserID()] = mfa
+
+	return nil
+}
+
+// UpdateLastUsedStep 最後に使われた確認コードのステップを更新する
+// 同じか古いステップで更新しようとした場合はErrMFACodeUsedを返す
+func (r *MFARepository) UpdateLastUsedStep(userID string, step int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m, ok := r.mfas[userID]
+	if !ok || m.LastUsedStep() >= step {
+		return repository.ErrMFACodeUsed
+	}
+	r.mfas[userID] = model.NewMFA(m.UserID(), m.Secret(), m.ConfirmedAt(), step, m.CreatedAt())
+
+	return nil
+}
+
+// Delete 2要素認証の設定とリカバリーコードを削除する
+func (r *MFARepository) Delete(userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.mfas, userID)
+	delete(r.recoveryCodes, userID)
+
+	return nil
+}
+
+// ReplaceRecoveryCodes ユーザーのリカバリーコード(ハッシュ)を入れ替える
+func (r *MFARepository) ReplaceRecoveryCodes(userID string, hashes []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	codes := make(map[string]bool, len(hashes))
+	for _, h := range hashes {
+		codes[h] = false
+	}
+	r.recoveryCodes[userID] = codes
+
+	return nil
+}
+
+// UseRecoveryCode 未使用のリカバリーコードを使用済みにする
+func (r *MFARepository) UseRecoveryCode(userID string, hash string, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	used, ok := r.recoveryCodes[userID][hash]
+	if !ok || used {
+		return repository.ErrRecoveryCodeNotFound
+	}
+	r.recoveryCodes[userID][hash] = true
+
+	return nil
+}
+
+// RegistChallenge チャレンジを登録する
+func (r *MFARepository) RegistChallenge(challenge model.MFAChallenge) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.challenges[challenge.ID()] = challenge
+
+	return nil
+}
+
+// FindChallenge IDからチャレンジを取得する
+func (r *MFARepository) FindChallenge(id string) (model.MFAChallenge, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c, ok := r.challenges[id]
+	if !ok {
+		return nil, repository.ErrMFAChallengeNotFound
+	}
+
+	return c, nil
+}
+
+// ConsumeChallengeAttempt 有効なチャレンジの試行回数を1増やす
+// 試行回数が上限に達した、または有効期限切れのチャレンジはErrMFAChallengeNotFoundを返す
+func (r *MFARepository) ConsumeChallengeAttempt(id string, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, ok := r.challenges[id]
+	if !ok || !c.IsValid(now) {
+		return repository.ErrMFAChallengeNotFound
+	}
+	r.challenges[id] = model.NewMFAChallenge(c.ID(), c.UserID(), c.Attempts()+1, c.ExpiresAt(), c.CreatedAt())
+
+	return nil
+}
+
+// DeleteChallenge チャレンジを削除する
+func (r *MFARepository) DeleteChallenge(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.challenges, id)
+
+	return nil
+}
+
+// DeleteExpiredChallenges ユーザーの有効期限切れのチャレンジを削除する
+func (r *MFARepository) DeleteExpiredChallenges(userID string, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, c := range r.challenges {
+		if c.UserID() == userID && !now.Before(c.ExpiresAt()) {
+			delete(r.challenges, id)
+		}
+	}
+
+	return nil
+}
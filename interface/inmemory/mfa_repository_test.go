@@ -0,0 +1,13 @@
+package inmemory
+
+import (
+	"GoBBS/domain/repository"
+	"GoBBS/domain/repository/repositorytest"
+	"testing"
+)
+
+func TestMFARepository_Contract(t *testing.T) {
+	repositorytest.RunMFAContract(t, func(t *testing.T) (repository.MFA, string, string) {
+		return NewMFARepository(), "1", "2"
+	})
+}
@@ -0,0 +1,144 @@
+package inmemory
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+// NotificationRepository インメモリの通知リポジトリ
+type NotificationRepository struct {
+	mu            sync.RWMutex
+	lastID        int
+	notifications map[string]model.Notification
+	preferences   map[string]model.NotificationPreferences
+}
+
+var _ repository.Notification = (*NotificationRepository)(nil)
+
+// NewNotificationRepository インメモリの通知リポジトリを生成する
+func NewNotificationRepository() *NotificationRepository {
+	return &NotificationRepository{
+		notifications: make(map[string]model.Notification),
+		preferences:   make(map[string]model.NotificationPreferences),
+	}
+}
+
+// FindByUserID ユーザーの通知を新しい順に取得する(beforeIDを指定した場合はそれより前のもの)
+func (r *NotificationRepository) FindByUserID(userID string, beforeID string, limit int) ([]model.Notification, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	before, _ := strconv.Atoi(beforeID)
+	notifications := []model.Notification{}
+	for _, n := range r.notifications {
+		id, _ := strconv.Atoi(n.ID())
+		if n.UserID() == userID && (before == 0 || id < before) {
+			notifications = append(notifications, n)
+		}
+	}
+	sort.Slice(notifications, func(i, j int) bool {
+		a, _ := strconv.Atoi(notifications[i].ID())
+		b, _ := strconv.Atoi(notifications[j].ID())
+		return a > b
+	})
+	if len(notifications) > limit {
+		notifications = notifications[:limit]
+	}
+
+	return notifications, nil
+}
+
+// CountUnread ユーザーの未読の通知数を返す
+func (r *NotificationRepository) CountUnread(userID string) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for _, n := range r.notifications {
+		if n.UserID() == userID && !n.IsRead() {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// Regist 通知を登録し、採番したIDを返す
+func (r *NotificationRepository) Regist(notification model.Notification, now time.Time) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+	id := strconv.Itoa(r.lastID)
+	r.notifications[id] = model.NewNotification(
+		id,
+		notification.UserID(),
+		notification.Kind(),
+		notification.ActorID(),
+		notification.TargetID(),
+		time.Time{},
+		now,
+	)
+
+	return id, nil
+}
+
+// MarkRead ユーザーの通知を既読にする
+func (r *NotificationRepository) MarkRead(userID string, id string, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n, ok := r.notifications[id]
+	if !ok || n.UserID() != userID {
+		return repository.ErrNotificationNotFound
+	}
+	if !n.IsRead() {
+		r.notifications[id] = markRead(n, now)
+	}
+
+	return nil
+}
+
+// MarkAllRead ユーザーの通知をすべて既読にする
+func (r *NotificationRepository) MarkAllRead(userID string, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, n := range r.notifications {
+		if n.UserID() == userID && !n.IsRead() {
+			r.notifications[id] = markRead(n, now)
+		}
+	}
+
+	return nil
+}
+
+// FindPreferences 受信設定を取得する(未設定の場合はデフォルト)
+func (r *NotificationRepository) FindPreferences(userID string) (model.NotificationPreferences, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if preferences, ok := r.preferences[userID]; ok {
+		return preferences, nil
+	}
+	return model.DefaultNotificationPreferences(), nil
+}
+
+// SavePreferences 受信設定を保存する
+func (r *NotificationRepository) SavePreferences(userID string, preferences model.NotificationPreferences, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.preferences[userID] = preferences
+	return nil
+}
+
+// markRead 既読にした通知を返す
+func markRead(n model.Notification, now time.Time) model.Notification {
+	return model.NewNotification(n.ID(), n.UserID(), n.Kind(), n.ActorID(), n.TargetID(), now, n.CreatedAt())
+}
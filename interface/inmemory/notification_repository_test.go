@@ -0,0 +1,13 @@
+package inmemory
+
+import (
+	"GoBBS/domain/repository"
+	"GoBBS/domain/repository/repositorytest"
+	"testing"
+)
+
+func TestNotificationRepository_Contract(t *testing.T) {
+	repositorytest.RunNotificationContract(t, func(t *testing.T) (repository.Notification, string, string) {
+		return NewNotificationRepository(), "1", "2"
+	})
+}
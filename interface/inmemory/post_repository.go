@@ -0,0 +1,117 @@
+package inmemory
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+// PostRepository インメモリの投稿リポジトリ
+type PostRepository struct {
+	mu     sync.RWMutex
+	lastID int
+	posts  map[string]model.Post
+}
+
+var _ repository.Post = (*PostRepository)(nil)
+
+// NewPostRepository インメモリの投稿リポジトリを生成する
+func NewPostRepository() *PostRepository {
+	return &PostRepository{
+		posts: make(map[string]model.Post),
+	}
+}
+
+// FindByThreadID スレッドの投稿を番号順に取得する(afterNumberより後のもの)
+func (r *PostRepository) FindByThreadID(threadID string, afterNumber int, limit int) ([]model.Post, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	posts := []model.Post{}
+	for _, p := range r.posts {
+		if p.ThreadID() == threadID && p.Number() > afterNumber {
+			posts = append(posts, p)
+		}
+	}
+	sort.Slice(posts, func(i, j int) bool {
+		return posts[i].Number() < posts[j].Number()
+	})
+	if len(posts) > limit {
+		posts = posts[:limit]
+	}
+
+	return posts, nil
+}
+
+// FindByID IDで投稿を取得する
+func (r *PostRepository) FindByID(id string) (model.Post, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	p, ok := r.posts[id]
+	if !ok {
+		return nil, repository.ErrPostNotFound
+	}
+
+	return p, nil
+}
+
+// CountCreated スレッドの非表示でない投稿のうち、作成日時がfrom以上to未満のものを数える
+func (r *PostRepository) CountCreated(threadIDs []string, from time.Time, to time.Time) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for _, p := range r.posts {
+		if slices.Contains(threadIDs, p.ThreadID()) && !p.CreatedAt().Before(from) && p.CreatedAt().Before(to) && !p.IsHidden() {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// Regist 投稿を登録し、採番したIDを返す
+func (r *PostRepository) Regist(post model.Post, now time.Time) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+	id := strconv.Itoa(r.lastID)
+	r.posts[id] = model.NewPost(id, post.ThreadID(), post.UserID(), post.Number(), post.Body(), post.BodyHTML(), now, time.Time{}, time.Time{}, "")
+
+	return id, nil
+}
+
+// Update 投稿の本文を更新し、編集日時を記録する
+func (r *PostRepository) Update(post model.Post, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, ok := r.posts[post.ID()]
+	if !ok {
+		return repository.ErrPostNotFound
+	}
+	r.posts[p.ID()] = model.NewPost(p.ID(), p.ThreadID(), p.UserID(), p.Number(), post.Body(), post.BodyHTML(), p.CreatedAt(), now, p.HiddenAt(), p.HiddenReason())
+
+	return nil
+}
+
+// Hide 投稿を非表示にする(本文は版とともに残す)
+func (r *PostRepository) Hide(id string, reason string, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, ok := r.posts[id]
+	if !ok {
+		return repository.ErrPostNotFound
+	}
+	r.posts[id] = model.NewPost(p.ID(), p.ThreadID(), p.UserID(), p.Number(), p.Body(), p.BodyHTML(), p.CreatedAt(), p.EditedAt(), now, reason)
+
+	return nil
+}
@@ -0,0 +1,13 @@
+package inmemory
+
+import (
+	"GoBBS/domain/repository"
+	"GoBBS/domain/repository/repositorytest"
+	"testing"
+)
+
+func TestPostRepository_Contract(t *testing.T) {
+	repositorytest.RunPostContract(t, func(t *testing.T) (repository.Post, string, string, string) {
+		return NewPostRepository(), "1", "2", "1"
+	})
+}
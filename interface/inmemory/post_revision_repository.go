@@ -0,0 +1,72 @@
+package inmemory
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+// PostRevisionRepository インメモリの投稿の版リポジトリ
+type PostRevisionRepository struct {
+	mu        sync.RWMutex
+	lastID    int
+	revisions map[string]model.PostRevision
+}
+
+var _ repository.PostRevision = (*PostRevisionRepository)(nil)
+
+// NewPostRevisionRepository インメモリの投稿の版リポジトリを生成する
+func NewPostRevisionRepository() *PostRevisionRepository {
+	return &PostRevisionRepository{
+		revisions: make(map[string]model.PostRevision),
+	}
+}
+
+// FindByPostID 投稿の版を古い順に取得する
+func (r *PostRevisionRepository) FindByPostID(postID string) ([]model.PostRevision, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	revisions := []model.PostRevision{}
+	for _, rev := range r.revisions {
+		if rev.PostID() == postID {
+			revisions = append(revisions, rev)
+		}
+	}
+	sort.Slice(revisions, func(i, j int) bool {
+		a, _ := strconv.Atoi(revisions[i].ID())
+		b, _ := strconv.Atoi(revisions[j].ID())
+		return a < b
+	})
+
+	return revisions, nil
+}
+
+// FindByID IDで版を取得する
+func (r *PostRevisionRepository) FindByID(id string) (model.PostRevision, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rev, ok := r.revisions[id]
+	if !ok {
+		return nil, repository.ErrPostRevisionNotFound
+	}
+
+	return rev, nil
+}
+
+// Regist 版を登録し、採番したIDを返す
+func (r *PostRevisionRepository) Regist(revision model.PostRevision, now time.Time) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+	id := strconv.Itoa(r.lastID)
+	r.revisions[id] = model.NewPostRevision(id, revision.PostID(), revision.EditorID(), revision.Body(), now)
+
+	return id, nil
+}
@@ -0,0 +1,13 @@
+package inmemory
+
+import (
+	"GoBBS/domain/repository"
+	"GoBBS/domain/repository/repositorytest"
+	"testing"
+)
+
+func TestPostRevisionRepository_Contract(t *testing.T) {
+	repositorytest.RunPostRevisionContract(t, func(t *testing.T) (repository.PostRevision, string, string, string) {
+		return NewPostRevisionRepository(), "1", "2", "1"
+	})
+}
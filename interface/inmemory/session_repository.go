@@ -0,0 +1,132 @@
+package inmemory
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+// SessionRepository インメモリのセッションリポジトリ
+type SessionRepository struct {
+	mu       sync.RWMutex
+	sessions map[string]model.Session
+}
+
+var _ repository.Session = (*SessionRepository)(nil)
+
+// NewSessionRepository インメモリのセッションリポジトリを生成する
+func NewSessionRepository() *SessionRepository {
+	return &SessionRepository{
+		sessions: make(map[string]model.Session),
+	}
+}
+
+// Regist セッションを登録する
+func (r *SessionRepository) Regist(session model.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sessions[session.ID()] = session
+
+	return nil
+}
+
+// FindByID IDからセッションを取得する
+func (r *SessionRepository) FindByID(id string) (model.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	session, ok := r.sessions[id]
+	if !ok {
+		return nil, repository.ErrSessionNotFound
+	}
+
+	return session, nil
+}
+
+// FindActiveByUserID ユーザーの有効なセッションをログインの新しい順に取得する
+func (r *SessionRepository) FindActiveByUserID(userID string, now time.Time) ([]model.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sessions := []model.Session{}
+	for _, s := range r.sessions {
+		if s.UserID() == userID && s.IsActive(now) {
+			sessions = append(sessions, s)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		a, b := sessions[i], sessions[j]
+		if !a.CreatedAt().Equal(b.CreatedAt()) {
+			return a.CreatedAt().After(b.CreatedAt())
+		}
+		return a.ID() > b.ID()
+	})
+
+	return sessions, nil
+}
+
+// Touch 最終利用日時を更新する
+func (r *SessionRepository) Touch(id string, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, ok := r.sessions[id]
+	if !ok {
+		return nil
+	}
+	r.sessions[id] = model.NewSession(
+		s.ID(),
+		s.UserID(),
+		s.Device(),
+		s.IP(),
+		s.UserAgent(),
+		s.CreatedAt(),
+		now,
+		s.ExpiresAt(),
+		s.RevokedAt(),
+	)
+
+	return nil
+}
+
+// Revoke ユーザーの失効していないセッションを失効させる
+func (r *SessionRepository) Revoke(userID string, id string, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, ok := r.sessions[id]
+	if !ok || s.UserID() != userID || !s.RevokedAt().IsZero() {
+		return repository.ErrSessionNotFound
+	}
+	r.sessions[id] = model.NewSession(
+		s.ID(),
+		s.UserID(),
+		s.Device(),
+		s.IP(),
+		s.UserAgent(),
+		s.CreatedAt(),
+		s.LastSeenAt(),
+		s.ExpiresAt(),
+		now,
+	)
+
+	return nil
+}
+
+// DeleteExpired ユーザーの有効期限切れのセッションを削除する
+func (r *SessionRepository) DeleteExpired(userID string, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, s := range r.sessions {
+		if s.UserID() == userID && !now.Before(s.ExpiresAt()) {
+			delete(r.sessions, id)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,13 @@
+package inmemory
+
+import (
+	"GoBBS/domain/repository"
+	"GoBBS/domain/repository/repositorytest"
+	"testing"
+)
+
+func TestSessionRepository_Contract(t *testing.T) {
+	repositorytest.RunSessionContract(t, func(t *testing.T) (repository.Session, string, string) {
+		return NewSessionRepository(), "1", "2"
+	})
+}
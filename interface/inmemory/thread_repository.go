@@ -0,0 +1,112 @@
+package inmemory
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+// ThreadRepository インメモリのスレッドリポジトリ
+type ThreadRepository struct {
+	mu      sync.RWMutex
+	lastID  int
+	threads map[string]model.Thread
+}
+
+var _ repository.Thread = (*ThreadRepository)(nil)
+
+// NewThreadRepository インメモリのスレッドリポジトリを生成する
+func NewThreadRepository() *ThreadRepository {
+	return &ThreadRepository{
+		threads: make(map[string]model.Thread),
+	}
+}
+
+// FindByBoardID 板のスレッドを新しい順に取得する(beforeIDを指定した場合はそれより前のもの)
+func (r *ThreadRepository) FindByBoardID(boardID string, beforeID string, limit int) ([]model.Thread, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	before, _ := strconv.Atoi(beforeID)
+	threads := []model.Thread{}
+	for _, t := range r.threads {
+		id, _ := strconv.Atoi(t.ID())
+		if t.BoardID() == boardID && (before == 0 || id < before) {
+			threads = append(threads, t)
+		}
+	}
+	sort.Slice(threads, func(i, j int) bool {
+		a, _ := strconv.Atoi(threads[i].ID())
+		b, _ := strconv.Atoi(threads[j].ID())
+		return a > b
+	})
+	if len(threads) > limit {
+		threads = threads[:limit]
+	}
+
+	return threads, nil
+}
+
+// FindByID IDでスレッドを取得する
+func (r *ThreadRepository) FindByID(id string) (model.Thread, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	t, ok := r.threads[id]
+	if !ok {
+		return nil, repository.ErrThreadNotFound
+	}
+
+	return t, nil
+}
+
+// FindPostedSince 板のスレッドのうち最終投稿日時がsince以降のものをID順に取得する
+func (r *ThreadRepository) FindPostedSince(boardID string, since time.Time) ([]model.Thread, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	threads := []model.Thread{}
+	for _, t := range r.threads {
+		if t.BoardID() == boardID && !t.LastPostedAt().Before(since) {
+			threads = append(threads, t)
+		}
+	}
+	sort.Slice(threads, func(i, j int) bool {
+		a, _ := strconv.Atoi(threads[i].ID())
+		b, _ := strconv.Atoi(threads[j].ID())
+		return a < b
+	})
+
+	return threads, nil
+}
+
+// Regist 投稿のないスレッドを登録し、採番したIDを返す
+func (r *ThreadRepository) Regist(thread model.Thread, now time.Time) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+	id := strconv.Itoa(r.lastID)
+	r.threads[id] = model.NewThread(id, thread.BoardID(), thread.UserID(), thread.Title(), 0, now, now)
+
+	return id, nil
+}
+
+// AddPost スレッドの投稿数を増やして最終投稿日時を更新し、追加する投稿の番号を返す
+func (r *ThreadRepository) AddPost(id string, now time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, ok := r.threads[id]
+	if !ok {
+		return 0, repository.ErrThreadNotFound
+	}
+	number := t.PostCount() + 1
+	r.threads[id] = model.NewThread(t.ID(), t.BoardID(), t.UserID(), t.Title(), number, now, t.CreatedAt())
+
+	return number, nil
+}
@@ -0,0 +1,13 @@
+package inmemory
+
+import (
+	"GoBBS/domain/repository"
+	"GoBBS/domain/repository/repositorytest"
+	"testing"
+)
+
+func TestThreadRepository_Contract(t *testing.T) {
+	repositorytest.RunThreadContract(t, func(t *testing.T) (repository.Thread, string, string, string) {
+		return NewThreadRepository(), "1", "2", "1"
+	})
+}
@@ -0,0 +1,66 @@
+package inmemory
+
+import (
+	"sync"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+// UserIdentityRepository インメモリの外部アカウントリポジトリ
+type UserIdentityRepository struct {
+	mu         sync.RWMutex
+	identities map[userIdentityKey]model.UserIdentity
+}
+
+// userIdentityKey 外部アカウントを識別するキー
+type userIdentityKey struct {
+	issuer  string
+	subject string
+}
+
+var _ repository.UserIdentity = (*UserIdentityRepository)(nil)
+
+// NewUserIdentityRepository インメモリの外部アカウントリポジトリを生成する
+func NewUserIdentityRepository() *UserIdentityRepository {
+	return &UserIdentityRepository{
+		identities: make(map[userIdentityKey]model.UserIdentity),
+	}
+}
+
+// FindByIssuerSubject 発行者とsubjectから外部アカウントを取得する
+func (r *UserIdentityRepository) FindByIssuerSubject(issuer string, subject string) (model.UserIdentity, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	identity, ok := r.identities[userIdentityKey{issuer: issuer, subject: subject}]
+	if !ok {
+		return nil, repository.ErrUserIdentityNotFound
+	}
+
+	return identity, nil
+}
+
+// Regist 外部アカウントを登録する
+func (r *UserIdentityRepository) Regist(identity model.UserIdentity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.identities[userIdentityKey{issuer: identity.Issuer(), subject: identity.Subject()}] = identity
+
+	return nil
+}
+
+// ExistsByUserID ユーザーに紐づく外部アカウントがあるか判定する
+func (r *UserIdentityRepository) ExistsByUserID(userID string) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, identity := range r.identities {
+		if identity.UserID() == userID {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
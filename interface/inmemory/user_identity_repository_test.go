@@ -0,0 +1,13 @@
+package inmemory
+
+import (
+	"GoBBS/domain/repository"
+	"GoBBS/domain/repository/repositorytest"
+	"testing"
+)
+
+func TestUserIdentityRepository_Contract(t *testing.T) {
+	repositorytest.RunUserIdentityContract(t, func(t *testing.T) (repository.UserIdentity, string, string) {
+		return NewUserIdentityRepository(), "1", "2"
+	})
+}
@@ -0,0 +1,144 @@
+package inmemory
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+// UserRepository インメモリのユーザーリポジトリ
+type UserRepository struct {
+	mu     sync.RWMutex
+	lastID int
+	users  map[string]model.User
+}
+
+var _ repository.User = (*UserRepository)(nil)
+
+// ErrDuplicateEmail メールアドレス重複エラー(DBの一意制約違反に相当)
+var ErrDuplicateEmail = errors.New("duplicate email")
+
+// NewUserRepository インメモリのユーザーリポジトリを生成する
+func NewUserRepository() *UserRepository {
+	return &UserRepository{
+		users: make(map[string]model.User),
+	}
+}
+
+// FindByID IDを指定してユーザーを取得する
+func (r *UserRepository) FindByID(id string) (model.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if user, ok := r.users[id]; ok {
+		return user, nil
+	}
+	return nil, repository.ErrUserNotFound
+}
+
+// FindByEmail メールアドレスを指定してユーザーを取得する
+func (r *UserRepository) FindByEmail(email string) (model.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if user := r.findByEmail(email); user != nil {
+		return user, nil
+	}
+	return nil, repository.ErrUserNotFound
+}
+
+// FindByName 名前が一致するユーザーをID順にlimit件まで取得する(名前は一意ではない)
+func (r *UserRepository) FindByName(name string, limit int) ([]model.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	users := []model.User{}
+	for _, u := range r.users {
+		if u.Name() == name {
+			users = append(users, u)
+		}
+	}
+	sort.Slice(users, func(i, j int) bool {
+		a, _ := strconv.Atoi(users[i].ID())
+		b, _ := strconv.Atoi(users[j].ID())
+		return a < b
+	})
+	if len(users) > limit {
+		users = users[:limit]
+	}
+
+	return users, nil
+}
+
+// Regist ユーザーを登録する
+func (r *UserRepository) Regist(user model.User, now time.Time) error {
+	cryptPw, salt, err := user.EncryptPassword()
+	if err != nil {
+		return errors.Wrap(err, "Regist error")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.findByEmail(user.Email()) != nil {
+		return errors.Wrap(ErrDuplicateEmail, "Regist error")
+	}
+
+	r.lastID++
+	id := strconv.Itoa(r.lastID)
+	profile := user.Profile()
+	profile.JoinedAt = now
+	r.users[id] = model.NewUserWithProfile(id, user.Name(), user.Email(), cryptPw, salt, profile)
+
+	return nil
+}
+
+// Update ユーザーを更新する
+func (r *UserRepository) Update(user model.User, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	findUser, ok := r.users[user.ID()]
+	if !ok {
+		return nil
+	}
+
+	profile := user.Profile()
+	profile.JoinedAt = findUser.Profile().JoinedAt
+	r.users[user.ID()] = model.NewUserWithProfile(
+		findUser.ID(),
+		user.Name(),
+		findUser.Email(),
+		user.Password(),
+		findUser.Salt(),
+		profile,
+	)
+
+	return nil
+}
+
+// Delete ユーザーを削除する
+func (r *UserRepository) Delete(user model.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.users, user.ID())
+
+	return nil
+}
+
+// findByEmail メールアドレスに一致するユーザーを返す(呼び出し側でロックすること)
+func (r *UserRepository) findByEmail(email string) model.User {
+	for _, user := range r.users {
+		if user.Email() == email {
+			return user
+		}
+	}
+	return nil
+}
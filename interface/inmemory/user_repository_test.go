@@ -0,0 +1,53 @@
+package inmemory
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+	"GoBBS/domain/repository/repositorytest"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestUserRepository_Contract(t *testing.T) {
+	repositorytest.RunUserContract(t, func(t *testing.T) repository.User {
+		return NewUserRepository()
+	})
+}
+
+func TestUserRepository_ConcurrentRegist(t *testing.T) {
+	repo := NewUserRepository()
+
+	const n = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			email := fmt.Sprintf("user%d@example.com", i)
+			errs <- repo.Regist(model.NewUser("", "name", email, "password", ""), time.Now())
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("予期せぬエラー(error: %s)", err)
+		}
+	}
+
+	ids := make(map[string]bool)
+	for i := 0; i < n; i++ {
+		user, err := repo.FindByEmail(fmt.Sprintf("user%d@example.com", i))
+		if err != nil {
+			t.Fatalf("予期せぬエラー(error: %s)", err)
+		}
+		if ids[user.ID()] {
+			t.Errorf("IDが重複している(id: %s)", user.ID())
+		}
+		ids[user.ID()] = true
+	}
+}
@@ -0,0 +1,217 @@
+package inmemory
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/repository"
+)
+
+// WebhookRepository インメモリのWebhookリポジトリ
+type WebhookRepository struct {
+	mu             sync.RWMutex
+	lastID         int
+	lastDeliveryID int
+	webhooks       map[string]model.Webhook
+	deliveries     map[string]model.WebhookDelivery
+	// deliveredEvents 配信を登録済みのWebhookとドメインイベントの組
+	deliveredEvents map[webhookEventKey]bool
+}
+
+// webhookEventKey Webhookとドメインイベントの組
+type webhookEventKey struct {
+	webhookID string
+	eventID   string
+}
+
+var _ repository.Webhook = (*WebhookRepository)(nil)
+
+// NewWebhookRepository インメモリのWebhookリポジトリを生成する
+func NewWebhookRepository() *WebhookRepository {
+	return &WebhookRepository{
+		webhooks:        make(map[string]model.Webhook),
+		deliveries:      make(map[string]model.WebhookDelivery),
+		deliveredEvents: make(map[webhookEventKey]bool),
+	}
+}
+
+// Regist Webhookを登録し、採番したIDを返す
+func (r *WebhookRepository) Regist(webhook model.Webhook) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+	id := strconv.Itoa(r.lastID)
+	r.webhooks[id] = model.NewWebhook(
+		id,
+		webhook.BoardID(),
+		webhook.URL(),
+		webhook.Secret(),
+		webhook.Events(),
+		webhook.CreatedAt(),
+	)
+
+	return id, nil
+}
+
+// FindByID IDからWebhookを取得する
+func (r *WebhookRepository) FindByID(id string) (model.Webhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	webhook, ok := r.webhooks[id]
+	if !ok {
+		return nil, repository.ErrWebhookNotFound
+	}
+
+	return webhook, nil
+}
+
+// FindByBoardID 板のWebhookを登録順に取得する
+func (r *WebhookRepository) FindByBoardID(boardID string) ([]model.Webhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	webhooks := []model.Webhook{}
+	for _, w := range r.webhooks {
+		if w.BoardID() == boardID {
+			webhooks = append(webhooks, w)
+		}
+	}
+	sort.Slice(webhooks, func(i, j int) bool {
+		return numericID(webhooks[i].ID()) < numericID(webhooks[j].ID())
+	})
+
+	return webhooks, nil
+}
+
+// Delete Webhookとその配信を削除する
+func (r *WebhookRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.webhooks[id]; !ok {
+		return repository.ErrWebhookNotFound
+	}
+	delete(r.webhooks, id)
+	for deliveryID, d := range r.deliveries {
+		if d.WebhookID() == id {
+			delete(r.deliveries, deliveryID)
+		}
+	}
+
+	return nil
+}
+
+// RegistDelivery ドメインイベントの配信をアウトボックスに登録し、採番したIDを返す
+// 同じWebhookへの同じイベントの配信が登録済みの場合は登録せずfalseを返す
+func (r *WebhookRepository) RegistDelivery(eventID string, delivery model.WebhookDelivery) (string, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := webhookEventKey{webhookID: delivery.WebhookID(), eventID: eventID}
+	if r.deliveredEvents[key] {
+		return "", false, nil
+	}
+	r.deliveredEvents[key] = true
+
+	r.lastDeliveryID++
+	id := strconv.Itoa(r.lastDeliveryID)
+	r.deliveries[id] = model.NewWebhookDelivery(
+		id,
+		delivery.WebhookID(),
+		delivery.Event(),
+		delivery.Payload(),
+		delivery.Status(),
+		delivery.Attempts(),
+		delivery.NextAttemptAt(),
+		0,
+		"",
+		delivery.CreatedAt(),
+		time.Time{},
+	)
+
+	return id, true, nil
+}
+
+// FindDueDeliveries 送信時刻に達した送信待ちの配信を取得する
+func (r *WebhookRepository) FindDueDeliveries(now time.Time, limit int) ([]model.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	deliveries := []model.WebhookDelivery{}
+	for _, d := range r.deliveries {
+		if d.Status() == model.WebhookDeliveryPending && !d.NextAttemptAt().After(now) {
+			deliveries = append(deliveries, d)
+		}
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		a, b := deliveries[i], deliveries[j]
+		if !a.NextAttemptAt().Equal(b.NextAttemptAt()) {
+			return a.NextAttemptAt().Before(b.NextAttemptAt())
+		}
+		return numericID(a.ID()) < numericID(b.ID())
+	})
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+
+	return deliveries, nil
+}
+
+// UpdateDelivery 配信の状態を更新する
+func (r *WebhookRepository) UpdateDelivery(delivery model.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	d, ok := r.deliveries[delivery.ID()]
+	if !ok {
+		return nil
+	}
+	r.deliveries[d.ID()] = model.NewWebhookDelivery(
+		d.ID(),
+		d.WebhookID(),
+		d.Event(),
+		d.Payload(),
+		delivery.Status(),
+		delivery.Attempts(),
+		delivery.NextAttemptAt(),
+		delivery.LastStatusCode(),
+		delivery.LastError(),
+		d.CreatedAt(),
+		delivery.DeliveredAt(),
+	)
+
+	return nil
+}
+
+// FindDeliveriesByWebhookID Webhookの配信を新しい順に取得する(beforeIDを指定した場合はそれより前のもの)
+func (r *WebhookRepository) FindDeliveriesByWebhookID(webhookID string, beforeID string, limit int) ([]model.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	before := numericID(beforeID)
+	deliveries := []model.WebhookDelivery{}
+	for _, d := range r.deliveries {
+		if d.WebhookID() == webhookID && (before == 0 || numericID(d.ID()) < before) {
+			deliveries = append(deliveries, d)
+		}
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		return numericID(deliveries[i].ID()) > numericID(deliveries[j].ID())
+	})
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+
+	return deliveries, nil
+}
+
+// numericID 数値のIDを整数にする(数値でない場合は0)
+func numericID(id string) int {
+	n, _ := strconv.Atoi(id)
+	return n
+}
@@ -0,0 +1,13 @@
+package inmemory
+
+import (
+	"GoBBS/domain/repository"
+	"GoBBS/domain/repository/repositorytest"
+	"testing"
+)
+
+func TestWebhookRepository_Contract(t *testing.T) {
+	repositorytest.RunWebhookContract(t, func(t *testing.T) repository.Webhook {
+		return NewWebhookRepository()
+	})
+}
@@ -0,0 +1,76 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+type (
+	// contextHandler コンテキストに紐づくリクエストID・トレースIDをログに付与するハンドラー
+	contextHandler struct {
+		slog.Handler
+	}
+
+	// requestIDKey リクエストIDのコンテキストキー
+	requestIDKey struct{}
+)
+
+var _ slog.Handler = contextHandler{}
+
+const (
+	// RequestIDKey ログに出力するリクエストIDのキー
+	RequestIDKey = "request_id"
+	// TraceIDKey ログに出力するトレースIDのキー
+	TraceIDKey = "trace_id"
+	// SpanIDKey ログに出力するスパンIDのキー
+	SpanIDKey = "span_id"
+)
+
+// NewLogger JSON形式で出力する構造化ロガーを生成する
+// ログ出力時のコンテキストにリクエストID・スパンがあれば付与する
+// levelに*slog.LevelVarを渡すと、出力するレベルを後から変更できる
+func NewLogger(w io.Writer, level slog.Leveler) *slog.Logger {
+	return slog.New(contextHandler{
+		Handler: slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level}),
+	})
+}
+
+// Handle リクエストID・トレースIDを付与してログを出力する
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		r.AddAttrs(slog.String(RequestIDKey, id))
+	}
+	if ctx != nil {
+		if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+			r.AddAttrs(slog.String(TraceIDKey, sc.TraceID().String()), slog.String(SpanIDKey, sc.SpanID().String()))
+		}
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs 属性を追加したハンドラーを返す
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup グループを追加したハンドラーを返す
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+// WithRequestID リクエストIDを紐づけたコンテキストを返す
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID コンテキストに紐づくリクエストIDを返す(紐づいていない場合は空文字)
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
@@ -0,0 +1,112 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"reflect"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNewLogger(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	spanCtx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+	}))
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		log  func(*slog.Logger, context.Context)
+		want []map[string]any
+	}{
+		{
+			name: "正常ケース(リクエストIDを付与する)",
+			ctx:  WithRequestID(context.Background(), "abc"),
+			log: func(l *slog.Logger, ctx context.Context) {
+				l.With("component", "test").ErrorContext(ctx, "failed", "error", "ng")
+			},
+			want: []map[string]any{
+				{"level": "ERROR", "msg": "failed", "component": "test", "error": "ng", "request_id": "abc"},
+			},
+		},
+		{
+			name: "正常ケース(グループ内でもリクエストIDを付与する)",
+			ctx:  WithRequestID(context.Background(), "abc"),
+			log: func(l *slog.Logger, ctx context.Context) {
+				l.WithGroup("db").InfoContext(ctx, "query", "rows", 1)
+			},
+			want: []map[string]any{
+				{"level": "INFO", "msg": "query", "db": map[string]any{"rows": float64(1), "request_id": "abc"}},
+			},
+		},
+		{
+			name: "正常ケース(トレースID・スパンIDを付与する)",
+			ctx:  WithRequestID(spanCtx, "abc"),
+			log: func(l *slog.Logger, ctx context.Context) {
+				l.WarnContext(ctx, "slow")
+			},
+			want: []map[string]any{
+				{
+					"level":      "WARN",
+					"msg":        "slow",
+					"request_id": "abc",
+					"trace_id":   "4bf92f3577b34da6a3ce929d0e0e4736",
+					"span_id":    "00f067aa0ba902b7",
+				},
+			},
+		},
+		{
+			name: "正常ケース(リクエストIDなし)",
+			ctx:  context.Background(),
+			log: func(l *slog.Logger, ctx context.Context) {
+				l.InfoContext(ctx, "started")
+			},
+			want: []map[string]any{
+				{"level": "INFO", "msg": "started"},
+			},
+		},
+		{
+			name: "正常ケース(レベル未満は出力しない)",
+			ctx:  context.Background(),
+			log: func(l *slog.Logger, ctx context.Context) {
+				l.DebugContext(ctx, "debug")
+			},
+			want: []map[string]any{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			tt.log(NewLogger(&buf, slog.LevelInfo), tt.ctx)
+
+			got := []map[string]any{}
+			dec := json.NewDecoder(&buf)
+			for dec.More() {
+				var m map[string]any
+				if err := dec.Decode(&m); err != nil {
+					t.Fatalf("予期せぬエラー(error: %s)", err)
+				}
+				delete(m, "time")
+				got = append(got, m)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	if got := RequestID(WithRequestID(context.Background(), "abc")); got != "abc" {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, "abc")
+	}
+	if got := RequestID(context.Background()); got != "" {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, "")
+	}
+}
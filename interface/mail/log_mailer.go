@@ -0,0 +1,23 @@
+package mail
+
+import (
+	"log"
+)
+
+// logMailer メールを送信せずにログに出力する(開発用)
+type logMailer struct {
+	logger *log.Logger
+}
+
+var _ Mailer = (*logMailer)(nil)
+
+// NewLogMailer ログ出力のメール送信を生成する
+func NewLogMailer(logger *log.Logger) *logMailer {
+	return &logMailer{logger: logger}
+}
+
+// Send メールの宛先と件名、本文をログに出力する
+func (m *logMailer) Send(message Message) error {
+	m.logger.Printf("mail to: %s subject: %s\n%s", message.To, message.Subject, message.Body)
+	return nil
+}
@@ -0,0 +1,20 @@
+package mail
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+)
+
+func Test_logMailer_Send(t *testing.T) {
+	var buf bytes.Buffer
+	m := NewLogMailer(log.New(&buf, "", 0))
+
+	if err := m.Send(Message{To: "user@example.com", Subject: "subject", Body: "body"}); err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	if got := buf.String(); !strings.Contains(got, "user@example.com") || !strings.Contains(got, "subject") || !strings.Contains(got, "body") {
+		t.Errorf("ログ出力不一致 got: %#v", got)
+	}
+}
@@ -0,0 +1,83 @@
+package mail
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"mime"
+	"net/mail"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Mailer メール送信
+// mockgen -source interface/mail/mailer.go -destination mock/mock_mail/mailer_mock.go
+type Mailer interface {
+	Send(message Message) error
+}
+
+// Message 送信するメール(本文はプレーンテキスト)
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+	// Headers 追加のヘッダ(List-Unsubscribeなど)
+	Headers map[string]string
+}
+
+var (
+	ErrInvalidAddress = errors.New("invalid mail address")
+	ErrInvalidHeader  = errors.New("invalid mail header")
+)
+
+// base64LineLength 本文をBase64で符号化する際の1行の文字数
+const base64LineLength = 76
+
+// Build 送信元と送信日時を指定して、RFC 5322形式のメールを組み立てる
+func (m Message) Build(from string, date time.Time) ([]byte, error) {
+	if _, err := mail.ParseAddress(from); err != nil {
+		return nil, ErrInvalidAddress
+	}
+	if _, err := mail.ParseAddress(m.To); err != nil {
+		return nil, ErrInvalidAddress
+	}
+
+	headers := map[string]string{
+		"From":                      from,
+		"To":                        m.To,
+		"Subject":                   mime.BEncoding.Encode("UTF-8", m.Subject),
+		"Date":                      date.Format(time.RFC1123Z),
+		"MIME-Version":              "1.0",
+		"Content-Type":              "text/plain; charset=UTF-8",
+		"Content-Transfer-Encoding": "base64",
+	}
+	for k, v := range m.Headers {
+		headers[k] = v
+	}
+
+	keys := make([]string, 0, len(headers))
+	for k, v := range headers {
+		// ヘッダインジェクションを防ぐため改行を含む値は拒否する
+		if k == "" || strings.ContainsAny(k, "\r\n:") || strings.ContainsAny(v, "\r\n") {
+			return nil, ErrInvalidHeader
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	for _, k := range keys {
+		buf.WriteString(k + ": " + headers[k] + "\r\n")
+	}
+	buf.WriteString("\r\n")
+
+	body := base64.StdEncoding.EncodeToString([]byte(m.Body))
+	for len(body) > base64LineLength {
+		buf.WriteString(body[:base64LineLength] + "\r\n")
+		body = body[base64LineLength:]
+	}
+	buf.WriteString(body + "\r\n")
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,105 @@
+package mail
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"io"
+	"mime"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMessage_Build(t *testing.T) {
+	date := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	body := strings.Repeat("ダイジェスト本文", 20)
+
+	got, err := Message{
+		To:      "user@example.com",
+		Subject: "新着のお知らせ",
+		Body:    body,
+		Headers: map[string]string{"List-Unsubscribe": "<https://example.com/u>"},
+	}.Build("GoBBS <noreply@example.com>", date)
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(got))
+	if err != nil {
+		t.Fatalf("メールの解析に失敗(error: %s)", err)
+	}
+	subject, _ := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{name: "From", got: msg.Header.Get("From"), want: "GoBBS <noreply@example.com>"},
+		{name: "To", got: msg.Header.Get("To"), want: "user@example.com"},
+		{name: "Subject", got: subject, want: "新着のお知らせ"},
+		{name: "Date", got: msg.Header.Get("Date"), want: "Mon, 02 Jan 2023 03:04:05 +0000"},
+		{name: "Content-Type", got: msg.Header.Get("Content-Type"), want: "text/plain; charset=UTF-8"},
+		{name: "List-Unsubscribe", got: msg.Header.Get("List-Unsubscribe"), want: "<https://example.com/u>"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s 戻り値不一致 got: %#v want: %#v", tt.name, tt.got, tt.want)
+		}
+	}
+
+	raw, _ := io.ReadAll(msg.Body)
+	for _, line := range strings.Split(strings.TrimRight(string(raw), "\r\n"), "\r\n") {
+		if len(line) > base64LineLength {
+			t.Errorf("本文の行が長すぎる(%d文字)", len(line))
+		}
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(string(raw), "\r\n", ""))
+	if err != nil || string(decoded) != body {
+		t.Errorf("本文不一致 got: %#v (error: %v)", string(decoded), err)
+	}
+}
+
+func TestMessage_BuildInvalid(t *testing.T) {
+	date := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		from    string
+		message Message
+		wantErr error
+	}{
+		{
+			name:    "送信元不正",
+			from:    "invalid",
+			message: Message{To: "user@example.com"},
+			wantErr: ErrInvalidAddress,
+		},
+		{
+			name:    "宛先不正",
+			from:    "noreply@example.com",
+			message: Message{To: "user@example.com\r\nBcc: other@example.com"},
+			wantErr: ErrInvalidAddress,
+		},
+		{
+			name:    "ヘッダに改行",
+			from:    "noreply@example.com",
+			message: Message{To: "user@example.com", Headers: map[string]string{"X-Test": "a\r\nBcc: other@example.com"}},
+			wantErr: ErrInvalidHeader,
+		},
+		{
+			name:    "ヘッダ名不正",
+			from:    "noreply@example.com",
+			message: Message{To: "user@example.com", Headers: map[string]string{"Bcc: x\r\nX": "a"}},
+			wantErr: ErrInvalidHeader,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.message.Build(tt.from, date); !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package mail
+
+import (
+	"net/mail"
+	"net/smtp"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// smtpMailer SMTPサーバー経由でメールを送信する
+type smtpMailer struct {
+	addr     string
+	auth     smtp.Auth
+	from     string
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+	now      func() time.Time
+}
+
+var _ Mailer = (*smtpMailer)(nil)
+
+// NewSMTPMailer SMTPのメール送信を生成する(usernameが空の場合は認証しない)
+func NewSMTPMailer(host string, port string, username string, password string, from string) (*smtpMailer, error) {
+	if _, err := mail.ParseAddress(from); err != nil {
+		return nil, errors.Wrap(ErrInvalidAddress, "NewSMTPMailer error")
+	}
+
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &smtpMailer{
+		addr:     host + ":" + port,
+		auth:     auth,
+		from:     from,
+		sendMail: smtp.SendMail,
+		now:      time.Now,
+	}, nil
+}
+
+// Send メールを送信する
+func (m *smtpMailer) Send(message Message) error {
+	msg, err := message.Build(m.from, m.now())
+	if err != nil {
+		return errors.Wrap(err, "Send error")
+	}
+
+	from, _ := mail.ParseAddress(m.from)
+	to, _ := mail.ParseAddress(message.To)
+	if err := m.sendMail(m.addr, m.auth, from.Address, []string{to.Address}, msg); err != nil {
+		return errors.Wrap(err, "Send error")
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package mail
+
+import (
+	"errors"
+	"net/smtp"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewSMTPMailer(t *testing.T) {
+	m, err := NewSMTPMailer("smtp.example.com", "587", "user", "password", "GoBBS <noreply@example.com>")
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	if m.addr != "smtp.example.com:587" || m.auth == nil || m.from != "GoBBS <noreply@example.com>" {
+		t.Errorf("NewSMTPMailer() = %+v", m)
+	}
+
+	m, err = NewSMTPMailer("localhost", "25", "", "", "noreply@example.com")
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	if m.auth != nil {
+		t.Errorf("認証情報未指定の場合は認証しない got: %#v", m.auth)
+	}
+
+	if _, err := NewSMTPMailer("localhost", "25", "", "", "invalid"); !errors.Is(err, ErrInvalidAddress) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", err, ErrInvalidAddress)
+	}
+}
+
+func Test_smtpMailer_Send(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	errTest := errors.New("test")
+
+	tests := []struct {
+		name    string
+		message Message
+		sendErr error
+		wantErr error
+	}{
+		{
+			name:    "正常ケース",
+			message: Message{To: "User <user@example.com>", Subject: "subject", Body: "body"},
+		},
+		{
+			name:    "異常ケース(宛先不正)",
+			message: Message{To: "invalid"},
+			wantErr: ErrInvalidAddress,
+		},
+		{
+			name:    "異常ケース(送信失敗)",
+			message: Message{To: "user@example.com"},
+			sendErr: errTest,
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sentTo []string
+			m := &smtpMailer{
+				addr: "localhost:25",
+				from: "GoBBS <noreply@example.com>",
+				sendMail: func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+					if addr != "localhost:25" || from != "noreply@example.com" || !strings.Contains(string(msg), "Subject: ") {
+						t.Errorf("予期せぬ送信内容 addr: %s from: %s", addr, from)
+					}
+					sentTo = to
+					return tt.sendErr
+				},
+				now: func() time.Time { return now },
+			}
+
+			err := m.Send(tt.message)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(sentTo, []string{"user@example.com"}) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", sentTo, []string{"user@example.com"})
+			}
+		})
+	}
+}
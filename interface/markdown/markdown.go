@@ -0,0 +1,324 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+type (
+	// Renderer マークダウンをHTMLに変換する
+	// mockgen -source interface/markdown/markdown.go -destination mock/mock_markdown/markdown_mock.go
+	Renderer interface {
+		Render(source string, lastNumber int) (string, error)
+		References(source string, lastNumber int) References
+	}
+
+	// References 投稿の本文から抽出した通知の対象になる参照
+	References struct {
+		// Quotes 引用レスの投稿番号(出現順、重複なし)
+		Quotes []int
+		// Mentions @メンションのユーザー名(出現順、重複なし)
+		Mentions []string
+	}
+
+	// goldmarkRenderer goldmarkによるマークダウンレンダラー
+	goldmarkRenderer struct {
+		md goldmark.Markdown
+	}
+)
+
+var _ Renderer = (*goldmarkRenderer)(nil)
+
+const (
+	// linkRel 投稿内のリンクに付与するrel属性
+	linkRel = "nofollow ugc noopener"
+	// maxMentionLength @メンションのユーザー名の文字数の上限
+	maxMentionLength = 64
+)
+
+var (
+	// quotePattern 引用レス(>>123)
+	quotePattern = regexp.MustCompile(`^>>([0-9]{1,9})`)
+	// lineQuotePattern 行頭の引用レス
+	lineQuotePattern = regexp.MustCompile(`^[ ]{0,3}>>[0-9]`)
+	// mentionPattern @メンション(直前が名前に使える文字の場合はメールアドレスなどとして扱わない)
+	mentionPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_-])@([\p{L}\p{N}_-]{1,` + strconv.Itoa(maxMentionLength) + `})`)
+	// lastNumberKey 引用可能な最後の投稿番号を保持するコンテキストキー
+	lastNumberKey = parser.NewContextKey()
+)
+
+// NewRenderer マークダウンレンダラーを生成する
+// quoteURLは引用レスのリンク先を投稿番号から生成する
+func NewRenderer(quoteURL func(number int) string) *goldmarkRenderer {
+	blockParsers := parser.DefaultBlockParsers()
+	for i, v := range blockParsers {
+		if bp, ok := v.Value.(parser.BlockParser); ok && bytes.Equal(bp.Trigger(), []byte{'>'}) {
+			blockParsers[i] = util.Prioritized(&blockquoteParser{BlockParser: bp}, v.Priority)
+		}
+	}
+
+	md := goldmark.New(
+		goldmark.WithParser(parser.NewParser(
+			parser.WithBlockParsers(blockParsers...),
+			parser.WithInlineParsers(append(
+				parser.DefaultInlineParsers(),
+				util.Prioritized(&quoteParser{}, 900),
+			)...),
+			parser.WithParagraphTransformers(parser.DefaultParagraphTransformers()...),
+			parser.WithASTTransformers(util.Prioritized(&linkTransformer{}, 1000)),
+		)),
+		goldmark.WithExtensions(extension.GFM),
+		// html.WithUnsafeを指定しないため、生のHTMLと危険なスキームのURLは出力されない
+		goldmark.WithRendererOptions(
+			renderer.WithNodeRenderers(util.Prioritized(&quoteRenderer{quoteURL: quoteURL}, 1000)),
+		),
+	)
+
+	return &goldmarkRenderer{md: md}
+}
+
+// Render マークダウンをサニタイズ済みのHTMLに変換する
+// 引用レスは1からlastNumberまでの投稿番号のみリンクにする
+func (r *goldmarkRenderer) Render(source string, lastNumber int) (string, error) {
+	ctx := parser.NewContext()
+	ctx.Set(lastNumberKey, lastNumber)
+
+	var buf bytes.Buffer
+	if err := r.md.Convert([]byte(source), &buf, parser.WithContext(ctx)); err != nil {
+		return "", errors.Wrap(err, "Render error")
+	}
+
+	return buf.String(), nil
+}
+
+// References 引用レスと@メンションを抽出する
+// 引用レスはRenderでリンクにするものと同じく1からlastNumberまでの投稿番号のみ、コードやリンクの中は対象にしない
+func (r *goldmarkRenderer) References(source string, lastNumber int) References {
+	ctx := parser.NewContext()
+	ctx.Set(lastNumberKey, lastNumber)
+	src := []byte(source)
+	doc := r.md.Parser().Parse(text.NewReader(src), parser.WithContext(ctx))
+
+	var refs References
+	quoted := map[int]bool{}
+	// 書式で分割されたテキストをつなげてからメンションを探す(ブロックやコードの境界は空白で区切る)
+	var plain strings.Builder
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if n.Type() == ast.TypeBlock {
+			plain.WriteByte('\n')
+			return ast.WalkContinue, nil
+		}
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n := n.(type) {
+		case *ast.Text:
+			plain.Write(n.Segment.Value(src))
+			if n.SoftLineBreak() || n.HardLineBreak() {
+				plain.WriteByte('\n')
+			}
+		case *ast.String:
+			plain.Write(n.Value)
+		case *Quote:
+			if !quoted[n.Number] {
+				quoted[n.Number] = true
+				refs.Quotes = append(refs.Quotes, n.Number)
+			}
+			plain.WriteByte(' ')
+		case *ast.CodeSpan, *ast.Link, *ast.AutoLink, *ast.Image, *ast.RawHTML:
+			plain.WriteByte(' ')
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+
+	plainText := plain.String()
+	mentioned := map[string]bool{}
+	for _, m := range mentionPattern.FindAllStringSubmatchIndex(plainText, -1) {
+		// 上限を超える長さの名前は途中で区切らずメンションとして扱わない
+		if next, _ := utf8.DecodeRuneInString(plainText[m[1]:]); m[1] < len(plainText) && isMentionRune(next) {
+			continue
+		}
+		name := plainText[m[2]:m[3]]
+		if !mentioned[name] {
+			mentioned[name] = true
+			refs.Mentions = append(refs.Mentions, name)
+		}
+	}
+
+	return refs
+}
+
+// isMentionRune @メンションのユーザー名に使える文字か判定する
+func isMentionRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r) || r == '_' || r == '-'
+}
+
+// KindQuote 引用レスのノード種別
+var KindQuote = ast.NewNodeKind("Quote")
+
+// Quote 引用レス(>>123)
+type Quote struct {
+	ast.BaseInline
+	Number int
+}
+
+// Kind ノード種別を返す
+func (n *Quote) Kind() ast.NodeKind {
+	return KindQuote
+}
+
+// Dump デバッグ用にノードを出力する
+func (n *Quote) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"Number": strconv.Itoa(n.Number)}, nil)
+}
+
+// quoteParser 引用レスのインラインパーサー
+type quoteParser struct{}
+
+// Trigger パースを開始する文字を返す
+func (p *quoteParser) Trigger() []byte {
+	return []byte{'>'}
+}
+
+// Parse 引用レスをパースする
+func (p *quoteParser) Parse(parent ast.Node, block text.Reader, pc parser.Context) ast.Node {
+	line, _ := block.PeekLine()
+	match := quotePattern.FindSubmatch(line)
+	if match == nil {
+		return nil
+	}
+	// 桁数超過の番号は途中で区切らず引用として扱わない
+	if len(match[0]) < len(line) && line[len(match[0])] >= '0' && line[len(match[0])] <= '9' {
+		return nil
+	}
+
+	number, err := strconv.Atoi(string(match[1]))
+	if err != nil {
+		return nil
+	}
+	lastNumber, _ := pc.Get(lastNumberKey).(int)
+	if number < 1 || number > lastNumber {
+		return nil
+	}
+
+	block.Advance(len(match[0]))
+	return &Quote{Number: number}
+}
+
+// quoteRenderer 引用レスのレンダラー
+type quoteRenderer struct {
+	quoteURL func(number int) string
+}
+
+// RegisterFuncs レンダリング関数を登録する
+func (r *quoteRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(KindQuote, r.render)
+}
+
+// render 引用レスを投稿へのアンカーとして出力する
+func (r *quoteRenderer) render(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	n := node.(*Quote)
+	url := util.EscapeHTML(util.URLEscape([]byte(r.quoteURL(n.Number)), false))
+	_, _ = fmt.Fprintf(w, `<a href="%s" class="quote">&gt;&gt;%d</a>`, url, n.Number)
+	return ast.WalkContinue, nil
+}
+
+// blockquoteParser 行頭の引用レスを引用ブロックとして扱わない引用ブロックパーサー
+type blockquoteParser struct {
+	parser.BlockParser
+}
+
+// Open 行頭が引用レスの場合は段落として扱わせる
+func (p *blockquoteParser) Open(parent ast.Node, reader text.Reader, pc parser.Context) (ast.Node, parser.State) {
+	if isLineQuote(reader) {
+		return nil, parser.NoChildren
+	}
+	return p.BlockParser.Open(parent, reader, pc)
+}
+
+// Continue 引用レスの行で引用ブロックを閉じる
+func (p *blockquoteParser) Continue(node ast.Node, reader text.Reader, pc parser.Context) parser.State {
+	if isLineQuote(reader) {
+		return parser.Close
+	}
+	return p.BlockParser.Continue(node, reader, pc)
+}
+
+// isLineQuote 現在の行が引用レスで始まるか判定する
+func isLineQuote(reader text.Reader) bool {
+	line, _ := reader.PeekLine()
+	return lineQuotePattern.Match(line)
+}
+
+// linkTransformer 投稿内のリンクのURLを検証し、rel属性を付与する
+type linkTransformer struct{}
+
+// Transform ASTを変換する
+func (t *linkTransformer) Transform(node *ast.Document, reader text.Reader, pc parser.Context) {
+	source := reader.Source()
+	var unsafeAutoLinks []*ast.AutoLink
+
+	_ = ast.Walk(node, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n := n.(type) {
+		case *ast.Link:
+			if !isSafeURL(n.Destination) {
+				n.Destination = []byte{}
+			}
+			n.SetAttributeString("rel", []byte(linkRel))
+		case *ast.Image:
+			if !isSafeURL(n.Destination) {
+				n.Destination = []byte{}
+			}
+		case *ast.AutoLink:
+			if !isSafeURL(n.URL(source)) {
+				unsafeAutoLinks = append(unsafeAutoLinks, n)
+			}
+			n.SetAttributeString("rel", []byte(linkRel))
+		}
+		return ast.WalkContinue, nil
+	})
+
+	// 走査中にノードを置き換えないよう、走査後にテキストへ置き換える
+	for _, n := range unsafeAutoLinks {
+		n.Parent().ReplaceChild(n.Parent(), n, ast.NewString(n.Label(source)))
+	}
+}
+
+// safeSchemes リンクに許可するスキーム
+var safeSchemes = map[string]bool{
+	"http":   true,
+	"https":  true,
+	"mailto": true,
+}
+
+// isSafeURL 文字参照を解決した後のURLのスキームが許可されたものか判定する
+// スキームのない相対URLは許可する
+func isSafeURL(url []byte) bool {
+	resolved := bytes.ToLower(util.URLEscape(url, true))
+	end := bytes.IndexAny(resolved, ":/?#")
+	if end < 0 || resolved[end] != ':' {
+		return true
+	}
+	return safeSchemes[string(resolved[:end])]
+}
@@ -0,0 +1,208 @@
+package markdown
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newTestRenderer() *goldmarkRenderer {
+	return NewRenderer(func(number int) string {
+		return fmt.Sprintf("#post-%d", number)
+	})
+}
+
+func Test_goldmarkRenderer_Render(t *testing.T) {
+	tests := []struct {
+		name       string
+		source     string
+		lastNumber int
+		want       string
+	}{
+		{
+			name:       "正常ケース(マークダウン)",
+			source:     "# title\n\n**bold** and `code`",
+			lastNumber: 0,
+			want:       "<h1>title</h1>\n<p><strong>bold</strong> and <code>code</code></p>\n",
+		},
+		{
+			name:       "正常ケース(行頭の引用レス)",
+			source:     ">>1\nthanks",
+			lastNumber: 1,
+			want:       "<p><a href=\"#post-1\" class=\"quote\">&gt;&gt;1</a>\nthanks</p>\n",
+		},
+		{
+			name:       "正常ケース(文中の引用レス)",
+			source:     "see >>2 and >>3",
+			lastNumber: 3,
+			want:       "<p>see <a href=\"#post-2\" class=\"quote\">&gt;&gt;2</a> and <a href=\"#post-3\" class=\"quote\">&gt;&gt;3</a></p>\n",
+		},
+		{
+			name:       "正常ケース(以降の投稿への引用はリンクにしない)",
+			source:     ">>4",
+			lastNumber: 3,
+			want:       "<p>&gt;&gt;4</p>\n",
+		},
+		{
+			name:       "正常ケース(桁数超過の引用はリンクにしない)",
+			source:     ">>1234567890",
+			lastNumber: 3,
+			want:       "<p>&gt;&gt;1234567890</p>\n",
+		},
+		{
+			name:       "正常ケース(コード内の引用はリンクにしない)",
+			source:     "`>>1`",
+			lastNumber: 1,
+			want:       "<p><code>&gt;&gt;1</code></p>\n",
+		},
+		{
+			name:       "正常ケース(数字以外の二重引用は引用ブロック)",
+			source:     ">> nested",
+			lastNumber: 1,
+			want:       "<blockquote>\n<blockquote>\n<p>nested</p>\n</blockquote>\n</blockquote>\n",
+		},
+		{
+			name:       "正常ケース(リンク)",
+			source:     "[a](https://example.com) https://example.org",
+			lastNumber: 0,
+			want:       "<p><a href=\"https://example.com\" rel=\"nofollow ugc noopener\">a</a> <a href=\"https://example.org\" rel=\"nofollow ugc noopener\">https://example.org</a></p>\n",
+		},
+		{
+			name:       "正常ケース(scriptタグ除去)",
+			source:     "<script>alert(1)</script>",
+			lastNumber: 0,
+			want:       "<!-- raw HTML omitted -->\n",
+		},
+		{
+			name:       "正常ケース(イベントハンドラ除去)",
+			source:     "<img src=x onerror=alert(1)> text",
+			lastNumber: 0,
+			want:       "<p><!-- raw HTML omitted --> text</p>\n",
+		},
+		{
+			name:       "正常ケース(javascriptスキームのリンク除去)",
+			source:     "[a](javascript:alert(1)) [b](JaVaScRiPt:alert(1)) [c](&#106;avascript:alert(1))",
+			lastNumber: 0,
+			want:       "<p><a href=\"\" rel=\"nofollow ugc noopener\">a</a> <a href=\"\" rel=\"nofollow ugc noopener\">b</a> <a href=\"\" rel=\"nofollow ugc noopener\">c</a></p>\n",
+		},
+		{
+			name:       "正常ケース(javascriptスキームの画像・自動リンク除去)",
+			source:     "![i](javascript:alert(1)) <javascript:alert(1)>",
+			lastNumber: 0,
+			want:       "<p><img src=\"\" alt=\"i\"> javascript:alert(1)</p>\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newTestRenderer().Render(tt.source, tt.lastNumber)
+			if err != nil {
+				t.Fatalf("予期せぬエラー(error: %s)", err)
+			}
+			if got != tt.want {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_goldmarkRenderer_Render_unsafe(t *testing.T) {
+	sources := []string{
+		"<a href=\"javascript:alert(1)\">x</a>",
+		"<div onclick=\"alert(1)\">x</div>",
+		"[x](data:text/html;base64,PHNjcmlwdD5hbGVydCgxKTwvc2NyaXB0Pg==)",
+		"[x](vbscript:msgbox(1))",
+		"[x](<javascript:alert(1)>)",
+		"<iframe src=\"https://example.com\"></iframe>",
+	}
+	for _, source := range sources {
+		got, err := newTestRenderer().Render(source, 0)
+		if err != nil {
+			t.Fatalf("予期せぬエラー(error: %s)", err)
+		}
+		lower := strings.ToLower(got)
+		for _, ng := range []string{"<script", "<iframe", "<div", "onclick", "javascript:", "vbscript:", "data:text"} {
+			if strings.Contains(lower, ng) {
+				t.Errorf("危険な出力(source: %s, got: %s)", source, got)
+			}
+		}
+	}
+}
+
+func Test_isSafeURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{url: "https://example.com", want: true},
+		{url: "http://example.com", want: true},
+		{url: "mailto:a@example.com", want: true},
+		{url: "/users/1", want: true},
+		{url: "#post-1", want: true},
+		{url: "page?a=b:c", want: true},
+		{url: "javascript:alert(1)", want: false},
+		{url: "&#x6A;avascript:alert(1)", want: false},
+		{url: "ftp://example.com", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			if got := isSafeURL([]byte(tt.url)); got != tt.want {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_goldmarkRenderer_References(t *testing.T) {
+	tests := []struct {
+		name       string
+		source     string
+		lastNumber int
+		want       References
+	}{
+		{
+			name:       "正常ケース(引用レスとメンション)",
+			source:     ">>1\n@alice see >>3 and >>1, @bob_2 @alice",
+			lastNumber: 3,
+			want:       References{Quotes: []int{1, 3}, Mentions: []string{"alice", "bob_2"}},
+		},
+		{
+			name:       "正常ケース(書式で分割されたメンション)",
+			source:     "**hi** @snake_case_name and *@太郎*",
+			lastNumber: 0,
+			want:       References{Mentions: []string{"snake_case_name", "太郎"}},
+		},
+		{
+			name:       "正常ケース(存在しない番号の引用レス)",
+			source:     ">>0 >>4",
+			lastNumber: 3,
+			want:       References{},
+		},
+		{
+			name:       "正常ケース(コードとリンクの中は対象外)",
+			source:     "`@code >>1`\n\n```\n@block >>1\n```\n\n[@link](https://example.com) <https://example.com/@path>",
+			lastNumber: 1,
+			want:       References{},
+		},
+		{
+			name:       "正常ケース(メールアドレスはメンションではない)",
+			source:     "mail a@example.com",
+			lastNumber: 0,
+			want:       References{},
+		},
+		{
+			name:       "正常ケース(名前の上限)",
+			source:     "@" + strings.Repeat("a", maxMentionLength) + " @" + strings.Repeat("b", maxMentionLength+1),
+			lastNumber: 0,
+			want:       References{Mentions: []string{strings.Repeat("a", maxMentionLength)}},
+		},
+	}
+	r := newTestRenderer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.References(tt.source, tt.lastNumber); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "gobbs"
+
+const (
+	// TxCommit コミットしたトランザクション
+	TxCommit = "commit"
+	// TxRollback ロールバックしたトランザクション
+	TxRollback = "rollback"
+)
+
+const (
+	// LoginSuccess ログイン成功
+	LoginSuccess = "success"
+	// LoginFailure ログイン失敗
+	LoginFailure = "failure"
+	// LoginMFARequired パスワード認証に成功し、確認コードの入力待ち
+	LoginMFARequired = "mfa_required"
+)
+
+const (
+	// ConnectionSSE Server-Sent Eventsの接続
+	ConnectionSSE = "sse"
+	// ConnectionWebSocket WebSocketの接続
+	ConnectionWebSocket = "websocket"
+)
+
+// Registry /metricsで公開するメトリクスのレジストリ
+var Registry = prometheus.NewRegistry()
+
+var (
+	// HTTPRequests ルーティング、メソッド、ステータスごとのリクエスト数
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Number of HTTP requests by route, method and status.",
+	}, []string{"route", "method", "status"})
+
+	// HTTPRequestDuration ルーティング、メソッド、ステータスごとの処理時間
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by route, method and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+
+	// DBTransactions 結果(commit, rollback)ごとのトランザクション数
+	DBTransactions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "db_transactions_total",
+		Help:      "Number of database transactions by result.",
+	}, []string{"result"})
+
+	// Logins 結果(success, failure, mfa_required)ごとのログイン数
+	Logins = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "logins_total",
+		Help:      "Number of login attempts by result.",
+	}, []string{"result"})
+
+	// Panics ルーティングごとのハンドラーで発生したパニック数
+	Panics = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_panics_total",
+		Help:      "Number of panics recovered in HTTP handlers by route.",
+	}, []string{"route"})
+
+	// ActiveConnections 種類(sse, websocket)ごとの接続中のストリーム数
+	ActiveConnections = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "active_connections",
+		Help:      "Number of open streaming connections by kind.",
+	}, []string{"kind"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequests,
+		HTTPRequestDuration,
+		DBTransactions,
+		Logins,
+		Panics,
+		ActiveConnections,
+	)
+}
+
+// RegisterDB コネクションプールの統計をメトリクスに追加する
+func RegisterDB(db *sql.DB, dbName string) error {
+	return Registry.Register(collectors.NewDBStatsCollector(db, dbName))
+}
+
+// Handler メトリクスをPrometheusの形式で返すハンドラーを返す
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestHandler(t *testing.T) {
+	HTTPRequests.WithLabelValues("/users/", "GET", "200").Inc()
+	DBTransactions.WithLabelValues(TxCommit).Inc()
+	Logins.WithLabelValues(LoginFailure).Inc()
+	Panics.WithLabelValues("/users/").Inc()
+	ActiveConnections.WithLabelValues(ConnectionSSE).Inc()
+	defer ActiveConnections.WithLabelValues(ConnectionSSE).Dec()
+
+	recorder := httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body, _ := io.ReadAll(recorder.Body)
+	for _, want := range []string{
+		`gobbs_http_requests_total{method="GET",route="/users/",status="200"}`,
+		`gobbs_db_transactions_total{result="commit"}`,
+		`gobbs_logins_total{result="failure"}`,
+		`gobbs_http_panics_total{route="/users/"}`,
+		`gobbs_active_connections{kind="sse"} 1`,
+		`go_goroutines`,
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("メトリクスに %s が含まれない", want)
+		}
+	}
+}
+
+func TestRegisterDB(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmockの生成に失敗(error: %v)", err)
+	}
+	defer db.Close()
+
+	if err := RegisterDB(db, "bbs"); err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	if err := RegisterDB(db, "bbs"); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+
+	recorder := httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if want := `go_sql_max_open_connections{db_name="bbs"}`; !strings.Contains(recorder.Body.String(), want) {
+		t.Errorf("メトリクスに %s が含まれない", want)
+	}
+}
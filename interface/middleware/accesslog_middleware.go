@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/interface/middleware/middlewarehelper"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+type (
+	// AccessLog アクセスログミドルウェア
+	// mockgen -source interface/middleware/accesslog_middleware.go -destination mock/mock_middleware/accesslog_middleware_mock.go
+	AccessLog interface {
+		Record(middlewarehelper.HandlerFunc) middlewarehelper.HandlerFunc
+	}
+
+	// accessLog アクセスログミドルウェア
+	accessLog struct {
+		logger *slog.Logger
+		now    func() time.Time
+	}
+)
+
+var _ AccessLog = (*accessLog)(nil)
+
+// NewAccessLog アクセスログミドルウェアを生成する
+func NewAccessLog(logger *slog.Logger) *accessLog {
+	return &accessLog{logger: logger, now: time.Now}
+}
+
+// Record リクエストの処理後にメソッド、ルーティング、ステータス、処理時間、ユーザーIDを記録する
+// ユーザーIDは後続の認証ミドルウェアでセットされるため、認証より前に適用する
+func (m *accessLog) Record(next middlewarehelper.HandlerFunc) middlewarehelper.HandlerFunc {
+	return func(c handlerctx.APIContext) error {
+		start := m.now()
+		err := next(c)
+
+		m.logger.LogAttrs(c.RequestContext(), slog.LevelInfo, "access",
+			slog.String("method", c.RequestMethod()),
+			slog.String("route", c.Route()),
+			slog.String("path", c.URL().Path),
+			slog.Int("status", responseStatus(c, err)),
+			slog.Float64("latency_ms", float64(m.now().Sub(start))/float64(time.Millisecond)),
+			slog.String("user_id", c.UserID()),
+		)
+
+		return err
+	}
+}
+
+// responseStatus ハンドラーの処理後にクライアントへ返したステータスコードを返す
+func responseStatus(c handlerctx.APIContext, err error) int {
+	if err != nil {
+		// エラーを返した場合はApplyが500を返す
+		return http.StatusInternalServerError
+	}
+	if status := c.StatusCode(); status != 0 {
+		return status
+	}
+	// 何も書き込まなかった場合はnet/httpが200を返す
+	return http.StatusOK
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/mock/mock_handler/mock_handlerctx"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewAccessLog(t *testing.T) {
+	logger := slog.Default()
+	got := NewAccessLog(logger)
+	if got.logger != logger || reflect.ValueOf(got.now).Pointer() != reflect.ValueOf(time.Now).Pointer() {
+		t.Errorf("NewAccessLog() = %v", got)
+	}
+}
+
+func Test_accessLog_Record(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errNext := errors.New("next")
+	start := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		statusCode int
+		nextErr    error
+		wantStatus float64
+	}{
+		{name: "正常ケース", statusCode: http.StatusCreated, nextErr: nil, wantStatus: http.StatusCreated},
+		{name: "正常ケース(ステータス未書き込みは200)", statusCode: 0, nextErr: nil, wantStatus: http.StatusOK},
+		{name: "正常ケース(エラーは500)", statusCode: 0, nextErr: errNext, wantStatus: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := mock_handlerctx.NewMockAPIContext(ctrl)
+			mock.EXPECT().StatusCode().Return(tt.statusCode).AnyTimes()
+			gomock.InOrder(
+				mock.EXPECT().RequestContext().Return(context.Background()),
+				mock.EXPECT().RequestMethod().Return(http.MethodPost),
+				mock.EXPECT().Route().Return("/boards/"),
+				mock.EXPECT().URL().Return(&url.URL{Path: "/boards/1"}),
+				mock.EXPECT().UserID().Return("2"),
+			)
+
+			var buf bytes.Buffer
+			times := []time.Time{start, start.Add(1500 * time.Microsecond)}
+			m := &accessLog{
+				logger: slog.New(slog.NewJSONHandler(&buf, nil)),
+				now: func() time.Time {
+					now := times[0]
+					times = times[1:]
+					return now
+				},
+			}
+			next := func(c handlerctx.APIContext) error { return tt.nextErr }
+			if err := m.Record(next)(mock); err != tt.nextErr {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.nextErr)
+			}
+
+			var got map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatalf("予期せぬエラー(error: %s)", err)
+			}
+			delete(got, "time")
+			want := map[string]any{
+				"level":      "INFO",
+				"msg":        "access",
+				"method":     "POST",
+				"route":      "/boards/",
+				"path":       "/boards/1",
+				"status":     tt.wantStatus,
+				"latency_ms": 1.5,
+				"user_id":    "2",
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
+			}
+		})
+	}
+}
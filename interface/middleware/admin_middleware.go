@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/interface/middleware/middlewarehelper"
+	"net/http"
+)
+
+type (
+	// Admin 管理者認可ミドルウェア
+	// mockgen -source interface/middleware/admin_middleware.go -destination mock/mock_middleware/admin_middleware_mock.go
+	Admin interface {
+		VerifyAdmin(middlewarehelper.HandlerFunc) middlewarehelper.HandlerFunc
+	}
+
+	// admin 管理者認可ミドルウェア
+	admin struct {
+		userIDs map[string]struct{}
+	}
+)
+
+var _ Admin = (*admin)(nil)
+
+// NewAdmin 管理者認可ミドルウェアを生成する
+func NewAdmin(userIDs []string) *admin {
+	m := &admin{userIDs: make(map[string]struct{}, len(userIDs))}
+	for _, id := range userIDs {
+		m.userIDs[id] = struct{}{}
+	}
+	return m
+}
+
+// VerifyAdmin 認証済みのユーザーが管理者か確認する(VerifyAuthの後に適用する)
+func (m *admin) VerifyAdmin(next middlewarehelper.HandlerFunc) middlewarehelper.HandlerFunc {
+	return func(c handlerctx.APIContext) error {
+		if _, ok := m.userIDs[c.UserID()]; !ok {
+			c.WriteStatusCode(http.StatusForbidden)
+			return nil
+		}
+
+		return next(c)
+	}
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/mock/mock_handler/mock_handlerctx"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewAdmin(t *testing.T) {
+	want := &admin{userIDs: map[string]struct{}{"1": {}, "3": {}}}
+	if got := NewAdmin([]string{"1", "3"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewAdmin() = %v, want %v", got, want)
+	}
+}
+
+func Test_admin_VerifyAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errNext := errors.New("next")
+
+	tests := []struct {
+		name    string
+		ctx     func() handlerctx.APIContext
+		wantErr error
+	}{
+		{
+			name: "正常ケース",
+			ctx: func() handlerctx.APIContext {
+				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				mock.EXPECT().UserID().Return("1")
+				return mock
+			},
+			wantErr: errNext,
+		},
+		{
+			name: "異常ケース(管理者以外)",
+			ctx: func() handlerctx.APIContext {
+				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().UserID().Return("2"),
+					mock.EXPECT().WriteStatusCode(http.StatusForbidden),
+				)
+				return mock
+			},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := func(c handlerctx.APIContext) error { return errNext }
+			if err := NewAdmin([]string{"1"}).VerifyAdmin(next)(tt.ctx()); err != tt.wantErr {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+		})
+	}
+}
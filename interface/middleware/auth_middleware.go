@@ -1,10 +1,12 @@
 package middleware
 
 import (
+	"GoBBS/domain/model"
 	"GoBBS/interface/handler/handlerctx"
 	"GoBBS/interface/middleware/middlewarehelper"
 	"GoBBS/usecase"
 	"net/http"
+	"slices"
 	"strings"
 )
 
@@ -13,6 +15,8 @@ type (
 	// mockgen -source interface/middleware/auth_middleware.go -destination mock/mock_middleware/auth_middleware_mock.go
 	Auth interface {
 		VerifyAuth(middlewarehelper.HandlerFunc) middlewarehelper.HandlerFunc
+		VerifyWebSocketAuth(middlewarehelper.HandlerFunc) middlewarehelper.HandlerFunc
+		VerifyScope(scope string) middlewarehelper.MiddlewareFunc
 	}
 
 	// auth 認証ミドルウェア
@@ -23,18 +27,25 @@ type (
 
 var _ Auth = (*auth)(nil)
 
+// WebSocketAuthProtocol トークンを渡すWebSocketのサブプロトコル
+// ブラウザのWebSocketはヘッダを指定できないため、サブプロトコルに"bearer"とトークンを並べて渡す
+const WebSocketAuthProtocol = "bearer"
+
 // NewAuth 認証ミドルウェアを生成する
 func NewAuth(usecase usecase.User) *auth {
 	return &auth{uc: usecase}
 }
 
 // VerifyAuth 認証する
+// Authorizationヘッダーのトークン、またはセッションのクッキーのトークンを受け付ける
+// 失効したログインセッションのトークンは受け付けない
+// クッキーで認証する場合、状態を変更するリクエストにはCSRFトークンが必要
+// 個人用アクセストークンは受け付けない(アカウントの設定はログインセッションでのみ変更できる)
 func (m *auth) VerifyAuth(next middlewarehelper.HandlerFunc) middlewarehelper.HandlerFunc {
 	return func(c handlerctx.APIContext) error {
 		header := c.RequestHeader()
 		if len(header["Authorization"]) == 0 {
-			c.WriteStatusCode(http.StatusUnauthorized)
-			return nil
+			return m.verifyCookieAuth(c, next)
 		}
 
 		auth := header["Authorization"][0]
@@ -44,10 +55,92 @@ func (m *auth) VerifyAuth(next middlewarehelper.HandlerFunc) middlewarehelper.Ha
 		}
 
 		token := strings.Replace(auth, "Bearer ", "", 1)
-		if ok := m.uc.VerifyAuthorization(token); !ok {
+		userID, sessionID, ok := m.uc.VerifyAuthorization(c.RequestContext(), token)
+		if !ok {
+			c.WriteStatusCode(http.StatusUnauthorized)
+			return nil
+		}
+		c.SetUserID(userID)
+		c.SetSessionID(sessionID)
+
+		return next(c)
+	}
+}
+
+// verifyCookieAuth セッションのクッキーで認証する
+func (m *auth) verifyCookieAuth(c handlerctx.APIContext, next middlewarehelper.HandlerFunc) error {
+	cookie, err := c.RequestCookie(SessionCookieName)
+	if err != nil {
+		c.WriteStatusCode(http.StatusUnauthorized)
+		return nil
+	}
+
+	userID, sessionID, ok := m.uc.VerifyAuthorization(c.RequestContext(), cookie.Value)
+	if !ok {
+		c.WriteStatusCode(http.StatusUnauthorized)
+		return nil
+	}
+	if !verifyCSRF(c) {
+		c.WriteStatusCode(http.StatusForbidden)
+		return nil
+	}
+	c.SetUserID(userID)
+	c.SetSessionID(sessionID)
+
+	return next(c)
+}
+
+// VerifyScope VerifyAuthと同じ認証に加え、scopeを持つ個人用アクセストークンを受け付けるミドルウェアを返す
+// 個人用アクセストークンはAuthorizationヘッダーでのみ受け付け、scopeを持たない場合は403を返す
+// 個人用アクセストークンで認証した場合、ログインセッションのIDは設定しない
+func (m *auth) VerifyScope(scope string) middlewarehelper.MiddlewareFunc {
+	return func(next middlewarehelper.HandlerFunc) middlewarehelper.HandlerFunc {
+		verifyAuth := m.VerifyAuth(next)
+		return func(c handlerctx.APIContext) error {
+			token, ok := strings.CutPrefix(c.RequestHeader().Get("Authorization"), "Bearer ")
+			if !ok || !model.IsAPIToken(token) {
+				return verifyAuth(c)
+			}
+
+			userID, scopes, ok := m.uc.VerifyAPIToken(c.RequestContext(), token)
+			if !ok {
+				c.WriteStatusCode(http.StatusUnauthorized)
+				return nil
+			}
+			if !slices.Contains(scopes, scope) {
+				c.WriteStatusCode(http.StatusForbidden)
+				return nil
+			}
+			c.SetUserID(userID)
+
+			return next(c)
+		}
+	}
+}
+
+// VerifyWebSocketAuth WebSocket接続を認証する
+// Authorizationヘッダー、セッションのクッキーに加え、サブプロトコルで渡されたトークンを受け付ける
+func (m *auth) VerifyWebSocketAuth(next middlewarehelper.HandlerFunc) middlewarehelper.HandlerFunc {
+	verifyAuth := m.VerifyAuth(next)
+	return func(c handlerctx.APIContext) error {
+		header := c.RequestHeader()
+		protocols := strings.Split(header.Get("Sec-WebSocket-Protocol"), ",")
+		if len(header["Authorization"]) > 0 || strings.TrimSpace(protocols[0]) != WebSocketAuthProtocol {
+			return verifyAuth(c)
+		}
+
+		if len(protocols) != 2 {
+			c.WriteStatusCode(http.StatusUnauthorized)
+			return nil
+		}
+
+		userID, sessionID, ok := m.uc.VerifyAuthorization(c.RequestContext(), strings.TrimSpace(protocols[1]))
+		if !ok {
 			c.WriteStatusCode(http.StatusUnauthorized)
 			return nil
 		}
+		c.SetUserID(userID)
+		c.SetSessionID(sessionID)
 
 		return next(c)
 	}
@@ -1,11 +1,13 @@
 package middleware
 
 import (
+	"GoBBS/domain/model"
 	"GoBBS/interface/handler/handlerctx"
 	"GoBBS/interface/middleware/middlewarehelper"
 	"GoBBS/mock/mock_handler/mock_handlerctx"
 	"GoBBS/mock/mock_usecase"
 	"GoBBS/usecase"
+	"context"
 	"net/http"
 	"reflect"
 	"testing"
@@ -66,7 +68,7 @@ func Test_auth_VerifyAuth(t *testing.T) {
 			m: &auth{
 				uc: func() *mock_usecase.MockUser {
 					mock := mock_usecase.NewMockUser(ctrl)
-					mock.EXPECT().VerifyAuthorization(gomock.Any()).Return(true)
+					mock.EXPECT().VerifyAuthorization(gomock.Any(), gomock.Any()).Return("1", "sid", true)
 					return mock
 				}(),
 			},
@@ -79,8 +81,13 @@ func Test_auth_VerifyAuth(t *testing.T) {
 			},
 			ctx: func() *mock_handlerctx.MockAPIContext {
 				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				mock.EXPECT().RequestContext().Return(context.Background()).AnyTimes()
 				header := http.Header{"Authorization": {"Bearer abc"}}
-				mock.EXPECT().RequestHeader().Return(header)
+				gomock.InOrder(
+					mock.EXPECT().RequestHeader().Return(header),
+					mock.EXPECT().SetUserID("1"),
+					mock.EXPECT().SetSessionID("sid"),
+				)
 				return mock
 			}(),
 			wantFuncErr: false,
@@ -90,7 +97,7 @@ func Test_auth_VerifyAuth(t *testing.T) {
 			m: &auth{
 				uc: func() *mock_usecase.MockUser {
 					mock := mock_usecase.NewMockUser(ctrl)
-					mock.EXPECT().VerifyAuthorization(gomock.Any()).Return(false)
+					mock.EXPECT().VerifyAuthorization(gomock.Any(), gomock.Any()).Return("", "", false)
 					return mock
 				}(),
 			},
@@ -103,6 +110,7 @@ func Test_auth_VerifyAuth(t *testing.T) {
 			},
 			ctx: func() *mock_handlerctx.MockAPIContext {
 				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				mock.EXPECT().RequestContext().Return(context.Background()).AnyTimes()
 				header := http.Header{"Authorization": {"Bearer abc"}}
 				gomock.InOrder(
 					mock.EXPECT().RequestHeader().Return(header),
@@ -124,6 +132,7 @@ func Test_auth_VerifyAuth(t *testing.T) {
 			},
 			ctx: func() *mock_handlerctx.MockAPIContext {
 				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				mock.EXPECT().RequestContext().Return(context.Background()).AnyTimes()
 				header := http.Header{"Authorization": {"abc"}}
 				gomock.InOrder(
 					mock.EXPECT().RequestHeader().Return(header),
@@ -145,9 +154,124 @@ func Test_auth_VerifyAuth(t *testing.T) {
 			},
 			ctx: func() *mock_handlerctx.MockAPIContext {
 				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				mock.EXPECT().RequestContext().Return(context.Background()).AnyTimes()
 				header := http.Header{}
 				gomock.InOrder(
 					mock.EXPECT().RequestHeader().Return(header),
+					mock.EXPECT().RequestCookie(SessionCookieName).Return(nil, http.ErrNoCookie),
+					mock.EXPECT().WriteStatusCode(http.StatusUnauthorized),
+				)
+				return mock
+			}(),
+			wantFuncErr: false,
+		},
+		{
+			name: "正常ケース(クッキー)",
+			m: &auth{
+				uc: func() *mock_usecase.MockUser {
+					mock := mock_usecase.NewMockUser(ctrl)
+					mock.EXPECT().VerifyAuthorization(gomock.Any(), "abc").Return("1", "sid", true)
+					return mock
+				}(),
+			},
+			args: args{
+				next: func(c handlerctx.APIContext) error {
+					return nil
+				},
+			},
+			ctx: func() *mock_handlerctx.MockAPIContext {
+				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				mock.EXPECT().RequestContext().Return(context.Background()).AnyTimes()
+				gomock.InOrder(
+					mock.EXPECT().RequestHeader().Return(http.Header{}),
+					mock.EXPECT().RequestCookie(SessionCookieName).Return(&http.Cookie{Name: SessionCookieName, Value: "abc"}, nil),
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().SetUserID("1"),
+					mock.EXPECT().SetSessionID("sid"),
+				)
+				return mock
+			}(),
+			wantFuncErr: false,
+		},
+		{
+			name: "正常ケース(クッキーとCSRFトークン)",
+			m: &auth{
+				uc: func() *mock_usecase.MockUser {
+					mock := mock_usecase.NewMockUser(ctrl)
+					mock.EXPECT().VerifyAuthorization(gomock.Any(), "abc").Return("1", "sid", true)
+					return mock
+				}(),
+			},
+			args: args{
+				next: func(c handlerctx.APIContext) error {
+					return nil
+				},
+			},
+			ctx: func() *mock_handlerctx.MockAPIContext {
+				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				mock.EXPECT().RequestContext().Return(context.Background()).AnyTimes()
+				gomock.InOrder(
+					mock.EXPECT().RequestHeader().Return(http.Header{}),
+					mock.EXPECT().RequestCookie(SessionCookieName).Return(&http.Cookie{Name: SessionCookieName, Value: "abc"}, nil),
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().RequestHeader().Return(http.Header{http.CanonicalHeaderKey(CSRFHeaderName): {"csrf"}}),
+					mock.EXPECT().RequestCookie(CSRFCookieName).Return(&http.Cookie{Name: CSRFCookieName, Value: "csrf"}, nil),
+					mock.EXPECT().SetUserID("1"),
+					mock.EXPECT().SetSessionID("sid"),
+				)
+				return mock
+			}(),
+			wantFuncErr: false,
+		},
+		{
+			name: "異常ケース(クッキーのCSRFトークン不一致)",
+			m: &auth{
+				uc: func() *mock_usecase.MockUser {
+					mock := mock_usecase.NewMockUser(ctrl)
+					mock.EXPECT().VerifyAuthorization(gomock.Any(), "abc").Return("1", "sid", true)
+					return mock
+				}(),
+			},
+			args: args{
+				next: func(c handlerctx.APIContext) error {
+					return nil
+				},
+			},
+			ctx: func() *mock_handlerctx.MockAPIContext {
+				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				mock.EXPECT().RequestContext().Return(context.Background()).AnyTimes()
+				gomock.InOrder(
+					mock.EXPECT().RequestHeader().Return(http.Header{}),
+					mock.EXPECT().RequestCookie(SessionCookieName).Return(&http.Cookie{Name: SessionCookieName, Value: "abc"}, nil),
+					mock.EXPECT().RequestMethod().Return(http.MethodDelete),
+					mock.EXPECT().RequestHeader().Return(http.Header{http.CanonicalHeaderKey(CSRFHeaderName): {"other"}}),
+					mock.EXPECT().RequestCookie(CSRFCookieName).Return(&http.Cookie{Name: CSRFCookieName, Value: "csrf"}, nil),
+					mock.EXPECT().WriteStatusCode(http.StatusForbidden),
+				)
+				return mock
+			}(),
+			wantFuncErr: false,
+		},
+		{
+			name: "異常ケース(クッキーの認証失敗)",
+			m: &auth{
+				uc: func() *mock_usecase.MockUser {
+					mock := mock_usecase.NewMockUser(ctrl)
+					mock.EXPECT().VerifyAuthorization(gomock.Any(), "abc").Return("", "", false)
+					return mock
+				}(),
+			},
+			args: args{
+				next: func(c handlerctx.APIContext) error {
+					return nil
+				},
+			},
+			ctx: func() *mock_handlerctx.MockAPIContext {
+				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				mock.EXPECT().RequestContext().Return(context.Background()).AnyTimes()
+				gomock.InOrder(
+					mock.EXPECT().RequestHeader().Return(http.Header{}),
+					mock.EXPECT().RequestCookie(SessionCookieName).Return(&http.Cookie{Name: SessionCookieName, Value: "abc"}, nil),
 					mock.EXPECT().WriteStatusCode(http.StatusUnauthorized),
 				)
 				return mock
@@ -165,3 +289,266 @@ func Test_auth_VerifyAuth(t *testing.T) {
 		})
 	}
 }
+
+func Test_auth_VerifyWebSocketAuth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	next := func(c handlerctx.APIContext) error {
+		return nil
+	}
+
+	tests := []struct {
+		name string
+		m    *auth
+		ctx  handlerctx.APIContext
+	}{
+		{
+			name: "正常ケース(サブプロトコル)",
+			m: &auth{
+				uc: func() *mock_usecase.MockUser {
+					mock := mock_usecase.NewMockUser(ctrl)
+					mock.EXPECT().VerifyAuthorization(gomock.Any(), "abc").Return("1", "sid", true)
+					return mock
+				}(),
+			},
+			ctx: func() *mock_handlerctx.MockAPIContext {
+				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				mock.EXPECT().RequestContext().Return(context.Background()).AnyTimes()
+				header := http.Header{"Sec-Websocket-Protocol": {"bearer, abc"}}
+				gomock.InOrder(
+					mock.EXPECT().RequestHeader().Return(header),
+					mock.EXPECT().SetUserID("1"),
+					mock.EXPECT().SetSessionID("sid"),
+				)
+				return mock
+			}(),
+		},
+		{
+			name: "正常ケース(Authorizationヘッダー)",
+			m: &auth{
+				uc: func() *mock_usecase.MockUser {
+					mock := mock_usecase.NewMockUser(ctrl)
+					mock.EXPECT().VerifyAuthorization(gomock.Any(), "abc").Return("1", "sid", true)
+					return mock
+				}(),
+			},
+			ctx: func() *mock_handlerctx.MockAPIContext {
+				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				mock.EXPECT().RequestContext().Return(context.Background()).AnyTimes()
+				header := http.Header{"Authorization": {"Bearer abc"}}
+				gomock.InOrder(
+					mock.EXPECT().RequestHeader().Return(header).Times(2),
+					mock.EXPECT().SetUserID("1"),
+					mock.EXPECT().SetSessionID("sid"),
+				)
+				return mock
+			}(),
+		},
+		{
+			name: "異常ケース(認証失敗)",
+			m: &auth{
+				uc: func() *mock_usecase.MockUser {
+					mock := mock_usecase.NewMockUser(ctrl)
+					mock.EXPECT().VerifyAuthorization(gomock.Any(), "abc").Return("", "", false)
+					return mock
+				}(),
+			},
+			ctx: func() *mock_handlerctx.MockAPIContext {
+				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				mock.EXPECT().RequestContext().Return(context.Background()).AnyTimes()
+				header := http.Header{"Sec-Websocket-Protocol": {"bearer, abc"}}
+				gomock.InOrder(
+					mock.EXPECT().RequestHeader().Return(header),
+					mock.EXPECT().WriteStatusCode(http.StatusUnauthorized),
+				)
+				return mock
+			}(),
+		},
+		{
+			name: "異常ケース(サブプロトコルにトークンなし)",
+			m:    &auth{},
+			ctx: func() *mock_handlerctx.MockAPIContext {
+				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				mock.EXPECT().RequestContext().Return(context.Background()).AnyTimes()
+				header := http.Header{"Sec-Websocket-Protocol": {"bearer"}}
+				gomock.InOrder(
+					mock.EXPECT().RequestHeader().Return(header),
+					mock.EXPECT().WriteStatusCode(http.StatusUnauthorized),
+				)
+				return mock
+			}(),
+		},
+		{
+			name: "異常ケース(トークンなし)",
+			m:    &auth{},
+			ctx: func() *mock_handlerctx.MockAPIContext {
+				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				mock.EXPECT().RequestContext().Return(context.Background()).AnyTimes()
+				gomock.InOrder(
+					mock.EXPECT().RequestHeader().Return(http.Header{}).Times(2),
+					mock.EXPECT().RequestCookie(SessionCookieName).Return(nil, http.ErrNoCookie),
+					mock.EXPECT().WriteStatusCode(http.StatusUnauthorized),
+				)
+				return mock
+			}(),
+		},
+		{
+			name: "正常ケース(クッキー)",
+			m: &auth{
+				uc: func() *mock_usecase.MockUser {
+					mock := mock_usecase.NewMockUser(ctrl)
+					mock.EXPECT().VerifyAuthorization(gomock.Any(), "abc").Return("1", "sid", true)
+					return mock
+				}(),
+			},
+			ctx: func() *mock_handlerctx.MockAPIContext {
+				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				mock.EXPECT().RequestContext().Return(context.Background()).AnyTimes()
+				header := http.Header{"Sec-Websocket-Protocol": {"chat"}}
+				gomock.InOrder(
+					mock.EXPECT().RequestHeader().Return(header).Times(2),
+					mock.EXPECT().RequestCookie(SessionCookieName).Return(&http.Cookie{Name: SessionCookieName, Value: "abc"}, nil),
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().SetUserID("1"),
+					mock.EXPECT().SetSessionID("sid"),
+				)
+				return mock
+			}(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.m.VerifyWebSocketAuth(next)(tt.ctx); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+}
+
+func Test_auth_VerifyScope(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	next := func(c handlerctx.APIContext) error {
+		return nil
+	}
+	apiToken := "gbp_abc"
+
+	tests := []struct {
+		name string
+		m    *auth
+		ctx  handlerctx.APIContext
+	}{
+		{
+			name: "正常ケース(個人用アクセストークン)",
+			m: &auth{
+				uc: func() *mock_usecase.MockUser {
+					mock := mock_usecase.NewMockUser(ctrl)
+					mock.EXPECT().VerifyAPIToken(gomock.Any(), apiToken).Return("1", []string{"read", "post"}, true)
+					return mock
+				}(),
+			},
+			ctx: func() *mock_handlerctx.MockAPIContext {
+				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				mock.EXPECT().RequestContext().Return(context.Background()).AnyTimes()
+				header := http.Header{"Authorization": {"Bearer " + apiToken}}
+				gomock.InOrder(
+					mock.EXPECT().RequestHeader().Return(header),
+					mock.EXPECT().SetUserID("1"),
+				)
+				return mock
+			}(),
+		},
+		{
+			name: "正常ケース(ログインセッションのトークン)",
+			m: &auth{
+				uc: func() *mock_usecase.MockUser {
+					mock := mock_usecase.NewMockUser(ctrl)
+					mock.EXPECT().VerifyAuthorization(gomock.Any(), "abc").Return("1", "sid", true)
+					return mock
+				}(),
+			},
+			ctx: func() *mock_handlerctx.MockAPIContext {
+				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				mock.EXPECT().RequestContext().Return(context.Background()).AnyTimes()
+				header := http.Header{"Authorization": {"Bearer abc"}}
+				gomock.InOrder(
+					mock.EXPECT().RequestHeader().Return(header).Times(2),
+					mock.EXPECT().SetUserID("1"),
+					mock.EXPECT().SetSessionID("sid"),
+				)
+				return mock
+			}(),
+		},
+		{
+			name: "正常ケース(セッションのクッキー)",
+			m: &auth{
+				uc: func() *mock_usecase.MockUser {
+					mock := mock_usecase.NewMockUser(ctrl)
+					mock.EXPECT().VerifyAuthorization(gomock.Any(), "abc").Return("1", "sid", true)
+					return mock
+				}(),
+			},
+			ctx: func() *mock_handlerctx.MockAPIContext {
+				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				mock.EXPECT().RequestContext().Return(context.Background()).AnyTimes()
+				gomock.InOrder(
+					mock.EXPECT().RequestHeader().Return(http.Header{}).Times(2),
+					mock.EXPECT().RequestCookie(SessionCookieName).Return(&http.Cookie{Name: SessionCookieName, Value: "abc"}, nil),
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().SetUserID("1"),
+					mock.EXPECT().SetSessionID("sid"),
+				)
+				return mock
+			}(),
+		},
+		{
+			name: "異常ケース(スコープなし)",
+			m: &auth{
+				uc: func() *mock_usecase.MockUser {
+					mock := mock_usecase.NewMockUser(ctrl)
+					mock.EXPECT().VerifyAPIToken(gomock.Any(), apiToken).Return("1", []string{"post"}, true)
+					return mock
+				}(),
+			},
+			ctx: func() *mock_handlerctx.MockAPIContext {
+				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				mock.EXPECT().RequestContext().Return(context.Background()).AnyTimes()
+				header := http.Header{"Authorization": {"Bearer " + apiToken}}
+				gomock.InOrder(
+					mock.EXPECT().RequestHeader().Return(header),
+					mock.EXPECT().WriteStatusCode(http.StatusForbidden),
+				)
+				return mock
+			}(),
+		},
+		{
+			name: "異常ケース(認証失敗)",
+			m: &auth{
+				uc: func() *mock_usecase.MockUser {
+					mock := mock_usecase.NewMockUser(ctrl)
+					mock.EXPECT().VerifyAPIToken(gomock.Any(), apiToken).Return("", nil, false)
+					return mock
+				}(),
+			},
+			ctx: func() *mock_handlerctx.MockAPIContext {
+				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				mock.EXPECT().RequestContext().Return(context.Background()).AnyTimes()
+				header := http.Header{"Authorization": {"Bearer " + apiToken}}
+				gomock.InOrder(
+					mock.EXPECT().RequestHeader().Return(header),
+					mock.EXPECT().WriteStatusCode(http.StatusUnauthorized),
+				)
+				return mock
+			}(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.m.VerifyScope(model.APIScopeRead)(next)(tt.ctx); err != nil {
+				t.Errorf("予期せぬエラー(error: %s)", err)
+			}
+		})
+	}
+}
@@ -1,54 +1,112 @@
 package middleware
 
 import (
+	"GoBBS/config"
 	"GoBBS/interface/handler/handlerctx"
 	"GoBBS/interface/middleware/middlewarehelper"
+	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 )
 
 type (
 	// CORS CORSミドルウェア
 	// mockgen -source interface/middleware/cors_middleware.go -destination mock/mock_middleware/cors_middleware_mock.go
 	CORS interface {
-		AddResponseHeader(middlewarehelper.HandlerFunc) middlewarehelper.HandlerFunc
+		Handle(middlewarehelper.HandlerFunc) middlewarehelper.HandlerFunc
 	}
 
 	// cors corsミドルウェア
 	cors struct {
-		allowOrigin  string
-		allowMethods []string
-		allowHeaders []string
-		maxAge       int
+		// settings リクエストごとに現在の設定を返す(設定の再読み込みを反映するため)
+		settings func() config.CORSConfig
 	}
 )
 
 var _ CORS = (*cors)(nil)
 
 // NewCORS corsミドルウェアを生成する
-func NewCORS(allowOrigin string, allowMethods []string, allowHeaders []string, maxAge int) *cors {
-	return &cors{
-		allowOrigin:  allowOrigin,
-		allowMethods: allowMethods,
-		allowHeaders: allowHeaders,
-		maxAge:       maxAge,
-	}
+func NewCORS(settings func() config.CORSConfig) *cors {
+	return &cors{settings: settings}
 }
 
-// AddResponseHeader レスポンスヘッダを追加
-func (cors *cors) AddResponseHeader(next middlewarehelper.HandlerFunc) middlewarehelper.HandlerFunc {
+// Handle 許可されたオリジンからのリクエストにCORSのレスポンスヘッダーを追加する
+// プリフライト(Access-Control-Request-Methodを付けたOPTIONS)にはハンドラーを呼ばずに応答する
+// 許可されていないオリジンにはCORSのヘッダーを付けない(プリフライトは403を返す)
+func (cors *cors) Handle(next middlewarehelper.HandlerFunc) middlewarehelper.HandlerFunc {
 	return func(c handlerctx.APIContext) error {
-		c.AddResponseHeader("Access-Control-Allow-Origin", cors.allowOrigin)
+		header := c.RequestHeader()
+		preflight := c.RequestMethod() == http.MethodOptions && header.Get("Access-Control-Request-Method") != ""
+
+		// レスポンスはOriginによって変わるため、キャッシュに区別させる
+		c.AddResponseHeader("Vary", "Origin")
+		if preflight {
+			c.AddResponseHeader("Vary", "Access-Control-Request-Method")
+			c.AddResponseHeader("Vary", "Access-Control-Request-Headers")
+		}
+
+		origin := header.Get("Origin")
+		if origin == "" {
+			return next(c)
+		}
+		settings := cors.settings()
+		if !MatchOrigin(settings.AllowOrigins, origin) {
+			if preflight {
+				c.WriteStatusCode(http.StatusForbidden)
+				return nil
+			}
+			return next(c)
+		}
 
-		for _, method := range cors.allowMethods {
-			c.AddResponseHeader("Access-Control-Allow-Methods", method)
+		c.AddResponseHeader("Access-Control-Allow-Origin", origin)
+		if settings.AllowCredentials {
+			c.AddResponseHeader("Access-Control-Allow-Credentials", "true")
+		}
+		if !preflight {
+			return next(c)
 		}
 
-		for _, header := range cors.allowHeaders {
-			c.AddResponseHeader("Access-Control-Allow-Headers", header)
+		if methods := allowList(settings.AllowMethods, header.Get("Access-Control-Request-Method")); methods != "" {
+			c.AddResponseHeader("Access-Control-Allow-Methods", methods)
+		}
+		if headers := allowList(settings.AllowHeaders, header.Get("Access-Control-Request-Headers")); headers != "" {
+			c.AddResponseHeader("Access-Control-Allow-Headers", headers)
 		}
+		if settings.MaxAge > 0 {
+			c.AddResponseHeader("Access-Control-Max-Age", strconv.Itoa(settings.MaxAge))
+		}
+		c.WriteStatusCode(http.StatusNoContent)
+		return nil
+	}
+}
 
-		c.AddResponseHeader("Access-Control-Max-Age", strconv.Itoa(cors.maxAge))
+// MatchOrigin オリジンが許可されたオリジンのいずれかに一致するか判定する
+// "*"は全てのオリジン、"https://*.example.com"はexample.comのサブドメイン(example.com自体は含まない)に一致する
+func MatchOrigin(allowOrigins []string, origin string) bool {
+	origin = strings.ToLower(origin)
+	for _, allow := range allowOrigins {
+		allow = strings.ToLower(allow)
+		if allow == "*" || allow == origin {
+			return true
+		}
+		prefix, suffix, ok := strings.Cut(allow, "://*.")
+		if !ok {
+			continue
+		}
+		scheme, host, ok := strings.Cut(origin, "://")
+		if ok && scheme == prefix && strings.HasSuffix(host, "."+suffix) && len(host) > len(suffix)+1 {
+			return true
+		}
+	}
+	return false
+}
 
-		return next(c)
+// allowList プリフライトに返す許可リストをカンマ区切りで返す
+// "*"を含む場合は、クッキー付きのリクエストでも使えるようにリクエストされた値をそのまま返す
+func allowList(allowed []string, requested string) string {
+	if slices.Contains(allowed, "*") {
+		return requested
 	}
+	return strings.Join(allowed, ", ")
 }
@@ -1,9 +1,11 @@
 package middleware
 
 import (
+	"GoBBS/config"
 	"GoBBS/interface/handler/handlerctx"
-	"GoBBS/interface/middleware/middlewarehelper"
 	"GoBBS/mock/mock_handler/mock_handlerctx"
+	"errors"
+	"net/http"
 	"reflect"
 	"testing"
 
@@ -11,86 +13,194 @@ import (
 )
 
 func TestNewCORS(t *testing.T) {
-	type args struct {
-		allowOrigin  string
-		allowMethods []string
-		allowHeaders []string
-		maxAge       int
+	want := config.CORSConfig{
+		AllowOrigins: []string{"a"},
+		AllowMethods: []string{"b", "c"},
+		AllowHeaders: []string{"d", "e"},
+		MaxAge:       10,
 	}
-	tests := []struct {
-		name string
-		args args
-		want *cors
-	}{
-		{
-			name: "正常ケース",
-			args: args{
-				allowOrigin:  "a",
-				allowMethods: []string{"b", "c"},
-				allowHeaders: []string{"d", "e"},
-				maxAge:       10,
-			},
-			want: &cors{
-				allowOrigin:  "a",
-				allowMethods: []string{"b", "c"},
-				allowHeaders: []string{"d", "e"},
-				maxAge:       10,
-			},
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if got := NewCORS(tt.args.allowOrigin, tt.args.allowMethods, tt.args.allowHeaders, tt.args.maxAge); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("NewCORS() = %v, want %v", got, tt.want)
-			}
-		})
+	got := NewCORS(func() config.CORSConfig { return want })
+	if !reflect.DeepEqual(got.settings(), want) {
+		t.Errorf("NewCORS() = %v, want %v", got.settings(), want)
 	}
 }
 
-func Test_cors_AddResponseHeader(t *testing.T) {
+func Test_cors_Handle(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	type args struct {
-		next middlewarehelper.HandlerFunc
+	errNext := errors.New("next")
+	settings := config.CORSConfig{
+		AllowOrigins:     []string{"http://localhost", "https://*.example.com"},
+		AllowMethods:     []string{"GET", "POST"},
+		AllowHeaders:     []string{"Content-Type", "X-CSRF-Token"},
+		AllowCredentials: true,
+		MaxAge:           10,
 	}
+
 	tests := []struct {
-		name       string
-		cors       *cors
-		args       args
-		apiContext handlerctx.APIContext
+		name     string
+		settings config.CORSConfig
+		ctx      func() handlerctx.APIContext
+		wantErr  error
 	}{
 		{
-			name: "指定値",
-			cors: &cors{
-				allowOrigin:  "a",
-				allowMethods: []string{"b", "c"},
-				allowHeaders: []string{"d", "e"},
-				maxAge:       10,
+			name:     "正常ケース(Originなし)",
+			settings: settings,
+			ctx: func() handlerctx.APIContext {
+				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestHeader().Return(http.Header{}),
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().AddResponseHeader("Vary", "Origin"),
+				)
+				return mock
+			},
+			wantErr: errNext,
+		},
+		{
+			name:     "正常ケース(許可されたオリジン)",
+			settings: settings,
+			ctx: func() handlerctx.APIContext {
+				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestHeader().Return(http.Header{"Origin": {"http://localhost"}}),
+					mock.EXPECT().RequestMethod().Return(http.MethodPost),
+					mock.EXPECT().AddResponseHeader("Vary", "Origin"),
+					mock.EXPECT().AddResponseHeader("Access-Control-Allow-Origin", "http://localhost"),
+					mock.EXPECT().AddResponseHeader("Access-Control-Allow-Credentials", "true"),
+				)
+				return mock
 			},
-			args: args{
-				next: func(c handlerctx.APIContext) error {
-					return nil
-				},
+			wantErr: errNext,
+		},
+		{
+			name:     "正常ケース(許可されていないオリジン)",
+			settings: settings,
+			ctx: func() handlerctx.APIContext {
+				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().RequestHeader().Return(http.Header{"Origin": {"https://example.com"}}),
+					mock.EXPECT().RequestMethod().Return(http.MethodGet),
+					mock.EXPECT().AddResponseHeader("Vary", "Origin"),
+				)
+				return mock
 			},
-			apiContext: func() *mock_handlerctx.MockAPIContext {
+			wantErr: errNext,
+		},
+		{
+			name:     "正常ケース(プリフライト)",
+			settings: settings,
+			ctx: func() handlerctx.APIContext {
 				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				header := http.Header{
+					"Origin":                         {"https://app.example.com"},
+					"Access-Control-Request-Method":  {"POST"},
+					"Access-Control-Request-Headers": {"content-type,x-csrf-token"},
+				}
 				gomock.InOrder(
-					mock.EXPECT().AddResponseHeader("Access-Control-Allow-Origin", "a"),
-					mock.EXPECT().AddResponseHeader("Access-Control-Allow-Methods", "b"),
-					mock.EXPECT().AddResponseHeader("Access-Control-Allow-Methods", "c"),
-					mock.EXPECT().AddResponseHeader("Access-Control-Allow-Headers", "d"),
-					mock.EXPECT().AddResponseHeader("Access-Control-Allow-Headers", "e"),
+					mock.EXPECT().RequestHeader().Return(header),
+					mock.EXPECT().RequestMethod().Return(http.MethodOptions),
+					mock.EXPECT().AddResponseHeader("Vary", "Origin"),
+					mock.EXPECT().AddResponseHeader("Vary", "Access-Control-Request-Method"),
+					mock.EXPECT().AddResponseHeader("Vary", "Access-Control-Request-Headers"),
+					mock.EXPECT().AddResponseHeader("Access-Control-Allow-Origin", "https://app.example.com"),
+					mock.EXPECT().AddResponseHeader("Access-Control-Allow-Credentials", "true"),
+					mock.EXPECT().AddResponseHeader("Access-Control-Allow-Methods", "GET, POST"),
+					mock.EXPECT().AddResponseHeader("Access-Control-Allow-Headers", "Content-Type, X-CSRF-Token"),
 					mock.EXPECT().AddResponseHeader("Access-Control-Max-Age", "10"),
+					mock.EXPECT().WriteStatusCode(http.StatusNoContent),
+				)
+				return mock
+			},
+		},
+		{
+			name: "正常ケース(プリフライトでリクエストされた値を全て許可)",
+			settings: config.CORSConfig{
+				AllowOrigins: []string{"*"},
+				AllowMethods: []string{"*"},
+				AllowHeaders: []string{"*"},
+			},
+			ctx: func() handlerctx.APIContext {
+				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				header := http.Header{
+					"Origin":                         {"http://example.org"},
+					"Access-Control-Request-Method":  {"DELETE"},
+					"Access-Control-Request-Headers": {"authorization"},
+				}
+				gomock.InOrder(
+					mock.EXPECT().RequestHeader().Return(header),
+					mock.EXPECT().RequestMethod().Return(http.MethodOptions),
+					mock.EXPECT().AddResponseHeader("Vary", "Origin"),
+					mock.EXPECT().AddResponseHeader("Vary", "Access-Control-Request-Method"),
+					mock.EXPECT().AddResponseHeader("Vary", "Access-Control-Request-Headers"),
+					mock.EXPECT().AddResponseHeader("Access-Control-Allow-Origin", "http://example.org"),
+					mock.EXPECT().AddResponseHeader("Access-Control-Allow-Methods", "DELETE"),
+					mock.EXPECT().AddResponseHeader("Access-Control-Allow-Headers", "authorization"),
+					mock.EXPECT().WriteStatusCode(http.StatusNoContent),
+				)
+				return mock
+			},
+		},
+		{
+			name:     "異常ケース(許可されていないオリジンのプリフライト)",
+			settings: settings,
+			ctx: func() handlerctx.APIContext {
+				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				header := http.Header{
+					"Origin":                        {"http://example.org"},
+					"Access-Control-Request-Method": {"POST"},
+				}
+				gomock.InOrder(
+					mock.EXPECT().RequestHeader().Return(header),
+					mock.EXPECT().RequestMethod().Return(http.MethodOptions),
+					mock.EXPECT().AddResponseHeader("Vary", "Origin"),
+					mock.EXPECT().AddResponseHeader("Vary", "Access-Control-Request-Method"),
+					mock.EXPECT().AddResponseHeader("Vary", "Access-Control-Request-Headers"),
+					mock.EXPECT().WriteStatusCode(http.StatusForbidden),
 				)
 				return mock
-			}(),
+			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := tt.cors.AddResponseHeader(tt.args.next)
-			got(tt.apiContext)
+			m := NewCORS(func() config.CORSConfig { return tt.settings })
+			next := func(c handlerctx.APIContext) error {
+				return errNext
+			}
+			if err := m.Handle(next)(tt.ctx()); !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMatchOrigin(t *testing.T) {
+	allow := []string{"http://localhost:3000", "https://*.example.com"}
+
+	tests := []struct {
+		name   string
+		allow  []string
+		origin string
+		want   bool
+	}{
+		{name: "正常ケース(一致)", allow: allow, origin: "http://localhost:3000", want: true},
+		{name: "正常ケース(大文字小文字を区別しない)", allow: allow, origin: "http://LOCALHOST:3000", want: true},
+		{name: "正常ケース(サブドメイン)", allow: allow, origin: "https://app.example.com", want: true},
+		{name: "正常ケース(多段のサブドメイン)", allow: allow, origin: "https://a.b.example.com", want: true},
+		{name: "正常ケース(全オリジン許可)", allow: []string{"*"}, origin: "http://example.org", want: true},
+		{name: "異常ケース(ポート違い)", allow: allow, origin: "http://localhost:8080", want: false},
+		{name: "異常ケース(サブドメインのパターンに親ドメイン)", allow: allow, origin: "https://example.com", want: false},
+		{name: "異常ケース(スキーム違い)", allow: allow, origin: "http://app.example.com", want: false},
+		{name: "異常ケース(末尾が一致する別ドメイン)", allow: allow, origin: "https://evilexample.com", want: false},
+		{name: "異常ケース(許可なし)", allow: nil, origin: "http://localhost:3000", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchOrigin(tt.allow, tt.origin); got != tt.want {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
 		})
 	}
 }
@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/interface/metrics"
+	"GoBBS/interface/middleware/middlewarehelper"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type (
+	// Metrics リクエストのメトリクスを記録するミドルウェア
+	// mockgen -source interface/middleware/metrics_middleware.go -destination mock/mock_middleware/metrics_middleware_mock.go
+	Metrics interface {
+		Observe(middlewarehelper.HandlerFunc) middlewarehelper.HandlerFunc
+	}
+
+	// metricsRecorder リクエストのメトリクスを記録するミドルウェア
+	metricsRecorder struct {
+		requests *prometheus.CounterVec
+		duration *prometheus.HistogramVec
+		now      func() time.Time
+	}
+)
+
+var _ Metrics = (*metricsRecorder)(nil)
+
+// methodOther 標準以外のメソッドのラベル
+const methodOther = "OTHER"
+
+// knownMethods ラベルにそのまま使うメソッド(任意のメソッド名でラベルの種類が増えないようにする)
+var knownMethods = map[string]struct{}{
+	http.MethodGet:     {},
+	http.MethodHead:    {},
+	http.MethodPost:    {},
+	http.MethodPut:     {},
+	http.MethodPatch:   {},
+	http.MethodDelete:  {},
+	http.MethodOptions: {},
+}
+
+// NewMetrics リクエストのメトリクスを記録するミドルウェアを生成する
+func NewMetrics() *metricsRecorder {
+	return &metricsRecorder{
+		requests: metrics.HTTPRequests,
+		duration: metrics.HTTPRequestDuration,
+		now:      time.Now,
+	}
+}
+
+// Observe ルーティング、メソッド、ステータスごとにリクエスト数と処理時間を記録する
+// パスではなくルーティングのパターンをラベルにし、パスパラメーターでラベルの種類が増えないようにする
+// メソッドも標準以外はOTHERにまとめる
+func (m *metricsRecorder) Observe(next middlewarehelper.HandlerFunc) middlewarehelper.HandlerFunc {
+	return func(c handlerctx.APIContext) error {
+		start := m.now()
+		err := next(c)
+
+		labels := prometheus.Labels{
+			"route":  c.Route(),
+			"method": methodLabel(c.RequestMethod()),
+			"status": strconv.Itoa(responseStatus(c, err)),
+		}
+		m.requests.With(labels).Inc()
+		m.duration.With(labels).Observe(m.now().Sub(start).Seconds())
+
+		return err
+	}
+}
+
+// methodLabel メソッドのラベル(標準以外のメソッドはOTHER)
+func methodLabel(method string) string {
+	if _, ok := knownMethods[method]; ok {
+		return method
+	}
+	return methodOther
+}
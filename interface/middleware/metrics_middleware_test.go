@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/interface/metrics"
+	"GoBBS/mock/mock_handler/mock_handlerctx"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestNewMetrics(t *testing.T) {
+	got := NewMetrics()
+	if got.requests != metrics.HTTPRequests ||
+		got.duration != metrics.HTTPRequestDuration ||
+		reflect.ValueOf(got.now).Pointer() != reflect.ValueOf(time.Now).Pointer() {
+		t.Errorf("NewMetrics() = %v", got)
+	}
+}
+
+func Test_metricsRecorder_Observe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errNext := errors.New("next")
+	start := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		method     string
+		statusCode int
+		nextErr    error
+		wantMethod string
+		wantStatus string
+	}{
+		{name: "正常ケース", method: http.MethodGet, statusCode: http.StatusNotFound, nextErr: nil, wantMethod: http.MethodGet, wantStatus: "404"},
+		{name: "正常ケース(エラーは500)", method: http.MethodGet, statusCode: 0, nextErr: errNext, wantMethod: http.MethodGet, wantStatus: "500"},
+		{name: "正常ケース(標準以外のメソッドはOTHER)", method: "PROPFIND", statusCode: http.StatusMethodNotAllowed, nextErr: nil, wantMethod: "OTHER", wantStatus: "405"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := mock_handlerctx.NewMockAPIContext(ctrl)
+			mock.EXPECT().Route().Return("/users/")
+			mock.EXPECT().RequestMethod().Return(tt.method)
+			mock.EXPECT().StatusCode().Return(tt.statusCode).AnyTimes()
+
+			times := []time.Time{start, start.Add(250 * time.Millisecond)}
+			m := &metricsRecorder{
+				requests: prometheus.NewCounterVec(prometheus.CounterOpts{Name: "requests"}, []string{"route", "method", "status"}),
+				duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: "duration"}, []string{"route", "method", "status"}),
+				now: func() time.Time {
+					now := times[0]
+					times = times[1:]
+					return now
+				},
+			}
+			next := func(c handlerctx.APIContext) error { return tt.nextErr }
+			if err := m.Observe(next)(mock); err != tt.nextErr {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.nextErr)
+			}
+
+			if got := testutil.ToFloat64(m.requests.WithLabelValues("/users/", tt.wantMethod, tt.wantStatus)); got != 1 {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, 1)
+			}
+			if got := testutil.CollectAndCount(m.duration); got != 1 {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, 1)
+			}
+		})
+	}
+}
@@ -3,8 +3,9 @@ package middlewarehelper
 import (
 	"GoBBS/interface/handler/handlerctx"
 	"errors"
-	"log"
+	"log/slog"
 	"net/http"
+	"sync"
 )
 
 type (
@@ -16,20 +17,62 @@ type (
 
 	HandlerFunc    func(handlerctx.APIContext) error
 	MiddlewareFunc func(HandlerFunc) HandlerFunc
+	// PanicHandler ミドルウェア・ハンドラーで回復されなかったパニックの処理
+	PanicHandler func(c handlerctx.APIContext, rec any) error
 )
 
 var ErrContextKeyNotFound = errors.New("context key not found")
 
+var (
+	defaultsMu sync.RWMutex
+	// defaults 全てのハンドラーに適用するミドルウェア
+	defaults []MiddlewareFunc
+	// panicHandler Applyで回復したパニックの処理(未登録の場合はパニックをそのまま伝える)
+	panicHandler PanicHandler
+)
+
+// Use 全てのハンドラーに適用するミドルウェアを追加する
+// Applyで指定したミドルウェアより先に追加順で適用する(Applyより前に呼び出す)
+func Use(m ...MiddlewareFunc) {
+	defaultsMu.Lock()
+	defer defaultsMu.Unlock()
+
+	defaults = append(defaults, m...)
+}
+
+// OnPanic ミドルウェア・ハンドラーで回復されなかったパニックの処理を登録する(Applyより前に呼び出す)
+// パニック回復ミドルウェアより前に適用したミドルウェア(リクエストID、アクセスログなど)のパニックの回復に使う
+func OnPanic(h PanicHandler) {
+	defaultsMu.Lock()
+	defer defaultsMu.Unlock()
+
+	panicHandler = h
+}
+
 // Apply ハンドラーにミドルウェアを適用する
 func Apply(ctxFactory handlerctx.APIContextFactory, h HandlerFunc, m ...MiddlewareFunc) http.HandlerFunc {
+	defaultsMu.RLock()
+	chain := append(append([]MiddlewareFunc{}, defaults...), m...)
+	onPanic := panicHandler
+	defaultsMu.RUnlock()
+
 	f := h
-	for i := len(m) - 1; i >= 0; i-- {
-		f = m[i](f)
+	for i := len(chain) - 1; i >= 0; i-- {
+		f = chain[i](f)
 	}
 	return func(w http.ResponseWriter, r *http.Request) {
 		c := ctxFactory(w, r)
+		if onPanic != nil {
+			defer func() {
+				if rec := recover(); rec != nil {
+					if err := onPanic(c, rec); err != nil {
+						slog.ErrorContext(c.RequestContext(), "handlerfunc error", "error", err)
+					}
+				}
+			}()
+		}
 		if err := f(c); err != nil {
-			log.Printf("handlerfunc error: %v", err)
+			slog.ErrorContext(c.RequestContext(), "handlerfunc error", "error", err)
 			w.WriteHeader(http.StatusInternalServerError)
 		}
 	}
@@ -4,10 +4,12 @@ import (
 	"GoBBS/interface/handler/handlerctx"
 	"GoBBS/mock/mock_handler/mock_handlerctx"
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -76,7 +78,10 @@ func TestApply(t *testing.T) {
 			args: args{
 				ctxFactory: func(w http.ResponseWriter, r *http.Request) handlerctx.APIContext {
 					mock := mock_handlerctx.NewMockAPIContext(ctrl)
-					mock.EXPECT().SetPathParam(gomock.Any())
+					gomock.InOrder(
+						mock.EXPECT().SetPathParam(gomock.Any()),
+						mock.EXPECT().RequestContext().Return(context.Background()),
+					)
 					return mock
 				},
 				h: func() HandlerFunc {
@@ -119,3 +124,103 @@ func TestApply(t *testing.T) {
 		})
 	}
 }
+
+func TestApply_recover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errHandle := errors.New("handle")
+
+	tests := []struct {
+		name      string
+		handler   func(got *[]any) PanicHandler
+		wantRecs  []any
+		wantPanic any
+	}{
+		{
+			name: "正常ケース(登録した処理でパニックを回復する)",
+			handler: func(got *[]any) PanicHandler {
+				return func(c handlerctx.APIContext, rec any) error {
+					*got = append(*got, rec)
+					return nil
+				}
+			},
+			wantRecs: []any{"test"},
+		},
+		{
+			name: "正常ケース(処理のエラーは記録のみ)",
+			handler: func(got *[]any) PanicHandler {
+				return func(c handlerctx.APIContext, rec any) error {
+					*got = append(*got, rec)
+					return errHandle
+				}
+			},
+			wantRecs: []any{"test"},
+		},
+		{
+			name:      "正常ケース(未登録の場合はそのまま伝える)",
+			handler:   func(got *[]any) PanicHandler { return nil },
+			wantRecs:  []any{},
+			wantPanic: "test",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(func() { panicHandler = nil })
+
+			mock := mock_handlerctx.NewMockAPIContext(ctrl)
+			mock.EXPECT().RequestContext().Return(context.Background()).AnyTimes()
+
+			recs := []any{}
+			OnPanic(tt.handler(&recs))
+
+			// パニック回復ミドルウェアより前に適用したミドルウェアのパニック
+			h := Apply(
+				func(w http.ResponseWriter, r *http.Request) handlerctx.APIContext { return mock },
+				func(c handlerctx.APIContext) error { return nil },
+				func(next HandlerFunc) HandlerFunc {
+					return func(c handlerctx.APIContext) error { panic("test") }
+				},
+			)
+
+			defer func() {
+				if got := recover(); got != tt.wantPanic {
+					t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.wantPanic)
+				}
+				if !reflect.DeepEqual(recs, tt.wantRecs) {
+					t.Errorf("戻り値不一致 got: %#v want: %#v", recs, tt.wantRecs)
+				}
+			}()
+			h(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		})
+	}
+}
+
+func TestUse(t *testing.T) {
+	t.Cleanup(func() { defaults = nil })
+
+	calls := []string{}
+	record := func(name string) MiddlewareFunc {
+		return func(next HandlerFunc) HandlerFunc {
+			return func(c handlerctx.APIContext) error {
+				calls = append(calls, name)
+				return next(c)
+			}
+		}
+	}
+	Use(record("default1"), record("default2"))
+
+	h := Apply(
+		func(w http.ResponseWriter, r *http.Request) handlerctx.APIContext { return nil },
+		func(c handlerctx.APIContext) error {
+			calls = append(calls, "handler")
+			return nil
+		},
+		record("route"),
+	)
+	h(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if want := []string{"default1", "default2", "route", "handler"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", calls, want)
+	}
+}
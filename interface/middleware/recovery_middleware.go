@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"GoBBS/dto"
+	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/interface/metrics"
+	"GoBBS/interface/middleware/middlewarehelper"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type (
+	// Recovery パニック回復ミドルウェア
+	// mockgen -source interface/middleware/recovery_middleware.go -destination mock/mock_middleware/recovery_middleware_mock.go
+	Recovery interface {
+		Recover(middlewarehelper.HandlerFunc) middlewarehelper.HandlerFunc
+		HandlePanic(c handlerctx.APIContext, rec any) error
+	}
+
+	// recovery パニック回復ミドルウェア
+	recovery struct {
+		logger *slog.Logger
+		panics *prometheus.CounterVec
+	}
+)
+
+var _ Recovery = (*recovery)(nil)
+
+// NewRecovery パニック回復ミドルウェアを生成する
+func NewRecovery(logger *slog.Logger) *recovery {
+	return &recovery{logger: logger, panics: metrics.Panics}
+}
+
+// Recover 後続のミドルウェア・ハンドラーで発生したパニックを回復し、HandlePanicで500を返す
+// アクセスログ・メトリクスに500として記録されるよう、それらより後に適用する
+func (m *recovery) Recover(next middlewarehelper.HandlerFunc) middlewarehelper.HandlerFunc {
+	return func(c handlerctx.APIContext) (err error) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				err = m.HandlePanic(c, rec)
+			}
+		}()
+
+		return next(c)
+	}
+}
+
+// HandlePanic 回復したパニックのスタックトレースを記録して500を返す
+// レスポンスを書き込み済みの場合はステータスを変更できないため、記録のみ行う
+// 適用順でRecoverより前のミドルウェアのパニックもmiddlewarehelper.OnPanicに登録して同じ処理にする
+func (m *recovery) HandlePanic(c handlerctx.APIContext, rec any) error {
+	// net/httpがレスポンスを中断するためのパニックはそのまま伝える
+	if rec == http.ErrAbortHandler {
+		panic(rec)
+	}
+
+	m.panics.WithLabelValues(c.Route()).Inc()
+	m.logger.LogAttrs(c.RequestContext(), slog.LevelError, "panic recovered",
+		slog.Any("panic", rec),
+		slog.String("stack", string(debug.Stack())),
+	)
+
+	if c.StatusCode() != 0 {
+		return nil
+	}
+	return c.WriteResponseJSON(
+		http.StatusInternalServerError,
+		dto.NewError(http.StatusText(http.StatusInternalServerError), c.RequestID()),
+	)
+}
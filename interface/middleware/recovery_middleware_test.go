@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"GoBBS/dto"
+	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/interface/logging"
+	"GoBBS/interface/metrics"
+	"GoBBS/interface/middleware/middlewarehelper"
+	"GoBBS/mock/mock_handler/mock_handlerctx"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestNewRecovery(t *testing.T) {
+	logger := slog.Default()
+	got := NewRecovery(logger)
+	if got.logger != logger || got.panics != metrics.Panics {
+		t.Errorf("NewRecovery() = %v", got)
+	}
+}
+
+func Test_recovery_Recover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errNext := errors.New("next")
+	errWrite := errors.New("write")
+
+	tests := []struct {
+		name       string
+		next       middlewarehelper.HandlerFunc
+		c          func() handlerctx.APIContext
+		wantErr    error
+		wantPanics float64
+	}{
+		{
+			name: "正常ケース(パニックなし)",
+			next: func(c handlerctx.APIContext) error { return errNext },
+			c: func() handlerctx.APIContext {
+				return mock_handlerctx.NewMockAPIContext(ctrl)
+			},
+			wantErr:    errNext,
+			wantPanics: 0,
+		},
+		{
+			name: "正常ケース(パニックを回復して500を返す)",
+			next: func(c handlerctx.APIContext) error { panic("boom") },
+			c: func() handlerctx.APIContext {
+				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().Route().Return("/users/"),
+					mock.EXPECT().RequestContext().Return(logging.WithRequestID(context.Background(), "abc")),
+					mock.EXPECT().StatusCode().Return(0),
+					mock.EXPECT().RequestID().Return("abc"),
+					mock.EXPECT().WriteResponseJSON(http.StatusInternalServerError, dto.NewError("Internal Server Error", "abc")),
+				)
+				return mock
+			},
+			wantErr:    nil,
+			wantPanics: 1,
+		},
+		{
+			name: "正常ケース(書き込み済みの場合は記録のみ)",
+			next: func(c handlerctx.APIContext) error { panic("boom") },
+			c: func() handlerctx.APIContext {
+				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().Route().Return("/users/"),
+					mock.EXPECT().RequestContext().Return(logging.WithRequestID(context.Background(), "abc")),
+					mock.EXPECT().StatusCode().Return(http.StatusOK),
+				)
+				return mock
+			},
+			wantErr:    nil,
+			wantPanics: 1,
+		},
+		{
+			name: "異常ケース(レスポンス書き込み失敗)",
+			next: func(c handlerctx.APIContext) error { panic(errors.New("boom")) },
+			c: func() handlerctx.APIContext {
+				mock := mock_handlerctx.NewMockAPIContext(ctrl)
+				gomock.InOrder(
+					mock.EXPECT().Route().Return("/users/"),
+					mock.EXPECT().RequestContext().Return(logging.WithRequestID(context.Background(), "abc")),
+					mock.EXPECT().StatusCode().Return(0),
+					mock.EXPECT().RequestID().Return("abc"),
+					mock.EXPECT().WriteResponseJSON(http.StatusInternalServerError, gomock.Any()).Return(errWrite),
+				)
+				return mock
+			},
+			wantErr:    errWrite,
+			wantPanics: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			m := &recovery{
+				logger: logging.NewLogger(&buf, slog.LevelInfo),
+				panics: prometheus.NewCounterVec(prometheus.CounterOpts{Name: "panics"}, []string{"route"}),
+			}
+			if err := m.Recover(tt.next)(tt.c()); err != tt.wantErr {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+
+			if got := testutil.ToFloat64(m.panics.WithLabelValues("/users/")); got != tt.wantPanics {
+				t.Errorf("パニック数不一致 got: %#v want: %#v", got, tt.wantPanics)
+			}
+			if tt.wantPanics == 0 {
+				if buf.Len() != 0 {
+					t.Errorf("予期せぬログ出力 got: %s", buf.String())
+				}
+				return
+			}
+
+			var got map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatalf("予期せぬエラー(error: %s)", err)
+			}
+			if got["level"] != "ERROR" || got["msg"] != "panic recovered" || got["panic"] != "boom" || got["request_id"] != "abc" {
+				t.Errorf("ログ不一致 got: %#v", got)
+			}
+			if stack, _ := got["stack"].(string); !strings.Contains(stack, "recovery_middleware_test.go") {
+				t.Errorf("スタックトレース不一致 got: %#v", stack)
+			}
+		})
+	}
+}
+
+func Test_recovery_Recover_abortHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewRecovery(slog.Default())
+	next := func(c handlerctx.APIContext) error { panic(http.ErrAbortHandler) }
+
+	defer func() {
+		if got := recover(); got != http.ErrAbortHandler {
+			t.Errorf("パニック不一致 got: %#v want: %#v", got, http.ErrAbortHandler)
+		}
+	}()
+	m.Recover(next)(mock_handlerctx.NewMockAPIContext(ctrl))
+}
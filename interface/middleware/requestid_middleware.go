@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/interface/middleware/middlewarehelper"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+type (
+	// RequestID リクエストIDミドルウェア
+	// mockgen -source interface/middleware/requestid_middleware.go -destination mock/mock_middleware/requestid_middleware_mock.go
+	RequestID interface {
+		Assign(middlewarehelper.HandlerFunc) middlewarehelper.HandlerFunc
+	}
+
+	// requestID リクエストIDミドルウェア
+	requestID struct {
+		newID func() string
+	}
+)
+
+var _ RequestID = (*requestID)(nil)
+
+const (
+	// HeaderRequestID リクエストIDのヘッダ
+	HeaderRequestID = "X-Request-ID"
+	// maxRequestIDLength 受け付けるリクエストIDの長さの上限
+	maxRequestIDLength = 128
+)
+
+// NewRequestID リクエストIDミドルウェアを生成する
+func NewRequestID() *requestID {
+	return &requestID{newID: newRequestID}
+}
+
+// Assign リクエストにIDを割り当て、レスポンスヘッダで返す
+// リクエストヘッダで指定されたIDが妥当な場合はそれを引き継ぎ、それ以外は新たに生成する
+func (m *requestID) Assign(next middlewarehelper.HandlerFunc) middlewarehelper.HandlerFunc {
+	return func(c handlerctx.APIContext) error {
+		id := c.RequestHeader().Get(HeaderRequestID)
+		if !isValidRequestID(id) {
+			id = m.newID()
+		}
+		c.SetRequestID(id)
+		c.AddResponseHeader(HeaderRequestID, id)
+
+		return next(c)
+	}
+}
+
+// isValidRequestID ログに出力して問題のない(空白・制御文字を含まない)IDか判定する
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID ランダムなリクエストIDを生成する
+func newRequestID() string {
+	b := make([]byte, 16)
+	// crypto/randの読み込みは失敗しない
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/mock/mock_handler/mock_handlerctx"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewRequestID(t *testing.T) {
+	got := NewRequestID()
+	if reflect.ValueOf(got.newID).Pointer() != reflect.ValueOf(newRequestID).Pointer() {
+		t.Errorf("NewRequestID().newID is not newRequestID")
+	}
+}
+
+func Test_requestID_Assign(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errNext := errors.New("next")
+
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{name: "正常ケース(指定されたIDを引き継ぐ)", header: "req-1", want: "req-1"},
+		{name: "正常ケース(未指定は生成する)", header: "", want: "generated"},
+		{name: "正常ケース(空白を含むIDは生成し直す)", header: "req 1", want: "generated"},
+		{name: "正常ケース(長すぎるIDは生成し直す)", header: strings.Repeat("a", maxRequestIDLength+1), want: "generated"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			header.Set(HeaderRequestID, tt.header)
+			mock := mock_handlerctx.NewMockAPIContext(ctrl)
+			gomock.InOrder(
+				mock.EXPECT().RequestHeader().Return(header),
+				mock.EXPECT().SetRequestID(tt.want),
+				mock.EXPECT().AddResponseHeader(HeaderRequestID, tt.want),
+			)
+
+			m := &requestID{newID: func() string { return "generated" }}
+			next := func(c handlerctx.APIContext) error { return errNext }
+			if err := m.Assign(next)(mock); err != errNext {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, errNext)
+			}
+		})
+	}
+}
+
+func Test_newRequestID(t *testing.T) {
+	a, b := newRequestID(), newRequestID()
+	if len(a) != 32 || a == b || !isValidRequestID(a) {
+		t.Errorf("戻り値不一致 got: %#v, %#v", a, b)
+	}
+}
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+
+	"GoBBS/interface/handler/handlerctx"
+)
+
+const (
+	// SessionCookieName ブラウザ向けにトークンを保持するクッキー(JavaScriptから読めない)
+	SessionCookieName = "gobbs_session"
+	// CSRFCookieName CSRFトークンを保持するクッキー(JavaScriptで読み取り、CSRFHeaderNameで送り返す)
+	CSRFCookieName = "gobbs_csrf"
+	// CSRFHeaderName クッキー認証で状態を変更するリクエストにCSRFトークンを付けるヘッダー
+	CSRFHeaderName = "X-CSRF-Token"
+)
+
+// NewCSRFToken ランダムなCSRFトークンを生成する
+func NewCSRFToken() string {
+	b := make([]byte, 32)
+	// crypto/randの読み込みは失敗しない
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// NewSessionCookies ログイン時に設定するセッションのクッキーとCSRFトークンのクッキーを返す
+// どちらもブラウザを閉じるまでのセッションクッキーとし、トークン自体の有効期限で失効させる
+func NewSessionCookies(token string, csrfToken string) []*http.Cookie {
+	return []*http.Cookie{
+		{
+			Name:     SessionCookieName,
+			Value:    token,
+			Path:     "/",
+			HttpOnly: true,
+			Secure:   true,
+			SameSite: http.SameSiteLaxMode,
+		},
+		{
+			Name:     CSRFCookieName,
+			Value:    csrfToken,
+			Path:     "/",
+			Secure:   true,
+			SameSite: http.SameSiteLaxMode,
+		},
+	}
+}
+
+// ExpiredSessionCookies ログアウト時にセッションとCSRFトークンのクッキーを削除するクッキーを返す
+func ExpiredSessionCookies() []*http.Cookie {
+	cookies := NewSessionCookies("", "")
+	for _, cookie := range cookies {
+		cookie.MaxAge = -1
+	}
+	return cookies
+}
+
+// verifyCSRF 状態を変更するリクエストでは、CSRFトークンのヘッダーとクッキーが一致するか検証する(double-submit)
+// 他のサイトからはクッキーの値を読めないため、ヘッダーに同じ値を付けられない
+func verifyCSRF(c handlerctx.APIContext) bool {
+	switch c.RequestMethod() {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+
+	header := c.RequestHeader().Get(CSRFHeaderName)
+	cookie, err := c.RequestCookie(CSRFCookieName)
+	if err != nil || header == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(header), []byte(cookie.Value)) == 1
+}
@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"GoBBS/mock/mock_handler/mock_handlerctx"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewCSRFToken(t *testing.T) {
+	got := NewCSRFToken()
+	if len(got) != 64 {
+		t.Errorf("長さ不一致 got: %#v", got)
+	}
+	if got == NewCSRFToken() {
+		t.Errorf("同じトークンが生成された")
+	}
+}
+
+func TestNewSessionCookies(t *testing.T) {
+	want := []*http.Cookie{
+		{Name: SessionCookieName, Value: "token", Path: "/", HttpOnly: true, Secure: true, SameSite: http.SameSiteLaxMode},
+		{Name: CSRFCookieName, Value: "csrf", Path: "/", Secure: true, SameSite: http.SameSiteLaxMode},
+	}
+	if got := NewSessionCookies("token", "csrf"); !reflect.DeepEqual(got, want) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
+	}
+}
+
+func TestExpiredSessionCookies(t *testing.T) {
+	want := []*http.Cookie{
+		{Name: SessionCookieName, Path: "/", MaxAge: -1, HttpOnly: true, Secure: true, SameSite: http.SameSiteLaxMode},
+		{Name: CSRFCookieName, Path: "/", MaxAge: -1, Secure: true, SameSite: http.SameSiteLaxMode},
+	}
+	if got := ExpiredSessionCookies(); !reflect.DeepEqual(got, want) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
+	}
+}
+
+func Test_verifyCSRF(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name   string
+		method string
+		header string
+		cookie *http.Cookie
+		want   bool
+	}{
+		{name: "正常ケース(GETは検証しない)", method: http.MethodGet, want: true},
+		{name: "正常ケース(HEADは検証しない)", method: http.MethodHead, want: true},
+		{name: "正常ケース(一致)", method: http.MethodPost, header: "csrf", cookie: &http.Cookie{Name: CSRFCookieName, Value: "csrf"}, want: true},
+		{name: "異常ケース(不一致)", method: http.MethodPut, header: "other", cookie: &http.Cookie{Name: CSRFCookieName, Value: "csrf"}, want: false},
+		{name: "異常ケース(ヘッダーなし)", method: http.MethodPatch, cookie: &http.Cookie{Name: CSRFCookieName, Value: ""}, want: false},
+		{name: "異常ケース(クッキーなし)", method: http.MethodDelete, header: "csrf", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := mock_handlerctx.NewMockAPIContext(ctrl)
+			mock.EXPECT().RequestMethod().Return(tt.method)
+			if tt.method != http.MethodGet && tt.method != http.MethodHead {
+				header := http.Header{}
+				if tt.header != "" {
+					header.Set(CSRFHeaderName, tt.header)
+				}
+				mock.EXPECT().RequestHeader().Return(header)
+				if tt.cookie != nil {
+					mock.EXPECT().RequestCookie(CSRFCookieName).Return(tt.cookie, nil)
+				} else {
+					mock.EXPECT().RequestCookie(CSRFCookieName).Return(nil, http.ErrNoCookie)
+				}
+			}
+
+			if got := verifyCSRF(mock); got != tt.want {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/interface/middleware/middlewarehelper"
+	"GoBBS/interface/tracing"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type (
+	// Tracing リクエストのスパンを記録するミドルウェア
+	// mockgen -source interface/middleware/tracing_middleware.go -destination mock/mock_middleware/tracing_middleware_mock.go
+	Tracing interface {
+		Trace(middlewarehelper.HandlerFunc) middlewarehelper.HandlerFunc
+	}
+
+	// tracingMiddleware リクエストのスパンを記録するミドルウェア
+	tracingMiddleware struct {
+		tracer     trace.Tracer
+		propagator propagation.TextMapPropagator
+	}
+)
+
+var _ Tracing = (*tracingMiddleware)(nil)
+
+// NewTracing リクエストのスパンを記録するミドルウェアを生成する
+func NewTracing() *tracingMiddleware {
+	return &tracingMiddleware{
+		tracer:     tracing.Tracer("GoBBS/interface/middleware"),
+		propagator: otel.GetTextMapPropagator(),
+	}
+}
+
+// Trace リクエストヘッダのtraceparentを引き継いでスパンを開始し、リクエストのコンテキストに紐づける
+// ハンドラーはRequestContextをユースケースに渡すことで、ユースケース・DB操作のスパンを子にする
+func (m *tracingMiddleware) Trace(next middlewarehelper.HandlerFunc) middlewarehelper.HandlerFunc {
+	return func(c handlerctx.APIContext) error {
+		ctx := m.propagator.Extract(c.RequestContext(), propagation.HeaderCarrier(c.RequestHeader()))
+		ctx, span := m.tracer.Start(ctx, c.RequestMethod()+" "+c.Route(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", c.RequestMethod()),
+				attribute.String("http.route", c.Route()),
+				attribute.String("url.path", c.URL().Path),
+			),
+		)
+		defer span.End()
+		c.SetRequestContext(ctx)
+
+		err := next(c)
+
+		status := responseStatus(c, err)
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		if err != nil {
+			tracing.RecordError(span, err)
+		} else if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+
+		return err
+	}
+}
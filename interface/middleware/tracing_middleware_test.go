@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"GoBBS/interface/handler/handlerctx"
+	"GoBBS/mock/mock_handler/mock_handlerctx"
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNewTracing(t *testing.T) {
+	got := NewTracing()
+	if got.tracer == nil || got.propagator == nil {
+		t.Errorf("NewTracing() = %v", got)
+	}
+}
+
+func Test_tracingMiddleware_Trace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errNext := errors.New("next")
+	traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+	parentSpanID := "00f067aa0ba902b7"
+
+	tests := []struct {
+		name        string
+		traceparent string
+		statusCode  int
+		nextErr     error
+		wantStatus  int
+		wantCode    codes.Code
+		wantParent  bool
+	}{
+		{
+			name:        "正常ケース(traceparentを引き継ぐ)",
+			traceparent: "00-" + traceID + "-" + parentSpanID + "-01",
+			statusCode:  http.StatusOK,
+			wantStatus:  http.StatusOK,
+			wantCode:    codes.Unset,
+			wantParent:  true,
+		},
+		{
+			name:       "正常ケース(traceparentなし)",
+			statusCode: http.StatusServiceUnavailable,
+			wantStatus: http.StatusServiceUnavailable,
+			wantCode:   codes.Error,
+		},
+		{
+			name:       "正常ケース(エラーは500)",
+			nextErr:    errNext,
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   codes.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			m := &tracingMiddleware{
+				tracer:     sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test"),
+				propagator: propagation.TraceContext{},
+			}
+
+			header := http.Header{}
+			if tt.traceparent != "" {
+				header.Set("traceparent", tt.traceparent)
+			}
+			var requestCtx context.Context
+			mock := mock_handlerctx.NewMockAPIContext(ctrl)
+			mock.EXPECT().RequestContext().Return(context.Background())
+			mock.EXPECT().RequestHeader().Return(header)
+			mock.EXPECT().RequestMethod().Return(http.MethodGet).AnyTimes()
+			mock.EXPECT().Route().Return("/users/").AnyTimes()
+			mock.EXPECT().URL().Return(&url.URL{Path: "/users/1"})
+			mock.EXPECT().SetRequestContext(gomock.Any()).Do(func(ctx context.Context) { requestCtx = ctx })
+			mock.EXPECT().StatusCode().Return(tt.statusCode).AnyTimes()
+
+			next := func(c handlerctx.APIContext) error { return tt.nextErr }
+			if err := m.Trace(next)(mock); err != tt.nextErr {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.nextErr)
+			}
+
+			spans := recorder.Ended()
+			if len(spans) != 1 {
+				t.Fatalf("スパン数不一致 got: %#v want: %#v", len(spans), 1)
+			}
+			span := spans[0]
+			if got := trace.SpanContextFromContext(requestCtx); got.SpanID() != span.SpanContext().SpanID() {
+				t.Errorf("コンテキストのスパン不一致 got: %v want: %v", got.SpanID(), span.SpanContext().SpanID())
+			}
+			if got := span.Name(); got != "GET /users/" {
+				t.Errorf("スパン名不一致 got: %#v want: %#v", got, "GET /users/")
+			}
+			if got := span.SpanKind(); got != trace.SpanKindServer {
+				t.Errorf("スパン種類不一致 got: %v want: %v", got, trace.SpanKindServer)
+			}
+			if got := span.Status().Code; got != tt.wantCode {
+				t.Errorf("ステータス不一致 got: %v want: %v", got, tt.wantCode)
+			}
+			wantAttr := attribute.Int("http.response.status_code", tt.wantStatus)
+			found := false
+			for _, attr := range span.Attributes() {
+				if attr == wantAttr {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("属性不一致 got: %v want: %v", span.Attributes(), wantAttr)
+			}
+			if gotParent := span.Parent().IsValid(); gotParent != tt.wantParent {
+				t.Errorf("親スパン有無不一致 got: %v want: %v", gotParent, tt.wantParent)
+			}
+			if tt.wantParent {
+				if got := span.SpanContext().TraceID().String(); got != traceID {
+					t.Errorf("トレースID不一致 got: %v want: %v", got, traceID)
+				}
+				if got := span.Parent().SpanID().String(); got != parentSpanID {
+					t.Errorf("親スパンID不一致 got: %v want: %v", got, parentSpanID)
+				}
+			}
+		})
+	}
+}
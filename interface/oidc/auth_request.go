@@ -0,0 +1,70 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// AuthRequest 認可リクエストごとに生成し、コールバックまでブラウザに保持させる値
+type AuthRequest struct {
+	// State コールバックが同じブラウザから始めたログインのものか確認する値
+	State string
+	// Nonce IDトークンがこのログインのために発行されたものか確認する値
+	Nonce string
+	// CodeVerifier PKCEで認可コードを横取りされても交換できないようにする値
+	CodeVerifier string
+}
+
+var ErrInvalidAuthRequest = errors.New("invalid auth request")
+
+// authRequestSeparator Encodeで値をつなぐ文字(Base64URLに含まれない文字)
+const authRequestSeparator = "."
+
+// NewAuthRequest 推測できないstate、nonce、code_verifierを生成する
+func NewAuthRequest() (*AuthRequest, error) {
+	values := make([]string, 3)
+	for i := range values {
+		b := make([]byte, 32)
+		if _, err := rand.Read(b); err != nil {
+			return nil, errors.Wrap(err, "NewAuthRequest error")
+		}
+		values[i] = base64.RawURLEncoding.EncodeToString(b)
+	}
+
+	return &AuthRequest{State: values[0], Nonce: values[1], CodeVerifier: values[2]}, nil
+}
+
+// ParseAuthRequest Encodeした値を読み込む
+func ParseAuthRequest(s string) (*AuthRequest, error) {
+	values := strings.Split(s, authRequestSeparator)
+	if len(values) != 3 {
+		return nil, ErrInvalidAuthRequest
+	}
+	for _, v := range values {
+		if v == "" {
+			return nil, ErrInvalidAuthRequest
+		}
+	}
+
+	return &AuthRequest{State: values[0], Nonce: values[1], CodeVerifier: values[2]}, nil
+}
+
+// Encode クッキーに保存できる1つの文字列にする
+func (r *AuthRequest) Encode() string {
+	return strings.Join([]string{r.State, r.Nonce, r.CodeVerifier}, authRequestSeparator)
+}
+
+// CodeChallenge code_verifierから認可リクエストに付けるcode_challenge(S256)を返す
+func (r *AuthRequest) CodeChallenge() string {
+	return CodeChallengeS256(r.CodeVerifier)
+}
+
+// CodeChallengeS256 code_verifierのSHA-256をBase64URLにする(RFC 7636)
+func CodeChallengeS256(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
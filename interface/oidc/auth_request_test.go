@@ -0,0 +1,71 @@
+package oidc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewAuthRequest(t *testing.T) {
+	a, err := NewAuthRequest()
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	b, _ := NewAuthRequest()
+	if len(a.State) != 43 || len(a.Nonce) != 43 || len(a.CodeVerifier) != 43 {
+		t.Errorf("長さ不一致 got: %#v", a)
+	}
+	if a.State == a.Nonce || a.State == b.State || a.CodeVerifier == b.CodeVerifier {
+		t.Errorf("値が重複している got: %#v, %#v", a, b)
+	}
+}
+
+func TestParseAuthRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    *AuthRequest
+		wantErr error
+	}{
+		{name: "正常ケース", s: "state.nonce.verifier", want: &AuthRequest{State: "state", Nonce: "nonce", CodeVerifier: "verifier"}},
+		{name: "異常ケース(値が足りない)", s: "state.nonce", wantErr: ErrInvalidAuthRequest},
+		{name: "異常ケース(空の値)", s: "state..verifier", wantErr: ErrInvalidAuthRequest},
+		{name: "異常ケース(空文字)", s: "", wantErr: ErrInvalidAuthRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAuthRequest(tt.s)
+			if err != tt.wantErr {
+				t.Fatalf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthRequest_Encode(t *testing.T) {
+	req, err := NewAuthRequest()
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	got, err := ParseAuthRequest(req.Encode())
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	if !reflect.DeepEqual(got, req) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, req)
+	}
+}
+
+func TestCodeChallengeS256(t *testing.T) {
+	// RFC 7636 Appendix Bの例
+	want := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+	if got := CodeChallengeS256("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"); got != want {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
+	}
+	req := &AuthRequest{CodeVerifier: "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"}
+	if got := req.CodeChallenge(); got != want {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
+	}
+}
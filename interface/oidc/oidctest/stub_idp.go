@@ -0,0 +1,230 @@
+// Package oidctest OpenID Connectのログインを試すためのIdPのスタブ
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type (
+	// StubIdP 認可リクエストを自動で承認し、IDトークンを発行するIdP
+	// 認可エンドポイントは同意画面を出さずにredirect_uriへ認可コードを付けてリダイレクトする
+	StubIdP struct {
+		Server       *httptest.Server
+		ClientID     string
+		ClientSecret string
+		KeyID        string
+		Key          *rsa.PrivateKey
+
+		// Subject, Email, EmailVerified, Name 次に発行するIDトークンのクレーム
+		Subject       string
+		Email         string
+		EmailVerified bool
+		Name          string
+		// ModifyClaims 発行するIDトークンのクレームを書き換える(不正なトークンを試す場合に使う)
+		ModifyClaims func(claims jwt.MapClaims)
+
+		mu    sync.Mutex
+		codes map[string]authorization
+	}
+
+	// authorization 認可コードに紐づく認可リクエスト
+	authorization struct {
+		redirectURI   string
+		nonce         string
+		codeChallenge string
+	}
+)
+
+// NewStubIdP IdPのスタブを起動する(Closeで停止する)
+func NewStubIdP() *StubIdP {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+
+	idp := &StubIdP{
+		ClientID:      "gobbs",
+		ClientSecret:  "secret",
+		KeyID:         "stub-key",
+		Key:           key,
+		Subject:       "stub-user",
+		Email:         "stub@example.com",
+		EmailVerified: true,
+		Name:          "Stub User",
+		codes:         map[string]authorization{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", idp.discovery)
+	mux.HandleFunc("/authorize", idp.authorize)
+	mux.HandleFunc("/token", idp.token)
+	mux.HandleFunc("/jwks", idp.jwks)
+	idp.Server = httptest.NewServer(mux)
+
+	return idp
+}
+
+// Issuer 発行者URLを返す
+func (idp *StubIdP) Issuer() string {
+	return idp.Server.URL
+}
+
+// Close IdPを停止する
+func (idp *StubIdP) Close() {
+	idp.Server.Close()
+}
+
+// discovery ディスカバリー
+func (idp *StubIdP) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                idp.Issuer(),
+		"authorization_endpoint":                idp.Issuer() + "/authorize",
+		"token_endpoint":                        idp.Issuer() + "/token",
+		"jwks_uri":                              idp.Issuer() + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+// authorize 認可リクエストを承認して認可コードを発行する
+func (idp *StubIdP) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("client_id") != idp.ClientID || query.Get("response_type") != "code" ||
+		query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" {
+		http.Error(w, "invalid_request", http.StatusBadRequest)
+		return
+	}
+	redirectURI, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil || !redirectURI.IsAbs() {
+		http.Error(w, "invalid_request", http.StatusBadRequest)
+		return
+	}
+
+	code := randomString()
+	idp.mu.Lock()
+	idp.codes[code] = authorization{
+		redirectURI:   query.Get("redirect_uri"),
+		nonce:         query.Get("nonce"),
+		codeChallenge: query.Get("code_challenge"),
+	}
+	idp.mu.Unlock()
+
+	params := redirectURI.Query()
+	params.Set("code", code)
+	params.Set("state", query.Get("state"))
+	redirectURI.RawQuery = params.Encode()
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+// token 認可コードをIDトークンと交換する(認可コードは1回だけ使える)
+func (idp *StubIdP) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.ParseForm() != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+	clientID, clientSecret, ok := r.BasicAuth()
+	if ok {
+		clientID, _ = url.QueryUnescape(clientID)
+		clientSecret, _ = url.QueryUnescape(clientSecret)
+	}
+	if !ok || clientID != idp.ClientID || subtle.ConstantTimeCompare([]byte(clientSecret), []byte(idp.ClientSecret)) != 1 {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	code := r.PostForm.Get("code")
+	idp.mu.Lock()
+	auth, ok := idp.codes[code]
+	delete(idp.codes, code)
+	idp.mu.Unlock()
+
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !ok || r.PostForm.Get("grant_type") != "authorization_code" ||
+		r.PostForm.Get("redirect_uri") != auth.redirectURI ||
+		base64.RawURLEncoding.EncodeToString(sum[:]) != auth.codeChallenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	idToken, err := idp.IDToken(auth.nonce)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": randomString(),
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idToken,
+	})
+}
+
+// jwks IDトークンを検証する公開鍵
+func (idp *StubIdP) jwks(w http.ResponseWriter, r *http.Request) {
+	pub := idp.Key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": idp.KeyID,
+			"alg": "RS256",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
+
+// IDToken 署名したIDトークンを発行する
+func (idp *StubIdP) IDToken(nonce string) (string, error) {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":            idp.Issuer(),
+		"sub":            idp.Subject,
+		"aud":            idp.ClientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(5 * time.Minute).Unix(),
+		"nonce":          nonce,
+		"email":          idp.Email,
+		"email_verified": idp.EmailVerified,
+		"name":           idp.Name,
+	}
+	if idp.ModifyClaims != nil {
+		idp.ModifyClaims(claims)
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = idp.KeyID
+	return token.SignedString(idp.Key)
+}
+
+// writeJSON JSONのレスポンスを書き込む
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// randomString 認可コードなどに使う乱数の文字列
+func randomString() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,377 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/subtle"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+
+	"GoBBS/interface/security"
+)
+
+type (
+	// Provider OpenID ConnectのIdP(認可コードフロー + PKCE)
+	// mockgen -source interface/oidc/provider.go -destination mock/mock_oidc/provider_mock.go
+	Provider interface {
+		AuthCodeURL(ctx context.Context, req *AuthRequest) (string, error)
+		Exchange(ctx context.Context, code string, req *AuthRequest) (*Identity, error)
+	}
+
+	// Identity IDトークンで確認した外部アカウント
+	Identity struct {
+		Issuer        string
+		Subject       string
+		Email         string
+		EmailVerified bool
+		Name          string
+	}
+
+	// provider ディスカバリーで取得したエンドポイントを使うIdP
+	provider struct {
+		issuer       string
+		clientID     string
+		clientSecret string
+		redirectURL  string
+		scopes       []string
+		client       *http.Client
+		now          func() time.Time
+
+		mu            sync.Mutex
+		metadata      *metadata
+		keys          map[string]crypto.PublicKey
+		keysFetchedAt time.Time
+	}
+
+	// metadata ディスカバリー(/.well-known/openid-configuration)の必要な項目
+	metadata struct {
+		Issuer                string `json:"issuer"`
+		AuthorizationEndpoint string `json:"authorization_endpoint"`
+		TokenEndpoint         string `json:"token_endpoint"`
+		JWKSURI               string `json:"jwks_uri"`
+	}
+
+	// tokenResponse トークンエンドポイントのレスポンス
+	tokenResponse struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+
+	// idTokenClaims IDトークンのクレーム
+	idTokenClaims struct {
+		jwt.RegisteredClaims
+		Nonce         string    `json:"nonce"`
+		AZP           string    `json:"azp"`
+		Email         string    `json:"email"`
+		EmailVerified claimBool `json:"email_verified"`
+		Name          string    `json:"name"`
+	}
+
+	// claimBool 真偽値のクレーム(文字列の"true"で返すIdPもあるため両方受け付ける)
+	claimBool bool
+)
+
+const (
+	// discoveryPath ディスカバリーのパス(発行者URLに付ける)
+	discoveryPath = "/.well-known/openid-configuration"
+	// httpTimeout IdPへのリクエストのタイムアウト
+	httpTimeout = 10 * time.Second
+	// maxResponseSize IdPのレスポンスとして読み込む上限
+	maxResponseSize = 1 << 20
+	// keysRefreshInterval 未知のkidで公開鍵を再取得する最短の間隔(不正なトークンで何度も取得させない)
+	keysRefreshInterval = time.Minute
+	// idTokenLeeway IdPとの時計のずれを許容する幅
+	idTokenLeeway = 30 * time.Second
+)
+
+var (
+	ErrDiscovery         = errors.New("oidc discovery failed")
+	ErrTokenExchange     = errors.New("oidc token exchange failed")
+	ErrInvalidIDToken    = errors.New("invalid id token")
+	ErrUnknownSigningKey = errors.New("unknown id token signing key")
+)
+
+// idTokenMethods IDトークンの署名として受け付けるアルゴリズム(共通鍵とnoneは受け付けない)
+var idTokenMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// NewProvider IdPを生成する
+// ディスカバリーは最初のログイン時に取得するため、IdPが停止していても起動できる
+func NewProvider(issuer string, clientID string, clientSecret string, redirectURL string, scopes []string) Provider {
+	return &provider{
+		issuer:       strings.TrimSuffix(issuer, "/"),
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		redirectURL:  redirectURL,
+		scopes:       scopes,
+		client:       &http.Client{Timeout: httpTimeout},
+		now:          time.Now,
+	}
+}
+
+// AuthCodeURL ユーザーをリダイレクトさせる認可リクエストのURLを返す
+func (p *provider) AuthCodeURL(ctx context.Context, req *AuthRequest) (string, error) {
+	m, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(m.AuthorizationEndpoint)
+	if err != nil {
+		return "", errors.Wrap(ErrDiscovery, err.Error())
+	}
+	query := u.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.clientID)
+	query.Set("redirect_uri", p.redirectURL)
+	query.Set("scope", strings.Join(p.scopeList(), " "))
+	query.Set("state", req.State)
+	query.Set("nonce", req.Nonce)
+	query.Set("code_challenge", req.CodeChallenge())
+	query.Set("code_challenge_method", "S256")
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+// Exchange 認可コードをIDトークンと交換し、検証した外部アカウントを返す
+func (p *provider) Exchange(ctx context.Context, code string, req *AuthRequest) (*Identity, error) {
+	m, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.redirectURL)
+	form.Set("code_verifier", req.CodeVerifier)
+	form.Set("client_id", p.clientID)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, m.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, errors.Wrap(err, "Exchange error")
+	}
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	httpReq.Header.Set("Accept", "application/json")
+	if p.clientSecret != "" {
+		// client_secret_basic(RFC 6749 2.3.1によりURLエンコードしてから送る)
+		httpReq.SetBasicAuth(url.QueryEscape(p.clientID), url.QueryEscape(p.clientSecret))
+	}
+
+	res, err := p.client.Do(httpReq)
+	if err != nil {
+		return nil, errors.Wrap(ErrTokenExchange, err.Error())
+	}
+	defer res.Body.Close()
+
+	var token tokenResponse
+	if err := json.NewDecoder(io.LimitReader(res.Body, maxResponseSize)).Decode(&token); err != nil {
+		return nil, errors.Wrapf(ErrTokenExchange, "status %d", res.StatusCode)
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.Wrapf(ErrTokenExchange, "status %d: %s %s", res.StatusCode, token.Error, token.ErrorDescription)
+	}
+	if token.IDToken == "" {
+		return nil, errors.Wrap(ErrTokenExchange, "id_token is missing")
+	}
+
+	return p.verifyIDToken(ctx, token.IDToken, req.Nonce)
+}
+
+// verifyIDToken IDトークンの署名、発行者、宛先、有効期限、nonceを検証する
+func (p *provider) verifyIDToken(ctx context.Context, raw string, nonce string) (*Identity, error) {
+	claims := &idTokenClaims{}
+	_, err := jwt.ParseWithClaims(
+		raw,
+		claims,
+		func(t *jwt.Token) (interface{}, error) {
+			kid, _ := t.Header["kid"].(string)
+			return p.publicKey(ctx, kid)
+		},
+		jwt.WithValidMethods(idTokenMethods),
+		jwt.WithIssuer(p.issuer),
+		jwt.WithAudience(p.clientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(idTokenLeeway),
+		jwt.WithTimeFunc(p.now),
+	)
+	if err != nil {
+		if errors.Is(err, ErrUnknownSigningKey) || errors.Is(err, ErrDiscovery) {
+			return nil, err
+		}
+		return nil, errors.Wrap(ErrInvalidIDToken, err.Error())
+	}
+
+	if claims.Subject == "" {
+		return nil, errors.Wrap(ErrInvalidIDToken, "sub is missing")
+	}
+	if subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return nil, errors.Wrap(ErrInvalidIDToken, "nonce mismatch")
+	}
+	if len(claims.Audience) > 1 && claims.AZP != p.clientID {
+		return nil, errors.Wrap(ErrInvalidIDToken, "azp mismatch")
+	}
+
+	return &Identity{
+		Issuer:        p.issuer,
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: bool(claims.EmailVerified),
+		Name:          claims.Name,
+	}, nil
+}
+
+// publicKey IDトークンの署名を検証する公開鍵を返す
+// 鍵のローテーションに追従するため、未知のkidの場合は公開鍵を取得し直す
+func (p *provider) publicKey(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+	if !p.keysFetchedAt.IsZero() && p.now().Sub(p.keysFetchedAt) < keysRefreshInterval {
+		return nil, ErrUnknownSigningKey
+	}
+
+	if err := p.fetchKeys(ctx); err != nil {
+		return nil, err
+	}
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+
+	return nil, ErrUnknownSigningKey
+}
+
+// lookupKey 取得済みの公開鍵を探す(kidがない場合は鍵が1つだけのときに限りその鍵を使う)
+func (p *provider) lookupKey(kid string) (crypto.PublicKey, bool) {
+	if kid == "" {
+		if len(p.keys) != 1 {
+			return nil, false
+		}
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+	key, ok := p.keys[kid]
+	return key, ok
+}
+
+// fetchKeys IdPの公開鍵(JWKS)を取得する(p.muをロックして呼び出す)
+func (p *provider) fetchKeys(ctx context.Context) error {
+	m, err := p.discoverLocked(ctx)
+	if err != nil {
+		return err
+	}
+
+	var jwks security.JWKS
+	if err := p.getJSON(ctx, m.JWKSURI, &jwks); err != nil {
+		return errors.Wrap(ErrDiscovery, err.Error())
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.PublicKey()
+		if err != nil {
+			// 対応していない種類の鍵は無視する
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	p.keys = keys
+	p.keysFetchedAt = p.now()
+
+	return nil
+}
+
+// discover ディスカバリーを取得する(取得できた内容は使い回す)
+func (p *provider) discover(ctx context.Context) (*metadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.discoverLocked(ctx)
+}
+
+// discoverLocked discoverの本体(p.muをロックして呼び出す)
+func (p *provider) discoverLocked(ctx context.Context) (*metadata, error) {
+	if p.metadata != nil {
+		return p.metadata, nil
+	}
+
+	var m metadata
+	if err := p.getJSON(ctx, p.issuer+discoveryPath, &m); err != nil {
+		return nil, errors.Wrap(ErrDiscovery, err.Error())
+	}
+	// 別のIdPになりすまされないよう、発行者が設定と一致することを確認する(OpenID Connect Discovery 4.3)
+	if strings.TrimSuffix(m.Issuer, "/") != p.issuer {
+		return nil, errors.Wrapf(ErrDiscovery, "issuer mismatch: %s", m.Issuer)
+	}
+	if m.AuthorizationEndpoint == "" || m.TokenEndpoint == "" || m.JWKSURI == "" {
+		return nil, errors.Wrap(ErrDiscovery, "endpoint is missing")
+	}
+	p.metadata = &m
+
+	return p.metadata, nil
+}
+
+// getJSON GETしたJSONを読み込む
+func (p *provider) getJSON(ctx context.Context, rawURL string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return errors.Errorf("GET %s: status %d", rawURL, res.StatusCode)
+	}
+
+	return json.NewDecoder(io.LimitReader(res.Body, maxResponseSize)).Decode(v)
+}
+
+// scopeList 要求するスコープ(openidは必ず含める)
+func (p *provider) scopeList() []string {
+	for _, s := range p.scopes {
+		if s == "openid" {
+			return p.scopes
+		}
+	}
+	return append([]string{"openid"}, p.scopes...)
+}
+
+// UnmarshalJSON 真偽値、または"true"/"false"の文字列を読み込む
+func (b *claimBool) UnmarshalJSON(data []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case bool:
+		*b = claimBool(v)
+	case string:
+		*b = claimBool(v == "true")
+	default:
+		*b = false
+	}
+	return nil
+}
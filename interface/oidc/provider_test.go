@@ -0,0 +1,256 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+
+	"GoBBS/interface/oidc/oidctest"
+)
+
+// authorize 認可リクエストのURLにアクセスし、リダイレクト先から認可コードを取り出す
+func authorize(t *testing.T, p Provider, req *AuthRequest) string {
+	t.Helper()
+
+	authURL, err := p.AuthCodeURL(context.Background(), req)
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	res, err := client.Get(authURL)
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusFound {
+		t.Fatalf("ステータス不一致 got: %d", res.StatusCode)
+	}
+	location, err := url.Parse(res.Header.Get("Location"))
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	if got := location.Query().Get("state"); got != req.State {
+		t.Fatalf("state不一致 got: %#v want: %#v", got, req.State)
+	}
+
+	return location.Query().Get("code")
+}
+
+func newTestProvider(idp *oidctest.StubIdP) Provider {
+	return NewProvider(idp.Issuer(), idp.ClientID, idp.ClientSecret, "https://bbs.example.com/oidc/callback", []string{"email", "profile"})
+}
+
+func TestProvider_AuthCodeURL(t *testing.T) {
+	idp := oidctest.NewStubIdP()
+	defer idp.Close()
+
+	req := &AuthRequest{State: "state", Nonce: "nonce", CodeVerifier: "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"}
+	got, err := newTestProvider(idp).AuthCodeURL(context.Background(), req)
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	u, _ := url.Parse(got)
+	want := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {"gobbs"},
+		"redirect_uri":          {"https://bbs.example.com/oidc/callback"},
+		"scope":                 {"openid email profile"},
+		"state":                 {"state"},
+		"nonce":                 {"nonce"},
+		"code_challenge":        {"E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"},
+		"code_challenge_method": {"S256"},
+	}
+	if u.Path != "/authorize" || !reflect.DeepEqual(u.Query(), want) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", u.Query(), want)
+	}
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(idp *oidctest.StubIdP, req *AuthRequest)
+		want    func(idp *oidctest.StubIdP) *Identity
+		wantErr error
+	}{
+		{
+			name:  "正常ケース",
+			setup: func(idp *oidctest.StubIdP, req *AuthRequest) {},
+			want: func(idp *oidctest.StubIdP) *Identity {
+				return &Identity{Issuer: idp.Issuer(), Subject: "stub-user", Email: "stub@example.com", EmailVerified: true, Name: "Stub User"}
+			},
+		},
+		{
+			name: "正常ケース(email_verifiedが文字列)",
+			setup: func(idp *oidctest.StubIdP, req *AuthRequest) {
+				idp.ModifyClaims = func(c jwt.MapClaims) { c["email_verified"] = "false" }
+			},
+			want: func(idp *oidctest.StubIdP) *Identity {
+				return &Identity{Issuer: idp.Issuer(), Subject: "stub-user", Email: "stub@example.com", Name: "Stub User"}
+			},
+		},
+		{
+			name: "正常ケース(複数の宛先でazpが一致)",
+			setup: func(idp *oidctest.StubIdP, req *AuthRequest) {
+				idp.ModifyClaims = func(c jwt.MapClaims) { c["aud"] = []string{"gobbs", "other"}; c["azp"] = "gobbs" }
+			},
+			want: func(idp *oidctest.StubIdP) *Identity {
+				return &Identity{Issuer: idp.Issuer(), Subject: "stub-user", Email: "stub@example.com", EmailVerified: true, Name: "Stub User"}
+			},
+		},
+		{
+			name:    "異常ケース(code_verifier不一致)",
+			setup:   func(idp *oidctest.StubIdP, req *AuthRequest) { req.CodeVerifier = "other" },
+			wantErr: ErrTokenExchange,
+		},
+		{
+			name:    "異常ケース(クライアントシークレット不一致)",
+			setup:   func(idp *oidctest.StubIdP, req *AuthRequest) { idp.ClientSecret = "other" },
+			wantErr: ErrTokenExchange,
+		},
+		{
+			name:    "異常ケース(nonce不一致)",
+			setup:   func(idp *oidctest.StubIdP, req *AuthRequest) { req.Nonce = "other" },
+			wantErr: ErrInvalidIDToken,
+		},
+		{
+			name: "異常ケース(宛先不一致)",
+			setup: func(idp *oidctest.StubIdP, req *AuthRequest) {
+				idp.ModifyClaims = func(c jwt.MapClaims) { c["aud"] = "other" }
+			},
+			wantErr: ErrInvalidIDToken,
+		},
+		{
+			name: "異常ケース(複数の宛先でazpなし)",
+			setup: func(idp *oidctest.StubIdP, req *AuthRequest) {
+				idp.ModifyClaims = func(c jwt.MapClaims) { c["aud"] = []string{"gobbs", "other"} }
+			},
+			wantErr: ErrInvalidIDToken,
+		},
+		{
+			name: "異常ケース(発行者不一致)",
+			setup: func(idp *oidctest.StubIdP, req *AuthRequest) {
+				idp.ModifyClaims = func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" }
+			},
+			wantErr: ErrInvalidIDToken,
+		},
+		{
+			name: "異常ケース(有効期限切れ)",
+			setup: func(idp *oidctest.StubIdP, req *AuthRequest) {
+				idp.ModifyClaims = func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() }
+			},
+			wantErr: ErrInvalidIDToken,
+		},
+		{
+			name: "異常ケース(subなし)",
+			setup: func(idp *oidctest.StubIdP, req *AuthRequest) {
+				idp.ModifyClaims = func(c jwt.MapClaims) { delete(c, "sub") }
+			},
+			wantErr: ErrInvalidIDToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idp := oidctest.NewStubIdP()
+			defer idp.Close()
+
+			p := newTestProvider(idp)
+			req, _ := NewAuthRequest()
+			code := authorize(t, p, req)
+			tt.setup(idp, req)
+
+			got, err := p.Exchange(context.Background(), code, req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("戻り値不一致 got: %#v want: nil", got)
+				}
+				return
+			}
+			if want := tt.want(idp); !reflect.DeepEqual(got, want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
+			}
+		})
+	}
+}
+
+func TestProvider_Exchange_KeyRotation(t *testing.T) {
+	idp := oidctest.NewStubIdP()
+	defer idp.Close()
+
+	now := time.Now()
+	p := newTestProvider(idp).(*provider)
+	p.now = func() time.Time { return now }
+
+	exchange := func() error {
+		req, _ := NewAuthRequest()
+		code := authorize(t, p, req)
+		_, err := p.Exchange(context.Background(), code, req)
+		return err
+	}
+
+	if err := exchange(); err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	idp.Key = key
+	idp.KeyID = "rotated-key"
+
+	// 直前に取得したばかりの場合は取得し直さない
+	if err := exchange(); !errors.Is(err, ErrUnknownSigningKey) {
+		t.Fatalf("戻り値不一致 got: %#v want: %#v", err, ErrUnknownSigningKey)
+	}
+
+	now = now.Add(keysRefreshInterval)
+	if err := exchange(); err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+}
+
+func TestProvider_Discovery(t *testing.T) {
+	idp := oidctest.NewStubIdP()
+	defer idp.Close()
+
+	tests := []struct {
+		name    string
+		issuer  string
+		wantErr error
+	}{
+		{name: "正常ケース(末尾のスラッシュは無視する)", issuer: idp.Issuer() + "/"},
+		{name: "異常ケース(発行者不一致)", issuer: idp.Issuer() + "/realms/other", wantErr: ErrDiscovery},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewProvider(tt.issuer, idp.ClientID, idp.ClientSecret, "https://bbs.example.com/oidc/callback", nil)
+			_, err := p.AuthCodeURL(context.Background(), &AuthRequest{State: "s", Nonce: "n", CodeVerifier: "v"})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("異常ケース(発行者がなりすまし)", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"issuer":"` + idp.Issuer() + `","authorization_endpoint":"a","token_endpoint":"t","jwks_uri":"j"}`))
+		}))
+		defer server.Close()
+
+		p := NewProvider(server.URL, "gobbs", "", "https://bbs.example.com/oidc/callback", nil)
+		_, err := p.AuthCodeURL(context.Background(), &AuthRequest{State: "s", Nonce: "n", CodeVerifier: "v"})
+		if !errors.Is(err, ErrDiscovery) {
+			t.Errorf("戻り値不一致 got: %#v want: %#v", err, ErrDiscovery)
+		}
+	})
+}
@@ -0,0 +1,225 @@
+package pubsub
+
+import (
+	"container/list"
+	"sync"
+)
+
+type (
+	// Hub プロセス内のパブリッシュ/サブスクライブ
+	// mockgen -source interface/pubsub/hub.go -destination mock/mock_pubsub/hub_mock.go
+	Hub interface {
+		Publish(topic, eventType string, data []byte) Event
+		Subscribe(topic string, lastEventID uint64) Subscription
+	}
+
+	// Subscription 購読
+	Subscription interface {
+		Replay() []Event
+		Events() <-chan Event
+		Close()
+	}
+
+	// Event 配信するイベント
+	Event struct {
+		ID    uint64
+		Topic string
+		Type  string
+		Data  []byte
+	}
+
+	// memoryHub メモリ上のハブ
+	memoryHub struct {
+		mu            sync.Mutex
+		lastID        uint64
+		historySize   int
+		bufferSize    int
+		maxIdleTopics int
+		topics        map[string]*topic
+		// idle 購読者のいないトピック名(先頭ほど最近使われた)
+		idle *list.List
+	}
+
+	// topic トピックごとの購読者と再送用の履歴
+	topic struct {
+		subscribers map[*subscription]struct{}
+		history     []Event
+		// idleElem 購読者のいない間のidleの要素(購読者がいる場合はnil)
+		idleElem *list.Element
+	}
+
+	// subscription メモリ上のハブの購読
+	subscription struct {
+		hub    *memoryHub
+		topic  string
+		replay []Event
+		events chan Event
+		closed bool
+	}
+)
+
+var (
+	_ Hub          = (*memoryHub)(nil)
+	_ Subscription = (*subscription)(nil)
+)
+
+// 配信するイベントの種類(データはスレッドまたは投稿のJSON)
+// スレッドのトピックには投稿のイベント、板のトピックにはスレッドの作成と投稿の作成を配信する
+const (
+	// EventThreadCreated スレッドの作成
+	EventThreadCreated = "thread.created"
+	// EventPostCreated 投稿の作成
+	EventPostCreated = "post.created"
+	// EventPostEdited 投稿の編集
+	EventPostEdited = "post.edited"
+	// EventPostHidden モデレーターによる投稿の非表示
+	EventPostHidden = "post.hidden"
+)
+
+// BoardTopic 板のトピック名を返す
+func BoardTopic(boardID string) string {
+	return "board:" + boardID
+}
+
+// ThreadTopic スレッドのトピック名を返す
+func ThreadTopic(threadID string) string {
+	return "thread:" + threadID
+}
+
+// NewMemoryHub メモリ上のハブを生成する
+// historySizeはトピックごとに再送用に保持するイベント数、bufferSizeは購読ごとの未読イベント数の上限
+// maxIdleTopicsは購読者のいないトピックの履歴を保持する数の上限(超えた場合は最も長く使われていないトピックから破棄する)
+func NewMemoryHub(historySize, bufferSize, maxIdleTopics int) *memoryHub {
+	return &memoryHub{
+		historySize:   historySize,
+		bufferSize:    bufferSize,
+		maxIdleTopics: maxIdleTopics,
+		topics:        map[string]*topic{},
+		idle:          list.New(),
+	}
+}
+
+// Publish イベントを採番してトピックの購読者に配信する
+// 未読が上限に達した購読者は切断し、Last-Event-IDでの再接続に任せる
+func (h *memoryHub) Publish(topicName, eventType string, data []byte) Event {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.lastID++
+	event := Event{ID: h.lastID, Topic: topicName, Type: eventType, Data: data}
+
+	t := h.topic(topicName)
+	t.history = append(t.history, event)
+	if len(t.history) > h.historySize {
+		t.history = t.history[len(t.history)-h.historySize:]
+	}
+
+	for s := range t.subscribers {
+		select {
+		case s.events <- event:
+		default:
+			h.remove(s)
+		}
+	}
+	if len(t.subscribers) == 0 {
+		h.markIdle(topicName, t)
+	}
+
+	return event
+}
+
+// Subscribe トピックを購読する
+// lastEventIDより後の履歴に残っているイベントは再送対象として返す
+func (h *memoryHub) Subscribe(topicName string, lastEventID uint64) Subscription {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	t := h.topic(topicName)
+	s := &subscription{
+		hub:    h,
+		topic:  topicName,
+		events: make(chan Event, h.bufferSize),
+	}
+	if lastEventID > 0 {
+		for _, e := range t.history {
+			if e.ID > lastEventID {
+				s.replay = append(s.replay, e)
+			}
+		}
+	}
+	t.subscribers[s] = struct{}{}
+	if t.idleElem != nil {
+		h.idle.Remove(t.idleElem)
+		t.idleElem = nil
+	}
+
+	return s
+}
+
+// topic トピックを返す、存在しない場合は生成する
+// 呼び出し元でロックを取得していること
+func (h *memoryHub) topic(name string) *topic {
+	t, ok := h.topics[name]
+	if !ok {
+		t = &topic{subscribers: map[*subscription]struct{}{}}
+		h.topics[name] = t
+	}
+	return t
+}
+
+// remove 購読を解除する
+// 呼び出し元でロックを取得していること
+func (h *memoryHub) remove(s *subscription) {
+	if s.closed {
+		return
+	}
+	s.closed = true
+	close(s.events)
+
+	t := h.topics[s.topic]
+	delete(t.subscribers, s)
+	if len(t.subscribers) > 0 {
+		return
+	}
+	if len(t.history) == 0 {
+		delete(h.topics, s.topic)
+		return
+	}
+	h.markIdle(s.topic, t)
+}
+
+// markIdle 購読者のいないトピックを最近使われたものとして記録する
+// 購読者のいないトピックが上限を超えた場合は、最も長く使われていないトピックを履歴ごと破棄する
+// 呼び出し元でロックを取得していること
+func (h *memoryHub) markIdle(name string, t *topic) {
+	if t.idleElem != nil {
+		h.idle.MoveToFront(t.idleElem)
+	} else {
+		t.idleElem = h.idle.PushFront(name)
+	}
+
+	for h.idle.Len() > h.maxIdleTopics {
+		oldest := h.idle.Back()
+		h.idle.Remove(oldest)
+		delete(h.topics, oldest.Value.(string))
+	}
+}
+
+// Replay 購読開始時に再送するイベントを返す
+func (s *subscription) Replay() []Event {
+	return s.replay
+}
+
+// Events 購読開始後に配信されるイベントを返す
+// ハブから切断された場合はクローズされる
+func (s *subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Close 購読を解除する
+func (s *subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	s.hub.remove(s)
+}
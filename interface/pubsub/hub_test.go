@@ -0,0 +1,151 @@
+package pubsub
+
+import (
+	"reflect"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestMemoryHub_PublishSubscribe(t *testing.T) {
+	hub := NewMemoryHub(10, 10, 10)
+
+	sub := hub.Subscribe(ThreadTopic("1"), 0)
+	defer sub.Close()
+	other := hub.Subscribe(ThreadTopic("2"), 0)
+	defer other.Close()
+
+	published := hub.Publish(ThreadTopic("1"), "post.created", []byte(`{"id":"1"}`))
+	want := Event{ID: 1, Topic: "thread:1", Type: "post.created", Data: []byte(`{"id":"1"}`)}
+	if !reflect.DeepEqual(published, want) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", published, want)
+	}
+
+	if got := <-sub.Events(); !reflect.DeepEqual(got, want) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
+	}
+	select {
+	case e := <-other.Events():
+		t.Errorf("他のトピックのイベントを受信(event: %#v)", e)
+	default:
+	}
+}
+
+func TestMemoryHub_Subscribe_replay(t *testing.T) {
+	hub := NewMemoryHub(2, 10, 10)
+	hub.Publish(ThreadTopic("1"), "post.created", []byte("1"))
+	hub.Publish(ThreadTopic("2"), "post.created", []byte("2"))
+	hub.Publish(ThreadTopic("1"), "post.created", []byte("3"))
+	hub.Publish(ThreadTopic("1"), "post.updated", []byte("4"))
+
+	tests := []struct {
+		name        string
+		lastEventID uint64
+		want        []uint64
+	}{
+		{name: "正常ケース(Last-Event-IDなし)", lastEventID: 0, want: nil},
+		{name: "正常ケース(途中から再送)", lastEventID: 3, want: []uint64{4}},
+		{name: "正常ケース(履歴に残っている分のみ再送)", lastEventID: 1, want: []uint64{3, 4}},
+		{name: "正常ケース(再送なし)", lastEventID: 4, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub := hub.Subscribe(ThreadTopic("1"), tt.lastEventID)
+			defer sub.Close()
+
+			var got []uint64
+			for _, e := range sub.Replay() {
+				got = append(got, e.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryHub_Publish_slowSubscriber(t *testing.T) {
+	hub := NewMemoryHub(10, 1, 10)
+	sub := hub.Subscribe(ThreadTopic("1"), 0)
+	defer sub.Close()
+
+	hub.Publish(ThreadTopic("1"), "post.created", nil)
+	hub.Publish(ThreadTopic("1"), "post.created", nil)
+
+	if e, ok := <-sub.Events(); !ok || e.ID != 1 {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", e.ID, 1)
+	}
+	if _, ok := <-sub.Events(); ok {
+		t.Error("未読超過の購読が切断されていない")
+	}
+}
+
+func TestMemoryHub_Close(t *testing.T) {
+	hub := NewMemoryHub(10, 10, 10)
+	sub := hub.Subscribe(ThreadTopic("1"), 0)
+	sub.Close()
+	sub.Close()
+
+	if _, ok := <-sub.Events(); ok {
+		t.Error("解除した購読がクローズされていない")
+	}
+	if len(hub.topics) != 0 {
+		t.Errorf("購読者のいないトピックが残っている(topics: %#v)", hub.topics)
+	}
+}
+
+func TestMemoryHub_evictIdleTopics(t *testing.T) {
+	hub := NewMemoryHub(10, 10, 2)
+	sub := hub.Subscribe(ThreadTopic("1"), 0)
+	defer sub.Close()
+
+	hub.Publish(ThreadTopic("1"), "post.created", nil)
+	hub.Publish(ThreadTopic("2"), "post.created", nil)
+	hub.Publish(ThreadTopic("3"), "post.created", nil)
+	// 最近使われたトピックは破棄しない
+	hub.Publish(ThreadTopic("2"), "post.created", nil)
+	hub.Publish(ThreadTopic("4"), "post.created", nil)
+
+	var got []string
+	for name := range hub.topics {
+		got = append(got, name)
+	}
+	slices.Sort(got)
+	// 購読者のいるトピックは上限に数えず、購読者のいないトピックは最も長く使われていないものから破棄する
+	if want := []string{"thread:1", "thread:2", "thread:4"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
+	}
+
+	// 最後の購読者が解除したトピックは購読者のいないトピックとして数える
+	sub.Close()
+	if _, ok := hub.topics[ThreadTopic("2")]; ok || len(hub.topics) != 2 || hub.idle.Len() != 2 {
+		t.Errorf("購読者のいないトピックが上限を超えて残っている(topics: %#v)", hub.topics)
+	}
+
+	replay := hub.Subscribe(ThreadTopic("2"), 1)
+	defer replay.Close()
+	if replay.Replay() != nil {
+		t.Errorf("破棄したトピックの履歴が残っている(replay: %#v)", replay.Replay())
+	}
+}
+
+func TestMemoryHub_concurrent(t *testing.T) {
+	hub := NewMemoryHub(10, 100, 10)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sub := hub.Subscribe(ThreadTopic("1"), 0)
+			defer sub.Close()
+			for j := 0; j < 10; j++ {
+				hub.Publish(ThreadTopic("1"), "post.created", nil)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if hub.lastID != 100 {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", hub.lastID, 100)
+	}
+}
@@ -0,0 +1,41 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+)
+
+// Scheduler 一定間隔でジョブを実行する
+type Scheduler struct {
+	interval time.Duration
+	location *time.Location
+	job      func(now time.Time)
+	now      func() time.Time
+}
+
+// NewScheduler 指定したタイムゾーンの現在時刻でジョブを実行するスケジューラーを生成する
+func NewScheduler(interval time.Duration, location *time.Location, job func(now time.Time)) *Scheduler {
+	return &Scheduler{
+		interval: interval,
+		location: location,
+		job:      job,
+		now:      time.Now,
+	}
+}
+
+// Run 起動直後とその後の一定間隔でジョブを実行する(ctxがキャンセルされるまで戻らない)
+// 再起動直後にも実行するため、ジョブは冪等にしておく
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.job(s.now().In(s.location))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestScheduler_Run(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var got []time.Time
+	s := NewScheduler(time.Millisecond, jst, func(now time.Time) {
+		got = append(got, now)
+		if len(got) == 3 {
+			cancel()
+		}
+	})
+
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("スケジューラーが終了しない")
+	}
+	if len(got) != 3 {
+		t.Fatalf("実行回数不一致 got: %d want: 3", len(got))
+	}
+	if got[0].Location() != jst {
+		t.Errorf("タイムゾーン不一致 got: %v want: %v", got[0].Location(), jst)
+	}
+}
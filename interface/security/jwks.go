@@ -0,0 +1,127 @@
+package security
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	// KeySet 他のサービスがトークンを検証するための公開鍵を提供する
+	// mockgen -source interface/security/jwks.go -destination mock/mock_security/jwks_mock.go
+	KeySet interface {
+		JWKS() *JWKS
+	}
+
+	// JWKS JSON Web Key Set(RFC 7517)
+	JWKS struct {
+		Keys []*JWK `json:"keys"`
+	}
+
+	// JWK JSON Web Key(公開鍵のみ)
+	JWK struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		Alg string `json:"alg"`
+		Use string `json:"use"`
+		// N, E RSA公開鍵
+		N string `json:"n,omitempty"`
+		E string `json:"e,omitempty"`
+		// Crv, X Ed25519公開鍵(楕円曲線の公開鍵の場合はYも使う)
+		Crv string `json:"crv,omitempty"`
+		X   string `json:"x,omitempty"`
+		Y   string `json:"y,omitempty"`
+	}
+)
+
+// NewJWK 公開鍵のJWKを生成する
+// 共通鍵(HS256)は公開できないため、okはfalseを返す
+func NewJWK(key *Key) (jwk *JWK, ok bool) {
+	switch k := key.verifyKey.(type) {
+	case *rsa.PublicKey:
+		return &JWK{
+			Kty: "RSA",
+			Kid: key.ID,
+			Alg: key.Algorithm(),
+			Use: "sig",
+			N:   base64.RawURLEncoding.EncodeToString(k.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes()),
+		}, true
+	case ed25519.PublicKey:
+		return &JWK{
+			Kty: "OKP",
+			Kid: key.ID,
+			Alg: key.Algorithm(),
+			Use: "sig",
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(k),
+		}, true
+	default:
+		return nil, false
+	}
+}
+
+// PublicKey JWKの公開鍵を返す(外部のIdPが公開するRSA、楕円曲線、Ed25519の鍵を読み込む)
+func (j *JWK) PublicKey() (crypto.PublicKey, error) {
+	switch j.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(j.N)
+		if err != nil {
+			return nil, errors.Wrap(err, "PublicKey error")
+		}
+		e, err := base64.RawURLEncoding.DecodeString(j.E)
+		if err != nil {
+			return nil, errors.Wrap(err, "PublicKey error")
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if len(n) == 0 || !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+			return nil, errors.New("PublicKey error: invalid rsa key")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch j.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, errors.Errorf("PublicKey error: unsupported curve %s", j.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(j.X)
+		if err != nil {
+			return nil, errors.Wrap(err, "PublicKey error")
+		}
+		y, err := base64.RawURLEncoding.DecodeString(j.Y)
+		if err != nil {
+			return nil, errors.Wrap(err, "PublicKey error")
+		}
+		key := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(key.X, key.Y) {
+			return nil, errors.New("PublicKey error: point is not on curve")
+		}
+		return key, nil
+	case "OKP":
+		if j.Crv != "Ed25519" {
+			return nil, errors.Errorf("PublicKey error: unsupported curve %s", j.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(j.X)
+		if err != nil {
+			return nil, errors.Wrap(err, "PublicKey error")
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("PublicKey error: invalid ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, errors.Errorf("PublicKey error: unsupported key type %s", j.Kty)
+	}
+}
@@ -0,0 +1,122 @@
+package security
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestNewJWK(t *testing.T) {
+	rsaKey := testRSAKey(t)
+
+	tests := []struct {
+		name   string
+		key    *Key
+		want   *JWK
+		wantOK bool
+	}{
+		{
+			name: "正常ケース(RSA)",
+			key:  &Key{ID: "r1", method: jwt.SigningMethodRS256, signKey: rsaKey, verifyKey: &rsaKey.PublicKey},
+			want: &JWK{
+				Kty: "RSA",
+				Kid: "r1",
+				Alg: "RS256",
+				Use: "sig",
+				N:   base64.RawURLEncoding.EncodeToString(rsaKey.N.Bytes()),
+				E:   "AQAB",
+			},
+			wantOK: true,
+		},
+		{
+			name: "正常ケース(Ed25519)",
+			key:  &Key{ID: "e1", method: jwt.SigningMethodEdDSA, verifyKey: testEd25519Key.Public()},
+			want: &JWK{
+				Kty: "OKP",
+				Kid: "e1",
+				Alg: "EdDSA",
+				Use: "sig",
+				Crv: "Ed25519",
+				X:   "O2onvM62pC1io6jQKm8Nc2UyFXcd4kOmOsBIoYtZ2ik",
+			},
+			wantOK: true,
+		},
+		{
+			name:   "正常ケース(共通鍵は公開しない)",
+			key:    NewHMACKey("h1", []byte("key")),
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := NewJWK(tt.key)
+			if ok != tt.wantOK {
+				t.Fatalf("NewJWK() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+
+	// JWKから公開鍵を復元できること
+	jwk, _ := NewJWK(&Key{ID: "r1", method: jwt.SigningMethodRS256, verifyKey: &rsaKey.PublicKey})
+	n, _ := base64.RawURLEncoding.DecodeString(jwk.N)
+	e, _ := base64.RawURLEncoding.DecodeString(jwk.E)
+	restored := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	if !restored.Equal(&rsaKey.PublicKey) {
+		t.Errorf("公開鍵を復元できない")
+	}
+}
+
+func TestJWK_PublicKey(t *testing.T) {
+	rsaKey := testRSAKey(t)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("鍵の生成に失敗(error: %s)", err)
+	}
+	rsaJWK, _ := NewJWK(&Key{ID: "r1", method: jwt.SigningMethodRS256, signKey: rsaKey, verifyKey: &rsaKey.PublicKey})
+	edJWK, _ := NewJWK(&Key{ID: "e1", method: jwt.SigningMethodEdDSA, verifyKey: testEd25519Key.Public()})
+	ecJWK := &JWK{
+		Kty: "EC",
+		Crv: "P-256",
+		X:   base64.RawURLEncoding.EncodeToString(ecKey.X.Bytes()),
+		Y:   base64.RawURLEncoding.EncodeToString(ecKey.Y.Bytes()),
+	}
+
+	tests := []struct {
+		name    string
+		jwk     *JWK
+		want    crypto.PublicKey
+		wantErr bool
+	}{
+		{name: "正常ケース(RSA)", jwk: rsaJWK, want: &rsaKey.PublicKey},
+		{name: "正常ケース(Ed25519)", jwk: edJWK, want: testEd25519Key.Public()},
+		{name: "正常ケース(P-256)", jwk: ecJWK, want: &ecKey.PublicKey},
+		{name: "異常ケース(RSAの指数不正)", jwk: &JWK{Kty: "RSA", N: rsaJWK.N, E: "AQ"}, wantErr: true},
+		{name: "異常ケース(曲線上にない点)", jwk: &JWK{Kty: "EC", Crv: "P-256", X: "AQ", Y: "AQ"}, wantErr: true},
+		{name: "異常ケース(未対応の曲線)", jwk: &JWK{Kty: "EC", Crv: "secp256k1"}, wantErr: true},
+		{name: "異常ケース(Ed25519の長さ不正)", jwk: &JWK{Kty: "OKP", Crv: "Ed25519", X: "AQ"}, wantErr: true},
+		{name: "異常ケース(Base64不正)", jwk: &JWK{Kty: "RSA", N: "!", E: "AQAB"}, wantErr: true},
+		{name: "異常ケース(未対応の種類)", jwk: &JWK{Kty: "oct"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.jwk.PublicKey()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("予期せぬエラー(error: %s)", err)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,9 +2,10 @@ package security
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
-	"github.com/dgrijalva/jwt-go"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/pkg/errors"
 )
 
@@ -12,37 +13,81 @@ type (
 	// Token トークン
 	// mockgen -source interface/security/jwt_token.go -destination mock/mock_security/jwt_token_mock.go
 	Token interface {
-		Generate(string, time.Time) (string, error)
-		Verify(string) bool
+		Generate(userID string, tokenID string, now time.Time) (string, error)
+		Verify(string) (userID string, tokenID string, ok bool)
+		TTL() time.Duration
+	}
+
+	// JWTOptions 発行・検証するクレームの設定
+	JWTOptions struct {
+		// Issuer iss(トークンの発行者)
+		Issuer string
+		// Audience aud(トークンを受け付けるサービス)
+		Audience string
+		// TTL 発行から有効期限(exp)までの時間
+		TTL time.Duration
+		// Leeway exp, nbf, iatの検証で許容する時計のずれ
+		Leeway time.Duration
 	}
 
 	// jwtToken jwtトークン
+	// 署名は1つの鍵で行い、検証はkidに対応する全ての鍵で行う(鍵を切り替えても発行済みのトークンを使える)
 	jwtToken struct {
-		secretKey     string
-		signingMethod jwt.SigningMethod
+		// signer 署名に使う鍵
+		signer *Key
+		// verifiers kidごとの検証に使う鍵(kidのないトークンはIDが空の鍵で検証する)
+		verifiers map[string]*Key
+		options   JWTOptions
 	}
 )
 
-var _ Token = (*jwtToken)(nil)
+var (
+	_ Token  = (*jwtToken)(nil)
+	_ KeySet = (*jwtToken)(nil)
+)
 
 // NewJWTToken jwtトークンを生成する
-func NewJWTToken(secretKey string) *jwtToken {
-	return &jwtToken{
-		secretKey:     secretKey,
-		signingMethod: jwt.SigningMethodHS256,
+// activeKeyIDの鍵で署名し、keysの全ての鍵で検証する
+func NewJWTToken(options JWTOptions, activeKeyID string, keys ...*Key) (*jwtToken, error) {
+	j := &jwtToken{verifiers: map[string]*Key{}, options: options}
+	for _, key := range keys {
+		if _, ok := j.verifiers[key.ID]; ok {
+			return nil, errors.Errorf("NewJWTToken duplicate key id: %q", key.ID)
+		}
+		j.verifiers[key.ID] = key
+	}
+
+	signer, ok := j.verifiers[activeKeyID]
+	if !ok {
+		return nil, errors.Errorf("NewJWTToken active key not found: %q", activeKeyID)
 	}
+	if !signer.CanSign() {
+		return nil, errors.Errorf("NewJWTToken active key has no private key: %q", activeKeyID)
+	}
+	j.signer = signer
+
+	return j, nil
 }
 
 // Generate トークンを生成する
-func (j *jwtToken) Generate(userID string, now time.Time) (string, error) {
-	claims := jwt.MapClaims{
-		"exp": now.Add(time.Hour * 1).Unix(),
-		"id":  userID,
+// tokenIDはjtiにする(ログインセッションのID)
+func (j *jwtToken) Generate(userID string, tokenID string, now time.Time) (string, error) {
+	claims := jwt.RegisteredClaims{
+		Issuer:    j.options.Issuer,
+		Subject:   userID,
+		Audience:  jwt.ClaimStrings{j.options.Audience},
+		ExpiresAt: jwt.NewNumericDate(now.Add(j.options.TTL)),
+		NotBefore: jwt.NewNumericDate(now),
+		IssuedAt:  jwt.NewNumericDate(now),
+		ID:        tokenID,
 	}
 
-	token := jwt.NewWithClaims(j.signingMethod, claims)
+	token := jwt.NewWithClaims(j.signer.method, claims)
+	if j.signer.ID != "" {
+		token.Header["kid"] = j.signer.ID
+	}
 
-	tokenString, err := token.SignedString([]byte(j.secretKey))
+	tokenString, err := token.SignedString(j.signer.signKey)
 	if err != nil {
 		return "", errors.Wrap(err, "Generate error")
 	}
@@ -50,14 +95,57 @@ func (j *jwtToken) Generate(userID string, now time.Time) (string, error) {
 	return tokenString, nil
 }
 
-// Verify トークンを検証する
-func (j *jwtToken) Verify(tokenString string) bool {
-	_, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
-		if !(token.Method.Alg() == j.signingMethod.Alg()) {
+// Verify トークンを検証し、ユーザーIDとトークンID(jti)を返す
+// 署名に加えて、iss, audの一致とexp, nbf, iat(許容するずれはLeeway)を検証する
+func (j *jwtToken) Verify(tokenString string) (string, string, bool) {
+	parser := jwt.NewParser(
+		jwt.WithIssuer(j.options.Issuer),
+		jwt.WithAudience(j.options.Audience),
+		jwt.WithLeeway(j.options.Leeway),
+		jwt.WithIssuedAt(),
+		jwt.WithExpirationRequired(),
+	)
+
+	claims := &jwt.RegisteredClaims{}
+	_, err := parser.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := j.verifiers[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown key id: %q", kid)
+		}
+		// 鍵ごとにアルゴリズムを固定し、公開鍵をHMACの鍵として使わせない
+		if !(token.Method.Alg() == key.method.Alg()) {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Method.Alg())
 		}
-		return []byte(j.secretKey), nil
+		return key.verifyKey, nil
 	})
 
-	return err == nil
+	if err != nil {
+		return "", "", false
+	}
+
+	if claims.Subject == "" || claims.ID == "" {
+		return "", "", false
+	}
+
+	return claims.Subject, claims.ID, true
+}
+
+// TTL 発行から有効期限までの時間
+func (j *jwtToken) TTL() time.Duration {
+	return j.options.TTL
+}
+
+// JWKS 検証に使う公開鍵をkidの順に返す(共通鍵は含めない)
+func (j *jwtToken) JWKS() *JWKS {
+	jwks := &JWKS{Keys: []*JWK{}}
+	for _, key := range j.verifiers {
+		if jwk, ok := NewJWK(key); ok {
+			jwks.Keys = append(jwks.Keys, jwk)
+		}
+	}
+	sort.Slice(jwks.Keys, func(a, b int) bool {
+		return jwks.Keys[a].Kid < jwks.Keys[b].Kid
+	})
+	return jwks
 }
@@ -0,0 +1,124 @@
+package security
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+)
+
+// Key JWTの署名・検証に使う鍵
+type Key struct {
+	// ID JWTヘッダーのkid(空の場合はkidを付けない)
+	ID     string
+	method jwt.SigningMethod
+	// signKey 署名に使う鍵(検証専用の鍵はnil)
+	signKey any
+	// verifyKey 検証に使う鍵
+	verifyKey any
+}
+
+const (
+	// keyFileExt 鍵ファイルの拡張子
+	keyFileExt = ".pem"
+	// minRSAKeyBits RSA鍵の最低長
+	minRSAKeyBits = 2048
+)
+
+// NewHMACKey HS256の共通鍵を生成する
+func NewHMACKey(id string, secret []byte) *Key {
+	return &Key{
+		ID:        id,
+		method:    jwt.SigningMethodHS256,
+		signKey:   secret,
+		verifyKey: secret,
+	}
+}
+
+// ParsePEMKey PEM形式の鍵を読み込む
+// 秘密鍵(PKCS#8、RSAはPKCS#1も可)は署名と検証に、公開鍵(PKIX)は検証にだけ使う
+// RSA鍵はRS256、Ed25519鍵はEdDSAで署名する
+func ParsePEMKey(id string, data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.Errorf("ParsePEMKey %s: no PEM block", id)
+	}
+
+	var (
+		parsed any
+		err    error
+	)
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, errors.Errorf("ParsePEMKey %s: unsupported PEM type: %s", id, block.Type)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "ParsePEMKey %s error", id)
+	}
+
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < minRSAKeyBits {
+			return nil, errors.Errorf("ParsePEMKey %s: RSA key must be at least %d bits", id, minRSAKeyBits)
+		}
+		return &Key{ID: id, method: jwt.SigningMethodRS256, signKey: k, verifyKey: &k.PublicKey}, nil
+	case *rsa.PublicKey:
+		if k.N.BitLen() < minRSAKeyBits {
+			return nil, errors.Errorf("ParsePEMKey %s: RSA key must be at least %d bits", id, minRSAKeyBits)
+		}
+		return &Key{ID: id, method: jwt.SigningMethodRS256, verifyKey: k}, nil
+	case ed25519.PrivateKey:
+		return &Key{ID: id, method: jwt.SigningMethodEdDSA, signKey: k, verifyKey: k.Public()}, nil
+	case ed25519.PublicKey:
+		return &Key{ID: id, method: jwt.SigningMethodEdDSA, verifyKey: k}, nil
+	default:
+		return nil, errors.Errorf("ParsePEMKey %s: unsupported key type: %T", id, parsed)
+	}
+}
+
+// LoadKeyDir ディレクトリ内の鍵ファイル(*.pem)をkidの順に読み込む
+// ファイル名から拡張子を除いたものをkidにする
+func LoadKeyDir(dir string) ([]*Key, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+keyFileExt))
+	if err != nil {
+		return nil, errors.Wrap(err, "LoadKeyDir error")
+	}
+	sort.Strings(paths)
+
+	keys := make([]*Key, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrap(err, "LoadKeyDir error")
+		}
+		key, err := ParsePEMKey(strings.TrimSuffix(filepath.Base(path), keyFileExt), data)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// Algorithm 署名アルゴリズム名(HS256, RS256, EdDSA)
+func (k *Key) Algorithm() string {
+	return k.method.Alg()
+}
+
+// CanSign 署名に使えるか(秘密鍵を持つか)
+func (k *Key) CanSign() bool {
+	return k.signKey != nil
+}
@@ -0,0 +1,185 @@
+package security
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	testRSAOnce       sync.Once
+	testRSAPrivateKey *rsa.PrivateKey
+	testEd25519Key    = ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+)
+
+// testRSAKey テスト用のRSA秘密鍵を返す(生成に時間がかかるため使い回す)
+func testRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	testRSAOnce.Do(func() {
+		key, err := rsa.GenerateKey(rand.Reader, minRSAKeyBits)
+		if err != nil {
+			t.Fatalf("予期せぬエラー(error: %s)", err)
+		}
+		testRSAPrivateKey = key
+	})
+	return testRSAPrivateKey
+}
+
+// encodePEM 鍵をPEM形式にする(秘密鍵はPKCS#8、公開鍵はPKIX)
+func encodePEM(t *testing.T, key any) []byte {
+	t.Helper()
+	var (
+		der       []byte
+		blockType string
+		err       error
+	)
+	switch key.(type) {
+	case *rsa.PublicKey, ed25519.PublicKey:
+		der, err = x509.MarshalPKIXPublicKey(key)
+		blockType = "PUBLIC KEY"
+	default:
+		der, err = x509.MarshalPKCS8PrivateKey(key)
+		blockType = "PRIVATE KEY"
+	}
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+}
+
+func TestNewHMACKey(t *testing.T) {
+	want := &Key{ID: "k1", method: jwt.SigningMethodHS256, signKey: []byte("key"), verifyKey: []byte("key")}
+	if got := NewHMACKey("k1", []byte("key")); !reflect.DeepEqual(got, want) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
+	}
+}
+
+func TestParsePEMKey(t *testing.T) {
+	rsaKey := testRSAKey(t)
+	smallRSAKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    *Key
+		wantErr string
+	}{
+		{
+			name: "正常ケース(RSA秘密鍵)",
+			data: encodePEM(t, rsaKey),
+			want: &Key{ID: "k1", method: jwt.SigningMethodRS256, signKey: rsaKey, verifyKey: &rsaKey.PublicKey},
+		},
+		{
+			name: "正常ケース(RSA秘密鍵 PKCS#1)",
+			data: pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}),
+			want: &Key{ID: "k1", method: jwt.SigningMethodRS256, signKey: rsaKey, verifyKey: &rsaKey.PublicKey},
+		},
+		{
+			name: "正常ケース(RSA公開鍵)",
+			data: encodePEM(t, &rsaKey.PublicKey),
+			want: &Key{ID: "k1", method: jwt.SigningMethodRS256, verifyKey: &rsaKey.PublicKey},
+		},
+		{
+			name: "正常ケース(Ed25519秘密鍵)",
+			data: encodePEM(t, testEd25519Key),
+			want: &Key{ID: "k1", method: jwt.SigningMethodEdDSA, signKey: testEd25519Key, verifyKey: testEd25519Key.Public()},
+		},
+		{
+			name: "正常ケース(Ed25519公開鍵)",
+			data: encodePEM(t, testEd25519Key.Public()),
+			want: &Key{ID: "k1", method: jwt.SigningMethodEdDSA, verifyKey: testEd25519Key.Public()},
+		},
+		{
+			name:    "異常ケース(PEMでない)",
+			data:    []byte("key"),
+			wantErr: "no PEM block",
+		},
+		{
+			name:    "異常ケース(未対応のPEM)",
+			data:    pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("x")}),
+			wantErr: "unsupported PEM type",
+		},
+		{
+			name:    "異常ケース(鍵の形式不正)",
+			data:    pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("x")}),
+			wantErr: "ParsePEMKey k1 error",
+		},
+		{
+			name:    "異常ケース(RSA鍵長不足)",
+			data:    encodePEM(t, smallRSAKey),
+			wantErr: "at least 2048 bits",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePEMKey("k1", tt.data)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ParsePEMKey() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("予期せぬエラー(error: %s)", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadKeyDir(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string][]byte{
+		"2024-01.pem": encodePEM(t, testEd25519Key.Public()),
+		"2025-01.pem": encodePEM(t, testEd25519Key),
+		"README":      []byte("ignored"),
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+			t.Fatalf("予期せぬエラー(error: %s)", err)
+		}
+	}
+
+	got, err := LoadKeyDir(dir)
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	want := []*Key{
+		{ID: "2024-01", method: jwt.SigningMethodEdDSA, verifyKey: testEd25519Key.Public()},
+		{ID: "2025-01", method: jwt.SigningMethodEdDSA, signKey: testEd25519Key, verifyKey: testEd25519Key.Public()},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "broken.pem"), []byte("x"), 0o600); err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	if _, err := LoadKeyDir(dir); err == nil || !strings.Contains(err.Error(), "broken") {
+		t.Errorf("LoadKeyDir() error = %v", err)
+	}
+}
+
+func TestKey_CanSign(t *testing.T) {
+	if !NewHMACKey("", []byte("key")).CanSign() {
+		t.Errorf("共通鍵で署名できない")
+	}
+	if (&Key{method: jwt.SigningMethodEdDSA, verifyKey: testEd25519Key.Public()}).CanSign() {
+		t.Errorf("公開鍵で署名できる")
+	}
+}
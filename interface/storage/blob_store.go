@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"regexp"
+)
+
+// BlobStore バイナリオブジェクトの保存先
+// mockgen -source interface/storage/blob_store.go -destination mock/mock_storage/blob_store_mock.go
+type BlobStore interface {
+	Put(key string, contentType string, body io.Reader, size int64) error
+	Get(key string) (io.ReadCloser, string, error)
+	Delete(key string) error
+}
+
+var (
+	ErrBlobNotFound   = errors.New("blob not found")
+	ErrInvalidBlobKey = errors.New("invalid blob key")
+)
+
+// blobKeyPattern キーとして使用可能な文字列(パス区切りや..を含めない)
+var blobKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_-][A-Za-z0-9._-]{0,254}$`)
+
+// ValidateKey キーが使用可能か検証する
+func ValidateKey(key string) error {
+	if !blobKeyPattern.MatchString(key) {
+		return ErrInvalidBlobKey
+	}
+	return nil
+}
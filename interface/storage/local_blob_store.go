@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// localBlobStore ローカルファイルシステムのBlobStore
+type localBlobStore struct {
+	dir string
+}
+
+var _ BlobStore = (*localBlobStore)(nil)
+
+// contentTypeSuffix コンテントタイプを保存するファイルの接尾辞
+const contentTypeSuffix = ".content-type"
+
+// NewLocalBlobStore ローカルファイルシステムのBlobStoreを生成する
+func NewLocalBlobStore(dir string) (*localBlobStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.Wrap(err, "NewLocalBlobStore error")
+	}
+
+	return &localBlobStore{dir: dir}, nil
+}
+
+// Put オブジェクトを保存する
+func (s *localBlobStore) Put(key string, contentType string, body io.Reader, size int64) error {
+	if err := s.validateKey(key); err != nil {
+		return errors.Wrap(err, "Put error")
+	}
+
+	// 書き込み途中のファイルを読まれないよう一時ファイルに書き込んでからリネームする
+	tmp, err := os.CreateTemp(s.dir, ".upload-*")
+	if err != nil {
+		return errors.Wrap(err, "Put error")
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "Put error")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "Put error")
+	}
+
+	if err := os.WriteFile(s.path(key)+contentTypeSuffix, []byte(contentType), 0o644); err != nil {
+		return errors.Wrap(err, "Put error")
+	}
+	if err := os.Rename(tmp.Name(), s.path(key)); err != nil {
+		return errors.Wrap(err, "Put error")
+	}
+
+	return nil
+}
+
+// Get オブジェクトとコンテントタイプを取得する
+func (s *localBlobStore) Get(key string) (io.ReadCloser, string, error) {
+	if err := s.validateKey(key); err != nil {
+		return nil, "", errors.Wrap(err, "Get error")
+	}
+
+	f, err := os.Open(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, "", ErrBlobNotFound
+	} else if err != nil {
+		return nil, "", errors.Wrap(err, "Get error")
+	}
+
+	contentType, err := os.ReadFile(s.path(key) + contentTypeSuffix)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		f.Close()
+		return nil, "", errors.Wrap(err, "Get error")
+	}
+
+	return f, string(contentType), nil
+}
+
+// Delete オブジェクトを削除する(存在しない場合もエラーにしない)
+func (s *localBlobStore) Delete(key string) error {
+	if err := s.validateKey(key); err != nil {
+		return errors.Wrap(err, "Delete error")
+	}
+
+	for _, p := range []string{s.path(key), s.path(key) + contentTypeSuffix} {
+		if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return errors.Wrap(err, "Delete error")
+		}
+	}
+
+	return nil
+}
+
+// validateKey キーを検証する(コンテントタイプ保存用のファイル名と衝突するキーも不可)
+func (s *localBlobStore) validateKey(key string) error {
+	if strings.HasSuffix(key, contentTypeSuffix) {
+		return ErrInvalidBlobKey
+	}
+	return ValidateKey(key)
+}
+
+// path キーに対応するファイルパスを返す
+func (s *localBlobStore) path(key string) string {
+	return filepath.Join(s.dir, key)
+}
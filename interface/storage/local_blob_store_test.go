@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestNewLocalBlobStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "blobs")
+
+	got, err := NewLocalBlobStore(dir)
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	if got.dir != dir {
+		t.Errorf("NewLocalBlobStore().dir = %v, want %v", got.dir, dir)
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		t.Errorf("ディレクトリが作成されていない(error: %v)", err)
+	}
+}
+
+func Test_localBlobStore_PutGetDelete(t *testing.T) {
+	s, err := NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+
+	if err := s.Put("a.png", "image/png", bytes.NewBufferString("data"), 4); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	body, contentType, err := s.Get("a.png")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	got, _ := io.ReadAll(body)
+	body.Close()
+	if string(got) != "data" || contentType != "image/png" {
+		t.Errorf("Get() = %s, %s, want data, image/png", got, contentType)
+	}
+
+	if err := s.Delete("a.png"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, _, err := s.Get("a.png"); !errors.Is(err, ErrBlobNotFound) {
+		t.Errorf("Get() error = %v, want %v", err, ErrBlobNotFound)
+	}
+	if err := s.Delete("a.png"); err != nil {
+		t.Errorf("Delete() error = %v, want nil", err)
+	}
+}
+
+func Test_localBlobStore_InvalidKey(t *testing.T) {
+	s, err := NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+
+	for _, key := range []string{"", "../a.png", "a/b.png", ".hidden", "a.png.content-type"} {
+		t.Run(key, func(t *testing.T) {
+			if err := s.Put(key, "image/png", bytes.NewBufferString("data"), 4); !errors.Is(err, ErrInvalidBlobKey) {
+				t.Errorf("Put() error = %v, want %v", err, ErrInvalidBlobKey)
+			}
+			if _, _, err := s.Get(key); !errors.Is(err, ErrInvalidBlobKey) {
+				t.Errorf("Get() error = %v, want %v", err, ErrInvalidBlobKey)
+			}
+			if err := s.Delete(key); !errors.Is(err, ErrInvalidBlobKey) {
+				t.Errorf("Delete() error = %v, want %v", err, ErrInvalidBlobKey)
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// s3BlobStore S3互換ストレージのBlobStore(パス形式のURL、署名バージョン4)
+type s3BlobStore struct {
+	endpoint        *url.URL
+	region          string
+	bucket          string
+	accessKeyID     string
+	secretAccessKey string
+	client          *http.Client
+	now             func() time.Time
+}
+
+var _ BlobStore = (*s3BlobStore)(nil)
+
+const (
+	s3Service          = "s3"
+	s3SigningAlgorithm = "AWS4-HMAC-SHA256"
+	s3DateFormat       = "20060102"
+	s3DateTimeFormat   = "20060102T150405Z"
+	// s3UnsignedPayload ボディをハッシュせずに署名する(ストリーミングアップロード用)
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+)
+
+// NewS3BlobStore S3互換ストレージのBlobStoreを生成する
+func NewS3BlobStore(
+	endpoint string,
+	region string,
+	bucket string,
+	accessKeyID string,
+	secretAccessKey string,
+	client *http.Client) (*s3BlobStore, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, errors.Wrap(err, "NewS3BlobStore error")
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, errors.Errorf("NewS3BlobStore error: invalid endpoint %q", endpoint)
+	}
+	if bucket == "" {
+		return nil, errors.New("NewS3BlobStore error: bucket is empty")
+	}
+
+	return &s3BlobStore{
+		endpoint:        u,
+		region:          region,
+		bucket:          bucket,
+		accessKeyID:     accessKeyID,
+		secretAccessKey: secretAccessKey,
+		client:          client,
+		now:             time.Now,
+	}, nil
+}
+
+// Put オブジェクトを保存する
+func (s *s3BlobStore) Put(key string, contentType string, body io.Reader, size int64) error {
+	if err := ValidateKey(key); err != nil {
+		return errors.Wrap(err, "Put error")
+	}
+
+	req, err := s.newRequest(http.MethodPut, key, body)
+	if err != nil {
+		return errors.Wrap(err, "Put error")
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+
+	res, err := s.do(req)
+	if err != nil {
+		return errors.Wrap(err, "Put error")
+	}
+	res.Body.Close()
+
+	return nil
+}
+
+// Get オブジェクトとコンテントタイプを取得する
+func (s *s3BlobStore) Get(key string) (io.ReadCloser, string, error) {
+	if err := ValidateKey(key); err != nil {
+		return nil, "", errors.Wrap(err, "Get error")
+	}
+
+	req, err := s.newRequest(http.MethodGet, key, nil)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "Get error")
+	}
+
+	res, err := s.do(req)
+	if err != nil {
+		if errors.Is(err, ErrBlobNotFound) {
+			return nil, "", ErrBlobNotFound
+		}
+		return nil, "", errors.Wrap(err, "Get error")
+	}
+
+	return res.Body, res.Header.Get("Content-Type"), nil
+}
+
+// Delete オブジェクトを削除する(存在しない場合もエラーにしない)
+func (s *s3BlobStore) Delete(key string) error {
+	if err := ValidateKey(key); err != nil {
+		return errors.Wrap(err, "Delete error")
+	}
+
+	req, err := s.newRequest(http.MethodDelete, key, nil)
+	if err != nil {
+		return errors.Wrap(err, "Delete error")
+	}
+
+	res, err := s.do(req)
+	if err != nil && !errors.Is(err, ErrBlobNotFound) {
+		return errors.Wrap(err, "Delete error")
+	}
+	if res != nil {
+		res.Body.Close()
+	}
+
+	return nil
+}
+
+// newRequest 署名済みのリクエストを生成する
+func (s *s3BlobStore) newRequest(method string, key string, body io.Reader) (*http.Request, error) {
+	u := *s.endpoint
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + s.bucket + "/" + key
+
+	req, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	s.sign(req, s.now().UTC())
+
+	return req, nil
+}
+
+// do リクエストを送信し、2xx以外はエラーとして返す
+func (s *s3BlobStore) do(req *http.Request) (*http.Response, error) {
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode/100 == 2 {
+		return res, nil
+	}
+
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, ErrBlobNotFound
+	}
+	msg, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+	return nil, errors.Errorf("s3 %s %s: status %d: %s", req.Method, req.URL.Path, res.StatusCode, msg)
+}
+
+// sign リクエストに署名バージョン4のAuthorizationヘッダを付与する
+func (s *s3BlobStore) sign(req *http.Request, now time.Time) {
+	amzDate := now.Format(s3DateTimeFormat)
+	date := now.Format(s3DateFormat)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", s3UnsignedPayload)
+
+	headers := map[string]string{
+		"host":                 req.URL.Host,
+		"x-amz-content-sha256": s3UnsignedPayload,
+		"x-amz-date":           amzDate,
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		s3UnsignedPayload,
+	}, "\n")
+
+	scope := strings.Join([]string{date, s.region, s3Service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		s3SigningAlgorithm,
+		amzDate,
+		scope,
+		hexSHA256([]byte(canonicalRequest)),
+	}, "\n")
+
+	signature := hex.EncodeToString(hmacSHA256(s3SigningKey(s.secretAccessKey, date, s.region), stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3SigningAlgorithm,
+		s.accessKeyID,
+		scope,
+		signedHeaders,
+		signature,
+	))
+}
+
+// s3SigningKey 署名キーを導出する
+func s3SigningKey(secretAccessKey string, date string, region string) []byte {
+	key := hmacSHA256([]byte("AWS4"+secretAccessKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, s3Service)
+	return hmacSHA256(key, "aws4_request")
+}
+
+// hmacSHA256 HMAC-SHA256を計算する
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// hexSHA256 SHA256の16進文字列を返す
+func hexSHA256(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,198 @@
+package textdiff
+
+import (
+	"errors"
+	"strings"
+)
+
+// Op 差分の操作
+type Op int
+
+const (
+	// OpEqual 変更なし
+	OpEqual Op = iota
+	// OpInsert 追加
+	OpInsert
+	// OpDelete 削除
+	OpDelete
+)
+
+// opNames 操作の表記
+var opNames = map[Op]string{
+	OpEqual:  "equal",
+	OpInsert: "insert",
+	OpDelete: "delete",
+}
+
+// String 操作の表記を返す
+func (o Op) String() string {
+	return opNames[o]
+}
+
+// MarshalText JSONなどで操作を表記で出力する
+func (o Op) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
+
+// Line 差分の1行
+type Line struct {
+	Op   Op     `json:"op"`
+	Text string `json:"text"`
+}
+
+// MaxLines 差分を求めるテキストの行数の上限
+// 計算量は(行数の和)×(変更行数)に比例するため、入力の大きさで上限を設ける
+const MaxLines = 2000
+
+// ErrTooManyLines 行数が上限を超えている
+var ErrTooManyLines = errors.New("too many lines")
+
+// Lines 2つのテキストの行単位の差分を返す
+// Myersの線形空間のアルゴリズムで最短の編集を求め、連続する変更では削除を追加より先に並べる
+func Lines(before, after string) ([]Line, error) {
+	a := splitLines(before)
+	b := splitLines(after)
+	if len(a) > MaxLines || len(b) > MaxLines {
+		return nil, ErrTooManyLines
+	}
+
+	d := &differ{a: a, b: b, lines: make([]Line, 0, len(a)+len(b))}
+	d.diff(0, len(a), 0, len(b))
+	d.reorder()
+	return d.lines, nil
+}
+
+// differ 差分の計算状態
+type differ struct {
+	a, b  []string
+	lines []Line
+}
+
+// diff a[aLo:aHi]とb[bLo:bHi]の差分を追加する
+func (d *differ) diff(aLo, aHi, bLo, bHi int) {
+	// 共通の先頭・末尾は計算対象から外す
+	for aLo < aHi && bLo < bHi && d.a[aLo] == d.b[bLo] {
+		d.equal(aLo, aLo+1)
+		aLo++
+		bLo++
+	}
+	suffix := 0
+	for aLo < aHi-suffix && bLo < bHi-suffix && d.a[aHi-1-suffix] == d.b[bHi-1-suffix] {
+		suffix++
+	}
+	aHi -= suffix
+	bHi -= suffix
+
+	switch {
+	case aLo == aHi:
+		for j := bLo; j < bHi; j++ {
+			d.lines = append(d.lines, Line{Op: OpInsert, Text: d.b[j]})
+		}
+	case bLo == bHi:
+		for i := aLo; i < aHi; i++ {
+			d.lines = append(d.lines, Line{Op: OpDelete, Text: d.a[i]})
+		}
+	default:
+		// 先頭・末尾が異なるため中央のスネークの前後はどちらも元より小さくなる
+		x, y, u, v := d.middleSnake(aLo, aHi, bLo, bHi)
+		d.diff(aLo, x, bLo, y)
+		d.equal(x, u)
+		d.diff(u, aHi, v, bHi)
+	}
+
+	d.equal(aHi, aHi+suffix)
+}
+
+// equal a[lo:hi]を変更なしとして追加する
+// 連続する削除・追加は削除を先に並べ替える
+func (d *differ) equal(lo, hi int) {
+	if lo == hi {
+		return
+	}
+	d.reorder()
+	for i := lo; i < hi; i++ {
+		d.lines = append(d.lines, Line{Op: OpEqual, Text: d.a[i]})
+	}
+}
+
+// reorder 末尾の連続する変更の削除を追加より先に並べる
+func (d *differ) reorder() {
+	start := len(d.lines)
+	for start > 0 && d.lines[start-1].Op != OpEqual {
+		start--
+	}
+	changes := d.lines[start:]
+	sorted := make([]Line, 0, len(changes))
+	for _, op := range []Op{OpDelete, OpInsert} {
+		for _, line := range changes {
+			if line.Op == op {
+				sorted = append(sorted, line)
+			}
+		}
+	}
+	copy(changes, sorted)
+}
+
+// middleSnake 最短の編集の中央にあるスネーク(対角線の連続)の始点(x, y)と終点(u, v)を返す
+// 前方と後方から同時に探索し、各方向で対角線ごとに到達したxだけを保持する
+func (d *differ) middleSnake(aLo, aHi, bLo, bHi int) (x, y, u, v int) {
+	n, m := aHi-aLo, bHi-bLo
+	delta := n - m
+	odd := delta%2 != 0
+	limit := (n + m + 1) / 2
+	offset := limit + 1
+	// forward[k] 前方から対角線kで到達したx、backward[k] 後方から対角線kで到達した末尾からの距離
+	forward := make([]int, 2*offset+1)
+	backward := make([]int, 2*offset+1)
+
+	for step := 0; step <= limit; step++ {
+		for k := -step; k <= step; k += 2 {
+			var fx int
+			if k == -step || (k != step && forward[offset+k-1] < forward[offset+k+1]) {
+				fx = forward[offset+k+1]
+			} else {
+				fx = forward[offset+k-1] + 1
+			}
+			fy := fx - k
+			sx, sy := fx, fy
+			for fx < n && fy < m && d.a[aLo+fx] == d.b[bLo+fy] {
+				fx++
+				fy++
+			}
+			forward[offset+k] = fx
+			if odd && delta-k >= -(step-1) && delta-k <= step-1 && fx+backward[offset+delta-k] >= n {
+				return aLo + sx, bLo + sy, aLo + fx, bLo + fy
+			}
+		}
+		for k := -step; k <= step; k += 2 {
+			var bx int
+			if k == -step || (k != step && backward[offset+k-1] < backward[offset+k+1]) {
+				bx = backward[offset+k+1]
+			} else {
+				bx = backward[offset+k-1] + 1
+			}
+			by := bx - k
+			sx, sy := bx, by
+			for bx < n && by < m && d.a[aHi-1-bx] == d.b[bHi-1-by] {
+				bx++
+				by++
+			}
+			backward[offset+k] = bx
+			if !odd && delta-k >= -step && delta-k <= step && bx+forward[offset+delta-k] >= n {
+				return aHi - bx, bHi - by, aHi - sx, bHi - sy
+			}
+		}
+	}
+	// 到達しない(編集の長さはn+m以下のため必ず途中で重なる)
+	panic("textdiff: middle snake not found")
+}
+
+// splitLines テキストを行に分割する
+// 空のテキストは0行とし、末尾の改行は行として扱わない
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
@@ -0,0 +1,125 @@
+package textdiff
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLines(t *testing.T) {
+	tests := []struct {
+		name    string
+		before  string
+		after   string
+		want    []Line
+		wantErr error
+	}{
+		{
+			name:   "正常ケース(変更なし)",
+			before: "a\nb\n",
+			after:  "a\nb",
+			want: []Line{
+				{Op: OpEqual, Text: "a"},
+				{Op: OpEqual, Text: "b"},
+			},
+		},
+		{
+			name:   "正常ケース(行の変更)",
+			before: "a\nb\nc",
+			after:  "a\nB\nc",
+			want: []Line{
+				{Op: OpEqual, Text: "a"},
+				{Op: OpDelete, Text: "b"},
+				{Op: OpInsert, Text: "B"},
+				{Op: OpEqual, Text: "c"},
+			},
+		},
+		{
+			name:   "正常ケース(追加と削除)",
+			before: "a\nb\nc\nd",
+			after:  "b\nc\ne\nd",
+			want: []Line{
+				{Op: OpDelete, Text: "a"},
+				{Op: OpEqual, Text: "b"},
+				{Op: OpEqual, Text: "c"},
+				{Op: OpInsert, Text: "e"},
+				{Op: OpEqual, Text: "d"},
+			},
+		},
+		{
+			name:   "正常ケース(複数箇所の変更)",
+			before: "a\nb\nc\nd\ne\nf",
+			after:  "x\nb\nd\ny\ne\nz",
+			want: []Line{
+				{Op: OpDelete, Text: "a"},
+				{Op: OpInsert, Text: "x"},
+				{Op: OpEqual, Text: "b"},
+				{Op: OpDelete, Text: "c"},
+				{Op: OpEqual, Text: "d"},
+				{Op: OpInsert, Text: "y"},
+				{Op: OpEqual, Text: "e"},
+				{Op: OpDelete, Text: "f"},
+				{Op: OpInsert, Text: "z"},
+			},
+		},
+		{
+			name:   "正常ケース(空から追加)",
+			before: "",
+			after:  "a\r\nb",
+			want: []Line{
+				{Op: OpInsert, Text: "a"},
+				{Op: OpInsert, Text: "b"},
+			},
+		},
+		{
+			name:   "正常ケース(全削除)",
+			before: "a",
+			after:  "",
+			want: []Line{
+				{Op: OpDelete, Text: "a"},
+			},
+		},
+		{
+			name:   "正常ケース(行数が上限)",
+			before: strings.Repeat("a\n", MaxLines),
+			after:  strings.Repeat("a\n", MaxLines-1) + "b",
+			want: func() []Line {
+				lines := make([]Line, 0, MaxLines+1)
+				for i := 0; i < MaxLines-1; i++ {
+					lines = append(lines, Line{Op: OpEqual, Text: "a"})
+				}
+				return append(lines, Line{Op: OpDelete, Text: "a"}, Line{Op: OpInsert, Text: "b"})
+			}(),
+		},
+		{
+			name:    "異常ケース(行数が上限超過)",
+			before:  "a",
+			after:   strings.Repeat("a\n", MaxLines+1),
+			wantErr: ErrTooManyLines,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Lines(tt.before, tt.after)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("予期せぬエラー(error: %s)", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLine_MarshalJSON(t *testing.T) {
+	got, err := json.Marshal([]Line{{Op: OpInsert, Text: "a"}, {Op: OpDelete, Text: "b"}})
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	want := `[{"op":"insert","text":"a"},{"op":"delete","text":"b"}]`
+	if string(got) != want {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", string(got), want)
+	}
+}
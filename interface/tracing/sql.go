@@ -0,0 +1,276 @@
+package tracing
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type (
+	// connector SQL文ごとにスパンを記録するコネクター
+	connector struct {
+		driver.Connector
+		system string
+	}
+
+	// conn SQL文ごとにスパンを記録するコネクション
+	conn struct {
+		driver.Conn
+		system string
+		// txCtx 実行中のトランザクションを開始したコンテキスト
+		// database/sqlはトランザクション内のSQL文にコンテキストを渡さないため、スパンの親に使う
+		txCtx context.Context
+	}
+
+	// tx トランザクションの終了時にコネクションのコンテキストを破棄するトランザクション
+	tx struct {
+		driver.Tx
+		conn *conn
+	}
+
+	// stmt 実行時にスパンを記録するプリペアドステートメント
+	stmt struct {
+		driver.Stmt
+		conn  *conn
+		query string
+	}
+)
+
+var (
+	_ driver.Connector          = connector{}
+	_ driver.ConnBeginTx        = (*conn)(nil)
+	_ driver.ConnPrepareContext = (*conn)(nil)
+	_ driver.ExecerContext      = (*conn)(nil)
+	_ driver.QueryerContext     = (*conn)(nil)
+	_ driver.Pinger             = (*conn)(nil)
+	_ driver.SessionResetter    = (*conn)(nil)
+	_ driver.Validator          = (*conn)(nil)
+	_ driver.NamedValueChecker  = (*conn)(nil)
+	_ driver.StmtExecContext    = (*stmt)(nil)
+	_ driver.StmtQueryContext   = (*stmt)(nil)
+)
+
+var (
+	// sqlStringLiteral SQL文中の文字列リテラル
+	sqlStringLiteral = regexp.MustCompile(`'(?:[^'\\]|\\.|'')*'|"(?:[^"\\]|\\.|"")*"`)
+	// sqlNumberLiteral SQL文中の数値リテラル
+	sqlNumberLiteral = regexp.MustCompile(`\b\d+(?:\.\d+)?\b`)
+	// sqlWhitespace SQL文中の連続する空白
+	sqlWhitespace = regexp.MustCompile(`\s+`)
+)
+
+var sqlTracer = Tracer("GoBBS/interface/tracing/sql")
+
+// WrapConnector SQL文を実行するごとにスパンを記録するコネクターを返す
+// systemはスパンに記録するDBの種類(mysqlなど)
+func WrapConnector(c driver.Connector, system string) driver.Connector {
+	return connector{Connector: c, system: system}
+}
+
+// Connect スパンを記録するコネクションを返す
+func (c connector) Connect(ctx context.Context) (driver.Conn, error) {
+	dc, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &conn{Conn: dc, system: c.system}, nil
+}
+
+// SanitizeQuery スパンに記録するため、SQL文のリテラルを?に置き換え、空白を詰める
+func SanitizeQuery(query string) string {
+	query = sqlStringLiteral.ReplaceAllString(query, "?")
+	query = sqlNumberLiteral.ReplaceAllString(query, "?")
+	return strings.TrimSpace(sqlWhitespace.ReplaceAllString(query, " "))
+}
+
+// BeginTx トランザクションを開始し、以降のSQL文のスパンの親にするコンテキストを保持する
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	var (
+		dt  driver.Tx
+		err error
+	)
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		dt, err = beginner.BeginTx(ctx, opts)
+	} else {
+		dt, err = c.Conn.Begin()
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.txCtx = ctx
+	return &tx{Tx: dt, conn: c}, nil
+}
+
+// PrepareContext 実行時にスパンを記録するプリペアドステートメントを返す
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var (
+		ds  driver.Stmt
+		err error
+	)
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		ds, err = preparer.PrepareContext(ctx, query)
+	} else {
+		ds, err = c.Conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &stmt{Stmt: ds, conn: c, query: query}, nil
+}
+
+// ExecContext SQL文を実行し、スパンを記録する
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	result, err := execer.ExecContext(ctx, query, args)
+	// ErrSkipの場合はプリペアドステートメントで実行され直すため、そちらで記録する
+	if err != driver.ErrSkip {
+		c.record(ctx, query, start, err)
+	}
+	return result, err
+}
+
+// QueryContext SQL文を実行し、スパンを記録する
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	rows, err := queryer.QueryContext(ctx, query, args)
+	if err != driver.ErrSkip {
+		c.record(ctx, query, start, err)
+	}
+	return rows, err
+}
+
+// Ping DBとの接続を確認する
+func (c *conn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+// ResetSession コネクションを再利用する前に状態をリセットする
+func (c *conn) ResetSession(ctx context.Context) error {
+	c.txCtx = nil
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+// IsValid コネクションを再利用できるか判定する
+func (c *conn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+// CheckNamedValue 引数の型の変換をドライバーに委ねる
+func (c *conn) CheckNamedValue(nv *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+// record 実行したSQL文のスパンを記録する
+// 引数のコンテキストにスパンがない場合はトランザクションを開始したコンテキストを親にする
+func (c *conn) record(ctx context.Context, query string, start time.Time, err error) {
+	if !trace.SpanContextFromContext(ctx).IsValid() && c.txCtx != nil {
+		ctx = c.txCtx
+	}
+	_, span := sqlTracer.Start(ctx, spanName(query),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithTimestamp(start),
+		trace.WithAttributes(
+			attribute.String("db.system", c.system),
+			attribute.String("db.statement", SanitizeQuery(query)),
+		),
+	)
+	RecordError(span, err)
+	span.End()
+}
+
+// spanName SQL文の種類(SELECT, INSERTなど)をスパン名にする
+func spanName(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return "SQL"
+	}
+	return strings.ToUpper(fields[0])
+}
+
+// Commit トランザクションをコミットする
+func (t *tx) Commit() error {
+	t.conn.txCtx = nil
+	return t.Tx.Commit()
+}
+
+// Rollback トランザクションをロールバックする
+func (t *tx) Rollback() error {
+	t.conn.txCtx = nil
+	return t.Tx.Rollback()
+}
+
+// ExecContext プリペアドステートメントを実行し、スパンを記録する
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	start := time.Now()
+	var (
+		result driver.Result
+		err    error
+	)
+	if execer, ok := s.Stmt.(driver.StmtExecContext); ok {
+		result, err = execer.ExecContext(ctx, args)
+	} else {
+		var values []driver.Value
+		if values, err = namedValues(args); err == nil {
+			result, err = s.Stmt.Exec(values)
+		}
+	}
+	s.conn.record(ctx, s.query, start, err)
+	return result, err
+}
+
+// QueryContext プリペアドステートメントを実行し、スパンを記録する
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	start := time.Now()
+	var (
+		rows driver.Rows
+		err  error
+	)
+	if queryer, ok := s.Stmt.(driver.StmtQueryContext); ok {
+		rows, err = queryer.QueryContext(ctx, args)
+	} else {
+		var values []driver.Value
+		if values, err = namedValues(args); err == nil {
+			rows, err = s.Stmt.Query(values)
+		}
+	}
+	s.conn.record(ctx, s.query, start, err)
+	return rows, err
+}
+
+// namedValues 名前付き引数をサポートしないドライバー向けに引数を変換する
+func namedValues(args []driver.NamedValue) ([]driver.Value, error) {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		if arg.Name != "" {
+			return nil, errors.New("named parameters are not supported")
+		}
+		values[i] = arg.Value
+	}
+	return values, nil
+}
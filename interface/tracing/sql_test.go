@@ -0,0 +1,132 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// dsnConnector DSNとドライバーからコネクションを生成するコネクター
+type dsnConnector struct {
+	dsn    string
+	driver driver.Driver
+}
+
+func (c dsnConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.dsn)
+}
+
+func (c dsnConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+func TestSanitizeQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "正常ケース(プレースホルダー)",
+			query: "select id, name\n\t\tfrom users\n\t\twhere id = ?",
+			want:  "select id, name from users where id = ?",
+		},
+		{
+			name:  "正常ケース(リテラルを置き換える)",
+			query: `update users set name = 'o''brien', email = "a@example.com", age = 20, score = 1.5 where id = 3`,
+			want:  "update users set name = ?, email = ?, age = ?, score = ? where id = ?",
+		},
+		{
+			name:  "正常ケース(識別子中の数字は残す)",
+			query: "select col1 from table2 limit 10",
+			want:  "select col1 from table2 limit ?",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeQuery(tt.query); got != tt.want {
+				t.Errorf("SanitizeQuery() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrapConnector(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	mockDB, mock, err := sqlmock.NewWithDSN("tracing_test", sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("sqlmockの生成に失敗(error: %s)", err)
+	}
+	defer mockDB.Close()
+	db := sql.OpenDB(WrapConnector(dsnConnector{dsn: "tracing_test", driver: mockDB.Driver()}, "mysql"))
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("insert into users (name) values (?)").WithArgs("test").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery("select id from users where id = ?").WithArgs(1).WillReturnError(errors.New("ng"))
+	mock.ExpectCommit()
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "parent")
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	// トランザクション内のSQL文にはコンテキストを渡さない
+	if _, err := tx.Exec("insert into users (name) values (?)", "test"); err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	if _, err := tx.Query("select id from users where id = ?", 1); err == nil {
+		t.Errorf("予期せぬ正常終了")
+	}
+	if err := tx.Commit(); err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+	parent.End()
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("予期せぬDB操作(error: %s)", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("スパン数不一致 got: %#v want: %#v", len(spans), 3)
+	}
+	want := []struct {
+		name      string
+		statement string
+		code      codes.Code
+	}{
+		{name: "INSERT", statement: "insert into users (name) values (?)", code: codes.Unset},
+		{name: "SELECT", statement: "select id from users where id = ?", code: codes.Error},
+	}
+	for i, w := range want {
+		span := spans[i]
+		if span.Name() != w.name {
+			t.Errorf("スパン名不一致 got: %#v want: %#v", span.Name(), w.name)
+		}
+		if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+			t.Errorf("親スパン不一致 got: %v want: %v", span.Parent().SpanID(), parent.SpanContext().SpanID())
+		}
+		if span.Status().Code != w.code {
+			t.Errorf("ステータス不一致 got: %v want: %v", span.Status().Code, w.code)
+		}
+		attrs := map[attribute.Key]string{}
+		for _, attr := range span.Attributes() {
+			attrs[attr.Key] = attr.Value.AsString()
+		}
+		if attrs["db.system"] != "mysql" || attrs["db.statement"] != w.statement {
+			t.Errorf("属性不一致 got: %v", attrs)
+		}
+	}
+}
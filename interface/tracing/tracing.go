@@ -0,0 +1,67 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ShutdownFunc 未送信のスパンを送信して終了する関数
+type ShutdownFunc func(context.Context) error
+
+// Tracer 名前(計装するパッケージ)ごとのトレーサーを返す
+// Setup前に取得したトレーサーもSetup後の設定で記録する
+func Tracer(name string) trace.Tracer {
+	return otel.Tracer(name)
+}
+
+// Setup W3C Trace Contextでの伝搬を設定し、endpointのOTLPコレクターへスパンを送信する
+// enabledがfalseの場合はスパンを記録しないが、受け取ったトレースIDの伝搬は行う
+func Setup(ctx context.Context, enabled bool, endpoint string, serviceName string) (ShutdownFunc, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+	if !enabled {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint))
+	if err != nil {
+		return nil, errors.Wrap(err, "Setup exporter error")
+	}
+	// OTEL_SERVICE_NAMEなどの環境変数が設定されていればそちらを優先する
+	res, err := resource.New(ctx,
+		resource.WithAttributes(attribute.String("service.name", serviceName)),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "Setup resource error")
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// RecordError スパンにエラーを記録する(errがnilの場合は何もしない)
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
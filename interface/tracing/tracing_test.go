@@ -0,0 +1,55 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSetup(t *testing.T) {
+	shutdown, err := Setup(context.Background(), false, "http://localhost:4318", "gobbs")
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("予期せぬエラー(error: %s)", err)
+	}
+
+	// 複合プロパゲーターはヘッダ名をmapで重複除去するため、順序は不定
+	want := []string{"baggage", "traceparent", "tracestate"}
+	got := otel.GetTextMapPropagator().Fields()
+	slices.Sort(got)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("伝搬するヘッダ不一致 got: %v want: %v", got, want)
+	}
+}
+
+func TestRecordError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{name: "正常ケース", err: errors.New("ng"), want: codes.Error},
+		{name: "正常ケース(エラーなし)", err: nil, want: codes.Unset},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			_, span := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test").Start(context.Background(), "test")
+			RecordError(span, tt.err)
+			span.End()
+
+			if got := recorder.Ended()[0].Status().Code; got != tt.want {
+				t.Errorf("ステータス不一致 got: %v want: %v", got, tt.want)
+			}
+		})
+	}
+}
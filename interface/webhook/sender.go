@@ -0,0 +1,82 @@
+package webhook
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+)
+
+const (
+	// HeaderEvent イベントを通知するヘッダー
+	HeaderEvent = "X-GoBBS-Event"
+	// HeaderDelivery 配信のIDを通知するヘッダー(再送時も同じ値のため、受信側で重複を除ける)
+	HeaderDelivery = "X-GoBBS-Delivery"
+	// HeaderTimestamp 送信日時(UNIX秒)を通知するヘッダー
+	HeaderTimestamp = "X-GoBBS-Timestamp"
+	// HeaderSignature 署名を通知するヘッダー
+	HeaderSignature = "X-GoBBS-Signature"
+
+	// sendTimeout 1回の送信のタイムアウト(配信の取得時に先送りする1件あたりの期間より短くする)
+	sendTimeout = 10 * time.Second
+	// maxResponseBody 読み捨てる応答の本文の上限
+	maxResponseBody = 64 << 10
+)
+
+// Sender Webhookの送信
+// mockgen -source interface/webhook/sender.go -destination mock/mock_webhook/sender_mock.go
+type Sender interface {
+	Send(webhook model.Webhook, delivery model.WebhookDelivery) (int, error)
+}
+
+// httpSender HTTPでWebhookを送信する
+type httpSender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+var _ Sender = (*httpSender)(nil)
+
+// NewHTTPSender HTTPのWebhook送信を生成する(リダイレクトには従わない)
+func NewHTTPSender() *httpSender {
+	return &httpSender{
+		client: &http.Client{
+			Timeout: sendTimeout,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		now: time.Now,
+	}
+}
+
+// Send 署名付きで配信をPOSTし、応答のステータスコードを返す(応答がない場合はエラー)
+func (s *httpSender) Send(webhook model.Webhook, delivery model.WebhookDelivery) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, webhook.URL(), bytes.NewReader(delivery.Payload()))
+	if err != nil {
+		return 0, errors.Wrap(err, "Send error")
+	}
+
+	timestamp := s.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "GoBBS-Webhook")
+	req.Header.Set(HeaderEvent, string(delivery.Event()))
+	req.Header.Set(HeaderDelivery, delivery.ID())
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret(), timestamp, delivery.Payload()))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, errors.Wrap(err, "Send error")
+	}
+	defer resp.Body.Close()
+	// コネクションを再利用できるよう本文を読み捨てる
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBody))
+
+	return resp.StatusCode, nil
+}
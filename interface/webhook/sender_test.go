@@ -0,0 +1,91 @@
+package webhook
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"GoBBS/domain/model"
+)
+
+func TestNewHTTPSender(t *testing.T) {
+	s := NewHTTPSender()
+	if s.client.Timeout != sendTimeout || s.client.CheckRedirect == nil || s.now == nil {
+		t.Errorf("NewHTTPSender() = %+v", s)
+	}
+}
+
+func Test_httpSender_Send(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	payload := []byte(`{"event":"post.created"}`)
+	delivery := model.NewWebhookDelivery("7", "1", model.WebhookPostCreated, payload, model.WebhookDeliveryPending, 0, now, 0, "", now, time.Time{})
+
+	var got *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		body, _ = io.ReadAll(r.Body)
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "/hook", http.StatusFound)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	s := NewHTTPSender()
+	s.now = func() time.Time { return now }
+
+	t.Run("正常ケース", func(t *testing.T) {
+		webhook := model.NewWebhook("1", "2", server.URL+"/hook", "secret", nil, now)
+		status, err := s.Send(webhook, delivery)
+		if err != nil {
+			t.Fatalf("予期せぬエラー(error: %s)", err)
+		}
+		if status != http.StatusAccepted {
+			t.Errorf("戻り値不一致 got: %#v want: %#v", status, http.StatusAccepted)
+		}
+		if got.Method != http.MethodPost || string(body) != string(payload) {
+			t.Errorf("リクエスト不一致 got: %s %s", got.Method, body)
+		}
+		headers := map[string]string{
+			"Content-Type":  "application/json",
+			HeaderEvent:     "post.created",
+			HeaderDelivery:  "7",
+			HeaderTimestamp: "1672628645",
+			HeaderSignature: Sign("secret", 1672628645, payload),
+		}
+		for k, v := range headers {
+			if got.Header.Get(k) != v {
+				t.Errorf("%s不一致 got: %#v want: %#v", k, got.Header.Get(k), v)
+			}
+		}
+	})
+
+	t.Run("正常ケース(リダイレクトには従わない)", func(t *testing.T) {
+		webhook := model.NewWebhook("1", "2", server.URL+"/redirect", "secret", nil, now)
+		status, err := s.Send(webhook, delivery)
+		if err != nil {
+			t.Fatalf("予期せぬエラー(error: %s)", err)
+		}
+		if status != http.StatusFound {
+			t.Errorf("戻り値不一致 got: %#v want: %#v", status, http.StatusFound)
+		}
+	})
+
+	t.Run("異常ケース(接続失敗)", func(t *testing.T) {
+		webhook := model.NewWebhook("1", "2", "http://127.0.0.1:0/hook", "secret", nil, now)
+		if _, err := s.Send(webhook, delivery); err == nil {
+			t.Errorf("予期せぬ正常終了")
+		}
+	})
+
+	t.Run("異常ケース(URL不正)", func(t *testing.T) {
+		webhook := model.NewWebhook("1", "2", "://invalid", "secret", nil, now)
+		if _, err := s.Send(webhook, delivery); err == nil {
+			t.Errorf("予期せぬ正常終了")
+		}
+	})
+}
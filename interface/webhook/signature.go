@@ -0,0 +1,22 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// SignaturePrefix 署名ヘッダーの値の接頭辞
+const SignaturePrefix = "sha256="
+
+// Sign 送信日時(UNIX秒)と本文からHMAC-SHA256の署名を計算する
+// 受信側は "<timestamp>.<body>" を秘密鍵で署名し、X-GoBBS-Signatureヘッダーと比較することで検証できる
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return SignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
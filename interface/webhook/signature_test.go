@@ -0,0 +1,18 @@
+package webhook
+
+import "testing"
+
+func TestSign(t *testing.T) {
+	// echo -n '1672628645.{"event":"post.created"}' | openssl dgst -sha256 -hmac secret
+	want := "sha256=7fd02ecc3523f37afa5d9032d75d38b7a01092072453d7162e61c3c7c6c50dd0"
+	got := Sign("secret", 1672628645, []byte(`{"event":"post.created"}`))
+	if got != want {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", got, want)
+	}
+	if Sign("other", 1672628645, []byte(`{"event":"post.created"}`)) == got {
+		t.Errorf("秘密鍵が異なる場合は署名も異なる")
+	}
+	if Sign("secret", 1672628646, []byte(`{"event":"post.created"}`)) == got {
+		t.Errorf("送信日時が異なる場合は署名も異なる")
+	}
+}
@@ -0,0 +1,53 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface/digest/activity.go
+
+// Package mock_digest is a generated GoMock package.
+package mock_digest
+
+import (
+	model "GoBBS/domain/model"
+	digest "GoBBS/interface/digest"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockActivitySource is a mock of ActivitySource interface.
+type MockActivitySource struct {
+	ctrl     *gomock.Controller
+	recorder *MockActivitySourceMockRecorder
+}
+
+// MockActivitySourceMockRecorder is the mock recorder for MockActivitySource.
+type MockActivitySourceMockRecorder struct {
+	mock *MockActivitySource
+}
+
+// NewMockActivitySource creates a new mock instance.
+func NewMockActivitySource(ctrl *gomock.Controller) *MockActivitySource {
+	mock := &MockActivitySource{ctrl: ctrl}
+	mock.recorder = &MockActivitySourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockActivitySource) EXPECT() *MockActivitySourceMockRecorder {
+	return m.recorder
+}
+
+// Find mocks base method.
+func (m *MockActivitySource) Find(ctx context.Context, watches []model.Watch, from, to time.Time) ([]digest.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, watches, from, to)
+	ret0, _ := ret[0].([]digest.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockActivitySourceMockRecorder) Find(ctx, watches, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockActivitySource)(nil).Find), ctx, watches, from, to)
+}
@@ -5,12 +5,14 @@
 package mock_handlerctx
 
 import (
+	context "context"
 	io "io"
 	http "net/http"
 	url "net/url"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	websocket "github.com/gorilla/websocket"
 )
 
 // MockAPIContext is a mock of APIContext interface.
@@ -62,6 +64,20 @@ func (mr *MockAPIContextMockRecorder) PathParam() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PathParam", reflect.TypeOf((*MockAPIContext)(nil).PathParam))
 }
 
+// RemoteIP mocks base method.
+func (m *MockAPIContext) RemoteIP() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoteIP")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// RemoteIP indicates an expected call of RemoteIP.
+func (mr *MockAPIContextMockRecorder) RemoteIP() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoteIP", reflect.TypeOf((*MockAPIContext)(nil).RemoteIP))
+}
+
 // RequestBody mocks base method.
 func (m *MockAPIContext) RequestBody() io.ReadCloser {
 	m.ctrl.T.Helper()
@@ -76,6 +92,35 @@ func (mr *MockAPIContextMockRecorder) RequestBody() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestBody", reflect.TypeOf((*MockAPIContext)(nil).RequestBody))
 }
 
+// RequestContext mocks base method.
+func (m *MockAPIContext) RequestContext() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestContext")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// RequestContext indicates an expected call of RequestContext.
+func (mr *MockAPIContextMockRecorder) RequestContext() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestContext", reflect.TypeOf((*MockAPIContext)(nil).RequestContext))
+}
+
+// RequestCookie mocks base method.
+func (m *MockAPIContext) RequestCookie(arg0 string) (*http.Cookie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestCookie", arg0)
+	ret0, _ := ret[0].(*http.Cookie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestCookie indicates an expected call of RequestCookie.
+func (mr *MockAPIContextMockRecorder) RequestCookie(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestCookie", reflect.TypeOf((*MockAPIContext)(nil).RequestCookie), arg0)
+}
+
 // RequestHeader mocks base method.
 func (m *MockAPIContext) RequestHeader() http.Header {
 	m.ctrl.T.Helper()
@@ -90,6 +135,20 @@ func (mr *MockAPIContextMockRecorder) RequestHeader() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestHeader", reflect.TypeOf((*MockAPIContext)(nil).RequestHeader))
 }
 
+// RequestID mocks base method.
+func (m *MockAPIContext) RequestID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// RequestID indicates an expected call of RequestID.
+func (mr *MockAPIContextMockRecorder) RequestID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestID", reflect.TypeOf((*MockAPIContext)(nil).RequestID))
+}
+
 // RequestMethod mocks base method.
 func (m *MockAPIContext) RequestMethod() string {
 	m.ctrl.T.Helper()
@@ -104,6 +163,46 @@ func (mr *MockAPIContextMockRecorder) RequestMethod() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestMethod", reflect.TypeOf((*MockAPIContext)(nil).RequestMethod))
 }
 
+// Route mocks base method.
+func (m *MockAPIContext) Route() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Route")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Route indicates an expected call of Route.
+func (mr *MockAPIContextMockRecorder) Route() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Route", reflect.TypeOf((*MockAPIContext)(nil).Route))
+}
+
+// SessionID mocks base method.
+func (m *MockAPIContext) SessionID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SessionID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// SessionID indicates an expected call of SessionID.
+func (mr *MockAPIContextMockRecorder) SessionID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SessionID", reflect.TypeOf((*MockAPIContext)(nil).SessionID))
+}
+
+// SetCookie mocks base method.
+func (m *MockAPIContext) SetCookie(arg0 *http.Cookie) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetCookie", arg0)
+}
+
+// SetCookie indicates an expected call of SetCookie.
+func (mr *MockAPIContextMockRecorder) SetCookie(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCookie", reflect.TypeOf((*MockAPIContext)(nil).SetCookie), arg0)
+}
+
 // SetPathParam mocks base method.
 func (m *MockAPIContext) SetPathParam(arg0 string) {
 	m.ctrl.T.Helper()
@@ -116,6 +215,82 @@ func (mr *MockAPIContextMockRecorder) SetPathParam(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPathParam", reflect.TypeOf((*MockAPIContext)(nil).SetPathParam), arg0)
 }
 
+// SetRequestContext mocks base method.
+func (m *MockAPIContext) SetRequestContext(arg0 context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetRequestContext", arg0)
+}
+
+// SetRequestContext indicates an expected call of SetRequestContext.
+func (mr *MockAPIContextMockRecorder) SetRequestContext(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRequestContext", reflect.TypeOf((*MockAPIContext)(nil).SetRequestContext), arg0)
+}
+
+// SetRequestID mocks base method.
+func (m *MockAPIContext) SetRequestID(arg0 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetRequestID", arg0)
+}
+
+// SetRequestID indicates an expected call of SetRequestID.
+func (mr *MockAPIContextMockRecorder) SetRequestID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRequestID", reflect.TypeOf((*MockAPIContext)(nil).SetRequestID), arg0)
+}
+
+// SetSessionID mocks base method.
+func (m *MockAPIContext) SetSessionID(arg0 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSessionID", arg0)
+}
+
+// SetSessionID indicates an expected call of SetSessionID.
+func (mr *MockAPIContextMockRecorder) SetSessionID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSessionID", reflect.TypeOf((*MockAPIContext)(nil).SetSessionID), arg0)
+}
+
+// SetUserID mocks base method.
+func (m *MockAPIContext) SetUserID(arg0 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetUserID", arg0)
+}
+
+// SetUserID indicates an expected call of SetUserID.
+func (mr *MockAPIContextMockRecorder) SetUserID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserID", reflect.TypeOf((*MockAPIContext)(nil).SetUserID), arg0)
+}
+
+// StartEventStream mocks base method.
+func (m *MockAPIContext) StartEventStream() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartEventStream")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartEventStream indicates an expected call of StartEventStream.
+func (mr *MockAPIContextMockRecorder) StartEventStream() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartEventStream", reflect.TypeOf((*MockAPIContext)(nil).StartEventStream))
+}
+
+// StatusCode mocks base method.
+func (m *MockAPIContext) StatusCode() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StatusCode")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// StatusCode indicates an expected call of StatusCode.
+func (mr *MockAPIContextMockRecorder) StatusCode() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StatusCode", reflect.TypeOf((*MockAPIContext)(nil).StatusCode))
+}
+
 // URL mocks base method.
 func (m *MockAPIContext) URL() *url.URL {
 	m.ctrl.T.Helper()
@@ -130,6 +305,63 @@ func (mr *MockAPIContextMockRecorder) URL() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "URL", reflect.TypeOf((*MockAPIContext)(nil).URL))
 }
 
+// UpgradeWebSocket mocks base method.
+func (m *MockAPIContext) UpgradeWebSocket(arg0 *websocket.Upgrader) (*websocket.Conn, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpgradeWebSocket", arg0)
+	ret0, _ := ret[0].(*websocket.Conn)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpgradeWebSocket indicates an expected call of UpgradeWebSocket.
+func (mr *MockAPIContextMockRecorder) UpgradeWebSocket(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeWebSocket", reflect.TypeOf((*MockAPIContext)(nil).UpgradeWebSocket), arg0)
+}
+
+// UserID mocks base method.
+func (m *MockAPIContext) UserID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// UserID indicates an expected call of UserID.
+func (mr *MockAPIContextMockRecorder) UserID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserID", reflect.TypeOf((*MockAPIContext)(nil).UserID))
+}
+
+// WriteEvent mocks base method.
+func (m *MockAPIContext) WriteEvent(arg0, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteEvent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteEvent indicates an expected call of WriteEvent.
+func (mr *MockAPIContextMockRecorder) WriteEvent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteEvent", reflect.TypeOf((*MockAPIContext)(nil).WriteEvent), arg0, arg1, arg2)
+}
+
+// WriteEventComment mocks base method.
+func (m *MockAPIContext) WriteEventComment(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteEventComment", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteEventComment indicates an expected call of WriteEventComment.
+func (mr *MockAPIContextMockRecorder) WriteEventComment(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteEventComment", reflect.TypeOf((*MockAPIContext)(nil).WriteEventComment), arg0)
+}
+
 // WriteResponseJSON mocks base method.
 func (m *MockAPIContext) WriteResponseJSON(arg0 int, arg1 any) error {
 	m.ctrl.T.Helper()
@@ -144,6 +376,20 @@ func (mr *MockAPIContextMockRecorder) WriteResponseJSON(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteResponseJSON", reflect.TypeOf((*MockAPIContext)(nil).WriteResponseJSON), arg0, arg1)
 }
 
+// WriteResponseStream mocks base method.
+func (m *MockAPIContext) WriteResponseStream(arg0 int, arg1 string, arg2 io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteResponseStream", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteResponseStream indicates an expected call of WriteResponseStream.
+func (mr *MockAPIContextMockRecorder) WriteResponseStream(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteResponseStream", reflect.TypeOf((*MockAPIContext)(nil).WriteResponseStream), arg0, arg1, arg2)
+}
+
 // WriteStatusCode mocks base method.
 func (m *MockAPIContext) WriteStatusCode(arg0 int) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface/middleware/accesslog_middleware.go
+
+// Package mock_middleware is a generated GoMock package.
+package mock_middleware
+
+import (
+	middlewarehelper "GoBBS/interface/middleware/middlewarehelper"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAccessLog is a mock of AccessLog interface.
+type MockAccessLog struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccessLogMockRecorder
+}
+
+// MockAccessLogMockRecorder is the mock recorder for MockAccessLog.
+type MockAccessLogMockRecorder struct {
+	mock *MockAccessLog
+}
+
+// NewMockAccessLog creates a new mock instance.
+func NewMockAccessLog(ctrl *gomock.Controller) *MockAccessLog {
+	mock := &MockAccessLog{ctrl: ctrl}
+	mock.recorder = &MockAccessLogMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccessLog) EXPECT() *MockAccessLogMockRecorder {
+	return m.recorder
+}
+
+// Record mocks base method.
+func (m *MockAccessLog) Record(arg0 middlewarehelper.HandlerFunc) middlewarehelper.HandlerFunc {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", arg0)
+	ret0, _ := ret[0].(middlewarehelper.HandlerFunc)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockAccessLogMockRecorder) Record(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockAccessLog)(nil).Record), arg0)
+}
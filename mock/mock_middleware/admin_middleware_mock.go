@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface/middleware/admin_middleware.go
+
+// Package mock_middleware is a generated GoMock package.
+package mock_middleware
+
+import (
+	middlewarehelper "GoBBS/interface/middleware/middlewarehelper"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAdmin is a mock of Admin interface.
+type MockAdmin struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminMockRecorder
+}
+
+// MockAdminMockRecorder is the mock recorder for MockAdmin.
+type MockAdminMockRecorder struct {
+	mock *MockAdmin
+}
+
+// NewMockAdmin creates a new mock instance.
+func NewMockAdmin(ctrl *gomock.Controller) *MockAdmin {
+	mock := &MockAdmin{ctrl: ctrl}
+	mock.recorder = &MockAdminMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdmin) EXPECT() *MockAdminMockRecorder {
+	return m.recorder
+}
+
+// VerifyAdmin mocks base method.
+func (m *MockAdmin) VerifyAdmin(arg0 middlewarehelper.HandlerFunc) middlewarehelper.HandlerFunc {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyAdmin", arg0)
+	ret0, _ := ret[0].(middlewarehelper.HandlerFunc)
+	return ret0
+}
+
+// VerifyAdmin indicates an expected call of VerifyAdmin.
+func (mr *MockAdminMockRecorder) VerifyAdmin(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyAdmin", reflect.TypeOf((*MockAdmin)(nil).VerifyAdmin), arg0)
+}
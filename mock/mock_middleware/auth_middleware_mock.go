@@ -47,3 +47,31 @@ func (mr *MockAuthMockRecorder) VerifyAuth(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyAuth", reflect.TypeOf((*MockAuth)(nil).VerifyAuth), arg0)
 }
+
+// VerifyScope mocks base method.
+func (m *MockAuth) VerifyScope(scope string) middlewarehelper.MiddlewareFunc {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyScope", scope)
+	ret0, _ := ret[0].(middlewarehelper.MiddlewareFunc)
+	return ret0
+}
+
+// VerifyScope indicates an expected call of VerifyScope.
+func (mr *MockAuthMockRecorder) VerifyScope(scope interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyScope", reflect.TypeOf((*MockAuth)(nil).VerifyScope), scope)
+}
+
+// VerifyWebSocketAuth mocks base method.
+func (m *MockAuth) VerifyWebSocketAuth(arg0 middlewarehelper.HandlerFunc) middlewarehelper.HandlerFunc {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyWebSocketAuth", arg0)
+	ret0, _ := ret[0].(middlewarehelper.HandlerFunc)
+	return ret0
+}
+
+// VerifyWebSocketAuth indicates an expected call of VerifyWebSocketAuth.
+func (mr *MockAuthMockRecorder) VerifyWebSocketAuth(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyWebSocketAuth", reflect.TypeOf((*MockAuth)(nil).VerifyWebSocketAuth), arg0)
+}
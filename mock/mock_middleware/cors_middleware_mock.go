@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface/middleware/cors_middleware.go
+
+// Package mock_middleware is a generated GoMock package.
+package mock_middleware
+
+import (
+	middlewarehelper "GoBBS/interface/middleware/middlewarehelper"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCORS is a mock of CORS interface.
+type MockCORS struct {
+	ctrl     *gomock.Controller
+	recorder *MockCORSMockRecorder
+}
+
+// MockCORSMockRecorder is the mock recorder for MockCORS.
+type MockCORSMockRecorder struct {
+	mock *MockCORS
+}
+
+// NewMockCORS creates a new mock instance.
+func NewMockCORS(ctrl *gomock.Controller) *MockCORS {
+	mock := &MockCORS{ctrl: ctrl}
+	mock.recorder = &MockCORSMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCORS) EXPECT() *MockCORSMockRecorder {
+	return m.recorder
+}
+
+// Handle mocks base method.
+func (m *MockCORS) Handle(arg0 middlewarehelper.HandlerFunc) middlewarehelper.HandlerFunc {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Handle", arg0)
+	ret0, _ := ret[0].(middlewarehelper.HandlerFunc)
+	return ret0
+}
+
+// Handle indicates an expected call of Handle.
+func (mr *MockCORSMockRecorder) Handle(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Handle", reflect.TypeOf((*MockCORS)(nil).Handle), arg0)
+}
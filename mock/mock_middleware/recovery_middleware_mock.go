@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface/middleware/recovery_middleware.go
+
+// Package mock_middleware is a generated GoMock package.
+package mock_middleware
+
+import (
+	handlerctx "GoBBS/interface/handler/handlerctx"
+	middlewarehelper "GoBBS/interface/middleware/middlewarehelper"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRecovery is a mock of Recovery interface.
+type MockRecovery struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecoveryMockRecorder
+}
+
+// MockRecoveryMockRecorder is the mock recorder for MockRecovery.
+type MockRecoveryMockRecorder struct {
+	mock *MockRecovery
+}
+
+// NewMockRecovery creates a new mock instance.
+func NewMockRecovery(ctrl *gomock.Controller) *MockRecovery {
+	mock := &MockRecovery{ctrl: ctrl}
+	mock.recorder = &MockRecoveryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecovery) EXPECT() *MockRecoveryMockRecorder {
+	return m.recorder
+}
+
+// HandlePanic mocks base method.
+func (m *MockRecovery) HandlePanic(c handlerctx.APIContext, rec any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandlePanic", c, rec)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandlePanic indicates an expected call of HandlePanic.
+func (mr *MockRecoveryMockRecorder) HandlePanic(c, rec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandlePanic", reflect.TypeOf((*MockRecovery)(nil).HandlePanic), c, rec)
+}
+
+// Recover mocks base method.
+func (m *MockRecovery) Recover(arg0 middlewarehelper.HandlerFunc) middlewarehelper.HandlerFunc {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recover", arg0)
+	ret0, _ := ret[0].(middlewarehelper.HandlerFunc)
+	return ret0
+}
+
+// Recover indicates an expected call of Recover.
+func (mr *MockRecoveryMockRecorder) Recover(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recover", reflect.TypeOf((*MockRecovery)(nil).Recover), arg0)
+}
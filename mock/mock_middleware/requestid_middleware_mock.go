@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface/middleware/requestid_middleware.go
+
+// Package mock_middleware is a generated GoMock package.
+package mock_middleware
+
+import (
+	middlewarehelper "GoBBS/interface/middleware/middlewarehelper"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRequestID is a mock of RequestID interface.
+type MockRequestID struct {
+	ctrl     *gomock.Controller
+	recorder *MockRequestIDMockRecorder
+}
+
+// MockRequestIDMockRecorder is the mock recorder for MockRequestID.
+type MockRequestIDMockRecorder struct {
+	mock *MockRequestID
+}
+
+// NewMockRequestID creates a new mock instance.
+func NewMockRequestID(ctrl *gomock.Controller) *MockRequestID {
+	mock := &MockRequestID{ctrl: ctrl}
+	mock.recorder = &MockRequestIDMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRequestID) EXPECT() *MockRequestIDMockRecorder {
+	return m.recorder
+}
+
+// Assign mocks base method.
+func (m *MockRequestID) Assign(arg0 middlewarehelper.HandlerFunc) middlewarehelper.HandlerFunc {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Assign", arg0)
+	ret0, _ := ret[0].(middlewarehelper.HandlerFunc)
+	return ret0
+}
+
+// Assign indicates an expected call of Assign.
+func (mr *MockRequestIDMockRecorder) Assign(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Assign", reflect.TypeOf((*MockRequestID)(nil).Assign), arg0)
+}
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface/middleware/tracing_middleware.go
+
+// Package mock_middleware is a generated GoMock package.
+package mock_middleware
+
+import (
+	middlewarehelper "GoBBS/interface/middleware/middlewarehelper"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTracing is a mock of Tracing interface.
+type MockTracing struct {
+	ctrl     *gomock.Controller
+	recorder *MockTracingMockRecorder
+}
+
+// MockTracingMockRecorder is the mock recorder for MockTracing.
+type MockTracingMockRecorder struct {
+	mock *MockTracing
+}
+
+// NewMockTracing creates a new mock instance.
+func NewMockTracing(ctrl *gomock.Controller) *MockTracing {
+	mock := &MockTracing{ctrl: ctrl}
+	mock.recorder = &MockTracingMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTracing) EXPECT() *MockTracingMockRecorder {
+	return m.recorder
+}
+
+// Trace mocks base method.
+func (m *MockTracing) Trace(arg0 middlewarehelper.HandlerFunc) middlewarehelper.HandlerFunc {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trace", arg0)
+	ret0, _ := ret[0].(middlewarehelper.HandlerFunc)
+	return ret0
+}
+
+// Trace indicates an expected call of Trace.
+func (mr *MockTracingMockRecorder) Trace(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trace", reflect.TypeOf((*MockTracing)(nil).Trace), arg0)
+}
@@ -0,0 +1,175 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/model/api_token_model.go
+
+// Package mock_model is a generated GoMock package.
+package mock_model
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAPIToken is a mock of APIToken interface.
+type MockAPIToken struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPITokenMockRecorder
+}
+
+// MockAPITokenMockRecorder is the mock recorder for MockAPIToken.
+type MockAPITokenMockRecorder struct {
+	mock *MockAPIToken
+}
+
+// NewMockAPIToken creates a new mock instance.
+func NewMockAPIToken(ctrl *gomock.Controller) *MockAPIToken {
+	mock := &MockAPIToken{ctrl: ctrl}
+	mock.recorder = &MockAPITokenMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIToken) EXPECT() *MockAPITokenMockRecorder {
+	return m.recorder
+}
+
+// CreatedAt mocks base method.
+func (m *MockAPIToken) CreatedAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatedAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// CreatedAt indicates an expected call of CreatedAt.
+func (mr *MockAPITokenMockRecorder) CreatedAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatedAt", reflect.TypeOf((*MockAPIToken)(nil).CreatedAt))
+}
+
+// ExpiresAt mocks base method.
+func (m *MockAPIToken) ExpiresAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpiresAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// ExpiresAt indicates an expected call of ExpiresAt.
+func (mr *MockAPITokenMockRecorder) ExpiresAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpiresAt", reflect.TypeOf((*MockAPIToken)(nil).ExpiresAt))
+}
+
+// HasScope mocks base method.
+func (m *MockAPIToken) HasScope(scope string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasScope", scope)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasScope indicates an expected call of HasScope.
+func (mr *MockAPITokenMockRecorder) HasScope(scope interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasScope", reflect.TypeOf((*MockAPIToken)(nil).HasScope), scope)
+}
+
+// Hash mocks base method.
+func (m *MockAPIToken) Hash() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hash")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Hash indicates an expected call of Hash.
+func (mr *MockAPITokenMockRecorder) Hash() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hash", reflect.TypeOf((*MockAPIToken)(nil).Hash))
+}
+
+// ID mocks base method.
+func (m *MockAPIToken) ID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ID indicates an expected call of ID.
+func (mr *MockAPITokenMockRecorder) ID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ID", reflect.TypeOf((*MockAPIToken)(nil).ID))
+}
+
+// IsValid mocks base method.
+func (m *MockAPIToken) IsValid(now time.Time) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsValid", now)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsValid indicates an expected call of IsValid.
+func (mr *MockAPITokenMockRecorder) IsValid(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsValid", reflect.TypeOf((*MockAPIToken)(nil).IsValid), now)
+}
+
+// LastUsedAt mocks base method.
+func (m *MockAPIToken) LastUsedAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastUsedAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// LastUsedAt indicates an expected call of LastUsedAt.
+func (mr *MockAPITokenMockRecorder) LastUsedAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastUsedAt", reflect.TypeOf((*MockAPIToken)(nil).LastUsedAt))
+}
+
+// Name mocks base method.
+func (m *MockAPIToken) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockAPITokenMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockAPIToken)(nil).Name))
+}
+
+// Scopes mocks base method.
+func (m *MockAPIToken) Scopes() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Scopes")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// Scopes indicates an expected call of Scopes.
+func (mr *MockAPITokenMockRecorder) Scopes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scopes", reflect.TypeOf((*MockAPIToken)(nil).Scopes))
+}
+
+// UserID mocks base method.
+func (m *MockAPIToken) UserID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// UserID indicates an expected call of UserID.
+func (mr *MockAPITokenMockRecorder) UserID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserID", reflect.TypeOf((*MockAPIToken)(nil).UserID))
+}
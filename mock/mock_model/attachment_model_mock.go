@@ -0,0 +1,175 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/model/attachment_model.go
+
+// Package mock_model is a generated GoMock package.
+package mock_model
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAttachment is a mock of Attachment interface.
+type MockAttachment struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentMockRecorder
+}
+
+// MockAttachmentMockRecorder is the mock recorder for MockAttachment.
+type MockAttachmentMockRecorder struct {
+	mock *MockAttachment
+}
+
+// NewMockAttachment creates a new mock instance.
+func NewMockAttachment(ctrl *gomock.Controller) *MockAttachment {
+	mock := &MockAttachment{ctrl: ctrl}
+	mock.recorder = &MockAttachmentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachment) EXPECT() *MockAttachmentMockRecorder {
+	return m.recorder
+}
+
+// BlobKey mocks base method.
+func (m *MockAttachment) BlobKey() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlobKey")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// BlobKey indicates an expected call of BlobKey.
+func (mr *MockAttachmentMockRecorder) BlobKey() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlobKey", reflect.TypeOf((*MockAttachment)(nil).BlobKey))
+}
+
+// ContentType mocks base method.
+func (m *MockAttachment) ContentType() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ContentType")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ContentType indicates an expected call of ContentType.
+func (mr *MockAttachmentMockRecorder) ContentType() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ContentType", reflect.TypeOf((*MockAttachment)(nil).ContentType))
+}
+
+// CreatedAt mocks base method.
+func (m *MockAttachment) CreatedAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatedAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// CreatedAt indicates an expected call of CreatedAt.
+func (mr *MockAttachmentMockRecorder) CreatedAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatedAt", reflect.TypeOf((*MockAttachment)(nil).CreatedAt))
+}
+
+// Height mocks base method.
+func (m *MockAttachment) Height() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Height")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Height indicates an expected call of Height.
+func (mr *MockAttachmentMockRecorder) Height() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Height", reflect.TypeOf((*MockAttachment)(nil).Height))
+}
+
+// ID mocks base method.
+func (m *MockAttachment) ID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ID indicates an expected call of ID.
+func (mr *MockAttachmentMockRecorder) ID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ID", reflect.TypeOf((*MockAttachment)(nil).ID))
+}
+
+// PostID mocks base method.
+func (m *MockAttachment) PostID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// PostID indicates an expected call of PostID.
+func (mr *MockAttachmentMockRecorder) PostID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostID", reflect.TypeOf((*MockAttachment)(nil).PostID))
+}
+
+// Size mocks base method.
+func (m *MockAttachment) Size() int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Size")
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// Size indicates an expected call of Size.
+func (mr *MockAttachmentMockRecorder) Size() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Size", reflect.TypeOf((*MockAttachment)(nil).Size))
+}
+
+// ThumbnailKey mocks base method.
+func (m *MockAttachment) ThumbnailKey() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ThumbnailKey")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ThumbnailKey indicates an expected call of ThumbnailKey.
+func (mr *MockAttachmentMockRecorder) ThumbnailKey() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ThumbnailKey", reflect.TypeOf((*MockAttachment)(nil).ThumbnailKey))
+}
+
+// UserID mocks base method.
+func (m *MockAttachment) UserID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// UserID indicates an expected call of UserID.
+func (mr *MockAttachmentMockRecorder) UserID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserID", reflect.TypeOf((*MockAttachment)(nil).UserID))
+}
+
+// Width mocks base method.
+func (m *MockAttachment) Width() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Width")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Width indicates an expected call of Width.
+func (mr *MockAttachmentMockRecorder) Width() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Width", reflect.TypeOf((*MockAttachment)(nil).Width))
+}
@@ -0,0 +1,176 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/model/event_model.go
+
+// Package mock_model is a generated GoMock package.
+package mock_model
+
+import (
+	model "GoBBS/domain/model"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockEvent is a mock of Event interface.
+type MockEvent struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventMockRecorder
+}
+
+// MockEventMockRecorder is the mock recorder for MockEvent.
+type MockEventMockRecorder struct {
+	mock *MockEvent
+}
+
+// NewMockEvent creates a new mock instance.
+func NewMockEvent(ctrl *gomock.Controller) *MockEvent {
+	mock := &MockEvent{ctrl: ctrl}
+	mock.recorder = &MockEventMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEvent) EXPECT() *MockEventMockRecorder {
+	return m.recorder
+}
+
+// AggregateID mocks base method.
+func (m *MockEvent) AggregateID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AggregateID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// AggregateID indicates an expected call of AggregateID.
+func (mr *MockEventMockRecorder) AggregateID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AggregateID", reflect.TypeOf((*MockEvent)(nil).AggregateID))
+}
+
+// Attempts mocks base method.
+func (m *MockEvent) Attempts() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Attempts")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Attempts indicates an expected call of Attempts.
+func (mr *MockEventMockRecorder) Attempts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attempts", reflect.TypeOf((*MockEvent)(nil).Attempts))
+}
+
+// DispatchedAt mocks base method.
+func (m *MockEvent) DispatchedAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DispatchedAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// DispatchedAt indicates an expected call of DispatchedAt.
+func (mr *MockEventMockRecorder) DispatchedAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DispatchedAt", reflect.TypeOf((*MockEvent)(nil).DispatchedAt))
+}
+
+// ID mocks base method.
+func (m *MockEvent) ID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ID indicates an expected call of ID.
+func (mr *MockEventMockRecorder) ID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ID", reflect.TypeOf((*MockEvent)(nil).ID))
+}
+
+// IsDispatched mocks base method.
+func (m *MockEvent) IsDispatched() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsDispatched")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsDispatched indicates an expected call of IsDispatched.
+func (mr *MockEventMockRecorder) IsDispatched() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsDispatched", reflect.TypeOf((*MockEvent)(nil).IsDispatched))
+}
+
+// LastError mocks base method.
+func (m *MockEvent) LastError() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastError")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// LastError indicates an expected call of LastError.
+func (mr *MockEventMockRecorder) LastError() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastError", reflect.TypeOf((*MockEvent)(nil).LastError))
+}
+
+// NextAttemptAt mocks base method.
+func (m *MockEvent) NextAttemptAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextAttemptAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// NextAttemptAt indicates an expected call of NextAttemptAt.
+func (mr *MockEventMockRecorder) NextAttemptAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextAttemptAt", reflect.TypeOf((*MockEvent)(nil).NextAttemptAt))
+}
+
+// OccurredAt mocks base method.
+func (m *MockEvent) OccurredAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OccurredAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// OccurredAt indicates an expected call of OccurredAt.
+func (mr *MockEventMockRecorder) OccurredAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OccurredAt", reflect.TypeOf((*MockEvent)(nil).OccurredAt))
+}
+
+// Payload mocks base method.
+func (m *MockEvent) Payload() []byte {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Payload")
+	ret0, _ := ret[0].([]byte)
+	return ret0
+}
+
+// Payload indicates an expected call of Payload.
+func (mr *MockEventMockRecorder) Payload() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Payload", reflect.TypeOf((*MockEvent)(nil).Payload))
+}
+
+// Type mocks base method.
+func (m *MockEvent) Type() model.EventType {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Type")
+	ret0, _ := ret[0].(model.EventType)
+	return ret0
+}
+
+// Type indicates an expected call of Type.
+func (mr *MockEventMockRecorder) Type() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Type", reflect.TypeOf((*MockEvent)(nil).Type))
+}
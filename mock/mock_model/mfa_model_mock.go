@@ -0,0 +1,255 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/model/mfa_model.go
+
+// Package mock_model is a generated GoMock package.
+package mock_model
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMFA is a mock of MFA interface.
+type MockMFA struct {
+	ctrl     *gomock.Controller
+	recorder *MockMFAMockRecorder
+}
+
+// MockMFAMockRecorder is the mock recorder for MockMFA.
+type MockMFAMockRecorder struct {
+	mock *MockMFA
+}
+
+// NewMockMFA creates a new mock instance.
+func NewMockMFA(ctrl *gomock.Controller) *MockMFA {
+	mock := &MockMFA{ctrl: ctrl}
+	mock.recorder = &MockMFAMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMFA) EXPECT() *MockMFAMockRecorder {
+	return m.recorder
+}
+
+// ConfirmedAt mocks base method.
+func (m *MockMFA) ConfirmedAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmedAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// ConfirmedAt indicates an expected call of ConfirmedAt.
+func (mr *MockMFAMockRecorder) ConfirmedAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmedAt", reflect.TypeOf((*MockMFA)(nil).ConfirmedAt))
+}
+
+// CreatedAt mocks base method.
+func (m *MockMFA) CreatedAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatedAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// CreatedAt indicates an expected call of CreatedAt.
+func (mr *MockMFAMockRecorder) CreatedAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatedAt", reflect.TypeOf((*MockMFA)(nil).CreatedAt))
+}
+
+// IsEnabled mocks base method.
+func (m *MockMFA) IsEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsEnabled indicates an expected call of IsEnabled.
+func (mr *MockMFAMockRecorder) IsEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsEnabled", reflect.TypeOf((*MockMFA)(nil).IsEnabled))
+}
+
+// LastUsedStep mocks base method.
+func (m *MockMFA) LastUsedStep() int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastUsedStep")
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// LastUsedStep indicates an expected call of LastUsedStep.
+func (mr *MockMFAMockRecorder) LastUsedStep() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastUsedStep", reflect.TypeOf((*MockMFA)(nil).LastUsedStep))
+}
+
+// Secret mocks base method.
+func (m *MockMFA) Secret() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Secret")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Secret indicates an expected call of Secret.
+func (mr *MockMFAMockRecorder) Secret() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Secret", reflect.TypeOf((*MockMFA)(nil).Secret))
+}
+
+// URI mocks base method.
+func (m *MockMFA) URI(issuer, accountName string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "URI", issuer, accountName)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// URI indicates an expected call of URI.
+func (mr *MockMFAMockRecorder) URI(issuer, accountName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "URI", reflect.TypeOf((*MockMFA)(nil).URI), issuer, accountName)
+}
+
+// UserID mocks base method.
+func (m *MockMFA) UserID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// UserID indicates an expected call of UserID.
+func (mr *MockMFAMockRecorder) UserID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserID", reflect.TypeOf((*MockMFA)(nil).UserID))
+}
+
+// VerifyCode mocks base method.
+func (m *MockMFA) VerifyCode(code string, now time.Time) (int64, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyCode", code, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// VerifyCode indicates an expected call of VerifyCode.
+func (mr *MockMFAMockRecorder) VerifyCode(code, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyCode", reflect.TypeOf((*MockMFA)(nil).VerifyCode), code, now)
+}
+
+// MockMFAChallenge is a mock of MFAChallenge interface.
+type MockMFAChallenge struct {
+	ctrl     *gomock.Controller
+	recorder *MockMFAChallengeMockRecorder
+}
+
+// MockMFAChallengeMockRecorder is the mock recorder for MockMFAChallenge.
+type MockMFAChallengeMockRecorder struct {
+	mock *MockMFAChallenge
+}
+
+// NewMockMFAChallenge creates a new mock instance.
+func NewMockMFAChallenge(ctrl *gomock.Controller) *MockMFAChallenge {
+	mock := &MockMFAChallenge{ctrl: ctrl}
+	mock.recorder = &MockMFAChallengeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMFAChallenge) EXPECT() *MockMFAChallengeMockRecorder {
+	return m.recorder
+}
+
+// Attempts mocks base method.
+func (m *MockMFAChallenge) Attempts() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Attempts")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Attempts indicates an expected call of Attempts.
+func (mr *MockMFAChallengeMockRecorder) Attempts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attempts", reflect.TypeOf((*MockMFAChallenge)(nil).Attempts))
+}
+
+// CreatedAt mocks base method.
+func (m *MockMFAChallenge) CreatedAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatedAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// CreatedAt indicates an expected call of CreatedAt.
+func (mr *MockMFAChallengeMockRecorder) CreatedAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatedAt", reflect.TypeOf((*MockMFAChallenge)(nil).CreatedAt))
+}
+
+// ExpiresAt mocks base method.
+func (m *MockMFAChallenge) ExpiresAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpiresAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// ExpiresAt indicates an expected call of ExpiresAt.
+func (mr *MockMFAChallengeMockRecorder) ExpiresAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpiresAt", reflect.TypeOf((*MockMFAChallenge)(nil).ExpiresAt))
+}
+
+// ID mocks base method.
+func (m *MockMFAChallenge) ID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ID indicates an expected call of ID.
+func (mr *MockMFAChallengeMockRecorder) ID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ID", reflect.TypeOf((*MockMFAChallenge)(nil).ID))
+}
+
+// IsValid mocks base method.
+func (m *MockMFAChallenge) IsValid(now time.Time) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsValid", now)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsValid indicates an expected call of IsValid.
+func (mr *MockMFAChallengeMockRecorder) IsValid(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsValid", reflect.TypeOf((*MockMFAChallenge)(nil).IsValid), now)
+}
+
+// UserID mocks base method.
+func (m *MockMFAChallenge) UserID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// UserID indicates an expected call of UserID.
+func (mr *MockMFAChallengeMockRecorder) UserID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserID", reflect.TypeOf((*MockMFAChallenge)(nil).UserID))
+}
@@ -0,0 +1,148 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/model/notification_model.go
+
+// Package mock_model is a generated GoMock package.
+package mock_model
+
+import (
+	model "GoBBS/domain/model"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockNotification is a mock of Notification interface.
+type MockNotification struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationMockRecorder
+}
+
+// MockNotificationMockRecorder is the mock recorder for MockNotification.
+type MockNotificationMockRecorder struct {
+	mock *MockNotification
+}
+
+// NewMockNotification creates a new mock instance.
+func NewMockNotification(ctrl *gomock.Controller) *MockNotification {
+	mock := &MockNotification{ctrl: ctrl}
+	mock.recorder = &MockNotificationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotification) EXPECT() *MockNotificationMockRecorder {
+	return m.recorder
+}
+
+// ActorID mocks base method.
+func (m *MockNotification) ActorID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActorID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ActorID indicates an expected call of ActorID.
+func (mr *MockNotificationMockRecorder) ActorID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActorID", reflect.TypeOf((*MockNotification)(nil).ActorID))
+}
+
+// CreatedAt mocks base method.
+func (m *MockNotification) CreatedAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatedAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// CreatedAt indicates an expected call of CreatedAt.
+func (mr *MockNotificationMockRecorder) CreatedAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatedAt", reflect.TypeOf((*MockNotification)(nil).CreatedAt))
+}
+
+// ID mocks base method.
+func (m *MockNotification) ID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ID indicates an expected call of ID.
+func (mr *MockNotificationMockRecorder) ID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ID", reflect.TypeOf((*MockNotification)(nil).ID))
+}
+
+// IsRead mocks base method.
+func (m *MockNotification) IsRead() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsRead")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsRead indicates an expected call of IsRead.
+func (mr *MockNotificationMockRecorder) IsRead() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsRead", reflect.TypeOf((*MockNotification)(nil).IsRead))
+}
+
+// Kind mocks base method.
+func (m *MockNotification) Kind() model.NotificationKind {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Kind")
+	ret0, _ := ret[0].(model.NotificationKind)
+	return ret0
+}
+
+// Kind indicates an expected call of Kind.
+func (mr *MockNotificationMockRecorder) Kind() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Kind", reflect.TypeOf((*MockNotification)(nil).Kind))
+}
+
+// ReadAt mocks base method.
+func (m *MockNotification) ReadAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// ReadAt indicates an expected call of ReadAt.
+func (mr *MockNotificationMockRecorder) ReadAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAt", reflect.TypeOf((*MockNotification)(nil).ReadAt))
+}
+
+// TargetID mocks base method.
+func (m *MockNotification) TargetID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TargetID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// TargetID indicates an expected call of TargetID.
+func (mr *MockNotificationMockRecorder) TargetID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TargetID", reflect.TypeOf((*MockNotification)(nil).TargetID))
+}
+
+// UserID mocks base method.
+func (m *MockNotification) UserID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// UserID indicates an expected call of UserID.
+func (mr *MockNotificationMockRecorder) UserID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserID", reflect.TypeOf((*MockNotification)(nil).UserID))
+}
@@ -0,0 +1,203 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/model/post_model.go
+
+// Package mock_model is a generated GoMock package.
+package mock_model
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPost is a mock of Post interface.
+type MockPost struct {
+	ctrl     *gomock.Controller
+	recorder *MockPostMockRecorder
+}
+
+// MockPostMockRecorder is the mock recorder for MockPost.
+type MockPostMockRecorder struct {
+	mock *MockPost
+}
+
+// NewMockPost creates a new mock instance.
+func NewMockPost(ctrl *gomock.Controller) *MockPost {
+	mock := &MockPost{ctrl: ctrl}
+	mock.recorder = &MockPostMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPost) EXPECT() *MockPostMockRecorder {
+	return m.recorder
+}
+
+// Body mocks base method.
+func (m *MockPost) Body() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Body")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Body indicates an expected call of Body.
+func (mr *MockPostMockRecorder) Body() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Body", reflect.TypeOf((*MockPost)(nil).Body))
+}
+
+// BodyHTML mocks base method.
+func (m *MockPost) BodyHTML() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BodyHTML")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// BodyHTML indicates an expected call of BodyHTML.
+func (mr *MockPostMockRecorder) BodyHTML() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BodyHTML", reflect.TypeOf((*MockPost)(nil).BodyHTML))
+}
+
+// CreatedAt mocks base method.
+func (m *MockPost) CreatedAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatedAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// CreatedAt indicates an expected call of CreatedAt.
+func (mr *MockPostMockRecorder) CreatedAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatedAt", reflect.TypeOf((*MockPost)(nil).CreatedAt))
+}
+
+// EditedAt mocks base method.
+func (m *MockPost) EditedAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditedAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// EditedAt indicates an expected call of EditedAt.
+func (mr *MockPostMockRecorder) EditedAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditedAt", reflect.TypeOf((*MockPost)(nil).EditedAt))
+}
+
+// HiddenAt mocks base method.
+func (m *MockPost) HiddenAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HiddenAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// HiddenAt indicates an expected call of HiddenAt.
+func (mr *MockPostMockRecorder) HiddenAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HiddenAt", reflect.TypeOf((*MockPost)(nil).HiddenAt))
+}
+
+// HiddenReason mocks base method.
+func (m *MockPost) HiddenReason() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HiddenReason")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// HiddenReason indicates an expected call of HiddenReason.
+func (mr *MockPostMockRecorder) HiddenReason() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HiddenReason", reflect.TypeOf((*MockPost)(nil).HiddenReason))
+}
+
+// ID mocks base method.
+func (m *MockPost) ID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ID indicates an expected call of ID.
+func (mr *MockPostMockRecorder) ID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ID", reflect.TypeOf((*MockPost)(nil).ID))
+}
+
+// IsEdited mocks base method.
+func (m *MockPost) IsEdited() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsEdited")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsEdited indicates an expected call of IsEdited.
+func (mr *MockPostMockRecorder) IsEdited() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsEdited", reflect.TypeOf((*MockPost)(nil).IsEdited))
+}
+
+// IsHidden mocks base method.
+func (m *MockPost) IsHidden() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsHidden")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsHidden indicates an expected call of IsHidden.
+func (mr *MockPostMockRecorder) IsHidden() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsHidden", reflect.TypeOf((*MockPost)(nil).IsHidden))
+}
+
+// Number mocks base method.
+func (m *MockPost) Number() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Number")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Number indicates an expected call of Number.
+func (mr *MockPostMockRecorder) Number() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Number", reflect.TypeOf((*MockPost)(nil).Number))
+}
+
+// ThreadID mocks base method.
+func (m *MockPost) ThreadID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ThreadID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ThreadID indicates an expected call of ThreadID.
+func (mr *MockPostMockRecorder) ThreadID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ThreadID", reflect.TypeOf((*MockPost)(nil).ThreadID))
+}
+
+// UserID mocks base method.
+func (m *MockPost) UserID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// UserID indicates an expected call of UserID.
+func (mr *MockPostMockRecorder) UserID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserID", reflect.TypeOf((*MockPost)(nil).UserID))
+}
@@ -0,0 +1,105 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/model/post_revision_model.go
+
+// Package mock_model is a generated GoMock package.
+package mock_model
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPostRevision is a mock of PostRevision interface.
+type MockPostRevision struct {
+	ctrl     *gomock.Controller
+	recorder *MockPostRevisionMockRecorder
+}
+
+// MockPostRevisionMockRecorder is the mock recorder for MockPostRevision.
+type MockPostRevisionMockRecorder struct {
+	mock *MockPostRevision
+}
+
+// NewMockPostRevision creates a new mock instance.
+func NewMockPostRevision(ctrl *gomock.Controller) *MockPostRevision {
+	mock := &MockPostRevision{ctrl: ctrl}
+	mock.recorder = &MockPostRevisionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPostRevision) EXPECT() *MockPostRevisionMockRecorder {
+	return m.recorder
+}
+
+// Body mocks base method.
+func (m *MockPostRevision) Body() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Body")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Body indicates an expected call of Body.
+func (mr *MockPostRevisionMockRecorder) Body() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Body", reflect.TypeOf((*MockPostRevision)(nil).Body))
+}
+
+// CreatedAt mocks base method.
+func (m *MockPostRevision) CreatedAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatedAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// CreatedAt indicates an expected call of CreatedAt.
+func (mr *MockPostRevisionMockRecorder) CreatedAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatedAt", reflect.TypeOf((*MockPostRevision)(nil).CreatedAt))
+}
+
+// EditorID mocks base method.
+func (m *MockPostRevision) EditorID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditorID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// EditorID indicates an expected call of EditorID.
+func (mr *MockPostRevisionMockRecorder) EditorID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditorID", reflect.TypeOf((*MockPostRevision)(nil).EditorID))
+}
+
+// ID mocks base method.
+func (m *MockPostRevision) ID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ID indicates an expected call of ID.
+func (mr *MockPostRevisionMockRecorder) ID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ID", reflect.TypeOf((*MockPostRevision)(nil).ID))
+}
+
+// PostID mocks base method.
+func (m *MockPostRevision) PostID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// PostID indicates an expected call of PostID.
+func (mr *MockPostRevisionMockRecorder) PostID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostID", reflect.TypeOf((*MockPostRevision)(nil).PostID))
+}
@@ -0,0 +1,175 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/model/session_model.go
+
+// Package mock_model is a generated GoMock package.
+package mock_model
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSession is a mock of Session interface.
+type MockSession struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionMockRecorder
+}
+
+// MockSessionMockRecorder is the mock recorder for MockSession.
+type MockSessionMockRecorder struct {
+	mock *MockSession
+}
+
+// NewMockSession creates a new mock instance.
+func NewMockSession(ctrl *gomock.Controller) *MockSession {
+	mock := &MockSession{ctrl: ctrl}
+	mock.recorder = &MockSessionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSession) EXPECT() *MockSessionMockRecorder {
+	return m.recorder
+}
+
+// CreatedAt mocks base method.
+func (m *MockSession) CreatedAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatedAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// CreatedAt indicates an expected call of CreatedAt.
+func (mr *MockSessionMockRecorder) CreatedAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatedAt", reflect.TypeOf((*MockSession)(nil).CreatedAt))
+}
+
+// Device mocks base method.
+func (m *MockSession) Device() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Device")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Device indicates an expected call of Device.
+func (mr *MockSessionMockRecorder) Device() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Device", reflect.TypeOf((*MockSession)(nil).Device))
+}
+
+// ExpiresAt mocks base method.
+func (m *MockSession) ExpiresAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpiresAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// ExpiresAt indicates an expected call of ExpiresAt.
+func (mr *MockSessionMockRecorder) ExpiresAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpiresAt", reflect.TypeOf((*MockSession)(nil).ExpiresAt))
+}
+
+// ID mocks base method.
+func (m *MockSession) ID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ID indicates an expected call of ID.
+func (mr *MockSessionMockRecorder) ID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ID", reflect.TypeOf((*MockSession)(nil).ID))
+}
+
+// IP mocks base method.
+func (m *MockSession) IP() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IP")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// IP indicates an expected call of IP.
+func (mr *MockSessionMockRecorder) IP() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IP", reflect.TypeOf((*MockSession)(nil).IP))
+}
+
+// IsActive mocks base method.
+func (m *MockSession) IsActive(now time.Time) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsActive", now)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsActive indicates an expected call of IsActive.
+func (mr *MockSessionMockRecorder) IsActive(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsActive", reflect.TypeOf((*MockSession)(nil).IsActive), now)
+}
+
+// LastSeenAt mocks base method.
+func (m *MockSession) LastSeenAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastSeenAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// LastSeenAt indicates an expected call of LastSeenAt.
+func (mr *MockSessionMockRecorder) LastSeenAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastSeenAt", reflect.TypeOf((*MockSession)(nil).LastSeenAt))
+}
+
+// RevokedAt mocks base method.
+func (m *MockSession) RevokedAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokedAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// RevokedAt indicates an expected call of RevokedAt.
+func (mr *MockSessionMockRecorder) RevokedAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokedAt", reflect.TypeOf((*MockSession)(nil).RevokedAt))
+}
+
+// UserAgent mocks base method.
+func (m *MockSession) UserAgent() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserAgent")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// UserAgent indicates an expected call of UserAgent.
+func (mr *MockSessionMockRecorder) UserAgent() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserAgent", reflect.TypeOf((*MockSession)(nil).UserAgent))
+}
+
+// UserID mocks base method.
+func (m *MockSession) UserID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// UserID indicates an expected call of UserID.
+func (mr *MockSessionMockRecorder) UserID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserID", reflect.TypeOf((*MockSession)(nil).UserID))
+}
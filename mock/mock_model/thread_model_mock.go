@@ -0,0 +1,133 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/model/thread_model.go
+
+// Package mock_model is a generated GoMock package.
+package mock_model
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockThread is a mock of Thread interface.
+type MockThread struct {
+	ctrl     *gomock.Controller
+	recorder *MockThreadMockRecorder
+}
+
+// MockThreadMockRecorder is the mock recorder for MockThread.
+type MockThreadMockRecorder struct {
+	mock *MockThread
+}
+
+// NewMockThread creates a new mock instance.
+func NewMockThread(ctrl *gomock.Controller) *MockThread {
+	mock := &MockThread{ctrl: ctrl}
+	mock.recorder = &MockThreadMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockThread) EXPECT() *MockThreadMockRecorder {
+	return m.recorder
+}
+
+// BoardID mocks base method.
+func (m *MockThread) BoardID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BoardID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// BoardID indicates an expected call of BoardID.
+func (mr *MockThreadMockRecorder) BoardID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BoardID", reflect.TypeOf((*MockThread)(nil).BoardID))
+}
+
+// CreatedAt mocks base method.
+func (m *MockThread) CreatedAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatedAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// CreatedAt indicates an expected call of CreatedAt.
+func (mr *MockThreadMockRecorder) CreatedAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatedAt", reflect.TypeOf((*MockThread)(nil).CreatedAt))
+}
+
+// ID mocks base method.
+func (m *MockThread) ID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ID indicates an expected call of ID.
+func (mr *MockThreadMockRecorder) ID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ID", reflect.TypeOf((*MockThread)(nil).ID))
+}
+
+// LastPostedAt mocks base method.
+func (m *MockThread) LastPostedAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastPostedAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// LastPostedAt indicates an expected call of LastPostedAt.
+func (mr *MockThreadMockRecorder) LastPostedAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastPostedAt", reflect.TypeOf((*MockThread)(nil).LastPostedAt))
+}
+
+// PostCount mocks base method.
+func (m *MockThread) PostCount() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostCount")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// PostCount indicates an expected call of PostCount.
+func (mr *MockThreadMockRecorder) PostCount() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostCount", reflect.TypeOf((*MockThread)(nil).PostCount))
+}
+
+// Title mocks base method.
+func (m *MockThread) Title() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Title")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Title indicates an expected call of Title.
+func (mr *MockThreadMockRecorder) Title() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Title", reflect.TypeOf((*MockThread)(nil).Title))
+}
+
+// UserID mocks base method.
+func (m *MockThread) UserID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// UserID indicates an expected call of UserID.
+func (mr *MockThreadMockRecorder) UserID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserID", reflect.TypeOf((*MockThread)(nil).UserID))
+}
@@ -0,0 +1,105 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/model/user_identity_model.go
+
+// Package mock_model is a generated GoMock package.
+package mock_model
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserIdentity is a mock of UserIdentity interface.
+type MockUserIdentity struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserIdentityMockRecorder
+}
+
+// MockUserIdentityMockRecorder is the mock recorder for MockUserIdentity.
+type MockUserIdentityMockRecorder struct {
+	mock *MockUserIdentity
+}
+
+// NewMockUserIdentity creates a new mock instance.
+func NewMockUserIdentity(ctrl *gomock.Controller) *MockUserIdentity {
+	mock := &MockUserIdentity{ctrl: ctrl}
+	mock.recorder = &MockUserIdentityMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserIdentity) EXPECT() *MockUserIdentityMockRecorder {
+	return m.recorder
+}
+
+// CreatedAt mocks base method.
+func (m *MockUserIdentity) CreatedAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatedAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// CreatedAt indicates an expected call of CreatedAt.
+func (mr *MockUserIdentityMockRecorder) CreatedAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatedAt", reflect.TypeOf((*MockUserIdentity)(nil).CreatedAt))
+}
+
+// Email mocks base method.
+func (m *MockUserIdentity) Email() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Email")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Email indicates an expected call of Email.
+func (mr *MockUserIdentityMockRecorder) Email() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Email", reflect.TypeOf((*MockUserIdentity)(nil).Email))
+}
+
+// Issuer mocks base method.
+func (m *MockUserIdentity) Issuer() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Issuer")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Issuer indicates an expected call of Issuer.
+func (mr *MockUserIdentityMockRecorder) Issuer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Issuer", reflect.TypeOf((*MockUserIdentity)(nil).Issuer))
+}
+
+// Subject mocks base method.
+func (m *MockUserIdentity) Subject() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subject")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Subject indicates an expected call of Subject.
+func (mr *MockUserIdentityMockRecorder) Subject() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subject", reflect.TypeOf((*MockUserIdentity)(nil).Subject))
+}
+
+// UserID mocks base method.
+func (m *MockUserIdentity) UserID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// UserID indicates an expected call of UserID.
+func (mr *MockUserIdentityMockRecorder) UserID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserID", reflect.TypeOf((*MockUserIdentity)(nil).UserID))
+}
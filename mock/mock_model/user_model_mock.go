@@ -5,6 +5,7 @@
 package mock_model
 
 import (
+	model "GoBBS/domain/model"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -105,6 +106,20 @@ func (mr *MockUserMockRecorder) Password() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Password", reflect.TypeOf((*MockUser)(nil).Password))
 }
 
+// Profile mocks base method.
+func (m *MockUser) Profile() model.Profile {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Profile")
+	ret0, _ := ret[0].(model.Profile)
+	return ret0
+}
+
+// Profile indicates an expected call of Profile.
+func (mr *MockUserMockRecorder) Profile() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Profile", reflect.TypeOf((*MockUser)(nil).Profile))
+}
+
 // Salt mocks base method.
 func (m *MockUser) Salt() string {
 	m.ctrl.T.Helper()
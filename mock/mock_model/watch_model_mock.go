@@ -0,0 +1,92 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/model/watch_model.go
+
+// Package mock_model is a generated GoMock package.
+package mock_model
+
+import (
+	model "GoBBS/domain/model"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockWatch is a mock of Watch interface.
+type MockWatch struct {
+	ctrl     *gomock.Controller
+	recorder *MockWatchMockRecorder
+}
+
+// MockWatchMockRecorder is the mock recorder for MockWatch.
+type MockWatchMockRecorder struct {
+	mock *MockWatch
+}
+
+// NewMockWatch creates a new mock instance.
+func NewMockWatch(ctrl *gomock.Controller) *MockWatch {
+	mock := &MockWatch{ctrl: ctrl}
+	mock.recorder = &MockWatchMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWatch) EXPECT() *MockWatchMockRecorder {
+	return m.recorder
+}
+
+// CreatedAt mocks base method.
+func (m *MockWatch) CreatedAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatedAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// CreatedAt indicates an expected call of CreatedAt.
+func (mr *MockWatchMockRecorder) CreatedAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatedAt", reflect.TypeOf((*MockWatch)(nil).CreatedAt))
+}
+
+// Kind mocks base method.
+func (m *MockWatch) Kind() model.WatchKind {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Kind")
+	ret0, _ := ret[0].(model.WatchKind)
+	return ret0
+}
+
+// Kind indicates an expected call of Kind.
+func (mr *MockWatchMockRecorder) Kind() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Kind", reflect.TypeOf((*MockWatch)(nil).Kind))
+}
+
+// TargetID mocks base method.
+func (m *MockWatch) TargetID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TargetID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// TargetID indicates an expected call of TargetID.
+func (mr *MockWatchMockRecorder) TargetID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TargetID", reflect.TypeOf((*MockWatch)(nil).TargetID))
+}
+
+// UserID mocks base method.
+func (m *MockWatch) UserID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// UserID indicates an expected call of UserID.
+func (mr *MockWatchMockRecorder) UserID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserID", reflect.TypeOf((*MockWatch)(nil).UserID))
+}
@@ -0,0 +1,311 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/model/webhook_model.go
+
+// Package mock_model is a generated GoMock package.
+package mock_model
+
+import (
+	model "GoBBS/domain/model"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockWebhook is a mock of Webhook interface.
+type MockWebhook struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookMockRecorder
+}
+
+// MockWebhookMockRecorder is the mock recorder for MockWebhook.
+type MockWebhookMockRecorder struct {
+	mock *MockWebhook
+}
+
+// NewMockWebhook creates a new mock instance.
+func NewMockWebhook(ctrl *gomock.Controller) *MockWebhook {
+	mock := &MockWebhook{ctrl: ctrl}
+	mock.recorder = &MockWebhookMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhook) EXPECT() *MockWebhookMockRecorder {
+	return m.recorder
+}
+
+// BoardID mocks base method.
+func (m *MockWebhook) BoardID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BoardID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// BoardID indicates an expected call of BoardID.
+func (mr *MockWebhookMockRecorder) BoardID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BoardID", reflect.TypeOf((*MockWebhook)(nil).BoardID))
+}
+
+// CreatedAt mocks base method.
+func (m *MockWebhook) CreatedAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatedAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// CreatedAt indicates an expected call of CreatedAt.
+func (mr *MockWebhookMockRecorder) CreatedAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatedAt", reflect.TypeOf((*MockWebhook)(nil).CreatedAt))
+}
+
+// Events mocks base method.
+func (m *MockWebhook) Events() []model.WebhookEvent {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Events")
+	ret0, _ := ret[0].([]model.WebhookEvent)
+	return ret0
+}
+
+// Events indicates an expected call of Events.
+func (mr *MockWebhookMockRecorder) Events() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Events", reflect.TypeOf((*MockWebhook)(nil).Events))
+}
+
+// ID mocks base method.
+func (m *MockWebhook) ID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ID indicates an expected call of ID.
+func (mr *MockWebhookMockRecorder) ID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ID", reflect.TypeOf((*MockWebhook)(nil).ID))
+}
+
+// Secret mocks base method.
+func (m *MockWebhook) Secret() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Secret")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Secret indicates an expected call of Secret.
+func (mr *MockWebhookMockRecorder) Secret() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Secret", reflect.TypeOf((*MockWebhook)(nil).Secret))
+}
+
+// Subscribes mocks base method.
+func (m *MockWebhook) Subscribes(event model.WebhookEvent) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribes", event)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Subscribes indicates an expected call of Subscribes.
+func (mr *MockWebhookMockRecorder) Subscribes(event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribes", reflect.TypeOf((*MockWebhook)(nil).Subscribes), event)
+}
+
+// URL mocks base method.
+func (m *MockWebhook) URL() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "URL")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// URL indicates an expected call of URL.
+func (mr *MockWebhookMockRecorder) URL() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "URL", reflect.TypeOf((*MockWebhook)(nil).URL))
+}
+
+// MockWebhookDelivery is a mock of WebhookDelivery interface.
+type MockWebhookDelivery struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookDeliveryMockRecorder
+}
+
+// MockWebhookDeliveryMockRecorder is the mock recorder for MockWebhookDelivery.
+type MockWebhookDeliveryMockRecorder struct {
+	mock *MockWebhookDelivery
+}
+
+// NewMockWebhookDelivery creates a new mock instance.
+func NewMockWebhookDelivery(ctrl *gomock.Controller) *MockWebhookDelivery {
+	mock := &MockWebhookDelivery{ctrl: ctrl}
+	mock.recorder = &MockWebhookDeliveryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookDelivery) EXPECT() *MockWebhookDeliveryMockRecorder {
+	return m.recorder
+}
+
+// Attempts mocks base method.
+func (m *MockWebhookDelivery) Attempts() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Attempts")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Attempts indicates an expected call of Attempts.
+func (mr *MockWebhookDeliveryMockRecorder) Attempts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attempts", reflect.TypeOf((*MockWebhookDelivery)(nil).Attempts))
+}
+
+// CreatedAt mocks base method.
+func (m *MockWebhookDelivery) CreatedAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatedAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// CreatedAt indicates an expected call of CreatedAt.
+func (mr *MockWebhookDeliveryMockRecorder) CreatedAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatedAt", reflect.TypeOf((*MockWebhookDelivery)(nil).CreatedAt))
+}
+
+// DeliveredAt mocks base method.
+func (m *MockWebhookDelivery) DeliveredAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliveredAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// DeliveredAt indicates an expected call of DeliveredAt.
+func (mr *MockWebhookDeliveryMockRecorder) DeliveredAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliveredAt", reflect.TypeOf((*MockWebhookDelivery)(nil).DeliveredAt))
+}
+
+// Event mocks base method.
+func (m *MockWebhookDelivery) Event() model.WebhookEvent {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Event")
+	ret0, _ := ret[0].(model.WebhookEvent)
+	return ret0
+}
+
+// Event indicates an expected call of Event.
+func (mr *MockWebhookDeliveryMockRecorder) Event() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Event", reflect.TypeOf((*MockWebhookDelivery)(nil).Event))
+}
+
+// ID mocks base method.
+func (m *MockWebhookDelivery) ID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ID indicates an expected call of ID.
+func (mr *MockWebhookDeliveryMockRecorder) ID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ID", reflect.TypeOf((*MockWebhookDelivery)(nil).ID))
+}
+
+// LastError mocks base method.
+func (m *MockWebhookDelivery) LastError() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastError")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// LastError indicates an expected call of LastError.
+func (mr *MockWebhookDeliveryMockRecorder) LastError() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastError", reflect.TypeOf((*MockWebhookDelivery)(nil).LastError))
+}
+
+// LastStatusCode mocks base method.
+func (m *MockWebhookDelivery) LastStatusCode() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastStatusCode")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// LastStatusCode indicates an expected call of LastStatusCode.
+func (mr *MockWebhookDeliveryMockRecorder) LastStatusCode() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastStatusCode", reflect.TypeOf((*MockWebhookDelivery)(nil).LastStatusCode))
+}
+
+// NextAttemptAt mocks base method.
+func (m *MockWebhookDelivery) NextAttemptAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextAttemptAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// NextAttemptAt indicates an expected call of NextAttemptAt.
+func (mr *MockWebhookDeliveryMockRecorder) NextAttemptAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextAttemptAt", reflect.TypeOf((*MockWebhookDelivery)(nil).NextAttemptAt))
+}
+
+// Payload mocks base method.
+func (m *MockWebhookDelivery) Payload() []byte {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Payload")
+	ret0, _ := ret[0].([]byte)
+	return ret0
+}
+
+// Payload indicates an expected call of Payload.
+func (mr *MockWebhookDeliveryMockRecorder) Payload() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Payload", reflect.TypeOf((*MockWebhookDelivery)(nil).Payload))
+}
+
+// Status mocks base method.
+func (m *MockWebhookDelivery) Status() model.WebhookDeliveryStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status")
+	ret0, _ := ret[0].(model.WebhookDeliveryStatus)
+	return ret0
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockWebhookDeliveryMockRecorder) Status() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockWebhookDelivery)(nil).Status))
+}
+
+// WebhookID mocks base method.
+func (m *MockWebhookDelivery) WebhookID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WebhookID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// WebhookID indicates an expected call of WebhookID.
+func (mr *MockWebhookDeliveryMockRecorder) WebhookID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WebhookID", reflect.TypeOf((*MockWebhookDelivery)(nil).WebhookID))
+}
@@ -0,0 +1,122 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/api_token_repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	model "GoBBS/domain/model"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAPIToken is a mock of APIToken interface.
+type MockAPIToken struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPITokenMockRecorder
+}
+
+// MockAPITokenMockRecorder is the mock recorder for MockAPIToken.
+type MockAPITokenMockRecorder struct {
+	mock *MockAPIToken
+}
+
+// NewMockAPIToken creates a new mock instance.
+func NewMockAPIToken(ctrl *gomock.Controller) *MockAPIToken {
+	mock := &MockAPIToken{ctrl: ctrl}
+	mock.recorder = &MockAPITokenMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIToken) EXPECT() *MockAPITokenMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockAPIToken) Delete(userID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAPITokenMockRecorder) Delete(userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAPIToken)(nil).Delete), userID, id)
+}
+
+// DeleteExpired mocks base method.
+func (m *MockAPIToken) DeleteExpired(userID string, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", userID, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockAPITokenMockRecorder) DeleteExpired(userID, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockAPIToken)(nil).DeleteExpired), userID, now)
+}
+
+// FindActiveByUserID mocks base method.
+func (m *MockAPIToken) FindActiveByUserID(userID string, now time.Time) ([]model.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActiveByUserID", userID, now)
+	ret0, _ := ret[0].([]model.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActiveByUserID indicates an expected call of FindActiveByUserID.
+func (mr *MockAPITokenMockRecorder) FindActiveByUserID(userID, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveByUserID", reflect.TypeOf((*MockAPIToken)(nil).FindActiveByUserID), userID, now)
+}
+
+// FindByHash mocks base method.
+func (m *MockAPIToken) FindByHash(hash string) (model.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByHash", hash)
+	ret0, _ := ret[0].(model.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByHash indicates an expected call of FindByHash.
+func (mr *MockAPITokenMockRecorder) FindByHash(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByHash", reflect.TypeOf((*MockAPIToken)(nil).FindByHash), hash)
+}
+
+// Regist mocks base method.
+func (m *MockAPIToken) Regist(token model.APIToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Regist", token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Regist indicates an expected call of Regist.
+func (mr *MockAPITokenMockRecorder) Regist(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Regist", reflect.TypeOf((*MockAPIToken)(nil).Regist), token)
+}
+
+// Touch mocks base method.
+func (m *MockAPIToken) Touch(id string, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Touch", id, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Touch indicates an expected call of Touch.
+func (mr *MockAPITokenMockRecorder) Touch(id, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Touch", reflect.TypeOf((*MockAPIToken)(nil).Touch), id, now)
+}
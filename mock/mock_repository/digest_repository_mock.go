@@ -0,0 +1,168 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/digest_repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	model "GoBBS/domain/model"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockDigest is a mock of Digest interface.
+type MockDigest struct {
+	ctrl     *gomock.Controller
+	recorder *MockDigestMockRecorder
+}
+
+// MockDigestMockRecorder is the mock recorder for MockDigest.
+type MockDigestMockRecorder struct {
+	mock *MockDigest
+}
+
+// NewMockDigest creates a new mock instance.
+func NewMockDigest(ctrl *gomock.Controller) *MockDigest {
+	mock := &MockDigest{ctrl: ctrl}
+	mock.recorder = &MockDigestMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDigest) EXPECT() *MockDigestMockRecorder {
+	return m.recorder
+}
+
+// DeleteDelivery mocks base method.
+func (m *MockDigest) DeleteDelivery(userID, periodKey string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDelivery", userID, periodKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDelivery indicates an expected call of DeleteDelivery.
+func (mr *MockDigestMockRecorder) DeleteDelivery(userID, periodKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDelivery", reflect.TypeOf((*MockDigest)(nil).DeleteDelivery), userID, periodKey)
+}
+
+// DeleteWatch mocks base method.
+func (m *MockDigest) DeleteWatch(userID string, kind model.WatchKind, targetID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWatch", userID, kind, targetID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWatch indicates an expected call of DeleteWatch.
+func (mr *MockDigestMockRecorder) DeleteWatch(userID, kind, targetID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWatch", reflect.TypeOf((*MockDigest)(nil).DeleteWatch), userID, kind, targetID)
+}
+
+// FindDueUserIDs mocks base method.
+func (m *MockDigest) FindDueUserIDs(frequency model.DigestFrequency, periodKey string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDueUserIDs", frequency, periodKey)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDueUserIDs indicates an expected call of FindDueUserIDs.
+func (mr *MockDigestMockRecorder) FindDueUserIDs(frequency, periodKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDueUserIDs", reflect.TypeOf((*MockDigest)(nil).FindDueUserIDs), frequency, periodKey)
+}
+
+// FindSubscription mocks base method.
+func (m *MockDigest) FindSubscription(userID string) (model.DigestSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSubscription", userID)
+	ret0, _ := ret[0].(model.DigestSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSubscription indicates an expected call of FindSubscription.
+func (mr *MockDigestMockRecorder) FindSubscription(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSubscription", reflect.TypeOf((*MockDigest)(nil).FindSubscription), userID)
+}
+
+// FindSubscriptionByToken mocks base method.
+func (m *MockDigest) FindSubscriptionByToken(token string) (string, model.DigestSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSubscriptionByToken", token)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(model.DigestSubscription)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindSubscriptionByToken indicates an expected call of FindSubscriptionByToken.
+func (mr *MockDigestMockRecorder) FindSubscriptionByToken(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSubscriptionByToken", reflect.TypeOf((*MockDigest)(nil).FindSubscriptionByToken), token)
+}
+
+// FindWatchesByUserID mocks base method.
+func (m *MockDigest) FindWatchesByUserID(userID string) ([]model.Watch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindWatchesByUserID", userID)
+	ret0, _ := ret[0].([]model.Watch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindWatchesByUserID indicates an expected call of FindWatchesByUserID.
+func (mr *MockDigestMockRecorder) FindWatchesByUserID(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindWatchesByUserID", reflect.TypeOf((*MockDigest)(nil).FindWatchesByUserID), userID)
+}
+
+// RegistDelivery mocks base method.
+func (m *MockDigest) RegistDelivery(userID, periodKey string, now time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegistDelivery", userID, periodKey, now)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegistDelivery indicates an expected call of RegistDelivery.
+func (mr *MockDigestMockRecorder) RegistDelivery(userID, periodKey, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegistDelivery", reflect.TypeOf((*MockDigest)(nil).RegistDelivery), userID, periodKey, now)
+}
+
+// RegistWatch mocks base method.
+func (m *MockDigest) RegistWatch(watch model.Watch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegistWatch", watch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegistWatch indicates an expected call of RegistWatch.
+func (mr *MockDigestMockRecorder) RegistWatch(watch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegistWatch", reflect.TypeOf((*MockDigest)(nil).RegistWatch), watch)
+}
+
+// SaveSubscription mocks base method.
+func (m *MockDigest) SaveSubscription(userID string, subscription model.DigestSubscription, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSubscription", userID, subscription, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSubscription indicates an expected call of SaveSubscription.
+func (mr *MockDigestMockRecorder) SaveSubscription(userID, subscription, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSubscription", reflect.TypeOf((*MockDigest)(nil).SaveSubscription), userID, subscription, now)
+}
@@ -0,0 +1,192 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/mfa_repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	model "GoBBS/domain/model"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMFA is a mock of MFA interface.
+type MockMFA struct {
+	ctrl     *gomock.Controller
+	recorder *MockMFAMockRecorder
+}
+
+// MockMFAMockRecorder is the mock recorder for MockMFA.
+type MockMFAMockRecorder struct {
+	mock *MockMFA
+}
+
+// NewMockMFA creates a new mock instance.
+func NewMockMFA(ctrl *gomock.Controller) *MockMFA {
+	mock := &MockMFA{ctrl: ctrl}
+	mock.recorder = &MockMFAMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMFA) EXPECT() *MockMFAMockRecorder {
+	return m.recorder
+}
+
+// ConsumeChallengeAttempt mocks base method.
+func (m *MockMFA) ConsumeChallengeAttempt(id string, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeChallengeAttempt", id, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConsumeChallengeAttempt indicates an expected call of ConsumeChallengeAttempt.
+func (mr *MockMFAMockRecorder) ConsumeChallengeAttempt(id, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeChallengeAttempt", reflect.TypeOf((*MockMFA)(nil).ConsumeChallengeAttempt), id, now)
+}
+
+// Delete mocks base method.
+func (m *MockMFA) Delete(userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMFAMockRecorder) Delete(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMFA)(nil).Delete), userID)
+}
+
+// DeleteChallenge mocks base method.
+func (m *MockMFA) DeleteChallenge(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChallenge", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteChallenge indicates an expected call of DeleteChallenge.
+func (mr *MockMFAMockRecorder) DeleteChallenge(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChallenge", reflect.TypeOf((*MockMFA)(nil).DeleteChallenge), id)
+}
+
+// DeleteExpiredChallenges mocks base method.
+func (m *MockMFA) DeleteExpiredChallenges(userID string, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredChallenges", userID, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpiredChallenges indicates an expected call of DeleteExpiredChallenges.
+func (mr *MockMFAMockRecorder) DeleteExpiredChallenges(userID, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredChallenges", reflect.TypeOf((*MockMFA)(nil).DeleteExpiredChallenges), userID, now)
+}
+
+// FindByUserID mocks base method.
+func (m *MockMFA) FindByUserID(userID string) (model.MFA, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserID", userID)
+	ret0, _ := ret[0].(model.MFA)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserID indicates an expected call of FindByUserID.
+func (mr *MockMFAMockRecorder) FindByUserID(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserID", reflect.TypeOf((*MockMFA)(nil).FindByUserID), userID)
+}
+
+// FindChallenge mocks base method.
+func (m *MockMFA) FindChallenge(id string) (model.MFAChallenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindChallenge", id)
+	ret0, _ := ret[0].(model.MFAChallenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindChallenge indicates an expected call of FindChallenge.
+func (mr *MockMFAMockRecorder) FindChallenge(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindChallenge", reflect.TypeOf((*MockMFA)(nil).FindChallenge), id)
+}
+
+// RegistChallenge mocks base method.
+func (m *MockMFA) RegistChallenge(challenge model.MFAChallenge) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegistChallenge", challenge)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegistChallenge indicates an expected call of RegistChallenge.
+func (mr *MockMFAMockRecorder) RegistChallenge(challenge interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegistChallenge", reflect.TypeOf((*MockMFA)(nil).RegistChallenge), challenge)
+}
+
+// ReplaceRecoveryCodes mocks base method.
+func (m *MockMFA) ReplaceRecoveryCodes(userID string, hashes []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceRecoveryCodes", userID, hashes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceRecoveryCodes indicates an expected call of ReplaceRecoveryCodes.
+func (mr *MockMFAMockRecorder) ReplaceRecoveryCodes(userID, hashes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceRecoveryCodes", reflect.TypeOf((*MockMFA)(nil).ReplaceRecoveryCodes), userID, hashes)
+}
+
+// Save mocks base method.
+func (m *MockMFA) Save(mfa model.MFA) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", mfa)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockMFAMockRecorder) Save(mfa interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockMFA)(nil).Save), mfa)
+}
+
+// UpdateLastUsedStep mocks base method.
+func (m *MockMFA) UpdateLastUsedStep(userID string, step int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLastUsedStep", userID, step)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLastUsedStep indicates an expected call of UpdateLastUsedStep.
+func (mr *MockMFAMockRecorder) UpdateLastUsedStep(userID, step interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastUsedStep", reflect.TypeOf((*MockMFA)(nil).UpdateLastUsedStep), userID, step)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockMFA) UseRecoveryCode(userID, hash string, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", userID, hash, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockMFAMockRecorder) UseRecoveryCode(userID, hash, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockMFA)(nil).UseRecoveryCode), userID, hash, now)
+}
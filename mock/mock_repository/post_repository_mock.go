@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/post_repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	model "GoBBS/domain/model"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPost is a mock of Post interface.
+type MockPost struct {
+	ctrl     *gomock.Controller
+	recorder *MockPostMockRecorder
+}
+
+// MockPostMockRecorder is the mock recorder for MockPost.
+type MockPostMockRecorder struct {
+	mock *MockPost
+}
+
+// NewMockPost creates a new mock instance.
+func NewMockPost(ctrl *gomock.Controller) *MockPost {
+	mock := &MockPost{ctrl: ctrl}
+	mock.recorder = &MockPostMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPost) EXPECT() *MockPostMockRecorder {
+	return m.recorder
+}
+
+// CountCreated mocks base method.
+func (m *MockPost) CountCreated(threadIDs []string, from, to time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCreated", threadIDs, from, to)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCreated indicates an expected call of CountCreated.
+func (mr *MockPostMockRecorder) CountCreated(threadIDs, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCreated", reflect.TypeOf((*MockPost)(nil).CountCreated), threadIDs, from, to)
+}
+
+// FindByID mocks base method.
+func (m *MockPost) FindByID(id string) (model.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", id)
+	ret0, _ := ret[0].(model.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockPostMockRecorder) FindByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockPost)(nil).FindByID), id)
+}
+
+// FindByThreadID mocks base method.
+func (m *MockPost) FindByThreadID(threadID string, afterNumber, limit int) ([]model.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByThreadID", threadID, afterNumber, limit)
+	ret0, _ := ret[0].([]model.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByThreadID indicates an expected call of FindByThreadID.
+func (mr *MockPostMockRecorder) FindByThreadID(threadID, afterNumber, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByThreadID", reflect.TypeOf((*MockPost)(nil).FindByThreadID), threadID, afterNumber, limit)
+}
+
+// Hide mocks base method.
+func (m *MockPost) Hide(id, reason string, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hide", id, reason, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Hide indicates an expected call of Hide.
+func (mr *MockPostMockRecorder) Hide(id, reason, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hide", reflect.TypeOf((*MockPost)(nil).Hide), id, reason, now)
+}
+
+// Regist mocks base method.
+func (m *MockPost) Regist(post model.Post, now time.Time) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Regist", post, now)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Regist indicates an expected call of Regist.
+func (mr *MockPostMockRecorder) Regist(post, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Regist", reflect.TypeOf((*MockPost)(nil).Regist), post, now)
+}
+
+// Update mocks base method.
+func (m *MockPost) Update(post model.Post, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", post, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPostMockRecorder) Update(post, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPost)(nil).Update), post, now)
+}
@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/post_revision_repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	model "GoBBS/domain/model"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPostRevision is a mock of PostRevision interface.
+type MockPostRevision struct {
+	ctrl     *gomock.Controller
+	recorder *MockPostRevisionMockRecorder
+}
+
+// MockPostRevisionMockRecorder is the mock recorder for MockPostRevision.
+type MockPostRevisionMockRecorder struct {
+	mock *MockPostRevision
+}
+
+// NewMockPostRevision creates a new mock instance.
+func NewMockPostRevision(ctrl *gomock.Controller) *MockPostRevision {
+	mock := &MockPostRevision{ctrl: ctrl}
+	mock.recorder = &MockPostRevisionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPostRevision) EXPECT() *MockPostRevisionMockRecorder {
+	return m.recorder
+}
+
+// FindByID mocks base method.
+func (m *MockPostRevision) FindByID(id string) (model.PostRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", id)
+	ret0, _ := ret[0].(model.PostRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockPostRevisionMockRecorder) FindByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockPostRevision)(nil).FindByID), id)
+}
+
+// FindByPostID mocks base method.
+func (m *MockPostRevision) FindByPostID(postID string) ([]model.PostRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByPostID", postID)
+	ret0, _ := ret[0].([]model.PostRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByPostID indicates an expected call of FindByPostID.
+func (mr *MockPostRevisionMockRecorder) FindByPostID(postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByPostID", reflect.TypeOf((*MockPostRevision)(nil).FindByPostID), postID)
+}
+
+// Regist mocks base method.
+func (m *MockPostRevision) Regist(revision model.PostRevision, now time.Time) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Regist", revision, now)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Regist indicates an expected call of Regist.
+func (mr *MockPostRevisionMockRecorder) Regist(revision, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Regist", reflect.TypeOf((*MockPostRevision)(nil).Regist), revision, now)
+}
@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/thread_repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	model "GoBBS/domain/model"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockThread is a mock of Thread interface.
+type MockThread struct {
+	ctrl     *gomock.Controller
+	recorder *MockThreadMockRecorder
+}
+
+// MockThreadMockRecorder is the mock recorder for MockThread.
+type MockThreadMockRecorder struct {
+	mock *MockThread
+}
+
+// NewMockThread creates a new mock instance.
+func NewMockThread(ctrl *gomock.Controller) *MockThread {
+	mock := &MockThread{ctrl: ctrl}
+	mock.recorder = &MockThreadMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockThread) EXPECT() *MockThreadMockRecorder {
+	return m.recorder
+}
+
+// AddPost mocks base method.
+func (m *MockThread) AddPost(id string, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPost", id, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPost indicates an expected call of AddPost.
+func (mr *MockThreadMockRecorder) AddPost(id, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPost", reflect.TypeOf((*MockThread)(nil).AddPost), id, now)
+}
+
+// FindByBoardID mocks base method.
+func (m *MockThread) FindByBoardID(boardID, beforeID string, limit int) ([]model.Thread, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByBoardID", boardID, beforeID, limit)
+	ret0, _ := ret[0].([]model.Thread)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByBoardID indicates an expected call of FindByBoardID.
+func (mr *MockThreadMockRecorder) FindByBoardID(boardID, beforeID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByBoardID", reflect.TypeOf((*MockThread)(nil).FindByBoardID), boardID, beforeID, limit)
+}
+
+// FindByID mocks base method.
+func (m *MockThread) FindByID(id string) (model.Thread, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", id)
+	ret0, _ := ret[0].(model.Thread)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockThreadMockRecorder) FindByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockThread)(nil).FindByID), id)
+}
+
+// FindPostedSince mocks base method.
+func (m *MockThread) FindPostedSince(boardID string, since time.Time) ([]model.Thread, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPostedSince", boardID, since)
+	ret0, _ := ret[0].([]model.Thread)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPostedSince indicates an expected call of FindPostedSince.
+func (mr *MockThreadMockRecorder) FindPostedSince(boardID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPostedSince", reflect.TypeOf((*MockThread)(nil).FindPostedSince), boardID, since)
+}
+
+// Regist mocks base method.
+func (m *MockThread) Regist(thread model.Thread, now time.Time) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Regist", thread, now)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Regist indicates an expected call of Regist.
+func (mr *MockThreadMockRecorder) Regist(thread, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Regist", reflect.TypeOf((*MockThread)(nil).Regist), thread, now)
+}
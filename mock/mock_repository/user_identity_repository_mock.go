@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/user_identity_repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	model "GoBBS/domain/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserIdentity is a mock of UserIdentity interface.
+type MockUserIdentity struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserIdentityMockRecorder
+}
+
+// MockUserIdentityMockRecorder is the mock recorder for MockUserIdentity.
+type MockUserIdentityMockRecorder struct {
+	mock *MockUserIdentity
+}
+
+// NewMockUserIdentity creates a new mock instance.
+func NewMockUserIdentity(ctrl *gomock.Controller) *MockUserIdentity {
+	mock := &MockUserIdentity{ctrl: ctrl}
+	mock.recorder = &MockUserIdentityMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserIdentity) EXPECT() *MockUserIdentityMockRecorder {
+	return m.recorder
+}
+
+// ExistsByUserID mocks base method.
+func (m *MockUserIdentity) ExistsByUserID(userID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistsByUserID", userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistsByUserID indicates an expected call of ExistsByUserID.
+func (mr *MockUserIdentityMockRecorder) ExistsByUserID(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsByUserID", reflect.TypeOf((*MockUserIdentity)(nil).ExistsByUserID), userID)
+}
+
+// FindByIssuerSubject mocks base method.
+func (m *MockUserIdentity) FindByIssuerSubject(issuer, subject string) (model.UserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIssuerSubject", issuer, subject)
+	ret0, _ := ret[0].(model.UserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIssuerSubject indicates an expected call of FindByIssuerSubject.
+func (mr *MockUserIdentityMockRecorder) FindByIssuerSubject(issuer, subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIssuerSubject", reflect.TypeOf((*MockUserIdentity)(nil).FindByIssuerSubject), issuer, subject)
+}
+
+// Regist mocks base method.
+func (m *MockUserIdentity) Regist(identity model.UserIdentity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Regist", identity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Regist indicates an expected call of Regist.
+func (mr *MockUserIdentityMockRecorder) Regist(identity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Regist", reflect.TypeOf((*MockUserIdentity)(nil).Regist), identity)
+}
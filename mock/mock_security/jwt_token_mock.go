@@ -35,26 +35,42 @@ func (m *MockToken) EXPECT() *MockTokenMockRecorder {
 }
 
 // Generate mocks base method.
-func (m *MockToken) Generate(arg0 string, arg1 time.Time) (string, error) {
+func (m *MockToken) Generate(userID, tokenID string, now time.Time) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Generate", arg0, arg1)
+	ret := m.ctrl.Call(m, "Generate", userID, tokenID, now)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Generate indicates an expected call of Generate.
-func (mr *MockTokenMockRecorder) Generate(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockTokenMockRecorder) Generate(userID, tokenID, now interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockToken)(nil).Generate), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockToken)(nil).Generate), userID, tokenID, now)
+}
+
+// TTL mocks base method.
+func (m *MockToken) TTL() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TTL")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// TTL indicates an expected call of TTL.
+func (mr *MockTokenMockRecorder) TTL() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TTL", reflect.TypeOf((*MockToken)(nil).TTL))
 }
 
 // Verify mocks base method.
-func (m *MockToken) Verify(arg0 string) bool {
+func (m *MockToken) Verify(arg0 string) (string, string, bool) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Verify", arg0)
-	ret0, _ := ret[0].(bool)
-	return ret0
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(bool)
+	return ret0, ret1, ret2
 }
 
 // Verify indicates an expected call of Verify.
@@ -0,0 +1,135 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/service/api_token_service.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	model "GoBBS/domain/model"
+	repository "GoBBS/domain/repository"
+	service "GoBBS/domain/service"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAPIToken is a mock of APIToken interface.
+type MockAPIToken struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPITokenMockRecorder
+}
+
+// MockAPITokenMockRecorder is the mock recorder for MockAPIToken.
+type MockAPITokenMockRecorder struct {
+	mock *MockAPIToken
+}
+
+// NewMockAPIToken creates a new mock instance.
+func NewMockAPIToken(ctrl *gomock.Controller) *MockAPIToken {
+	mock := &MockAPIToken{ctrl: ctrl}
+	mock.recorder = &MockAPITokenMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIToken) EXPECT() *MockAPITokenMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAPIToken) Create(userID, name string, scopes []string, expiresAt, now time.Time) (string, model.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", userID, name, scopes, expiresAt, now)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(model.APIToken)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAPITokenMockRecorder) Create(userID, name, scopes, expiresAt, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAPIToken)(nil).Create), userID, name, scopes, expiresAt, now)
+}
+
+// List mocks base method.
+func (m *MockAPIToken) List(userID string, now time.Time) ([]model.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", userID, now)
+	ret0, _ := ret[0].([]model.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockAPITokenMockRecorder) List(userID, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAPIToken)(nil).List), userID, now)
+}
+
+// Revoke mocks base method.
+func (m *MockAPIToken) Revoke(userID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockAPITokenMockRecorder) Revoke(userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockAPIToken)(nil).Revoke), userID, id)
+}
+
+// Verify mocks base method.
+func (m *MockAPIToken) Verify(token string, now time.Time) (model.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", token, now)
+	ret0, _ := ret[0].(model.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockAPITokenMockRecorder) Verify(token, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockAPIToken)(nil).Verify), token, now)
+}
+
+// MockAPITokenFactory is a mock of APITokenFactory interface.
+type MockAPITokenFactory struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPITokenFactoryMockRecorder
+}
+
+// MockAPITokenFactoryMockRecorder is the mock recorder for MockAPITokenFactory.
+type MockAPITokenFactoryMockRecorder struct {
+	mock *MockAPITokenFactory
+}
+
+// NewMockAPITokenFactory creates a new mock instance.
+func NewMockAPITokenFactory(ctrl *gomock.Controller) *MockAPITokenFactory {
+	mock := &MockAPITokenFactory{ctrl: ctrl}
+	mock.recorder = &MockAPITokenFactoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPITokenFactory) EXPECT() *MockAPITokenFactoryMockRecorder {
+	return m.recorder
+}
+
+// NewAPITokenService mocks base method.
+func (m *MockAPITokenFactory) NewAPITokenService(repo repository.APIToken) service.APIToken {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewAPITokenService", repo)
+	ret0, _ := ret[0].(service.APIToken)
+	return ret0
+}
+
+// NewAPITokenService indicates an expected call of NewAPITokenService.
+func (mr *MockAPITokenFactoryMockRecorder) NewAPITokenService(repo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewAPITokenService", reflect.TypeOf((*MockAPITokenFactory)(nil).NewAPITokenService), repo)
+}
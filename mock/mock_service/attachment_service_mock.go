@@ -0,0 +1,105 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/service/attachment_service.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	model "GoBBS/domain/model"
+	repository "GoBBS/domain/repository"
+	service "GoBBS/domain/service"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAttachment is a mock of Attachment interface.
+type MockAttachment struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentMockRecorder
+}
+
+// MockAttachmentMockRecorder is the mock recorder for MockAttachment.
+type MockAttachmentMockRecorder struct {
+	mock *MockAttachment
+}
+
+// NewMockAttachment creates a new mock instance.
+func NewMockAttachment(ctrl *gomock.Controller) *MockAttachment {
+	mock := &MockAttachment{ctrl: ctrl}
+	mock.recorder = &MockAttachmentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachment) EXPECT() *MockAttachmentMockRecorder {
+	return m.recorder
+}
+
+// FindByID mocks base method.
+func (m *MockAttachment) FindByID(id string) (model.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", id)
+	ret0, _ := ret[0].(model.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockAttachmentMockRecorder) FindByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockAttachment)(nil).FindByID), id)
+}
+
+// Regist mocks base method.
+func (m *MockAttachment) Regist(attachment model.Attachment, now time.Time) (model.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Regist", attachment, now)
+	ret0, _ := ret[0].(model.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Regist indicates an expected call of Regist.
+func (mr *MockAttachmentMockRecorder) Regist(attachment, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Regist", reflect.TypeOf((*MockAttachment)(nil).Regist), attachment, now)
+}
+
+// MockAttachmentFactory is a mock of AttachmentFactory interface.
+type MockAttachmentFactory struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentFactoryMockRecorder
+}
+
+// MockAttachmentFactoryMockRecorder is the mock recorder for MockAttachmentFactory.
+type MockAttachmentFactoryMockRecorder struct {
+	mock *MockAttachmentFactory
+}
+
+// NewMockAttachmentFactory creates a new mock instance.
+func NewMockAttachmentFactory(ctrl *gomock.Controller) *MockAttachmentFactory {
+	mock := &MockAttachmentFactory{ctrl: ctrl}
+	mock.recorder = &MockAttachmentFactoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentFactory) EXPECT() *MockAttachmentFactoryMockRecorder {
+	return m.recorder
+}
+
+// NewAttachmentService mocks base method.
+func (m *MockAttachmentFactory) NewAttachmentService(repo repository.Attachment) service.Attachment {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewAttachmentService", repo)
+	ret0, _ := ret[0].(service.Attachment)
+	return ret0
+}
+
+// NewAttachmentService indicates an expected call of NewAttachmentService.
+func (mr *MockAttachmentFactoryMockRecorder) NewAttachmentService(repo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewAttachmentService", reflect.TypeOf((*MockAttachmentFactory)(nil).NewAttachmentService), repo)
+}
@@ -0,0 +1,120 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/service/board_service.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	model "GoBBS/domain/model"
+	repository "GoBBS/domain/repository"
+	service "GoBBS/domain/service"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBoard is a mock of Board interface.
+type MockBoard struct {
+	ctrl     *gomock.Controller
+	recorder *MockBoardMockRecorder
+}
+
+// MockBoardMockRecorder is the mock recorder for MockBoard.
+type MockBoardMockRecorder struct {
+	mock *MockBoard
+}
+
+// NewMockBoard creates a new mock instance.
+func NewMockBoard(ctrl *gomock.Controller) *MockBoard {
+	mock := &MockBoard{ctrl: ctrl}
+	mock.recorder = &MockBoardMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBoard) EXPECT() *MockBoardMockRecorder {
+	return m.recorder
+}
+
+// Find mocks base method.
+func (m *MockBoard) Find(id string) (model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", id)
+	ret0, _ := ret[0].(model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockBoardMockRecorder) Find(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockBoard)(nil).Find), id)
+}
+
+// List mocks base method.
+func (m *MockBoard) List() ([]model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockBoardMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockBoard)(nil).List))
+}
+
+// Regist mocks base method.
+func (m *MockBoard) Regist(name, description string, now time.Time) (model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Regist", name, description, now)
+	ret0, _ := ret[0].(model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Regist indicates an expected call of Regist.
+func (mr *MockBoardMockRecorder) Regist(name, description, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Regist", reflect.TypeOf((*MockBoard)(nil).Regist), name, description, now)
+}
+
+// MockBoardFactory is a mock of BoardFactory interface.
+type MockBoardFactory struct {
+	ctrl     *gomock.Controller
+	recorder *MockBoardFactoryMockRecorder
+}
+
+// MockBoardFactoryMockRecorder is the mock recorder for MockBoardFactory.
+type MockBoardFactoryMockRecorder struct {
+	mock *MockBoardFactory
+}
+
+// NewMockBoardFactory creates a new mock instance.
+func NewMockBoardFactory(ctrl *gomock.Controller) *MockBoardFactory {
+	mock := &MockBoardFactory{ctrl: ctrl}
+	mock.recorder = &MockBoardFactoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBoardFactory) EXPECT() *MockBoardFactoryMockRecorder {
+	return m.recorder
+}
+
+// NewBoardService mocks base method.
+func (m *MockBoardFactory) NewBoardService(repo repository.Board) service.Board {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewBoardService", repo)
+	ret0, _ := ret[0].(service.Board)
+	return ret0
+}
+
+// NewBoardService indicates an expected call of NewBoardService.
+func (mr *MockBoardFactoryMockRecorder) NewBoardService(repo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewBoardService", reflect.TypeOf((*MockBoardFactory)(nil).NewBoardService), repo)
+}
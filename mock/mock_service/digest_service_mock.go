@@ -0,0 +1,206 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/service/digest_service.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	model "GoBBS/domain/model"
+	repository "GoBBS/domain/repository"
+	service "GoBBS/domain/service"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockDigest is a mock of Digest interface.
+type MockDigest struct {
+	ctrl     *gomock.Controller
+	recorder *MockDigestMockRecorder
+}
+
+// MockDigestMockRecorder is the mock recorder for MockDigest.
+type MockDigestMockRecorder struct {
+	mock *MockDigest
+}
+
+// NewMockDigest creates a new mock instance.
+func NewMockDigest(ctrl *gomock.Controller) *MockDigest {
+	mock := &MockDigest{ctrl: ctrl}
+	mock.recorder = &MockDigestMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDigest) EXPECT() *MockDigestMockRecorder {
+	return m.recorder
+}
+
+// ClaimDelivery mocks base method.
+func (m *MockDigest) ClaimDelivery(userID string, period model.DigestPeriod, now time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDelivery", userID, period, now)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDelivery indicates an expected call of ClaimDelivery.
+func (mr *MockDigestMockRecorder) ClaimDelivery(userID, period, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDelivery", reflect.TypeOf((*MockDigest)(nil).ClaimDelivery), userID, period, now)
+}
+
+// DueUserIDs mocks base method.
+func (m *MockDigest) DueUserIDs(frequency model.DigestFrequency, period model.DigestPeriod) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DueUserIDs", frequency, period)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DueUserIDs indicates an expected call of DueUserIDs.
+func (mr *MockDigestMockRecorder) DueUserIDs(frequency, period interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DueUserIDs", reflect.TypeOf((*MockDigest)(nil).DueUserIDs), frequency, period)
+}
+
+// ReleaseDelivery mocks base method.
+func (m *MockDigest) ReleaseDelivery(userID string, period model.DigestPeriod) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseDelivery", userID, period)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseDelivery indicates an expected call of ReleaseDelivery.
+func (mr *MockDigestMockRecorder) ReleaseDelivery(userID, period interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseDelivery", reflect.TypeOf((*MockDigest)(nil).ReleaseDelivery), userID, period)
+}
+
+// Subscription mocks base method.
+func (m *MockDigest) Subscription(userID string) (model.DigestSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscription", userID)
+	ret0, _ := ret[0].(model.DigestSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscription indicates an expected call of Subscription.
+func (mr *MockDigestMockRecorder) Subscription(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscription", reflect.TypeOf((*MockDigest)(nil).Subscription), userID)
+}
+
+// Unsubscribe mocks base method.
+func (m *MockDigest) Unsubscribe(token string, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unsubscribe", token, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockDigestMockRecorder) Unsubscribe(token, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockDigest)(nil).Unsubscribe), token, now)
+}
+
+// Unwatch mocks base method.
+func (m *MockDigest) Unwatch(userID string, kind model.WatchKind, targetID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unwatch", userID, kind, targetID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unwatch indicates an expected call of Unwatch.
+func (mr *MockDigestMockRecorder) Unwatch(userID, kind, targetID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unwatch", reflect.TypeOf((*MockDigest)(nil).Unwatch), userID, kind, targetID)
+}
+
+// UpdateSubscription mocks base method.
+func (m *MockDigest) UpdateSubscription(userID string, frequency model.DigestFrequency, locale string, now time.Time) (model.DigestSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubscription", userID, frequency, locale, now)
+	ret0, _ := ret[0].(model.DigestSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSubscription indicates an expected call of UpdateSubscription.
+func (mr *MockDigestMockRecorder) UpdateSubscription(userID, frequency, locale, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscription", reflect.TypeOf((*MockDigest)(nil).UpdateSubscription), userID, frequency, locale, now)
+}
+
+// Watch mocks base method.
+func (m *MockDigest) Watch(userID string, kind model.WatchKind, targetID string, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Watch", userID, kind, targetID, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Watch indicates an expected call of Watch.
+func (mr *MockDigestMockRecorder) Watch(userID, kind, targetID, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockDigest)(nil).Watch), userID, kind, targetID, now)
+}
+
+// Watches mocks base method.
+func (m *MockDigest) Watches(userID string) ([]model.Watch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Watches", userID)
+	ret0, _ := ret[0].([]model.Watch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Watches indicates an expected call of Watches.
+func (mr *MockDigestMockRecorder) Watches(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watches", reflect.TypeOf((*MockDigest)(nil).Watches), userID)
+}
+
+// MockDigestFactory is a mock of DigestFactory interface.
+type MockDigestFactory struct {
+	ctrl     *gomock.Controller
+	recorder *MockDigestFactoryMockRecorder
+}
+
+// MockDigestFactoryMockRecorder is the mock recorder for MockDigestFactory.
+type MockDigestFactoryMockRecorder struct {
+	mock *MockDigestFactory
+}
+
+// NewMockDigestFactory creates a new mock instance.
+func NewMockDigestFactory(ctrl *gomock.Controller) *MockDigestFactory {
+	mock := &MockDigestFactory{ctrl: ctrl}
+	mock.recorder = &MockDigestFactoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDigestFactory) EXPECT() *MockDigestFactoryMockRecorder {
+	return m.recorder
+}
+
+// NewDigestService mocks base method.
+func (m *MockDigestFactory) NewDigestService(repo repository.Digest) service.Digest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewDigestService", repo)
+	ret0, _ := ret[0].(service.Digest)
+	return ret0
+}
+
+// NewDigestService indicates an expected call of NewDigestService.
+func (mr *MockDigestFactoryMockRecorder) NewDigestService(repo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewDigestService", reflect.TypeOf((*MockDigestFactory)(nil).NewDigestService), repo)
+}
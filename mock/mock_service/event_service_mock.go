@@ -0,0 +1,119 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/service/event_service.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	model "GoBBS/domain/model"
+	repository "GoBBS/domain/repository"
+	service "GoBBS/domain/service"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockEvent is a mock of Event interface.
+type MockEvent struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventMockRecorder
+}
+
+// MockEventMockRecorder is the mock recorder for MockEvent.
+type MockEventMockRecorder struct {
+	mock *MockEvent
+}
+
+// NewMockEvent creates a new mock instance.
+func NewMockEvent(ctrl *gomock.Controller) *MockEvent {
+	mock := &MockEvent{ctrl: ctrl}
+	mock.recorder = &MockEventMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEvent) EXPECT() *MockEventMockRecorder {
+	return m.recorder
+}
+
+// ClaimDue mocks base method.
+func (m *MockEvent) ClaimDue(now time.Time, limit int) ([]model.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDue", now, limit)
+	ret0, _ := ret[0].([]model.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDue indicates an expected call of ClaimDue.
+func (mr *MockEventMockRecorder) ClaimDue(now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDue", reflect.TypeOf((*MockEvent)(nil).ClaimDue), now, limit)
+}
+
+// Emit mocks base method.
+func (m *MockEvent) Emit(eventType model.EventType, aggregateID string, data any, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Emit", eventType, aggregateID, data, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Emit indicates an expected call of Emit.
+func (mr *MockEventMockRecorder) Emit(eventType, aggregateID, data, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Emit", reflect.TypeOf((*MockEvent)(nil).Emit), eventType, aggregateID, data, now)
+}
+
+// RecordResult mocks base method.
+func (m *MockEvent) RecordResult(event model.Event, handleErr error, now time.Time) (model.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordResult", event, handleErr, now)
+	ret0, _ := ret[0].(model.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordResult indicates an expected call of RecordResult.
+func (mr *MockEventMockRecorder) RecordResult(event, handleErr, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordResult", reflect.TypeOf((*MockEvent)(nil).RecordResult), event, handleErr, now)
+}
+
+// MockEventFactory is a mock of EventFactory interface.
+type MockEventFactory struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventFactoryMockRecorder
+}
+
+// MockEventFactoryMockRecorder is the mock recorder for MockEventFactory.
+type MockEventFactoryMockRecorder struct {
+	mock *MockEventFactory
+}
+
+// NewMockEventFactory creates a new mock instance.
+func NewMockEventFactory(ctrl *gomock.Controller) *MockEventFactory {
+	mock := &MockEventFactory{ctrl: ctrl}
+	mock.recorder = &MockEventFactoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventFactory) EXPECT() *MockEventFactoryMockRecorder {
+	return m.recorder
+}
+
+// NewEventService mocks base method.
+func (m *MockEventFactory) NewEventService(repo repository.Event) service.Event {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewEventService", repo)
+	ret0, _ := ret[0].(service.Event)
+	return ret0
+}
+
+// NewEventService indicates an expected call of NewEventService.
+func (mr *MockEventFactoryMockRecorder) NewEventService(repo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewEventService", reflect.TypeOf((*MockEventFactory)(nil).NewEventService), repo)
+}
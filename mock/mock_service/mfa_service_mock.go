@@ -0,0 +1,166 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/service/mfa_service.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	model "GoBBS/domain/model"
+	repository "GoBBS/domain/repository"
+	service "GoBBS/domain/service"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMFA is a mock of MFA interface.
+type MockMFA struct {
+	ctrl     *gomock.Controller
+	recorder *MockMFAMockRecorder
+}
+
+// MockMFAMockRecorder is the mock recorder for MockMFA.
+type MockMFAMockRecorder struct {
+	mock *MockMFA
+}
+
+// NewMockMFA creates a new mock instance.
+func NewMockMFA(ctrl *gomock.Controller) *MockMFA {
+	mock := &MockMFA{ctrl: ctrl}
+	mock.recorder = &MockMFAMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMFA) EXPECT() *MockMFAMockRecorder {
+	return m.recorder
+}
+
+// Confirm mocks base method.
+func (m *MockMFA) Confirm(userID, code string, now time.Time) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Confirm", userID, code, now)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Confirm indicates an expected call of Confirm.
+func (mr *MockMFAMockRecorder) Confirm(userID, code, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Confirm", reflect.TypeOf((*MockMFA)(nil).Confirm), userID, code, now)
+}
+
+// Disable mocks base method.
+func (m *MockMFA) Disable(userID, code string, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Disable", userID, code, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Disable indicates an expected call of Disable.
+func (mr *MockMFAMockRecorder) Disable(userID, code, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disable", reflect.TypeOf((*MockMFA)(nil).Disable), userID, code, now)
+}
+
+// Enroll mocks base method.
+func (m *MockMFA) Enroll(userID string, now time.Time) (model.MFA, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enroll", userID, now)
+	ret0, _ := ret[0].(model.MFA)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enroll indicates an expected call of Enroll.
+func (mr *MockMFAMockRecorder) Enroll(userID, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enroll", reflect.TypeOf((*MockMFA)(nil).Enroll), userID, now)
+}
+
+// IsEnabled mocks base method.
+func (m *MockMFA) IsEnabled(userID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsEnabled", userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsEnabled indicates an expected call of IsEnabled.
+func (mr *MockMFAMockRecorder) IsEnabled(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsEnabled", reflect.TypeOf((*MockMFA)(nil).IsEnabled), userID)
+}
+
+// IssueChallenge mocks base method.
+func (m *MockMFA) IssueChallenge(userID string, now time.Time) (string, model.MFAChallenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueChallenge", userID, now)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(model.MFAChallenge)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// IssueChallenge indicates an expected call of IssueChallenge.
+func (mr *MockMFAMockRecorder) IssueChallenge(userID, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueChallenge", reflect.TypeOf((*MockMFA)(nil).IssueChallenge), userID, now)
+}
+
+// VerifyChallenge mocks base method.
+func (m *MockMFA) VerifyChallenge(token, code string, now time.Time) (string, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyChallenge", token, code, now)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// VerifyChallenge indicates an expected call of VerifyChallenge.
+func (mr *MockMFAMockRecorder) VerifyChallenge(token, code, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyChallenge", reflect.TypeOf((*MockMFA)(nil).VerifyChallenge), token, code, now)
+}
+
+// MockMFAFactory is a mock of MFAFactory interface.
+type MockMFAFactory struct {
+	ctrl     *gomock.Controller
+	recorder *MockMFAFactoryMockRecorder
+}
+
+// MockMFAFactoryMockRecorder is the mock recorder for MockMFAFactory.
+type MockMFAFactoryMockRecorder struct {
+	mock *MockMFAFactory
+}
+
+// NewMockMFAFactory creates a new mock instance.
+func NewMockMFAFactory(ctrl *gomock.Controller) *MockMFAFactory {
+	mock := &MockMFAFactory{ctrl: ctrl}
+	mock.recorder = &MockMFAFactoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMFAFactory) EXPECT() *MockMFAFactoryMockRecorder {
+	return m.recorder
+}
+
+// NewMFAService mocks base method.
+func (m *MockMFAFactory) NewMFAService(repo repository.MFA) service.MFA {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewMFAService", repo)
+	ret0, _ := ret[0].(service.MFA)
+	return ret0
+}
+
+// NewMFAService indicates an expected call of NewMFAService.
+func (mr *MockMFAFactoryMockRecorder) NewMFAService(repo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewMFAService", reflect.TypeOf((*MockMFAFactory)(nil).NewMFAService), repo)
+}
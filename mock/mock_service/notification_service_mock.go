@@ -0,0 +1,163 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/service/notification_service.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	model "GoBBS/domain/model"
+	repository "GoBBS/domain/repository"
+	service "GoBBS/domain/service"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockNotification is a mock of Notification interface.
+type MockNotification struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationMockRecorder
+}
+
+// MockNotificationMockRecorder is the mock recorder for MockNotification.
+type MockNotificationMockRecorder struct {
+	mock *MockNotification
+}
+
+// NewMockNotification creates a new mock instance.
+func NewMockNotification(ctrl *gomock.Controller) *MockNotification {
+	mock := &MockNotification{ctrl: ctrl}
+	mock.recorder = &MockNotificationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotification) EXPECT() *MockNotificationMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockNotification) List(userID, beforeID string, limit int) ([]model.Notification, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", userID, beforeID, limit)
+	ret0, _ := ret[0].([]model.Notification)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockNotificationMockRecorder) List(userID, beforeID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockNotification)(nil).List), userID, beforeID, limit)
+}
+
+// MarkAllRead mocks base method.
+func (m *MockNotification) MarkAllRead(userID string, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllRead", userID, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAllRead indicates an expected call of MarkAllRead.
+func (mr *MockNotificationMockRecorder) MarkAllRead(userID, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllRead", reflect.TypeOf((*MockNotification)(nil).MarkAllRead), userID, now)
+}
+
+// MarkRead mocks base method.
+func (m *MockNotification) MarkRead(userID, id string, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", userID, id, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockNotificationMockRecorder) MarkRead(userID, id, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockNotification)(nil).MarkRead), userID, id, now)
+}
+
+// Notify mocks base method.
+func (m *MockNotification) Notify(notification model.Notification, now time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", notification, now)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockNotificationMockRecorder) Notify(notification, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockNotification)(nil).Notify), notification, now)
+}
+
+// Preferences mocks base method.
+func (m *MockNotification) Preferences(userID string) (model.NotificationPreferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Preferences", userID)
+	ret0, _ := ret[0].(model.NotificationPreferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Preferences indicates an expected call of Preferences.
+func (mr *MockNotificationMockRecorder) Preferences(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preferences", reflect.TypeOf((*MockNotification)(nil).Preferences), userID)
+}
+
+// UpdatePreferences mocks base method.
+func (m *MockNotification) UpdatePreferences(userID string, preferences model.NotificationPreferences, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePreferences", userID, preferences, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePreferences indicates an expected call of UpdatePreferences.
+func (mr *MockNotificationMockRecorder) UpdatePreferences(userID, preferences, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePreferences", reflect.TypeOf((*MockNotification)(nil).UpdatePreferences), userID, preferences, now)
+}
+
+// MockNotificationFactory is a mock of NotificationFactory interface.
+type MockNotificationFactory struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationFactoryMockRecorder
+}
+
+// MockNotificationFactoryMockRecorder is the mock recorder for MockNotificationFactory.
+type MockNotificationFactoryMockRecorder struct {
+	mock *MockNotificationFactory
+}
+
+// NewMockNotificationFactory creates a new mock instance.
+func NewMockNotificationFactory(ctrl *gomock.Controller) *MockNotificationFactory {
+	mock := &MockNotificationFactory{ctrl: ctrl}
+	mock.recorder = &MockNotificationFactoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationFactory) EXPECT() *MockNotificationFactoryMockRecorder {
+	return m.recorder
+}
+
+// NewNotificationService mocks base method.
+func (m *MockNotificationFactory) NewNotificationService(repo repository.Notification) service.Notification {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewNotificationService", repo)
+	ret0, _ := ret[0].(service.Notification)
+	return ret0
+}
+
+// NewNotificationService indicates an expected call of NewNotificationService.
+func (mr *MockNotificationFactoryMockRecorder) NewNotificationService(repo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewNotificationService", reflect.TypeOf((*MockNotificationFactory)(nil).NewNotificationService), repo)
+}
@@ -0,0 +1,279 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/service/post_service.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	model "GoBBS/domain/model"
+	repository "GoBBS/domain/repository"
+	service "GoBBS/domain/service"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPost is a mock of Post interface.
+type MockPost struct {
+	ctrl     *gomock.Controller
+	recorder *MockPostMockRecorder
+}
+
+// MockPostMockRecorder is the mock recorder for MockPost.
+type MockPostMockRecorder struct {
+	mock *MockPost
+}
+
+// NewMockPost creates a new mock instance.
+func NewMockPost(ctrl *gomock.Controller) *MockPost {
+	mock := &MockPost{ctrl: ctrl}
+	mock.recorder = &MockPostMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPost) EXPECT() *MockPostMockRecorder {
+	return m.recorder
+}
+
+// CreateThread mocks base method.
+func (m *MockPost) CreateThread(boardID, userID, title, body string, now time.Time) (model.Thread, model.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateThread", boardID, userID, title, body, now)
+	ret0, _ := ret[0].(model.Thread)
+	ret1, _ := ret[1].(model.Post)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateThread indicates an expected call of CreateThread.
+func (mr *MockPostMockRecorder) CreateThread(boardID, userID, title, body, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateThread", reflect.TypeOf((*MockPost)(nil).CreateThread), boardID, userID, title, body, now)
+}
+
+// Edit mocks base method.
+func (m *MockPost) Edit(id, editorID string, moderator bool, body string, editWindow time.Duration, now time.Time) (model.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Edit", id, editorID, moderator, body, editWindow, now)
+	ret0, _ := ret[0].(model.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Edit indicates an expected call of Edit.
+func (mr *MockPostMockRecorder) Edit(id, editorID, moderator, body, editWindow, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Edit", reflect.TypeOf((*MockPost)(nil).Edit), id, editorID, moderator, body, editWindow, now)
+}
+
+// Hide mocks base method.
+func (m *MockPost) Hide(id, reason string, now time.Time) (model.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hide", id, reason, now)
+	ret0, _ := ret[0].(model.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Hide indicates an expected call of Hide.
+func (mr *MockPostMockRecorder) Hide(id, reason, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hide", reflect.TypeOf((*MockPost)(nil).Hide), id, reason, now)
+}
+
+// Post mocks base method.
+func (m *MockPost) Post(id string) (model.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Post", id)
+	ret0, _ := ret[0].(model.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Post indicates an expected call of Post.
+func (mr *MockPostMockRecorder) Post(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Post", reflect.TypeOf((*MockPost)(nil).Post), id)
+}
+
+// PostByNumber mocks base method.
+func (m *MockPost) PostByNumber(threadID string, number int) (model.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostByNumber", threadID, number)
+	ret0, _ := ret[0].(model.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostByNumber indicates an expected call of PostByNumber.
+func (mr *MockPostMockRecorder) PostByNumber(threadID, number interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostByNumber", reflect.TypeOf((*MockPost)(nil).PostByNumber), threadID, number)
+}
+
+// Posts mocks base method.
+func (m *MockPost) Posts(threadID string, afterNumber, limit int) ([]model.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Posts", threadID, afterNumber, limit)
+	ret0, _ := ret[0].([]model.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Posts indicates an expected call of Posts.
+func (mr *MockPostMockRecorder) Posts(threadID, afterNumber, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Posts", reflect.TypeOf((*MockPost)(nil).Posts), threadID, afterNumber, limit)
+}
+
+// Reply mocks base method.
+func (m *MockPost) Reply(threadID, userID, body string, now time.Time) (model.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reply", threadID, userID, body, now)
+	ret0, _ := ret[0].(model.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reply indicates an expected call of Reply.
+func (mr *MockPostMockRecorder) Reply(threadID, userID, body, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reply", reflect.TypeOf((*MockPost)(nil).Reply), threadID, userID, body, now)
+}
+
+// Revision mocks base method.
+func (m *MockPost) Revision(postID, revisionID string) (model.PostRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revision", postID, revisionID)
+	ret0, _ := ret[0].(model.PostRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Revision indicates an expected call of Revision.
+func (mr *MockPostMockRecorder) Revision(postID, revisionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revision", reflect.TypeOf((*MockPost)(nil).Revision), postID, revisionID)
+}
+
+// Revisions mocks base method.
+func (m *MockPost) Revisions(postID string) ([]model.PostRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revisions", postID)
+	ret0, _ := ret[0].([]model.PostRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Revisions indicates an expected call of Revisions.
+func (mr *MockPostMockRecorder) Revisions(postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revisions", reflect.TypeOf((*MockPost)(nil).Revisions), postID)
+}
+
+// Thread mocks base method.
+func (m *MockPost) Thread(id string) (model.Thread, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Thread", id)
+	ret0, _ := ret[0].(model.Thread)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Thread indicates an expected call of Thread.
+func (mr *MockPostMockRecorder) Thread(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Thread", reflect.TypeOf((*MockPost)(nil).Thread), id)
+}
+
+// Threads mocks base method.
+func (m *MockPost) Threads(boardID, beforeID string, limit int) ([]model.Thread, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Threads", boardID, beforeID, limit)
+	ret0, _ := ret[0].([]model.Thread)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Threads indicates an expected call of Threads.
+func (mr *MockPostMockRecorder) Threads(boardID, beforeID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Threads", reflect.TypeOf((*MockPost)(nil).Threads), boardID, beforeID, limit)
+}
+
+// MockPostFactory is a mock of PostFactory interface.
+type MockPostFactory struct {
+	ctrl     *gomock.Controller
+	recorder *MockPostFactoryMockRecorder
+}
+
+// MockPostFactoryMockRecorder is the mock recorder for MockPostFactory.
+type MockPostFactoryMockRecorder struct {
+	mock *MockPostFactory
+}
+
+// NewMockPostFactory creates a new mock instance.
+func NewMockPostFactory(ctrl *gomock.Controller) *MockPostFactory {
+	mock := &MockPostFactory{ctrl: ctrl}
+	mock.recorder = &MockPostFactoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPostFactory) EXPECT() *MockPostFactoryMockRecorder {
+	return m.recorder
+}
+
+// NewPostService mocks base method.
+func (m *MockPostFactory) NewPostService(threadRepo repository.Thread, postRepo repository.Post, revisionRepo repository.PostRevision, renderer service.BodyRenderer) service.Post {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewPostService", threadRepo, postRepo, revisionRepo, renderer)
+	ret0, _ := ret[0].(service.Post)
+	return ret0
+}
+
+// NewPostService indicates an expected call of NewPostService.
+func (mr *MockPostFactoryMockRecorder) NewPostService(threadRepo, postRepo, revisionRepo, renderer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewPostService", reflect.TypeOf((*MockPostFactory)(nil).NewPostService), threadRepo, postRepo, revisionRepo, renderer)
+}
+
+// MockBodyRenderer is a mock of BodyRenderer interface.
+type MockBodyRenderer struct {
+	ctrl     *gomock.Controller
+	recorder *MockBodyRendererMockRecorder
+}
+
+// MockBodyRendererMockRecorder is the mock recorder for MockBodyRenderer.
+type MockBodyRendererMockRecorder struct {
+	mock *MockBodyRenderer
+}
+
+// NewMockBodyRenderer creates a new mock instance.
+func NewMockBodyRenderer(ctrl *gomock.Controller) *MockBodyRenderer {
+	mock := &MockBodyRenderer{ctrl: ctrl}
+	mock.recorder = &MockBodyRendererMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBodyRenderer) EXPECT() *MockBodyRendererMockRecorder {
+	return m.recorder
+}
+
+// Render mocks base method.
+func (m *MockBodyRenderer) Render(source string, lastNumber int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Render", source, lastNumber)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Render indicates an expected call of Render.
+func (mr *MockBodyRendererMockRecorder) Render(source, lastNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Render", reflect.TypeOf((*MockBodyRenderer)(nil).Render), source, lastNumber)
+}
@@ -0,0 +1,133 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/service/session_service.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	model "GoBBS/domain/model"
+	repository "GoBBS/domain/repository"
+	service "GoBBS/domain/service"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSession is a mock of Session interface.
+type MockSession struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionMockRecorder
+}
+
+// MockSessionMockRecorder is the mock recorder for MockSession.
+type MockSessionMockRecorder struct {
+	mock *MockSession
+}
+
+// NewMockSession creates a new mock instance.
+func NewMockSession(ctrl *gomock.Controller) *MockSession {
+	mock := &MockSession{ctrl: ctrl}
+	mock.recorder = &MockSessionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSession) EXPECT() *MockSessionMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockSession) List(userID string, now time.Time) ([]model.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", userID, now)
+	ret0, _ := ret[0].([]model.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockSessionMockRecorder) List(userID, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSession)(nil).List), userID, now)
+}
+
+// Revoke mocks base method.
+func (m *MockSession) Revoke(userID, id string, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", userID, id, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockSessionMockRecorder) Revoke(userID, id, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockSession)(nil).Revoke), userID, id, now)
+}
+
+// Start mocks base method.
+func (m *MockSession) Start(userID, ip, userAgent string, now, expiresAt time.Time) (model.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", userID, ip, userAgent, now, expiresAt)
+	ret0, _ := ret[0].(model.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockSessionMockRecorder) Start(userID, ip, userAgent, now, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockSession)(nil).Start), userID, ip, userAgent, now, expiresAt)
+}
+
+// Verify mocks base method.
+func (m *MockSession) Verify(id, userID string, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", id, userID, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockSessionMockRecorder) Verify(id, userID, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockSession)(nil).Verify), id, userID, now)
+}
+
+// MockSessionFactory is a mock of SessionFactory interface.
+type MockSessionFactory struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionFactoryMockRecorder
+}
+
+// MockSessionFactoryMockRecorder is the mock recorder for MockSessionFactory.
+type MockSessionFactoryMockRecorder struct {
+	mock *MockSessionFactory
+}
+
+// NewMockSessionFactory creates a new mock instance.
+func NewMockSessionFactory(ctrl *gomock.Controller) *MockSessionFactory {
+	mock := &MockSessionFactory{ctrl: ctrl}
+	mock.recorder = &MockSessionFactoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionFactory) EXPECT() *MockSessionFactoryMockRecorder {
+	return m.recorder
+}
+
+// NewSessionService mocks base method.
+func (m *MockSessionFactory) NewSessionService(repo repository.Session) service.Session {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewSessionService", repo)
+	ret0, _ := ret[0].(service.Session)
+	return ret0
+}
+
+// NewSessionService indicates an expected call of NewSessionService.
+func (mr *MockSessionFactoryMockRecorder) NewSessionService(repo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewSessionService", reflect.TypeOf((*MockSessionFactory)(nil).NewSessionService), repo)
+}
@@ -0,0 +1,91 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/service/user_identity_service.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	model "GoBBS/domain/model"
+	repository "GoBBS/domain/repository"
+	service "GoBBS/domain/service"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserIdentity is a mock of UserIdentity interface.
+type MockUserIdentity struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserIdentityMockRecorder
+}
+
+// MockUserIdentityMockRecorder is the mock recorder for MockUserIdentity.
+type MockUserIdentityMockRecorder struct {
+	mock *MockUserIdentity
+}
+
+// NewMockUserIdentity creates a new mock instance.
+func NewMockUserIdentity(ctrl *gomock.Controller) *MockUserIdentity {
+	mock := &MockUserIdentity{ctrl: ctrl}
+	mock.recorder = &MockUserIdentityMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserIdentity) EXPECT() *MockUserIdentityMockRecorder {
+	return m.recorder
+}
+
+// Resolve mocks base method.
+func (m *MockUserIdentity) Resolve(issuer, subject, email string, emailVerified bool, name string, now time.Time) (model.User, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", issuer, subject, email, emailVerified, name, now)
+	ret0, _ := ret[0].(model.User)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockUserIdentityMockRecorder) Resolve(issuer, subject, email, emailVerified, name, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockUserIdentity)(nil).Resolve), issuer, subject, email, emailVerified, name, now)
+}
+
+// MockUserIdentityFactory is a mock of UserIdentityFactory interface.
+type MockUserIdentityFactory struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserIdentityFactoryMockRecorder
+}
+
+// MockUserIdentityFactoryMockRecorder is the mock recorder for MockUserIdentityFactory.
+type MockUserIdentityFactoryMockRecorder struct {
+	mock *MockUserIdentityFactory
+}
+
+// NewMockUserIdentityFactory creates a new mock instance.
+func NewMockUserIdentityFactory(ctrl *gomock.Controller) *MockUserIdentityFactory {
+	mock := &MockUserIdentityFactory{ctrl: ctrl}
+	mock.recorder = &MockUserIdentityFactoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserIdentityFactory) EXPECT() *MockUserIdentityFactoryMockRecorder {
+	return m.recorder
+}
+
+// NewUserIdentityService mocks base method.
+func (m *MockUserIdentityFactory) NewUserIdentityService(repo repository.UserIdentity, userRepo repository.User) service.UserIdentity {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewUserIdentityService", repo, userRepo)
+	ret0, _ := ret[0].(service.UserIdentity)
+	return ret0
+}
+
+// NewUserIdentityService indicates an expected call of NewUserIdentityService.
+func (mr *MockUserIdentityFactoryMockRecorder) NewUserIdentityService(repo, userRepo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewUserIdentityService", reflect.TypeOf((*MockUserIdentityFactory)(nil).NewUserIdentityService), repo, userRepo)
+}
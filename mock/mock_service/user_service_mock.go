@@ -66,6 +66,36 @@ func (mr *MockUserMockRecorder) Delete(user interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUser)(nil).Delete), user)
 }
 
+// FindByID mocks base method.
+func (m *MockUser) FindByID(id string) (model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", id)
+	ret0, _ := ret[0].(model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockUserMockRecorder) FindByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockUser)(nil).FindByID), id)
+}
+
+// FindByName mocks base method.
+func (m *MockUser) FindByName(name string) (model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByName", name)
+	ret0, _ := ret[0].(model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByName indicates an expected call of FindByName.
+func (mr *MockUserMockRecorder) FindByName(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByName", reflect.TypeOf((*MockUser)(nil).FindByName), name)
+}
+
 // IsDuplicate mocks base method.
 func (m *MockUser) IsDuplicate(email string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -82,11 +112,12 @@ func (mr *MockUserMockRecorder) IsDuplicate(email interface{}) *gomock.Call {
 }
 
 // Regist mocks base method.
-func (m *MockUser) Regist(user model.User, now time.Time) error {
+func (m *MockUser) Regist(user model.User, now time.Time) (model.User, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Regist", user, now)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Regist indicates an expected call of Regist.
@@ -96,17 +127,32 @@ func (mr *MockUserMockRecorder) Regist(user, now interface{}) *gomock.Call {
 }
 
 // Update mocks base method.
-func (m *MockUser) Update(user model.User, now time.Time) error {
+func (m *MockUser) Update(email string, patch model.UserPatch, now time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", user, now)
+	ret := m.ctrl.Call(m, "Update", email, patch, now)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockUserMockRecorder) Update(user, now interface{}) *gomock.Call {
+func (mr *MockUserMockRecorder) Update(email, patch, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUser)(nil).Update), email, patch, now)
+}
+
+// UpdateAvatar mocks base method.
+func (m *MockUser) UpdateAvatar(id, avatarURL string, now time.Time) (model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAvatar", id, avatarURL, now)
+	ret0, _ := ret[0].(model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAvatar indicates an expected call of UpdateAvatar.
+func (mr *MockUserMockRecorder) UpdateAvatar(id, avatarURL, now interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUser)(nil).Update), user, now)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAvatar", reflect.TypeOf((*MockUser)(nil).UpdateAvatar), id, avatarURL, now)
 }
 
 // MockUserFactory is a mock of UserFactory interface.
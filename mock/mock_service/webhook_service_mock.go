@@ -0,0 +1,194 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/service/webhook_service.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	model "GoBBS/domain/model"
+	repository "GoBBS/domain/repository"
+	service "GoBBS/domain/service"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockWebhook is a mock of Webhook interface.
+type MockWebhook struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookMockRecorder
+}
+
+// MockWebhookMockRecorder is the mock recorder for MockWebhook.
+type MockWebhookMockRecorder struct {
+	mock *MockWebhook
+}
+
+// NewMockWebhook creates a new mock instance.
+func NewMockWebhook(ctrl *gomock.Controller) *MockWebhook {
+	mock := &MockWebhook{ctrl: ctrl}
+	mock.recorder = &MockWebhookMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhook) EXPECT() *MockWebhookMockRecorder {
+	return m.recorder
+}
+
+// ClaimDue mocks base method.
+func (m *MockWebhook) ClaimDue(now time.Time, limit int) ([]model.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDue", now, limit)
+	ret0, _ := ret[0].([]model.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDue indicates an expected call of ClaimDue.
+func (mr *MockWebhookMockRecorder) ClaimDue(now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDue", reflect.TypeOf((*MockWebhook)(nil).ClaimDue), now, limit)
+}
+
+// Delete mocks base method.
+func (m *MockWebhook) Delete(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWebhookMockRecorder) Delete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWebhook)(nil).Delete), id)
+}
+
+// Deliveries mocks base method.
+func (m *MockWebhook) Deliveries(webhookID, beforeID string, limit int) ([]model.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deliveries", webhookID, beforeID, limit)
+	ret0, _ := ret[0].([]model.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Deliveries indicates an expected call of Deliveries.
+func (mr *MockWebhookMockRecorder) Deliveries(webhookID, beforeID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deliveries", reflect.TypeOf((*MockWebhook)(nil).Deliveries), webhookID, beforeID, limit)
+}
+
+// Enqueue mocks base method.
+func (m *MockWebhook) Enqueue(eventID, boardID string, event model.WebhookEvent, data any, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", eventID, boardID, event, data, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockWebhookMockRecorder) Enqueue(eventID, boardID, event, data, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockWebhook)(nil).Enqueue), eventID, boardID, event, data, now)
+}
+
+// Find mocks base method.
+func (m *MockWebhook) Find(id string) (model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", id)
+	ret0, _ := ret[0].(model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockWebhookMockRecorder) Find(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockWebhook)(nil).Find), id)
+}
+
+// List mocks base method.
+func (m *MockWebhook) List(boardID string) ([]model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", boardID)
+	ret0, _ := ret[0].([]model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockWebhookMockRecorder) List(boardID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockWebhook)(nil).List), boardID)
+}
+
+// RecordAttempt mocks base method.
+func (m *MockWebhook) RecordAttempt(delivery model.WebhookDelivery, statusCode int, sendErr error, now time.Time) (model.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordAttempt", delivery, statusCode, sendErr, now)
+	ret0, _ := ret[0].(model.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordAttempt indicates an expected call of RecordAttempt.
+func (mr *MockWebhookMockRecorder) RecordAttempt(delivery, statusCode, sendErr, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordAttempt", reflect.TypeOf((*MockWebhook)(nil).RecordAttempt), delivery, statusCode, sendErr, now)
+}
+
+// Regist mocks base method.
+func (m *MockWebhook) Regist(boardID, rawURL string, events []model.WebhookEvent, now time.Time) (model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Regist", boardID, rawURL, events, now)
+	ret0, _ := ret[0].(model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Regist indicates an expected call of Regist.
+func (mr *MockWebhookMockRecorder) Regist(boardID, rawURL, events, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Regist", reflect.TypeOf((*MockWebhook)(nil).Regist), boardID, rawURL, events, now)
+}
+
+// MockWebhookFactory is a mock of WebhookFactory interface.
+type MockWebhookFactory struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookFactoryMockRecorder
+}
+
+// MockWebhookFactoryMockRecorder is the mock recorder for MockWebhookFactory.
+type MockWebhookFactoryMockRecorder struct {
+	mock *MockWebhookFactory
+}
+
+// NewMockWebhookFactory creates a new mock instance.
+func NewMockWebhookFactory(ctrl *gomock.Controller) *MockWebhookFactory {
+	mock := &MockWebhookFactory{ctrl: ctrl}
+	mock.recorder = &MockWebhookFactoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookFactory) EXPECT() *MockWebhookFactoryMockRecorder {
+	return m.recorder
+}
+
+// NewWebhookService mocks base method.
+func (m *MockWebhookFactory) NewWebhookService(repo repository.Webhook) service.Webhook {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewWebhookService", repo)
+	ret0, _ := ret[0].(service.Webhook)
+	return ret0
+}
+
+// NewWebhookService indicates an expected call of NewWebhookService.
+func (mr *MockWebhookFactoryMockRecorder) NewWebhookService(repo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewWebhookService", reflect.TypeOf((*MockWebhookFactory)(nil).NewWebhookService), repo)
+}
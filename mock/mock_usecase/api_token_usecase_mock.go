@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase/api_token_usecase.go
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	dto "GoBBS/dto"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAPIToken is a mock of APIToken interface.
+type MockAPIToken struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPITokenMockRecorder
+}
+
+// MockAPITokenMockRecorder is the mock recorder for MockAPIToken.
+type MockAPITokenMockRecorder struct {
+	mock *MockAPIToken
+}
+
+// NewMockAPIToken creates a new mock instance.
+func NewMockAPIToken(ctrl *gomock.Controller) *MockAPIToken {
+	mock := &MockAPIToken{ctrl: ctrl}
+	mock.recorder = &MockAPITokenMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIToken) EXPECT() *MockAPITokenMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAPIToken) Create(ctx context.Context, userID string, req *dto.APITokenRequest, now time.Time) (*dto.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userID, req, now)
+	ret0, _ := ret[0].(*dto.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAPITokenMockRecorder) Create(ctx, userID, req, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAPIToken)(nil).Create), ctx, userID, req, now)
+}
+
+// List mocks base method.
+func (m *MockAPIToken) List(ctx context.Context, userID string, now time.Time) (*dto.APITokenList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, userID, now)
+	ret0, _ := ret[0].(*dto.APITokenList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockAPITokenMockRecorder) List(ctx, userID, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAPIToken)(nil).List), ctx, userID, now)
+}
+
+// Revoke mocks base method.
+func (m *MockAPIToken) Revoke(ctx context.Context, userID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockAPITokenMockRecorder) Revoke(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockAPIToken)(nil).Revoke), ctx, userID, id)
+}
@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase/digest_usecase.go
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	dto "GoBBS/dto"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockDigest is a mock of Digest interface.
+type MockDigest struct {
+	ctrl     *gomock.Controller
+	recorder *MockDigestMockRecorder
+}
+
+// MockDigestMockRecorder is the mock recorder for MockDigest.
+type MockDigestMockRecorder struct {
+	mock *MockDigest
+}
+
+// NewMockDigest creates a new mock instance.
+func NewMockDigest(ctrl *gomock.Controller) *MockDigest {
+	mock := &MockDigest{ctrl: ctrl}
+	mock.recorder = &MockDigestMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDigest) EXPECT() *MockDigestMockRecorder {
+	return m.recorder
+}
+
+// SendDigests mocks base method.
+func (m *MockDigest) SendDigests(ctx context.Context, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendDigests", ctx, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendDigests indicates an expected call of SendDigests.
+func (mr *MockDigestMockRecorder) SendDigests(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendDigests", reflect.TypeOf((*MockDigest)(nil).SendDigests), ctx, now)
+}
+
+// Subscription mocks base method.
+func (m *MockDigest) Subscription(ctx context.Context, userID string) (*dto.DigestSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscription", ctx, userID)
+	ret0, _ := ret[0].(*dto.DigestSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscription indicates an expected call of Subscription.
+func (mr *MockDigestMockRecorder) Subscription(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscription", reflect.TypeOf((*MockDigest)(nil).Subscription), ctx, userID)
+}
+
+// Unsubscribe mocks base method.
+func (m *MockDigest) Unsubscribe(ctx context.Context, token string, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unsubscribe", ctx, token, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockDigestMockRecorder) Unsubscribe(ctx, token, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockDigest)(nil).Unsubscribe), ctx, token, now)
+}
+
+// Unwatch mocks base method.
+func (m *MockDigest) Unwatch(ctx context.Context, userID, kind, targetID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unwatch", ctx, userID, kind, targetID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unwatch indicates an expected call of Unwatch.
+func (mr *MockDigestMockRecorder) Unwatch(ctx, userID, kind, targetID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unwatch", reflect.TypeOf((*MockDigest)(nil).Unwatch), ctx, userID, kind, targetID)
+}
+
+// UpdateSubscription mocks base method.
+func (m *MockDigest) UpdateSubscription(ctx context.Context, userID string, subscription *dto.DigestSubscription, now time.Time) (*dto.DigestSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubscription", ctx, userID, subscription, now)
+	ret0, _ := ret[0].(*dto.DigestSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSubscription indicates an expected call of UpdateSubscription.
+func (mr *MockDigestMockRecorder) UpdateSubscription(ctx, userID, subscription, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscription", reflect.TypeOf((*MockDigest)(nil).UpdateSubscription), ctx, userID, subscription, now)
+}
+
+// Watch mocks base method.
+func (m *MockDigest) Watch(ctx context.Context, userID, kind, targetID string, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Watch", ctx, userID, kind, targetID, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Watch indicates an expected call of Watch.
+func (mr *MockDigestMockRecorder) Watch(ctx, userID, kind, targetID, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockDigest)(nil).Watch), ctx, userID, kind, targetID, now)
+}
+
+// Watches mocks base method.
+func (m *MockDigest) Watches(ctx context.Context, userID string) ([]*dto.Watch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Watches", ctx, userID)
+	ret0, _ := ret[0].([]*dto.Watch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Watches indicates an expected call of Watches.
+func (mr *MockDigestMockRecorder) Watches(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watches", reflect.TypeOf((*MockDigest)(nil).Watches), ctx, userID)
+}
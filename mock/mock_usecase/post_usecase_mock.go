@@ -0,0 +1,157 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase/post_usecase.go
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	dto "GoBBS/dto"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPost is a mock of Post interface.
+type MockPost struct {
+	ctrl     *gomock.Controller
+	recorder *MockPostMockRecorder
+}
+
+// MockPostMockRecorder is the mock recorder for MockPost.
+type MockPostMockRecorder struct {
+	mock *MockPost
+}
+
+// NewMockPost creates a new mock instance.
+func NewMockPost(ctrl *gomock.Controller) *MockPost {
+	mock := &MockPost{ctrl: ctrl}
+	mock.recorder = &MockPostMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPost) EXPECT() *MockPostMockRecorder {
+	return m.recorder
+}
+
+// CreateThread mocks base method.
+func (m *MockPost) CreateThread(ctx context.Context, userID, boardID string, req *dto.ThreadRequest, now time.Time) (*dto.ThreadPosts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateThread", ctx, userID, boardID, req, now)
+	ret0, _ := ret[0].(*dto.ThreadPosts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateThread indicates an expected call of CreateThread.
+func (mr *MockPostMockRecorder) CreateThread(ctx, userID, boardID, req, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateThread", reflect.TypeOf((*MockPost)(nil).CreateThread), ctx, userID, boardID, req, now)
+}
+
+// Diff mocks base method.
+func (m *MockPost) Diff(ctx context.Context, postID, fromID, toID string) (*dto.PostDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Diff", ctx, postID, fromID, toID)
+	ret0, _ := ret[0].(*dto.PostDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Diff indicates an expected call of Diff.
+func (mr *MockPostMockRecorder) Diff(ctx, postID, fromID, toID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Diff", reflect.TypeOf((*MockPost)(nil).Diff), ctx, postID, fromID, toID)
+}
+
+// Edit mocks base method.
+func (m *MockPost) Edit(ctx context.Context, userID, postID string, moderator bool, req *dto.PostRequest, now time.Time) (*dto.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Edit", ctx, userID, postID, moderator, req, now)
+	ret0, _ := ret[0].(*dto.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Edit indicates an expected call of Edit.
+func (mr *MockPostMockRecorder) Edit(ctx, userID, postID, moderator, req, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Edit", reflect.TypeOf((*MockPost)(nil).Edit), ctx, userID, postID, moderator, req, now)
+}
+
+// Hide mocks base method.
+func (m *MockPost) Hide(ctx context.Context, postID string, req *dto.PostHideRequest, now time.Time) (*dto.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hide", ctx, postID, req, now)
+	ret0, _ := ret[0].(*dto.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Hide indicates an expected call of Hide.
+func (mr *MockPostMockRecorder) Hide(ctx, postID, req, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hide", reflect.TypeOf((*MockPost)(nil).Hide), ctx, postID, req, now)
+}
+
+// Posts mocks base method.
+func (m *MockPost) Posts(ctx context.Context, threadID string, afterNumber, limit int) (*dto.ThreadPosts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Posts", ctx, threadID, afterNumber, limit)
+	ret0, _ := ret[0].(*dto.ThreadPosts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Posts indicates an expected call of Posts.
+func (mr *MockPostMockRecorder) Posts(ctx, threadID, afterNumber, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Posts", reflect.TypeOf((*MockPost)(nil).Posts), ctx, threadID, afterNumber, limit)
+}
+
+// Reply mocks base method.
+func (m *MockPost) Reply(ctx context.Context, userID, threadID string, req *dto.PostRequest, now time.Time) (*dto.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reply", ctx, userID, threadID, req, now)
+	ret0, _ := ret[0].(*dto.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reply indicates an expected call of Reply.
+func (mr *MockPostMockRecorder) Reply(ctx, userID, threadID, req, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reply", reflect.TypeOf((*MockPost)(nil).Reply), ctx, userID, threadID, req, now)
+}
+
+// Revisions mocks base method.
+func (m *MockPost) Revisions(ctx context.Context, postID string) ([]*dto.PostRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revisions", ctx, postID)
+	ret0, _ := ret[0].([]*dto.PostRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Revisions indicates an expected call of Revisions.
+func (mr *MockPostMockRecorder) Revisions(ctx, postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revisions", reflect.TypeOf((*MockPost)(nil).Revisions), ctx, postID)
+}
+
+// Threads mocks base method.
+func (m *MockPost) Threads(ctx context.Context, boardID, beforeID string, limit int) ([]*dto.Thread, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Threads", ctx, boardID, beforeID, limit)
+	ret0, _ := ret[0].([]*dto.Thread)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Threads indicates an expected call of Threads.
+func (mr *MockPostMockRecorder) Threads(ctx, boardID, beforeID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Threads", reflect.TypeOf((*MockPost)(nil).Threads), ctx, boardID, beforeID, limit)
+}
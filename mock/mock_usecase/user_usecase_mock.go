@@ -6,6 +6,8 @@ package mock_usecase
 
 import (
 	dto "GoBBS/dto"
+	oidc "GoBBS/interface/oidc"
+	context "context"
 	reflect "reflect"
 	time "time"
 
@@ -36,72 +38,137 @@ func (m *MockUser) EXPECT() *MockUserMockRecorder {
 }
 
 // Authorize mocks base method.
-func (m *MockUser) Authorize(email, password string) (string, error) {
+func (m *MockUser) Authorize(ctx context.Context, email, password, ip, userAgent string) (string, *dto.MFAChallenge, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Authorize", email, password)
+	ret := m.ctrl.Call(m, "Authorize", ctx, email, password, ip, userAgent)
 	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(*dto.MFAChallenge)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // Authorize indicates an expected call of Authorize.
-func (mr *MockUserMockRecorder) Authorize(email, password interface{}) *gomock.Call {
+func (mr *MockUserMockRecorder) Authorize(ctx, email, password, ip, userAgent interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*MockUser)(nil).Authorize), ctx, email, password, ip, userAgent)
+}
+
+// AuthorizeExternal mocks base method.
+func (m *MockUser) AuthorizeExternal(ctx context.Context, identity *oidc.Identity, ip, userAgent string) (string, *dto.MFAChallenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizeExternal", ctx, identity, ip, userAgent)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(*dto.MFAChallenge)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AuthorizeExternal indicates an expected call of AuthorizeExternal.
+func (mr *MockUserMockRecorder) AuthorizeExternal(ctx, identity, ip, userAgent interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*MockUser)(nil).Authorize), email, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeExternal", reflect.TypeOf((*MockUser)(nil).AuthorizeExternal), ctx, identity, ip, userAgent)
 }
 
 // Delete mocks base method.
-func (m *MockUser) Delete(arg0 *dto.User) error {
+func (m *MockUser) Delete(arg0 context.Context, arg1 *dto.User) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockUserMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+func (mr *MockUserMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUser)(nil).Delete), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUser)(nil).Delete), arg0, arg1)
+}
+
+// FindByID mocks base method.
+func (m *MockUser) FindByID(ctx context.Context, id string) (*dto.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*dto.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockUserMockRecorder) FindByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockUser)(nil).FindByID), ctx, id)
 }
 
 // Regist mocks base method.
-func (m *MockUser) Regist(arg0 *dto.User, arg1 time.Time) error {
+func (m *MockUser) Regist(arg0 context.Context, arg1 *dto.User, arg2 time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Regist", arg0, arg1)
+	ret := m.ctrl.Call(m, "Regist", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Regist indicates an expected call of Regist.
-func (mr *MockUserMockRecorder) Regist(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockUserMockRecorder) Regist(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Regist", reflect.TypeOf((*MockUser)(nil).Regist), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Regist", reflect.TypeOf((*MockUser)(nil).Regist), arg0, arg1, arg2)
 }
 
 // Update mocks base method.
-func (m *MockUser) Update(arg0 *dto.User, arg1 time.Time) error {
+func (m *MockUser) Update(arg0 context.Context, arg1 *dto.UserUpdateRequest, arg2 time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockUserMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockUserMockRecorder) Update(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUser)(nil).Update), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUser)(nil).Update), arg0, arg1, arg2)
+}
+
+// VerifyAPIToken mocks base method.
+func (m *MockUser) VerifyAPIToken(ctx context.Context, token string) (string, []string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyAPIToken", ctx, token)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(bool)
+	return ret0, ret1, ret2
+}
+
+// VerifyAPIToken indicates an expected call of VerifyAPIToken.
+func (mr *MockUserMockRecorder) VerifyAPIToken(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyAPIToken", reflect.TypeOf((*MockUser)(nil).VerifyAPIToken), ctx, token)
 }
 
 // VerifyAuthorization mocks base method.
-func (m *MockUser) VerifyAuthorization(token string) bool {
+func (m *MockUser) VerifyAuthorization(ctx context.Context, token string) (string, string, bool) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "VerifyAuthorization", token)
-	ret0, _ := ret[0].(bool)
-	return ret0
+	ret := m.ctrl.Call(m, "VerifyAuthorization", ctx, token)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(bool)
+	return ret0, ret1, ret2
 }
 
 // VerifyAuthorization indicates an expected call of VerifyAuthorization.
-func (mr *MockUserMockRecorder) VerifyAuthorization(token interface{}) *gomock.Call {
+func (mr *MockUserMockRecorder) VerifyAuthorization(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyAuthorization", reflect.TypeOf((*MockUser)(nil).VerifyAuthorization), ctx, token)
+}
+
+// VerifyMFA mocks base method.
+func (m *MockUser) VerifyMFA(ctx context.Context, mfaToken, code, ip, userAgent string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyMFA", ctx, mfaToken, code, ip, userAgent)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyMFA indicates an expected call of VerifyMFA.
+func (mr *MockUserMockRecorder) VerifyMFA(ctx, mfaToken, code, ip, userAgent interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyAuthorization", reflect.TypeOf((*MockUser)(nil).VerifyAuthorization), token)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyMFA", reflect.TypeOf((*MockUser)(nil).VerifyMFA), ctx, mfaToken, code, ip, userAgent)
 }
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"GoBBS/domain/service"
+	"GoBBS/dto"
+	"GoBBS/interface/dao"
+)
+
+// APIToken 個人用アクセストークンユースケース
+// mockgen -source usecase/api_token_usecase.go -destination mock/mock_usecase/api_token_usecase_mock.go
+type APIToken interface {
+	Create(ctx context.Context, userID string, req *dto.APITokenRequest, now time.Time) (*dto.APIToken, error)
+	List(ctx context.Context, userID string, now time.Time) (*dto.APITokenList, error)
+	Revoke(ctx context.Context, userID string, id string) error
+}
+
+type apiTokenUseCase struct {
+	db                     *sql.DB
+	apiTokenServiceFactory service.APITokenFactory
+}
+
+var _ APIToken = (*apiTokenUseCase)(nil)
+
+// NewAPITokenUseCase 個人用アクセストークンユースケースを生成する
+func NewAPITokenUseCase(db *sql.DB, f service.APITokenFactory) *apiTokenUseCase {
+	return &apiTokenUseCase{
+		db:                     db,
+		apiTokenServiceFactory: f,
+	}
+}
+
+// Create トークンを発行する(トークンはこのレスポンスでしか返さない)
+func (uc *apiTokenUseCase) Create(ctx context.Context, userID string, req *dto.APITokenRequest, now time.Time) (*dto.APIToken, error) {
+	ctx, span := tracer.Start(ctx, "APIToken.Create")
+	defer span.End()
+
+	return dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) (*dto.APIToken, error) {
+			token, apiToken, err := uc.service(tx).Create(userID, req.Name, req.Scopes, req.ExpiresAt, now)
+			if err != nil {
+				return nil, err
+			}
+			return dto.NewCreatedAPIToken(token, apiToken), nil
+		},
+	)
+}
+
+// List 有効なトークンの一覧を取得する
+func (uc *apiTokenUseCase) List(ctx context.Context, userID string, now time.Time) (*dto.APITokenList, error) {
+	ctx, span := tracer.Start(ctx, "APIToken.List")
+	defer span.End()
+
+	return dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) (*dto.APITokenList, error) {
+			tokens, err := uc.service(tx).List(userID, now)
+			if err != nil {
+				return nil, err
+			}
+			return dto.NewAPITokenList(tokens), nil
+		},
+	)
+}
+
+// Revoke トークンを削除する
+func (uc *apiTokenUseCase) Revoke(ctx context.Context, userID string, id string) error {
+	ctx, span := tracer.Start(ctx, "APIToken.Revoke")
+	defer span.End()
+
+	_, err := dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) (any, error) {
+			return nil, uc.service(tx).Revoke(userID, id)
+		},
+	)
+
+	return err
+}
+
+// service トランザクションに紐づく個人用アクセストークンサービスを生成する
+func (uc *apiTokenUseCase) service(tx *sql.Tx) service.APIToken {
+	return uc.apiTokenServiceFactory.NewAPITokenService(dao.NewAPITokenDAO(tx))
+}
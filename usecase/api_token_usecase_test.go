@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/dto"
+	"GoBBS/mock/mock_service"
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+// apiTokenFactory 個人用アクセストークンサービスを返すファクトリーのモックを生成する
+func apiTokenFactory(ctrl *gomock.Controller, svc *mock_service.MockAPIToken) *mock_service.MockAPITokenFactory {
+	mock := mock_service.NewMockAPITokenFactory(ctrl)
+	mock.EXPECT().NewAPITokenService(gomock.Any()).Return(svc)
+	return mock
+}
+
+func TestNewAPITokenUseCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db := &sql.DB{}
+	f := mock_service.NewMockAPITokenFactory(ctrl)
+
+	want := &apiTokenUseCase{db: db, apiTokenServiceFactory: f}
+	if got := NewAPITokenUseCase(db, f); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewAPITokenUseCase() = %v, want %v", got, want)
+	}
+}
+
+func Test_apiTokenUseCase_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	expires := now.Add(time.Hour)
+	req := &dto.APITokenRequest{Name: "ci-bot", Scopes: []string{"read"}, ExpiresAt: expires}
+
+	tests := []struct {
+		name    string
+		uc      *apiTokenUseCase
+		want    *dto.APIToken
+		wantErr error
+	}{
+		{
+			name: "正常ケース",
+			uc: &apiTokenUseCase{
+				db: testDB(t, true),
+				apiTokenServiceFactory: func() *mock_service.MockAPITokenFactory {
+					svc := mock_service.NewMockAPIToken(ctrl)
+					svc.EXPECT().Create("1", "ci-bot", []string{"read"}, expires, now).
+						Return("gbp_secret", model.NewAPIToken("t1", "1", "ci-bot", "hash", []string{"read"}, expires, time.Time{}, now), nil)
+					return apiTokenFactory(ctrl, svc)
+				}(),
+			},
+			want: &dto.APIToken{ID: "t1", Name: "ci-bot", Scopes: []string{"read"}, Token: "gbp_secret", ExpiresAt: expires, CreatedAt: now},
+		},
+		{
+			name: "異常ケース",
+			uc: &apiTokenUseCase{
+				db: testDB(t, false),
+				apiTokenServiceFactory: func() *mock_service.MockAPITokenFactory {
+					svc := mock_service.NewMockAPIToken(ctrl)
+					svc.EXPECT().Create("1", "ci-bot", []string{"read"}, expires, now).Return("", nil, errTest)
+					return apiTokenFactory(ctrl, svc)
+				}(),
+			},
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.uc.Create(context.Background(), "1", req, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("apiTokenUseCase.Create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("apiTokenUseCase.Create() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_apiTokenUseCase_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	expires := now.Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		uc      *apiTokenUseCase
+		want    *dto.APITokenList
+		wantErr error
+	}{
+		{
+			name: "正常ケース",
+			uc: &apiTokenUseCase{
+				db: testDB(t, true),
+				apiTokenServiceFactory: func() *mock_service.MockAPITokenFactory {
+					svc := mock_service.NewMockAPIToken(ctrl)
+					svc.EXPECT().List("1", now).Return([]model.APIToken{
+						model.NewAPIToken("t1", "1", "ci-bot", "hash", []string{"read"}, expires, time.Time{}, now),
+					}, nil)
+					return apiTokenFactory(ctrl, svc)
+				}(),
+			},
+			want: &dto.APITokenList{
+				Tokens: []*dto.APIToken{
+					{ID: "t1", Name: "ci-bot", Scopes: []string{"read"}, ExpiresAt: expires, CreatedAt: now},
+				},
+			},
+		},
+		{
+			name: "異常ケース",
+			uc: &apiTokenUseCase{
+				db: testDB(t, false),
+				apiTokenServiceFactory: func() *mock_service.MockAPITokenFactory {
+					svc := mock_service.NewMockAPIToken(ctrl)
+					svc.EXPECT().List("1", now).Return(nil, errTest)
+					return apiTokenFactory(ctrl, svc)
+				}(),
+			},
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.uc.List(context.Background(), "1", now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("apiTokenUseCase.List() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("apiTokenUseCase.List() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_apiTokenUseCase_Revoke(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	for _, wantErr := range []error{nil, errTest} {
+		svc := mock_service.NewMockAPIToken(ctrl)
+		svc.EXPECT().Revoke("1", "t1").Return(wantErr)
+		uc := &apiTokenUseCase{db: testDB(t, wantErr == nil), apiTokenServiceFactory: apiTokenFactory(ctrl, svc)}
+
+		if err := uc.Revoke(context.Background(), "1", "t1"); !errors.Is(err, wantErr) {
+			t.Errorf("apiTokenUseCase.Revoke() error = %v, wantErr %v", err, wantErr)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"GoBBS/domain/service"
+	"GoBBS/dto"
+	"GoBBS/interface/dao"
+)
+
+// Board 板ユースケース
+// mockgen -source usecase/board_usecase.go -destination mock/mock_usecase/board_usecase_mock.go
+type Board interface {
+	List(ctx context.Context) ([]*dto.Board, error)
+	Regist(ctx context.Context, req *dto.BoardRequest, now time.Time) (*dto.Board, error)
+}
+
+type boardUseCase struct {
+	db                  *sql.DB
+	boardServiceFactory service.BoardFactory
+}
+
+var _ Board = (*boardUseCase)(nil)
+
+// NewBoardUseCase 板ユースケースを生成する
+func NewBoardUseCase(db *sql.DB, f service.BoardFactory) *boardUseCase {
+	return &boardUseCase{
+		db:                  db,
+		boardServiceFactory: f,
+	}
+}
+
+// List 板の一覧を取得する
+func (uc *boardUseCase) List(ctx context.Context) ([]*dto.Board, error) {
+	ctx, span := tracer.Start(ctx, "Board.List")
+	defer span.End()
+
+	return dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) ([]*dto.Board, error) {
+			boards, err := uc.service(tx).List()
+			if err != nil {
+				return nil, err
+			}
+			return dto.NewBoards(boards), nil
+		},
+	)
+}
+
+// Regist 板を登録する
+func (uc *boardUseCase) Regist(ctx context.Context, req *dto.BoardRequest, now time.Time) (*dto.Board, error) {
+	ctx, span := tracer.Start(ctx, "Board.Regist")
+	defer span.End()
+
+	return dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) (*dto.Board, error) {
+			board, err := uc.service(tx).Regist(req.Name, req.Description, now)
+			if err != nil {
+				return nil, err
+			}
+			return dto.NewBoard(board), nil
+		},
+	)
+}
+
+// service トランザクションに紐づく板サービスを生成する
+func (uc *boardUseCase) service(tx *sql.Tx) service.Board {
+	return uc.boardServiceFactory.NewBoardService(dao.NewBoardDAO(tx))
+}
@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/dto"
+	"GoBBS/mock/mock_service"
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+// boardFactory 板サービスを返すファクトリーのモックを生成する
+func boardFactory(ctrl *gomock.Controller, svc *mock_service.MockBoard) *mock_service.MockBoardFactory {
+	mock := mock_service.NewMockBoardFactory(ctrl)
+	mock.EXPECT().NewBoardService(gomock.Any()).Return(svc)
+	return mock
+}
+
+func TestNewBoardUseCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db := &sql.DB{}
+	f := mock_service.NewMockBoardFactory(ctrl)
+
+	want := &boardUseCase{db: db, boardServiceFactory: f}
+	if got := NewBoardUseCase(db, f); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewBoardUseCase() = %v, want %v", got, want)
+	}
+}
+
+func Test_boardUseCase_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		uc      *boardUseCase
+		want    []*dto.Board
+		wantErr error
+	}{
+		{
+			name: "正常ケース",
+			uc: &boardUseCase{
+				db: testDB(t, true),
+				boardServiceFactory: func() *mock_service.MockBoardFactory {
+					svc := mock_service.NewMockBoard(ctrl)
+					svc.EXPECT().List().Return([]model.Board{
+						model.NewBoard("1", "雑談", "なんでも", now),
+					}, nil)
+					return boardFactory(ctrl, svc)
+				}(),
+			},
+			want:    []*dto.Board{{ID: "1", Name: "雑談", Description: "なんでも", CreatedAt: now}},
+			wantErr: nil,
+		},
+		{
+			name: "異常ケース",
+			uc: &boardUseCase{
+				db: testDB(t, false),
+				boardServiceFactory: func() *mock_service.MockBoardFactory {
+					svc := mock_service.NewMockBoard(ctrl)
+					svc.EXPECT().List().Return(nil, errTest)
+					return boardFactory(ctrl, svc)
+				}(),
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.uc.List(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("boardUseCase.List() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("boardUseCase.List() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_boardUseCase_Regist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	req := &dto.BoardRequest{Name: "雑談", Description: "なんでも"}
+
+	tests := []struct {
+		name    string
+		uc      *boardUseCase
+		want    *dto.Board
+		wantErr error
+	}{
+		{
+			name: "正常ケース",
+			uc: &boardUseCase{
+				db: testDB(t, true),
+				boardServiceFactory: func() *mock_service.MockBoardFactory {
+					svc := mock_service.NewMockBoard(ctrl)
+					svc.EXPECT().Regist("雑談", "なんでも", now).Return(model.NewBoard("1", "雑談", "なんでも", now), nil)
+					return boardFactory(ctrl, svc)
+				}(),
+			},
+			want:    &dto.Board{ID: "1", Name: "雑談", Description: "なんでも", CreatedAt: now},
+			wantErr: nil,
+		},
+		{
+			name: "異常ケース",
+			uc: &boardUseCase{
+				db: testDB(t, false),
+				boardServiceFactory: func() *mock_service.MockBoardFactory {
+					svc := mock_service.NewMockBoard(ctrl)
+					svc.EXPECT().Regist("雑談", "なんでも", now).Return(nil, errTest)
+					return boardFactory(ctrl, svc)
+				}(),
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.uc.Regist(context.Background(), req, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("boardUseCase.Regist() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("boardUseCase.Regist() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,322 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/service"
+	"GoBBS/dto"
+	"GoBBS/interface/dao"
+	"GoBBS/interface/digest"
+	"GoBBS/interface/mail"
+)
+
+// Digest ウォッチ・ダイジェストメールユースケース
+// mockgen -source usecase/digest_usecase.go -destination mock/mock_usecase/digest_usecase_mock.go
+type Digest interface {
+	Watch(ctx context.Context, userID string, kind string, targetID string, now time.Time) error
+	Unwatch(ctx context.Context, userID string, kind string, targetID string) error
+	Watches(ctx context.Context, userID string) ([]*dto.Watch, error)
+	Subscription(ctx context.Context, userID string) (*dto.DigestSubscription, error)
+	UpdateSubscription(ctx context.Context, userID string, subscription *dto.DigestSubscription, now time.Time) (*dto.DigestSubscription, error)
+	Unsubscribe(ctx context.Context, token string, now time.Time) error
+	SendDigests(ctx context.Context, now time.Time) (int, error)
+}
+
+type digestUseCase struct {
+	db                   *sql.DB
+	digestServiceFactory service.DigestFactory
+	userServiceFactory   service.UserFactory
+	activity             digest.ActivitySource
+	renderer             digest.Renderer
+	mailer               mail.Mailer
+	baseURL              string
+}
+
+// digestRecipient ダイジェストメールの送信先
+type digestRecipient struct {
+	user         model.User
+	subscription model.DigestSubscription
+	watches      []model.Watch
+}
+
+var _ Digest = (*digestUseCase)(nil)
+
+// digestFrequencies 配信処理の対象とする配信頻度
+var digestFrequencies = []model.DigestFrequency{model.DigestDaily, model.DigestWeekly}
+
+// DigestUnsubscribePath 配信停止のパス
+const DigestUnsubscribePath = "/digest/unsubscribe"
+
+// NewDigestUseCase ウォッチ・ダイジェストメールユースケースを生成する
+// baseURLは配信停止URLの生成に使用する
+func NewDigestUseCase(
+	db *sql.DB,
+	df service.DigestFactory,
+	uf service.UserFactory,
+	activity digest.ActivitySource,
+	renderer digest.Renderer,
+	mailer mail.Mailer,
+	baseURL string) *digestUseCase {
+	return &digestUseCase{
+		db:                   db,
+		digestServiceFactory: df,
+		userServiceFactory:   uf,
+		activity:             activity,
+		renderer:             renderer,
+		mailer:               mailer,
+		baseURL:              baseURL,
+	}
+}
+
+// Watch スレッド・板をウォッチする
+func (uc *digestUseCase) Watch(ctx context.Context, userID string, kind string, targetID string, now time.Time) error {
+	ctx, span := tracer.Start(ctx, "Digest.Watch")
+	defer span.End()
+
+	_, err := dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) (any, error) {
+			return nil, uc.service(tx).Watch(userID, model.WatchKind(kind), targetID, now)
+		},
+	)
+
+	return err
+}
+
+// Unwatch ウォッチを解除する
+func (uc *digestUseCase) Unwatch(ctx context.Context, userID string, kind string, targetID string) error {
+	ctx, span := tracer.Start(ctx, "Digest.Unwatch")
+	defer span.End()
+
+	_, err := dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) (any, error) {
+			return nil, uc.service(tx).Unwatch(userID, model.WatchKind(kind), targetID)
+		},
+	)
+
+	return err
+}
+
+// Watches ウォッチの一覧を取得する
+func (uc *digestUseCase) Watches(ctx context.Context, userID string) ([]*dto.Watch, error) {
+	ctx, span := tracer.Start(ctx, "Digest.Watches")
+	defer span.End()
+
+	watches, err := dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) ([]model.Watch, error) {
+			return uc.service(tx).Watches(userID)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.NewWatches(watches), nil
+}
+
+// Subscription 配信設定を取得する
+func (uc *digestUseCase) Subscription(ctx context.Context, userID string) (*dto.DigestSubscription, error) {
+	ctx, span := tracer.Start(ctx, "Digest.Subscription")
+	defer span.End()
+
+	subscription, err := dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) (model.DigestSubscription, error) {
+			return uc.service(tx).Subscription(userID)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.NewDigestSubscription(subscription), nil
+}
+
+// UpdateSubscription 配信設定を更新する
+func (uc *digestUseCase) UpdateSubscription(ctx context.Context, userID string, subscription *dto.DigestSubscription, now time.Time) (*dto.DigestSubscription, error) {
+	ctx, span := tracer.Start(ctx, "Digest.UpdateSubscription")
+	defer span.End()
+
+	updated, err := dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) (model.DigestSubscription, error) {
+			return uc.service(tx).UpdateSubscription(
+				userID,
+				model.DigestFrequency(subscription.Frequency),
+				subscription.Locale,
+				now,
+			)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.NewDigestSubscription(updated), nil
+}
+
+// Unsubscribe 配信停止トークンで配信を停止する
+func (uc *digestUseCase) Unsubscribe(ctx context.Context, token string, now time.Time) error {
+	ctx, span := tracer.Start(ctx, "Digest.Unsubscribe")
+	defer span.End()
+
+	_, err := dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) (any, error) {
+			return nil, uc.service(tx).Unsubscribe(token, now)
+		},
+	)
+
+	return err
+}
+
+// SendDigests nowの時点で未送信のダイジェストメールを送信し、送信件数を返す
+// 送信前に集計期間ごとの送信記録を登録するため、複数回実行しても同じ期間のメールは二重送信しない
+func (uc *digestUseCase) SendDigests(ctx context.Context, now time.Time) (int, error) {
+	ctx, span := tracer.Start(ctx, "Digest.SendDigests")
+	defer span.End()
+
+	sent := 0
+	for _, frequency := range digestFrequencies {
+		period, _ := model.NewDigestPeriod(frequency, now)
+
+		userIDs, err := dao.ExecWithTx(
+			ctx,
+			uc.db,
+			func(tx *sql.Tx) ([]string, error) {
+				return uc.service(tx).DueUserIDs(frequency, period)
+			},
+		)
+		if err != nil {
+			return sent, errors.Wrap(err, "SendDigests error")
+		}
+
+		for _, userID := range userIDs {
+			ok, err := uc.sendDigest(ctx, userID, frequency, period, now)
+			if err != nil {
+				// 1ユーザーの失敗で他のユーザーへの送信を止めない
+				slog.Error("send digest error", "user_id", userID, "period", period.Key, "error", err)
+				continue
+			}
+			if ok {
+				sent++
+			}
+		}
+	}
+
+	return sent, nil
+}
+
+// sendDigest 1ユーザー分のダイジェストメールを送信し、送信したかを返す(新着がない場合は送信しない)
+func (uc *digestUseCase) sendDigest(ctx context.Context, userID string, frequency model.DigestFrequency, period model.DigestPeriod, now time.Time) (bool, error) {
+	recipient, err := dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) (*digestRecipient, error) {
+			digestService := uc.service(tx)
+			claimed, err := digestService.ClaimDelivery(userID, period, now)
+			if err != nil || !claimed {
+				return nil, err
+			}
+
+			user, err := uc.userServiceFactory.NewUserService(dao.NewUserDAO(tx)).FindByID(userID)
+			if err != nil {
+				return nil, err
+			}
+			subscription, err := digestService.Subscription(userID)
+			if err != nil {
+				return nil, err
+			}
+			watches, err := digestService.Watches(userID)
+			if err != nil {
+				return nil, err
+			}
+
+			return &digestRecipient{user: user, subscription: subscription, watches: watches}, nil
+		},
+	)
+	if err != nil {
+		return false, errors.Wrap(err, "sendDigest error")
+	}
+	// 他のプロセスが送信済み、または集計後に配信頻度が変更された
+	if recipient == nil || recipient.subscription.Frequency != frequency {
+		return false, nil
+	}
+
+	items, err := uc.activity.Find(ctx, recipient.watches, period.From, period.To)
+	if err != nil {
+		uc.releaseDelivery(ctx, userID, period)
+		return false, errors.Wrap(err, "sendDigest error")
+	}
+	if len(items) == 0 {
+		return false, nil
+	}
+
+	name := recipient.user.Profile().DisplayName
+	if name == "" {
+		name = recipient.user.Name()
+	}
+	unsubscribeURL := uc.baseURL + DigestUnsubscribePath + "?token=" + url.QueryEscape(recipient.subscription.UnsubscribeToken)
+
+	subject, body, err := uc.renderer.Render(recipient.subscription.Locale, digest.Data{
+		Name:           name,
+		Frequency:      frequency,
+		From:           period.From,
+		To:             period.To,
+		Items:          items,
+		UnsubscribeURL: unsubscribeURL,
+	})
+	if err != nil {
+		uc.releaseDelivery(ctx, userID, period)
+		return false, errors.Wrap(err, "sendDigest error")
+	}
+
+	if err := uc.mailer.Send(mail.Message{
+		To:      recipient.user.Email(),
+		Subject: subject,
+		Body:    body,
+		Headers: map[string]string{
+			// RFC 8058のワンクリック配信停止
+			"List-Unsubscribe":      "<" + unsubscribeURL + ">",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		},
+	}); err != nil {
+		uc.releaseDelivery(ctx, userID, period)
+		return false, errors.Wrap(err, "sendDigest error")
+	}
+
+	return true, nil
+}
+
+// releaseDelivery 送信記録を取り消す(失敗してもログ出力のみ)
+func (uc *digestUseCase) releaseDelivery(ctx context.Context, userID string, period model.DigestPeriod) {
+	if _, err := dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) (any, error) {
+			return nil, uc.service(tx).ReleaseDelivery(userID, period)
+		},
+	); err != nil {
+		slog.Error("release digest delivery error", "user_id", userID, "period", period.Key, "error", err)
+	}
+}
+
+// service トランザクションに紐づくウォッチ・ダイジェストメールサービスを生成する
+func (uc *digestUseCase) service(tx *sql.Tx) service.Digest {
+	return uc.digestServiceFactory.NewDigestService(dao.NewDigestDAO(tx))
+}
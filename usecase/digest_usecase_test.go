@@ -0,0 +1,362 @@
+package usecase
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/dto"
+	"GoBBS/interface/digest"
+	"GoBBS/interface/mail"
+	"GoBBS/mock/mock_digest"
+	"GoBBS/mock/mock_mail"
+	"GoBBS/mock/mock_service"
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+)
+
+// testDBTxs 複数のトランザクションを順に実行するsqlmockのDBを返す(trueはコミット、falseはロールバック)
+func testDBTxs(t *testing.T, commits ...bool) *sql.DB {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmockの生成に失敗(error: %v)", err)
+	}
+	for _, commit := range commits {
+		mock.ExpectBegin()
+		if commit {
+			mock.ExpectCommit()
+		} else {
+			mock.ExpectRollback()
+		}
+	}
+	t.Cleanup(func() {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("予期せぬDB操作(error: %s)", err)
+		}
+	})
+	return db
+}
+
+// digestFactory ウォッチ・ダイジェストメールサービスを返すファクトリーのモックを生成する
+func digestFactory(ctrl *gomock.Controller, svc *mock_service.MockDigest) *mock_service.MockDigestFactory {
+	mock := mock_service.NewMockDigestFactory(ctrl)
+	mock.EXPECT().NewDigestService(gomock.Any()).Return(svc).AnyTimes()
+	return mock
+}
+
+func TestNewDigestUseCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db := &sql.DB{}
+	df := mock_service.NewMockDigestFactory(ctrl)
+	uf := mock_service.NewMockUserFactory(ctrl)
+	activity := mock_digest.NewMockActivitySource(ctrl)
+	renderer := mock_digest.NewMockRenderer(ctrl)
+	mailer := mock_mail.NewMockMailer(ctrl)
+
+	want := &digestUseCase{
+		db:                   db,
+		digestServiceFactory: df,
+		userServiceFactory:   uf,
+		activity:             activity,
+		renderer:             renderer,
+		mailer:               mailer,
+		baseURL:              "https://example.com",
+	}
+	if got := NewDigestUseCase(db, df, uf, activity, renderer, mailer, "https://example.com"); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewDigestUseCase() = %v, want %v", got, want)
+	}
+}
+
+func Test_digestUseCase_Watch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for _, wantErr := range []error{nil, errTest} {
+		svc := mock_service.NewMockDigest(ctrl)
+		svc.EXPECT().Watch("1", model.WatchThread, "10", now).Return(wantErr)
+		uc := &digestUseCase{db: testDBTxs(t, wantErr == nil), digestServiceFactory: digestFactory(ctrl, svc)}
+
+		if err := uc.Watch(context.Background(), "1", "thread", "10", now); !errors.Is(err, wantErr) {
+			t.Errorf("digestUseCase.Watch() error = %v, wantErr %v", err, wantErr)
+		}
+	}
+}
+
+func Test_digestUseCase_Unwatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	for _, wantErr := range []error{nil, errTest} {
+		svc := mock_service.NewMockDigest(ctrl)
+		svc.EXPECT().Unwatch("1", model.WatchBoard, "2").Return(wantErr)
+		uc := &digestUseCase{db: testDBTxs(t, wantErr == nil), digestServiceFactory: digestFactory(ctrl, svc)}
+
+		if err := uc.Unwatch(context.Background(), "1", "board", "2"); !errors.Is(err, wantErr) {
+			t.Errorf("digestUseCase.Unwatch() error = %v, wantErr %v", err, wantErr)
+		}
+	}
+}
+
+func Test_digestUseCase_Watches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	svc := mock_service.NewMockDigest(ctrl)
+	gomock.InOrder(
+		svc.EXPECT().Watches("1").Return([]model.Watch{model.NewWatch("1", model.WatchThread, "10", now)}, nil),
+		svc.EXPECT().Watches("1").Return(nil, errTest),
+	)
+	uc := &digestUseCase{db: testDBTxs(t, true, false), digestServiceFactory: digestFactory(ctrl, svc)}
+
+	got, err := uc.Watches(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	want := []*dto.Watch{{Kind: "thread", TargetID: "10", CreatedAt: now}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("digestUseCase.Watches() = %v, want %v", got, want)
+	}
+
+	if _, err := uc.Watches(context.Background(), "1"); !errors.Is(err, errTest) {
+		t.Errorf("digestUseCase.Watches() error = %v, wantErr %v", err, errTest)
+	}
+}
+
+func Test_digestUseCase_Subscription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	svc := mock_service.NewMockDigest(ctrl)
+	gomock.InOrder(
+		svc.EXPECT().Subscription("1").Return(model.DigestSubscription{Frequency: model.DigestDaily, Locale: "ja", UnsubscribeToken: "token"}, nil),
+		svc.EXPECT().UpdateSubscription("1", model.DigestWeekly, "en", now).Return(model.DigestSubscription{Frequency: model.DigestWeekly, Locale: "en", UnsubscribeToken: "token"}, nil),
+		svc.EXPECT().Subscription("1").Return(model.DigestSubscription{}, errTest),
+		svc.EXPECT().UpdateSubscription("1", model.DigestFrequency("monthly"), "en", now).Return(model.DigestSubscription{}, errTest),
+	)
+	uc := &digestUseCase{db: testDBTxs(t, true, true, false, false), digestServiceFactory: digestFactory(ctrl, svc)}
+
+	got, err := uc.Subscription(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	if want := (&dto.DigestSubscription{Frequency: "daily", Locale: "ja"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("digestUseCase.Subscription() = %v, want %v", got, want)
+	}
+
+	got, err = uc.UpdateSubscription(context.Background(), "1", &dto.DigestSubscription{Frequency: "weekly", Locale: "en"}, now)
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	if want := (&dto.DigestSubscription{Frequency: "weekly", Locale: "en"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("digestUseCase.UpdateSubscription() = %v, want %v", got, want)
+	}
+
+	if _, err := uc.Subscription(context.Background(), "1"); !errors.Is(err, errTest) {
+		t.Errorf("digestUseCase.Subscription() error = %v, wantErr %v", err, errTest)
+	}
+	if _, err := uc.UpdateSubscription(context.Background(), "1", &dto.DigestSubscription{Frequency: "monthly", Locale: "en"}, now); !errors.Is(err, errTest) {
+		t.Errorf("digestUseCase.UpdateSubscription() error = %v, wantErr %v", err, errTest)
+	}
+}
+
+func Test_digestUseCase_Unsubscribe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for _, wantErr := range []error{nil, errTest} {
+		svc := mock_service.NewMockDigest(ctrl)
+		svc.EXPECT().Unsubscribe("token", now).Return(wantErr)
+		uc := &digestUseCase{db: testDBTxs(t, wantErr == nil), digestServiceFactory: digestFactory(ctrl, svc)}
+
+		if err := uc.Unsubscribe(context.Background(), "token", now); !errors.Is(err, wantErr) {
+			t.Errorf("digestUseCase.Unsubscribe() error = %v, wantErr %v", err, wantErr)
+		}
+	}
+}
+
+func Test_digestUseCase_SendDigests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2023, 1, 4, 7, 0, 0, 0, time.UTC)
+	daily, _ := model.NewDigestPeriod(model.DigestDaily, now)
+	weekly, _ := model.NewDigestPeriod(model.DigestWeekly, now)
+	user := model.NewUserWithProfile("1", "taro", "taro@example.com", "", "", model.Profile{DisplayName: "Taro"})
+	subscription := model.DigestSubscription{Frequency: model.DigestDaily, Locale: "en", UnsubscribeToken: "t/1"}
+	watches := []model.Watch{model.NewWatch("1", model.WatchThread, "10", now)}
+	items := []digest.Item{{Kind: model.WatchThread, TargetID: "10", Title: "title", NewPosts: 2, URL: "https://example.com/threads/10"}}
+	data := digest.Data{
+		Name:           "Taro",
+		Frequency:      model.DigestDaily,
+		From:           daily.From,
+		To:             daily.To,
+		Items:          items,
+		UnsubscribeURL: "https://example.com/digest/unsubscribe?token=t%2F1",
+	}
+	message := mail.Message{
+		To:      "taro@example.com",
+		Subject: "subject",
+		Body:    "body",
+		Headers: map[string]string{
+			"List-Unsubscribe":      "<https://example.com/digest/unsubscribe?token=t%2F1>",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		},
+	}
+
+	// userFactory ユーザーを返すユーザーサービスファクトリーのモックを生成する
+	userFactory := func() *mock_service.MockUserFactory {
+		svc := mock_service.NewMockUser(ctrl)
+		svc.EXPECT().FindByID("1").Return(user, nil)
+		mock := mock_service.NewMockUserFactory(ctrl)
+		mock.EXPECT().NewUserService(gomock.Any()).Return(svc)
+		return mock
+	}
+
+	tests := []struct {
+		name    string
+		uc      func() *digestUseCase
+		want    int
+		wantErr error
+	}{
+		{
+			name: "正常ケース(送信済み・新着なしは送信しない)",
+			uc: func() *digestUseCase {
+				svc := mock_service.NewMockDigest(ctrl)
+				gomock.InOrder(
+					svc.EXPECT().DueUserIDs(model.DigestDaily, daily).Return([]string{"1", "2", "3"}, nil),
+					svc.EXPECT().ClaimDelivery("1", daily, now).Return(true, nil),
+					svc.EXPECT().Subscription("1").Return(subscription, nil),
+					svc.EXPECT().Watches("1").Return(watches, nil),
+					svc.EXPECT().ClaimDelivery("2", daily, now).Return(false, nil),
+					svc.EXPECT().ClaimDelivery("3", daily, now).Return(true, nil),
+					svc.EXPECT().Subscription("3").Return(subscription, nil),
+					svc.EXPECT().Watches("3").Return(nil, nil),
+					svc.EXPECT().DueUserIDs(model.DigestWeekly, weekly).Return(nil, nil),
+				)
+
+				userSvc := mock_service.NewMockUser(ctrl)
+				userSvc.EXPECT().FindByID("1").Return(user, nil)
+				userSvc.EXPECT().FindByID("3").Return(model.NewUser("3", "jiro", "jiro@example.com", "", ""), nil)
+				uf := mock_service.NewMockUserFactory(ctrl)
+				uf.EXPECT().NewUserService(gomock.Any()).Return(userSvc).Times(2)
+
+				activity := mock_digest.NewMockActivitySource(ctrl)
+				activity.EXPECT().Find(gomock.Any(), watches, daily.From, daily.To).Return(items, nil)
+				activity.EXPECT().Find(gomock.Any(), nil, daily.From, daily.To).Return(nil, nil)
+
+				renderer := mock_digest.NewMockRenderer(ctrl)
+				renderer.EXPECT().Render("en", data).Return("subject", "body", nil)
+
+				mailer := mock_mail.NewMockMailer(ctrl)
+				mailer.EXPECT().Send(message).Return(nil)
+
+				return &digestUseCase{
+					db:                   testDBTxs(t, true, true, true, true, true),
+					digestServiceFactory: digestFactory(ctrl, svc),
+					userServiceFactory:   uf,
+					activity:             activity,
+					renderer:             renderer,
+					mailer:               mailer,
+					baseURL:              "https://example.com",
+				}
+			},
+			want:    1,
+			wantErr: nil,
+		},
+		{
+			name: "正常ケース(送信失敗時は送信記録を取り消して続行)",
+			uc: func() *digestUseCase {
+				svc := mock_service.NewMockDigest(ctrl)
+				gomock.InOrder(
+					svc.EXPECT().DueUserIDs(model.DigestDaily, daily).Return([]string{"1"}, nil),
+					svc.EXPECT().ClaimDelivery("1", daily, now).Return(true, nil),
+					svc.EXPECT().Subscription("1").Return(subscription, nil),
+					svc.EXPECT().Watches("1").Return(watches, nil),
+					svc.EXPECT().ReleaseDelivery("1", daily).Return(nil),
+					svc.EXPECT().DueUserIDs(model.DigestWeekly, weekly).Return(nil, nil),
+				)
+
+				activity := mock_digest.NewMockActivitySource(ctrl)
+				activity.EXPECT().Find(gomock.Any(), watches, daily.From, daily.To).Return(items, nil)
+
+				renderer := mock_digest.NewMockRenderer(ctrl)
+				renderer.EXPECT().Render("en", data).Return("subject", "body", nil)
+
+				mailer := mock_mail.NewMockMailer(ctrl)
+				mailer.EXPECT().Send(message).Return(errTest)
+
+				return &digestUseCase{
+					db:                   testDBTxs(t, true, true, true, true),
+					digestServiceFactory: digestFactory(ctrl, svc),
+					userServiceFactory:   userFactory(),
+					activity:             activity,
+					renderer:             renderer,
+					mailer:               mailer,
+					baseURL:              "https://example.com",
+				}
+			},
+			want:    0,
+			wantErr: nil,
+		},
+		{
+			name: "正常ケース(配信頻度が変更されていれば送信しない)",
+			uc: func() *digestUseCase {
+				svc := mock_service.NewMockDigest(ctrl)
+				gomock.InOrder(
+					svc.EXPECT().DueUserIDs(model.DigestDaily, daily).Return([]string{"1"}, nil),
+					svc.EXPECT().ClaimDelivery("1", daily, now).Return(true, nil),
+					svc.EXPECT().Subscription("1").Return(model.DigestSubscription{Frequency: model.DigestOff}, nil),
+					svc.EXPECT().Watches("1").Return(watches, nil),
+					svc.EXPECT().DueUserIDs(model.DigestWeekly, weekly).Return(nil, nil),
+				)
+
+				return &digestUseCase{
+					db:                   testDBTxs(t, true, true, true),
+					digestServiceFactory: digestFactory(ctrl, svc),
+					userServiceFactory:   userFactory(),
+				}
+			},
+			want:    0,
+			wantErr: nil,
+		},
+		{
+			name: "異常ケース(送信対象の取得失敗)",
+			uc: func() *digestUseCase {
+				svc := mock_service.NewMockDigest(ctrl)
+				svc.EXPECT().DueUserIDs(model.DigestDaily, daily).Return(nil, errTest)
+
+				return &digestUseCase{
+					db:                   testDBTxs(t, false),
+					digestServiceFactory: digestFactory(ctrl, svc),
+				}
+			},
+			want:    0,
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.uc().SendDigests(context.Background(), now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("digestUseCase.SendDigests() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("digestUseCase.SendDigests() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/service"
+	"GoBBS/interface/dao"
+)
+
+// EventHandler ドメインイベントを処理するハンドラー
+// 処理の失敗やプロセスの停止で同じイベントが複数回渡されることがあるため、冪等にする
+type EventHandler func(ctx context.Context, event model.Event) error
+
+// Event ドメインイベントの配信ユースケース
+// mockgen -source usecase/event_usecase.go -destination mock/mock_usecase/event_usecase_mock.go
+type Event interface {
+	Subscribe(eventType model.EventType, handler EventHandler)
+	DispatchDue(ctx context.Context, now time.Time) (int, error)
+}
+
+type eventUseCase struct {
+	db                  *sql.DB
+	eventServiceFactory service.EventFactory
+	mu                  sync.RWMutex
+	handlers            map[model.EventType][]EventHandler
+}
+
+var _ Event = (*eventUseCase)(nil)
+
+// eventBatchSize 1回の配信処理で配信するイベントの上限
+const eventBatchSize = 100
+
+// NewEventUseCase ドメインイベントの配信ユースケースを生成する
+func NewEventUseCase(db *sql.DB, f service.EventFactory) *eventUseCase {
+	return &eventUseCase{
+		db:                  db,
+		eventServiceFactory: f,
+		handlers:            make(map[model.EventType][]EventHandler),
+	}
+}
+
+// Subscribe イベントの種類にハンドラーを登録する
+func (uc *eventUseCase) Subscribe(eventType model.EventType, handler EventHandler) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	uc.handlers[eventType] = append(uc.handlers[eventType], handler)
+}
+
+// DispatchDue 配信時刻に達したイベントをハンドラーに渡し、配信済みにした件数を返す
+// ハンドラーはtxの外で呼び出し、全てのハンドラーが成功したイベントだけを配信済みにする(失敗したイベントは全てのハンドラーに再配信される)
+func (uc *eventUseCase) DispatchDue(ctx context.Context, now time.Time) (int, error) {
+	ctx, span := tracer.Start(ctx, "Event.DispatchDue")
+	defer span.End()
+
+	events, err := dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) ([]model.Event, error) {
+			return uc.service(tx).ClaimDue(now, eventBatchSize)
+		},
+	)
+	if err != nil {
+		return 0, errors.Wrap(err, "DispatchDue error")
+	}
+
+	dispatched := 0
+	for _, e := range events {
+		handleErr := uc.handle(ctx, e)
+		event, err := dao.ExecWithTx(
+			ctx,
+			uc.db,
+			func(tx *sql.Tx) (model.Event, error) {
+				return uc.service(tx).RecordResult(e, handleErr, now)
+			},
+		)
+		if err != nil {
+			// 1件の記録の失敗で他のイベントを止めない(記録できなかったイベントは取得時に先送りした時刻に再配信される)
+			slog.Error("record event result error", "event_id", e.ID(), "error", err)
+			continue
+		}
+		if event.IsDispatched() {
+			dispatched++
+		} else {
+			slog.Warn("handle event error", "event_id", e.ID(), "type", e.Type(), "error", handleErr)
+		}
+	}
+
+	return dispatched, nil
+}
+
+// handle イベントの種類に登録されたハンドラーを順に呼び出し、最初に失敗したハンドラーのエラーを返す
+func (uc *eventUseCase) handle(ctx context.Context, event model.Event) error {
+	uc.mu.RLock()
+	handlers := uc.handlers[event.Type()]
+	uc.mu.RUnlock()
+
+	for _, h := range handlers {
+		if err := h(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// service トランザクションに紐づくドメインイベントサービスを生成する
+func (uc *eventUseCase) service(tx *sql.Tx) service.Event {
+	return uc.eventServiceFactory.NewEventService(dao.NewEventDAO(tx))
+}
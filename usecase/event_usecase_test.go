@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/mock/mock_service"
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+// eventFactory ドメインイベントサービスを返すファクトリーのモックを生成する
+func eventFactory(ctrl *gomock.Controller, svc *mock_service.MockEvent) *mock_service.MockEventFactory {
+	mock := mock_service.NewMockEventFactory(ctrl)
+	mock.EXPECT().NewEventService(gomock.Any()).Return(svc).AnyTimes()
+	return mock
+}
+
+func TestNewEventUseCase(t *testing.T) {
+	db := &sql.DB{}
+	f := &mock_service.MockEventFactory{}
+	want := &eventUseCase{db: db, eventServiceFactory: f, handlers: map[model.EventType][]EventHandler{}}
+	if got := NewEventUseCase(db, f); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewEventUseCase() = %v, want %v", got, want)
+	}
+}
+
+func Test_eventUseCase_DispatchDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	handleErr := errors.New("handler error")
+	first := model.NewEvent("1", model.EventUserRegistered, "5", []byte("{}"), 0, now, "", now, time.Time{})
+	second := model.NewEvent("2", model.EventPostCreated, "6", []byte("{}"), 0, now, "", now, time.Time{})
+	third := model.NewEvent("3", model.EventPostHidden, "7", []byte("{}"), 0, now, "", now, time.Time{})
+	fourth := model.NewEvent("4", model.EventThreadCreated, "8", []byte("{}"), 0, now, "", now, time.Time{})
+	dispatched := func(e model.Event) model.Event {
+		return model.NewEvent(e.ID(), e.Type(), e.AggregateID(), e.Payload(), 1, now, "", now, now)
+	}
+	retrying := model.NewEvent("2", model.EventPostCreated, "6", []byte("{}"), 1, now.Add(time.Minute), "handler error", now, time.Time{})
+
+	tests := []struct {
+		name    string
+		uc      func() *eventUseCase
+		want    int
+		wantErr error
+	}{
+		{
+			name: "正常ケース(失敗したイベントと記録に失敗したイベントがあっても他のイベントを続ける)",
+			uc: func() *eventUseCase {
+				svc := mock_service.NewMockEvent(ctrl)
+				gomock.InOrder(
+					svc.EXPECT().ClaimDue(now, eventBatchSize).Return([]model.Event{first, second, third, fourth}, nil),
+					svc.EXPECT().RecordResult(first, nil, now).Return(dispatched(first), nil),
+					svc.EXPECT().RecordResult(second, handleErr, now).Return(retrying, nil),
+					svc.EXPECT().RecordResult(third, nil, now).Return(nil, errTest),
+					svc.EXPECT().RecordResult(fourth, nil, now).Return(dispatched(fourth), nil),
+				)
+				uc := NewEventUseCase(testDBTxs(t, true, true, true, false, true), eventFactory(ctrl, svc))
+				uc.Subscribe(model.EventUserRegistered, func(context.Context, model.Event) error { return nil })
+				uc.Subscribe(model.EventPostCreated, func(context.Context, model.Event) error { return nil })
+				uc.Subscribe(model.EventPostCreated, func(context.Context, model.Event) error { return handleErr })
+				uc.Subscribe(model.EventPostHidden, func(context.Context, model.Event) error { return nil })
+				return uc
+			},
+			want:    2,
+			wantErr: nil,
+		},
+		{
+			name: "正常ケース(配信待ちなし)",
+			uc: func() *eventUseCase {
+				svc := mock_service.NewMockEvent(ctrl)
+				svc.EXPECT().ClaimDue(now, eventBatchSize).Return([]model.Event{}, nil)
+				return NewEventUseCase(testDBTxs(t, true), eventFactory(ctrl, svc))
+			},
+			want:    0,
+			wantErr: nil,
+		},
+		{
+			name: "異常ケース(取得失敗)",
+			uc: func() *eventUseCase {
+				svc := mock_service.NewMockEvent(ctrl)
+				svc.EXPECT().ClaimDue(now, eventBatchSize).Return(nil, errTest)
+				return NewEventUseCase(testDBTxs(t, false), eventFactory(ctrl, svc))
+			},
+			want:    0,
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.uc().DispatchDue(context.Background(), now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_eventUseCase_handle(t *testing.T) {
+	event := model.NewEvent("1", model.EventPostCreated, "5", []byte("{}"), 0, time.Time{}, "", time.Time{}, time.Time{})
+	handleErr := errors.New("handler error")
+	calls := []string{}
+	uc := NewEventUseCase(nil, nil)
+	uc.Subscribe(model.EventPostCreated, func(ctx context.Context, e model.Event) error {
+		calls = append(calls, "first:"+e.ID())
+		return nil
+	})
+	uc.Subscribe(model.EventPostCreated, func(ctx context.Context, e model.Event) error {
+		calls = append(calls, "second:"+e.ID())
+		return handleErr
+	})
+	uc.Subscribe(model.EventPostCreated, func(ctx context.Context, e model.Event) error {
+		calls = append(calls, "third:"+e.ID())
+		return nil
+	})
+	uc.Subscribe(model.EventPostHidden, func(ctx context.Context, e model.Event) error {
+		calls = append(calls, "hidden:"+e.ID())
+		return nil
+	})
+
+	if err := uc.handle(context.Background(), event); !errors.Is(err, handleErr) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", err, handleErr)
+	}
+	if want := []string{"first:1", "second:1"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", calls, want)
+	}
+}
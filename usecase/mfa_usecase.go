@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"GoBBS/domain/service"
+	"GoBBS/dto"
+	"GoBBS/interface/dao"
+)
+
+// MFA 2要素認証ユースケース
+// mockgen -source usecase/mfa_usecase.go -destination mock/mock_usecase/mfa_usecase_mock.go
+type MFA interface {
+	Enroll(ctx context.Context, userID string, now time.Time) (*dto.MFAEnrollment, error)
+	Confirm(ctx context.Context, userID string, code string, now time.Time) (*dto.MFARecoveryCodes, error)
+	Disable(ctx context.Context, userID string, code string, now time.Time) error
+}
+
+type mfaUseCase struct {
+	db                 *sql.DB
+	userServiceFactory service.UserFactory
+	mfaServiceFactory  service.MFAFactory
+}
+
+var _ MFA = (*mfaUseCase)(nil)
+
+// NewMFAUseCase 2要素認証ユースケースを生成する
+func NewMFAUseCase(db *sql.DB, uf service.UserFactory, f service.MFAFactory) *mfaUseCase {
+	return &mfaUseCase{
+		db:                 db,
+		userServiceFactory: uf,
+		mfaServiceFactory:  f,
+	}
+}
+
+// Enroll TOTPの秘密鍵を生成し、認証アプリに登録するotpauth URIを返す
+func (uc *mfaUseCase) Enroll(ctx context.Context, userID string, now time.Time) (*dto.MFAEnrollment, error) {
+	ctx, span := tracer.Start(ctx, "MFA.Enroll")
+	defer span.End()
+
+	return dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) (*dto.MFAEnrollment, error) {
+			user, err := uc.userServiceFactory.NewUserService(dao.NewUserDAO(tx)).FindByID(userID)
+			if err != nil {
+				return nil, err
+			}
+			mfa, err := uc.service(tx).Enroll(userID, now)
+			if err != nil {
+				return nil, err
+			}
+			return dto.NewMFAEnrollment(mfa.Secret(), mfa.URI(service.MFAIssuer, user.Email())), nil
+		},
+	)
+}
+
+// Confirm 最初の確認コードで2要素認証を有効にし、リカバリーコードを返す
+func (uc *mfaUseCase) Confirm(ctx context.Context, userID string, code string, now time.Time) (*dto.MFARecoveryCodes, error) {
+	ctx, span := tracer.Start(ctx, "MFA.Confirm")
+	defer span.End()
+
+	return dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) (*dto.MFARecoveryCodes, error) {
+			codes, err := uc.service(tx).Confirm(userID, code, now)
+			if err != nil {
+				return nil, err
+			}
+			return dto.NewMFARecoveryCodes(codes), nil
+		},
+	)
+}
+
+// Disable 確認コードかリカバリーコードで2要素認証を無効にする
+func (uc *mfaUseCase) Disable(ctx context.Context, userID string, code string, now time.Time) error {
+	ctx, span := tracer.Start(ctx, "MFA.Disable")
+	defer span.End()
+
+	_, err := dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) (any, error) {
+			return nil, uc.service(tx).Disable(userID, code, now)
+		},
+	)
+
+	return err
+}
+
+// service トランザクションに紐づく2要素認証サービスを生成する
+func (uc *mfaUseCase) service(tx *sql.Tx) service.MFA {
+	return uc.mfaServiceFactory.NewMFAService(dao.NewMFADAO(tx))
+}
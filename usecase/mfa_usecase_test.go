@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/domain/service"
+	"GoBBS/dto"
+	"GoBBS/mock/mock_service"
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+// mfaFactory 2要素認証サービスを返すファクトリーのモックを生成する
+func mfaFactory(ctrl *gomock.Controller, svc *mock_service.MockMFA) *mock_service.MockMFAFactory {
+	mock := mock_service.NewMockMFAFactory(ctrl)
+	mock.EXPECT().NewMFAService(gomock.Any()).Return(svc)
+	return mock
+}
+
+func TestNewMFAUseCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db := &sql.DB{}
+	uf := mock_service.NewMockUserFactory(ctrl)
+	f := mock_service.NewMockMFAFactory(ctrl)
+
+	want := &mfaUseCase{db: db, userServiceFactory: uf, mfaServiceFactory: f}
+	if got := NewMFAUseCase(db, uf, f); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewMFAUseCase() = %v, want %v", got, want)
+	}
+}
+
+func Test_mfaUseCase_Enroll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	// userFactory ユーザーを返すユーザーサービスのファクトリーを生成する
+	userFactory := func(user model.User, err error) *mock_service.MockUserFactory {
+		svc := mock_service.NewMockUser(ctrl)
+		svc.EXPECT().FindByID("1").Return(user, err)
+		mock := mock_service.NewMockUserFactory(ctrl)
+		mock.EXPECT().NewUserService(gomock.Any()).Return(svc)
+		return mock
+	}
+	user := model.NewUser("1", "name", "alice@example.com", "", "")
+
+	tests := []struct {
+		name    string
+		uc      *mfaUseCase
+		want    *dto.MFAEnrollment
+		wantErr error
+	}{
+		{
+			name: "正常ケース",
+			uc: &mfaUseCase{
+				db:                 testDB(t, true),
+				userServiceFactory: userFactory(user, nil),
+				mfaServiceFactory: func() *mock_service.MockMFAFactory {
+					svc := mock_service.NewMockMFA(ctrl)
+					svc.EXPECT().Enroll("1", now).Return(model.NewMFA("1", "JBSWY3DPEHPK3PXP", time.Time{}, 0, now), nil)
+					return mfaFactory(ctrl, svc)
+				}(),
+			},
+			want: &dto.MFAEnrollment{
+				Secret:     "JBSWY3DPEHPK3PXP",
+				OTPAuthURI: "otpauth://totp/GoBBS:alice@example.com?algorithm=SHA1&digits=6&issuer=GoBBS&period=30&secret=JBSWY3DPEHPK3PXP",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "異常ケース(ユーザー取得エラー)",
+			uc: &mfaUseCase{
+				db:                 testDB(t, false),
+				userServiceFactory: userFactory(nil, service.ErrUserNotFound),
+			},
+			want:    nil,
+			wantErr: service.ErrUserNotFound,
+		},
+		{
+			name: "異常ケース(有効化済み)",
+			uc: &mfaUseCase{
+				db:                 testDB(t, false),
+				userServiceFactory: userFactory(user, nil),
+				mfaServiceFactory: func() *mock_service.MockMFAFactory {
+					svc := mock_service.NewMockMFA(ctrl)
+					svc.EXPECT().Enroll("1", now).Return(nil, service.ErrMFAAlreadyEnabled)
+					return mfaFactory(ctrl, svc)
+				}(),
+			},
+			want:    nil,
+			wantErr: service.ErrMFAAlreadyEnabled,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.uc.Enroll(context.Background(), "1", now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_mfaUseCase_Confirm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		uc      *mfaUseCase
+		want    *dto.MFARecoveryCodes
+		wantErr error
+	}{
+		{
+			name: "正常ケース",
+			uc: &mfaUseCase{
+				db: testDB(t, true),
+				mfaServiceFactory: func() *mock_service.MockMFAFactory {
+					svc := mock_service.NewMockMFA(ctrl)
+					svc.EXPECT().Confirm("1", "123456", now).Return([]string{"0123a-4567b"}, nil)
+					return mfaFactory(ctrl, svc)
+				}(),
+			},
+			want:    &dto.MFARecoveryCodes{RecoveryCodes: []string{"0123a-4567b"}},
+			wantErr: nil,
+		},
+		{
+			name: "異常ケース",
+			uc: &mfaUseCase{
+				db: testDB(t, false),
+				mfaServiceFactory: func() *mock_service.MockMFAFactory {
+					svc := mock_service.NewMockMFA(ctrl)
+					svc.EXPECT().Confirm("1", "123456", now).Return(nil, service.ErrInvalidMFACode)
+					return mfaFactory(ctrl, svc)
+				}(),
+			},
+			want:    nil,
+			wantErr: service.ErrInvalidMFACode,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.uc.Confirm(context.Background(), "1", "123456", now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_mfaUseCase_Disable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		uc      *mfaUseCase
+		wantErr error
+	}{
+		{
+			name: "正常ケース",
+			uc: &mfaUseCase{
+				db: testDB(t, true),
+				mfaServiceFactory: func() *mock_service.MockMFAFactory {
+					svc := mock_service.NewMockMFA(ctrl)
+					svc.EXPECT().Disable("1", "123456", now).Return(nil)
+					return mfaFactory(ctrl, svc)
+				}(),
+			},
+			wantErr: nil,
+		},
+		{
+			name: "異常ケース",
+			uc: &mfaUseCase{
+				db: testDB(t, false),
+				mfaServiceFactory: func() *mock_service.MockMFAFactory {
+					svc := mock_service.NewMockMFA(ctrl)
+					svc.EXPECT().Disable("1", "123456", now).Return(service.ErrMFANotEnrolled)
+					return mfaFactory(ctrl, svc)
+				}(),
+			},
+			wantErr: service.ErrMFANotEnrolled,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.uc.Disable(context.Background(), "1", "123456", now); !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/service"
+	"GoBBS/dto"
+	"GoBBS/interface/dao"
+)
+
+// Notification 通知ユースケース
+// mockgen -source usecase/notification_usecase.go -destination mock/mock_usecase/notification_usecase_mock.go
+type Notification interface {
+	List(ctx context.Context, userID string, beforeID string, limit int) (*dto.NotificationList, error)
+	MarkRead(ctx context.Context, userID string, id string, now time.Time) error
+	MarkAllRead(ctx context.Context, userID string, now time.Time) error
+	Preferences(ctx context.Context, userID string) (*dto.NotificationPreferences, error)
+	UpdatePreferences(ctx context.Context, userID string, preferences *dto.NotificationPreferences, now time.Time) error
+}
+
+type notificationUseCase struct {
+	db                         *sql.DB
+	notificationServiceFactory service.NotificationFactory
+}
+
+var _ Notification = (*notificationUseCase)(nil)
+
+// NewNotificationUseCase 通知ユースケースを生成する
+func NewNotificationUseCase(db *sql.DB, f service.NotificationFactory) *notificationUseCase {
+	return &notificationUseCase{
+		db:                         db,
+		notificationServiceFactory: f,
+	}
+}
+
+// List 通知一覧と未読数を取得する
+func (uc *notificationUseCase) List(ctx context.Context, userID string, beforeID string, limit int) (*dto.NotificationList, error) {
+	ctx, span := tracer.Start(ctx, "Notification.List")
+	defer span.End()
+
+	return dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) (*dto.NotificationList, error) {
+			notifications, unread, err := uc.service(tx).List(userID, beforeID, limit)
+			if err != nil {
+				return nil, err
+			}
+			return dto.NewNotificationList(notifications, unread), nil
+		},
+	)
+}
+
+// MarkRead 通知を既読にする
+func (uc *notificationUseCase) MarkRead(ctx context.Context, userID string, id string, now time.Time) error {
+	ctx, span := tracer.Start(ctx, "Notification.MarkRead")
+	defer span.End()
+
+	_, err := dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) (any, error) {
+			return nil, uc.service(tx).MarkRead(userID, id, now)
+		},
+	)
+
+	return err
+}
+
+// MarkAllRead 未読の通知をすべて既読にする
+func (uc *notificationUseCase) MarkAllRead(ctx context.Context, userID string, now time.Time) error {
+	ctx, span := tracer.Start(ctx, "Notification.MarkAllRead")
+	defer span.End()
+
+	_, err := dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) (any, error) {
+			return nil, uc.service(tx).MarkAllRead(userID, now)
+		},
+	)
+
+	return err
+}
+
+// Preferences 通知の受信設定を取得する
+func (uc *notificationUseCase) Preferences(ctx context.Context, userID string) (*dto.NotificationPreferences, error) {
+	ctx, span := tracer.Start(ctx, "Notification.Preferences")
+	defer span.End()
+
+	preferences, err := dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) (model.NotificationPreferences, error) {
+			return uc.service(tx).Preferences(userID)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.NewNotificationPreferences(preferences), nil
+}
+
+// UpdatePreferences 通知の受信設定を更新する
+func (uc *notificationUseCase) UpdatePreferences(ctx context.Context, userID string, preferences *dto.NotificationPreferences, now time.Time) error {
+	ctx, span := tracer.Start(ctx, "Notification.UpdatePreferences")
+	defer span.End()
+
+	_, err := dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) (any, error) {
+			return nil, uc.service(tx).UpdatePreferences(userID, preferences.MapNotificationPreferencesModel(), now)
+		},
+	)
+
+	return err
+}
+
+// service トランザクションに紐づく通知サービスを生成する
+func (uc *notificationUseCase) service(tx *sql.Tx) service.Notification {
+	return uc.notificationServiceFactory.NewNotificationService(dao.NewNotificationDAO(tx))
+}
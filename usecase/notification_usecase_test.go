@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/dto"
+	"GoBBS/mock/mock_service"
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+// notificationFactory 通知サービスを返すファクトリーのモックを生成する
+func notificationFactory(ctrl *gomock.Controller, svc *mock_service.MockNotification) *mock_service.MockNotificationFactory {
+	mock := mock_service.NewMockNotificationFactory(ctrl)
+	mock.EXPECT().NewNotificationService(gomock.Any()).Return(svc)
+	return mock
+}
+
+func TestNewNotificationUseCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db := &sql.DB{}
+	f := mock_service.NewMockNotificationFactory(ctrl)
+
+	want := &notificationUseCase{db: db, notificationServiceFactory: f}
+	if got := NewNotificationUseCase(db, f); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewNotificationUseCase() = %v, want %v", got, want)
+	}
+}
+
+func Test_notificationUseCase_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		uc      *notificationUseCase
+		want    *dto.NotificationList
+		wantErr error
+	}{
+		{
+			name: "正常ケース",
+			uc: &notificationUseCase{
+				db: testDB(t, true),
+				notificationServiceFactory: func() *mock_service.MockNotificationFactory {
+					svc := mock_service.NewMockNotification(ctrl)
+					svc.EXPECT().List("1", "", 20).Return([]model.Notification{
+						model.NewNotification("1", "1", model.NotificationReply, "2", "10", time.Time{}, now),
+					}, 1, nil)
+					return notificationFactory(ctrl, svc)
+				}(),
+			},
+			want: &dto.NotificationList{
+				Notifications: []*dto.Notification{
+					{ID: "1", Kind: "reply", ActorID: "2", TargetID: "10", CreatedAt: now},
+				},
+				UnreadCount: 1,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "異常ケース",
+			uc: &notificationUseCase{
+				db: testDB(t, false),
+				notificationServiceFactory: func() *mock_service.MockNotificationFactory {
+					svc := mock_service.NewMockNotification(ctrl)
+					svc.EXPECT().List("1", "", 20).Return(nil, 0, errTest)
+					return notificationFactory(ctrl, svc)
+				}(),
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.uc.List(context.Background(), "1", "", 20)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("notificationUseCase.List() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("notificationUseCase.List() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_notificationUseCase_MarkRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for _, wantErr := range []error{nil, errTest} {
+		svc := mock_service.NewMockNotification(ctrl)
+		svc.EXPECT().MarkRead("1", "5", now).Return(wantErr)
+		uc := &notificationUseCase{db: testDB(t, wantErr == nil), notificationServiceFactory: notificationFactory(ctrl, svc)}
+
+		if err := uc.MarkRead(context.Background(), "1", "5", now); !errors.Is(err, wantErr) {
+			t.Errorf("notificationUseCase.MarkRead() error = %v, wantErr %v", err, wantErr)
+		}
+	}
+}
+
+func Test_notificationUseCase_MarkAllRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for _, wantErr := range []error{nil, errTest} {
+		svc := mock_service.NewMockNotification(ctrl)
+		svc.EXPECT().MarkAllRead("1", now).Return(wantErr)
+		uc := &notificationUseCase{db: testDB(t, wantErr == nil), notificationServiceFactory: notificationFactory(ctrl, svc)}
+
+		if err := uc.MarkAllRead(context.Background(), "1", now); !errors.Is(err, wantErr) {
+			t.Errorf("notificationUseCase.MarkAllRead() error = %v, wantErr %v", err, wantErr)
+		}
+	}
+}
+
+func Test_notificationUseCase_Preferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mock_service.NewMockNotification(ctrl)
+	svc.EXPECT().Preferences("1").Return(model.NotificationPreferences{Reply: true, Mention: true}, nil)
+	uc := &notificationUseCase{db: testDB(t, true), notificationServiceFactory: notificationFactory(ctrl, svc)}
+
+	got, err := uc.Preferences(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	want := &dto.NotificationPreferences{Reply: true, Mention: true}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("notificationUseCase.Preferences() = %v, want %v", got, want)
+	}
+
+	svc = mock_service.NewMockNotification(ctrl)
+	svc.EXPECT().Preferences("1").Return(model.NotificationPreferences{}, errTest)
+	uc = &notificationUseCase{db: testDB(t, false), notificationServiceFactory: notificationFactory(ctrl, svc)}
+
+	if _, err := uc.Preferences(context.Background(), "1"); !errors.Is(err, errTest) {
+		t.Errorf("notificationUseCase.Preferences() error = %v, wantErr %v", err, errTest)
+	}
+}
+
+func Test_notificationUseCase_UpdatePreferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for _, wantErr := range []error{nil, errTest} {
+		svc := mock_service.NewMockNotification(ctrl)
+		svc.EXPECT().UpdatePreferences("1", model.NotificationPreferences{Quote: true}, now).Return(wantErr)
+		uc := &notificationUseCase{db: testDB(t, wantErr == nil), notificationServiceFactory: notificationFactory(ctrl, svc)}
+
+		if err := uc.UpdatePreferences(context.Background(), "1", &dto.NotificationPreferences{Quote: true}, now); !errors.Is(err, wantErr) {
+			t.Errorf("notificationUseCase.UpdatePreferences() error = %v, wantErr %v", err, wantErr)
+		}
+	}
+}
@@ -0,0 +1,412 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/service"
+	"GoBBS/dto"
+	"GoBBS/interface/dao"
+	"GoBBS/interface/markdown"
+	"GoBBS/interface/pubsub"
+	"GoBBS/interface/textdiff"
+)
+
+// Post スレッド・投稿ユースケース
+// mockgen -source usecase/post_usecase.go -destination mock/mock_usecase/post_usecase_mock.go
+type Post interface {
+	Threads(ctx context.Context, boardID string, beforeID string, limit int) ([]*dto.Thread, error)
+	CreateThread(ctx context.Context, userID string, boardID string, req *dto.ThreadRequest, now time.Time) (*dto.ThreadPosts, error)
+	Posts(ctx context.Context, threadID string, afterNumber int, limit int) (*dto.ThreadPosts, error)
+	Reply(ctx context.Context, userID string, threadID string, req *dto.PostRequest, now time.Time) (*dto.Post, error)
+	Edit(ctx context.Context, userID string, postID string, moderator bool, req *dto.PostRequest, now time.Time) (*dto.Post, error)
+	Revisions(ctx context.Context, postID string) ([]*dto.PostRevision, error)
+	Diff(ctx context.Context, postID string, fromID string, toID string) (*dto.PostDiff, error)
+	Hide(ctx context.Context, postID string, req *dto.PostHideRequest, now time.Time) (*dto.Post, error)
+}
+
+type postUseCase struct {
+	db                         *sql.DB
+	boardServiceFactory        service.BoardFactory
+	postServiceFactory         service.PostFactory
+	userServiceFactory         service.UserFactory
+	notificationServiceFactory service.NotificationFactory
+	eventServiceFactory        service.EventFactory
+	renderer                   markdown.Renderer
+	editWindow                 func() time.Duration
+	hub                        pubsub.Hub
+}
+
+// notificationRecipient 投稿を通知するユーザーと通知の種類
+type notificationRecipient struct {
+	userID string
+	kind   model.NotificationKind
+}
+
+// maxMentionNotifications 1つの投稿で通知する@メンションの上限
+const maxMentionNotifications = 10
+
+// postReply 返信した投稿と返信先のスレッド
+type postReply struct {
+	thread model.Thread
+	post   model.Post
+}
+
+var _ Post = (*postUseCase)(nil)
+
+// NewPostUseCase スレッド・投稿ユースケースを生成する
+// rendererは投稿の本文を保存時にHTMLへ変換し、通知する引用レスと@メンションを抽出するために使用する
+// editWindowは投稿者が編集できる期間を返す(設定の再読み込みに追従するため都度呼び出す)
+// スレッドの作成、投稿、非表示はドメインイベントとして同じtxで登録し、
+// hubにはコミットした投稿の作成、編集、非表示を配信する
+func NewPostUseCase(
+	db *sql.DB,
+	bf service.BoardFactory,
+	pf service.PostFactory,
+	uf service.UserFactory,
+	nf service.NotificationFactory,
+	ef service.EventFactory,
+	renderer markdown.Renderer,
+	editWindow func() time.Duration,
+	hub pubsub.Hub) *postUseCase {
+	return &postUseCase{
+		db:                         db,
+		boardServiceFactory:        bf,
+		postServiceFactory:         pf,
+		userServiceFactory:         uf,
+		notificationServiceFactory: nf,
+		eventServiceFactory:        ef,
+		renderer:                   renderer,
+		editWindow:                 editWindow,
+		hub:                        hub,
+	}
+}
+
+// Threads 板のスレッド一覧を取得する
+func (uc *postUseCase) Threads(ctx context.Context, boardID string, beforeID string, limit int) ([]*dto.Thread, error) {
+	ctx, span := tracer.Start(ctx, "Post.Threads")
+	defer span.End()
+
+	return dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) ([]*dto.Thread, error) {
+			if _, err := uc.boardService(tx).Find(boardID); err != nil {
+				return nil, err
+			}
+			threads, err := uc.service(tx).Threads(boardID, beforeID, limit)
+			if err != nil {
+				return nil, err
+			}
+			return dto.NewThreads(threads), nil
+		},
+	)
+}
+
+// CreateThread 板にスレッドを作成し、1番目の投稿でメンションされたユーザーに通知する
+func (uc *postUseCase) CreateThread(ctx context.Context, userID string, boardID string, req *dto.ThreadRequest, now time.Time) (*dto.ThreadPosts, error) {
+	ctx, span := tracer.Start(ctx, "Post.CreateThread")
+	defer span.End()
+
+	created, err := dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) (*dto.ThreadPosts, error) {
+			if _, err := uc.boardService(tx).Find(boardID); err != nil {
+				return nil, err
+			}
+			postService := uc.service(tx)
+			thread, post, err := postService.CreateThread(boardID, userID, req.Title, req.Body, now)
+			if err != nil {
+				return nil, err
+			}
+			if err := uc.notify(tx, postService, thread, post, now); err != nil {
+				return nil, err
+			}
+			if err := uc.eventService(tx).Emit(
+				model.EventThreadCreated,
+				thread.ID(),
+				model.ThreadCreatedData{BoardID: thread.BoardID(), ThreadID: thread.ID(), UserID: thread.UserID(), Title: thread.Title()},
+				now,
+			); err != nil {
+				return nil, err
+			}
+			return dto.NewThreadPosts(thread, []model.Post{post}), nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	uc.publish(ctx, pubsub.BoardTopic(boardID), pubsub.EventThreadCreated, created.Thread)
+
+	return created, nil
+}
+
+// Posts スレッドとafterNumberより後の投稿を取得する
+func (uc *postUseCase) Posts(ctx context.Context, threadID string, afterNumber int, limit int) (*dto.ThreadPosts, error) {
+	ctx, span := tracer.Start(ctx, "Post.Posts")
+	defer span.End()
+
+	return dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) (*dto.ThreadPosts, error) {
+			postService := uc.service(tx)
+			thread, err := postService.Thread(threadID)
+			if err != nil {
+				return nil, err
+			}
+			posts, err := postService.Posts(threadID, afterNumber, limit)
+			if err != nil {
+				return nil, err
+			}
+			return dto.NewThreadPosts(thread, posts), nil
+		},
+	)
+}
+
+// Reply スレッドに投稿し、スレッドの作成者、引用された投稿の投稿者、メンションされたユーザーに通知する
+func (uc *postUseCase) Reply(ctx context.Context, userID string, threadID string, req *dto.PostRequest, now time.Time) (*dto.Post, error) {
+	ctx, span := tracer.Start(ctx, "Post.Reply")
+	defer span.End()
+
+	replied, err := dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) (*postReply, error) {
+			postService := uc.service(tx)
+			post, err := postService.Reply(threadID, userID, req.Body, now)
+			if err != nil {
+				return nil, err
+			}
+			thread, err := postService.Thread(threadID)
+			if err != nil {
+				return nil, err
+			}
+			if err := uc.notify(tx, postService, thread, post, now); err != nil {
+				return nil, err
+			}
+			if err := uc.eventService(tx).Emit(
+				model.EventPostCreated,
+				post.ID(),
+				model.PostCreatedData{BoardID: thread.BoardID(), ThreadID: thread.ID(), PostID: post.ID(), UserID: post.UserID()},
+				now,
+			); err != nil {
+				return nil, err
+			}
+			return &postReply{thread: thread, post: post}, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	post := dto.NewPost(replied.post)
+	uc.publish(ctx, pubsub.ThreadTopic(threadID), pubsub.EventPostCreated, post)
+	uc.publish(ctx, pubsub.BoardTopic(replied.thread.BoardID()), pubsub.EventPostCreated, post)
+
+	return post, nil
+}
+
+// Edit 投稿を編集する
+// moderatorがtrueの場合は編集期間や投稿者に関わらず編集できる
+func (uc *postUseCase) Edit(ctx context.Context, userID string, postID string, moderator bool, req *dto.PostRequest, now time.Time) (*dto.Post, error) {
+	ctx, span := tracer.Start(ctx, "Post.Edit")
+	defer span.End()
+
+	post, err := dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) (*dto.Post, error) {
+			post, err := uc.service(tx).Edit(postID, userID, moderator, req.Body, uc.editWindow(), now)
+			if err != nil {
+				return nil, err
+			}
+			return dto.NewPost(post), nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	uc.publish(ctx, pubsub.ThreadTopic(post.ThreadID), pubsub.EventPostEdited, post)
+
+	return post, nil
+}
+
+// Revisions 投稿の版の一覧を古い順に取得する
+func (uc *postUseCase) Revisions(ctx context.Context, postID string) ([]*dto.PostRevision, error) {
+	ctx, span := tracer.Start(ctx, "Post.Revisions")
+	defer span.End()
+
+	return dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) ([]*dto.PostRevision, error) {
+			revisions, err := uc.service(tx).Revisions(postID)
+			if err != nil {
+				return nil, err
+			}
+			return dto.NewPostRevisions(revisions), nil
+		},
+	)
+}
+
+// Diff 投稿の2つの版の本文の差分を取得する
+func (uc *postUseCase) Diff(ctx context.Context, postID string, fromID string, toID string) (*dto.PostDiff, error) {
+	ctx, span := tracer.Start(ctx, "Post.Diff")
+	defer span.End()
+
+	return dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) (*dto.PostDiff, error) {
+			postService := uc.service(tx)
+			from, err := postService.Revision(postID, fromID)
+			if err != nil {
+				return nil, err
+			}
+			to, err := postService.Revision(postID, toID)
+			if err != nil {
+				return nil, err
+			}
+			lines, err := textdiff.Lines(from.Body(), to.Body())
+			if err != nil {
+				return nil, err
+			}
+
+			diff := &dto.PostDiff{From: from.ID(), To: to.ID(), Lines: make([]*dto.PostDiffLine, 0, len(lines))}
+			for _, l := range lines {
+				diff.Lines = append(diff.Lines, &dto.PostDiffLine{Op: l.Op.String(), Text: l.Text})
+			}
+			return diff, nil
+		},
+	)
+}
+
+// Hide モデレーターが投稿を非表示にする
+func (uc *postUseCase) Hide(ctx context.Context, postID string, req *dto.PostHideRequest, now time.Time) (*dto.Post, error) {
+	ctx, span := tracer.Start(ctx, "Post.Hide")
+	defer span.End()
+
+	post, err := dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) (*dto.Post, error) {
+			postService := uc.service(tx)
+			post, err := postService.Hide(postID, req.Reason, now)
+			if err != nil {
+				return nil, err
+			}
+			thread, err := postService.Thread(post.ThreadID())
+			if err != nil {
+				return nil, err
+			}
+			if err := uc.eventService(tx).Emit(
+				model.EventPostHidden,
+				post.ID(),
+				model.PostHiddenData{BoardID: thread.BoardID(), ThreadID: thread.ID(), PostID: post.ID(), Reason: post.HiddenReason()},
+				now,
+			); err != nil {
+				return nil, err
+			}
+			return dto.NewPost(post), nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	uc.publish(ctx, pubsub.ThreadTopic(post.ThreadID), pubsub.EventPostHidden, post)
+
+	return post, nil
+}
+
+// notify 投稿を通知する
+// 同じユーザーへの通知は1つの投稿につき1件とし、引用、@メンション、スレッドへの返信の順に優先する
+// 存在しない投稿番号の引用や、特定できない名前(未登録・同名のユーザーが複数)へのメンションは通知しない
+func (uc *postUseCase) notify(tx *sql.Tx, postService service.Post, thread model.Thread, post model.Post, now time.Time) error {
+	refs := uc.renderer.References(post.Body(), post.Number()-1)
+
+	recipients := []notificationRecipient{}
+	seen := map[string]bool{}
+	add := func(userID string, kind model.NotificationKind) {
+		if userID == "" || seen[userID] {
+			return
+		}
+		seen[userID] = true
+		recipients = append(recipients, notificationRecipient{userID: userID, kind: kind})
+	}
+
+	for _, number := range refs.Quotes {
+		quoted, err := postService.PostByNumber(thread.ID(), number)
+		if errors.Is(err, service.ErrPostNotFound) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		add(quoted.UserID(), model.NotificationQuote)
+	}
+
+	mentions := refs.Mentions
+	if len(mentions) > maxMentionNotifications {
+		mentions = mentions[:maxMentionNotifications]
+	}
+	if len(mentions) > 0 {
+		userService := uc.userServiceFactory.NewUserService(dao.NewUserDAO(tx))
+		for _, name := range mentions {
+			user, err := userService.FindByName(name)
+			if errors.Is(err, service.ErrUserNotFound) {
+				continue
+			} else if err != nil {
+				return err
+			}
+			add(user.ID(), model.NotificationMention)
+		}
+	}
+
+	if post.Number() > 1 {
+		add(thread.UserID(), model.NotificationReply)
+	}
+
+	if len(recipients) == 0 {
+		return nil
+	}
+	notificationService := uc.notificationServiceFactory.NewNotificationService(dao.NewNotificationDAO(tx))
+	for _, r := range recipients {
+		if _, err := notificationService.Notify(model.NewNotification("", r.userID, r.kind, post.UserID(), post.ID(), time.Time{}, now), now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// publish コミットした変更をハブに配信する
+// 配信は購読中のクライアントへの通知のため、失敗しても変更自体は成功として扱う
+func (uc *postUseCase) publish(ctx context.Context, topic string, eventType string, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		slog.ErrorContext(ctx, "publish error", "topic", topic, "type", eventType, "error", err)
+		return
+	}
+	uc.hub.Publish(topic, eventType, data)
+}
+
+// boardService トランザクションに紐づく板サービスを生成する
+func (uc *postUseCase) boardService(tx *sql.Tx) service.Board {
+	return uc.boardServiceFactory.NewBoardService(dao.NewBoardDAO(tx))
+}
+
+// eventService トランザクションに紐づくドメインイベントサービスを生成する
+func (uc *postUseCase) eventService(tx *sql.Tx) service.Event {
+	return uc.eventServiceFactory.NewEventService(dao.NewEventDAO(tx))
+}
+
+// service トランザクションに紐づくスレッド・投稿サービスを生成する
+func (uc *postUseCase) service(tx *sql.Tx) service.Post {
+	return uc.postServiceFactory.NewPostService(dao.NewThreadDAO(tx), dao.NewPostDAO(tx), dao.NewPostRevisionDAO(tx), uc.renderer)
+}
@@ -0,0 +1,877 @@
+package usecase
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/domain/service"
+	"GoBBS/dto"
+	"GoBBS/interface/markdown"
+	"GoBBS/interface/pubsub"
+	"GoBBS/interface/textdiff"
+	"GoBBS/mock/mock_markdown"
+	"GoBBS/mock/mock_pubsub"
+	"GoBBS/mock/mock_service"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+// postFactory スレッド・投稿サービスを返すファクトリーのモックを生成する
+func postFactory(ctrl *gomock.Controller, svc *mock_service.MockPost) *mock_service.MockPostFactory {
+	mock := mock_service.NewMockPostFactory(ctrl)
+	mock.EXPECT().NewPostService(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(svc)
+	return mock
+}
+
+// referencesRenderer 本文から抽出する参照を返すレンダラーのモックを生成する
+func referencesRenderer(ctrl *gomock.Controller, source string, lastNumber int, refs markdown.References) *mock_markdown.MockRenderer {
+	mock := mock_markdown.NewMockRenderer(ctrl)
+	mock.EXPECT().References(source, lastNumber).Return(refs)
+	return mock
+}
+
+// emitEvent 1件のドメインイベントの登録を期待するファクトリーのモックを生成する
+func emitEvent(ctrl *gomock.Controller, eventType model.EventType, aggregateID string, data any, now time.Time, err error) *mock_service.MockEventFactory {
+	svc := mock_service.NewMockEvent(ctrl)
+	svc.EXPECT().Emit(eventType, aggregateID, data, now).Return(err)
+	return eventFactory(ctrl, svc)
+}
+
+// publishHub 1件の配信を期待するハブのモックを生成する
+func publishHub(t *testing.T, ctrl *gomock.Controller, topic string, eventType string, v any) *mock_pubsub.MockHub {
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock := mock_pubsub.NewMockHub(ctrl)
+	mock.EXPECT().Publish(topic, eventType, data).Return(pubsub.Event{})
+	return mock
+}
+
+func TestNewPostUseCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db := &sql.DB{}
+	bf := mock_service.NewMockBoardFactory(ctrl)
+	pf := mock_service.NewMockPostFactory(ctrl)
+	uf := mock_service.NewMockUserFactory(ctrl)
+	nf := mock_service.NewMockNotificationFactory(ctrl)
+	ef := mock_service.NewMockEventFactory(ctrl)
+	r := mock_markdown.NewMockRenderer(ctrl)
+	hub := mock_pubsub.NewMockHub(ctrl)
+
+	got := NewPostUseCase(db, bf, pf, uf, nf, ef, r, func() time.Duration { return time.Minute }, hub)
+	if got.db != db || got.boardServiceFactory != bf || got.postServiceFactory != pf ||
+		got.userServiceFactory != uf || got.notificationServiceFactory != nf || got.eventServiceFactory != ef || got.renderer != r || got.hub != hub {
+		t.Errorf("NewPostUseCase() = %v", got)
+	}
+	if got.editWindow() != time.Minute {
+		t.Errorf("NewPostUseCase().editWindow() = %v, want %v", got.editWindow(), time.Minute)
+	}
+}
+
+func Test_postUseCase_Threads(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	board := model.NewBoard("1", "雑談", "", now)
+
+	tests := []struct {
+		name    string
+		uc      *postUseCase
+		want    []*dto.Thread
+		wantErr error
+	}{
+		{
+			name: "正常ケース",
+			uc: &postUseCase{
+				db: testDB(t, true),
+				boardServiceFactory: func() *mock_service.MockBoardFactory {
+					svc := mock_service.NewMockBoard(ctrl)
+					svc.EXPECT().Find("1").Return(board, nil)
+					return boardFactory(ctrl, svc)
+				}(),
+				postServiceFactory: func() *mock_service.MockPostFactory {
+					svc := mock_service.NewMockPost(ctrl)
+					svc.EXPECT().Threads("1", "10", 20).Return([]model.Thread{
+						model.NewThread("9", "1", "2", "タイトル", 3, now, now),
+					}, nil)
+					return postFactory(ctrl, svc)
+				}(),
+			},
+			want:    []*dto.Thread{{ID: "9", BoardID: "1", UserID: "2", Title: "タイトル", PostCount: 3, LastPostedAt: now, CreatedAt: now}},
+			wantErr: nil,
+		},
+		{
+			name: "異常ケース(板が存在しない)",
+			uc: &postUseCase{
+				db: testDB(t, false),
+				boardServiceFactory: func() *mock_service.MockBoardFactory {
+					svc := mock_service.NewMockBoard(ctrl)
+					svc.EXPECT().Find("1").Return(nil, service.ErrBoardNotFound)
+					return boardFactory(ctrl, svc)
+				}(),
+			},
+			want:    nil,
+			wantErr: service.ErrBoardNotFound,
+		},
+		{
+			name: "異常ケース(スレッド一覧の取得に失敗)",
+			uc: &postUseCase{
+				db: testDB(t, false),
+				boardServiceFactory: func() *mock_service.MockBoardFactory {
+					svc := mock_service.NewMockBoard(ctrl)
+					svc.EXPECT().Find("1").Return(board, nil)
+					return boardFactory(ctrl, svc)
+				}(),
+				postServiceFactory: func() *mock_service.MockPostFactory {
+					svc := mock_service.NewMockPost(ctrl)
+					svc.EXPECT().Threads("1", "10", 20).Return(nil, errTest)
+					return postFactory(ctrl, svc)
+				}(),
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.uc.Threads(context.Background(), "1", "10", 20)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("postUseCase.Threads() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("postUseCase.Threads() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postUseCase_CreateThread(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	board := model.NewBoard("1", "雑談", "", now)
+	req := &dto.ThreadRequest{Title: "タイトル", Body: "本文"}
+
+	tests := []struct {
+		name    string
+		uc      *postUseCase
+		want    *dto.ThreadPosts
+		wantErr error
+	}{
+		{
+			name: "正常ケース",
+			uc: &postUseCase{
+				db: testDB(t, true),
+				boardServiceFactory: func() *mock_service.MockBoardFactory {
+					svc := mock_service.NewMockBoard(ctrl)
+					svc.EXPECT().Find("1").Return(board, nil)
+					return boardFactory(ctrl, svc)
+				}(),
+				postServiceFactory: func() *mock_service.MockPostFactory {
+					svc := mock_service.NewMockPost(ctrl)
+					svc.EXPECT().CreateThread("1", "2", "タイトル", "本文", now).Return(
+						model.NewThread("9", "1", "2", "タイトル", 1, now, now),
+						model.NewPost("30", "9", "2", 1, "本文", "<p>本文</p>\n", now, time.Time{}, time.Time{}, ""),
+						nil,
+					)
+					return postFactory(ctrl, svc)
+				}(),
+				eventServiceFactory: emitEvent(ctrl, model.EventThreadCreated, "9",
+					model.ThreadCreatedData{BoardID: "1", ThreadID: "9", UserID: "2", Title: "タイトル"}, now, nil),
+				renderer: referencesRenderer(ctrl, "本文", 0, markdown.References{}),
+				hub: publishHub(t, ctrl, "board:1", pubsub.EventThreadCreated,
+					&dto.Thread{ID: "9", BoardID: "1", UserID: "2", Title: "タイトル", PostCount: 1, LastPostedAt: now, CreatedAt: now}),
+			},
+			want: &dto.ThreadPosts{
+				Thread: &dto.Thread{ID: "9", BoardID: "1", UserID: "2", Title: "タイトル", PostCount: 1, LastPostedAt: now, CreatedAt: now},
+				Posts:  []*dto.Post{{ID: "30", ThreadID: "9", UserID: "2", Number: 1, Body: "本文", BodyHTML: "<p>本文</p>\n", CreatedAt: now}},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "正常ケース(メンション)",
+			uc: &postUseCase{
+				db: testDB(t, true),
+				boardServiceFactory: func() *mock_service.MockBoardFactory {
+					svc := mock_service.NewMockBoard(ctrl)
+					svc.EXPECT().Find("1").Return(board, nil)
+					return boardFactory(ctrl, svc)
+				}(),
+				postServiceFactory: func() *mock_service.MockPostFactory {
+					svc := mock_service.NewMockPost(ctrl)
+					svc.EXPECT().CreateThread("1", "2", "タイトル", "本文", now).Return(
+						model.NewThread("9", "1", "2", "タイトル", 1, now, now),
+						model.NewPost("30", "9", "2", 1, "本文", "<p>本文</p>\n", now, time.Time{}, time.Time{}, ""),
+						nil,
+					)
+					return postFactory(ctrl, svc)
+				}(),
+				userServiceFactory: func() *mock_service.MockUserFactory {
+					svc := mock_service.NewMockUser(ctrl)
+					svc.EXPECT().FindByName("alice").Return(model.NewUser("4", "alice", "", "", ""), nil)
+					mock := mock_service.NewMockUserFactory(ctrl)
+					mock.EXPECT().NewUserService(gomock.Any()).Return(svc)
+					return mock
+				}(),
+				notificationServiceFactory: func() *mock_service.MockNotificationFactory {
+					svc := mock_service.NewMockNotification(ctrl)
+					svc.EXPECT().Notify(model.NewNotification("", "4", model.NotificationMention, "2", "30", time.Time{}, now), now).Return(true, nil)
+					return notificationFactory(ctrl, svc)
+				}(),
+				eventServiceFactory: emitEvent(ctrl, model.EventThreadCreated, "9",
+					model.ThreadCreatedData{BoardID: "1", ThreadID: "9", UserID: "2", Title: "タイトル"}, now, nil),
+				renderer: referencesRenderer(ctrl, "本文", 0, markdown.References{Mentions: []string{"alice"}}),
+				hub: publishHub(t, ctrl, "board:1", pubsub.EventThreadCreated,
+					&dto.Thread{ID: "9", BoardID: "1", UserID: "2", Title: "タイトル", PostCount: 1, LastPostedAt: now, CreatedAt: now}),
+			},
+			want: &dto.ThreadPosts{
+				Thread: &dto.Thread{ID: "9", BoardID: "1", UserID: "2", Title: "タイトル", PostCount: 1, LastPostedAt: now, CreatedAt: now},
+				Posts:  []*dto.Post{{ID: "30", ThreadID: "9", UserID: "2", Number: 1, Body: "本文", BodyHTML: "<p>本文</p>\n", CreatedAt: now}},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "異常ケース(板が存在しない)",
+			uc: &postUseCase{
+				db: testDB(t, false),
+				boardServiceFactory: func() *mock_service.MockBoardFactory {
+					svc := mock_service.NewMockBoard(ctrl)
+					svc.EXPECT().Find("1").Return(nil, service.ErrBoardNotFound)
+					return boardFactory(ctrl, svc)
+				}(),
+			},
+			want:    nil,
+			wantErr: service.ErrBoardNotFound,
+		},
+		{
+			name: "異常ケース(スレッドの作成に失敗)",
+			uc: &postUseCase{
+				db: testDB(t, false),
+				boardServiceFactory: func() *mock_service.MockBoardFactory {
+					svc := mock_service.NewMockBoard(ctrl)
+					svc.EXPECT().Find("1").Return(board, nil)
+					return boardFactory(ctrl, svc)
+				}(),
+				postServiceFactory: func() *mock_service.MockPostFactory {
+					svc := mock_service.NewMockPost(ctrl)
+					svc.EXPECT().CreateThread("1", "2", "タイトル", "本文", now).Return(nil, nil, service.ErrInvalidThreadTitle)
+					return postFactory(ctrl, svc)
+				}(),
+			},
+			want:    nil,
+			wantErr: service.ErrInvalidThreadTitle,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.uc.CreateThread(context.Background(), "2", "1", req, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("postUseCase.CreateThread() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("postUseCase.CreateThread() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postUseCase_Posts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	thread := model.NewThread("9", "1", "2", "タイトル", 2, now, now)
+
+	tests := []struct {
+		name    string
+		uc      *postUseCase
+		want    *dto.ThreadPosts
+		wantErr error
+	}{
+		{
+			name: "正常ケース",
+			uc: &postUseCase{
+				db: testDB(t, true),
+				postServiceFactory: func() *mock_service.MockPostFactory {
+					svc := mock_service.NewMockPost(ctrl)
+					svc.EXPECT().Thread("9").Return(thread, nil)
+					svc.EXPECT().Posts("9", 1, 50).Return([]model.Post{
+						model.NewPost("31", "9", "", 2, "返信", "<p>返信</p>\n", now, time.Time{}, time.Time{}, ""),
+					}, nil)
+					return postFactory(ctrl, svc)
+				}(),
+			},
+			want: &dto.ThreadPosts{
+				Thread: &dto.Thread{ID: "9", BoardID: "1", UserID: "2", Title: "タイトル", PostCount: 2, LastPostedAt: now, CreatedAt: now},
+				Posts:  []*dto.Post{{ID: "31", ThreadID: "9", Number: 2, Body: "返信", BodyHTML: "<p>返信</p>\n", CreatedAt: now}},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "異常ケース(スレッドが存在しない)",
+			uc: &postUseCase{
+				db: testDB(t, false),
+				postServiceFactory: func() *mock_service.MockPostFactory {
+					svc := mock_service.NewMockPost(ctrl)
+					svc.EXPECT().Thread("9").Return(nil, service.ErrThreadNotFound)
+					return postFactory(ctrl, svc)
+				}(),
+			},
+			want:    nil,
+			wantErr: service.ErrThreadNotFound,
+		},
+		{
+			name: "異常ケース(投稿の取得に失敗)",
+			uc: &postUseCase{
+				db: testDB(t, false),
+				postServiceFactory: func() *mock_service.MockPostFactory {
+					svc := mock_service.NewMockPost(ctrl)
+					svc.EXPECT().Thread("9").Return(thread, nil)
+					svc.EXPECT().Posts("9", 1, 50).Return(nil, errTest)
+					return postFactory(ctrl, svc)
+				}(),
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.uc.Posts(context.Background(), "9", 1, 50)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("postUseCase.Posts() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("postUseCase.Posts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postUseCase_Reply(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	req := &dto.PostRequest{Body: "返信"}
+	thread := model.NewThread("9", "1", "3", "タイトル", 2, now, now)
+	post := model.NewPost("31", "9", "2", 2, "返信", "<p>返信</p>\n", now, time.Time{}, time.Time{}, "")
+
+	tests := []struct {
+		name    string
+		uc      *postUseCase
+		want    *dto.Post
+		wantErr error
+	}{
+		{
+			name: "正常ケース",
+			uc: &postUseCase{
+				db: testDB(t, true),
+				postServiceFactory: func() *mock_service.MockPostFactory {
+					svc := mock_service.NewMockPost(ctrl)
+					gomock.InOrder(
+						svc.EXPECT().Reply("9", "2", "返信", now).Return(post, nil),
+						svc.EXPECT().Thread("9").Return(thread, nil),
+					)
+					return postFactory(ctrl, svc)
+				}(),
+				notificationServiceFactory: func() *mock_service.MockNotificationFactory {
+					svc := mock_service.NewMockNotification(ctrl)
+					svc.EXPECT().Notify(model.NewNotification("", "3", model.NotificationReply, "2", "31", time.Time{}, now), now).Return(true, nil)
+					return notificationFactory(ctrl, svc)
+				}(),
+				eventServiceFactory: emitEvent(ctrl, model.EventPostCreated, "31",
+					model.PostCreatedData{BoardID: "1", ThreadID: "9", PostID: "31", UserID: "2"}, now, nil),
+				renderer: referencesRenderer(ctrl, "返信", 1, markdown.References{}),
+				hub: func() *mock_pubsub.MockHub {
+					data, _ := json.Marshal(&dto.Post{ID: "31", ThreadID: "9", UserID: "2", Number: 2, Body: "返信", BodyHTML: "<p>返信</p>\n", CreatedAt: now})
+					mock := mock_pubsub.NewMockHub(ctrl)
+					gomock.InOrder(
+						mock.EXPECT().Publish("thread:9", pubsub.EventPostCreated, data),
+						mock.EXPECT().Publish("board:1", pubsub.EventPostCreated, data),
+					)
+					return mock
+				}(),
+			},
+			want:    &dto.Post{ID: "31", ThreadID: "9", UserID: "2", Number: 2, Body: "返信", BodyHTML: "<p>返信</p>\n", CreatedAt: now},
+			wantErr: nil,
+		},
+		{
+			name: "正常ケース(引用とメンションは1ユーザー1件で引用、メンション、返信の順に優先)",
+			uc: &postUseCase{
+				db: testDB(t, true),
+				postServiceFactory: func() *mock_service.MockPostFactory {
+					svc := mock_service.NewMockPost(ctrl)
+					gomock.InOrder(
+						svc.EXPECT().Reply("9", "2", "返信", now).Return(post, nil),
+						svc.EXPECT().Thread("9").Return(thread, nil),
+						svc.EXPECT().PostByNumber("9", 1).Return(model.NewPost("30", "9", "3", 1, "本文", "<p>本文</p>\n", now, time.Time{}, time.Time{}, ""), nil),
+						svc.EXPECT().PostByNumber("9", 5).Return(nil, service.ErrPostNotFound),
+					)
+					return postFactory(ctrl, svc)
+				}(),
+				userServiceFactory: func() *mock_service.MockUserFactory {
+					svc := mock_service.NewMockUser(ctrl)
+					gomock.InOrder(
+						svc.EXPECT().FindByName("alice").Return(model.NewUser("4", "alice", "", "", ""), nil),
+						svc.EXPECT().FindByName("bob").Return(nil, service.ErrUserNotFound),
+						svc.EXPECT().FindByName("carol").Return(model.NewUser("3", "carol", "", "", ""), nil),
+					)
+					mock := mock_service.NewMockUserFactory(ctrl)
+					mock.EXPECT().NewUserService(gomock.Any()).Return(svc)
+					return mock
+				}(),
+				notificationServiceFactory: func() *mock_service.MockNotificationFactory {
+					svc := mock_service.NewMockNotification(ctrl)
+					gomock.InOrder(
+						svc.EXPECT().Notify(model.NewNotification("", "3", model.NotificationQuote, "2", "31", time.Time{}, now), now).Return(true, nil),
+						svc.EXPECT().Notify(model.NewNotification("", "4", model.NotificationMention, "2", "31", time.Time{}, now), now).Return(false, nil),
+					)
+					return notificationFactory(ctrl, svc)
+				}(),
+				eventServiceFactory: emitEvent(ctrl, model.EventPostCreated, "31",
+					model.PostCreatedData{BoardID: "1", ThreadID: "9", PostID: "31", UserID: "2"}, now, nil),
+				renderer: referencesRenderer(ctrl, "返信", 1, markdown.References{Quotes: []int{1, 5}, Mentions: []string{"alice", "bob", "carol"}}),
+				hub: func() *mock_pubsub.MockHub {
+					mock := mock_pubsub.NewMockHub(ctrl)
+					mock.EXPECT().Publish(gomock.Any(), pubsub.EventPostCreated, gomock.Any()).Times(2)
+					return mock
+				}(),
+			},
+			want:    &dto.Post{ID: "31", ThreadID: "9", UserID: "2", Number: 2, Body: "返信", BodyHTML: "<p>返信</p>\n", CreatedAt: now},
+			wantErr: nil,
+		},
+		{
+			name: "異常ケース",
+			uc: &postUseCase{
+				db: testDB(t, false),
+				postServiceFactory: func() *mock_service.MockPostFactory {
+					svc := mock_service.NewMockPost(ctrl)
+					svc.EXPECT().Reply("9", "2", "返信", now).Return(nil, service.ErrThreadNotFound)
+					return postFactory(ctrl, svc)
+				}(),
+			},
+			want:    nil,
+			wantErr: service.ErrThreadNotFound,
+		},
+		{
+			name: "異常ケース(スレッドの取得に失敗)",
+			uc: &postUseCase{
+				db: testDB(t, false),
+				postServiceFactory: func() *mock_service.MockPostFactory {
+					svc := mock_service.NewMockPost(ctrl)
+					gomock.InOrder(
+						svc.EXPECT().Reply("9", "2", "返信", now).Return(post, nil),
+						svc.EXPECT().Thread("9").Return(nil, errTest),
+					)
+					return postFactory(ctrl, svc)
+				}(),
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+		{
+			name: "異常ケース(引用された投稿の取得に失敗)",
+			uc: &postUseCase{
+				db: testDB(t, false),
+				postServiceFactory: func() *mock_service.MockPostFactory {
+					svc := mock_service.NewMockPost(ctrl)
+					gomock.InOrder(
+						svc.EXPECT().Reply("9", "2", "返信", now).Return(post, nil),
+						svc.EXPECT().Thread("9").Return(thread, nil),
+						svc.EXPECT().PostByNumber("9", 1).Return(nil, errTest),
+					)
+					return postFactory(ctrl, svc)
+				}(),
+				renderer: referencesRenderer(ctrl, "返信", 1, markdown.References{Quotes: []int{1}}),
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+		{
+			name: "異常ケース(イベントの登録に失敗)",
+			uc: &postUseCase{
+				db: testDB(t, false),
+				postServiceFactory: func() *mock_service.MockPostFactory {
+					svc := mock_service.NewMockPost(ctrl)
+					gomock.InOrder(
+						svc.EXPECT().Reply("9", "2", "返信", now).Return(post, nil),
+						svc.EXPECT().Thread("9").Return(thread, nil),
+					)
+					return postFactory(ctrl, svc)
+				}(),
+				notificationServiceFactory: func() *mock_service.MockNotificationFactory {
+					svc := mock_service.NewMockNotification(ctrl)
+					svc.EXPECT().Notify(gomock.Any(), now).Return(true, nil)
+					return notificationFactory(ctrl, svc)
+				}(),
+				eventServiceFactory: emitEvent(ctrl, model.EventPostCreated, "31",
+					model.PostCreatedData{BoardID: "1", ThreadID: "9", PostID: "31", UserID: "2"}, now, errTest),
+				renderer: referencesRenderer(ctrl, "返信", 1, markdown.References{}),
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+		{
+			name: "異常ケース(通知に失敗)",
+			uc: &postUseCase{
+				db: testDB(t, false),
+				postServiceFactory: func() *mock_service.MockPostFactory {
+					svc := mock_service.NewMockPost(ctrl)
+					gomock.InOrder(
+						svc.EXPECT().Reply("9", "2", "返信", now).Return(post, nil),
+						svc.EXPECT().Thread("9").Return(thread, nil),
+					)
+					return postFactory(ctrl, svc)
+				}(),
+				notificationServiceFactory: func() *mock_service.MockNotificationFactory {
+					svc := mock_service.NewMockNotification(ctrl)
+					svc.EXPECT().Notify(gomock.Any(), now).Return(false, errTest)
+					return notificationFactory(ctrl, svc)
+				}(),
+				renderer: referencesRenderer(ctrl, "返信", 1, markdown.References{}),
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.uc.Reply(context.Background(), "2", "9", req, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("postUseCase.Reply() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("postUseCase.Reply() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postUseCase_Edit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	created := now.Add(-time.Minute)
+	req := &dto.PostRequest{Body: "編集"}
+	editWindow := func() time.Duration { return 30 * time.Minute }
+
+	tests := []struct {
+		name      string
+		moderator bool
+		uc        *postUseCase
+		want      *dto.Post
+		wantErr   error
+	}{
+		{
+			name: "正常ケース",
+			uc: &postUseCase{
+				db: testDB(t, true),
+				postServiceFactory: func() *mock_service.MockPostFactory {
+					svc := mock_service.NewMockPost(ctrl)
+					svc.EXPECT().Edit("31", "2", false, "編集", 30*time.Minute, now).
+						Return(model.NewPost("31", "9", "2", 2, "編集", "<p>編集</p>\n", created, now, time.Time{}, ""), nil)
+					return postFactory(ctrl, svc)
+				}(),
+				editWindow: editWindow,
+				hub: publishHub(t, ctrl, "thread:9", pubsub.EventPostEdited,
+					&dto.Post{ID: "31", ThreadID: "9", UserID: "2", Number: 2, Body: "編集", BodyHTML: "<p>編集</p>\n", CreatedAt: created, Edited: true, EditedAt: &now}),
+			},
+			want:    &dto.Post{ID: "31", ThreadID: "9", UserID: "2", Number: 2, Body: "編集", BodyHTML: "<p>編集</p>\n", CreatedAt: created, Edited: true, EditedAt: &now},
+			wantErr: nil,
+		},
+		{
+			name:      "正常ケース(モデレーター)",
+			moderator: true,
+			uc: &postUseCase{
+				db: testDB(t, true),
+				postServiceFactory: func() *mock_service.MockPostFactory {
+					svc := mock_service.NewMockPost(ctrl)
+					svc.EXPECT().Edit("31", "2", true, "編集", 30*time.Minute, now).
+						Return(model.NewPost("31", "9", "3", 2, "編集", "<p>編集</p>\n", created, now, time.Time{}, ""), nil)
+					return postFactory(ctrl, svc)
+				}(),
+				editWindow: editWindow,
+				hub: publishHub(t, ctrl, "thread:9", pubsub.EventPostEdited,
+					&dto.Post{ID: "31", ThreadID: "9", UserID: "3", Number: 2, Body: "編集", BodyHTML: "<p>編集</p>\n", CreatedAt: created, Edited: true, EditedAt: &now}),
+			},
+			want:    &dto.Post{ID: "31", ThreadID: "9", UserID: "3", Number: 2, Body: "編集", BodyHTML: "<p>編集</p>\n", CreatedAt: created, Edited: true, EditedAt: &now},
+			wantErr: nil,
+		},
+		{
+			name: "異常ケース",
+			uc: &postUseCase{
+				db: testDB(t, false),
+				postServiceFactory: func() *mock_service.MockPostFactory {
+					svc := mock_service.NewMockPost(ctrl)
+					svc.EXPECT().Edit("31", "2", false, "編集", 30*time.Minute, now).Return(nil, service.ErrPostEditWindowExpired)
+					return postFactory(ctrl, svc)
+				}(),
+				editWindow: editWindow,
+			},
+			want:    nil,
+			wantErr: service.ErrPostEditWindowExpired,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.uc.Edit(context.Background(), "2", "31", tt.moderator, req, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("postUseCase.Edit() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("postUseCase.Edit() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postUseCase_Revisions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		uc      *postUseCase
+		want    []*dto.PostRevision
+		wantErr error
+	}{
+		{
+			name: "正常ケース",
+			uc: &postUseCase{
+				db: testDB(t, true),
+				postServiceFactory: func() *mock_service.MockPostFactory {
+					svc := mock_service.NewMockPost(ctrl)
+					svc.EXPECT().Revisions("31").Return([]model.PostRevision{
+						model.NewPostRevision("20", "31", "2", "本文", now),
+					}, nil)
+					return postFactory(ctrl, svc)
+				}(),
+			},
+			want:    []*dto.PostRevision{{ID: "20", PostID: "31", EditorID: "2", Body: "本文", CreatedAt: now}},
+			wantErr: nil,
+		},
+		{
+			name: "異常ケース",
+			uc: &postUseCase{
+				db: testDB(t, false),
+				postServiceFactory: func() *mock_service.MockPostFactory {
+					svc := mock_service.NewMockPost(ctrl)
+					svc.EXPECT().Revisions("31").Return(nil, service.ErrPostNotFound)
+					return postFactory(ctrl, svc)
+				}(),
+			},
+			want:    nil,
+			wantErr: service.ErrPostNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.uc.Revisions(context.Background(), "31")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("postUseCase.Revisions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("postUseCase.Revisions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postUseCase_Diff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	from := model.NewPostRevision("20", "31", "2", "一行目\n二行目\n", now)
+	to := model.NewPostRevision("21", "31", "2", "一行目\n三行目\n", now)
+
+	tests := []struct {
+		name    string
+		uc      *postUseCase
+		want    *dto.PostDiff
+		wantErr error
+	}{
+		{
+			name: "正常ケース",
+			uc: &postUseCase{
+				db: testDB(t, true),
+				postServiceFactory: func() *mock_service.MockPostFactory {
+					svc := mock_service.NewMockPost(ctrl)
+					gomock.InOrder(
+						svc.EXPECT().Revision("31", "20").Return(from, nil),
+						svc.EXPECT().Revision("31", "21").Return(to, nil),
+					)
+					return postFactory(ctrl, svc)
+				}(),
+			},
+			want: &dto.PostDiff{From: "20", To: "21", Lines: []*dto.PostDiffLine{
+				{Op: "equal", Text: "一行目"},
+				{Op: "delete", Text: "二行目"},
+				{Op: "insert", Text: "三行目"},
+			}},
+			wantErr: nil,
+		},
+		{
+			name: "異常ケース(変更前の版なし)",
+			uc: &postUseCase{
+				db: testDB(t, false),
+				postServiceFactory: func() *mock_service.MockPostFactory {
+					svc := mock_service.NewMockPost(ctrl)
+					svc.EXPECT().Revision("31", "20").Return(nil, service.ErrPostRevisionNotFound)
+					return postFactory(ctrl, svc)
+				}(),
+			},
+			want:    nil,
+			wantErr: service.ErrPostRevisionNotFound,
+		},
+		{
+			name: "異常ケース(変更後の版なし)",
+			uc: &postUseCase{
+				db: testDB(t, false),
+				postServiceFactory: func() *mock_service.MockPostFactory {
+					svc := mock_service.NewMockPost(ctrl)
+					gomock.InOrder(
+						svc.EXPECT().Revision("31", "20").Return(from, nil),
+						svc.EXPECT().Revision("31", "21").Return(nil, service.ErrPostRevisionNotFound),
+					)
+					return postFactory(ctrl, svc)
+				}(),
+			},
+			want:    nil,
+			wantErr: service.ErrPostRevisionNotFound,
+		},
+		{
+			name: "異常ケース(行数超過)",
+			uc: &postUseCase{
+				db: testDB(t, false),
+				postServiceFactory: func() *mock_service.MockPostFactory {
+					svc := mock_service.NewMockPost(ctrl)
+					gomock.InOrder(
+						svc.EXPECT().Revision("31", "20").Return(from, nil),
+						svc.EXPECT().Revision("31", "21").Return(
+							model.NewPostRevision("21", "31", "2", strings.Repeat("行\n", textdiff.MaxLines+1), now), nil),
+					)
+					return postFactory(ctrl, svc)
+				}(),
+			},
+			want:    nil,
+			wantErr: textdiff.ErrTooManyLines,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.uc.Diff(context.Background(), "31", "20", "21")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("postUseCase.Diff() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("postUseCase.Diff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postUseCase_Hide(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	created := now.Add(-time.Hour)
+	req := &dto.PostHideRequest{Reason: "広告"}
+	hidden := &dto.Post{ID: "31", ThreadID: "9", UserID: "2", Number: 2, CreatedAt: created, Hidden: true, HiddenReason: "広告"}
+	thread := model.NewThread("9", "1", "3", "タイトル", 2, created, created)
+
+	tests := []struct {
+		name    string
+		uc      *postUseCase
+		want    *dto.Post
+		wantErr error
+	}{
+		{
+			name: "正常ケース",
+			uc: &postUseCase{
+				db: testDB(t, true),
+				postServiceFactory: func() *mock_service.MockPostFactory {
+					svc := mock_service.NewMockPost(ctrl)
+					gomock.InOrder(
+						svc.EXPECT().Hide("31", "広告", now).
+							Return(model.NewPost("31", "9", "2", 2, "spam", "<p>spam</p>\n", created, time.Time{}, now, "広告"), nil),
+						svc.EXPECT().Thread("9").Return(thread, nil),
+					)
+					return postFactory(ctrl, svc)
+				}(),
+				eventServiceFactory: emitEvent(ctrl, model.EventPostHidden, "31",
+					model.PostHiddenData{BoardID: "1", ThreadID: "9", PostID: "31", Reason: "広告"}, now, nil),
+				hub: publishHub(t, ctrl, "thread:9", pubsub.EventPostHidden, hidden),
+			},
+			want:    hidden,
+			wantErr: nil,
+		},
+		{
+			name: "異常ケース",
+			uc: &postUseCase{
+				db: testDB(t, false),
+				postServiceFactory: func() *mock_service.MockPostFactory {
+					svc := mock_service.NewMockPost(ctrl)
+					svc.EXPECT().Hide("31", "広告", now).Return(nil, service.ErrPostAlreadyHidden)
+					return postFactory(ctrl, svc)
+				}(),
+			},
+			want:    nil,
+			wantErr: service.ErrPostAlreadyHidden,
+		},
+		{
+			name: "異常ケース(イベントの登録に失敗)",
+			uc: &postUseCase{
+				db: testDB(t, false),
+				postServiceFactory: func() *mock_service.MockPostFactory {
+					svc := mock_service.NewMockPost(ctrl)
+					gomock.InOrder(
+						svc.EXPECT().Hide("31", "広告", now).
+							Return(model.NewPost("31", "9", "2", 2, "spam", "<p>spam</p>\n", created, time.Time{}, now, "広告"), nil),
+						svc.EXPECT().Thread("9").Return(thread, nil),
+					)
+					return postFactory(ctrl, svc)
+				}(),
+				eventServiceFactory: emitEvent(ctrl, model.EventPostHidden, "31",
+					model.PostHiddenData{BoardID: "1", ThreadID: "9", PostID: "31", Reason: "広告"}, now, errTest),
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.uc.Hide(context.Background(), "31", req, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("postUseCase.Hide() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("postUseCase.Hide() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"GoBBS/domain/service"
+	"GoBBS/dto"
+	"GoBBS/interface/dao"
+)
+
+// Session ログインセッションユースケース
+// mockgen -source usecase/session_usecase.go -destination mock/mock_usecase/session_usecase_mock.go
+type Session interface {
+	List(ctx context.Context, userID string, currentID string, now time.Time) (*dto.SessionList, error)
+	Revoke(ctx context.Context, userID string, id string, now time.Time) error
+}
+
+type sessionUseCase struct {
+	db                    *sql.DB
+	sessionServiceFactory service.SessionFactory
+}
+
+var _ Session = (*sessionUseCase)(nil)
+
+// NewSessionUseCase ログインセッションユースケースを生成する
+func NewSessionUseCase(db *sql.DB, f service.SessionFactory) *sessionUseCase {
+	return &sessionUseCase{
+		db:                    db,
+		sessionServiceFactory: f,
+	}
+}
+
+// List 有効なログインセッションの一覧を取得する(currentIDのセッションに印を付ける)
+func (uc *sessionUseCase) List(ctx context.Context, userID string, currentID string, now time.Time) (*dto.SessionList, error) {
+	ctx, span := tracer.Start(ctx, "Session.List")
+	defer span.End()
+
+	return dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) (*dto.SessionList, error) {
+			sessions, err := uc.service(tx).List(userID, now)
+			if err != nil {
+				return nil, err
+			}
+			return dto.NewSessionList(sessions, currentID), nil
+		},
+	)
+}
+
+// Revoke ログインセッションを失効させる
+func (uc *sessionUseCase) Revoke(ctx context.Context, userID string, id string, now time.Time) error {
+	ctx, span := tracer.Start(ctx, "Session.Revoke")
+	defer span.End()
+
+	_, err := dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) (any, error) {
+			return nil, uc.service(tx).Revoke(userID, id, now)
+		},
+	)
+
+	return err
+}
+
+// service トランザクションに紐づくログインセッションサービスを生成する
+func (uc *sessionUseCase) service(tx *sql.Tx) service.Session {
+	return uc.sessionServiceFactory.NewSessionService(dao.NewSessionDAO(tx))
+}
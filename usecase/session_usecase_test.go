@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/dto"
+	"GoBBS/mock/mock_service"
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+// sessionFactory ログインセッションサービスを返すファクトリーのモックを生成する
+func sessionFactory(ctrl *gomock.Controller, svc *mock_service.MockSession) *mock_service.MockSessionFactory {
+	mock := mock_service.NewMockSessionFactory(ctrl)
+	mock.EXPECT().NewSessionService(gomock.Any()).Return(svc)
+	return mock
+}
+
+func TestNewSessionUseCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db := &sql.DB{}
+	f := mock_service.NewMockSessionFactory(ctrl)
+
+	want := &sessionUseCase{db: db, sessionServiceFactory: f}
+	if got := NewSessionUseCase(db, f); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewSessionUseCase() = %v, want %v", got, want)
+	}
+}
+
+func Test_sessionUseCase_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	expires := now.Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		uc      *sessionUseCase
+		want    *dto.SessionList
+		wantErr error
+	}{
+		{
+			name: "正常ケース",
+			uc: &sessionUseCase{
+				db: testDB(t, true),
+				sessionServiceFactory: func() *mock_service.MockSessionFactory {
+					svc := mock_service.NewMockSession(ctrl)
+					svc.EXPECT().List("1", now).Return([]model.Session{
+						model.NewSession("s2", "1", "Chrome on Android", "192.0.2.2", "ua2", now, now, expires, time.Time{}),
+						model.NewSession("s1", "1", "Firefox on Linux", "192.0.2.1", "ua1", now, now, expires, time.Time{}),
+					}, nil)
+					return sessionFactory(ctrl, svc)
+				}(),
+			},
+			want: &dto.SessionList{
+				Sessions: []*dto.Session{
+					{ID: "s2", Device: "Chrome on Android", IP: "192.0.2.2", UserAgent: "ua2", CreatedAt: now, LastSeenAt: now, ExpiresAt: expires},
+					{ID: "s1", Device: "Firefox on Linux", IP: "192.0.2.1", UserAgent: "ua1", CreatedAt: now, LastSeenAt: now, ExpiresAt: expires, Current: true},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "異常ケース",
+			uc: &sessionUseCase{
+				db: testDB(t, false),
+				sessionServiceFactory: func() *mock_service.MockSessionFactory {
+					svc := mock_service.NewMockSession(ctrl)
+					svc.EXPECT().List("1", now).Return(nil, errTest)
+					return sessionFactory(ctrl, svc)
+				}(),
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.uc.List(context.Background(), "1", "s1", now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("sessionUseCase.List() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("sessionUseCase.List() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_sessionUseCase_Revoke(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for _, wantErr := range []error{nil, errTest} {
+		svc := mock_service.NewMockSession(ctrl)
+		svc.EXPECT().Revoke("1", "s1", now).Return(wantErr)
+		uc := &sessionUseCase{db: testDB(t, wantErr == nil), sessionServiceFactory: sessionFactory(ctrl, svc)}
+
+		if err := uc.Revoke(context.Background(), "1", "s1", now); !errors.Is(err, wantErr) {
+			t.Errorf("sessionUseCase.Revoke() error = %v, wantErr %v", err, wantErr)
+		}
+	}
+}
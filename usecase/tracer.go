@@ -0,0 +1,6 @@
+package usecase
+
+import "GoBBS/interface/tracing"
+
+// tracer ユースケースのスパンを記録するトレーサー
+var tracer = tracing.Tracer("GoBBS/usecase")
@@ -0,0 +1,248 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/service"
+	"GoBBS/dto"
+	"GoBBS/interface/dao"
+	"GoBBS/interface/imaging"
+	"GoBBS/interface/storage"
+)
+
+// Upload アップロードユースケース
+// mockgen -source usecase/upload_usecase.go -destination mock/mock_usecase/upload_usecase_mock.go
+type Upload interface {
+	UploadAvatar(ctx context.Context, userID string, data []byte, now time.Time) (*dto.User, error)
+	UploadAttachment(ctx context.Context, userID string, data []byte, now time.Time) (*dto.Attachment, error)
+	OpenBlob(ctx context.Context, key string) (io.ReadCloser, string, error)
+}
+
+type uploadUseCase struct {
+	db                       *sql.DB
+	userServiceFactory       service.UserFactory
+	attachmentServiceFactory service.AttachmentFactory
+	store                    storage.BlobStore
+	newKey                   func(prefix string) (string, error)
+	// decodeSlots 同時にデコードできる画像の数だけ容量を持つセマフォ
+	decodeSlots chan struct{}
+}
+
+var _ Upload = (*uploadUseCase)(nil)
+
+var (
+	// avatarLimits アバター画像の寸法上限
+	avatarLimits = imaging.Limits{MaxWidth: 2048, MaxHeight: 2048}
+	// attachmentLimits 添付画像の寸法上限(長辺は横長・縦長の画像のために広く取り、総画素数で展開後のメモリ量を抑える)
+	attachmentLimits = imaging.Limits{MaxWidth: 8192, MaxHeight: 8192, MaxPixels: 4096 * 4096}
+)
+
+const (
+	// avatarSize アバター画像の長辺(アップロードされた画像はこの大きさに縮小して保存する)
+	avatarSize = 256
+	// attachmentThumbnailSize 添付画像のサムネイルの長辺
+	attachmentThumbnailSize = 320
+	// maxConcurrentDecodes 同時にデコードする画像の数の上限(デコード中の画像のメモリ量を抑える)
+	maxConcurrentDecodes = 4
+)
+
+// NewUploadUseCase アップロードユースケースを生成する
+func NewUploadUseCase(
+	db *sql.DB,
+	uf service.UserFactory,
+	af service.AttachmentFactory,
+	store storage.BlobStore) *uploadUseCase {
+	return &uploadUseCase{
+		db:                       db,
+		userServiceFactory:       uf,
+		attachmentServiceFactory: af,
+		store:                    store,
+		newKey:                   newBlobKey,
+		decodeSlots:              make(chan struct{}, maxConcurrentDecodes),
+	}
+}
+
+// UploadAvatar アバター画像をアップロードし、更新後のユーザーを返す
+func (uc *uploadUseCase) UploadAvatar(ctx context.Context, userID string, data []byte, now time.Time) (*dto.User, error) {
+	ctx, span := tracer.Start(ctx, "Upload.UploadAvatar")
+	defer span.End()
+
+	var avatar []byte
+	var contentType string
+	err := uc.withDecodeSlot(ctx, func() error {
+		img, err := imaging.Load(data, avatarLimits)
+		if err != nil {
+			return err
+		}
+		avatar, contentType, err = img.Thumbnail(avatarSize)
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "UploadAvatar error")
+	}
+
+	name, err := uc.newKey("avatar")
+	if err != nil {
+		return nil, errors.Wrap(err, "UploadAvatar error")
+	}
+
+	key, err := uc.put(name, avatar, contentType)
+	if err != nil {
+		return nil, errors.Wrap(err, "UploadAvatar error")
+	}
+
+	avatarURL := dto.BlobURL(key)
+	oldUser, err := dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) (model.User, error) {
+			return uc.userServiceFactory.NewUserService(dao.NewUserDAO(tx)).UpdateAvatar(userID, avatarURL, now)
+		},
+	)
+	if err != nil {
+		uc.deleteBlobs(key)
+		return nil, errors.Wrap(err, "UploadAvatar error")
+	}
+
+	// 以前のアバター画像は参照されなくなるため削除する
+	if oldKey, ok := dto.BlobKey(oldUser.Profile().AvatarURL); ok {
+		uc.deleteBlobs(oldKey)
+	}
+
+	user := dto.NewUser(oldUser)
+	user.AvatarURL = avatarURL
+
+	return user, nil
+}
+
+// UploadAttachment 添付画像をアップロードし、サムネイルとともに登録する
+func (uc *uploadUseCase) UploadAttachment(ctx context.Context, userID string, data []byte, now time.Time) (*dto.Attachment, error) {
+	ctx, span := tracer.Start(ctx, "Upload.UploadAttachment")
+	defer span.End()
+
+	// 原本もデコードした画像から再エンコードし、EXIF(位置情報など)のメタデータを保存しない
+	var original, thumbnail []byte
+	var contentType, thumbnailContentType string
+	var width, height int
+	err := uc.withDecodeSlot(ctx, func() error {
+		img, err := imaging.Load(data, attachmentLimits)
+		if err != nil {
+			return err
+		}
+		if original, contentType, err = img.Encode(); err != nil {
+			return err
+		}
+		if thumbnail, thumbnailContentType, err = img.Thumbnail(attachmentThumbnailSize); err != nil {
+			return err
+		}
+		width, height = img.Width, img.Height
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "UploadAttachment error")
+	}
+
+	name, err := uc.newKey("attachment")
+	if err != nil {
+		return nil, errors.Wrap(err, "UploadAttachment error")
+	}
+
+	key, err := uc.put(name, original, contentType)
+	if err != nil {
+		return nil, errors.Wrap(err, "UploadAttachment error")
+	}
+
+	thumbnailKey, err := uc.put(name+"-thumb", thumbnail, thumbnailContentType)
+	if err != nil {
+		uc.deleteBlobs(key)
+		return nil, errors.Wrap(err, "UploadAttachment error")
+	}
+
+	attachment, err := dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) (model.Attachment, error) {
+			return uc.attachmentServiceFactory.NewAttachmentService(dao.NewAttachmentDAO(tx)).Regist(
+				model.NewAttachment(
+					"",
+					userID,
+					"",
+					key,
+					thumbnailKey,
+					contentType,
+					int64(len(original)),
+					width,
+					height,
+					now,
+				),
+				now,
+			)
+		},
+	)
+	if err != nil {
+		uc.deleteBlobs(key, thumbnailKey)
+		return nil, errors.Wrap(err, "UploadAttachment error")
+	}
+
+	return dto.NewAttachment(attachment), nil
+}
+
+// OpenBlob 保存済みのオブジェクトとコンテントタイプを返す
+func (uc *uploadUseCase) OpenBlob(ctx context.Context, key string) (io.ReadCloser, string, error) {
+	ctx, span := tracer.Start(ctx, "Upload.OpenBlob")
+	defer span.End()
+
+	return uc.store.Get(key)
+}
+
+// withDecodeSlot 同時にデコードする画像の数を制限してfnを実行する(空きがなければ待つ)
+// デコードした画像はfnの中だけで使い、fnの終了後に展開したメモリを解放できるようにする
+func (uc *uploadUseCase) withDecodeSlot(ctx context.Context, fn func() error) error {
+	select {
+	case uc.decodeSlots <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-uc.decodeSlots }()
+
+	return fn()
+}
+
+// put 名前にコンテントタイプの拡張子を付けたキーでオブジェクトを保存する
+func (uc *uploadUseCase) put(name string, data []byte, contentType string) (string, error) {
+	key := name + imaging.Extension(contentType)
+	if err := uc.store.Put(key, contentType, bytes.NewReader(data), int64(len(data))); err != nil {
+		return "", err
+	}
+
+	return key, nil
+}
+
+// deleteBlobs 不要になったオブジェクトを削除する(失敗してもログ出力のみ)
+func (uc *uploadUseCase) deleteBlobs(keys ...string) {
+	for _, key := range keys {
+		if err := uc.store.Delete(key); err != nil {
+			slog.Error("delete blob error", "key", key, "error", err)
+		}
+	}
+}
+
+// newBlobKey 推測できないキーを生成する
+func newBlobKey(prefix string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return prefix + "-" + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,354 @@
+package usecase
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/dto"
+	"GoBBS/interface/imaging"
+	"GoBBS/mock/mock_service"
+	"GoBBS/mock/mock_storage"
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+)
+
+// testPNG テスト用のPNG画像を生成する
+func testPNG(t *testing.T, width int, height int) []byte {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatalf("PNGのエンコードに失敗(error: %s)", err)
+	}
+	return buf.Bytes()
+}
+
+// testDB 期待するトランザクション操作を設定したsqlmockのDBを返す
+func testDB(t *testing.T, commit bool) *sql.DB {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmockの生成に失敗(error: %v)", err)
+	}
+	mock.ExpectBegin()
+	if commit {
+		mock.ExpectCommit()
+	} else {
+		mock.ExpectRollback()
+	}
+	return db
+}
+
+// errTest テスト用のエラー
+var errTest = errors.New("test")
+
+// fixedKey テスト用の固定キーを返す
+func fixedKey(prefix string) (string, error) {
+	return prefix + "-key", nil
+}
+
+func TestNewUploadUseCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db := &sql.DB{}
+	uf := mock_service.NewMockUserFactory(ctrl)
+	af := mock_service.NewMockAttachmentFactory(ctrl)
+	store := mock_storage.NewMockBlobStore(ctrl)
+
+	got := NewUploadUseCase(db, uf, af, store)
+	if got.db != db || got.userServiceFactory != uf || got.attachmentServiceFactory != af || got.store != store {
+		t.Errorf("NewUploadUseCase() = %v", got)
+	}
+	if reflect.ValueOf(got.newKey).Pointer() != reflect.ValueOf(newBlobKey).Pointer() {
+		t.Errorf("NewUploadUseCase().newKey is not newBlobKey")
+	}
+	if cap(got.decodeSlots) != maxConcurrentDecodes {
+		t.Errorf("戻り値不一致 got: %#v want: %#v", cap(got.decodeSlots), maxConcurrentDecodes)
+	}
+}
+
+func Test_uploadUseCase_UploadAvatar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	oldUser := model.NewUserWithProfile("1", "name", "email", "pw", "salt", model.Profile{AvatarURL: "/blobs/avatar-old.png"})
+
+	tests := []struct {
+		name    string
+		uc      *uploadUseCase
+		data    []byte
+		want    *dto.User
+		wantErr error
+	}{
+		{
+			name: "正常ケース",
+			uc: &uploadUseCase{
+				db: testDB(t, true),
+				userServiceFactory: func() *mock_service.MockUserFactory {
+					svc := mock_service.NewMockUser(ctrl)
+					svc.EXPECT().UpdateAvatar("1", "/blobs/avatar-key.png", now).Return(oldUser, nil)
+
+					mock := mock_service.NewMockUserFactory(ctrl)
+					mock.EXPECT().NewUserService(gomock.Any()).Return(svc)
+					return mock
+				}(),
+				store: func() *mock_storage.MockBlobStore {
+					mock := mock_storage.NewMockBlobStore(ctrl)
+					gomock.InOrder(
+						mock.EXPECT().Put("avatar-key.png", "image/png", gomock.Any(), gomock.Any()).Return(nil),
+						mock.EXPECT().Delete("avatar-old.png").Return(nil),
+					)
+					return mock
+				}(),
+				newKey:      fixedKey,
+				decodeSlots: make(chan struct{}, 1),
+			},
+			data: testPNG(t, 512, 512),
+			want: &dto.User{
+				ID:        "1",
+				Name:      "name",
+				Email:     "email",
+				Password:  "pw",
+				Salt:      "salt",
+				AvatarURL: "/blobs/avatar-key.png",
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "異常ケース(画像以外)",
+			uc:      &uploadUseCase{newKey: fixedKey, decodeSlots: make(chan struct{}, 1)},
+			data:    []byte("not image"),
+			want:    nil,
+			wantErr: imaging.ErrUnsupportedType,
+		},
+		{
+			name:    "異常ケース(寸法超過)",
+			uc:      &uploadUseCase{newKey: fixedKey, decodeSlots: make(chan struct{}, 1)},
+			data:    testPNG(t, 2049, 1),
+			want:    nil,
+			wantErr: imaging.ErrTooLarge,
+		},
+		{
+			name: "異常ケース(更新失敗時は保存した画像を削除)",
+			uc: &uploadUseCase{
+				db: testDB(t, false),
+				userServiceFactory: func() *mock_service.MockUserFactory {
+					svc := mock_service.NewMockUser(ctrl)
+					svc.EXPECT().UpdateAvatar("1", "/blobs/avatar-key.png", now).Return(nil, errTest)
+
+					mock := mock_service.NewMockUserFactory(ctrl)
+					mock.EXPECT().NewUserService(gomock.Any()).Return(svc)
+					return mock
+				}(),
+				store: func() *mock_storage.MockBlobStore {
+					mock := mock_storage.NewMockBlobStore(ctrl)
+					gomock.InOrder(
+						mock.EXPECT().Put("avatar-key.png", "image/png", gomock.Any(), gomock.Any()).Return(nil),
+						mock.EXPECT().Delete("avatar-key.png").Return(nil),
+					)
+					return mock
+				}(),
+				newKey:      fixedKey,
+				decodeSlots: make(chan struct{}, 1),
+			},
+			data:    testPNG(t, 10, 10),
+			want:    nil,
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.uc.UploadAvatar(context.Background(), "1", tt.data, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("uploadUseCase.UploadAvatar() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("uploadUseCase.UploadAvatar() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_uploadUseCase_UploadAttachment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	data := testPNG(t, 640, 320)
+	// 原本はデコードした画像から再エンコードして保存する
+	img, err := imaging.Load(data, attachmentLimits)
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	original, _, err := img.Encode()
+	if err != nil {
+		t.Fatalf("予期せぬエラー(error: %s)", err)
+	}
+	saved := model.NewAttachment("1", "2", "", "attachment-key.png", "attachment-key-thumb.png", "image/png", int64(len(original)), 640, 320, now)
+
+	tests := []struct {
+		name    string
+		uc      *uploadUseCase
+		want    *dto.Attachment
+		wantErr bool
+	}{
+		{
+			name: "正常ケース",
+			uc: &uploadUseCase{
+				db: testDB(t, true),
+				attachmentServiceFactory: func() *mock_service.MockAttachmentFactory {
+					svc := mock_service.NewMockAttachment(ctrl)
+					svc.EXPECT().Regist(
+						model.NewAttachment("", "2", "", "attachment-key.png", "attachment-key-thumb.png", "image/png", int64(len(original)), 640, 320, now),
+						now,
+					).Return(saved, nil)
+
+					mock := mock_service.NewMockAttachmentFactory(ctrl)
+					mock.EXPECT().NewAttachmentService(gomock.Any()).Return(svc)
+					return mock
+				}(),
+				store: func() *mock_storage.MockBlobStore {
+					mock := mock_storage.NewMockBlobStore(ctrl)
+					gomock.InOrder(
+						mock.EXPECT().Put("attachment-key.png", "image/png", gomock.Any(), int64(len(original))).
+							DoAndReturn(func(key string, contentType string, body io.Reader, size int64) error {
+								if got, _ := io.ReadAll(body); !bytes.Equal(got, original) {
+									t.Errorf("原本が再エンコードされていない")
+								}
+								return nil
+							}),
+						mock.EXPECT().Put("attachment-key-thumb.png", "image/png", gomock.Any(), gomock.Any()).
+							DoAndReturn(func(key string, contentType string, body io.Reader, size int64) error {
+								img, _, err := image.DecodeConfig(body)
+								if err != nil || img.Width != 320 || img.Height != 160 {
+									t.Errorf("サムネイル不正(%dx%d, error: %v)", img.Width, img.Height, err)
+								}
+								return nil
+							}),
+					)
+					return mock
+				}(),
+				newKey:      fixedKey,
+				decodeSlots: make(chan struct{}, 1),
+			},
+			want:    dto.NewAttachment(saved),
+			wantErr: false,
+		},
+		{
+			name: "異常ケース(登録失敗時は保存した画像を削除)",
+			uc: &uploadUseCase{
+				db: testDB(t, false),
+				attachmentServiceFactory: func() *mock_service.MockAttachmentFactory {
+					svc := mock_service.NewMockAttachment(ctrl)
+					svc.EXPECT().Regist(gomock.Any(), now).Return(nil, errTest)
+
+					mock := mock_service.NewMockAttachmentFactory(ctrl)
+					mock.EXPECT().NewAttachmentService(gomock.Any()).Return(svc)
+					return mock
+				}(),
+				store: func() *mock_storage.MockBlobStore {
+					mock := mock_storage.NewMockBlobStore(ctrl)
+					gomock.InOrder(
+						mock.EXPECT().Put("attachment-key.png", "image/png", gomock.Any(), gomock.Any()).Return(nil),
+						mock.EXPECT().Put("attachment-key-thumb.png", "image/png", gomock.Any(), gomock.Any()).Return(nil),
+						mock.EXPECT().Delete("attachment-key.png").Return(nil),
+						mock.EXPECT().Delete("attachment-key-thumb.png").Return(nil),
+					)
+					return mock
+				}(),
+				newKey:      fixedKey,
+				decodeSlots: make(chan struct{}, 1),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "異常ケース(サムネイル保存失敗時は原本を削除)",
+			uc: &uploadUseCase{
+				store: func() *mock_storage.MockBlobStore {
+					mock := mock_storage.NewMockBlobStore(ctrl)
+					gomock.InOrder(
+						mock.EXPECT().Put("attachment-key.png", "image/png", gomock.Any(), gomock.Any()).Return(nil),
+						mock.EXPECT().Put("attachment-key-thumb.png", "image/png", gomock.Any(), gomock.Any()).Return(errTest),
+						mock.EXPECT().Delete("attachment-key.png").Return(nil),
+					)
+					return mock
+				}(),
+				newKey:      fixedKey,
+				decodeSlots: make(chan struct{}, 1),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.uc.UploadAttachment(context.Background(), "2", data, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("uploadUseCase.UploadAttachment() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("uploadUseCase.UploadAttachment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_uploadUseCase_withDecodeSlot(t *testing.T) {
+	t.Run("正常ケース(空きがあれば実行して解放する)", func(t *testing.T) {
+		uc := &uploadUseCase{decodeSlots: make(chan struct{}, 1)}
+		called := false
+		if err := uc.withDecodeSlot(context.Background(), func() error {
+			called = true
+			return nil
+		}); err != nil {
+			t.Fatalf("予期せぬエラー(error: %s)", err)
+		}
+		if !called || len(uc.decodeSlots) != 0 {
+			t.Errorf("戻り値不一致 got: %#v want: %#v", called, true)
+		}
+	})
+
+	t.Run("異常ケース(空きを待つ間にキャンセル)", func(t *testing.T) {
+		uc := &uploadUseCase{decodeSlots: make(chan struct{}, 1)}
+		uc.decodeSlots <- struct{}{}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := uc.withDecodeSlot(ctx, func() error {
+			t.Errorf("空きがないのに実行された")
+			return nil
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("戻り値不一致 got: %#v want: %#v", err, context.Canceled)
+		}
+	})
+}
+
+func Test_uploadUseCase_OpenBlob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	body := io.NopCloser(bytes.NewBufferString("data"))
+	uc := &uploadUseCase{
+		store: func() *mock_storage.MockBlobStore {
+			mock := mock_storage.NewMockBlobStore(ctrl)
+			mock.EXPECT().Get("a.png").Return(body, "image/png", nil)
+			return mock
+		}(),
+	}
+
+	got, contentType, err := uc.OpenBlob(context.Background(), "a.png")
+	if err != nil || got != body || contentType != "image/png" {
+		t.Errorf("uploadUseCase.OpenBlob() = %v, %v, %v", got, contentType, err)
+	}
+}
@@ -1,87 +1,311 @@
 package usecase
 
 import (
+	"context"
 	"database/sql"
+	"log/slog"
 	"time"
 
+	"github.com/pkg/errors"
+
 	"GoBBS/domain/model"
 	"GoBBS/domain/service"
 	"GoBBS/dto"
 	"GoBBS/interface/dao"
+	"GoBBS/interface/oidc"
 	"GoBBS/interface/security"
 )
 
 // User ユーザーユースケース
 // mockgen -source usecase/user_usecase.go -destination mock/mock_usecase/user_usecase_mock.go
 type User interface {
-	Regist(*dto.User, time.Time) error
-	Update(*dto.User, time.Time) error
-	Authorize(email string, password string) (string, error)
-	VerifyAuthorization(token string) bool
-	Delete(*dto.User) error
+	Regist(context.Context, *dto.User, time.Time) error
+	Update(context.Context, *dto.UserUpdateRequest, time.Time) error
+	FindByID(ctx context.Context, id string) (*dto.User, error)
+	Authorize(ctx context.Context, email string, password string, ip string, userAgent string) (string, *dto.MFAChallenge, error)
+	AuthorizeExternal(ctx context.Context, identity *oidc.Identity, ip string, userAgent string) (string, *dto.MFAChallenge, error)
+	VerifyMFA(ctx context.Context, mfaToken string, code string, ip string, userAgent string) (string, error)
+	VerifyAuthorization(ctx context.Context, token string) (userID string, sessionID string, ok bool)
+	VerifyAPIToken(ctx context.Context, token string) (userID string, scopes []string, ok bool)
+	Delete(context.Context, *dto.User) error
 }
 
 type userUseCase struct {
-	db                 *sql.DB
-	userServiceFactory service.UserFactory
-	token              security.Token
+	db                    *sql.DB
+	userServiceFactory    service.UserFactory
+	eventServiceFactory   service.EventFactory
+	sessionServiceFactory service.SessionFactory
+	mfaServiceFactory     service.MFAFactory
+	identityFactory       service.UserIdentityFactory
+	apiTokenFactory       service.APITokenFactory
+	token                 security.Token
+}
+
+// authorization 認証結果(2要素認証が有効な場合はトークンの代わりにチャレンジを返す)
+type authorization struct {
+	token     string
+	challenge *dto.MFAChallenge
 }
 
 var _ User = (*userUseCase)(nil)
 
 // NewUserUseCase ユーザーユースケースを生成する
-func NewUserUseCase(db *sql.DB, f service.UserFactory, t security.Token) *userUseCase {
+func NewUserUseCase(
+	db *sql.DB,
+	f service.UserFactory,
+	ef service.EventFactory,
+	sf service.SessionFactory,
+	mf service.MFAFactory,
+	idf service.UserIdentityFactory,
+	tf service.APITokenFactory,
+	t security.Token,
+) *userUseCase {
 	return &userUseCase{
-		db:                 db,
-		userServiceFactory: f,
-		token:              t,
+		db:                    db,
+		userServiceFactory:    f,
+		eventServiceFactory:   ef,
+		sessionServiceFactory: sf,
+		mfaServiceFactory:     mf,
+		identityFactory:       idf,
+		apiTokenFactory:       tf,
+		token:                 t,
 	}
 }
 
-// Regist ユーザーを登録する
-func (uc *userUseCase) Regist(user *dto.User, now time.Time) error {
+// Regist ユーザーを登録し、同じtxでUserRegisteredイベントを発行する
+func (uc *userUseCase) Regist(ctx context.Context, user *dto.User, now time.Time) error {
+	ctx, span := tracer.Start(ctx, "User.Regist")
+	defer span.End()
+
 	_, err := dao.ExecWithTx(
+		ctx,
 		uc.db,
 		func(tx *sql.Tx) (any, error) {
-			return nil, uc.userServiceFactory.NewUserService(dao.NewUserDAO(tx)).Regist(user.MapUserModel(), now)
+			registered, err := uc.userServiceFactory.NewUserService(dao.NewUserDAO(tx)).Regist(user.MapUserModel(), now)
+			if err != nil {
+				return nil, err
+			}
+			return nil, uc.eventServiceFactory.NewEventService(dao.NewEventDAO(tx)).Emit(
+				model.EventUserRegistered,
+				registered.ID(),
+				model.UserRegisteredData{UserID: registered.ID(), Name: registered.Name()},
+				now,
+			)
 		},
 	)
 
 	return err
 }
 
-// Authorize 認証する
-func (uc *userUseCase) Authorize(email string, password string) (string, error) {
+// FindByID IDを指定してユーザーを取得する
+func (uc *userUseCase) FindByID(ctx context.Context, id string) (*dto.User, error) {
+	ctx, span := tracer.Start(ctx, "User.FindByID")
+	defer span.End()
+
 	user, err := dao.ExecWithTx(
+		ctx,
 		uc.db,
 		func(tx *sql.Tx) (model.User, error) {
-			return uc.userServiceFactory.NewUserService(dao.NewUserDAO(tx)).Authorize(email, password)
+			return uc.userServiceFactory.NewUserService(dao.NewUserDAO(tx)).FindByID(id)
 		},
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.NewUser(user), nil
+}
 
+// Authorize 認証し、ログインセッションを登録してそのセッションのトークンを発行する
+// 2要素認証を有効にしているユーザーにはトークンを発行せず、確認コードと交換するチャレンジを返す
+func (uc *userUseCase) Authorize(ctx context.Context, email string, password string, ip string, userAgent string) (string, *dto.MFAChallenge, error) {
+	ctx, span := tracer.Start(ctx, "User.Authorize")
+	defer span.End()
+
+	result, err := dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) (*authorization, error) {
+			user, err := uc.userServiceFactory.NewUserService(dao.NewUserDAO(tx)).Authorize(email, password)
+			if err != nil {
+				return nil, err
+			}
+
+			return uc.login(tx, user.ID(), ip, userAgent, time.Now())
+		},
+	)
 	if err != nil {
-		return "", err
+		return "", nil, err
+	}
+
+	return result.token, result.challenge, nil
+}
+
+// AuthorizeExternal 外部IdPで認証したユーザーのログインセッションを登録してそのセッションのトークンを発行する
+// 初めてログインした外部アカウントはユーザーに紐づけ、ユーザーを新しく登録した場合はUserRegisteredイベントを発行する
+// 2要素認証を有効にしているユーザーにはAuthorizeと同じくチャレンジを返す
+func (uc *userUseCase) AuthorizeExternal(ctx context.Context, identity *oidc.Identity, ip string, userAgent string) (string, *dto.MFAChallenge, error) {
+	ctx, span := tracer.Start(ctx, "User.AuthorizeExternal")
+	defer span.End()
+
+	result, err := dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) (*authorization, error) {
+			now := time.Now()
+			user, created, err := uc.identityFactory.NewUserIdentityService(dao.NewUserIdentityDAO(tx), dao.NewUserDAO(tx)).
+				Resolve(identity.Issuer, identity.Subject, identity.Email, identity.EmailVerified, identity.Name, now)
+			if err != nil {
+				return nil, err
+			}
+			if created {
+				if err := uc.eventServiceFactory.NewEventService(dao.NewEventDAO(tx)).Emit(
+					model.EventUserRegistered,
+					user.ID(),
+					model.UserRegisteredData{UserID: user.ID(), Name: user.Name()},
+					now,
+				); err != nil {
+					return nil, err
+				}
+			}
+
+			return uc.login(tx, user.ID(), ip, userAgent, now)
+		},
+	)
+	if err != nil {
+		return "", nil, err
 	}
 
-	token, err := uc.token.Generate(user.ID(), time.Now())
+	return result.token, result.challenge, nil
+}
+
+// VerifyMFA チャレンジのトークンと確認コード(またはリカバリーコード)を検証し、ログインセッションのトークンを発行する
+// コードを誤った場合も試行回数を記録するためトランザクションは確定させ、ErrInvalidMFACodeを返す
+func (uc *userUseCase) VerifyMFA(ctx context.Context, mfaToken string, code string, ip string, userAgent string) (string, error) {
+	ctx, span := tracer.Start(ctx, "User.VerifyMFA")
+	defer span.End()
+
+	token, err := dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) (string, error) {
+			now := time.Now()
+			userID, ok, err := uc.mfaServiceFactory.NewMFAService(dao.NewMFADAO(tx)).VerifyChallenge(mfaToken, code, now)
+			if err != nil || !ok {
+				return "", err
+			}
+
+			return uc.issueToken(tx, userID, ip, userAgent, now)
+		},
+	)
 	if err != nil {
 		return "", err
 	}
+	if token == "" {
+		return "", service.ErrInvalidMFACode
+	}
 
 	return token, nil
 }
 
-// VerifyAuthorization トークンを検証する
-func (uc *userUseCase) VerifyAuthorization(token string) bool {
-	return uc.token.Verify(token)
+// login 認証済みのユーザーのトークンを発行する(2要素認証が有効な場合はチャレンジを発行する)
+func (uc *userUseCase) login(tx *sql.Tx, userID string, ip string, userAgent string, now time.Time) (*authorization, error) {
+	mfaService := uc.mfaServiceFactory.NewMFAService(dao.NewMFADAO(tx))
+	enabled, err := mfaService.IsEnabled(userID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		token, challenge, err := mfaService.IssueChallenge(userID, now)
+		if err != nil {
+			return nil, err
+		}
+		return &authorization{challenge: dto.NewMFAChallenge(token, challenge.ExpiresAt())}, nil
+	}
+
+	token, err := uc.issueToken(tx, userID, ip, userAgent, now)
+	if err != nil {
+		return nil, err
+	}
+	return &authorization{token: token}, nil
+}
+
+// issueToken ログインセッションを登録し、そのセッションのトークンを発行する
+func (uc *userUseCase) issueToken(tx *sql.Tx, userID string, ip string, userAgent string, now time.Time) (string, error) {
+	session, err := uc.sessionServiceFactory.NewSessionService(dao.NewSessionDAO(tx)).
+		Start(userID, ip, userAgent, now, now.Add(uc.token.TTL()))
+	if err != nil {
+		return "", err
+	}
+
+	return uc.token.Generate(userID, session.ID(), now)
 }
 
-// Update 更新する
-func (uc *userUseCase) Update(user *dto.User, now time.Time) error {
+// VerifyAuthorization トークンを検証し、ユーザーIDとログインセッションのIDを返す
+// 失効したセッションのトークンは受け付けない
+func (uc *userUseCase) VerifyAuthorization(ctx context.Context, token string) (string, string, bool) {
+	userID, sessionID, ok := uc.token.Verify(token)
+	if !ok {
+		return "", "", false
+	}
+
+	ctx, span := tracer.Start(ctx, "User.VerifyAuthorization")
+	defer span.End()
+
 	_, err := dao.ExecWithTx(
+		ctx,
 		uc.db,
 		func(tx *sql.Tx) (any, error) {
-			return nil, uc.userServiceFactory.NewUserService(dao.NewUserDAO(tx)).Update(user.MapUserModel(), now)
+			return nil, uc.sessionServiceFactory.NewSessionService(dao.NewSessionDAO(tx)).Verify(sessionID, userID, time.Now())
+		},
+	)
+	if err != nil {
+		if !errors.Is(err, service.ErrSessionRevoked) {
+			slog.ErrorContext(ctx, "verify session error", "error", err)
+		}
+		return "", "", false
+	}
+
+	return userID, sessionID, true
+}
+
+// VerifyAPIToken 個人用アクセストークンを検証し、ユーザーIDとトークンのスコープを返す
+// 有効期限切れや削除済みのトークンは受け付けない
+func (uc *userUseCase) VerifyAPIToken(ctx context.Context, token string) (string, []string, bool) {
+	if !model.IsAPIToken(token) {
+		return "", nil, false
+	}
+
+	ctx, span := tracer.Start(ctx, "User.VerifyAPIToken")
+	defer span.End()
+
+	apiToken, err := dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) (model.APIToken, error) {
+			return uc.apiTokenFactory.NewAPITokenService(dao.NewAPITokenDAO(tx)).Verify(token, time.Now())
+		},
+	)
+	if err != nil {
+		if !errors.Is(err, service.ErrAPITokenInvalid) {
+			slog.ErrorContext(ctx, "verify api token error", "error", err)
+		}
+		return "", nil, false
+	}
+
+	return apiToken.UserID(), apiToken.Scopes(), true
+}
+
+// Update 更新する(リクエストで省略した項目は現在の値のまま)
+func (uc *userUseCase) Update(ctx context.Context, req *dto.UserUpdateRequest, now time.Time) error {
+	ctx, span := tracer.Start(ctx, "User.Update")
+	defer span.End()
+
+	_, err := dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) (any, error) {
+			return nil, uc.userServiceFactory.NewUserService(dao.NewUserDAO(tx)).Update(req.Email, req.MapUserPatch(), now)
 		},
 	)
 
@@ -89,8 +313,12 @@ func (uc *userUseCase) Update(user *dto.User, now time.Time) error {
 }
 
 // Delete 削除する
-func (uc *userUseCase) Delete(user *dto.User) error {
+func (uc *userUseCase) Delete(ctx context.Context, user *dto.User) error {
+	ctx, span := tracer.Start(ctx, "User.Delete")
+	defer span.End()
+
 	_, err := dao.ExecWithTx(
+		ctx,
 		uc.db,
 		func(tx *sql.Tx) (any, error) {
 			return nil, uc.userServiceFactory.NewUserService(dao.NewUserDAO(tx)).Delete(user.MapUserModel())
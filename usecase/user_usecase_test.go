@@ -1,12 +1,15 @@
 package usecase
 
 import (
+	"GoBBS/domain/model"
 	"GoBBS/domain/service"
 	"GoBBS/dto"
+	"GoBBS/interface/oidc"
 	"GoBBS/interface/security"
 	"GoBBS/mock/mock_model"
 	"GoBBS/mock/mock_security"
 	"GoBBS/mock/mock_service"
+	"context"
 	"database/sql"
 	"errors"
 	"reflect"
@@ -19,9 +22,14 @@ import (
 
 func TestNewUserUseCase(t *testing.T) {
 	type args struct {
-		db *sql.DB
-		f  service.UserFactory
-		t  security.Token
+		db  *sql.DB
+		f   service.UserFactory
+		ef  service.EventFactory
+		sf  service.SessionFactory
+		mf  service.MFAFactory
+		idf service.UserIdentityFactory
+		tf  service.APITokenFactory
+		t   security.Token
 	}
 	tests := []struct {
 		name string
@@ -31,20 +39,30 @@ func TestNewUserUseCase(t *testing.T) {
 		{
 			name: "正常ケース",
 			args: args{
-				db: &sql.DB{},
-				f:  &mock_service.MockUserFactory{},
-				t:  &mock_security.MockToken{},
+				db:  &sql.DB{},
+				f:   &mock_service.MockUserFactory{},
+				ef:  &mock_service.MockEventFactory{},
+				sf:  &mock_service.MockSessionFactory{},
+				mf:  &mock_service.MockMFAFactory{},
+				idf: &mock_service.MockUserIdentityFactory{},
+				tf:  &mock_service.MockAPITokenFactory{},
+				t:   &mock_security.MockToken{},
 			},
 			want: &userUseCase{
-				db:                 &sql.DB{},
-				userServiceFactory: &mock_service.MockUserFactory{},
-				token:              &mock_security.MockToken{},
+				db:                    &sql.DB{},
+				userServiceFactory:    &mock_service.MockUserFactory{},
+				eventServiceFactory:   &mock_service.MockEventFactory{},
+				sessionServiceFactory: &mock_service.MockSessionFactory{},
+				mfaServiceFactory:     &mock_service.MockMFAFactory{},
+				identityFactory:       &mock_service.MockUserIdentityFactory{},
+				apiTokenFactory:       &mock_service.MockAPITokenFactory{},
+				token:                 &mock_security.MockToken{},
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewUserUseCase(tt.args.db, tt.args.f, tt.args.t); !reflect.DeepEqual(got, tt.want) {
+			if got := NewUserUseCase(tt.args.db, tt.args.f, tt.args.ef, tt.args.sf, tt.args.mf, tt.args.idf, tt.args.tf, tt.args.t); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewUserUseCase() = %v, want %v", got, tt.want)
 			}
 		})
@@ -55,6 +73,8 @@ func Test_userUseCase_Regist(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
 	type args struct {
 		user *dto.User
 		now  time.Time
@@ -79,19 +99,89 @@ func Test_userUseCase_Regist(t *testing.T) {
 				}(),
 				userServiceFactory: func() *mock_service.MockUserFactory {
 					svc := mock_service.NewMockUser(ctrl)
-					svc.EXPECT().Regist(gomock.Any(), gomock.Any()).Return(nil)
+					svc.EXPECT().Regist(gomock.Any(), now).Return(model.NewUser("1", "name", "email", "password", "salt"), nil)
 
 					mock := mock_service.NewMockUserFactory(ctrl)
 					mock.EXPECT().NewUserService(gomock.Any()).Return(svc)
 					return mock
 				}(),
+				eventServiceFactory: func() *mock_service.MockEventFactory {
+					svc := mock_service.NewMockEvent(ctrl)
+					svc.EXPECT().Emit(model.EventUserRegistered, "1", model.UserRegisteredData{UserID: "1", Name: "name"}, now).Return(nil)
+
+					mock := mock_service.NewMockEventFactory(ctrl)
+					mock.EXPECT().NewEventService(gomock.Any()).Return(svc)
+					return mock
+				}(),
 			},
 			args: args{
 				user: &dto.User{},
-				now:  time.Now(),
+				now:  now,
 			},
 			wantErr: false,
 		},
+		{
+			name: "異常ケース(登録失敗はイベントを発行せずロールバック)",
+			uc: &userUseCase{
+				db: func() *sql.DB {
+					db, mock, err := sqlmock.New()
+					if err != nil {
+						t.Fatalf("sqlmockの生成失敗(error: %v)", err)
+					}
+					mock.ExpectBegin()
+					mock.ExpectRollback()
+					return db
+				}(),
+				userServiceFactory: func() *mock_service.MockUserFactory {
+					svc := mock_service.NewMockUser(ctrl)
+					svc.EXPECT().Regist(gomock.Any(), now).Return(nil, errors.New("ng"))
+
+					mock := mock_service.NewMockUserFactory(ctrl)
+					mock.EXPECT().NewUserService(gomock.Any()).Return(svc)
+					return mock
+				}(),
+			},
+			args: args{
+				user: &dto.User{},
+				now:  now,
+			},
+			wantErr: true,
+		},
+		{
+			name: "異常ケース(イベントの発行失敗はロールバック)",
+			uc: &userUseCase{
+				db: func() *sql.DB {
+					db, mock, err := sqlmock.New()
+					if err != nil {
+						t.Fatalf("sqlmockの生成失敗(error: %v)", err)
+					}
+					mock.ExpectBegin()
+					mock.ExpectRollback()
+					return db
+				}(),
+				userServiceFactory: func() *mock_service.MockUserFactory {
+					svc := mock_service.NewMockUser(ctrl)
+					svc.EXPECT().Regist(gomock.Any(), now).Return(model.NewUser("1", "name", "email", "password", "salt"), nil)
+
+					mock := mock_service.NewMockUserFactory(ctrl)
+					mock.EXPECT().NewUserService(gomock.Any()).Return(svc)
+					return mock
+				}(),
+				eventServiceFactory: func() *mock_service.MockEventFactory {
+					svc := mock_service.NewMockEvent(ctrl)
+					svc.EXPECT().Emit(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("ng"))
+
+					mock := mock_service.NewMockEventFactory(ctrl)
+					mock.EXPECT().NewEventService(gomock.Any()).Return(svc)
+					return mock
+				}(),
+			},
+			args: args{
+				user: &dto.User{},
+				now:  now,
+			},
+			wantErr: true,
+		},
 		{
 			name: "異常ケース",
 			uc: &userUseCase{
@@ -113,26 +203,25 @@ func Test_userUseCase_Regist(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := tt.uc.Regist(tt.args.user, tt.args.now); (err != nil) != tt.wantErr {
+			if err := tt.uc.Regist(context.Background(), tt.args.user, tt.args.now); (err != nil) != tt.wantErr {
 				t.Errorf("userUseCase.Regist() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
 
-func Test_userUseCase_Authorize(t *testing.T) {
+func Test_userUseCase_FindByID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	type args struct {
-		email    string
-		password string
+		id string
 	}
 	tests := []struct {
 		name    string
 		uc      *userUseCase
 		args    args
-		want    string
+		want    *dto.User
 		wantErr bool
 	}{
 		{
@@ -148,88 +237,249 @@ func Test_userUseCase_Authorize(t *testing.T) {
 					return db
 				}(),
 				userServiceFactory: func() *mock_service.MockUserFactory {
-					mockUser := mock_model.NewMockUser(ctrl)
-					mockUser.EXPECT().ID().Return("id")
-
 					svc := mock_service.NewMockUser(ctrl)
-					svc.EXPECT().Authorize(gomock.Any(), gomock.Any()).Return(mockUser, nil)
+					svc.EXPECT().FindByID("1").Return(model.NewUser("1", "name", "email", "password", "salt"), nil)
 
 					mock := mock_service.NewMockUserFactory(ctrl)
 					mock.EXPECT().NewUserService(gomock.Any()).Return(svc)
 					return mock
 				}(),
-				token: func() *mock_security.MockToken {
-					token := mock_security.NewMockToken(ctrl)
-					token.EXPECT().Generate(gomock.Any(), gomock.Any()).Return("token", nil)
-					return token
-				}(),
 			},
 			args: args{
-				email:    "email",
-				password: "password",
+				id: "1",
+			},
+			want: &dto.User{
+				ID:       "1",
+				Name:     "name",
+				Email:    "email",
+				Password: "password",
+				Salt:     "salt",
 			},
-			want:    "token",
 			wantErr: false,
 		},
 		{
-			name: "異常ケース(ユーザー取得エラー)",
+			name: "異常ケース(ユーザー取得失敗)",
 			uc: &userUseCase{
 				db: func() *sql.DB {
 					db, mock, err := sqlmock.New()
 					if err != nil {
-						t.Fatalf("sqlmockの生成に失敗(error: %v)", err)
+						t.Fatalf("sqlmockの生成失敗(error: %v)", err)
 					}
-					mock.ExpectBegin().WillReturnError(errors.New("ng"))
+					mock.ExpectBegin()
+					mock.ExpectRollback()
 					return db
 				}(),
+				userServiceFactory: func() *mock_service.MockUserFactory {
+					svc := mock_service.NewMockUser(ctrl)
+					svc.EXPECT().FindByID("1").Return(nil, service.ErrUserNotFound)
+
+					mock := mock_service.NewMockUserFactory(ctrl)
+					mock.EXPECT().NewUserService(gomock.Any()).Return(svc)
+					return mock
+				}(),
 			},
 			args: args{
-				email:    "email",
-				password: "password",
+				id: "1",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.uc.FindByID(context.Background(), tt.args.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("userUseCase.FindByID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("userUseCase.FindByID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_userUseCase_Authorize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// userFactory 認証に成功するユーザーサービスのファクトリーを生成する
+	userFactory := func() *mock_service.MockUserFactory {
+		mockUser := mock_model.NewMockUser(ctrl)
+		mockUser.EXPECT().ID().Return("id").AnyTimes()
+
+		svc := mock_service.NewMockUser(ctrl)
+		svc.EXPECT().Authorize("email", "password").Return(mockUser, nil)
+
+		mock := mock_service.NewMockUserFactory(ctrl)
+		mock.EXPECT().NewUserService(gomock.Any()).Return(svc)
+		return mock
+	}
+	// tokenTTL 有効期限を返すトークンのモックを生成する
+	tokenTTL := func() *mock_security.MockToken {
+		token := mock_security.NewMockToken(ctrl)
+		token.EXPECT().TTL().Return(time.Hour)
+		return token
+	}
+	// mfaDisabled 2要素認証を有効にしていない2要素認証サービスのファクトリーを生成する
+	mfaDisabled := func() *mock_service.MockMFAFactory {
+		svc := mock_service.NewMockMFA(ctrl)
+		svc.EXPECT().IsEnabled("id").Return(false, nil)
+		return mfaFactory(ctrl, svc)
+	}
+	session := model.NewSession("sid", "id", "", "192.0.2.1", "ua", time.Time{}, time.Time{}, time.Time{}, time.Time{})
+	expires := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		uc            *userUseCase
+		want          string
+		wantChallenge *dto.MFAChallenge
+		wantErr       bool
+	}{
+		{
+			name: "正常ケース",
+			uc: &userUseCase{
+				db:                 testDB(t, true),
+				userServiceFactory: userFactory(),
+				mfaServiceFactory:  mfaDisabled(),
+				sessionServiceFactory: func() *mock_service.MockSessionFactory {
+					svc := mock_service.NewMockSession(ctrl)
+					svc.EXPECT().Start("id", "192.0.2.1", "ua", gomock.Any(), gomock.Any()).
+						DoAndReturn(func(_, _, _ string, now time.Time, expiresAt time.Time) (model.Session, error) {
+							if !expiresAt.Equal(now.Add(time.Hour)) {
+								t.Errorf("有効期限不一致 got: %v want: %v", expiresAt, now.Add(time.Hour))
+							}
+							return session, nil
+						})
+					return sessionFactory(ctrl, svc)
+				}(),
+				token: func() *mock_security.MockToken {
+					token := tokenTTL()
+					token.EXPECT().Generate("id", "sid", gomock.Any()).Return("token", nil)
+					return token
+				}(),
+			},
+			want:    "token",
+			wantErr: false,
+		},
+		{
+			name: "正常ケース(2要素認証)",
+			uc: &userUseCase{
+				db:                 testDB(t, true),
+				userServiceFactory: userFactory(),
+				mfaServiceFactory: func() *mock_service.MockMFAFactory {
+					svc := mock_service.NewMockMFA(ctrl)
+					svc.EXPECT().IsEnabled("id").Return(true, nil)
+					svc.EXPECT().IssueChallenge("id", gomock.Any()).
+						Return("mfa-token", model.NewMFAChallenge("hash", "id", 0, expires, time.Time{}), nil)
+					return mfaFactory(ctrl, svc)
+				}(),
+			},
+			want:          "",
+			wantChallenge: &dto.MFAChallenge{MFARequired: true, MFAToken: "mfa-token", ExpiresAt: expires},
+			wantErr:       false,
+		},
+		{
+			name: "異常ケース(2要素認証の設定取得エラー)",
+			uc: &userUseCase{
+				db:                 testDB(t, false),
+				userServiceFactory: userFactory(),
+				mfaServiceFactory: func() *mock_service.MockMFAFactory {
+					svc := mock_service.NewMockMFA(ctrl)
+					svc.EXPECT().IsEnabled("id").Return(false, errors.New("ng"))
+					return mfaFactory(ctrl, svc)
+				}(),
 			},
 			want:    "",
 			wantErr: true,
 		},
 		{
-			name: "異常ケース(トークン生成エラー)",
+			name: "異常ケース(チャレンジ発行エラー)",
+			uc: &userUseCase{
+				db:                 testDB(t, false),
+				userServiceFactory: userFactory(),
+				mfaServiceFactory: func() *mock_service.MockMFAFactory {
+					svc := mock_service.NewMockMFA(ctrl)
+					svc.EXPECT().IsEnabled("id").Return(true, nil)
+					svc.EXPECT().IssueChallenge("id", gomock.Any()).Return("", nil, errors.New("ng"))
+					return mfaFactory(ctrl, svc)
+				}(),
+			},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "異常ケース(トランザクション開始エラー)",
 			uc: &userUseCase{
 				db: func() *sql.DB {
 					db, mock, err := sqlmock.New()
 					if err != nil {
-						t.Fatalf("sqlmockの生成失敗(error: %v)", err)
+						t.Fatalf("sqlmockの生成に失敗(error: %v)", err)
 					}
-					mock.ExpectBegin()
-					mock.ExpectCommit()
+					mock.ExpectBegin().WillReturnError(errors.New("ng"))
 					return db
 				}(),
+			},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "異常ケース(認証エラー)",
+			uc: &userUseCase{
+				db: testDB(t, false),
 				userServiceFactory: func() *mock_service.MockUserFactory {
-					mockUser := mock_model.NewMockUser(ctrl)
-					mockUser.EXPECT().ID().Return("id")
-
 					svc := mock_service.NewMockUser(ctrl)
-					svc.EXPECT().Authorize(gomock.Any(), gomock.Any()).Return(mockUser, nil)
+					svc.EXPECT().Authorize("email", "password").Return(nil, service.ErrAuthorizeFail)
 
 					mock := mock_service.NewMockUserFactory(ctrl)
 					mock.EXPECT().NewUserService(gomock.Any()).Return(svc)
 					return mock
 				}(),
+			},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "異常ケース(セッション登録エラー)",
+			uc: &userUseCase{
+				db:                 testDB(t, false),
+				userServiceFactory: userFactory(),
+				mfaServiceFactory:  mfaDisabled(),
+				sessionServiceFactory: func() *mock_service.MockSessionFactory {
+					svc := mock_service.NewMockSession(ctrl)
+					svc.EXPECT().Start("id", "192.0.2.1", "ua", gomock.Any(), gomock.Any()).Return(nil, errors.New("ng"))
+					return sessionFactory(ctrl, svc)
+				}(),
+				token: tokenTTL(),
+			},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "異常ケース(トークン生成エラー)",
+			uc: &userUseCase{
+				db:                 testDB(t, false),
+				userServiceFactory: userFactory(),
+				mfaServiceFactory:  mfaDisabled(),
+				sessionServiceFactory: func() *mock_service.MockSessionFactory {
+					svc := mock_service.NewMockSession(ctrl)
+					svc.EXPECT().Start("id", "192.0.2.1", "ua", gomock.Any(), gomock.Any()).Return(session, nil)
+					return sessionFactory(ctrl, svc)
+				}(),
 				token: func() *mock_security.MockToken {
-					token := mock_security.NewMockToken(ctrl)
-					token.EXPECT().Generate(gomock.Any(), gomock.Any()).Return("", errors.New("ng"))
+					token := tokenTTL()
+					token.EXPECT().Generate("id", "sid", gomock.Any()).Return("", errors.New("ng"))
 					return token
 				}(),
 			},
-			args: args{
-				email:    "email",
-				password: "password",
-			},
 			want:    "",
 			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := tt.uc.Authorize(tt.args.email, tt.args.password)
+			got, challenge, err := tt.uc.Authorize(context.Background(), "email", "password", "192.0.2.1", "ua")
 			if (err != nil) != tt.wantErr {
 				t.Errorf("userUseCase.Authorize() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -237,6 +487,238 @@ func Test_userUseCase_Authorize(t *testing.T) {
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("userUseCase.Authorize() = %v, want %v", got, tt.want)
 			}
+			if !reflect.DeepEqual(challenge, tt.wantChallenge) {
+				t.Errorf("userUseCase.Authorize() challenge = %v, want %v", challenge, tt.wantChallenge)
+			}
+		})
+	}
+}
+
+func Test_userUseCase_AuthorizeExternal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	identity := &oidc.Identity{Issuer: "https://idp.example.com", Subject: "sub-1", Email: "a@example.com", EmailVerified: true, Name: "Taro"}
+	user := model.NewUser("id", "Taro", "a@example.com", "pw", "salt")
+	session := model.NewSession("sid", "id", "", "192.0.2.1", "ua", time.Time{}, time.Time{}, time.Time{}, time.Time{})
+
+	// identityFactory 外部アカウントを解決する外部アカウントサービスのファクトリーを生成する
+	identityFactory := func(created bool, err error) *mock_service.MockUserIdentityFactory {
+		svc := mock_service.NewMockUserIdentity(ctrl)
+		if err != nil {
+			svc.EXPECT().Resolve("https://idp.example.com", "sub-1", "a@example.com", true, "Taro", gomock.Any()).Return(nil, false, err)
+		} else {
+			svc.EXPECT().Resolve("https://idp.example.com", "sub-1", "a@example.com", true, "Taro", gomock.Any()).Return(user, created, nil)
+		}
+
+		mock := mock_service.NewMockUserIdentityFactory(ctrl)
+		mock.EXPECT().NewUserIdentityService(gomock.Any(), gomock.Any()).Return(svc)
+		return mock
+	}
+	// eventFactory UserRegisteredイベントを発行するイベントサービスのファクトリーを生成する
+	eventFactory := func(err error) *mock_service.MockEventFactory {
+		svc := mock_service.NewMockEvent(ctrl)
+		svc.EXPECT().Emit(model.EventUserRegistered, "id", model.UserRegisteredData{UserID: "id", Name: "Taro"}, gomock.Any()).Return(err)
+
+		mock := mock_service.NewMockEventFactory(ctrl)
+		mock.EXPECT().NewEventService(gomock.Any()).Return(svc)
+		return mock
+	}
+	// mfaDisabled 2要素認証を有効にしていない2要素認証サービスのファクトリーを生成する
+	mfaDisabled := func() *mock_service.MockMFAFactory {
+		svc := mock_service.NewMockMFA(ctrl)
+		svc.EXPECT().IsEnabled("id").Return(false, nil)
+		return mfaFactory(ctrl, svc)
+	}
+	// sessionStarted ログインセッションを登録するセッションサービスのファクトリーを生成する
+	sessionStarted := func() *mock_service.MockSessionFactory {
+		svc := mock_service.NewMockSession(ctrl)
+		svc.EXPECT().Start("id", "192.0.2.1", "ua", gomock.Any(), gomock.Any()).Return(session, nil)
+		return sessionFactory(ctrl, svc)
+	}
+	// tokenGenerated トークンを発行するトークンのモックを生成する
+	tokenGenerated := func() *mock_security.MockToken {
+		token := mock_security.NewMockToken(ctrl)
+		token.EXPECT().TTL().Return(time.Hour)
+		token.EXPECT().Generate("id", "sid", gomock.Any()).Return("token", nil)
+		return token
+	}
+	expires := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		uc            *userUseCase
+		want          string
+		wantChallenge *dto.MFAChallenge
+		wantErr       error
+	}{
+		{
+			name: "正常ケース(紐づけ済み)",
+			uc: &userUseCase{
+				db:                    testDB(t, true),
+				identityFactory:       identityFactory(false, nil),
+				mfaServiceFactory:     mfaDisabled(),
+				sessionServiceFactory: sessionStarted(),
+				token:                 tokenGenerated(),
+			},
+			want: "token",
+		},
+		{
+			name: "正常ケース(ユーザー登録)",
+			uc: &userUseCase{
+				db:                    testDB(t, true),
+				identityFactory:       identityFactory(true, nil),
+				eventServiceFactory:   eventFactory(nil),
+				mfaServiceFactory:     mfaDisabled(),
+				sessionServiceFactory: sessionStarted(),
+				token:                 tokenGenerated(),
+			},
+			want: "token",
+		},
+		{
+			name: "正常ケース(2要素認証)",
+			uc: &userUseCase{
+				db:              testDB(t, true),
+				identityFactory: identityFactory(false, nil),
+				mfaServiceFactory: func() *mock_service.MockMFAFactory {
+					svc := mock_service.NewMockMFA(ctrl)
+					svc.EXPECT().IsEnabled("id").Return(true, nil)
+					svc.EXPECT().IssueChallenge("id", gomock.Any()).
+						Return("mfa-token", model.NewMFAChallenge("hash", "id", 0, expires, time.Time{}), nil)
+					return mfaFactory(ctrl, svc)
+				}(),
+			},
+			wantChallenge: &dto.MFAChallenge{MFARequired: true, MFAToken: "mfa-token", ExpiresAt: expires},
+		},
+		{
+			name: "異常ケース(メールアドレス未確認)",
+			uc: &userUseCase{
+				db:              testDB(t, false),
+				identityFactory: identityFactory(false, service.ErrIdentityEmailNotVerified),
+			},
+			wantErr: service.ErrIdentityEmailNotVerified,
+		},
+		{
+			name: "異常ケース(イベント発行エラー)",
+			uc: &userUseCase{
+				db:                  testDB(t, false),
+				identityFactory:     identityFactory(true, nil),
+				eventServiceFactory: eventFactory(errTest),
+			},
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, challenge, err := tt.uc.AuthorizeExternal(context.Background(), identity, "192.0.2.1", "ua")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+			if !reflect.DeepEqual(challenge, tt.wantChallenge) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", challenge, tt.wantChallenge)
+			}
+		})
+	}
+}
+
+func Test_userUseCase_VerifyMFA(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	session := model.NewSession("sid", "id", "", "192.0.2.1", "ua", time.Time{}, time.Time{}, time.Time{}, time.Time{})
+
+	tests := []struct {
+		name    string
+		uc      *userUseCase
+		want    string
+		wantErr error
+	}{
+		{
+			name: "正常ケース",
+			uc: &userUseCase{
+				db: testDB(t, true),
+				mfaServiceFactory: func() *mock_service.MockMFAFactory {
+					svc := mock_service.NewMockMFA(ctrl)
+					svc.EXPECT().VerifyChallenge("mfa-token", "123456", gomock.Any()).Return("id", true, nil)
+					return mfaFactory(ctrl, svc)
+				}(),
+				sessionServiceFactory: func() *mock_service.MockSessionFactory {
+					svc := mock_service.NewMockSession(ctrl)
+					svc.EXPECT().Start("id", "192.0.2.1", "ua", gomock.Any(), gomock.Any()).Return(session, nil)
+					return sessionFactory(ctrl, svc)
+				}(),
+				token: func() *mock_security.MockToken {
+					token := mock_security.NewMockToken(ctrl)
+					token.EXPECT().TTL().Return(time.Hour)
+					token.EXPECT().Generate("id", "sid", gomock.Any()).Return("token", nil)
+					return token
+				}(),
+			},
+			want:    "token",
+			wantErr: nil,
+		},
+		{
+			name: "異常ケース(コード不一致、試行回数は確定させる)",
+			uc: &userUseCase{
+				db: testDB(t, true),
+				mfaServiceFactory: func() *mock_service.MockMFAFactory {
+					svc := mock_service.NewMockMFA(ctrl)
+					svc.EXPECT().VerifyChallenge("mfa-token", "123456", gomock.Any()).Return("", false, nil)
+					return mfaFactory(ctrl, svc)
+				}(),
+			},
+			want:    "",
+			wantErr: service.ErrInvalidMFACode,
+		},
+		{
+			name: "異常ケース(チャレンジ無効)",
+			uc: &userUseCase{
+				db: testDB(t, false),
+				mfaServiceFactory: func() *mock_service.MockMFAFactory {
+					svc := mock_service.NewMockMFA(ctrl)
+					svc.EXPECT().VerifyChallenge("mfa-token", "123456", gomock.Any()).Return("", false, service.ErrMFAChallengeInvalid)
+					return mfaFactory(ctrl, svc)
+				}(),
+			},
+			want:    "",
+			wantErr: service.ErrMFAChallengeInvalid,
+		},
+		{
+			name: "異常ケース(セッション登録エラー)",
+			uc: &userUseCase{
+				db: testDB(t, false),
+				mfaServiceFactory: func() *mock_service.MockMFAFactory {
+					svc := mock_service.NewMockMFA(ctrl)
+					svc.EXPECT().VerifyChallenge("mfa-token", "123456", gomock.Any()).Return("id", true, nil)
+					return mfaFactory(ctrl, svc)
+				}(),
+				sessionServiceFactory: func() *mock_service.MockSessionFactory {
+					svc := mock_service.NewMockSession(ctrl)
+					svc.EXPECT().Start("id", "192.0.2.1", "ua", gomock.Any(), gomock.Any()).Return(nil, errTest)
+					return sessionFactory(ctrl, svc)
+				}(),
+				token: func() *mock_security.MockToken {
+					token := mock_security.NewMockToken(ctrl)
+					token.EXPECT().TTL().Return(time.Hour)
+					return token
+				}(),
+			},
+			want:    "",
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.uc.VerifyMFA(context.Background(), "mfa-token", "123456", "192.0.2.1", "ua")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
 		})
 	}
 }
@@ -245,9 +727,11 @@ func Test_userUseCase_Update(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	bio := "bio"
+
 	type args struct {
-		user *dto.User
-		now  time.Time
+		req *dto.UserUpdateRequest
+		now time.Time
 	}
 	tests := []struct {
 		name    string
@@ -269,7 +753,7 @@ func Test_userUseCase_Update(t *testing.T) {
 				}(),
 				userServiceFactory: func() *mock_service.MockUserFactory {
 					svc := mock_service.NewMockUser(ctrl)
-					svc.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+					svc.EXPECT().Update("email", model.UserPatch{Bio: &bio}, gomock.Any()).Return(nil)
 
 					mock := mock_service.NewMockUserFactory(ctrl)
 					mock.EXPECT().NewUserService(gomock.Any()).Return(svc)
@@ -277,8 +761,8 @@ func Test_userUseCase_Update(t *testing.T) {
 				}(),
 			},
 			args: args{
-				user: &dto.User{},
-				now:  time.Now(),
+				req: &dto.UserUpdateRequest{Email: "email", Bio: &bio},
+				now: time.Now(),
 			},
 			wantErr: false,
 		},
@@ -295,15 +779,15 @@ func Test_userUseCase_Update(t *testing.T) {
 				}(),
 			},
 			args: args{
-				user: &dto.User{},
-				now:  time.Now(),
+				req: &dto.UserUpdateRequest{},
+				now: time.Now(),
 			},
 			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := tt.uc.Update(tt.args.user, tt.args.now); (err != nil) != tt.wantErr {
+			if err := tt.uc.Update(context.Background(), tt.args.req, tt.args.now); (err != nil) != tt.wantErr {
 				t.Errorf("userUseCase.Update() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -369,7 +853,7 @@ func Test_userUseCase_Delete(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := tt.uc.Delete(tt.args.user); (err != nil) != tt.wantErr {
+			if err := tt.uc.Delete(context.Background(), tt.args.user); (err != nil) != tt.wantErr {
 				t.Errorf("userUseCase.Delete() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -380,52 +864,165 @@ func Test_userUseCase_VerifyAuthorization(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	type args struct {
-		token  string
-		userID string
+	// verified トークンの検証に成功するモックを生成する
+	verified := func() *mock_security.MockToken {
+		mock := mock_security.NewMockToken(ctrl)
+		mock.EXPECT().Verify("token").Return("userID", "sid", true)
+		return mock
 	}
+
 	tests := []struct {
-		name string
-		uc   *userUseCase
-		args args
-		want bool
+		name          string
+		uc            *userUseCase
+		wantUserID    string
+		wantSessionID string
+		want          bool
 	}{
 		{
 			name: "検証成功",
 			uc: &userUseCase{
-				token: func() *mock_security.MockToken {
-					mock := mock_security.NewMockToken(ctrl)
-					mock.EXPECT().Verify(gomock.Any()).Return(true)
-					return mock
+				db: testDB(t, true),
+				sessionServiceFactory: func() *mock_service.MockSessionFactory {
+					svc := mock_service.NewMockSession(ctrl)
+					svc.EXPECT().Verify("sid", "userID", gomock.Any()).Return(nil)
+					return sessionFactory(ctrl, svc)
 				}(),
+				token: verified(),
 			},
-			args: args{
-				token:  "token",
-				userID: "userID",
-			},
-			want: true,
+			wantUserID:    "userID",
+			wantSessionID: "sid",
+			want:          true,
 		},
 		{
-			name: "検証失敗",
+			name: "検証失敗(トークン不正)",
 			uc: &userUseCase{
 				token: func() *mock_security.MockToken {
 					mock := mock_security.NewMockToken(ctrl)
-					mock.EXPECT().Verify(gomock.Any()).Return(false)
+					mock.EXPECT().Verify("token").Return("", "", false)
 					return mock
 				}(),
 			},
-			args: args{
-				token:  "token",
-				userID: "userID",
+			want: false,
+		},
+		{
+			name: "検証失敗(失効したセッション)",
+			uc: &userUseCase{
+				db: testDB(t, false),
+				sessionServiceFactory: func() *mock_service.MockSessionFactory {
+					svc := mock_service.NewMockSession(ctrl)
+					svc.EXPECT().Verify("sid", "userID", gomock.Any()).Return(service.ErrSessionRevoked)
+					return sessionFactory(ctrl, svc)
+				}(),
+				token: verified(),
+			},
+			want: false,
+		},
+		{
+			name: "検証失敗(セッション取得エラー)",
+			uc: &userUseCase{
+				db: testDB(t, false),
+				sessionServiceFactory: func() *mock_service.MockSessionFactory {
+					svc := mock_service.NewMockSession(ctrl)
+					svc.EXPECT().Verify("sid", "userID", gomock.Any()).Return(errors.New("ng"))
+					return sessionFactory(ctrl, svc)
+				}(),
+				token: verified(),
 			},
 			want: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := tt.uc.VerifyAuthorization(tt.args.token); got != tt.want {
+			gotUserID, gotSessionID, got := tt.uc.VerifyAuthorization(context.Background(), "token")
+			if got != tt.want {
 				t.Errorf("userUseCase.VerifyAuthorization() = %v, want %v", got, tt.want)
 			}
+			if gotUserID != tt.wantUserID {
+				t.Errorf("userUseCase.VerifyAuthorization() userID = %v, want %v", gotUserID, tt.wantUserID)
+			}
+			if gotSessionID != tt.wantSessionID {
+				t.Errorf("userUseCase.VerifyAuthorization() sessionID = %v, want %v", gotSessionID, tt.wantSessionID)
+			}
+		})
+	}
+}
+
+func Test_userUseCase_VerifyAPIToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	token := "gbp_secret"
+	expires := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name       string
+		token      string
+		uc         *userUseCase
+		wantUserID string
+		wantScopes []string
+		want       bool
+	}{
+		{
+			name:  "検証成功",
+			token: token,
+			uc: &userUseCase{
+				db: testDB(t, true),
+				apiTokenFactory: func() *mock_service.MockAPITokenFactory {
+					svc := mock_service.NewMockAPIToken(ctrl)
+					svc.EXPECT().Verify(token, gomock.Any()).
+						Return(model.NewAPIToken("t1", "userID", "ci-bot", "hash", []string{"read", "post"}, expires, time.Time{}, time.Now()), nil)
+					return apiTokenFactory(ctrl, svc)
+				}(),
+			},
+			wantUserID: "userID",
+			wantScopes: []string{"read", "post"},
+			want:       true,
+		},
+		{
+			name:  "検証失敗(個人用アクセストークンではない)",
+			token: "eyJhbGciOiJIUzI1NiJ9.e30.sig",
+			uc:    &userUseCase{},
+			want:  false,
+		},
+		{
+			name:  "検証失敗(トークン不正)",
+			token: token,
+			uc: &userUseCase{
+				db: testDB(t, false),
+				apiTokenFactory: func() *mock_service.MockAPITokenFactory {
+					svc := mock_service.NewMockAPIToken(ctrl)
+					svc.EXPECT().Verify(token, gomock.Any()).Return(nil, service.ErrAPITokenInvalid)
+					return apiTokenFactory(ctrl, svc)
+				}(),
+			},
+			want: false,
+		},
+		{
+			name:  "検証失敗(取得エラー)",
+			token: token,
+			uc: &userUseCase{
+				db: testDB(t, false),
+				apiTokenFactory: func() *mock_service.MockAPITokenFactory {
+					svc := mock_service.NewMockAPIToken(ctrl)
+					svc.EXPECT().Verify(token, gomock.Any()).Return(nil, errors.New("ng"))
+					return apiTokenFactory(ctrl, svc)
+				}(),
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotUserID, gotScopes, got := tt.uc.VerifyAPIToken(context.Background(), tt.token)
+			if got != tt.want {
+				t.Errorf("userUseCase.VerifyAPIToken() = %v, want %v", got, tt.want)
+			}
+			if gotUserID != tt.wantUserID {
+				t.Errorf("userUseCase.VerifyAPIToken() userID = %v, want %v", gotUserID, tt.wantUserID)
+			}
+			if !reflect.DeepEqual(gotScopes, tt.wantScopes) {
+				t.Errorf("userUseCase.VerifyAPIToken() scopes = %v, want %v", gotScopes, tt.wantScopes)
+			}
 		})
 	}
 }
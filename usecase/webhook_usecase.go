@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"GoBBS/domain/model"
+	"GoBBS/domain/service"
+	"GoBBS/dto"
+	"GoBBS/interface/dao"
+	"GoBBS/interface/webhook"
+)
+
+// Webhook Webhookユースケース
+// mockgen -source usecase/webhook_usecase.go -destination mock/mock_usecase/webhook_usecase_mock.go
+type Webhook interface {
+	Regist(ctx context.Context, boardID string, req *dto.WebhookRequest, now time.Time) (*dto.Webhook, error)
+	List(ctx context.Context, boardID string) ([]*dto.Webhook, error)
+	Delete(ctx context.Context, id string) error
+	Deliveries(ctx context.Context, webhookID string, beforeID string, limit int) ([]*dto.WebhookDelivery, error)
+	DeliverDue(ctx context.Context, now time.Time) (int, error)
+	ForwardEvent(ctx context.Context, event model.Event) error
+}
+
+type webhookUseCase struct {
+	db                    *sql.DB
+	webhookServiceFactory service.WebhookFactory
+	sender                webhook.Sender
+}
+
+// webhookDispatch 送信する配信と送信先のWebhook
+type webhookDispatch struct {
+	webhook  model.Webhook
+	delivery model.WebhookDelivery
+}
+
+var _ Webhook = (*webhookUseCase)(nil)
+
+// webhookBatchSize 1回の配信処理で送信する配信の上限
+const webhookBatchSize = 50
+
+// webhookEvents 板のWebhookに転送するドメインイベント
+var webhookEvents = map[model.EventType]model.WebhookEvent{
+	model.EventThreadCreated: model.WebhookThreadCreated,
+	model.EventPostCreated:   model.WebhookPostCreated,
+	model.EventPostHidden:    model.WebhookPostHidden,
+}
+
+// NewWebhookUseCase Webhookユースケースを生成する
+func NewWebhookUseCase(db *sql.DB, f service.WebhookFactory, sender webhook.Sender) *webhookUseCase {
+	return &webhookUseCase{
+		db:                    db,
+		webhookServiceFactory: f,
+		sender:                sender,
+	}
+}
+
+// Regist 板にWebhookを登録する(署名用の秘密鍵は登録時にだけ返す)
+func (uc *webhookUseCase) Regist(ctx context.Context, boardID string, req *dto.WebhookRequest, now time.Time) (*dto.Webhook, error) {
+	ctx, span := tracer.Start(ctx, "Webhook.Regist")
+	defer span.End()
+
+	return dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) (*dto.Webhook, error) {
+			w, err := uc.service(tx).Regist(boardID, req.URL, req.MapWebhookEvents(), now)
+			if err != nil {
+				return nil, err
+			}
+			return dto.NewCreatedWebhook(w), nil
+		},
+	)
+}
+
+// List 板のWebhook一覧を取得する
+func (uc *webhookUseCase) List(ctx context.Context, boardID string) ([]*dto.Webhook, error) {
+	ctx, span := tracer.Start(ctx, "Webhook.List")
+	defer span.End()
+
+	return dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) ([]*dto.Webhook, error) {
+			webhooks, err := uc.service(tx).List(boardID)
+			if err != nil {
+				return nil, err
+			}
+			return dto.NewWebhooks(webhooks), nil
+		},
+	)
+}
+
+// Delete Webhookを削除する
+func (uc *webhookUseCase) Delete(ctx context.Context, id string) error {
+	ctx, span := tracer.Start(ctx, "Webhook.Delete")
+	defer span.End()
+
+	_, err := dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) (any, error) {
+			return nil, uc.service(tx).Delete(id)
+		},
+	)
+
+	return err
+}
+
+// Deliveries Webhookの配信ログを取得する
+func (uc *webhookUseCase) Deliveries(ctx context.Context, webhookID string, beforeID string, limit int) ([]*dto.WebhookDelivery, error) {
+	ctx, span := tracer.Start(ctx, "Webhook.Deliveries")
+	defer span.End()
+
+	return dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) ([]*dto.WebhookDelivery, error) {
+			deliveries, err := uc.service(tx).Deliveries(webhookID, beforeID, limit)
+			if err != nil {
+				return nil, err
+			}
+			return dto.NewWebhookDeliveries(deliveries), nil
+		},
+	)
+}
+
+// DeliverDue 送信時刻に達した配信を送信し、送信に成功した件数を返す
+// 送信はtxの外で行い、結果を配信ごとに記録する(記録前に停止した場合は再送されるため、受信側は配信IDで重複を除く)
+func (uc *webhookUseCase) DeliverDue(ctx context.Context, now time.Time) (int, error) {
+	ctx, span := tracer.Start(ctx, "Webhook.DeliverDue")
+	defer span.End()
+
+	dispatches, err := dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) ([]webhookDispatch, error) {
+			webhookService := uc.service(tx)
+			deliveries, err := webhookService.ClaimDue(now, webhookBatchSize)
+			if err != nil {
+				return nil, err
+			}
+
+			webhooks := map[string]model.Webhook{}
+			dispatches := make([]webhookDispatch, 0, len(deliveries))
+			for _, d := range deliveries {
+				w, ok := webhooks[d.WebhookID()]
+				if !ok {
+					if w, err = webhookService.Find(d.WebhookID()); err != nil {
+						return nil, err
+					}
+					webhooks[d.WebhookID()] = w
+				}
+				dispatches = append(dispatches, webhookDispatch{webhook: w, delivery: d})
+			}
+
+			return dispatches, nil
+		},
+	)
+	if err != nil {
+		return 0, errors.Wrap(err, "DeliverDue error")
+	}
+
+	succeeded := 0
+	for _, d := range dispatches {
+		statusCode, sendErr := uc.sender.Send(d.webhook, d.delivery)
+		delivery, err := dao.ExecWithTx(
+			ctx,
+			uc.db,
+			func(tx *sql.Tx) (model.WebhookDelivery, error) {
+				return uc.service(tx).RecordAttempt(d.delivery, statusCode, sendErr, now)
+			},
+		)
+		if err != nil {
+			// 1件の記録の失敗で他の配信を止めない(記録できなかった配信は取得時に先送りした時刻に再送される)
+			slog.Error("record webhook attempt error", "delivery_id", d.delivery.ID(), "error", err)
+			continue
+		}
+		if delivery.Status() == model.WebhookDeliverySucceeded {
+			succeeded++
+		}
+	}
+
+	return succeeded, nil
+}
+
+// ForwardEvent 板のドメインイベントを、そのイベントを通知する設定のWebhookの配信として登録する
+// イベントのペイロードをそのままWebhookのdataとして送信する
+func (uc *webhookUseCase) ForwardEvent(ctx context.Context, event model.Event) error {
+	ctx, span := tracer.Start(ctx, "Webhook.ForwardEvent")
+	defer span.End()
+
+	webhookEvent, ok := webhookEvents[event.Type()]
+	if !ok {
+		return nil
+	}
+
+	var target struct {
+		BoardID string `json:"board_id"`
+	}
+	if err := json.Unmarshal(event.Payload(), &target); err != nil {
+		return errors.Wrap(err, "ForwardEvent error")
+	}
+
+	_, err := dao.ExecWithTx(
+		ctx,
+		uc.db,
+		func(tx *sql.Tx) (int, error) {
+			return uc.service(tx).Enqueue(event.ID(), target.BoardID, webhookEvent, json.RawMessage(event.Payload()), event.OccurredAt())
+		},
+	)
+
+	return err
+}
+
+// service トランザクションに紐づくWebhookサービスを生成する
+func (uc *webhookUseCase) service(tx *sql.Tx) service.Webhook {
+	return uc.webhookServiceFactory.NewWebhookService(dao.NewWebhookDAO(tx))
+}
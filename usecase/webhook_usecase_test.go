@@ -0,0 +1,398 @@
+package usecase
+
+import (
+	"GoBBS/domain/model"
+	"GoBBS/domain/service"
+	"GoBBS/dto"
+	"GoBBS/mock/mock_service"
+	"GoBBS/mock/mock_webhook"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+// webhookFactory Webhookサービスを返すファクトリーのモックを生成する
+func webhookFactory(ctrl *gomock.Controller, svc *mock_service.MockWebhook) *mock_service.MockWebhookFactory {
+	mock := mock_service.NewMockWebhookFactory(ctrl)
+	mock.EXPECT().NewWebhookService(gomock.Any()).Return(svc).AnyTimes()
+	return mock
+}
+
+func TestNewWebhookUseCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db := &sql.DB{}
+	f := mock_service.NewMockWebhookFactory(ctrl)
+	sender := mock_webhook.NewMockSender(ctrl)
+
+	want := &webhookUseCase{db: db, webhookServiceFactory: f, sender: sender}
+	if got := NewWebhookUseCase(db, f, sender); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewWebhookUseCase() = %v, want %v", got, want)
+	}
+}
+
+func Test_webhookUseCase_Regist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	req := &dto.WebhookRequest{URL: "https://example.com/hook", Events: []string{"post.created"}}
+	events := []model.WebhookEvent{model.WebhookPostCreated}
+
+	tests := []struct {
+		name    string
+		uc      *webhookUseCase
+		want    *dto.Webhook
+		wantErr error
+	}{
+		{
+			name: "正常ケース(秘密鍵を返す)",
+			uc: &webhookUseCase{
+				db: testDB(t, true),
+				webhookServiceFactory: func() *mock_service.MockWebhookFactory {
+					svc := mock_service.NewMockWebhook(ctrl)
+					svc.EXPECT().Regist("2", "https://example.com/hook", events, now).
+						Return(model.NewWebhook("1", "2", "https://example.com/hook", "secret", events, now), nil)
+					return webhookFactory(ctrl, svc)
+				}(),
+			},
+			want: &dto.Webhook{
+				ID:        "1",
+				BoardID:   "2",
+				URL:       "https://example.com/hook",
+				Events:    []string{"post.created"},
+				Secret:    "secret",
+				CreatedAt: now,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "異常ケース",
+			uc: &webhookUseCase{
+				db: testDB(t, false),
+				webhookServiceFactory: func() *mock_service.MockWebhookFactory {
+					svc := mock_service.NewMockWebhook(ctrl)
+					svc.EXPECT().Regist("2", "https://example.com/hook", events, now).Return(nil, service.ErrInvalidWebhookURL)
+					return webhookFactory(ctrl, svc)
+				}(),
+			},
+			want:    nil,
+			wantErr: service.ErrInvalidWebhookURL,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.uc.Regist(context.Background(), "2", req, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_webhookUseCase_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		uc      *webhookUseCase
+		want    []*dto.Webhook
+		wantErr error
+	}{
+		{
+			name: "正常ケース(秘密鍵は返さない)",
+			uc: &webhookUseCase{
+				db: testDB(t, true),
+				webhookServiceFactory: func() *mock_service.MockWebhookFactory {
+					svc := mock_service.NewMockWebhook(ctrl)
+					svc.EXPECT().List("2").Return([]model.Webhook{
+						model.NewWebhook("1", "2", "https://example.com/hook", "secret", []model.WebhookEvent{model.WebhookPostHidden}, now),
+					}, nil)
+					return webhookFactory(ctrl, svc)
+				}(),
+			},
+			want: []*dto.Webhook{
+				{ID: "1", BoardID: "2", URL: "https://example.com/hook", Events: []string{"post.hidden"}, CreatedAt: now},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "異常ケース",
+			uc: &webhookUseCase{
+				db: testDB(t, false),
+				webhookServiceFactory: func() *mock_service.MockWebhookFactory {
+					svc := mock_service.NewMockWebhook(ctrl)
+					svc.EXPECT().List("2").Return(nil, errTest)
+					return webhookFactory(ctrl, svc)
+				}(),
+			},
+			want:    nil,
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.uc.List(context.Background(), "2")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_webhookUseCase_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name    string
+		commit  bool
+		err     error
+		wantErr error
+	}{
+		{name: "正常ケース", commit: true, err: nil, wantErr: nil},
+		{name: "異常ケース", commit: false, err: service.ErrWebhookNotFound, wantErr: service.ErrWebhookNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := mock_service.NewMockWebhook(ctrl)
+			svc.EXPECT().Delete("1").Return(tt.err)
+			uc := &webhookUseCase{db: testDB(t, tt.commit), webhookServiceFactory: webhookFactory(ctrl, svc)}
+
+			if err := uc.Delete(context.Background(), "1"); !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_webhookUseCase_Deliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		uc      *webhookUseCase
+		want    []*dto.WebhookDelivery
+		wantErr error
+	}{
+		{
+			name: "正常ケース",
+			uc: &webhookUseCase{
+				db: testDB(t, true),
+				webhookServiceFactory: func() *mock_service.MockWebhookFactory {
+					svc := mock_service.NewMockWebhook(ctrl)
+					svc.EXPECT().Deliveries("1", "5", 20).Return([]model.WebhookDelivery{
+						model.NewWebhookDelivery("3", "1", model.WebhookPostCreated, []byte("{}"), model.WebhookDeliveryFailed, 10, now, 500, "", now, time.Time{}),
+					}, nil)
+					return webhookFactory(ctrl, svc)
+				}(),
+			},
+			want: []*dto.WebhookDelivery{
+				{ID: "3", Event: "post.created", Payload: []byte("{}"), Status: "failed", Attempts: 10, LastStatusCode: 500, CreatedAt: now},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "異常ケース",
+			uc: &webhookUseCase{
+				db: testDB(t, false),
+				webhookServiceFactory: func() *mock_service.MockWebhookFactory {
+					svc := mock_service.NewMockWebhook(ctrl)
+					svc.EXPECT().Deliveries("1", "5", 20).Return(nil, service.ErrWebhookNotFound)
+					return webhookFactory(ctrl, svc)
+				}(),
+			},
+			want:    nil,
+			wantErr: service.ErrWebhookNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.uc.Deliveries(context.Background(), "1", "5", 20)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_webhookUseCase_DeliverDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	sendErr := errors.New("timeout")
+	webhook := model.NewWebhook("1", "2", "https://example.com/hook", "secret", []model.WebhookEvent{model.WebhookPostCreated}, now)
+	first := model.NewWebhookDelivery("10", "1", model.WebhookPostCreated, []byte("{}"), model.WebhookDeliveryPending, 0, now, 0, "", now, time.Time{})
+	second := model.NewWebhookDelivery("11", "1", model.WebhookPostCreated, []byte("{}"), model.WebhookDeliveryPending, 0, now, 0, "", now, time.Time{})
+	third := model.NewWebhookDelivery("12", "1", model.WebhookPostCreated, []byte("{}"), model.WebhookDeliveryPending, 0, now, 0, "", now, time.Time{})
+	succeeded := model.NewWebhookDelivery("10", "1", model.WebhookPostCreated, []byte("{}"), model.WebhookDeliverySucceeded, 1, now, 200, "", now, now)
+	retrying := model.NewWebhookDelivery("11", "1", model.WebhookPostCreated, []byte("{}"), model.WebhookDeliveryPending, 1, now, 0, "timeout", now, time.Time{})
+
+	tests := []struct {
+		name    string
+		uc      func() *webhookUseCase
+		want    int
+		wantErr error
+	}{
+		{
+			name: "正常ケース(記録に失敗した配信があっても他の配信を続ける)",
+			uc: func() *webhookUseCase {
+				svc := mock_service.NewMockWebhook(ctrl)
+				sender := mock_webhook.NewMockSender(ctrl)
+				gomock.InOrder(
+					svc.EXPECT().ClaimDue(now, webhookBatchSize).Return([]model.WebhookDelivery{first, second, third}, nil),
+					svc.EXPECT().Find("1").Return(webhook, nil),
+					sender.EXPECT().Send(webhook, first).Return(200, nil),
+					svc.EXPECT().RecordAttempt(first, 200, nil, now).Return(succeeded, nil),
+					sender.EXPECT().Send(webhook, second).Return(0, sendErr),
+					svc.EXPECT().RecordAttempt(second, 0, sendErr, now).Return(retrying, nil),
+					sender.EXPECT().Send(webhook, third).Return(200, nil),
+					svc.EXPECT().RecordAttempt(third, 200, nil, now).Return(nil, errTest),
+				)
+				return &webhookUseCase{
+					db:                    testDBTxs(t, true, true, true, false),
+					webhookServiceFactory: webhookFactory(ctrl, svc),
+					sender:                sender,
+				}
+			},
+			want:    1,
+			wantErr: nil,
+		},
+		{
+			name: "正常ケース(送信待ちなし)",
+			uc: func() *webhookUseCase {
+				svc := mock_service.NewMockWebhook(ctrl)
+				svc.EXPECT().ClaimDue(now, webhookBatchSize).Return([]model.WebhookDelivery{}, nil)
+				return &webhookUseCase{
+					db:                    testDBTxs(t, true),
+					webhookServiceFactory: webhookFactory(ctrl, svc),
+					sender:                mock_webhook.NewMockSender(ctrl),
+				}
+			},
+			want:    0,
+			wantErr: nil,
+		},
+		{
+			name: "異常ケース(取得失敗)",
+			uc: func() *webhookUseCase {
+				svc := mock_service.NewMockWebhook(ctrl)
+				svc.EXPECT().ClaimDue(now, webhookBatchSize).Return(nil, errTest)
+				return &webhookUseCase{
+					db:                    testDBTxs(t, false),
+					webhookServiceFactory: webhookFactory(ctrl, svc),
+					sender:                mock_webhook.NewMockSender(ctrl),
+				}
+			},
+			want:    0,
+			wantErr: errTest,
+		},
+		{
+			name: "異常ケース(Webhookの取得失敗)",
+			uc: func() *webhookUseCase {
+				svc := mock_service.NewMockWebhook(ctrl)
+				svc.EXPECT().ClaimDue(now, webhookBatchSize).Return([]model.WebhookDelivery{first}, nil)
+				svc.EXPECT().Find("1").Return(nil, errTest)
+				return &webhookUseCase{
+					db:                    testDBTxs(t, false),
+					webhookServiceFactory: webhookFactory(ctrl, svc),
+					sender:                mock_webhook.NewMockSender(ctrl),
+				}
+			},
+			want:    0,
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.uc().DeliverDue(context.Background(), now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("戻り値不一致 got: %#v want: %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_webhookUseCase_ForwardEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	payload := []byte(`{"board_id":"2","thread_id":"3","post_id":"4","user_id":"5"}`)
+
+	tests := []struct {
+		name    string
+		event   model.Event
+		uc      func() *webhookUseCase
+		wantErr bool
+	}{
+		{
+			name:  "正常ケース",
+			event: model.NewEvent("1", model.EventPostCreated, "4", payload, 0, now, "", now, time.Time{}),
+			uc: func() *webhookUseCase {
+				svc := mock_service.NewMockWebhook(ctrl)
+				svc.EXPECT().Enqueue("1", "2", model.WebhookPostCreated, json.RawMessage(payload), now).Return(1, nil)
+				return &webhookUseCase{db: testDBTxs(t, true), webhookServiceFactory: webhookFactory(ctrl, svc)}
+			},
+			wantErr: false,
+		},
+		{
+			name:  "正常ケース(板のイベント以外は転送しない)",
+			event: model.NewEvent("1", model.EventUserRegistered, "5", []byte(`{"user_id":"5"}`), 0, now, "", now, time.Time{}),
+			uc: func() *webhookUseCase {
+				return &webhookUseCase{}
+			},
+			wantErr: false,
+		},
+		{
+			name:  "異常ケース(ペイロードが不正)",
+			event: model.NewEvent("1", model.EventPostHidden, "4", []byte("{"), 0, now, "", now, time.Time{}),
+			uc: func() *webhookUseCase {
+				return &webhookUseCase{}
+			},
+			wantErr: true,
+		},
+		{
+			name:  "異常ケース(登録失敗)",
+			event: model.NewEvent("1", model.EventThreadCreated, "3", payload, 0, now, "", now, time.Time{}),
+			uc: func() *webhookUseCase {
+				svc := mock_service.NewMockWebhook(ctrl)
+				svc.EXPECT().Enqueue("1", "2", model.WebhookThreadCreated, gomock.Any(), now).Return(0, errTest)
+				return &webhookUseCase{db: testDBTxs(t, false), webhookServiceFactory: webhookFactory(ctrl, svc)}
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.uc().ForwardEvent(context.Background(), tt.event); (err != nil) != tt.wantErr {
+				t.Errorf("webhookUseCase.ForwardEvent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}